@@ -24,6 +24,7 @@ package cloudprober
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -33,6 +34,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudprober/cloudprober/config"
 	configpb "github.com/cloudprober/cloudprober/config/proto"
@@ -44,10 +46,12 @@ import (
 	"github.com/cloudprober/cloudprober/prober"
 	"github.com/cloudprober/cloudprober/probes"
 	"github.com/cloudprober/cloudprober/surfacers"
+	"github.com/cloudprober/cloudprober/web/webauth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -70,7 +74,12 @@ var cloudProber struct {
 	defaultGRPCLn   net.Listener
 	configSource    config.ConfigSource
 	config          *configpb.ProberConfig
-	cancelInitCtx   context.CancelFunc
+	// Raw and parsed versions of the running config. Config source has the
+	// last loaded config, which may have been rejected by Reload.
+	rawConfig     string
+	parsedConfig  string
+	webAuth       *webauth.Authenticator
+	cancelInitCtx context.CancelFunc
 	sync.RWMutex
 }
 
@@ -170,6 +179,14 @@ func InitWithConfigSource(configSrc config.ConfigSource) error {
 
 	globalLogger := logger.NewWithAttrs(slog.String("component", "global"))
 
+	var webAuth *webauth.Authenticator
+	if cfg.GetWebAuth() != nil {
+		webAuth, err = webauth.New(cfg.GetWebAuth(), logger.NewWithAttrs(slog.String("component", "webauth")))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Start default HTTP server. It's used for profile handlers and
 	// prometheus exporter.
 	ln, err := initDefaultServer(cfg, globalLogger)
@@ -199,6 +216,12 @@ func InitWithConfigSource(configSrc config.ConfigSource) error {
 				return err
 			}
 			tlsConfig.ClientCAs = tlsConfig.RootCAs
+			// Verify client certs if they are provided. Whether a client
+			// cert is required is decided by the individual services, e.g.
+			// RDS server's auth config.
+			if tlsConfig.ClientCAs != nil {
+				tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			}
 			serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 		}
 
@@ -227,6 +250,9 @@ func InitWithConfigSource(configSrc config.ConfigSource) error {
 	cloudProber.prober = pr
 	cloudProber.config = cfg
 	cloudProber.configSource = configSrc
+	cloudProber.rawConfig = configSrc.RawConfig()
+	cloudProber.parsedConfig = configSrc.ParsedConfig()
+	cloudProber.webAuth = webAuth
 	cloudProber.defaultServerLn = ln
 	cloudProber.defaultGRPCLn = grpcLn
 	cloudProber.cancelInitCtx = cancelFunc
@@ -241,7 +267,11 @@ func Start(ctx context.Context) {
 
 	// Default servers
 	srvMux := runconfig.DefaultHTTPServeMux()
-	httpSrv := &http.Server{Handler: srvMux}
+	var handler http.Handler = srvMux
+	if cloudProber.webAuth != nil {
+		handler = cloudProber.webAuth.Handler(srvMux)
+	}
+	httpSrv := &http.Server{Handler: handler}
 	grpcSrv := runconfig.DefaultGRPCServer()
 
 	// Set up a goroutine to cleanup if context ends.
@@ -258,6 +288,8 @@ func Start(ctx context.Context) {
 		cloudProber.defaultGRPCLn = nil
 		cloudProber.config = nil
 		cloudProber.configSource = nil
+		cloudProber.rawConfig, cloudProber.parsedConfig = "", ""
+		cloudProber.webAuth = nil
 		cloudProber.prober = nil
 	}()
 
@@ -274,6 +306,65 @@ func Start(ctx context.Context) {
 	srvMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "OK")
 	})
+	srvMux.HandleFunc("/-/reload", reloadHandler)
+}
+
+// Reload re-reads the config from the config source and applies the changes
+// to the running prober. See prober.Reload for what changes can be applied
+// without a restart. It's a no-op if config hasn't changed.
+func Reload() error {
+	cloudProber.Lock()
+	defer cloudProber.Unlock()
+
+	if cloudProber.prober == nil {
+		return errors.New("cloudprober is not initialized")
+	}
+
+	cfg, err := cloudProber.configSource.GetConfig()
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(cfg, cloudProber.config) {
+		if err := cloudProber.prober.Reload(cfg); err != nil {
+			return err
+		}
+	}
+
+	// Config is committed only after it's been applied successfully.
+	cloudProber.config = cfg
+	cloudProber.rawConfig = cloudProber.configSource.RawConfig()
+	cloudProber.parsedConfig = cloudProber.configSource.ParsedConfig()
+	return nil
+}
+
+func reloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST method is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := Reload(); err != nil {
+		http.Error(w, fmt.Sprintf("error reloading config: %v", err), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "OK")
+}
+
+// WatchConfig reloads the config at the given interval, until context is
+// canceled. Config is applied only if it has changed.
+func WatchConfig(ctx context.Context, interval time.Duration, l *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := Reload(); err != nil {
+			l.Errorf("Error reloading config: %v", err)
+		}
+	}
 }
 
 // GetConfig returns the prober config.
@@ -287,14 +378,22 @@ func GetConfig() *configpb.ProberConfig {
 func GetRawConfig() string {
 	cloudProber.RLock()
 	defer cloudProber.RUnlock()
-	return cloudProber.configSource.RawConfig()
+	return cloudProber.rawConfig
 }
 
 // GetParsedConfig returns the parsed prober config.
 func GetParsedConfig() string {
 	cloudProber.RLock()
 	defer cloudProber.RUnlock()
-	return cloudProber.configSource.ParsedConfig()
+	return cloudProber.parsedConfig
+}
+
+// GetRedactedConfig returns the running config, after templating, includes,
+// and environment variables substitution, with the secrets redacted.
+func GetRedactedConfig() *configpb.ProberConfig {
+	cloudProber.RLock()
+	defer cloudProber.RUnlock()
+	return config.RedactedConfig(cloudProber.config, cloudProber.parsedConfig)
 }
 
 // GetInfo returns information on all the probes, servers and surfacers.
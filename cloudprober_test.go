@@ -16,8 +16,12 @@ package cloudprober
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -220,6 +224,83 @@ func TestRestart(t *testing.T) {
 	}
 }
 
+func TestReload(t *testing.T) {
+	ports := freePortsT(t, 3)
+	cfgTmpl := `
+port: %d
+grpc_port: %d
+surfacer {}
+probe {
+  name: "%s"
+  type: TCP
+  targets { host_names: "localhost" }
+  tcp_probe { port: %d }
+  interval_msec: %d
+}
+`
+	probeCfg := func(name string, interval int) string {
+		return fmt.Sprintf(`probe { name: "%s" type: TCP targets { host_names: "localhost" } tcp_probe { port: %d } interval_msec: %d }`, name, ports[1], interval)
+	}
+
+	f, err := os.CreateTemp("", "cloudprober_test")
+	if err != nil {
+		t.Fatalf("os.CreateTemp(): %v", err)
+	}
+	defer os.Remove(f.Name())
+	os.WriteFile(f.Name(), []byte(fmt.Sprintf(cfgTmpl, ports[0], ports[2], "p1", ports[1], 2000)), 0644)
+
+	if err := InitFromConfig(f.Name()); err != nil {
+		t.Fatalf("Error initializing cloudprober: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		// Wait required for the cloudprober instance to fully shut down.
+		time.Sleep(time.Second)
+	}()
+	Start(ctx)
+
+	probeNames := func() []string {
+		var names []string
+		resp, _ := GetProber().ListProbes(context.Background(), nil)
+		for _, p := range resp.GetProbe() {
+			names = append(names, p.GetName())
+		}
+		sort.Strings(names)
+		return names
+	}
+	p1 := GetProber().Probes["p1"]
+
+	// Add a probe, p1 should be left untouched.
+	os.WriteFile(f.Name(), []byte(fmt.Sprintf(cfgTmpl, ports[0], ports[2], "p1", ports[1], 2000)+probeCfg("p2", 2000)), 0644)
+	assert.NoError(t, Reload())
+	assert.Equal(t, []string{"p1", "p2"}, probeNames())
+	assert.Same(t, p1, GetProber().Probes["p1"])
+
+	// Config error keeps the current config.
+	rawCfg, parsedCfg := GetRawConfig(), GetParsedConfig()
+	os.WriteFile(f.Name(), []byte("probe {"), 0644)
+	assert.Error(t, Reload())
+	assert.Equal(t, []string{"p1", "p2"}, probeNames())
+	assert.Equal(t, rawCfg, GetRawConfig())
+
+	// Rejected config doesn't replace the running config either.
+	os.WriteFile(f.Name(), []byte(fmt.Sprintf(cfgTmpl, ports[0]+1, ports[2], "p1", ports[1], 2000)), 0644)
+	assert.Error(t, Reload())
+	assert.Equal(t, rawCfg, GetRawConfig())
+	assert.Equal(t, parsedCfg, GetParsedConfig())
+	assert.Equal(t, ports[0], GetConfig().GetPort())
+
+	// Reload through the HTTP handler: change p1, remove p2.
+	os.WriteFile(f.Name(), []byte(fmt.Sprintf(cfgTmpl, ports[0], ports[2], "p1", ports[1], 3000)), 0644)
+	w := httptest.NewRecorder()
+	reloadHandler(w, httptest.NewRequest(http.MethodPost, "/-/reload", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"p1"}, probeNames())
+	assert.Equal(t, int32(3000), GetConfig().GetProbe()[0].GetIntervalMsec())
+	assert.NotSame(t, p1, GetProber().Probes["p1"])
+}
+
 func TestCloudproberConfig(t *testing.T) {
 	rawCfg := `probe { type: PING, name: "test_probe", targets { host_names: "localhost" }}`
 	f, err := os.CreateTemp("", "cloudprober_test")
@@ -252,6 +333,8 @@ func TestCloudproberConfig(t *testing.T) {
 			cloudProber.Lock()
 			cloudProber.configSource = configSrc
 			cloudProber.config, _ = configSrc.GetConfig()
+			cloudProber.rawConfig = configSrc.RawConfig()
+			cloudProber.parsedConfig = configSrc.ParsedConfig()
 			cloudProber.Unlock()
 
 			assert.Equal(t, tt.wantProbename, GetConfig().GetProbe()[0].GetName(), "GetConfig()")
@@ -47,8 +47,10 @@ var (
 	cpuprofile       = flag.String("cpuprof", "", "Write cpu profile to file")
 	memprofile       = flag.String("memprof", "", "Write heap profile to file")
 	configTest       = flag.Bool("configtest", false, "Dry run to test config file")
+	configDiffBase   = flag.String("config_diff_base", "", "With --configtest, print the changes in the config compared to this config: a config file or the running cloudprober's config URL, e.g. http://localhost:9313/config")
 	dumpConfig       = flag.Bool("dumpconfig", false, "Dump processed config to stdout")
 	dumpConfigFormat = flag.String("dumpconfig_fmt", "textpb", "Dump config format (textpb, json, yaml)")
+	configReloadInt  = flag.Duration("config_reload_interval", 0, "If set, check config for changes at this interval and apply them. Config is also reloaded on SIGHUP")
 )
 
 // These variables get overwritten by using -ldflags="-X main.<var>=<value?" at
@@ -140,6 +142,14 @@ func main() {
 	}
 
 	if *configTest {
+		if *configDiffBase != "" {
+			diff, err := config.ConfigDiffWithBase(nil, *configDiffBase)
+			if err != nil {
+				l.Criticalf("Config test failed. Err: %v", err)
+			}
+			fmt.Println(diff)
+			return
+		}
 		if err := config.ConfigTest(nil); err != nil {
 			l.Criticalf("Config test failed. Err: %v", err)
 		}
@@ -180,6 +190,22 @@ func main() {
 	}
 	cloudprober.Start(startCtx)
 
+	// Reload config on SIGHUP.
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	go func() {
+		for range hupCh {
+			l.Info("Received SIGHUP, reloading config")
+			if err := cloudprober.Reload(); err != nil {
+				l.Errorf("Error reloading config: %v", err)
+			}
+		}
+	}()
+
+	if *configReloadInt > 0 {
+		go cloudprober.WatchConfig(startCtx, *configReloadInt, l)
+	}
+
 	// Wait forever
 	select {}
 }
@@ -17,13 +17,17 @@ package config
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 
 	configpb "github.com/cloudprober/cloudprober/config/proto"
@@ -35,8 +39,9 @@ import (
 )
 
 var (
-	configFile       = flag.String("config_file", "", "Config file")
-	testInstanceName = flag.String("test_instance_name", "ig-us-central1-a-01-0000", "Instance name example to be used in tests")
+	configFile         = flag.String("config_file", "", "Config file. Besides the local files, it can be a GCS (gs://), S3 (s3://) or HTTP(S) URL, or a Kubernetes ConfigMap key (k8s://<namespace>/<configmap>/<key>)")
+	configChecksumFile = flag.String("config_checksum_file", "", "File or URL with the SHA-256 checksum of the config file, e.g. sha256sum output. If set, config file is rejected if it doesn't match the checksum")
+	testInstanceName   = flag.String("test_instance_name", "ig-us-central1-a-01-0000", "Instance name example to be used in tests")
 )
 
 // EnvRegex is the regex used to find environment variable placeholders
@@ -60,7 +65,10 @@ var configTestVars = map[string]string{
 }
 
 func DefaultConfigSource() ConfigSource {
-	return ConfigSourceWithFile(*configFile)
+	return &defaultConfigSource{
+		FileName:     *configFile,
+		ChecksumFile: *configChecksumFile,
+	}
 }
 
 func ConfigSourceWithFile(fileName string) ConfigSource {
@@ -70,19 +78,93 @@ func ConfigSourceWithFile(fileName string) ConfigSource {
 }
 
 func formatFromFileName(fileName string) string {
+	// Remove the query from the URLs, e.g. https://host/cloudprober.yaml?v=1.
+	if strings.Contains(fileName, "://") {
+		fileName, _, _ = strings.Cut(fileName, "?")
+	}
 	switch filepath.Ext(fileName) {
 	case ".json":
 		return "json"
 	case ".yaml", ".yml":
 		return "yaml"
+	case ".jsonnet":
+		return "jsonnet"
 	default:
 		return "textpb"
 	}
 }
 
+// includedFiles returns the files for an include statement's path, which can
+// be a file, a glob pattern (e.g. "probes/*.cfg"), or a directory. For
+// directories, files with the same extension as the including file are
+// included. Files are sorted by name, so that the config is assembled in a
+// deterministic order.
+func includedFiles(baseDir, path, ext string) ([]string, error) {
+	path = joinPath(baseDir, path)
+	// Globs and directories are supported only for the local files.
+	if strings.Contains(path, "://") {
+		return []string{path}, nil
+	}
+
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %s: %v", path, err)
+		}
+		var files []string
+		for _, m := range matches {
+			if fi, err := os.Stat(m); err == nil && fi.Mode().IsRegular() {
+				files = append(files, m)
+			}
+		}
+		sort.Strings(files)
+		return files, nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil || !fi.IsDir() {
+		// Let the file read report the errors.
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") && filepath.Ext(e.Name()) == ext {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// configDir returns the directory of the config file. For remote config files,
+// e.g. gs://bucket/dir/cloudprober.cfg, it's the URL's directory.
+func configDir(fileName string) string {
+	if scheme, rest, ok := strings.Cut(fileName, "://"); ok {
+		rest, _, _ = strings.Cut(rest, "?")
+		return scheme + "://" + path.Dir(rest)
+	}
+	return filepath.Dir(fileName)
+}
+
+// joinPath joins a relative include path to the including file's directory.
+func joinPath(baseDir, p string) string {
+	if filepath.IsAbs(p) || strings.Contains(p, "://") {
+		return p
+	}
+	if scheme, rest, ok := strings.Cut(baseDir, "://"); ok {
+		return scheme + "://" + path.Join(rest, p)
+	}
+	return filepath.Join(baseDir, p)
+}
+
 // handleIncludes handles "include" statements in the config file. It handles
-// nested includes in a depth-first manner.
-func handleIncludes(baseDir string, content []byte) (string, error) {
+// nested includes in a depth-first manner. includeStack is the chain of files
+// including this file, used to detect include cycles.
+func handleIncludes(fileName string, content []byte, includeStack []string) (string, error) {
 	var final []string
 
 	re := regexp.MustCompile(`(?m)^include\s+"([^"]+)"\s*$`)
@@ -95,11 +177,18 @@ func handleIncludes(baseDir string, content []byte) (string, error) {
 			final = append(final, line)
 			continue
 		}
-		includedCfg, err := readConfigFile(filepath.Join(baseDir, m[1]))
+
+		files, err := includedFiles(configDir(fileName), m[1], filepath.Ext(fileName))
 		if err != nil {
 			return "", err
 		}
-		final = append(final, includedCfg)
+		for _, f := range files {
+			includedCfg, err := readConfigFileWithIncludes(f, "", includeStack)
+			if err != nil {
+				return "", err
+			}
+			final = append(final, includedCfg)
+		}
 	}
 
 	newline := "\n"
@@ -109,14 +198,53 @@ func handleIncludes(baseDir string, content []byte) (string, error) {
 	return strings.Join(final, newline), nil
 }
 
-func readConfigFile(fileName string) (string, error) {
+func readConfigFileWithIncludes(fileName, checksumFile string, includeStack []string) (string, error) {
+	for _, f := range includeStack {
+		if filepath.Clean(f) == filepath.Clean(fileName) {
+			return "", fmt.Errorf("include cycle: %s -> %s", strings.Join(includeStack, " -> "), fileName)
+		}
+	}
+
 	b, err := file.ReadFile(fileName)
 	if err != nil {
 		return "", err
 	}
 
-	final, err := handleIncludes(filepath.Dir(fileName), b)
-	return final, err
+	// Checksum file applies only to the top-level config file.
+	if len(includeStack) == 0 && checksumFile != "" {
+		if err := verifyChecksum(b, checksumFile); err != nil {
+			return "", fmt.Errorf("error verifying config file %s: %v", fileName, err)
+		}
+	}
+
+	return handleIncludes(fileName, b, append(includeStack[:len(includeStack):len(includeStack)], fileName))
+}
+
+// verifyChecksum verifies the content against the SHA-256 checksum in the
+// checksum file. Checksum is the first field of the checksum file, so that
+// sha256sum output can be used as is.
+func verifyChecksum(content []byte, checksumFile string) error {
+	b, err := file.ReadFile(checksumFile)
+	if err != nil {
+		return fmt.Errorf("error reading checksum file: %v", err)
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return fmt.Errorf("checksum file %s is empty", checksumFile)
+	}
+	want := strings.ToLower(strings.TrimPrefix(fields[0], "sha256:"))
+
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
+
+// readConfigFile reads the config file, along with the included files. If
+// checksumFile is set, config file is verified against it.
+func readConfigFile(fileName, checksumFile string) (string, error) {
+	return readConfigFileWithIncludes(fileName, checksumFile, nil)
 }
 
 func unmarshalConfig(configStr, configFormat string) (*configpb.ProberConfig, error) {
@@ -128,6 +256,11 @@ func unmarshalConfig(configStr, configFormat string) (*configpb.ProberConfig, er
 			return nil, fmt.Errorf("error converting YAML config to JSON: %v", err)
 		}
 		if err := protojson.Unmarshal(jsonCfg, cfg); err != nil {
+			// Try to find the error in the YAML config, to report it with the
+			// line number.
+			if yamlErr := validateYAML(configStr, cfg.ProtoReflect().Descriptor()); yamlErr != nil {
+				return nil, fmt.Errorf("invalid YAML config:\n%v", yamlErr)
+			}
 			return nil, fmt.Errorf("error unmarshaling intermediate JSON to proto: %v", err)
 		}
 	case "json":
@@ -140,9 +273,43 @@ func unmarshalConfig(configStr, configFormat string) (*configpb.ProberConfig, er
 		}
 	}
 
+	if err := checkDuplicateNames(cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
+// checkDuplicateNames verifies that probes, shared targets and (named)
+// surfacers have unique names. Duplicates are easy to introduce when config
+// is split across multiple files.
+func checkDuplicateNames(cfg *configpb.ProberConfig) error {
+	var errs []error
+	check := func(kind string, names []string) {
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if name != "" && seen[name] {
+				errs = append(errs, fmt.Errorf("duplicate %s name: %s", kind, name))
+			}
+			seen[name] = true
+		}
+	}
+
+	var probeNames, targetsNames, surfacerNames []string
+	for _, p := range cfg.GetProbe() {
+		probeNames = append(probeNames, p.GetName())
+	}
+	for _, st := range cfg.GetSharedTargets() {
+		targetsNames = append(targetsNames, st.GetName())
+	}
+	for _, s := range cfg.GetSurfacer() {
+		surfacerNames = append(surfacerNames, s.GetName())
+	}
+	check("probe", probeNames)
+	check("shared_targets", targetsNames)
+	check("surfacer", surfacerNames)
+	return errors.Join(errs...)
+}
+
 // substEnvVars substitutes environment variables in the config string.
 func substEnvVars(configStr string, l *logger.Logger) string {
 	m := EnvRegex.FindAllStringSubmatch(configStr, -1)
@@ -177,29 +344,43 @@ func ConfigTest(cs ConfigSource) error {
 		if *configFile == "" {
 			return errors.New("config_file is required for testing")
 		}
-		cs = &defaultConfigSource{
-			FileName: *configFile,
-			BaseVars: configTestVars,
-			GetGCECustomMetadata: func(v string) (string, error) {
-				return v + "-test-value", nil
-			},
-		}
+		cs = testConfigSource(*configFile)
 	}
 	_, err := cs.GetConfig()
 	return err
 }
 
+// testConfigSource returns the config source used for testing the config:
+// it uses the test values for the system variables and the GCE metadata, and
+// doesn't resolve the secrets.
+func testConfigSource(fileName string) *defaultConfigSource {
+	return &defaultConfigSource{
+		FileName:     fileName,
+		ChecksumFile: *configChecksumFile,
+		BaseVars:     configTestVars,
+		GetGCECustomMetadata: func(v string) (string, error) {
+			return v + "-test-value", nil
+		},
+		skipSecrets: true,
+	}
+}
+
 func DumpConfig(outFormat string, cs ConfigSource) ([]byte, error) {
 	if cs == nil {
 		cs = &defaultConfigSource{
-			BaseVars: configTestVars,
+			BaseVars:    configTestVars,
+			skipSecrets: true,
 		}
 	}
 	cfg, err := cs.GetConfig()
 	if err != nil {
 		return nil, err
 	}
+	return FormatConfig(cfg, outFormat)
+}
 
+// FormatConfig formats the config in the given format: yaml, json or textpb.
+func FormatConfig(cfg *configpb.ProberConfig, outFormat string) ([]byte, error) {
 	switch outFormat {
 	case "yaml":
 		jsonCfg, err := protojson.Marshal(cfg)
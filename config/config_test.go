@@ -16,7 +16,11 @@ package config
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,7 +40,7 @@ import (
 func testUnmarshalConfig(t *testing.T, fileName string) (*configpb.ProberConfig, error) {
 	t.Helper()
 
-	configStr, err := readConfigFile(fileName)
+	configStr, err := readConfigFile(fileName, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -302,10 +306,17 @@ func TestReadConfigFile(t *testing.T) {
 		{
 			fileName: "testdata/cloudprober_include.nested.txtar",
 		},
+		{
+			fileName: "testdata/cloudprober_include.glob.txtar",
+		},
 		{
 			fileName: "testdata/cloudprober_include.error.txtar",
 			wantErr:  true,
 		},
+		{
+			fileName: "testdata/cloudprober_include.cycle.txtar",
+			wantErr:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(filepath.Base(tt.fileName), func(t *testing.T) {
@@ -364,7 +375,7 @@ func TestReadConfigFile(t *testing.T) {
 				return
 			}
 
-			got, err := readConfigFile(configFile)
+			got, err := readConfigFile(configFile, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("readConfigFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -379,3 +390,96 @@ func TestReadConfigFile(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDuplicateNames(t *testing.T) {
+	cfg, err := unmarshalConfig(`
+probe {
+  name: "web"
+  type: HTTP
+}
+probe {
+  name: "dns"
+  type: DNS
+}
+surfacer {
+  type: PROMETHEUS
+}
+surfacer {
+  type: FILE
+}
+`, "textpb")
+	assert.NoError(t, err)
+	assert.Len(t, cfg.GetProbe(), 2)
+
+	_, err = unmarshalConfig(`
+probe {
+  name: "web"
+  type: HTTP
+}
+probe {
+  name: "web"
+  type: DNS
+}
+surfacer {
+  name: "prom"
+  type: PROMETHEUS
+}
+surfacer {
+  name: "prom"
+  type: FILE
+}
+`, "textpb")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "duplicate probe name: web")
+		assert.Contains(t, err.Error(), "duplicate surfacer name: prom")
+	}
+}
+
+func TestRemoteConfigFile(t *testing.T) {
+	files := map[string]string{
+		"/configs/cloudprober.cfg": "include \"probes/web.cfg\"\n",
+		"/configs/probes/web.cfg":  "probe {\n  name: \"web\"\n  type: HTTP\n}",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer ts.Close()
+
+	sum := sha256.Sum256([]byte(files["/configs/cloudprober.cfg"]))
+	checksumFile := filepath.Join(t.TempDir(), "cloudprober.cfg.sha256")
+	if err := os.WriteFile(checksumFile, []byte(hex.EncodeToString(sum[:])+"  cloudprober.cfg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	badChecksumFile := filepath.Join(t.TempDir(), "bad.sha256")
+	if err := os.WriteFile(badChecksumFile, []byte(strings.Repeat("0", 64)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configURL := ts.URL + "/configs/cloudprober.cfg"
+	got, err := readConfigFile(configURL, checksumFile)
+	assert.NoError(t, err)
+	assert.Equal(t, files["/configs/probes/web.cfg"], got)
+
+	_, err = readConfigFile(configURL, badChecksumFile)
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	_, err = readConfigFile(ts.URL+"/configs/missing.cfg", "")
+	assert.Error(t, err)
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	for fileName, want := range map[string]string{
+		"/etc/cloudprober.cfg":                            "textpb",
+		"/etc/cloudprober.yaml":                           "yaml",
+		"gs://bucket/cloudprober.json":                    "json",
+		"https://example.com/cloudprober.yml?token=abc.d": "yaml",
+		"k8s://monitoring/cloudprober/cloudprober.yaml":   "yaml",
+	} {
+		assert.Equal(t, want, formatFromFileName(fileName), fileName)
+	}
+}
@@ -114,19 +114,73 @@ Cloudprober configs support some macros to make configs construction easier:
 
 		{{end}}
 		{{end}}
+
+	readFile, readJSON, readYAML, readCSV
+		Read a data file: as a string, as JSON or YAML data, or as a list of CSV
+		records. CSV records are maps keyed by the column names from the first
+		line. Relative paths are relative to the config file's directory, and
+		remote files (e.g. gs://, s3://, https://) are supported too. Together
+		with 'range', these can be used to generate probes from an inventory:
+
+		# inventory.csv:
+		# name,url,team
+		# checkout,https://checkout.example.com/health,payments
+		# search,https://search.example.com/health,discovery
+		{{range $svc := readCSV "inventory.csv"}}
+		probe {
+		  name: "{{$svc.name}}"
+		  type: HTTP
+		  targets {
+		    endpoint {
+		      name: "{{$svc.name}}"
+		      url: "{{$svc.url}}"
+		    }
+		  }
+		  additional_label {
+		    key: "team"
+		    value: "{{$svc.team}}"
+		  }
+		}
+		{{end}}
+
+	groupBy
+		Group a list of records (maps) by the value of a key. Returns a map from
+		the key's values to the records, which 'range' iterates in the key order.
+		Example, one probe per team:
+
+		{{range $team, $svcs := readCSV "inventory.csv" | groupBy "team"}}
+		probe {
+		  name: "health-{{$team}}"
+		  type: HTTP
+		  targets {
+		    {{- range $svcs}}
+		    endpoint {
+		      name: "{{.name}}"
+		      url: "{{.url}}"
+		    }
+		    {{- end}}
+		  }
+		}
+		{{end}}
 */
 package config
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"text/template"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/Masterminds/sprig/v3"
 	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/cloudprober/cloudprober/internal/file"
 	"google.golang.org/protobuf/encoding/prototext"
+	"sigs.k8s.io/yaml"
 )
 
 // readFromGCEMetadata returns the value of GCE custom metadata variables. To
@@ -154,8 +208,113 @@ func DefaultConfig() string {
 	return string(b)
 }
 
-// parseTemplate processes a config file as a Go text template.
-func parseTemplate(config string, sysVars map[string]string, getGCECustomMetadata func(string) (string, error)) (string, error) {
+// templateDataFuncs returns the template functions to read data files. Relative
+// paths are relative to baseDir.
+func templateDataFuncs(baseDir string) map[string]interface{} {
+	readFile := func(fileName string) ([]byte, error) {
+		return file.ReadFile(joinPath(baseDir, fileName))
+	}
+
+	// Numbers are decoded as json.Number, so that they are printed as is,
+	// e.g. 1000000 instead of 1e+06.
+	decodeJSON := func(b []byte) (interface{}, error) {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		var v interface{}
+		err := dec.Decode(&v)
+		return v, err
+	}
+
+	return map[string]interface{}{
+		"readFile": func(fileName string) (string, error) {
+			b, err := readFile(fileName)
+			return string(b), err
+		},
+		"readJSON": func(fileName string) (interface{}, error) {
+			b, err := readFile(fileName)
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing JSON file %s: %v", fileName, err)
+			}
+			return v, nil
+		},
+		"readYAML": func(fileName string) (interface{}, error) {
+			b, err := readFile(fileName)
+			if err != nil {
+				return nil, err
+			}
+			jsonB, err := yaml.YAMLToJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing YAML file %s: %v", fileName, err)
+			}
+			return decodeJSON(jsonB)
+		},
+		"readCSV": func(fileName string) ([]interface{}, error) {
+			b, err := readFile(fileName)
+			if err != nil {
+				return nil, err
+			}
+			return parseCSV(b)
+		},
+		"groupBy": groupBy,
+	}
+}
+
+// parseCSV parses CSV data into records keyed by the column names from the
+// header line.
+func parseCSV(b []byte) ([]interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CSV: %v", err)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	header := lines[0]
+	var records []interface{}
+	for _, line := range lines[1:] {
+		record := make(map[string]interface{}, len(header))
+		for i, col := range header {
+			record[strings.TrimSpace(col)] = line[i]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// groupBy groups a list of maps by the value of the given key. Records
+// without the key are grouped under the empty string.
+func groupBy(key string, list interface{}) (map[string][]interface{}, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("groupBy: expected a list, got %T", list)
+	}
+
+	groups := make(map[string][]interface{})
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(reflect.ValueOf(v.Index(i).Interface()))
+		if item.Kind() != reflect.Map || item.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("groupBy: expected list of maps, got an item of type %s", item.Type())
+		}
+		groupKey := ""
+		if kv := item.MapIndex(reflect.ValueOf(key).Convert(item.Type().Key())); kv.IsValid() {
+			groupKey = fmt.Sprint(kv.Interface())
+		}
+		groups[groupKey] = append(groups[groupKey], item.Interface())
+	}
+	return groups, nil
+}
+
+// parseTemplate processes a config file as a Go text template. Relative data
+// file paths in the template functions are relative to baseDir.
+func parseTemplate(config string, sysVars map[string]string, getGCECustomMetadata func(string) (string, error), baseDir string) (string, error) {
 	if getGCECustomMetadata == nil {
 		getGCECustomMetadata = readFromGCEMetadata
 	}
@@ -196,6 +355,9 @@ func parseTemplate(config string, sysVars map[string]string, getGCECustomMetadat
 	}
 	funcMap["mkSlice"] = funcMap["list"]
 	funcMap["mkMap"] = funcMap["dict"]
+	for name, f := range templateDataFuncs(baseDir) {
+		funcMap[name] = f
+	}
 
 	configTmpl, err := template.New("cloudprober_cfg").Funcs(funcMap).Parse(config)
 	if err != nil {
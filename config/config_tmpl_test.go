@@ -16,6 +16,8 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"cloud.google.com/go/compute/metadata"
@@ -25,7 +27,7 @@ import (
 )
 
 func testParse(config string, sysVars map[string]string) (*configpb.ProberConfig, error) {
-	textConfig, err := parseTemplate(config, sysVars, nil)
+	textConfig, err := parseTemplate(config, sysVars, nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +171,7 @@ probe {
 			return "", metadata.NotDefinedError("not defined")
 		}
 		return "", fmt.Errorf("not-implemented")
-	})
+	}, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -181,3 +183,71 @@ probe {
 	assert.Len(t, cfg.GetProbe(), 1, "number of probes")
 	assert.Equal(t, "google_dot_com_from-undefined", cfg.GetProbe()[0].GetName(), "probe name")
 }
+
+func TestParseTemplateDataFuncs(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"inventory.csv": "# Services\nname, url, team\ncheckout,https://checkout.example.com/health,payments\nsearch,https://search.example.com/health,discovery\nrefunds,https://refunds.example.com/health,payments\n",
+		"ports.json":    `{"dns": {"port": 53, "interval_msec": 1000000}}`,
+		"hosts.yaml":    "hosts:\n  - a.example.com\n  - b.example.com\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := `
+{{range $team, $svcs := readCSV "inventory.csv" | groupBy "team"}}
+probe {
+  name: "health-{{$team}}"
+  type: HTTP
+  targets {
+    {{- range $svcs}}
+    endpoint {
+      name: "{{.name}}"
+      url: "{{.url}}"
+    }
+    {{- end}}
+  }
+}
+{{end}}
+{{with $dns := (readJSON "ports.json").dns}}
+probe {
+  name: "dns"
+  type: DNS
+  interval_msec: {{$dns.interval_msec}}
+  targets {
+    host_names: "{{range $i, $h := (readYAML "hosts.yaml").hosts}}{{if $i}},{{end}}{{$h}}:{{$dns.port}}{{end}}"
+  }
+}
+{{end}}
+`
+	textConfig, err := parseTemplate(config, nil, nil, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &configpb.ProberConfig{}
+	if err := prototext.Unmarshal([]byte(textConfig), cfg); err != nil {
+		t.Fatalf("Error unmarshaling config: %v, config:\n%s", err, textConfig)
+	}
+
+	probes := cfg.GetProbe()
+	if !assert.Len(t, probes, 3) {
+		return
+	}
+	assert.Equal(t, "health-discovery", probes[0].GetName())
+	assert.Len(t, probes[0].GetTargets().GetEndpoint(), 1)
+	assert.Equal(t, "health-payments", probes[1].GetName())
+	assert.Equal(t, "https://refunds.example.com/health", probes[1].GetTargets().GetEndpoint()[1].GetUrl())
+	assert.Equal(t, int32(1000000), probes[2].GetIntervalMsec())
+	assert.Equal(t, "a.example.com:53,b.example.com:53", probes[2].GetTargets().GetHostNames())
+
+	for _, badConfig := range []string{
+		`{{readCSV "missing.csv"}}`,
+		`{{readJSON "inventory.csv"}}`,
+		`{{"abc" | groupBy "team"}}`,
+	} {
+		_, err := parseTemplate(badConfig, nil, nil, dir)
+		assert.Error(t, err, badConfig)
+	}
+}
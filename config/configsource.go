@@ -38,10 +38,15 @@ type ConfigSource interface {
 
 type defaultConfigSource struct {
 	FileName             string
+	ChecksumFile         string
 	BaseVars             map[string]string
 	GetGCECustomMetadata func(string) (string, error)
 	l                    *logger.Logger
 
+	// If set, secret references are not resolved, e.g. when testing or
+	// dumping the config.
+	skipSecrets bool
+
 	parsedConfig string
 	rawConfig    string
 	cfg          *configpb.ProberConfig
@@ -49,7 +54,7 @@ type defaultConfigSource struct {
 
 func (dcs *defaultConfigSource) configContent() (content string, format string, err error) {
 	if dcs.FileName != "" {
-		content, err := readConfigFile(dcs.FileName)
+		content, err := readConfigFile(dcs.FileName, dcs.ChecksumFile)
 		return content, formatFromFileName(dcs.FileName), err
 	}
 
@@ -90,18 +95,43 @@ func (dcs *defaultConfigSource) GetConfig() (*configpb.ProberConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	dcs.rawConfig = configStr
 
-	dcs.parsedConfig, err = parseTemplate(dcs.rawConfig, dcs.BaseVars, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing config file as Go template. Err: %v", err)
+	// Config is loaded into the local variables first, so that a bad config
+	// doesn't replace the current config in the config source.
+	var parsedConfig string
+
+	// Jsonnet configs are evaluated to JSON, instead of the Go templating.
+	if configFormat == "jsonnet" {
+		parsedConfig, err = evaluateJsonnet(dcs.FileName, configStr, dcs.BaseVars)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating Jsonnet config. Err: %v", err)
+		}
+		configFormat = "json"
+	} else {
+		var baseDir string
+		if dcs.FileName != "" {
+			baseDir = configDir(dcs.FileName)
+		}
+		parsedConfig, err = parseTemplate(configStr, dcs.BaseVars, nil, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing config file as Go template. Err: %v", err)
+		}
 	}
 
-	dcs.cfg, err = unmarshalConfig(substEnvVars(dcs.parsedConfig, dcs.l), configFormat)
+	cfg, err := unmarshalConfig(substEnvVars(parsedConfig, dcs.l), configFormat)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling config. Err: %v", err)
+		// Use the parsed config for the context, to not show the values of
+		// the environment variables.
+		return nil, fmt.Errorf("error unmarshaling config. Err: %v", withLineContext(parsedConfig, err))
+	}
+
+	if !dcs.skipSecrets {
+		if err := resolveSecrets(cfg); err != nil {
+			return nil, fmt.Errorf("error resolving secrets in config. Err: %v", err)
+		}
 	}
 
+	dcs.rawConfig, dcs.parsedConfig, dcs.cfg = configStr, parsedConfig, cfg
 	return dcs.cfg, nil
 }
 
@@ -0,0 +1,239 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Lines around the error line shown in the config errors.
+const errorContextLines = 2
+
+// Position in the config parsing errors, e.g. "(line 12:3)".
+var errorLineRegex = regexp.MustCompile(`\(line (\d+):(\d+)\)`)
+
+// withLineContext adds the config lines around the error's line to the error,
+// as after templating and includes, line numbers don't match the config file.
+func withLineContext(configStr string, err error) error {
+	m := errorLineRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	lineNum, _ := strconv.Atoi(m[1])
+	lines := strings.Split(configStr, "\n")
+	if lineNum < 1 || lineNum > len(lines) {
+		return err
+	}
+
+	var b strings.Builder
+	start, end := max(lineNum-errorContextLines, 1), min(lineNum+errorContextLines, len(lines))
+	for i := start; i <= end; i++ {
+		marker := "  "
+		if i == lineNum {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "\n%s%4d | %s", marker, i, lines[i-1])
+	}
+	return fmt.Errorf("%v\nprocessed config around line %d:%s", err, lineNum, b.String())
+}
+
+// ConfigDiff is the semantic difference between two configs.
+type ConfigDiff struct {
+	AddedProbes, RemovedProbes, ChangedProbes          []string
+	AddedSurfacers, RemovedSurfacers, ChangedSurfacers []string
+
+	// Changed fields of the probes and surfacers, keyed by "probe/<name>" and
+	// "surfacer/<name>".
+	ChangedFields map[string][]string
+
+	// Other top-level fields that changed. Changing these requires a restart.
+	OtherChanges []string
+}
+
+// Empty tells if there are no differences.
+func (d *ConfigDiff) Empty() bool {
+	return len(d.AddedProbes)+len(d.RemovedProbes)+len(d.ChangedProbes)+
+		len(d.AddedSurfacers)+len(d.RemovedSurfacers)+len(d.ChangedSurfacers)+
+		len(d.OtherChanges) == 0
+}
+
+func (d *ConfigDiff) String() string {
+	if d.Empty() {
+		return "No changes."
+	}
+
+	var b strings.Builder
+	section := func(title, kind string, added, removed, changed []string) {
+		if len(added)+len(removed)+len(changed) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s:\n", title)
+		for _, name := range added {
+			fmt.Fprintf(&b, "  + %s\n", name)
+		}
+		for _, name := range removed {
+			fmt.Fprintf(&b, "  - %s\n", name)
+		}
+		for _, name := range changed {
+			fmt.Fprintf(&b, "  ~ %s (%s)\n", name, strings.Join(d.ChangedFields[kind+"/"+name], ", "))
+		}
+	}
+	section("Probes", "probe", d.AddedProbes, d.RemovedProbes, d.ChangedProbes)
+	section("Surfacers", "surfacer", d.AddedSurfacers, d.RemovedSurfacers, d.ChangedSurfacers)
+	if len(d.OtherChanges) != 0 {
+		fmt.Fprintf(&b, "Other changes (require a restart):\n  ~ %s\n", strings.Join(d.OtherChanges, ", "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// changedFields returns the names of the top-level fields that differ
+// between the two messages of the same type.
+func changedFields(a, b proto.Message) []string {
+	ma, mb := a.ProtoReflect(), b.ProtoReflect()
+	var changed []string
+	fields := ma.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fa, fb := ma.New(), mb.New()
+		if ma.Has(fd) {
+			fa.Set(fd, ma.Get(fd))
+		}
+		if mb.Has(fd) {
+			fb.Set(fd, mb.Get(fd))
+		}
+		if !proto.Equal(fa.Interface(), fb.Interface()) {
+			changed = append(changed, string(fd.Name()))
+		}
+	}
+	return changed
+}
+
+type namedDef struct {
+	name string
+	def  proto.Message
+}
+
+// diffDefs compares the definitions by name, and returns the added, removed
+// and changed names.
+func (d *ConfigDiff) diffDefs(kind string, oldDefs, newDefs []namedDef) (added, removed, changed []string) {
+	oldByName := make(map[string]proto.Message)
+	for _, nd := range oldDefs {
+		oldByName[nd.name] = nd.def
+	}
+	newByName := make(map[string]bool)
+	for _, nd := range newDefs {
+		newByName[nd.name] = true
+		oldDef, ok := oldByName[nd.name]
+		switch {
+		case !ok:
+			added = append(added, nd.name)
+		case !proto.Equal(oldDef, nd.def):
+			changed = append(changed, nd.name)
+			d.ChangedFields[kind+"/"+nd.name] = changedFields(oldDef, nd.def)
+		}
+	}
+	for _, nd := range oldDefs {
+		if !newByName[nd.name] {
+			removed = append(removed, nd.name)
+		}
+	}
+	return
+}
+
+func probeDefs(cfg *configpb.ProberConfig) []namedDef {
+	var defs []namedDef
+	for _, p := range cfg.GetProbe() {
+		defs = append(defs, namedDef{p.GetName(), p})
+	}
+	return defs
+}
+
+// surfacerDefs returns the surfacers keyed by their names, or by their types
+// if they are not named. Multiple unnamed surfacers of the same type are
+// numbered, e.g. "file", "file#2".
+func surfacerDefs(cfg *configpb.ProberConfig) []namedDef {
+	var defs []namedDef
+	seen := make(map[string]int)
+	for _, s := range cfg.GetSurfacer() {
+		name := s.GetName()
+		if name == "" {
+			name = strings.ToLower(s.GetType().String())
+		}
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s#%d", name, seen[name])
+		}
+		defs = append(defs, namedDef{name, s})
+	}
+	return defs
+}
+
+// DiffConfigs returns the semantic difference between the old and the new
+// configs: probes and surfacers added, removed or changed, and the other
+// changed top-level fields.
+func DiffConfigs(oldCfg, newCfg *configpb.ProberConfig) *ConfigDiff {
+	d := &ConfigDiff{ChangedFields: make(map[string][]string)}
+	d.AddedProbes, d.RemovedProbes, d.ChangedProbes = d.diffDefs("probe", probeDefs(oldCfg), probeDefs(newCfg))
+	d.AddedSurfacers, d.RemovedSurfacers, d.ChangedSurfacers = d.diffDefs("surfacer", surfacerDefs(oldCfg), surfacerDefs(newCfg))
+
+	oldCfg = proto.Clone(oldCfg).(*configpb.ProberConfig)
+	newCfg = proto.Clone(newCfg).(*configpb.ProberConfig)
+	oldCfg.Probe, newCfg.Probe = nil, nil
+	oldCfg.Surfacer, newCfg.Surfacer = nil, nil
+	d.OtherChanges = changedFields(oldCfg, newCfg)
+	return d
+}
+
+// redactedConfig loads the config from the config source, and redacts it like
+// the running config served at /config.
+func redactedConfig(cs ConfigSource) (*configpb.ProberConfig, error) {
+	cfg, err := cs.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return RedactedConfig(cfg, cs.ParsedConfig()), nil
+}
+
+// ConfigDiffWithBase validates the config, like ConfigTest, and returns its
+// difference from the base config. Base config can be a config file, or the
+// running cloudprober's config URL, e.g. http://localhost:9313/config.
+// As the running config has its secrets redacted, both configs are compared
+// after redaction.
+func ConfigDiffWithBase(cs ConfigSource, baseFile string) (*ConfigDiff, error) {
+	if cs == nil {
+		if *configFile == "" {
+			return nil, errors.New("config_file is required for testing")
+		}
+		cs = testConfigSource(*configFile)
+	}
+	newCfg, err := redactedConfig(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCS := testConfigSource(baseFile)
+	baseCS.ChecksumFile = ""
+	baseCfg, err := redactedConfig(baseCS)
+	if err != nil {
+		return nil, fmt.Errorf("error loading base config %s: %v", baseFile, err)
+	}
+	return DiffConfigs(baseCfg, newCfg), nil
+}
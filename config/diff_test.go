@@ -0,0 +1,138 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const diffBaseConfig = `
+probe {
+  name: "web"
+  type: HTTP
+  targets { host_names: "www.example.com" }
+  interval_msec: 10000
+}
+probe {
+  name: "dns"
+  type: DNS
+  targets { host_names: "1.1.1.1" }
+}
+surfacer {
+  type: PROMETHEUS
+}
+surfacer {
+  type: FILE
+}
+`
+
+const diffNewConfig = `
+probe {
+  name: "web"
+  type: HTTP
+  targets { host_names: "www.example.com" }
+  interval_msec: 5000
+  timeout_msec: 2000
+}
+probe {
+  name: "ping"
+  type: PING
+  targets { host_names: "1.1.1.1" }
+}
+surfacer {
+  type: PROMETHEUS
+}
+surfacer {
+  type: FILE
+  file_surfacer { file_path: "/tmp/metrics" }
+}
+port: 9314
+`
+
+func TestDiffConfigs(t *testing.T) {
+	oldCfg, err := unmarshalConfig(diffBaseConfig, "textpb")
+	assert.NoError(t, err)
+	newCfg, err := unmarshalConfig(diffNewConfig, "textpb")
+	assert.NoError(t, err)
+
+	d := DiffConfigs(oldCfg, newCfg)
+	assert.Equal(t, []string{"ping"}, d.AddedProbes)
+	assert.Equal(t, []string{"dns"}, d.RemovedProbes)
+	assert.Equal(t, []string{"web"}, d.ChangedProbes)
+	assert.Equal(t, []string{"interval_msec", "timeout_msec"}, d.ChangedFields["probe/web"])
+	assert.Equal(t, []string{"file"}, d.ChangedSurfacers)
+	assert.Empty(t, d.AddedSurfacers)
+	assert.Equal(t, []string{"port"}, d.OtherChanges)
+
+	want := `Probes:
+  + ping
+  - dns
+  ~ web (interval_msec, timeout_msec)
+Surfacers:
+  ~ file (file_surfacer)
+Other changes (require a restart):
+  ~ port`
+	assert.Equal(t, want, d.String())
+
+	assert.True(t, DiffConfigs(oldCfg, oldCfg).Empty())
+	assert.Equal(t, "No changes.", DiffConfigs(oldCfg, oldCfg).String())
+}
+
+func TestConfigDiffWithBase(t *testing.T) {
+	t.Setenv("TEST_DIFF_PASSWORD", "s3cret")
+
+	dir := t.TempDir()
+	files := map[string]string{
+		// Running config, as served at /config, with the secrets redacted.
+		"running.cfg": diffBaseConfig + `surfacer { type: POSTGRES postgres_surfacer { connection_string: "postgresql://user:<redacted>@db/metrics" metrics_table_name: "metrics" } }`,
+		"new.cfg":     diffNewConfig + `surfacer { type: POSTGRES postgres_surfacer { connection_string: "postgresql://user:{{envSecret "TEST_DIFF_PASSWORD"}}@db/metrics" metrics_table_name: "metrics" } }`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := ConfigDiffWithBase(testConfigSource(filepath.Join(dir, "new.cfg")), filepath.Join(dir, "running.cfg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"ping"}, d.AddedProbes)
+	// Postgres surfacer is the same, after redaction.
+	assert.Equal(t, []string{"file"}, d.ChangedSurfacers)
+
+	_, err = ConfigDiffWithBase(testConfigSource(filepath.Join(dir, "new.cfg")), filepath.Join(dir, "missing.cfg"))
+	assert.ErrorContains(t, err, "missing.cfg")
+}
+
+func TestWithLineContext(t *testing.T) {
+	configStr := "probe {\n  name: \"web\"\n  typ: HTTP\n}\nsurfacer {}"
+
+	_, err := unmarshalConfig(configStr, "textpb")
+	assert.Error(t, err)
+	err = withLineContext(configStr, err)
+	assert.Contains(t, err.Error(), "processed config around line 3:")
+	assert.Contains(t, err.Error(), ">    3 |   typ: HTTP")
+	assert.Contains(t, err.Error(), "     5 | surfacer {}")
+
+	// Errors without line numbers are returned as is.
+	err = errors.New("some error")
+	assert.Equal(t, err, withLineContext(configStr, err))
+}
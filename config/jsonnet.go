@@ -0,0 +1,88 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+)
+
+var (
+	jsonnetJPath   = flag.String("jsonnet_jpath", "", "Comma separated list of library directories to search for the Jsonnet config's imports, after the importing file's directory. As for the jsonnet command, right-most directory wins")
+	jsonnetExtVars = flag.String("jsonnet_ext_vars", "", "Comma separated list of external variables for the Jsonnet config, e.g. env=prod,region=us-east1. Variables without a value, e.g. HOME, get their value from the environment")
+)
+
+// jsonnetVM returns a Jsonnet VM set up with the import paths and the
+// external variables. System variables are available to the config as a
+// "sysvars" object external variable.
+func jsonnetVM(configDir, jpath, extVars string, sysVars map[string]string) (*jsonnet.VM, error) {
+	vm := jsonnet.MakeVM()
+
+	// Imported files' imports are looked up relative to them first. For the
+	// config file itself, which is evaluated as a snippet, config file's
+	// directory is added as the last (i.e. highest precedence) library path.
+	var jpaths []string
+	if jpath != "" {
+		jpaths = strings.Split(jpath, ",")
+	}
+	if configDir != "" {
+		jpaths = append(jpaths, configDir)
+	}
+	vm.Importer(&jsonnet.FileImporter{JPaths: jpaths})
+
+	if extVars != "" {
+		for _, v := range strings.Split(extVars, ",") {
+			key, value, ok := strings.Cut(v, "=")
+			if !ok {
+				envValue, found := os.LookupEnv(key)
+				if !found {
+					return nil, fmt.Errorf("jsonnet external variable %s has no value, and environment variable %s is not set", key, key)
+				}
+				value = envValue
+			}
+			vm.ExtVar(key, value)
+		}
+	}
+
+	b, err := json.Marshal(sysVars)
+	if err != nil {
+		return nil, err
+	}
+	vm.ExtCode("sysvars", string(b))
+	return vm, nil
+}
+
+// evaluateJsonnet evaluates the Jsonnet config, and returns the resulting JSON
+// config.
+func evaluateJsonnet(fileName, configStr string, sysVars map[string]string) (string, error) {
+	var dir string
+	if fileName != "" && !strings.Contains(fileName, "://") {
+		dir = filepath.Dir(fileName)
+	}
+	vm, err := jsonnetVM(dir, *jsonnetJPath, *jsonnetExtVars, sysVars)
+	if err != nil {
+		return "", err
+	}
+	if fileName == "" {
+		fileName = "<config>"
+	}
+	return vm.EvaluateAnonymousSnippet(fileName, configStr)
+}
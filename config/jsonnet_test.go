@@ -0,0 +1,110 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonnetConfig(t *testing.T) {
+	dir := t.TempDir()
+	libDir := filepath.Join(dir, "vendor")
+
+	files := map[string]string{
+		filepath.Join(dir, "cloudprober.jsonnet"): `
+local probes = import 'lib/probes.libsonnet';
+local common = import 'common.libsonnet';
+
+{
+  probe: [
+    probes.http(name, std.extVar('env'))
+    for name in ['checkout', 'search']
+  ] + [common.ping(std.extVar('sysvars').zone)],
+}
+`,
+		filepath.Join(dir, "lib", "probes.libsonnet"): `
+{
+  http(name, env):: {
+    name: name,
+    type: 'HTTP',
+    targets: { host_names: '%s.%s.example.com' % [name, env] },
+    interval_msec: 5000,
+  },
+}
+`,
+		// Imported from the library path.
+		filepath.Join(libDir, "common.libsonnet"): `
+{
+  ping(zone):: {
+    name: 'ping-' + zone,
+    type: 'PING',
+    targets: { host_names: '1.1.1.1' },
+  },
+}
+`,
+	}
+	files[filepath.Join(dir, "env.jsonnet")] = `{probe: [{name: std.extVar('CLOUDPROBER_TEST_ENV'), type: 'PING'}]}`
+	files[filepath.Join(dir, "bad.jsonnet")] = `{probe: [{name: 'p', typ: 'PING'}]}`
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldJPath, oldExtVars := *jsonnetJPath, *jsonnetExtVars
+	defer func() { *jsonnetJPath, *jsonnetExtVars = oldJPath, oldExtVars }()
+	*jsonnetJPath = libDir
+	*jsonnetExtVars = "env=prod"
+
+	dcs := &defaultConfigSource{
+		FileName: filepath.Join(dir, "cloudprober.jsonnet"),
+		BaseVars: map[string]string{"zone": "us-east1-b"},
+	}
+	cfg, err := dcs.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names, hosts []string
+	for _, p := range cfg.GetProbe() {
+		names = append(names, p.GetName())
+		hosts = append(hosts, p.GetTargets().GetHostNames())
+	}
+	assert.Equal(t, []string{"checkout", "search", "ping-us-east1-b"}, names)
+	assert.Equal(t, []string{"checkout.prod.example.com", "search.prod.example.com", "1.1.1.1"}, hosts)
+	assert.Contains(t, dcs.ParsedConfig(), `"interval_msec": 5000`)
+
+	// External variables without values are read from the environment.
+	t.Setenv("CLOUDPROBER_TEST_ENV", "staging")
+	*jsonnetExtVars = "CLOUDPROBER_TEST_ENV"
+	cfg, err = (&defaultConfigSource{FileName: filepath.Join(dir, "env.jsonnet"), BaseVars: map[string]string{}}).GetConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", cfg.GetProbe()[0].GetName())
+
+	// Errors: missing external variable, and an unknown config field.
+	*jsonnetExtVars = ""
+	_, err = dcs.GetConfig()
+	assert.ErrorContains(t, err, "env")
+
+	_, err = (&defaultConfigSource{FileName: filepath.Join(dir, "bad.jsonnet"), BaseVars: map[string]string{}}).GetConfig()
+	assert.ErrorContains(t, err, "typ")
+}
@@ -7,6 +7,9 @@ import (
 	proto_A "github.com/cloudprober/cloudprober/internal/rds/server/proto"
 	proto_8 "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 	proto_E "github.com/cloudprober/cloudprober/targets/proto"
+	proto_B "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	proto_F "github.com/cloudprober/cloudprober/web/webauth/proto"
+	proto_R "github.com/cloudprober/cloudprober/internal/ratelimit/proto"
 )
 
 // Cloudprober config proto defines the config schema. Cloudprober config can
@@ -63,7 +66,7 @@ import (
 	// }
 	sharedTargets?: [...#SharedTargets] @protobuf(4,SharedTargets,name=shared_targets)
 	// Common services related options.
-	// Next tag: 106
+	// Next tag: 109
 
 	// Resource discovery server
 	rdsServer?: proto_A.#ServerConf @protobuf(95,rds.ServerConf,name=rds_server)
@@ -123,6 +126,21 @@ import (
 	// Global targets options. Per-probe options are specified within the probe
 	// stanza.
 	globalTargetsOptions?: proto_E.#GlobalTargetsOptions @protobuf(100,targets.GlobalTargetsOptions,name=global_targets_options)
+
+	// Global alerting options. Alerts are configured within the probe stanza.
+	alertingOptions?: proto_B.#AlertingOptions @protobuf(106,alerting.AlertingOptions,name=alerting_options)
+
+	// Authentication for the default HTTP server: status pages, config view,
+	// metrics and the admin endpoints. If not configured, anyone who can reach
+	// the HTTP port can access everything. "/health" doesn't require
+	// authentication.
+	webAuth?: proto_F.#AuthConfig @protobuf(107,webauth.AuthConfig,name=web_auth)
+
+	// Global rate limits on the probe requests, shared by all probes, e.g. to
+	// protect internal services from a misconfigured large set of targets.
+	// See RateLimit for details. Global limits are not applied to the EXTENSION
+	// and USER_DEFINED probes, which run their own probe loops.
+	rateLimit?: [...proto_R.#RateLimit] @protobuf(108,ratelimit.RateLimit,name=rate_limit)
 }
 
 #SharedTargets: {
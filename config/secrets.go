@@ -0,0 +1,95 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudprober/cloudprober/internal/secrets"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// secretResolver resolves the secret references in the config protos.
+type secretResolver struct {
+	errs []error
+}
+
+// resolveString resolves the secret reference. Other values are returned as
+// is.
+func (sr *secretResolver) resolveString(s string) string {
+	if !secrets.IsReference(s) {
+		return s
+	}
+	v, err := secrets.Resolve(context.Background(), s)
+	if err != nil {
+		sr.errs = append(sr.errs, err)
+		return s
+	}
+	return v
+}
+
+func (sr *secretResolver) resolveValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		sr.resolveMessage(v.Message())
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(sr.resolveString(v.String()))
+	}
+	return v
+}
+
+func (sr *secretResolver) resolveMessage(m protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		switch {
+		case fd.IsMap():
+			mp := m.Mutable(fd).Map()
+			var keys []protoreflect.MapKey
+			mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			for _, k := range keys {
+				mp.Set(k, sr.resolveValue(fd.MapValue(), mp.Get(k)))
+			}
+		case fd.IsList():
+			l := m.Mutable(fd).List()
+			for i := 0; i < l.Len(); i++ {
+				l.Set(i, sr.resolveValue(fd, l.Get(i)))
+			}
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			sr.resolveMessage(m.Mutable(fd).Message())
+		default:
+			m.Set(fd, sr.resolveValue(fd, m.Get(fd)))
+		}
+	}
+}
+
+// resolveSecrets replaces the secret references (secret://<store>/<path>) in
+// the config's string fields with the secret values. Secrets are resolved
+// every time the config is loaded, so rotated secrets are picked up on the
+// config reload.
+func resolveSecrets(cfg proto.Message) error {
+	sr := &secretResolver{}
+	sr.resolveMessage(cfg.ProtoReflect())
+	return errors.Join(sr.errs...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("TEST_API_TOKEN", "token-s3cret")
+	t.Setenv("TEST_PG_PASSWORD", "pg-s3cret")
+
+	configStr := `
+probe {
+  name: "api"
+  type: HTTP
+  targets { host_names: "api.example.com" }
+  http_probe {
+    header {
+      key: "X-Client-Id"
+      value: "secret://env/TEST_API_TOKEN"
+    }
+  }
+}
+surfacer {
+  type: INFLUXDB
+  influxdb_surfacer {
+    url: "http://influxdb:8086"
+    password: "secret://env/TEST_PG_PASSWORD"
+  }
+}
+`
+	cfg, err := unmarshalConfig(configStr, "textpb")
+	if err != nil {
+		t.Fatalf("Error unmarshaling config: %v", err)
+	}
+	assert.NoError(t, resolveSecrets(cfg))
+	assert.Equal(t, "token-s3cret", cfg.GetProbe()[0].GetHttpProbe().GetHeader()["X-Client-Id"])
+	assert.Equal(t, "pg-s3cret", cfg.GetSurfacer()[0].GetInfluxdbSurfacer().GetPassword())
+
+	// Resolved values are shown as their references in the redacted config.
+	redacted := strings.Join(strings.Fields(prototext.Format(RedactedConfig(cfg, configStr))), " ")
+	assert.NotContains(t, redacted, "s3cret")
+	assert.Contains(t, redacted, `value: "secret://env/TEST_API_TOKEN"`)
+
+	// Unresolvable references are reported.
+	cfg, err = unmarshalConfig(strings.ReplaceAll(configStr, "TEST_PG_PASSWORD", "TEST_UNDEFINED_VAR"), "textpb")
+	if err != nil {
+		t.Fatalf("Error unmarshaling config: %v", err)
+	}
+	err = resolveSecrets(cfg)
+	assert.ErrorContains(t, err, "secret://env/TEST_UNDEFINED_VAR")
+}
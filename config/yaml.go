@@ -0,0 +1,223 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// YAML configs are converted to JSON before unmarshaling them into the config
+// proto, so unmarshaling errors refer to the intermediate JSON. To report
+// errors with YAML line numbers, we validate the YAML config against the
+// config schema when unmarshaling fails.
+
+// Maximum number of errors reported for a YAML config.
+const maxYAMLErrors = 10
+
+type yamlValidator struct {
+	errs []error
+}
+
+func (v *yamlValidator) errorf(n *yaml.Node, format string, args ...interface{}) {
+	if len(v.errs) < maxYAMLErrors {
+		v.errs = append(v.errs, fmt.Errorf("line %d: %s", n.Line, fmt.Sprintf(format, args...)))
+	}
+}
+
+// resolve resolves the YAML aliases.
+func resolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func lookupField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	// Like protojson, accept both JSON (camelCase) and proto field names.
+	if fd := md.Fields().ByJSONName(name); fd != nil {
+		return fd
+	}
+	return md.Fields().ByTextName(name)
+}
+
+func (v *yamlValidator) validateMessage(n *yaml.Node, md protoreflect.MessageDescriptor) {
+	n = resolve(n)
+	if isNull(n) {
+		return
+	}
+	// Well-known types have special JSON representations, leave them to
+	// protojson.
+	if strings.HasPrefix(string(md.FullName()), "google.protobuf.") {
+		return
+	}
+	if n.Kind != yaml.MappingNode {
+		v.errorf(n, "expected a mapping for %s, got %s", md.FullName(), describe(n))
+		return
+	}
+
+	setOneofs := make(map[protoreflect.FullName]string)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, val := n.Content[i], n.Content[i+1]
+
+		// Merge keys (<<: *anchor) merge the fields of another mapping.
+		if k.Tag == "!!merge" {
+			v.validateMerge(val, md)
+			continue
+		}
+
+		fd := lookupField(md, k.Value)
+		if fd == nil {
+			v.errorf(k, "unknown field %q in %s", k.Value, md.FullName())
+			continue
+		}
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && !isNull(resolve(val)) {
+			if other, ok := setOneofs[od.FullName()]; ok {
+				v.errorf(k, "field %q conflicts with %q: only one of them can be set", k.Value, other)
+			}
+			setOneofs[od.FullName()] = k.Value
+		}
+		v.validateField(val, fd)
+	}
+}
+
+func (v *yamlValidator) validateMerge(n *yaml.Node, md protoreflect.MessageDescriptor) {
+	n = resolve(n)
+	if n.Kind == yaml.SequenceNode {
+		for _, c := range n.Content {
+			v.validateMessage(c, md)
+		}
+		return
+	}
+	v.validateMessage(n, md)
+}
+
+func (v *yamlValidator) validateField(n *yaml.Node, fd protoreflect.FieldDescriptor) {
+	n = resolve(n)
+	if isNull(n) {
+		return
+	}
+
+	switch {
+	case fd.IsMap():
+		if n.Kind != yaml.MappingNode {
+			v.errorf(n, "expected a mapping for field %q, got %s", fd.Name(), describe(n))
+			return
+		}
+		for i := 1; i < len(n.Content); i += 2 {
+			v.validateValue(n.Content[i], fd.MapValue())
+		}
+	case fd.IsList():
+		if n.Kind != yaml.SequenceNode {
+			v.errorf(n, "expected a list for field %q, got %s", fd.Name(), describe(n))
+			return
+		}
+		for _, c := range n.Content {
+			v.validateValue(c, fd)
+		}
+	default:
+		v.validateValue(n, fd)
+	}
+}
+
+// validateValue validates a singular value: a message, or a scalar.
+func (v *yamlValidator) validateValue(n *yaml.Node, fd protoreflect.FieldDescriptor) {
+	n = resolve(n)
+
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		v.validateMessage(n, fd.Message())
+		return
+	}
+
+	if n.Kind != yaml.ScalarNode {
+		v.errorf(n, "expected a %s value for field %q, got %s", fd.Kind(), fd.Name(), describe(n))
+		return
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if n.Tag != "!!str" {
+			v.errorf(n, "expected a string for field %q, got %s (quote the value to use it as a string)", fd.Name(), describe(n))
+		}
+	case protoreflect.BoolKind:
+		// YAML 1.1 booleans (yes, no, on, off) are accepted too.
+		switch strings.ToLower(n.Value) {
+		case "yes", "no", "on", "off":
+			return
+		}
+		if _, err := strconv.ParseBool(n.Value); err != nil && n.Tag != "!!bool" {
+			v.errorf(n, "expected a bool for field %q, got %s", fd.Name(), describe(n))
+		}
+	case protoreflect.EnumKind:
+		if fd.Enum().Values().ByName(protoreflect.Name(n.Value)) == nil {
+			if _, err := strconv.ParseInt(n.Value, 10, 32); err != nil {
+				v.errorf(n, "invalid value %q for enum %s, valid values: %s", n.Value, fd.Enum().FullName(), enumValues(fd.Enum()))
+			}
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if _, err := strconv.ParseFloat(n.Value, 64); err != nil {
+			v.errorf(n, "expected a number for field %q, got %s", fd.Name(), describe(n))
+		}
+	default: // Integers.
+		if _, err := strconv.ParseInt(n.Value, 0, 64); err != nil {
+			if _, err := strconv.ParseUint(n.Value, 0, 64); err != nil {
+				v.errorf(n, "expected an integer for field %q, got %s", fd.Name(), describe(n))
+			}
+		}
+	}
+}
+
+func enumValues(ed protoreflect.EnumDescriptor) string {
+	var names []string
+	for i := 0; i < ed.Values().Len(); i++ {
+		names = append(names, string(ed.Values().Get(i).Name()))
+	}
+	return strings.Join(names, ", ")
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return fmt.Sprintf("%q", n.Value)
+}
+
+// validateYAML validates the YAML config against the message's schema, and
+// returns the errors found, along with their line numbers.
+func validateYAML(configStr string, md protoreflect.MessageDescriptor) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(configStr), &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	v := &yamlValidator{}
+	v.validateMessage(doc.Content[0], md)
+	return errors.Join(v.errs...)
+}
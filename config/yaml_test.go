@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYAML(t *testing.T) {
+	md := (&configpb.ProberConfig{}).ProtoReflect().Descriptor()
+
+	validConfig, err := os.ReadFile("testdata/cloudprober.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, validateYAML(string(validConfig), md))
+
+	tests := []struct {
+		desc    string
+		config  string
+		wantErr string
+	}{
+		{
+			desc: "unknown_field",
+			config: `
+probe:
+  - name: dns
+    type: DNS
+    intervalMs: 1000
+`,
+			wantErr: `line 5: unknown field "intervalMs" in cloudprober.probes.ProbeDef`,
+		},
+		{
+			desc: "invalid_enum",
+			config: `
+probe:
+  - name: web
+    type: HTTPS
+`,
+			wantErr: `line 4: invalid value "HTTPS" for enum cloudprober.probes.ProbeDef.Type`,
+		},
+		{
+			desc: "wrong_type",
+			config: `
+probe:
+  - name: web
+    type: HTTP
+    interval_msec: 10s
+`,
+			wantErr: `line 5: expected an integer for field "interval_msec", got "10s"`,
+		},
+		{
+			desc: "not_a_list",
+			config: `
+probe:
+  name: web
+`,
+			wantErr: `line 3: expected a list for field "probe", got a mapping`,
+		},
+		{
+			desc: "oneof_conflict",
+			config: `
+probe:
+  - name: web
+    type: HTTP
+    targets:
+      host_names: www.example.com
+      file_targets:
+        file_path: /tmp/targets.json
+`,
+			wantErr: `line 7: field "file_targets" conflicts with "host_names"`,
+		},
+		{
+			desc: "alias",
+			config: `
+probe:
+  - &web
+    name: web
+    type: HTTP
+    targets:
+      hostNames: www.example.com
+  - <<: *web
+    name: web2
+    timeout_msec: eighty
+`,
+			wantErr: `line 10: expected an integer for field "timeout_msec", got "eighty"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := validateYAML(test.config, md)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+
+			// Unmarshaling the config returns the same errors.
+			_, err = unmarshalConfig(test.config, "yaml")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
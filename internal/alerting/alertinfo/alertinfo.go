@@ -38,6 +38,13 @@ type AlertInfo struct {
 	// DeduplicationID is used to de-duplicate alerts. It is set to a UUID
 	// created using the alert name, probe name and target.
 	DeduplicationID string
+
+	// Labels are the routing labels configured for the alert, expanded for
+	// the target.
+	Labels map[string]string
+
+	// Targets are the targets of a grouped alert.
+	Targets []endpoint.Endpoint
 }
 
 func (ai *AlertInfo) Fields(templateDetails map[string]string) map[string]string {
@@ -58,6 +65,15 @@ func (ai *AlertInfo) Fields(templateDetails map[string]string) map[string]string
 		fields["target_ip"] = ai.Target.IP.String()
 	}
 
+	if len(ai.Targets) > 0 {
+		var targets []string
+		for _, ep := range ai.Targets {
+			targets = append(targets, ep.Dst())
+		}
+		fields["targets"] = strings.Join(targets, ", ")
+		fields["num_targets"] = strconv.Itoa(len(ai.Targets))
+	}
+
 	// Note that we parse details in the end, that's because details template
 	// may use other parsed fields like dashboard_url, playbook_url, etc.
 	for k, v := range templateDetails {
@@ -94,3 +110,66 @@ func FieldsToString(fields map[string]string, skipKeys ...string) string {
 
 	return strings.Join(out, "\n")
 }
+
+// Alert event statuses.
+const (
+	StatusFiring   = "FIRING"
+	StatusResolved = "RESOLVED"
+)
+
+// Event is a structured alert event. It's used by the notifiers that publish
+// alerts to messaging services, e.g. SNS and Pub/Sub.
+type Event struct {
+	Status          string            `json:"status"`
+	Alert           string            `json:"alert"`
+	Probe           string            `json:"probe"`
+	Target          string            `json:"target"`
+	Severity        string            `json:"severity,omitempty"`
+	Failures        int               `json:"failures"`
+	Total           int               `json:"total"`
+	FailingSince    time.Time         `json:"failing_since"`
+	DeduplicationID string            `json:"deduplication_id"`
+	Fields          map[string]string `json:"fields"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// NewEvent creates a new alert event from the alert info and fields.
+func NewEvent(ai *AlertInfo, fields map[string]string, resolved bool) *Event {
+	e := &Event{
+		Status:          StatusFiring,
+		Alert:           ai.Name,
+		Probe:           ai.ProbeName,
+		Target:          ai.Target.Dst(),
+		Severity:        fields["severity"],
+		Failures:        ai.Failures,
+		Total:           ai.Total,
+		FailingSince:    ai.FailingSince,
+		DeduplicationID: ai.DeduplicationID,
+		Fields:          fields,
+		Labels:          ai.Labels,
+	}
+	if resolved {
+		e.Status = StatusResolved
+	}
+	return e
+}
+
+// Attributes returns the event attributes that can be used to filter events,
+// e.g. in subscription filters.
+func (e *Event) Attributes() map[string]string {
+	attrs := make(map[string]string, len(e.Labels)+4)
+	for k, v := range e.Labels {
+		attrs[k] = v
+	}
+	for k, v := range map[string]string{
+		"status": e.Status,
+		"alert":  e.Alert,
+		"probe":  e.Probe,
+	} {
+		attrs[k] = v
+	}
+	if e.Severity != "" {
+		attrs["severity"] = e.Severity
+	}
+	return attrs
+}
@@ -19,9 +19,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/cloudprober/cloudprober/common/strtemplate"
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
 	"github.com/cloudprober/cloudprober/internal/alerting/notifier"
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
@@ -35,11 +37,19 @@ import (
 type targetState struct {
 	lastSuccess int64
 	lastTotal   int64
+	lastLatency metrics.Value // Used only for the latency conditions.
 	failures    []bool
 
 	alerted      bool
 	alertTS      time.Time
 	failingSince time.Time
+
+	// notified is set if a notification was sent for the current alert, i.e.
+	// it was not silenced all along.
+	notified bool
+
+	// Endpoint the composite alert was fired for.
+	ep endpoint.Endpoint
 }
 
 // AlertHandler is responsible for handling alerts. It keeps track of the
@@ -53,6 +63,19 @@ type AlertHandler struct {
 	notifyCh     chan *alertinfo.AlertInfo // Used only for testing for now.
 	notifier     *notifier.Notifier
 
+	// Last exported delivery stats, by notifier name.
+	lastDeliveryStats map[string]notifier.DeliveryStats
+
+	silences            []*recurringSilence
+	suppressed          int64
+	lastSuppressedCount int64
+	grouper             *grouper
+	escalator           *escalator
+
+	// Composite alert expression and the alert names used in it.
+	composite     expr
+	compositeUses map[string]bool
+
 	mu      sync.Mutex
 	targets map[string]*targetState
 	l       *logger.Logger
@@ -67,6 +90,10 @@ func processConfig(conf *configpb.AlertConf) {
 			Total:    1,
 		}
 	}
+	// For latency conditions, default is to alert on 1 failed interval.
+	if conf.GetCondition().GetLatency() != nil && conf.GetCondition().Failures == 0 {
+		conf.Condition.Failures = 1
+	}
 	if conf.GetCondition().Total == 0 {
 		conf.Condition.Total = conf.Condition.Failures
 	}
@@ -103,6 +130,42 @@ func NewAlertHandler(conf *configpb.AlertConf, probeName string, l *logger.Logge
 	}
 	ah.notifier = notifier
 
+	for i, s := range conf.GetSilence() {
+		rs, err := newRecurringSilence(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing silence %d for alert %s: %v", i, ah.name, err)
+		}
+		ah.silences = append(ah.silences, rs)
+	}
+
+	if conf.GetComposite() != nil {
+		e, names, err := parseExpr(conf.GetComposite().GetExpression())
+		if err != nil {
+			return nil, fmt.Errorf("invalid composite expression for alert %s: %v", ah.name, err)
+		}
+		ah.composite, ah.compositeUses = e, make(map[string]bool)
+		for _, name := range names {
+			if name == ah.name {
+				return nil, fmt.Errorf("composite alert %s can't refer to itself", ah.name)
+			}
+			ah.compositeUses[name] = true
+		}
+		ah.condition = &configpb.Condition{Failures: 1, Total: int32(len(ah.compositeUses))}
+		globalComposites.register(ah)
+	}
+
+	if conf.GetGrouping() != nil {
+		repeat := time.Duration(conf.GetRepeatIntervalSec()) * time.Second
+		ah.grouper = newGrouper(conf.GetGrouping(), repeat, ah.sendNotification, ah.sendResolveNotification)
+	}
+
+	if conf.GetEscalation() != nil {
+		if ah.escalator, err = newEscalator(conf, l); err != nil {
+			return nil, fmt.Errorf("error configuring escalation for alert %s: %v", ah.name, err)
+		}
+		globalEscalators.register(ah.escalator)
+	}
+
 	return ah, nil
 }
 
@@ -140,29 +203,123 @@ func (ah *AlertHandler) notify(ep endpoint.Endpoint, ts *targetState, totalFailu
 		Total:           int(ah.condition.Total),
 		FailingSince:    ts.failingSince,
 	}
+	alertInfo.Labels = ah.expandLabels(alertInfo)
+	newAlert := globalState.get(alertKey) == nil
+	globalState.add(alertKey, alertInfo)
+
+	silenced := ah.silenced(alertInfo)
+	if newAlert {
+		details := fmt.Sprintf("failures: %d/%d", totalFailures, ah.condition.Total)
+		if silenced {
+			details += ", silenced"
+		}
+		globalHistory.record(alertInfo, EventFired, severityString(ah.c.GetSeverity()), details)
+	}
 
+	if silenced {
+		ah.l.Infof("ALERT (%s): target (%s) is silenced, not sending notification", ah.name, ep.Name)
+		ah.suppressed++
+		globalPersister.setAlert(alertKey, &persistedAlert{AlertInfo: alertInfo, LastNotified: ts.alertTS, Notified: ts.notified})
+		return
+	}
+	ts.notified = true
+	globalPersister.setAlert(alertKey, &persistedAlert{AlertInfo: alertInfo, LastNotified: ts.alertTS, Notified: true})
+
+	if ah.grouper != nil {
+		ah.grouper.add(alertInfo)
+		return
+	}
+	ah.sendNotification(alertInfo)
+}
+
+func (ah *AlertHandler) sendNotification(alertInfo *alertinfo.AlertInfo) {
 	if ah.notifyCh != nil {
 		ah.notifyCh <- alertInfo
 	}
 
 	ah.notifier.Notify(context.Background(), alertInfo)
-	globalState.add(alertKey, alertInfo)
+	if ah.escalator != nil {
+		ah.escalator.start(alertInfo)
+	}
+}
+
+func (ah *AlertHandler) sendResolveNotification(alertInfo *alertinfo.AlertInfo) {
+	ah.notifier.NotifyResolve(context.Background(), alertInfo)
+	if ah.escalator != nil {
+		ah.escalator.resolve(alertInfo)
+	}
+}
+
+// silenced returns true if the alert matches an active silence, either a
+// recurring silence from the config or an ad-hoc silence.
+func (ah *AlertHandler) silenced(alertInfo *alertinfo.AlertInfo) bool {
+	fields := alertInfo.Fields(nil)
+	for k, v := range alertInfo.Labels {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+	if ah.c.GetSeverity() != configpb.AlertConf_UNKNOWN_SEVERITY {
+		fields["severity"] = ah.c.GetSeverity().String()
+	}
+
+	now := time.Now()
+	for _, s := range ah.silences {
+		if s.match(fields, now) {
+			return true
+		}
+	}
+	return globalSilences.match(fields)
+}
+
+// expandLabels expands the routing labels for the alert. Labels can use the
+// alert fields, e.g. @target.label.team@.
+func (ah *AlertHandler) expandLabels(alertInfo *alertinfo.AlertInfo) map[string]string {
+	if len(ah.c.GetLabels()) == 0 {
+		return nil
+	}
+
+	fields := alertInfo.Fields(nil)
+	labels := make(map[string]string, len(ah.c.GetLabels()))
+	for k, v := range ah.c.GetLabels() {
+		val, ok := strtemplate.SubstituteLabels(v, fields)
+		if !ok {
+			ah.l.Warningf("Couldn't expand alert label %s (%s) for target %s", k, v, alertInfo.Target.Name)
+		}
+		labels[k] = val
+	}
+	return labels
 }
 
 func (ah *AlertHandler) resolveAlertCondition(ts *targetState, ep endpoint.Endpoint) {
 	ah.l.Infof("ALERT Resolved (%s): target: %s", ah.name, ep.Name)
 
+	notified := ts.notified
 	ts.alerted = false
 	ts.alertTS = time.Time{}
+	ts.notified = false
+
+	if ah.composite == nil {
+		globalComposites.update(ah.name, ep, false)
+	}
 
 	key := ah.globalKey(ep)
+	globalPersister.deleteAlert(key)
 	ai := globalState.get(key)
 	if ai == nil {
 		ah.l.Errorf("ALERT Resolved (%s): didn't find alert for target (%s) in the global state, will not send resolve notification", ah.name, ep.Name)
 		return
 	}
-
-	ah.notifier.NotifyResolve(context.Background(), ai)
+	globalHistory.record(ai, EventResolved, severityString(ah.c.GetSeverity()), "")
+
+	// Don't send resolve notification if the alert was silenced all along.
+	if notified {
+		if ah.grouper != nil {
+			ah.grouper.remove(ai)
+		} else {
+			ah.sendResolveNotification(ai)
+		}
+	}
 	globalState.resolve(key)
 }
 
@@ -170,6 +327,13 @@ func (ah *AlertHandler) resolveAlertCondition(ts *targetState, ep endpoint.Endpo
 func (ah *AlertHandler) handleAlertCondition(ts *targetState, ep endpoint.Endpoint, timestamp time.Time, totalFailures int) {
 	// Ongoing alert. Notify if the repeat interval has passed.
 	if ts.alerted {
+		// For grouped alerts, repeat notifications are sent per group.
+		if ah.grouper != nil && ts.notified {
+			if ai := globalState.get(ah.globalKey(ep)); ai != nil {
+				ah.grouper.refresh(ai)
+			}
+			return
+		}
 		if time.Since(ts.alertTS) > time.Duration(ah.c.GetRepeatIntervalSec())*time.Second {
 			ts.alertTS = time.Now()
 			ah.notify(ep, ts, totalFailures)
@@ -182,6 +346,59 @@ func (ah *AlertHandler) handleAlertCondition(ts *targetState, ep endpoint.Endpoi
 	ts.failingSince = timestamp
 	ts.alertTS = time.Now()
 	ah.notify(ep, ts, totalFailures)
+	globalComposites.update(ah.name, ep, true)
+}
+
+// DeliveryMetrics returns the notifications delivery metrics, one
+// EventMetrics per notifier, for the notifiers whose stats have changed since
+// the last call.
+func (ah *AlertHandler) DeliveryMetrics() []*metrics.EventMetrics {
+	stats := ah.notifier.DeliveryStats()
+
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+
+	var names []string
+	for name, s := range stats {
+		if s != ah.lastDeliveryStats[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	ah.lastDeliveryStats = stats
+
+	var result []*metrics.EventMetrics
+	for _, name := range names {
+		s := stats[name]
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("notifications_success", metrics.NewInt(s.Success)).
+			AddMetric("notifications_failure", metrics.NewInt(s.Failure)).
+			AddMetric("notifications_latency_ms", metrics.NewFloat(float64(s.Latency)/float64(time.Millisecond))).
+			AddMetric("notifications_retries", metrics.NewInt(s.Retries))
+		result = append(result, em.
+			AddLabel("ptype", "alerting").
+			AddLabel("probe", ah.probeName).
+			AddLabel("alert", ah.name).
+			AddLabel("notifier", name))
+	}
+	return result
+}
+
+// SuppressedMetrics returns the count of notifications suppressed by the
+// silences, if it has changed since the last call. It returns nil otherwise.
+func (ah *AlertHandler) SuppressedMetrics() *metrics.EventMetrics {
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+	if ah.suppressed == ah.lastSuppressedCount {
+		return nil
+	}
+	ah.lastSuppressedCount = ah.suppressed
+
+	return metrics.NewEventMetrics(time.Now()).
+		AddMetric("suppressed", metrics.NewInt(ah.suppressed)).
+		AddLabel("ptype", "alerting").
+		AddLabel("probe", ah.probeName).
+		AddLabel("alert", ah.name)
 }
 
 func (ah *AlertHandler) globalKey(ep endpoint.Endpoint) string {
@@ -189,6 +406,11 @@ func (ah *AlertHandler) globalKey(ep endpoint.Endpoint) string {
 }
 
 func (ah *AlertHandler) Record(ep endpoint.Endpoint, em *metrics.EventMetrics) {
+	// Composite alerts are evaluated on the other alerts' state changes.
+	if ah.composite != nil {
+		return
+	}
+
 	ah.mu.Lock()
 	defer ah.mu.Unlock()
 
@@ -214,7 +436,20 @@ func (ah *AlertHandler) Record(ep endpoint.Endpoint, em *metrics.EventMetrics) {
 			lastTotal:   total,
 			lastSuccess: success,
 		}
+		if ah.condition.GetLatency() != nil {
+			if lat := em.Metric(ah.latencyMetricName()); lat != nil {
+				ts.lastLatency = lat.Clone()
+			}
+		}
 		ah.targets[key] = ts
+		if pa := globalPersister.claim(ah.globalKey(ep)); pa != nil {
+			ah.restoreState(ts, ep, pa)
+		}
+		return
+	}
+
+	if ah.condition.GetLatency() != nil {
+		ah.recordLatency(ts, ep, em, total, success)
 		return
 	}
 
@@ -258,6 +493,13 @@ func (ah *AlertHandler) Record(ep endpoint.Endpoint, em *metrics.EventMetrics) {
 		failureCnt--
 	}
 
+	ah.evaluate(ts, ep, em.Timestamp)
+	ts.lastTotal, ts.lastSuccess = total, success
+}
+
+// evaluate evaluates the alert condition for the target, based on its
+// failures.
+func (ah *AlertHandler) evaluate(ts *targetState, ep endpoint.Endpoint, timestamp time.Time) {
 	totalFailures := 0
 	for _, failed := range ts.failures {
 		if failed {
@@ -266,10 +508,36 @@ func (ah *AlertHandler) Record(ep endpoint.Endpoint, em *metrics.EventMetrics) {
 	}
 
 	if totalFailures >= int(ah.condition.Failures) {
-		ah.handleAlertCondition(ts, ep, em.Timestamp, totalFailures)
+		ah.handleAlertCondition(ts, ep, timestamp, totalFailures)
 	} else if ts.alerted {
 		ah.resolveAlertCondition(ts, ep)
 	}
+}
 
-	ts.lastTotal, ts.lastSuccess = total, success
+func (ah *AlertHandler) latencyMetricName() string {
+	if ah.condition.GetLatency().MetricName != nil {
+		return ah.condition.GetLatency().GetMetricName()
+	}
+	return defaultLatencyMetricName
+}
+
+// recordLatency records an interval for the latency condition. Each interval
+// is considered failed if latency in that interval is above the threshold.
+func (ah *AlertHandler) recordLatency(ts *targetState, ep endpoint.Endpoint, em *metrics.EventMetrics, total, success int64) {
+	lat := em.Metric(ah.latencyMetricName())
+	if lat == nil {
+		ah.l.ErrorAttrs(fmt.Sprintf("%s metric not found in EventMetrics: %s", ah.latencyMetricName(), em.String()), slog.String("target", ep.Name))
+		return
+	}
+
+	breached, err := latencyBreached(ah.condition.GetLatency(), lat, ts.lastLatency, success-ts.lastSuccess, em.LatencyUnit)
+	if err != nil {
+		ah.l.ErrorAttrs(err.Error(), slog.String("target", ep.Name))
+	}
+
+	copy(ts.failures, ts.failures[1:])
+	ts.failures[len(ts.failures)-1] = breached
+
+	ah.evaluate(ts, ep, em.Timestamp)
+	ts.lastTotal, ts.lastSuccess, ts.lastLatency = total, success, lat.Clone()
 }
@@ -123,9 +123,11 @@ func TestUpdateState(t *testing.T) {
 }
 
 func TestStatusHTML(t *testing.T) {
-	oldGlobalState := globalState
-	globalState = state{}
-	defer func() { globalState = oldGlobalState }()
+	oldCurrentAlerts, oldResolvedAlerts := globalState.currentAlerts, globalState.resolvedAlerts
+	globalState.currentAlerts, globalState.resolvedAlerts = nil, nil
+	defer func() {
+		globalState.currentAlerts, globalState.resolvedAlerts = oldCurrentAlerts, oldResolvedAlerts
+	}()
 
 	ah := &AlertHandler{
 		name:      "test-alert-1",
@@ -15,6 +15,9 @@
 package alerting
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -377,3 +380,60 @@ func TestConditionID(t *testing.T) {
 		})
 	}
 }
+
+func TestDeliveryMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Name: "test-alert",
+		Notify: &configpb.NotifyConfig{
+			Webhook: &configpb.Webhook{Url: ts.URL},
+			Command: "/non-existent-command",
+		},
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+
+	// No notifications yet.
+	assert.Nil(t, ah.DeliveryMetrics())
+
+	ah.notifier.Notify(context.Background(), &alertinfo.AlertInfo{Name: "test-alert"})
+	ems := ah.DeliveryMetrics()
+	assert.Len(t, ems, 2)
+
+	wantStats := map[string][2]int64{"command": {0, 1}, "webhook": {1, 0}}
+	for _, em := range ems {
+		assert.Equal(t, "test-alert", em.Label("alert"))
+		want := wantStats[em.Label("notifier")]
+		assert.Equal(t, want[0], em.Metric("notifications_success").(*metrics.Int).Int64(), em.Label("notifier"))
+		assert.Equal(t, want[1], em.Metric("notifications_failure").(*metrics.Int).Int64(), em.Label("notifier"))
+		assert.NotNil(t, em.Metric("notifications_latency_ms"))
+		assert.Equal(t, int64(0), em.Metric("notifications_retries").(*metrics.Int).Int64(), em.Label("notifier"))
+	}
+
+	// Unchanged stats are not exported again.
+	assert.Nil(t, ah.DeliveryMetrics())
+
+	// No notifiers.
+	ah, err = NewAlertHandler(&configpb.AlertConf{}, "test-probe", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ah.DeliveryMetrics())
+}
+
+func TestAlertLabels(t *testing.T) {
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Name: "test-alert",
+		Labels: map[string]string{
+			"team": "@target.label.team@",
+			"env":  "prod",
+		},
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+	ah.notifyCh = make(chan *alertinfo.AlertInfo, 1)
+
+	ep := endpoint.Endpoint{Name: "target1", Labels: map[string]string{"team": "db"}}
+	ah.notify(ep, &targetState{}, 1)
+
+	a := <-ah.notifyCh
+	assert.Equal(t, map[string]string{"team": "db", "env": "prod"}, a.Labels)
+}
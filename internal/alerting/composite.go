@@ -0,0 +1,262 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// expr is a boolean expression over the alert names.
+type expr interface {
+	eval(alerted func(name string) bool) bool
+}
+
+type (
+	nameExpr string
+	notExpr  struct{ e expr }
+	andExpr  struct{ l, r expr }
+	orExpr   struct{ l, r expr }
+)
+
+func (e nameExpr) eval(alerted func(string) bool) bool { return alerted(string(e)) }
+func (e notExpr) eval(alerted func(string) bool) bool  { return !e.e.eval(alerted) }
+func (e andExpr) eval(alerted func(string) bool) bool {
+	return e.l.eval(alerted) && e.r.eval(alerted)
+}
+func (e orExpr) eval(alerted func(string) bool) bool {
+	return e.l.eval(alerted) || e.r.eval(alerted)
+}
+
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')' || c == '!':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(s[i:], "&&") || strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_-.", c):
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || strings.ContainsRune("_-.", rune(s[j]))) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser for the composite expressions:
+//
+//	or   := and ("||" and)*
+//	and  := not ("&&" not)*
+//	not  := "!" not | "(" or ")" | name
+type parser struct {
+	tokens []string
+	pos    int
+	names  []string
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) parseOr() (expr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = orExpr{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = andExpr{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	switch t := p.next(); t {
+	case "!":
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	case "(":
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return e, nil
+	case "", ")", "&&", "||":
+		return nil, fmt.Errorf("unexpected token %q", t)
+	default:
+		p.names = append(p.names, t)
+		return nameExpr(t), nil
+	}
+}
+
+// parseExpr parses the composite expression, and returns the expression and
+// the alert names used in it.
+func parseExpr(s string) (expr, []string, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing expression (%s): %v", s, err)
+	}
+	if p.pos < len(tokens) {
+		return nil, nil, fmt.Errorf("error parsing expression (%s): unexpected token %q", s, tokens[p.pos])
+	}
+	return e, p.names, nil
+}
+
+// compositeRegistry keeps track of the alert states by alert name and target
+// name, and evaluates the composite alerts when the states change. Composite
+// alerts' own states are not tracked, i.e. they can't be used in the other
+// composite alerts.
+type compositeRegistry struct {
+	mu         sync.Mutex
+	alerted    map[string]map[string]bool // alert name -> target name -> alerted
+	composites map[string]*AlertHandler   // Keyed by alert name.
+}
+
+var globalComposites = &compositeRegistry{
+	alerted:    make(map[string]map[string]bool),
+	composites: make(map[string]*AlertHandler),
+}
+
+func (r *compositeRegistry) register(ah *AlertHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.composites[ah.name] = ah
+}
+
+func (r *compositeRegistry) isAlerted(alertName, target string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.alerted[alertName][target]
+}
+
+// update updates the alert state for the target and evaluates the composite
+// alerts that use the alert.
+func (r *compositeRegistry) update(alertName string, ep endpoint.Endpoint, alerted bool) {
+	r.mu.Lock()
+	if r.alerted[alertName] == nil {
+		r.alerted[alertName] = make(map[string]bool)
+	}
+	r.alerted[alertName][ep.Name] = alerted
+
+	var handlers []*AlertHandler
+	for _, ah := range r.composites {
+		if ah.compositeUses[alertName] {
+			handlers = append(handlers, ah)
+		}
+	}
+	r.mu.Unlock()
+
+	// Evaluate outside the lock, as evaluation looks up the alert states.
+	for _, ah := range handlers {
+		ah.evaluateComposite(ep)
+	}
+}
+
+// evaluateComposite evaluates the composite alert for the target, and fires
+// or resolves the alert accordingly.
+func (ah *AlertHandler) evaluateComposite(ep endpoint.Endpoint) {
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+
+	alertedCnt := 0
+	for name := range ah.compositeUses {
+		if globalComposites.isAlerted(name, ep.Name) {
+			alertedCnt++
+		}
+	}
+	firing := ah.composite.eval(func(name string) bool {
+		return globalComposites.isAlerted(name, ep.Name)
+	})
+
+	key := ep.Name
+	ts := ah.targets[key]
+	if ts == nil {
+		ts = &targetState{}
+		ah.targets[key] = ts
+	}
+
+	if firing && !ts.alerted {
+		if pa := globalPersister.claim(ah.globalKey(ep)); pa != nil {
+			ah.restoreState(ts, ep, pa)
+			return
+		}
+		ts.alerted = true
+		ts.failingSince = time.Now()
+		ts.alertTS = time.Now()
+		ts.ep = ep
+		ah.notify(ep, ts, alertedCnt)
+	} else if !firing && ts.alerted {
+		// Use the same endpoint as the one used while firing the alert, as
+		// targets may differ slightly across probes, e.g. in port.
+		ah.resolveAlertCondition(ts, ts.ep)
+	}
+}
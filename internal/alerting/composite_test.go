@@ -0,0 +1,122 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpr(t *testing.T) {
+	alerted := map[string]bool{"http": true, "ping": false, "dns-probe.v2": true}
+
+	tests := []struct {
+		expr      string
+		want      bool
+		wantNames []string
+		wantErr   bool
+	}{
+		{expr: "http", want: true, wantNames: []string{"http"}},
+		{expr: "http && ping", want: false, wantNames: []string{"http", "ping"}},
+		{expr: "http || ping", want: true, wantNames: []string{"http", "ping"}},
+		{expr: "http && !ping", want: true, wantNames: []string{"http", "ping"}},
+		{expr: "!(http && dns-probe.v2)", want: false, wantNames: []string{"http", "dns-probe.v2"}},
+		{expr: "ping && http || dns-probe.v2", want: true, wantNames: []string{"ping", "http", "dns-probe.v2"}},
+		{expr: "ping && (http || dns-probe.v2)", want: false, wantNames: []string{"ping", "http", "dns-probe.v2"}},
+		{expr: "", wantErr: true},
+		{expr: "http &&", wantErr: true},
+		{expr: "(http", wantErr: true},
+		{expr: "http ping", wantErr: true},
+		{expr: "http & ping", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, names, err := parseExpr(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.want, e.eval(func(name string) bool { return alerted[name] }))
+		})
+	}
+}
+
+func TestCompositeAlert(t *testing.T) {
+	newAH := func(conf *configpb.AlertConf) *AlertHandler {
+		t.Helper()
+		ah, err := NewAlertHandler(conf, conf.GetName(), nil)
+		assert.NoError(t, err)
+		return ah
+	}
+
+	httpAH := newAH(&configpb.AlertConf{Name: "composite-test-http"})
+	pingAH := newAH(&configpb.AlertConf{Name: "composite-test-ping"})
+	compAH := newAH(&configpb.AlertConf{
+		Name:      "composite-test-both",
+		Composite: &configpb.Composite{Expression: "composite-test-http && composite-test-ping"},
+	})
+	compAH.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+
+	record := func(ah *AlertHandler, ep endpoint.Endpoint, total, success int64) {
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success))
+		ah.Record(ep, em)
+	}
+
+	// HTTP target has a port, ping target doesn't.
+	httpEP := endpoint.Endpoint{Name: "t1", Port: 443}
+	pingEP := endpoint.Endpoint{Name: "t1"}
+	record(httpAH, httpEP, 1, 1)
+	record(pingAH, pingEP, 1, 1)
+
+	// Only HTTP failing.
+	record(httpAH, httpEP, 2, 1)
+	assert.True(t, httpAH.targets[httpEP.Key()].alerted)
+	assert.Len(t, compAH.notifyCh, 0)
+
+	// Both failing.
+	record(pingAH, pingEP, 2, 1)
+	assert.Len(t, compAH.notifyCh, 1)
+	ai := <-compAH.notifyCh
+	assert.Equal(t, "composite-test-both", ai.Name)
+	assert.Equal(t, 2, ai.Failures)
+	assert.Equal(t, 2, ai.Total)
+	assert.True(t, compAH.targets["t1"].alerted)
+
+	// Ping recovers, composite alert resolves.
+	record(pingAH, pingEP, 3, 3)
+	assert.False(t, compAH.targets["t1"].alerted)
+	assert.Nil(t, globalState.get(compAH.globalKey(httpEP)))
+
+	// Composite alerts ignore probe results.
+	record(compAH, httpEP, 1, 0)
+	record(compAH, httpEP, 2, 0)
+	assert.False(t, compAH.targets["t1"].alerted)
+
+	_, err := NewAlertHandler(&configpb.AlertConf{
+		Name:      "self",
+		Composite: &configpb.Composite{Expression: "self || other"},
+	}, "test-probe", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,352 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+type escalationStage struct {
+	after    time.Duration // Since the first notification.
+	notifier *notifier.Notifier
+}
+
+// escalation is the escalation state of a notified alert.
+type escalation struct {
+	ai      *alertinfo.AlertInfo
+	stage   int // Number of stages notified so far.
+	acked   bool
+	started time.Time
+	timer   *time.Timer
+}
+
+// escalator escalates the unacknowledged alerts through the escalation
+// stages.
+type escalator struct {
+	stages   []*escalationStage
+	severity string
+
+	mu     sync.Mutex
+	alerts map[string]*escalation // Keyed by deduplication ID.
+	l      *logger.Logger
+
+	escalateCh chan int // Escalated stages, used only for testing.
+}
+
+func newEscalator(conf *configpb.AlertConf, l *logger.Logger) (*escalator, error) {
+	e := &escalator{
+		severity: severityString(conf.GetSeverity()),
+		alerts:   make(map[string]*escalation),
+		l:        l,
+	}
+
+	var last time.Duration
+	for i, s := range conf.GetEscalation().GetStage() {
+		after := time.Duration(s.GetAfterSec()) * time.Second
+		if after <= last {
+			return nil, fmt.Errorf("escalation stage %d: after_sec (%d) should be greater than the previous stage's", i, s.GetAfterSec())
+		}
+		last = after
+
+		// Stage notifiers use the alert's templates, but not its routes.
+		stageConf := proto.Clone(conf).(*configpb.AlertConf)
+		stageConf.Notify, stageConf.Route = s.GetNotify(), nil
+		n, err := notifier.New(stageConf, l)
+		if err != nil {
+			return nil, fmt.Errorf("escalation stage %d: %v", i, err)
+		}
+		e.stages = append(e.stages, &escalationStage{after: after, notifier: n})
+	}
+
+	return e, nil
+}
+
+// start starts the escalation for the alert, if it's not already escalating.
+func (e *escalator) start(ai *alertinfo.AlertInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if esc := e.alerts[ai.DeduplicationID]; esc != nil {
+		esc.ai = ai
+		return
+	}
+	esc := &escalation{ai: ai, started: time.Now()}
+	e.alerts[ai.DeduplicationID] = esc
+	if len(e.stages) > 0 {
+		esc.timer = time.AfterFunc(e.stages[0].after, func() { e.escalate(ai.DeduplicationID) })
+	}
+}
+
+// escalate notifies the next stage for the alert, and schedules the stage
+// after it.
+func (e *escalator) escalate(id string) {
+	e.mu.Lock()
+	esc := e.alerts[id]
+	if esc == nil || esc.acked || esc.stage >= len(e.stages) {
+		e.mu.Unlock()
+		return
+	}
+	stageNum, stage := esc.stage, e.stages[esc.stage]
+	esc.stage++
+	if esc.stage < len(e.stages) {
+		next := e.stages[esc.stage].after - time.Since(esc.started)
+		esc.timer = time.AfterFunc(next, func() { e.escalate(id) })
+	}
+	ai := esc.ai
+	e.mu.Unlock()
+
+	e.l.Warningf("ALERT (%s): target (%s) not acknowledged, escalating to stage %d", ai.Name, ai.Target.Name, stageNum+1)
+	globalHistory.record(ai, EventEscalated, e.severity, fmt.Sprintf("stage %d", stageNum+1))
+	stage.notifier.Notify(context.Background(), ai)
+	if e.escalateCh != nil {
+		e.escalateCh <- stageNum
+	}
+}
+
+// ack acknowledges the alert, stopping its escalation. It returns false if
+// the alert is not escalating.
+func (e *escalator) ack(id string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	esc := e.alerts[id]
+	if esc == nil {
+		return false
+	}
+	if !esc.acked {
+		e.l.Infof("ALERT (%s): target (%s) acknowledged", esc.ai.Name, esc.ai.Target.Name)
+		globalHistory.record(esc.ai, EventAcknowledged, e.severity, "")
+	}
+	esc.acked = true
+	if esc.timer != nil {
+		esc.timer.Stop()
+	}
+	return true
+}
+
+// resolve stops the escalation for the alert, and sends the resolve
+// notification to the stages that were notified.
+func (e *escalator) resolve(ai *alertinfo.AlertInfo) {
+	e.mu.Lock()
+	esc := e.alerts[ai.DeduplicationID]
+	if esc == nil {
+		e.mu.Unlock()
+		return
+	}
+	delete(e.alerts, ai.DeduplicationID)
+	if esc.timer != nil {
+		esc.timer.Stop()
+	}
+	notified := e.stages[:esc.stage]
+	e.mu.Unlock()
+
+	for _, s := range notified {
+		s.notifier.NotifyResolve(context.Background(), ai)
+	}
+}
+
+// EscalationStatus is the escalation status of an alert, returned by the
+// acks API.
+type EscalationStatus struct {
+	ID             string    `json:"id"`
+	Alert          string    `json:"alert"`
+	Target         string    `json:"target"`
+	StagesNotified int       `json:"stages_notified"`
+	Acked          bool      `json:"acked"`
+	Since          time.Time `json:"since"`
+}
+
+func (e *escalator) list() []*EscalationStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var result []*EscalationStatus
+	for id, esc := range e.alerts {
+		result = append(result, &EscalationStatus{
+			ID:             id,
+			Alert:          esc.ai.Name,
+			Target:         esc.ai.Target.Name,
+			StagesNotified: esc.stage,
+			Acked:          esc.acked,
+			Since:          esc.started,
+		})
+	}
+	return result
+}
+
+// escalators keeps track of all the escalators, to route the acks.
+type escalators struct {
+	mu         sync.Mutex
+	escalators []*escalator
+
+	// Secret to verify the PagerDuty webhook signatures.
+	pagerDutySecret string
+}
+
+var globalEscalators = &escalators{}
+
+func (es *escalators) register(e *escalator) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.escalators = append(es.escalators, e)
+}
+
+func (es *escalators) list() []*EscalationStatus {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	var result []*EscalationStatus
+	for _, e := range es.escalators {
+		result = append(result, e.list()...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Since.Before(result[j].Since)
+	})
+	return result
+}
+
+// ack acknowledges the alerts matching the deduplication ID, or the alert
+// name and target (target is optional). It returns the acknowledged IDs.
+func (es *escalators) ack(id, alert, target string) []string {
+	var ids []string
+	for _, s := range es.list() {
+		if id != "" && s.ID != id {
+			continue
+		}
+		if alert != "" && (s.Alert != alert || (target != "" && s.Target != target)) {
+			continue
+		}
+		ids = append(ids, s.ID)
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	for _, id := range ids {
+		for _, e := range es.escalators {
+			e.ack(id)
+		}
+	}
+	return ids
+}
+
+func (es *escalators) ackHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(es.list())
+
+	case http.MethodPost:
+		q := r.URL.Query()
+		id, alert := q.Get("id"), q.Get("alert")
+		if id == "" && alert == "" {
+			http.Error(w, "either id or alert is required", http.StatusBadRequest)
+			return
+		}
+		ids := es.ack(id, alert, q.Get("target"))
+		if len(ids) == 0 {
+			http.Error(w, "no matching escalating alert found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ids)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verifyPagerDutySignature verifies the PagerDuty webhook signature header,
+// which may contain multiple signatures, e.g. "v1=abc,v1=def".
+func verifyPagerDutySignature(secret string, body []byte, header string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	want := "v1=" + hex.EncodeToString(mac.Sum(nil))
+	for _, sig := range strings.Split(header, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(sig)), []byte(want)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pagerDutyWebhook is the part of the PagerDuty (v3) webhook payload that we
+// use. Incident key is the dedup key of the alert event.
+type pagerDutyWebhook struct {
+	Event struct {
+		EventType string `json:"event_type"`
+		Data      struct {
+			IncidentKey string `json:"incident_key"`
+		} `json:"data"`
+	} `json:"event"`
+}
+
+func (es *escalators) pagerDutyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if es.pagerDutySecret != "" && !verifyPagerDutySignature(es.pagerDutySecret, body, r.Header.Get("X-PagerDuty-Signature")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var wh pagerDutyWebhook
+	if err := json.Unmarshal(body, &wh); err != nil {
+		http.Error(w, fmt.Sprintf("error parsing webhook: %v", err), http.StatusBadRequest)
+		return
+	}
+	// Other events, e.g. incident.triggered, are ignored. Respond with 200
+	// anyway, as PagerDuty disables webhooks that keep failing.
+	if wh.Event.EventType == "incident.acknowledged" && wh.Event.Data.IncidentKey != "" {
+		es.ack(wh.Event.Data.IncidentKey, "", "")
+	}
+}
+
+// AckHandler serves the alerts acknowledgement API:
+//
+//	GET: list the escalating alerts.
+//	POST ?id=<deduplication-id>, or ?alert=<name>[&target=<target>]: acknowledge
+//	the alert, stopping its escalation.
+func AckHandler(w http.ResponseWriter, r *http.Request) {
+	globalEscalators.ackHandler(w, r)
+}
+
+// PagerDutyAckHandler acknowledges the alerts acknowledged in PagerDuty. It
+// should be configured as a PagerDuty (v3) webhook subscription endpoint.
+func PagerDutyAckHandler(w http.ResponseWriter, r *http.Request) {
+	globalEscalators.pagerDutyHandler(w, r)
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEscalator(t *testing.T, stages ...time.Duration) *escalator {
+	t.Helper()
+
+	conf := &configpb.AlertConf{Escalation: &configpb.Escalation{}}
+	for i := range stages {
+		conf.Escalation.Stage = append(conf.Escalation.Stage, &configpb.EscalationStage{AfterSec: int32(i + 1)})
+	}
+	e, err := newEscalator(conf, &logger.Logger{})
+	assert.NoError(t, err)
+	for i, d := range stages {
+		e.stages[i].after = d
+	}
+	e.escalateCh = make(chan int, 10)
+	return e
+}
+
+func waitForStage(t *testing.T, e *escalator) int {
+	t.Helper()
+	select {
+	case stage := <-e.escalateCh:
+		return stage
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for escalation")
+		return -1
+	}
+}
+
+func TestEscalator(t *testing.T) {
+	ai := &alertinfo.AlertInfo{Name: "a1", Target: endpoint.Endpoint{Name: "t1"}, DeduplicationID: "id1"}
+
+	t.Run("escalate", func(t *testing.T) {
+		e := testEscalator(t, 10*time.Millisecond, 20*time.Millisecond)
+		e.start(ai)
+		e.start(ai) // Repeat notification doesn't restart escalation.
+		assert.Equal(t, 0, waitForStage(t, e))
+		assert.Equal(t, 1, waitForStage(t, e))
+		assert.Equal(t, 2, e.list()[0].StagesNotified)
+
+		e.resolve(ai)
+		assert.Len(t, e.list(), 0)
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		e := testEscalator(t, 10*time.Millisecond, 20*time.Millisecond)
+		e.start(ai)
+		assert.Equal(t, 0, waitForStage(t, e))
+		assert.True(t, e.ack("id1"))
+		assert.False(t, e.ack("id2"))
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Len(t, e.escalateCh, 0)
+		assert.True(t, e.list()[0].Acked)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		e := testEscalator(t, 10*time.Millisecond)
+		e.start(ai)
+		e.resolve(ai)
+		time.Sleep(30 * time.Millisecond)
+		assert.Len(t, e.escalateCh, 0)
+	})
+
+	_, err := newEscalator(&configpb.AlertConf{Escalation: &configpb.Escalation{
+		Stage: []*configpb.EscalationStage{{AfterSec: 600}, {AfterSec: 300}},
+	}}, nil)
+	assert.Error(t, err)
+}
+
+func TestAckHandlers(t *testing.T) {
+	e := testEscalator(t, time.Hour)
+	es := &escalators{pagerDutySecret: "secret"}
+	es.register(e)
+
+	e.start(&alertinfo.AlertInfo{Name: "a1", Target: endpoint.Endpoint{Name: "t1"}, DeduplicationID: "id1"})
+	e.start(&alertinfo.AlertInfo{Name: "a1", Target: endpoint.Endpoint{Name: "t2"}, DeduplicationID: "id2"})
+	e.start(&alertinfo.AlertInfo{Name: "a2", Target: endpoint.Endpoint{Name: "t1"}, DeduplicationID: "id3"})
+
+	acked := func() map[string]bool {
+		m := make(map[string]bool)
+		for _, s := range es.list() {
+			m[s.ID] = s.Acked
+		}
+		return m
+	}
+
+	ack := func(query string) int {
+		w := httptest.NewRecorder()
+		es.ackHandler(w, httptest.NewRequest(http.MethodPost, "/alerts/ack?"+query, nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusBadRequest, ack(""))
+	assert.Equal(t, http.StatusNotFound, ack("id=id4"))
+	assert.Equal(t, http.StatusOK, ack("alert=a1&target=t2"))
+	assert.Equal(t, map[string]bool{"id1": false, "id2": true, "id3": false}, acked())
+
+	pdAck := func(body, sig string) int {
+		req := httptest.NewRequest(http.MethodPost, "/alerts/ack/pagerduty", strings.NewReader(body))
+		req.Header.Set("X-PagerDuty-Signature", sig)
+		w := httptest.NewRecorder()
+		es.pagerDutyHandler(w, req)
+		return w.Code
+	}
+	body := `{"event": {"event_type": "incident.acknowledged", "data": {"incident_key": "id3"}}}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	sig := "v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, http.StatusUnauthorized, pdAck(body, "v1=invalid"))
+	assert.False(t, acked()["id3"])
+	assert.Equal(t, http.StatusOK, pdAck(body, "v1=old,"+sig))
+	assert.True(t, acked()["id3"])
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+const (
+	defaultGroupWait     = 30 * time.Second
+	defaultGroupInterval = 5 * time.Minute
+)
+
+// alertGroup is a group of alerts that are notified together.
+type alertGroup struct {
+	key    string
+	alerts map[string]*alertinfo.AlertInfo // Keyed by target key.
+
+	notified     bool // Whether the group has been notified.
+	lastNotified time.Time
+	changed      bool // Whether the group changed since the last notification.
+	timer        *time.Timer
+
+	// Last notified alert info, used for the resolve notification.
+	lastAlertInfo *alertinfo.AlertInfo
+}
+
+// alertInfo returns the alert info for the whole group.
+func (g *alertGroup) alertInfo() *alertinfo.AlertInfo {
+	var keys []string
+	for k := range g.alerts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	first := g.alerts[keys[0]]
+	ai := &alertinfo.AlertInfo{
+		Name:            first.Name,
+		ProbeName:       first.ProbeName,
+		Target:          first.Target,
+		FailingSince:    first.FailingSince,
+		DeduplicationID: conditionID(g.key),
+		Labels:          first.Labels,
+	}
+	for _, k := range keys {
+		a := g.alerts[k]
+		ai.Targets = append(ai.Targets, a.Target)
+		ai.Failures += a.Failures
+		ai.Total += a.Total
+		if a.FailingSince.Before(ai.FailingSince) {
+			ai.FailingSince = a.FailingSince
+		}
+	}
+	if len(keys) > 1 {
+		ai.Target = endpoint.Endpoint{Name: fmt.Sprintf("%d targets", len(keys))}
+	}
+	return ai
+}
+
+// grouper groups the alerts and sends the grouped notifications, with
+// group_wait and group_interval semantics similar to the Prometheus
+// Alertmanager.
+type grouper struct {
+	groupBy                []string
+	wait, interval, repeat time.Duration
+
+	notify  func(*alertinfo.AlertInfo)
+	resolve func(*alertinfo.AlertInfo)
+
+	mu     sync.Mutex
+	groups map[string]*alertGroup
+
+	// Used for testing.
+	timeNow func() time.Time
+}
+
+func newGrouper(c *configpb.Grouping, alertRepeat time.Duration, notify, resolve func(*alertinfo.AlertInfo)) *grouper {
+	gr := &grouper{
+		groupBy:  c.GetGroupBy(),
+		wait:     defaultGroupWait,
+		interval: defaultGroupInterval,
+		repeat:   alertRepeat,
+		notify:   notify,
+		resolve:  resolve,
+		groups:   make(map[string]*alertGroup),
+		timeNow:  time.Now,
+	}
+	if c.GroupWaitSec != nil {
+		gr.wait = time.Duration(c.GetGroupWaitSec()) * time.Second
+	}
+	if c.GroupIntervalSec != nil {
+		gr.interval = time.Duration(c.GetGroupIntervalSec()) * time.Second
+	}
+	if c.RepeatIntervalSec != nil {
+		gr.repeat = time.Duration(c.GetRepeatIntervalSec()) * time.Second
+	}
+	return gr
+}
+
+// groupKey returns the group key for the alert, based on the group_by fields.
+func (gr *grouper) groupKey(ai *alertinfo.AlertInfo) string {
+	fields := ai.Fields(nil)
+	for k, v := range ai.Labels {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+
+	parts := []string{ai.Name, ai.ProbeName}
+	for _, f := range gr.groupBy {
+		parts = append(parts, f+"="+fields[f])
+	}
+	return strings.Join(parts, "-")
+}
+
+// schedule schedules the group flush, if it's not already scheduled. It
+// should be called with the lock held.
+func (gr *grouper) schedule(g *alertGroup) {
+	if g.timer != nil {
+		return
+	}
+
+	delay := gr.wait
+	if g.notified {
+		delay = gr.interval - gr.timeNow().Sub(g.lastNotified)
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	g.timer = time.AfterFunc(delay, func() { gr.flush(g) })
+}
+
+// add adds a firing alert to its group.
+func (gr *grouper) add(ai *alertinfo.AlertInfo) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	key := gr.groupKey(ai)
+	g := gr.groups[key]
+	if g == nil {
+		g = &alertGroup{key: key, alerts: make(map[string]*alertinfo.AlertInfo)}
+		gr.groups[key] = g
+	}
+
+	if g.alerts[ai.Target.Key()] == nil {
+		g.changed = true
+	}
+	g.alerts[ai.Target.Key()] = ai
+
+	if g.changed {
+		gr.schedule(g)
+	}
+}
+
+// restore adds a restored, already notified, alert to its group without
+// triggering a notification.
+func (gr *grouper) restore(ai *alertinfo.AlertInfo, lastNotified time.Time) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	key := gr.groupKey(ai)
+	g := gr.groups[key]
+	if g == nil {
+		g = &alertGroup{key: key, alerts: make(map[string]*alertinfo.AlertInfo)}
+		gr.groups[key] = g
+	}
+	g.alerts[ai.Target.Key()] = ai
+	g.notified = true
+	if lastNotified.After(g.lastNotified) {
+		g.lastNotified = lastNotified
+	}
+	g.lastAlertInfo = g.alertInfo()
+}
+
+// refresh re-notifies the alert's group if it hasn't changed for the repeat
+// interval. It's called for the ongoing alerts.
+func (gr *grouper) refresh(ai *alertinfo.AlertInfo) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	g := gr.groups[gr.groupKey(ai)]
+	if g == nil || !g.notified || g.timer != nil {
+		return
+	}
+	if gr.timeNow().Sub(g.lastNotified) >= gr.repeat {
+		g.changed = true
+		gr.schedule(g)
+	}
+}
+
+// remove removes a resolved alert from its group. Group is resolved once all
+// its alerts are resolved.
+func (gr *grouper) remove(ai *alertinfo.AlertInfo) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	g := gr.groups[gr.groupKey(ai)]
+	if g == nil || g.alerts[ai.Target.Key()] == nil {
+		return
+	}
+	delete(g.alerts, ai.Target.Key())
+	g.changed = true
+	gr.schedule(g)
+}
+
+func (gr *grouper) flush(g *alertGroup) {
+	gr.mu.Lock()
+	g.timer = nil
+
+	if len(g.alerts) == 0 {
+		delete(gr.groups, g.key)
+		gr.mu.Unlock()
+		if g.notified {
+			gr.resolve(g.lastAlertInfo)
+		}
+		return
+	}
+
+	if !g.changed {
+		gr.mu.Unlock()
+		return
+	}
+	ai := g.alertInfo()
+	g.notified, g.changed = true, false
+	g.lastNotified = gr.timeNow()
+	g.lastAlertInfo = ai
+	gr.mu.Unlock()
+
+	gr.notify(ai)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testGroupAlert(target, region string) *alertinfo.AlertInfo {
+	return &alertinfo.AlertInfo{
+		Name:      "test-alert",
+		ProbeName: "test-probe",
+		Target:    endpoint.Endpoint{Name: target, Labels: map[string]string{"region": region}},
+		Failures:  1,
+		Total:     1,
+	}
+}
+
+func waitAlert(t *testing.T, ch chan *alertinfo.AlertInfo) *alertinfo.AlertInfo {
+	t.Helper()
+	select {
+	case ai := <-ch:
+		return ai
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the notification")
+	}
+	return nil
+}
+
+func targetNames(ai *alertinfo.AlertInfo) []string {
+	var names []string
+	for _, ep := range ai.Targets {
+		names = append(names, ep.Name)
+	}
+	return names
+}
+
+func TestGrouper(t *testing.T) {
+	notifyCh := make(chan *alertinfo.AlertInfo, 10)
+	resolveCh := make(chan *alertinfo.AlertInfo, 10)
+
+	gr := newGrouper(&configpb.Grouping{
+		GroupBy:          []string{"target.label.region"},
+		GroupWaitSec:     proto.Int32(0),
+		GroupIntervalSec: proto.Int32(0),
+	}, time.Hour, func(ai *alertinfo.AlertInfo) { notifyCh <- ai }, func(ai *alertinfo.AlertInfo) { resolveCh <- ai })
+	gr.wait = 50 * time.Millisecond
+	gr.interval = 50 * time.Millisecond
+
+	gr.add(testGroupAlert("t1", "us"))
+	gr.add(testGroupAlert("t2", "us"))
+	gr.add(testGroupAlert("t3", "eu"))
+
+	got := map[string]*alertinfo.AlertInfo{}
+	for i := 0; i < 2; i++ {
+		ai := waitAlert(t, notifyCh)
+		got[ai.Target.Name] = ai
+	}
+	assert.Equal(t, []string{"t1", "t2"}, targetNames(got["2 targets"]))
+	assert.Equal(t, 2, got["2 targets"].Failures)
+	assert.Equal(t, []string{"t3"}, targetNames(got["t3"]))
+	assert.NotEqual(t, got["2 targets"].DeduplicationID, got["t3"].DeduplicationID)
+
+	// Existing alert doesn't trigger a notification.
+	gr.add(testGroupAlert("t1", "us"))
+	gr.refresh(testGroupAlert("t1", "us"))
+
+	// Resolve one target in the group.
+	gr.remove(testGroupAlert("t1", "us"))
+	ai := waitAlert(t, notifyCh)
+	assert.Equal(t, []string{"t2"}, targetNames(ai))
+	assert.Len(t, notifyCh, 0)
+
+	// Resolve the last target.
+	gr.remove(testGroupAlert("t2", "us"))
+	ai = waitAlert(t, resolveCh)
+	assert.Equal(t, []string{"t2"}, targetNames(ai))
+	assert.Len(t, notifyCh, 0)
+
+	// Repeat notification for the unchanged group.
+	gr.timeNow = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	gr.refresh(testGroupAlert("t3", "eu"))
+	ai = waitAlert(t, notifyCh)
+	assert.Equal(t, []string{"t3"}, targetNames(ai))
+}
+
+func TestGroupDetails(t *testing.T) {
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Name:     "test-alert",
+		Grouping: &configpb.Grouping{},
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+	ah.grouper.wait = 10 * time.Millisecond
+	ah.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+
+	ah.grouper.add(testGroupAlert("t1", "us"))
+	ah.grouper.add(testGroupAlert("t2", "eu"))
+
+	ai := waitAlert(t, ah.notifyCh)
+	fields := ai.Fields(nil)
+	assert.Equal(t, "2 targets", fields["target"])
+	assert.Equal(t, "t1, t2", fields["targets"])
+	assert.Equal(t, "2", fields["num_targets"])
+}
@@ -0,0 +1,300 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const defaultHistorySize = 1000
+
+// Alert event types.
+const (
+	EventFired        = "fired"
+	EventResolved     = "resolved"
+	EventEscalated    = "escalated"
+	EventAcknowledged = "acknowledged"
+)
+
+// AlertEvent is an alert state transition, recorded in the alerts history.
+type AlertEvent struct {
+	Time            time.Time `json:"time"`
+	Type            string    `json:"type"`
+	Alert           string    `json:"alert"`
+	Probe           string    `json:"probe"`
+	Target          string    `json:"target"`
+	Severity        string    `json:"severity,omitempty"`
+	DeduplicationID string    `json:"deduplication_id,omitempty"`
+	Details         string    `json:"details,omitempty"`
+}
+
+func severityString(sev configpb.AlertConf_Severity) string {
+	if sev == configpb.AlertConf_UNKNOWN_SEVERITY {
+		return ""
+	}
+	return sev.String()
+}
+
+// history is a bounded history of the alert events, optionally backed by a
+// file with one JSON event per line.
+type history struct {
+	mu     sync.Mutex
+	events []*AlertEvent // Oldest first.
+	size   int
+
+	file     string
+	appended int // Events appended to the file since it was last rewritten.
+	l        *logger.Logger
+}
+
+var globalHistory = &history{size: defaultHistorySize}
+
+func (h *history) init(opts *configpb.AlertingOptions, l *logger.Logger) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.l = l
+	if opts != nil && opts.HistorySize != nil {
+		h.size = int(opts.GetHistorySize())
+	}
+	h.file = opts.GetHistoryFile()
+	if h.file == "" {
+		return nil
+	}
+
+	f, err := os.Open(h.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error opening alerts history file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ev := &AlertEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), ev); err != nil {
+			l.Warningf("Skipping invalid alerts history line (%s): %v", scanner.Text(), err)
+			continue
+		}
+		h.events = append(h.events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading alerts history file: %v", err)
+	}
+	h.trim()
+	h.rewrite()
+	return nil
+}
+
+// trim drops the oldest events beyond the history size. It should be called
+// with the lock held.
+func (h *history) trim() {
+	if len(h.events) > h.size {
+		h.events = append([]*AlertEvent{}, h.events[len(h.events)-h.size:]...)
+	}
+}
+
+// rewrite rewrites the history file with the current events. It should be
+// called with the lock held.
+func (h *history) rewrite() {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range h.events {
+		enc.Encode(ev)
+	}
+	if err := (&FileStore{Path: h.file}).Save(buf.Bytes()); err != nil {
+		h.l.Errorf("Error writing alerts history file: %v", err)
+	}
+	h.appended = 0
+}
+
+// append appends the event to the history file, rewriting the file once
+// it has grown to twice the history size. It should be called with the lock
+// held.
+func (h *history) append(ev *AlertEvent) {
+	if h.appended >= h.size {
+		h.rewrite()
+		return
+	}
+
+	data, err := json.Marshal(ev)
+	if err == nil {
+		var f *os.File
+		if f, err = os.OpenFile(h.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
+			_, err = f.Write(append(data, '\n'))
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		h.l.Errorf("Error appending to alerts history file: %v", err)
+	}
+	h.appended++
+}
+
+func (h *history) record(ai *alertinfo.AlertInfo, typ, severity, details string) {
+	ev := &AlertEvent{
+		Time:            time.Now().Truncate(time.Second),
+		Type:            typ,
+		Alert:           ai.Name,
+		Probe:           ai.ProbeName,
+		Target:          ai.Target.Name,
+		Severity:        severity,
+		DeduplicationID: ai.DeduplicationID,
+		Details:         details,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.size <= 0 {
+		return
+	}
+	h.events = append(h.events, ev)
+	h.trim()
+	if h.file != "" {
+		h.append(ev)
+	}
+}
+
+// historyFilter filters the history events. Empty fields match everything.
+type historyFilter struct {
+	Alert, Probe, Target, Severity, Type string
+	Limit                                int
+}
+
+func parseHistoryFilter(q url.Values) historyFilter {
+	f := historyFilter{
+		Alert:    q.Get("alert"),
+		Probe:    q.Get("probe"),
+		Target:   q.Get("target"),
+		Severity: q.Get("severity"),
+		Type:     q.Get("type"),
+	}
+	f.Limit, _ = strconv.Atoi(q.Get("limit"))
+	return f
+}
+
+func (f *historyFilter) match(ev *AlertEvent) bool {
+	for _, m := range [][2]string{
+		{f.Alert, ev.Alert},
+		{f.Probe, ev.Probe},
+		{f.Target, ev.Target},
+		{f.Severity, ev.Severity},
+		{f.Type, ev.Type},
+	} {
+		if m[0] != "" && m[0] != m[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// list returns the events matching the filter, newest first.
+func (h *history) list(f historyFilter) []*AlertEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var result []*AlertEvent
+	for i := len(h.events) - 1; i >= 0; i-- {
+		if f.Limit > 0 && len(result) >= f.Limit {
+			break
+		}
+		if f.match(h.events[i]) {
+			result = append(result, h.events[i])
+		}
+	}
+	return result
+}
+
+var historyTmpl = template.Must(template.New("history").Parse(`
+<h3>Alerts History:</h3>
+<form method="GET">
+  Probe: <input type="text" name="probe" value="{{ .Filter.Probe }}">
+  Target: <input type="text" name="target" value="{{ .Filter.Target }}">
+  Severity: <input type="text" name="severity" value="{{ .Filter.Severity }}">
+  <input type="submit" value="Filter">
+</form>
+{{ if not .Events }}
+  <p>No alert events.</p>
+{{ else }}
+<table class="status-list">
+<tr>
+  <th>Time</th>
+  <th>Event</th>
+  <th>Alert</th>
+  <th>Probe</th>
+  <th>Target</th>
+  <th>Severity</th>
+  <th>Details</th>
+</tr>
+{{ range .Events }}
+<tr>
+  <td>{{ .Time }}</td>
+  <td>{{ .Type }}</td>
+  <td>{{ .Alert }}</td>
+  <td>{{ .Probe }}</td>
+  <td>{{ .Target }}</td>
+  <td>{{ .Severity }}</td>
+  <td>{{ .Details }}</td>
+</tr>
+{{- end }}
+</table>
+{{- end }}
+`))
+
+func (h *history) html(f historyFilter) (string, error) {
+	var buf bytes.Buffer
+	err := historyTmpl.Execute(&buf, struct {
+		Filter historyFilter
+		Events []*AlertEvent
+	}{
+		Filter: f,
+		Events: h.list(f),
+	})
+	return buf.String(), err
+}
+
+// HistoryHTML returns the alerts history page, filtered by the query
+// parameters: alert, probe, target, severity, type and limit.
+func HistoryHTML(q url.Values) (string, error) {
+	return globalHistory.html(parseHistoryFilter(q))
+}
+
+// HistoryHandler serves the alerts history as JSON, filtered by the query
+// parameters: alert, probe, target, severity, type and limit.
+func HistoryHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(globalHistory.list(parseHistoryFilter(r.URL.Query())))
+}
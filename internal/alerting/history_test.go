@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func eventTargets(events []*AlertEvent) []string {
+	var targets []string
+	for _, ev := range events {
+		targets = append(targets, ev.Target)
+	}
+	return targets
+}
+
+func TestHistory(t *testing.T) {
+	h := &history{size: 3}
+	for i, sev := range []string{"CRITICAL", "", "WARNING", "CRITICAL"} {
+		ai := &alertinfo.AlertInfo{Name: "a1", ProbeName: "p1", Target: endpoint.Endpoint{Name: fmt.Sprintf("t%d", i)}}
+		h.record(ai, EventFired, sev, "")
+	}
+
+	tests := []struct {
+		filter historyFilter
+		want   []string
+	}{
+		{filter: historyFilter{}, want: []string{"t3", "t2", "t1"}},
+		{filter: historyFilter{Severity: "CRITICAL"}, want: []string{"t3"}},
+		{filter: historyFilter{Target: "t2"}, want: []string{"t2"}},
+		{filter: historyFilter{Probe: "p2"}, want: nil},
+		{filter: historyFilter{Limit: 2}, want: []string{"t3", "t2"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", tt.filter), func(t *testing.T) {
+			assert.Equal(t, tt.want, eventTargets(h.list(tt.filter)))
+		})
+	}
+
+	html, err := h.html(historyFilter{Target: "t2"})
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<td>t2</td>")
+	assert.NotContains(t, html, "<td>t3</td>")
+}
+
+func TestHistoryFile(t *testing.T) {
+	opts := &configpb.AlertingOptions{
+		HistorySize: proto.Int32(2),
+		HistoryFile: proto.String(filepath.Join(t.TempDir(), "history.jsonl")),
+	}
+	l := &logger.Logger{}
+
+	h := &history{}
+	assert.NoError(t, h.init(opts, l))
+	for i := 0; i < 5; i++ {
+		h.record(&alertinfo.AlertInfo{Name: "a1", Target: endpoint.Endpoint{Name: fmt.Sprintf("t%d", i)}}, EventFired, "", "")
+	}
+
+	// File is rewritten once it has grown to twice the history size.
+	data, err := os.ReadFile(opts.GetHistoryFile())
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(strings.Split(strings.TrimSpace(string(data)), "\n")), 4)
+
+	h = &history{}
+	assert.NoError(t, h.init(opts, l))
+	assert.Equal(t, []string{"t4", "t3"}, eventTargets(h.list(historyFilter{})))
+}
+
+func TestAlertHistory(t *testing.T) {
+	oldHistory := globalHistory
+	globalHistory = &history{size: 10}
+	defer func() { globalHistory = oldHistory }()
+
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Name:     "history-test",
+		Severity: configpb.AlertConf_WARNING,
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+	ah.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+
+	ep := endpoint.Endpoint{Name: "target1"}
+	for _, v := range [][2]int64{{1, 1}, {2, 1}, {3, 1}, {4, 4}} {
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(v[0])).
+			AddMetric("success", metrics.NewInt(v[1]))
+		ah.Record(ep, em)
+	}
+
+	var types []string
+	for _, ev := range globalHistory.list(historyFilter{Alert: "history-test"}) {
+		assert.Equal(t, "WARNING", ev.Severity)
+		types = append(types, ev.Type)
+	}
+	assert.Equal(t, []string{EventResolved, EventFired}, types)
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+const defaultLatencyMetricName = "latency"
+
+// percentile returns the estimated percentile (0-100) from the distribution
+// data, interpolating linearly within the bucket, similar to Prometheus's
+// histogram_quantile.
+func percentile(d *metrics.DistributionData, p float64) float64 {
+	if d.Count == 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(d.Count)
+	var cumulative int64
+	for i, c := range d.BucketCounts {
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+
+		// For the first bucket (-Inf lower bound), we return the upper bound,
+		// and for the last bucket (+Inf upper bound), we return the lower
+		// bound.
+		if i == len(d.BucketCounts)-1 {
+			return d.LowerBounds[i]
+		}
+		upper := d.LowerBounds[i+1]
+		if i == 0 {
+			return upper
+		}
+		lower := d.LowerBounds[i]
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(c)
+	}
+	return d.LowerBounds[len(d.LowerBounds)-1]
+}
+
+// intervalLatency returns the latency statistic for the interval between the
+// last and the current latency metric values, in the latency unit. It returns
+// NaN if there were no successful probes in the interval.
+func intervalLatency(lc *configpb.LatencyCondition, cur, last metrics.Value, successCnt int64) (float64, error) {
+	switch v := cur.(type) {
+	case *metrics.Distribution:
+		d := v.CloneDist()
+		if last != nil {
+			if _, err := d.SubtractCounter(last); err != nil {
+				return 0, err
+			}
+		}
+		data := d.Data()
+		if data.Count == 0 {
+			return math.NaN(), nil
+		}
+		if lc.Percentile != nil {
+			return percentile(data, lc.GetPercentile()), nil
+		}
+		return data.Sum / float64(data.Count), nil
+
+	case metrics.NumValue:
+		if lc.Percentile != nil {
+			return 0, errors.New("latency percentile requires latency distribution")
+		}
+		if successCnt <= 0 {
+			return math.NaN(), nil
+		}
+		lastVal := 0.0
+		if last != nil {
+			lastVal = last.(metrics.NumValue).Float64()
+		}
+		return (v.Float64() - lastVal) / float64(successCnt), nil
+	}
+
+	return 0, fmt.Errorf("unsupported latency metric type: %T", cur)
+}
+
+// latencyBreached returns whether the interval latency is above the
+// threshold. Intervals with no successful probes are not considered breached.
+func latencyBreached(lc *configpb.LatencyCondition, cur, last metrics.Value, successCnt int64, unit time.Duration) (bool, error) {
+	latency, err := intervalLatency(lc, cur, last, successCnt)
+	if err != nil || math.IsNaN(latency) {
+		return false, err
+	}
+	if unit == 0 {
+		unit = time.Microsecond
+	}
+	return latency*float64(unit) > lc.GetThresholdMsec()*float64(time.Millisecond), nil
+}
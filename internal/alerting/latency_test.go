@@ -0,0 +1,159 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPercentile(t *testing.T) {
+	// Buckets: (-Inf, 0), [0, 100), [100, 200), [200, 400), [400, +Inf)
+	d := metrics.NewDistribution([]float64{0, 100, 200, 400})
+	for _, s := range []float64{50, 50, 150, 150, 150, 150, 300, 300, 300, 500} {
+		d.AddSample(s)
+	}
+
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{p: 10, want: 50},
+		{p: 20, want: 100},
+		{p: 40, want: 150},
+		{p: 90, want: 400},
+		{p: 99, want: 400},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, percentile(d.Data(), tt.p), 0.001, "p%v", tt.p)
+	}
+
+	assert.True(t, math.IsNaN(percentile(metrics.NewDistribution([]float64{1}).Data(), 50)))
+}
+
+func TestIntervalLatency(t *testing.T) {
+	last := metrics.NewDistribution([]float64{0, 100, 200})
+	last.AddSample(50)
+	cur := last.CloneDist()
+	cur.AddSample(150)
+	cur.AddSample(250)
+
+	tests := []struct {
+		name       string
+		lc         *configpb.LatencyCondition
+		cur, last  metrics.Value
+		successCnt int64
+		want       float64
+		wantErr    bool
+	}{
+		{
+			name: "dist_avg",
+			lc:   &configpb.LatencyCondition{},
+			cur:  cur,
+			last: last,
+			want: 200,
+		},
+		{
+			name: "dist_percentile",
+			lc:   &configpb.LatencyCondition{Percentile: proto.Float64(50)},
+			cur:  cur,
+			last: last,
+			want: 200,
+		},
+		{
+			name:       "float_avg",
+			lc:         &configpb.LatencyCondition{},
+			cur:        metrics.NewFloat(1000),
+			last:       metrics.NewFloat(400),
+			successCnt: 3,
+			want:       200,
+		},
+		{
+			name:       "float_no_success",
+			lc:         &configpb.LatencyCondition{},
+			cur:        metrics.NewFloat(1000),
+			last:       metrics.NewFloat(1000),
+			successCnt: 0,
+			want:       math.NaN(),
+		},
+		{
+			name:       "float_percentile",
+			lc:         &configpb.LatencyCondition{Percentile: proto.Float64(99)},
+			cur:        metrics.NewFloat(1000),
+			successCnt: 1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := intervalLatency(tt.lc, tt.cur, tt.last, tt.successCnt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if math.IsNaN(tt.want) {
+				assert.True(t, math.IsNaN(got), "got %v, want NaN", got)
+				return
+			}
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
+
+func TestLatencyAlert(t *testing.T) {
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Condition: &configpb.Condition{
+			Failures: 2,
+			Total:    3,
+			Latency:  &configpb.LatencyCondition{ThresholdMsec: 100},
+		},
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+	ah.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+
+	ep := endpoint.Endpoint{Name: "target1"}
+	var total, latency float64
+	record := func(intervalLatencyMs float64) {
+		total++
+		latency += intervalLatencyMs
+		em := metrics.NewEventMetrics(time.Time{}.Add(time.Duration(total)*time.Second)).
+			AddMetric("total", metrics.NewInt(int64(total))).
+			AddMetric("success", metrics.NewInt(int64(total))).
+			AddMetric("latency", metrics.NewFloat(latency))
+		em.LatencyUnit = time.Millisecond
+		ah.Record(ep, em)
+	}
+
+	record(0)   // Initial state.
+	record(150) // Breached.
+	record(50)
+	assert.False(t, ah.targets[ep.Key()].alerted)
+	record(200) // Breached, 2 of last 3 intervals.
+	assert.True(t, ah.targets[ep.Key()].alerted)
+	assert.Len(t, ah.notifyCh, 1)
+
+	record(50)
+	record(50) // 1 of last 3 intervals.
+	assert.False(t, ah.targets[ep.Key()].alerted)
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alertmanager implements forwarding of Cloudprober alerts to the
+// Prometheus Alertmanager.
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/strtemplate"
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const (
+	alertsAPIPath         = "/api/v2/alerts"
+	defaultResendInterval = 60 * time.Second
+	requestTimeout        = 10 * time.Second
+)
+
+var labelNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// alert is the alert format of the Alertmanager v2 API.
+type alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// Client is an Alertmanager client.
+type Client struct {
+	urls           []string
+	labels         map[string]string
+	header         map[string]string
+	resendInterval time.Duration
+	httpClient     *http.Client
+	l              *logger.Logger
+
+	mu       sync.Mutex
+	active   map[string]*alert
+	resender bool
+
+	// Used for testing.
+	timeNow func() time.Time
+}
+
+// New creates a new Alertmanager client.
+func New(amcfg *configpb.Alertmanager, l *logger.Logger) (*Client, error) {
+	if len(amcfg.GetUrl()) == 0 {
+		return nil, errors.New("at least one alertmanager url is required")
+	}
+
+	for k := range amcfg.GetLabels() {
+		if !labelNameRe.MatchString(k) {
+			return nil, fmt.Errorf("invalid label name: %s", k)
+		}
+	}
+
+	c := &Client{
+		labels:         amcfg.GetLabels(),
+		header:         amcfg.GetHeader(),
+		resendInterval: defaultResendInterval,
+		httpClient:     &http.Client{Timeout: requestTimeout},
+		l:              l,
+		active:         make(map[string]*alert),
+		timeNow:        time.Now,
+	}
+	for _, u := range amcfg.GetUrl() {
+		c.urls = append(c.urls, strings.TrimSuffix(u, "/")+alertsAPIPath)
+	}
+	if amcfg.GetResendIntervalSec() > 0 {
+		c.resendInterval = time.Duration(amcfg.GetResendIntervalSec()) * time.Second
+	}
+
+	return c, nil
+}
+
+// newAlert creates an Alertmanager alert from the alert info and fields.
+func (c *Client) newAlert(alertInfo *alertinfo.AlertInfo, alertFields map[string]string) *alert {
+	a := &alert{
+		Labels: map[string]string{
+			"alertname": alertInfo.Name,
+			"probe":     alertInfo.ProbeName,
+			"target":    alertInfo.Target.Dst(),
+		},
+		Annotations:  make(map[string]string),
+		StartsAt:     alertInfo.FailingSince,
+		GeneratorURL: alertFields["dashboard_url"],
+	}
+	if sev := alertFields["severity"]; sev != "" {
+		a.Labels["severity"] = strings.ToLower(sev)
+	}
+
+	for k, v := range alertInfo.Labels {
+		if _, ok := a.Labels[k]; ok || !labelNameRe.MatchString(k) {
+			continue
+		}
+		a.Labels[k] = v
+	}
+
+	for k, v := range c.labels {
+		val, ok := strtemplate.SubstituteLabels(v, alertFields)
+		if !ok {
+			c.l.Warningf("Couldn't expand alertmanager label %s (%s), skipping it", k, v)
+			continue
+		}
+		if val != "" {
+			a.Labels[k] = val
+		}
+	}
+
+	for k, field := range map[string]string{
+		"summary":     "summary",
+		"description": "details",
+		"dashboard":   "dashboard_url",
+		"runbook_url": "playbook_url",
+	} {
+		if v := alertFields[field]; v != "" {
+			a.Annotations[k] = v
+		}
+	}
+
+	return a
+}
+
+func (c *Client) post(ctx context.Context, alerts []*alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, url := range c.urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range c.header {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error sending alerts to %s: %v", url, err))
+			continue
+		}
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			errs = errors.Join(errs, fmt.Errorf("alertmanager (%s) returned error; statusCode: %d, response: %s", url, resp.StatusCode, string(b)))
+		}
+	}
+	return errs
+}
+
+// resendLoop re-sends the active alerts at the resend interval, until there
+// are no active alerts.
+func (c *Client) resendLoop() {
+	ticker := time.NewTicker(c.resendInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.mu.Lock()
+		if len(c.active) == 0 {
+			c.resender = false
+			c.mu.Unlock()
+			return
+		}
+		var alerts []*alert
+		for _, a := range c.active {
+			a.EndsAt = c.timeNow().Add(4 * c.resendInterval)
+			aCopy := *a
+			alerts = append(alerts, &aCopy)
+		}
+		c.mu.Unlock()
+
+		if err := c.post(context.Background(), alerts); err != nil {
+			c.l.Errorf("Error re-sending alerts to alertmanager: %v", err)
+		}
+	}
+}
+
+// Notify sends a firing alert to the Alertmanager, and keeps re-sending it
+// until it's resolved.
+func (c *Client) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	a := c.newAlert(alertInfo, alertFields)
+	a.EndsAt = c.timeNow().Add(4 * c.resendInterval)
+
+	c.mu.Lock()
+	c.active[alertInfo.DeduplicationID] = a
+	aCopy := *a
+	if !c.resender {
+		c.resender = true
+		go c.resendLoop()
+	}
+	c.mu.Unlock()
+
+	return c.post(ctx, []*alert{&aCopy})
+}
+
+// NotifyResolve sends a resolved alert to the Alertmanager.
+func (c *Client) NotifyResolve(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	c.mu.Lock()
+	a := c.active[alertInfo.DeduplicationID]
+	delete(c.active, alertInfo.DeduplicationID)
+	c.mu.Unlock()
+
+	if a == nil {
+		a = c.newAlert(alertInfo, alertFields)
+	}
+	a.EndsAt = c.timeNow()
+
+	return c.post(ctx, []*alert{a})
+}
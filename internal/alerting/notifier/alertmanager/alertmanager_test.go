@@ -0,0 +1,155 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+type testServer struct {
+	mu       sync.Mutex
+	requests [][]*alert
+	auth     string
+}
+
+func (ts *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if r.URL.Path != alertsAPIPath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var alerts []*alert
+	json.NewDecoder(r.Body).Decode(&alerts)
+	ts.requests = append(ts.requests, alerts)
+	ts.auth = r.Header.Get("Authorization")
+}
+
+func (ts *testServer) numRequests() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return len(ts.requests)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&configpb.Alertmanager{}, nil)
+	assert.Error(t, err)
+
+	_, err = New(&configpb.Alertmanager{Url: []string{"http://am:9093"}, Labels: map[string]string{"bad-label": "x"}}, nil)
+	assert.Error(t, err)
+
+	c, err := New(&configpb.Alertmanager{Url: []string{"http://am1:9093/", "http://am2:9093"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://am1:9093/api/v2/alerts", "http://am2:9093/api/v2/alerts"}, c.urls)
+	assert.Equal(t, defaultResendInterval, c.resendInterval)
+}
+
+func TestNotify(t *testing.T) {
+	ts1, ts2 := &testServer{}, &testServer{}
+	srv1, srv2 := httptest.NewServer(ts1), httptest.NewServer(ts2)
+	defer srv1.Close()
+	defer srv2.Close()
+
+	c, err := New(&configpb.Alertmanager{
+		Url:               []string{srv1.URL, srv2.URL},
+		Labels:            map[string]string{"team": "@target.label.team@", "owner": "@target.label.owner@"},
+		Header:            map[string]string{"Authorization": "Bearer test-token"},
+		ResendIntervalSec: 3600,
+	}, nil)
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	c.timeNow = func() time.Time { return now }
+
+	alertInfo := &alertinfo.AlertInfo{
+		Name:            "test-alert",
+		ProbeName:       "test-probe",
+		Target:          endpoint.Endpoint{Name: "test-target"},
+		FailingSince:    now.Add(-time.Minute),
+		DeduplicationID: "dedup-id",
+	}
+	fields := map[string]string{
+		"summary":           "test-summary",
+		"details":           "test-details",
+		"severity":          "CRITICAL",
+		"dashboard_url":     "http://localhost:9313/status?probe=test-probe",
+		"target.label.team": "sre",
+	}
+
+	assert.NoError(t, c.Notify(context.Background(), alertInfo, fields))
+	assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, fields))
+
+	wantFiring := &alert{
+		Labels: map[string]string{
+			"alertname": "test-alert",
+			"probe":     "test-probe",
+			"target":    "test-target",
+			"severity":  "critical",
+			"team":      "sre",
+		},
+		Annotations: map[string]string{
+			"summary":     "test-summary",
+			"description": "test-details",
+			"dashboard":   "http://localhost:9313/status?probe=test-probe",
+		},
+		StartsAt:     now.Add(-time.Minute),
+		EndsAt:       now.Add(4 * time.Hour),
+		GeneratorURL: "http://localhost:9313/status?probe=test-probe",
+	}
+	wantResolved := *wantFiring
+	wantResolved.EndsAt = now
+
+	for _, ts := range []*testServer{ts1, ts2} {
+		assert.Equal(t, [][]*alert{{wantFiring}, {&wantResolved}}, ts.requests)
+		assert.Equal(t, "Bearer test-token", ts.auth)
+	}
+	assert.Empty(t, c.active)
+}
+
+func TestResend(t *testing.T) {
+	ts := &testServer{}
+	srv := httptest.NewServer(ts)
+	defer srv.Close()
+
+	c, err := New(&configpb.Alertmanager{Url: []string{srv.URL}}, nil)
+	assert.NoError(t, err)
+	c.resendInterval = 10 * time.Millisecond
+
+	alertInfo := &alertinfo.AlertInfo{Name: "test-alert", DeduplicationID: "dedup-id"}
+	assert.NoError(t, c.Notify(context.Background(), alertInfo, nil))
+
+	// Wait for the resends.
+	assert.Eventually(t, func() bool { return ts.numRequests() >= 3 }, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, nil))
+
+	// Resend loop exits once there are no active alerts.
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return !c.resender
+	}, time.Second, 5*time.Millisecond)
+}
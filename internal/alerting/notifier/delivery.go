@@ -0,0 +1,163 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+)
+
+// DeliveryStats contains the cumulative delivery stats of a notifier.
+type DeliveryStats struct {
+	Success int64
+	Failure int64
+	Latency time.Duration // Total time spent in the deliveries.
+	Retries int64         // Always 0 for the notifiers that don't retry.
+}
+
+type deliveryStats struct {
+	mu    sync.Mutex
+	stats map[string]*DeliveryStats // Keyed by notifier name.
+}
+
+func (ds *deliveryStats) update(name string, success bool, latency time.Duration, retries int) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.stats == nil {
+		ds.stats = make(map[string]*DeliveryStats)
+	}
+	s := ds.stats[name]
+	if s == nil {
+		s = &DeliveryStats{}
+		ds.stats[name] = s
+	}
+	if success {
+		s.Success++
+	} else {
+		s.Failure++
+	}
+	s.Latency += latency
+	s.Retries += int64(retries)
+}
+
+// addTo adds the stats to the given map.
+func (ds *deliveryStats) addTo(m map[string]DeliveryStats) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	for name, s := range ds.stats {
+		ms := m[name]
+		ms.Success += s.Success
+		ms.Failure += s.Failure
+		ms.Latency += s.Latency
+		ms.Retries += s.Retries
+		m[name] = ms
+	}
+}
+
+// DeliveryStats returns the delivery stats by notifier name (e.g. "slack",
+// "webhook"), including the notifiers configured in routes.
+func (n *Notifier) DeliveryStats() map[string]DeliveryStats {
+	stats := make(map[string]DeliveryStats)
+	for _, nn := range append([]*Notifier{n}, n.routes...) {
+		nn.stats.addTo(stats)
+	}
+	return stats
+}
+
+// DeadLetter is an undeliverable notification, written to the dead-letter
+// file.
+type DeadLetter struct {
+	Time            time.Time         `json:"time"`
+	Notifier        string            `json:"notifier"`
+	Status          string            `json:"status"`
+	Alert           string            `json:"alert"`
+	Probe           string            `json:"probe"`
+	Target          string            `json:"target"`
+	DeduplicationID string            `json:"deduplication_id,omitempty"`
+	Error           string            `json:"error"`
+	Fields          map[string]string `json:"fields"`
+}
+
+var deadLetters struct {
+	mu   sync.Mutex
+	file string
+}
+
+// SetDeadLetterFile sets the file to write the undeliverable notifications
+// to, one JSON object per line.
+func SetDeadLetterFile(path string) {
+	deadLetters.mu.Lock()
+	defer deadLetters.mu.Unlock()
+	deadLetters.file = path
+}
+
+func (n *Notifier) writeDeadLetter(dl *DeadLetter) {
+	deadLetters.mu.Lock()
+	defer deadLetters.mu.Unlock()
+
+	if deadLetters.file == "" {
+		return
+	}
+
+	data, err := json.Marshal(dl)
+	if err == nil {
+		var f *os.File
+		if f, err = os.OpenFile(deadLetters.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err == nil {
+			_, err = f.Write(append(data, '\n'))
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		n.l.Errorf("Error writing to the dead-letter file (%s): %v", deadLetters.file, err)
+	}
+}
+
+// deliver runs the delivery function for the named notifier, updating the
+// delivery stats and writing the notification to the dead-letter file if the
+// delivery fails.
+func (n *Notifier) deliver(name, status string, alertInfo *alertinfo.AlertInfo, fields map[string]string, f func() error) error {
+	return n.deliverWithRetries(name, status, alertInfo, fields, func() (int, error) { return 0, f() })
+}
+
+// deliverWithRetries is like deliver, for the notifiers that retry failed
+// deliveries. f returns the number of retries along with the error.
+func (n *Notifier) deliverWithRetries(name, status string, alertInfo *alertinfo.AlertInfo, fields map[string]string, f func() (int, error)) error {
+	start := time.Now()
+	retries, err := f()
+	n.stats.update(name, err == nil, time.Since(start), retries)
+
+	if err != nil {
+		n.writeDeadLetter(&DeadLetter{
+			Time:            start,
+			Notifier:        name,
+			Status:          status,
+			Alert:           alertInfo.Name,
+			Probe:           alertInfo.ProbeName,
+			Target:          alertInfo.Target.Dst(),
+			DeduplicationID: alertInfo.DeduplicationID,
+			Error:           err.Error(),
+			Fields:          fields,
+		})
+	}
+	return err
+}
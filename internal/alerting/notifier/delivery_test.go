@@ -0,0 +1,95 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryStatsAndDeadLetter(t *testing.T) {
+	deadLetterFile := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+	SetDeadLetterFile(deadLetterFile)
+	defer SetDeadLetterFile("")
+
+	n, err := New(&configpb.AlertConf{
+		Notify: &configpb.NotifyConfig{Command: "/non-existent-command"},
+		Route: []*configpb.NotifyConfig{
+			{Command: "/non-existent-command-2"},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	ai := &alertinfo.AlertInfo{
+		Name:            "test-alert",
+		ProbeName:       "test-probe",
+		Target:          endpoint.Endpoint{Name: "test-target"},
+		DeduplicationID: "dedup-id",
+	}
+	assert.Error(t, n.Notify(context.Background(), ai))
+
+	stats := n.DeliveryStats()
+	assert.Equal(t, int64(2), stats["command"].Failure)
+	assert.Equal(t, int64(0), stats["command"].Success)
+
+	data, err := os.ReadFile(deadLetterFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	var dl DeadLetter
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &dl))
+	assert.Equal(t, "command", dl.Notifier)
+	assert.Equal(t, alertinfo.StatusFiring, dl.Status)
+	assert.Equal(t, "test-alert", dl.Alert)
+	assert.Equal(t, "test-target", dl.Target)
+	assert.Equal(t, "dedup-id", dl.DeduplicationID)
+	assert.NotEmpty(t, dl.Error)
+	assert.Equal(t, "test-alert", dl.Fields["alert"])
+}
+
+func TestDeliveryStatsRetries(t *testing.T) {
+	var numRequests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		if numRequests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	n, err := New(&configpb.AlertConf{
+		Notify: &configpb.NotifyConfig{
+			Webhook: &configpb.Webhook{Url: ts.URL, InitialBackoffMsec: 1},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, n.Notify(context.Background(), &alertinfo.AlertInfo{Name: "test-alert"}))
+	stats := n.DeliveryStats()
+	assert.Equal(t, int64(1), stats["webhook"].Success)
+	assert.Equal(t, int64(1), stats["webhook"].Retries)
+}
@@ -16,10 +16,13 @@ package notifier
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/smtp"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cloudprober/cloudprober/common/strtemplate"
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
@@ -27,32 +30,77 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 )
 
+const smtpDialTimeout = 30 * time.Second
+
 type emailNotifier struct {
-	to           []string
-	from         string
-	server       string
-	auth         smtp.Auth
-	sendMailFunc func(string, smtp.Auth, string, []string, []byte) error
-	l            *logger.Logger
+	to                  []string
+	fallbackTo          []string
+	from                string
+	server              string
+	auth                smtp.Auth
+	tlsMode             configpb.Email_TLSMode
+	insecureSkipVerify  bool
+	subjectTmpl         string
+	bodyTmpl            string
+	disableSendResolved bool
+	sendMailFunc        func(string, smtp.Auth, string, []string, []byte) error
+	l                   *logger.Logger
 }
 
-func (en *emailNotifier) Notify(ctx context.Context, alertFields map[string]string) error {
+// recipients expands the "to" addresses using the alert fields. Addresses
+// that can't be fully expanded are replaced by the fallback addresses.
+func (en *emailNotifier) recipients(alertFields map[string]string) []string {
 	var to []string
+	seen := make(map[string]bool)
+	add := func(addrs ...string) {
+		for _, addr := range addrs {
+			if addr != "" && !seen[addr] {
+				seen[addr] = true
+				to = append(to, addr)
+			}
+		}
+	}
+
 	for _, t := range en.to {
-		newTo, _ := strtemplate.SubstituteLabels(t, alertFields)
-		to = append(to, newTo)
+		newTo, ok := strtemplate.SubstituteLabels(t, alertFields)
+		if !ok {
+			en.l.Warningf("Couldn't expand email recipient (%s), using fallback recipients: %v", t, en.fallbackTo)
+			add(en.fallbackTo...)
+			continue
+		}
+		add(newTo)
+	}
+	return to
+}
+
+func (en *emailNotifier) subjectAndBody(alertFields map[string]string) (string, string) {
+	subject := alertFields["summary"]
+	if en.subjectTmpl != "" {
+		subject, _ = strtemplate.SubstituteLabels(en.subjectTmpl, alertFields)
+	}
+
+	body := alertFields["details"] + "\nDetails:\n" + alertinfo.FieldsToString(alertFields, "summary", "details")
+	if en.bodyTmpl != "" {
+		body, _ = strtemplate.SubstituteLabels(en.bodyTmpl, alertFields)
+	}
+	return subject, body
+}
+
+func (en *emailNotifier) send(alertFields map[string]string, subject, body string) error {
+	to := en.recipients(alertFields)
+	if len(to) == 0 {
+		return fmt.Errorf("no email recipients after expanding: %v", en.to)
 	}
 
 	msg := fmt.Sprintf("From: %s\r\n", en.from)
 	msg += fmt.Sprintf("To: %s\r\n", strings.Join(to, ","))
-	msg += fmt.Sprintf("Subject: %s\r\n", alertFields["summary"])
-	msgBody := alertFields["details"] + "\nDetails:\n" + alertinfo.FieldsToString(alertFields, "summary", "details")
-	msg += fmt.Sprintf("\r\n%s\r\n", msgBody)
+	msg += fmt.Sprintf("Subject: %s\r\n", subject)
+	msg += fmt.Sprintf("\r\n%s\r\n", body)
 
-	en.l.Infof("Sending email notification to: %v \nserver: %s\nmsg:\n%s", en.to, en.server, msg)
+	en.l.Infof("Sending email notification to: %v \nserver: %s\nmsg:\n%s", to, en.server, msg)
 
 	if en.sendMailFunc == nil {
-		en.sendMailFunc = smtp.SendMail
+		en.sendMailFunc = en.sendMail
 	}
 	if err := en.sendMailFunc(en.server, en.auth, en.from, to, []byte(msg)); err != nil {
 		return fmt.Errorf("error while sending email notification: %v", err)
@@ -60,6 +108,91 @@ func (en *emailNotifier) Notify(ctx context.Context, alertFields map[string]stri
 	return nil
 }
 
+func (en *emailNotifier) Notify(ctx context.Context, alertFields map[string]string) error {
+	subject, body := en.subjectAndBody(alertFields)
+	return en.send(alertFields, subject, body)
+}
+
+func (en *emailNotifier) NotifyResolve(ctx context.Context, alertFields map[string]string) error {
+	if en.disableSendResolved {
+		return nil
+	}
+	subject, body := en.subjectAndBody(alertFields)
+	return en.send(alertFields, "[RESOLVED] "+subject, "Alert resolved.\n\n"+body)
+}
+
+// sendMail is similar to smtp.SendMail, but it supports implicit TLS,
+// mandatory STARTTLS and skipping certificate verification.
+func (en *emailNotifier) sendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		// Use the default port if server address doesn't have a port.
+		host = addr
+		if en.tlsMode == configpb.Email_TLS {
+			addr = net.JoinHostPort(host, "465")
+		} else {
+			addr = net.JoinHostPort(host, "25")
+		}
+	}
+	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: en.insecureSkipVerify}
+
+	var conn net.Conn
+	if en.tlsMode == configpb.Email_TLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: smtpDialTimeout}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, smtpDialTimeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if en.tlsMode == configpb.Email_STARTTLS || en.tlsMode == configpb.Email_STARTTLS_OPPORTUNISTIC {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		} else if en.tlsMode == configpb.Email_STARTTLS {
+			return fmt.Errorf("smtp server (%s) doesn't support STARTTLS", addr)
+		}
+	}
+
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server (%s) doesn't support AUTH", addr)
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
 func smtpPassword(user string, emailCfg *configpb.Email) (string, error) {
 	password := emailCfg.GetSmtpPassword()
 	if password == "" {
@@ -110,10 +243,16 @@ func newEmailNotifier(emailCfg *configpb.Email, l *logger.Logger) (*emailNotifie
 	}
 
 	en := &emailNotifier{
-		to:     emailCfg.To,
-		from:   emailFrom(user, emailCfg),
-		server: server,
-		l:      l,
+		to:                  emailCfg.To,
+		fallbackTo:          emailCfg.GetFallbackTo(),
+		from:                emailFrom(user, emailCfg),
+		server:              server,
+		tlsMode:             emailCfg.GetTlsMode(),
+		insecureSkipVerify:  emailCfg.GetInsecureSkipVerify(),
+		subjectTmpl:         emailCfg.GetSubjectTemplate(),
+		bodyTmpl:            emailCfg.GetBodyTemplate(),
+		disableSendResolved: emailCfg.GetDisableSendResolved(),
+		l:                   l,
 	}
 
 	if user != "" {
@@ -15,9 +15,12 @@
 package notifier
 
 import (
+	"bufio"
 	"context"
+	"net"
 	"net/smtp"
 	"os"
+	"strings"
 	"testing"
 
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
@@ -217,3 +220,121 @@ func TestEmaiFrom(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailNotifierTemplates(t *testing.T) {
+	alertFields := map[string]string{
+		"alert":    "test-alert",
+		"target":   "test-target",
+		"severity": "CRITICAL",
+		"summary":  "summary1",
+		"details":  "details1",
+	}
+
+	en := &emailNotifier{
+		to:          []string{"@target.label.owner@", "oncall@cloudprober.org"},
+		fallbackTo:  []string{"sre@cloudprober.org", "oncall@cloudprober.org"},
+		from:        "f@gmail.com",
+		subjectTmpl: "[@severity@] @alert@ failing for @target@",
+		bodyTmpl:    "@details@",
+	}
+
+	var gotTo []string
+	var gotMsg string
+	en.sendMailFunc = func(server string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotTo, gotMsg = to, string(msg)
+		return nil
+	}
+
+	assert.NoError(t, en.Notify(context.Background(), alertFields))
+	assert.Equal(t, []string{"sre@cloudprober.org", "oncall@cloudprober.org"}, gotTo)
+	assert.Equal(t, "From: f@gmail.com\r\nTo: sre@cloudprober.org,oncall@cloudprober.org\r\nSubject: [CRITICAL] test-alert failing for test-target\r\n\r\ndetails1\r\n", gotMsg)
+
+	assert.NoError(t, en.NotifyResolve(context.Background(), alertFields))
+	assert.Equal(t, "From: f@gmail.com\r\nTo: sre@cloudprober.org,oncall@cloudprober.org\r\nSubject: [RESOLVED] [CRITICAL] test-alert failing for test-target\r\n\r\nAlert resolved.\n\ndetails1\r\n", gotMsg)
+
+	gotMsg = ""
+	en.disableSendResolved = true
+	assert.NoError(t, en.NotifyResolve(context.Background(), alertFields))
+	assert.Equal(t, "", gotMsg)
+
+	// No recipients after expansion.
+	en.to, en.fallbackTo = []string{"@target.label.owner@"}, nil
+	assert.Error(t, en.Notify(context.Background(), alertFields))
+}
+
+// fakeSMTPServer implements a minimal SMTP server that doesn't support
+// STARTTLS or AUTH. It sends the received message on the returned channel.
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Error starting listener: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	msgCh := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				conn.Write([]byte("220 localhost ESMTP\r\n"))
+
+				var data []string
+				inData := false
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if inData {
+						if line == ".\r\n" {
+							inData = false
+							msgCh <- strings.Join(data, "")
+							conn.Write([]byte("250 OK\r\n"))
+						} else {
+							data = append(data, line)
+						}
+						continue
+					}
+					switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+					case "EHLO":
+						conn.Write([]byte("250-localhost\r\n250 8BITMIME\r\n"))
+					case "DATA":
+						inData = true
+						conn.Write([]byte("354 Go ahead\r\n"))
+					case "QUIT":
+						conn.Write([]byte("221 Bye\r\n"))
+						return
+					default:
+						conn.Write([]byte("250 OK\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String(), msgCh
+}
+
+func TestEmailNotifierSendMail(t *testing.T) {
+	addr, msgCh := fakeSMTPServer(t)
+
+	en := &emailNotifier{tlsMode: configpb.Email_NONE}
+	assert.NoError(t, en.sendMail(addr, nil, "f@gmail.com", []string{"a@cloudprober.org"}, []byte("Subject: test\r\n\r\nbody\r\n")))
+	assert.Equal(t, "Subject: test\r\n\r\nbody\r\n", <-msgCh)
+
+	// Opportunistic STARTTLS works without STARTTLS support.
+	en.tlsMode = configpb.Email_STARTTLS_OPPORTUNISTIC
+	assert.NoError(t, en.sendMail(addr, nil, "f@gmail.com", []string{"a@cloudprober.org"}, []byte("body\r\n")))
+	<-msgCh
+
+	// Mandatory STARTTLS fails.
+	en.tlsMode = configpb.Email_STARTTLS
+	assert.ErrorContains(t, en.sendMail(addr, nil, "f@gmail.com", []string{"a@cloudprober.org"}, []byte("body\r\n")), "STARTTLS")
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+)
+
+// Matcher matches an alert field against a value or a regex.
+type Matcher struct {
+	name   string
+	value  string
+	re     *regexp.Regexp
+	negate bool
+}
+
+// NewMatcher creates a new matcher from the config. Regex matchers must match
+// the whole field value.
+func NewMatcher(m *configpb.Matcher) (*Matcher, error) {
+	if m.GetName() == "" {
+		return nil, errors.New("matcher name is required")
+	}
+
+	nm := &Matcher{
+		name:   m.GetName(),
+		value:  m.GetValue(),
+		negate: m.GetNegate(),
+	}
+	if m.GetRegex() {
+		re, err := regexp.Compile("^(?:" + m.GetValue() + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex (%s) for matcher %s: %v", m.GetValue(), m.GetName(), err)
+		}
+		nm.re = re
+	}
+	return nm, nil
+}
+
+// Match returns true if the matcher matches the alert fields.
+func (m *Matcher) Match(fields map[string]string) bool {
+	v := fields[m.name]
+
+	var matched bool
+	if m.re != nil {
+		matched = m.re.MatchString(v)
+	} else {
+		matched = v == m.value
+	}
+	return matched != m.negate
+}
+
+// MatchAll returns true if all the matchers match the alert fields.
+func MatchAll(matchers []*Matcher, fields map[string]string) bool {
+	for _, m := range matchers {
+		if !m.Match(fields) {
+			return false
+		}
+	}
+	return true
+}
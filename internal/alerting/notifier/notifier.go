@@ -21,9 +21,14 @@ import (
 	"fmt"
 
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier/alertmanager"
 	"github.com/cloudprober/cloudprober/internal/alerting/notifier/opsgenie"
 	"github.com/cloudprober/cloudprober/internal/alerting/notifier/pagerduty"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier/pubsub"
 	"github.com/cloudprober/cloudprober/internal/alerting/notifier/slack"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier/sns"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier/teams"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier/webhook"
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
 	httpreqpb "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 	"github.com/cloudprober/cloudprober/logger"
@@ -40,6 +45,9 @@ Probe: @probe@
 Dashboard: @dashboard_url@
 Playbook: @playbook_url@
 `
+	// DefaultGroupDetailsTemplate is the default details template for the
+	// grouped alerts.
+	DefaultGroupDetailsTemplate = DefaultDetailsTemplate + "Targets: @targets@\n"
 )
 
 type Notifier struct {
@@ -58,7 +66,16 @@ type Notifier struct {
 	pagerdutyNotifier *pagerduty.Client
 	opsgenieNotifier  *opsgenie.Client
 	slackNotifier     *slack.Client
+	teamsNotifier     *teams.Client
+	webhookNotifier   *webhook.Client
+	snsNotifier       *sns.Client
+	pubsubNotifier    *pubsub.Client
+	amNotifier        *alertmanager.Client
 	httpNotifier      *httpreqpb.HTTPRequest
+
+	matchers []*Matcher
+	routes   []*Notifier
+	stats    deliveryStats
 }
 
 func (n *Notifier) alertFields(alertInfo *alertinfo.AlertInfo) map[string]string {
@@ -68,12 +85,22 @@ func (n *Notifier) alertFields(alertInfo *alertinfo.AlertInfo) map[string]string
 		"dashboard_url": n.dashboardURLTmpl,
 		"playbook_url":  n.playbookURLTmpl,
 	}
+	if len(alertInfo.Targets) > 1 && n.detailsTmpl == DefaultDetailsTemplate {
+		templateDetails["details"] = DefaultGroupDetailsTemplate
+	}
 	for k, v := range n.otherInfo {
 		templateDetails[k] = v
 	}
 
 	fields := alertInfo.Fields(templateDetails)
 
+	// Routing labels don't override the other fields.
+	for k, v := range alertInfo.Labels {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+
 	if n.severity != configpb.AlertConf_UNKNOWN_SEVERITY {
 		fields["severity"] = n.severity.String()
 	}
@@ -81,12 +108,28 @@ func (n *Notifier) alertFields(alertInfo *alertinfo.AlertInfo) map[string]string
 	return fields
 }
 
+// Notify sends the alert notifications, using the notifiers configured in
+// the notify config and the matching routes.
 func (n *Notifier) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo) error {
 	fields := n.alertFields(alertInfo)
 
+	errs := n.notify(ctx, alertInfo, fields)
+	for _, r := range n.routes {
+		if err := r.notify(ctx, alertInfo, fields); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+func (n *Notifier) notify(ctx context.Context, alertInfo *alertinfo.AlertInfo, fields map[string]string) error {
+	if !MatchAll(n.matchers, fields) {
+		return nil
+	}
+
 	var errs error
 	if n.cmdNotifier != nil {
-		err := n.cmdNotifier.Notify(ctx, fields)
+		err := n.deliver("command", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.cmdNotifier.Notify(ctx, fields) })
 		if err != nil {
 			n.l.Errorf("Error running notify command: %v", err)
 			errs = errors.Join(errs, err)
@@ -94,15 +137,15 @@ func (n *Notifier) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo) e
 	}
 
 	if n.emailNotifier != nil {
-		err := n.emailNotifier.Notify(ctx, fields)
-		if err == nil {
+		err := n.deliver("email", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.emailNotifier.Notify(ctx, fields) })
+		if err != nil {
 			n.l.Errorf("Error sending email: %v", err)
 			errs = errors.Join(errs, err)
 		}
 	}
 
 	if n.pagerdutyNotifier != nil {
-		err := n.pagerdutyNotifier.Notify(ctx, alertInfo, fields)
+		err := n.deliver("pagerduty", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.pagerdutyNotifier.Notify(ctx, alertInfo, fields) })
 		if err != nil {
 			n.l.Errorf("Error sending PagerDuty event: %v", err)
 			errs = errors.Join(errs, err)
@@ -110,7 +153,7 @@ func (n *Notifier) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo) e
 	}
 
 	if n.opsgenieNotifier != nil {
-		err := n.opsgenieNotifier.Notify(ctx, alertInfo, fields)
+		err := n.deliver("opsgenie", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.opsgenieNotifier.Notify(ctx, alertInfo, fields) })
 		if err != nil {
 			n.l.Errorf("Error sending OpsGenie alert: %v", err)
 			errs = errors.Join(errs, err)
@@ -118,15 +161,55 @@ func (n *Notifier) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo) e
 	}
 
 	if n.slackNotifier != nil {
-		err := n.slackNotifier.Notify(ctx, fields)
+		err := n.deliver("slack", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.slackNotifier.Notify(ctx, fields) })
 		if err != nil {
 			n.l.Errorf("Error sending Slack message: %v", err)
 			errs = errors.Join(errs, err)
 		}
 	}
 
+	if n.teamsNotifier != nil {
+		err := n.deliver("teams", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.teamsNotifier.Notify(ctx, fields) })
+		if err != nil {
+			n.l.Errorf("Error sending Teams message: %v", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if n.webhookNotifier != nil {
+		err := n.deliverWithRetries("webhook", alertinfo.StatusFiring, alertInfo, fields, func() (int, error) { return n.webhookNotifier.NotifyWithRetries(ctx, fields) })
+		if err != nil {
+			n.l.Errorf("Error sending webhook notification: %v", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if n.snsNotifier != nil {
+		err := n.deliver("sns", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.snsNotifier.Notify(ctx, alertInfo, fields) })
+		if err != nil {
+			n.l.Errorf("Error publishing SNS alert event: %v", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if n.pubsubNotifier != nil {
+		err := n.deliver("pubsub", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.pubsubNotifier.Notify(ctx, alertInfo, fields) })
+		if err != nil {
+			n.l.Errorf("Error publishing Pub/Sub alert event: %v", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if n.amNotifier != nil {
+		err := n.deliver("alertmanager", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.amNotifier.Notify(ctx, alertInfo, fields) })
+		if err != nil {
+			n.l.Errorf("Error sending alert to Alertmanager: %v", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	if n.httpNotifier != nil {
-		err := n.httpNotify(ctx, fields)
+		err := n.deliver("http", alertinfo.StatusFiring, alertInfo, fields, func() error { return n.httpNotify(ctx, fields) })
 		if err != nil {
 			n.l.Errorf("Error sending HTTP notification: %v", err)
 			errs = errors.Join(errs, err)
@@ -135,20 +218,57 @@ func (n *Notifier) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo) e
 	return errs
 }
 
+// NotifyResolve sends the alert resolve notifications, for the notifiers
+// that support them.
 func (n *Notifier) NotifyResolve(ctx context.Context, alertInfo *alertinfo.AlertInfo) {
 	fields := n.alertFields(alertInfo)
 
+	n.notifyResolve(ctx, alertInfo, fields)
+	for _, r := range n.routes {
+		r.notifyResolve(ctx, alertInfo, fields)
+	}
+}
+
+func (n *Notifier) notifyResolve(ctx context.Context, alertInfo *alertinfo.AlertInfo, fields map[string]string) {
+	if !MatchAll(n.matchers, fields) {
+		return
+	}
+
 	if n.pagerdutyNotifier != nil {
-		if err := n.pagerdutyNotifier.NotifyResolve(ctx, alertInfo, fields); err != nil {
+		if err := n.deliver("pagerduty", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.pagerdutyNotifier.NotifyResolve(ctx, alertInfo, fields) }); err != nil {
 			n.l.Errorf("Error sending PagerDuty resolve event: %v", err)
 		}
 	}
 
 	if n.opsgenieNotifier != nil {
-		if err := n.opsgenieNotifier.NotifyResolve(ctx, alertInfo, fields); err != nil {
+		if err := n.deliver("opsgenie", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.opsgenieNotifier.NotifyResolve(ctx, alertInfo, fields) }); err != nil {
 			n.l.Errorf("Error closing OpsGenie alert: %v", err)
 		}
 	}
+
+	if n.emailNotifier != nil {
+		if err := n.deliver("email", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.emailNotifier.NotifyResolve(ctx, fields) }); err != nil {
+			n.l.Errorf("Error sending resolve email: %v", err)
+		}
+	}
+
+	if n.snsNotifier != nil {
+		if err := n.deliver("sns", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.snsNotifier.NotifyResolve(ctx, alertInfo, fields) }); err != nil {
+			n.l.Errorf("Error publishing SNS resolve event: %v", err)
+		}
+	}
+
+	if n.pubsubNotifier != nil {
+		if err := n.deliver("pubsub", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.pubsubNotifier.NotifyResolve(ctx, alertInfo, fields) }); err != nil {
+			n.l.Errorf("Error publishing Pub/Sub resolve event: %v", err)
+		}
+	}
+
+	if n.amNotifier != nil {
+		if err := n.deliver("alertmanager", alertinfo.StatusResolved, alertInfo, fields, func() error { return n.amNotifier.NotifyResolve(ctx, alertInfo, fields) }); err != nil {
+			n.l.Errorf("Error sending resolved alert to Alertmanager: %v", err)
+		}
+	}
 }
 
 func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
@@ -178,14 +298,42 @@ func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
 		n.dashboardURLTmpl = DefaultDashboardURLTemplate
 	}
 
+	if err := n.initNotifiers(); err != nil {
+		return nil, err
+	}
+
+	for i, routeCfg := range alertcfg.GetRoute() {
+		r := &Notifier{cfg: routeCfg, l: l}
+		if err := r.initNotifiers(); err != nil {
+			return nil, fmt.Errorf("error configuring route %d: %v", i, err)
+		}
+		n.routes = append(n.routes, r)
+	}
+
+	return n, nil
+}
+
+// initNotifiers initializes the notifiers and matchers from the notify
+// config.
+func (n *Notifier) initNotifiers() error {
+	l := n.l
+
 	if n.cfg == nil {
-		return n, nil
+		return nil
+	}
+
+	for _, m := range n.cfg.GetMatcher() {
+		nm, err := NewMatcher(m)
+		if err != nil {
+			return err
+		}
+		n.matchers = append(n.matchers, nm)
 	}
 
 	if n.cfg.Command != "" {
 		cmdParts, err := newCommandNotifier(n.cfg.Command, l)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing notify command: %v", err)
+			return fmt.Errorf("error parsing notify command: %v", err)
 		}
 		n.cmdNotifier = cmdParts
 	}
@@ -193,7 +341,7 @@ func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
 	if n.cfg.GetEmail() != nil {
 		en, err := newEmailNotifier(n.cfg.GetEmail(), l)
 		if err != nil {
-			return nil, fmt.Errorf("error configuring email notifier: %v", err)
+			return fmt.Errorf("error configuring email notifier: %v", err)
 		}
 		n.emailNotifier = en
 	}
@@ -201,7 +349,7 @@ func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
 	if n.cfg.GetPagerDuty() != nil {
 		pd, err := pagerduty.New(n.cfg.GetPagerDuty(), l)
 		if err != nil {
-			return nil, fmt.Errorf("error configuring PagerDuty notifier: %v", err)
+			return fmt.Errorf("error configuring PagerDuty notifier: %v", err)
 		}
 		n.pagerdutyNotifier = pd
 	}
@@ -209,7 +357,7 @@ func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
 	if n.cfg.GetOpsgenie() != nil {
 		og, err := opsgenie.New(n.cfg.GetOpsgenie(), l)
 		if err != nil {
-			return nil, fmt.Errorf("error configuring OpsGenie notifier: %v", err)
+			return fmt.Errorf("error configuring OpsGenie notifier: %v", err)
 		}
 		n.opsgenieNotifier = og
 	}
@@ -217,14 +365,54 @@ func New(alertcfg *configpb.AlertConf, l *logger.Logger) (*Notifier, error) {
 	if n.cfg.GetSlack() != nil {
 		slack, err := slack.New(n.cfg.GetSlack(), l)
 		if err != nil {
-			return nil, fmt.Errorf("error configuring Slack notifier: %v", err)
+			return fmt.Errorf("error configuring Slack notifier: %v", err)
 		}
 		n.slackNotifier = slack
 	}
 
+	if n.cfg.GetTeams() != nil {
+		tn, err := teams.New(n.cfg.GetTeams(), l)
+		if err != nil {
+			return fmt.Errorf("error configuring Teams notifier: %v", err)
+		}
+		n.teamsNotifier = tn
+	}
+
+	if n.cfg.GetWebhook() != nil {
+		wn, err := webhook.New(n.cfg.GetWebhook(), l)
+		if err != nil {
+			return fmt.Errorf("error configuring webhook notifier: %v", err)
+		}
+		n.webhookNotifier = wn
+	}
+
+	if n.cfg.GetSns() != nil {
+		sn, err := sns.New(n.cfg.GetSns(), l)
+		if err != nil {
+			return fmt.Errorf("error configuring SNS notifier: %v", err)
+		}
+		n.snsNotifier = sn
+	}
+
+	if n.cfg.GetPubsub() != nil {
+		pn, err := pubsub.New(n.cfg.GetPubsub(), l)
+		if err != nil {
+			return fmt.Errorf("error configuring Pub/Sub notifier: %v", err)
+		}
+		n.pubsubNotifier = pn
+	}
+
+	if n.cfg.GetAlertmanager() != nil {
+		am, err := alertmanager.New(n.cfg.GetAlertmanager(), l)
+		if err != nil {
+			return fmt.Errorf("error configuring Alertmanager notifier: %v", err)
+		}
+		n.amNotifier = am
+	}
+
 	if n.cfg.GetHttpNotify() != nil {
 		n.httpNotifier = n.cfg.GetHttpNotify()
 	}
 
-	return n, nil
+	return nil
 }
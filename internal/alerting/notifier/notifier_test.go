@@ -153,3 +153,116 @@ func TestNotify(t *testing.T) {
 		})
 	}
 }
+
+func TestMatcher(t *testing.T) {
+	fields := map[string]string{
+		"severity": "CRITICAL",
+		"team":     "db",
+	}
+
+	tests := []struct {
+		name    string
+		m       *configpb.Matcher
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "exact",
+			m:    &configpb.Matcher{Name: "severity", Value: "CRITICAL"},
+			want: true,
+		},
+		{
+			name: "exact_no_match",
+			m:    &configpb.Matcher{Name: "severity", Value: "CRIT"},
+			want: false,
+		},
+		{
+			name: "regex",
+			m:    &configpb.Matcher{Name: "severity", Value: "CRITICAL|ERROR", Regex: true},
+			want: true,
+		},
+		{
+			name: "regex_anchored",
+			m:    &configpb.Matcher{Name: "severity", Value: "CRIT", Regex: true},
+			want: false,
+		},
+		{
+			name: "negate",
+			m:    &configpb.Matcher{Name: "team", Value: "db", Negate: true},
+			want: false,
+		},
+		{
+			name: "missing_field",
+			m:    &configpb.Matcher{Name: "env", Value: ""},
+			want: true,
+		},
+		{
+			name:    "no_name",
+			m:       &configpb.Matcher{Value: "db"},
+			wantErr: true,
+		},
+		{
+			name:    "bad_regex",
+			m:       &configpb.Matcher{Name: "team", Value: "db(", Regex: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.m)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, m.Match(fields))
+		})
+	}
+}
+
+func TestNotifyRoutes(t *testing.T) {
+	alertInfo := &alertinfo.AlertInfo{
+		Name:      "test-alert",
+		ProbeName: "test-probe",
+		Target:    endpoint.Endpoint{Name: "test-target"},
+		Labels:    map[string]string{"team": "db"},
+	}
+
+	conf := &configpb.AlertConf{
+		Severity: configpb.AlertConf_CRITICAL,
+		Notify:   &configpb.NotifyConfig{Command: "/main-cmd"},
+		Route: []*configpb.NotifyConfig{
+			{
+				Command: "/page-@team@",
+				Matcher: []*configpb.Matcher{
+					{Name: "severity", Value: "CRITICAL|ERROR", Regex: true},
+					{Name: "team", Value: "db"},
+				},
+			},
+			{
+				Command: "/chat-cmd",
+				Matcher: []*configpb.Matcher{
+					{Name: "severity", Value: "WARNING"},
+				},
+			},
+		},
+	}
+
+	n, err := New(conf, nil)
+	assert.NoError(t, err)
+	assert.Len(t, n.routes, 2)
+
+	// Command notifiers fail as commands don't exist, that's how we find
+	// out which notifiers were invoked.
+	err = n.Notify(context.Background(), alertInfo)
+	assert.ErrorContains(t, err, "/main-cmd")
+	assert.ErrorContains(t, err, "/page-db")
+	assert.NotContains(t, err.Error(), "/chat-cmd")
+
+	_, err = New(&configpb.AlertConf{
+		Route: []*configpb.NotifyConfig{
+			{Matcher: []*configpb.Matcher{{Value: "x"}}},
+		},
+	}, nil)
+	assert.ErrorContains(t, err, "route 0")
+}
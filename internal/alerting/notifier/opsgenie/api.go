@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
@@ -50,9 +51,11 @@ type alertMessage struct {
 type priority string
 
 const (
-	P1, P2, P3, P4 priority = "P1", "P2", "P3", "P4"
+	P1, P2, P3, P4, P5 priority = "P1", "P2", "P3", "P4", "P5"
 )
 
+var validPriorities = map[priority]bool{P1: true, P2: true, P3: true, P4: true, P5: true}
+
 // sendRequest sends an event to PagerDuty using the V2 API.
 func (c *Client) sendRequest(req *http.Request, msg string) error {
 	c.l.Infof("Sending request to Opsgenie (URL: %s): %s", req.URL.String(), msg)
@@ -104,6 +107,19 @@ func (c *Client) closeRequest(alias string) (*http.Request, error) {
 	return c.httpRequest(http.MethodPost, url, bytes.NewBufferString("{}"))
 }
 
+// priority returns the Opsgenie priority for the alert severity, using the
+// configured priority map, if any.
+func (c *Client) priority(alertFields map[string]string) priority {
+	sev := alertFields["severity"]
+	if sev == "" {
+		sev = "UNKNOWN_SEVERITY"
+	}
+	if p, ok := c.cfg.GetPriorityMap()[sev]; ok {
+		return priority(p)
+	}
+	return priorityFromAlertFields(alertFields)
+}
+
 func priorityFromAlertFields(alertFields map[string]string) priority {
 	p, ok := map[string]priority{
 		"CRITICAL": P1,
@@ -125,7 +141,7 @@ func (c *Client) createAlertMessage(alertInfo *alertinfo.AlertInfo, alertFields
 		Entity:      "Cloudprober",
 		Message:     alertFields["summary"],
 		Description: alertFields["details"],
-		Priority:    priorityFromAlertFields(alertFields),
+		Priority:    c.priority(alertFields),
 		Details:     make(map[string]string),
 	}
 
@@ -142,10 +158,34 @@ func (c *Client) createAlertMessage(alertInfo *alertinfo.AlertInfo, alertFields
 			ID:   res.GetId(),
 		})
 	}
+	msg.Responders = append(msg.Responders, c.labelResponders(alertInfo)...)
 
 	return msg
 }
 
+// labelResponders returns the responders from the alert labels, as
+// configured by responder_labels.
+func (c *Client) labelResponders(alertInfo *alertinfo.AlertInfo) []responder {
+	var labels []string
+	for label := range c.cfg.GetResponderLabels() {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var responders []responder
+	for _, label := range labels {
+		for _, name := range strings.Split(alertInfo.Labels[label], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				responders = append(responders, responder{
+					Type: strings.ToLower(c.cfg.GetResponderLabels()[label]),
+					Name: name,
+				})
+			}
+		}
+	}
+	return responders
+}
+
 // dedupeKey returns a deduplication key.
 func dedupeKey(alertInfo *alertinfo.AlertInfo) string {
 	return alertInfo.DeduplicationID
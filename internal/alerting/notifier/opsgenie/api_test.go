@@ -26,6 +26,7 @@ import (
 func TestAlertRequest(t *testing.T) {
 	alertInfo := &alertinfo.AlertInfo{
 		DeduplicationID: "test-condition",
+		Labels:          map[string]string{"team": "db-oncall, sre", "owner": "joe"},
 	}
 
 	testKey := "test-genie-key"
@@ -113,6 +114,30 @@ func TestAlertRequest(t *testing.T) {
 			wantReqURL:  "https://test-api.opsgenie.com/v2/alerts",
 			wantReqBody: `{"alias":"test-condition","entity":"Cloudprober","message":"Cloudprober alert \"test-alert\" for \"test-target\"","priority":"P2","responders":[{"type":"team","name":"test-team"},{"type":"schedule","id":"test-schedule-id"}]}`,
 		},
+		{
+			name: "priorityMapAndLabelResponders",
+			cfg: &configpb.Opsgenie{
+				PriorityMap:     map[string]string{"WARNING": "P5"},
+				ResponderLabels: map[string]string{"team": "team", "owner": "USER", "missing": "team"},
+			},
+			alertFields: map[string]string{
+				"severity": "WARNING",
+				"summary":  `Cloudprober alert "test-alert" for "test-target"`,
+			},
+			wantAlertMsg: &alertMessage{
+				Alias:    "test-condition",
+				Entity:   "Cloudprober",
+				Message:  `Cloudprober alert "test-alert" for "test-target"`,
+				Priority: P5,
+				Details:  map[string]string{},
+				Responders: []responder{
+					{Type: "user", Name: "joe"},
+					{Type: "team", Name: "db-oncall"},
+					{Type: "team", Name: "sre"},
+				},
+			},
+			wantReqBody: `{"alias":"test-condition","entity":"Cloudprober","message":"Cloudprober alert \"test-alert\" for \"test-target\"","priority":"P5","responders":[{"type":"user","name":"joe"},{"type":"team","name":"db-oncall"},{"type":"team","name":"sre"}]}`,
+		},
 	}
 	for _, tt := range tests {
 		if tt.apiURL == "" {
@@ -19,8 +19,10 @@ package opsgenie
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
@@ -56,6 +58,17 @@ func New(cfg *configpb.Opsgenie, l *logger.Logger) (*Client, error) {
 		return nil, err
 	}
 
+	for sev, p := range cfg.GetPriorityMap() {
+		if !validPriorities[priority(p)] {
+			return nil, fmt.Errorf("invalid Opsgenie priority (%s) for severity %s, should be one of P1-P5", p, sev)
+		}
+	}
+	for label, typ := range cfg.GetResponderLabels() {
+		if v, ok := configpb.Opsgenie_Responder_Type_value[strings.ToUpper(typ)]; !ok || v == 0 {
+			return nil, fmt.Errorf("invalid responder type (%s) for label %s, should be one of user, team, escalation or schedule", typ, label)
+		}
+	}
+
 	return &Client{
 		cfg:        cfg,
 		httpClient: http.DefaultClient,
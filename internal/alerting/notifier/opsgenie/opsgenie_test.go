@@ -73,6 +73,22 @@ func TestNew(t *testing.T) {
 				ogKey:      "test-genie-key",
 			},
 		},
+		{
+			name: "invalid-priority",
+			cfg: &configpb.Opsgenie{
+				PriorityMap: map[string]string{"CRITICAL": "P0"},
+			},
+			want:    &Client{},
+			wantErr: true,
+		},
+		{
+			name: "invalid-responder-type",
+			cfg: &configpb.Opsgenie{
+				ResponderLabels: map[string]string{"team": "group"},
+			},
+			want:    &Client{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		tt.want.cfg = tt.cfg
@@ -82,6 +98,9 @@ func TestNew(t *testing.T) {
 				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pubsub implements GCP Pub/Sub notifications for Cloudprober alert
+// events.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/compute/metadata"
+	"cloud.google.com/go/pubsub"
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const publishTimeout = 10 * time.Second
+
+var newPubsubClient = func(ctx context.Context, project string) (*pubsub.Client, error) {
+	return pubsub.NewClient(ctx, project)
+}
+
+// Client is a Pub/Sub client.
+type Client struct {
+	c     *configpb.PubSub
+	topic *pubsub.Topic
+	l     *logger.Logger
+}
+
+// New creates a new Pub/Sub client.
+func New(pscfg *configpb.PubSub, l *logger.Logger) (*Client, error) {
+	if pscfg.GetTopic() == "" {
+		return nil, errors.New("topic is required")
+	}
+
+	project := pscfg.GetProject()
+	if project == "" {
+		if !metadata.OnGCE() {
+			return nil, errors.New("project not provided and not running on GCE")
+		}
+		var err error
+		if project, err = metadata.ProjectID(); err != nil {
+			return nil, fmt.Errorf("unable to retrieve project id: %v", err)
+		}
+	}
+
+	client, err := newPubsubClient(context.Background(), project)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Pub/Sub client: %v", err)
+	}
+
+	return &Client{
+		c:     pscfg,
+		topic: client.Topic(pscfg.GetTopic()),
+		l:     l,
+	}, nil
+}
+
+func (c *Client) publish(ctx context.Context, e *alertinfo.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	id, err := c.topic.Publish(ctx, &pubsub.Message{
+		Data:       data,
+		Attributes: e.Attributes(),
+	}).Get(ctx)
+	if err != nil {
+		return fmt.Errorf("error publishing to Pub/Sub topic %s: %v", c.topic.String(), err)
+	}
+	c.l.Infof("Published alert event to Pub/Sub topic %s, message ID: %s", c.topic.String(), id)
+	return nil
+}
+
+// Notify publishes an alert event to the Pub/Sub topic.
+func (c *Client) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	return c.publish(ctx, alertinfo.NewEvent(alertInfo, alertFields, false))
+}
+
+// NotifyResolve publishes an alert resolve event to the Pub/Sub topic.
+func (c *Client) NotifyResolve(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	if c.c.GetDisableSendResolved() {
+		return nil
+	}
+	return c.publish(ctx, alertinfo.NewEvent(alertInfo, alertFields, true))
+}
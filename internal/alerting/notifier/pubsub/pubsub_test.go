@@ -0,0 +1,81 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	pb "google.golang.org/genproto/googleapis/pubsub/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestNotify(t *testing.T) {
+	srv := pstest.NewServer()
+	t.Cleanup(func() { srv.Close() })
+
+	conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Error connecting to the test server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	newPubsubClient = func(ctx context.Context, project string) (*pubsub.Client, error) {
+		return pubsub.NewClient(ctx, project, option.WithGRPCConn(conn))
+	}
+
+	if _, err := srv.GServer.CreateTopic(context.Background(), &pb.Topic{Name: "projects/test-project/topics/alerts"}); err != nil {
+		t.Fatalf("Error creating topic: %v", err)
+	}
+
+	c, err := New(&configpb.PubSub{Project: "test-project", Topic: "alerts"}, nil)
+	assert.NoError(t, err)
+
+	alertInfo := &alertinfo.AlertInfo{
+		Name:      "test-alert",
+		ProbeName: "test-probe",
+		Target:    endpoint.Endpoint{Name: "test-target"},
+	}
+	fields := map[string]string{"alert": "test-alert"}
+
+	assert.NoError(t, c.Notify(context.Background(), alertInfo, fields))
+	assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, fields))
+
+	msgs := srv.Messages()
+	assert.Len(t, msgs, 2)
+	for i, wantStatus := range []string{alertinfo.StatusFiring, alertinfo.StatusResolved} {
+		var e alertinfo.Event
+		assert.NoError(t, json.Unmarshal(msgs[i].Data, &e))
+		assert.Equal(t, wantStatus, e.Status)
+		assert.Equal(t, "test-probe", e.Probe)
+		assert.Equal(t, map[string]string{"status": wantStatus, "alert": "test-alert", "probe": "test-probe"}, msgs[i].Attributes)
+	}
+
+	c.c.DisableSendResolved = true
+	assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, fields))
+	assert.Len(t, srv.Messages(), 2)
+
+	_, err = New(&configpb.PubSub{Project: "test-project"}, nil)
+	assert.Error(t, err)
+}
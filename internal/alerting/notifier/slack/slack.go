@@ -5,11 +5,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/cloudprober/cloudprober/common/strtemplate"
 	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
 	"github.com/cloudprober/cloudprober/logger"
@@ -19,6 +23,13 @@ const (
 	// DEFAULT_SLACK_WEBHOOK_URL_ENV_VAR is the default environment variable
 	// to use for the Slack webhook URL.
 	DEFAULT_SLACK_WEBHOOK_URL_ENV_VAR = "SLACK_WEBHOOK_URL"
+
+	// DEFAULT_SLACK_API_URL is the default URL for the chat.postMessage API,
+	// used in the bot token mode.
+	DEFAULT_SLACK_API_URL = "https://slack.com/api/chat.postMessage"
+
+	// DEFAULT_MAX_MESSAGES_PER_MINUTE is the default per-channel rate limit.
+	DEFAULT_MAX_MESSAGES_PER_MINUTE = 20
 )
 
 // Client is a Slack client.
@@ -26,20 +37,80 @@ type Client struct {
 	httpClient *http.Client
 	logger     *logger.Logger
 	webhookURL string
+
+	// Bot token mode.
+	botToken string
+	apiURL   string
+
+	channelTmpl     string
+	fallbackChannel string
+	blocksTmpl      string
+	limiter         *rateLimiter
 }
 
 // New creates a new Slack client.
 func New(slackcfg *configpb.Slack, l *logger.Logger) (*Client, error) {
-	webhookURL, err := lookupWebhookUrl(slackcfg)
+	c := &Client{
+		httpClient:      &http.Client{},
+		logger:          l,
+		channelTmpl:     slackcfg.GetChannel(),
+		fallbackChannel: slackcfg.GetFallbackChannel(),
+		blocksTmpl:      slackcfg.GetBlocksTemplate(),
+	}
+
+	botToken, err := lookupBotToken(slackcfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		httpClient: &http.Client{},
-		logger:     l,
-		webhookURL: webhookURL,
-	}, nil
+	if botToken != "" {
+		if c.channelTmpl == "" {
+			return nil, errors.New("channel is required in the bot token mode")
+		}
+		c.botToken = botToken
+		c.apiURL = slackcfg.GetApiUrl()
+		if c.apiURL == "" {
+			c.apiURL = DEFAULT_SLACK_API_URL
+		}
+	} else {
+		if c.webhookURL, err = lookupWebhookUrl(slackcfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.blocksTmpl != "" {
+		var blocks []json.RawMessage
+		if err := json.Unmarshal([]byte(c.blocksTmpl), &blocks); err != nil {
+			return nil, fmt.Errorf("blocks_template is not a valid JSON array: %v", err)
+		}
+	}
+
+	maxMessages := DEFAULT_MAX_MESSAGES_PER_MINUTE
+	if slackcfg.GetMaxMessagesPerMinute() != 0 {
+		maxMessages = int(slackcfg.GetMaxMessagesPerMinute())
+	}
+	if maxMessages > 0 {
+		c.limiter = newRateLimiter(maxMessages, time.Minute)
+	}
+
+	return c, nil
+}
+
+// lookupBotToken looks up the bot token to use for the Slack client. Empty
+// token means that the bot token mode is not configured.
+func lookupBotToken(slackcfg *configpb.Slack) (string, error) {
+	if slackcfg.GetBotToken() != "" {
+		return slackcfg.GetBotToken(), nil
+	}
+
+	if envVar := slackcfg.GetBotTokenEnvVar(); envVar != "" {
+		if botToken, exists := os.LookupEnv(envVar); exists && botToken != "" {
+			return botToken, nil
+		}
+		return "", fmt.Errorf("bot token environment variable %s is not set", envVar)
+	}
+
+	return "", nil
 }
 
 // lookupWebhookUrl looks up the webhook URL to use for the Slack client,
@@ -69,26 +140,94 @@ func webhookUrlEnvVar(slackcfg *configpb.Slack) string {
 	return DEFAULT_SLACK_WEBHOOK_URL_ENV_VAR
 }
 
-// webhookMessage is the message that is sent to the Slack webhook.
+// webhookMessage is the message that is sent to the Slack webhook, or to the
+// chat.postMessage API in the bot token mode.
 type webhookMessage struct {
-	Text string `json:"text"`
+	Channel string          `json:"channel,omitempty"`
+	Text    string          `json:"text"`
+	Blocks  json.RawMessage `json:"blocks,omitempty"`
+}
+
+// apiResponse is the response of the chat.postMessage API.
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// channel returns the channel for the alert. Empty channel means webhook's
+// default channel.
+func (c *Client) channel(alertFields map[string]string) (string, error) {
+	if c.channelTmpl == "" {
+		return "", nil
+	}
+	channel, ok := strtemplate.SubstituteLabels(c.channelTmpl, alertFields)
+	if ok {
+		return channel, nil
+	}
+	if c.fallbackChannel != "" {
+		return c.fallbackChannel, nil
+	}
+	return "", fmt.Errorf("couldn't expand channel template: %s", c.channelTmpl)
+}
+
+// blocks expands the blocks template using the alert fields. Field values
+// are JSON escaped before substitution.
+func (c *Client) blocks(alertFields map[string]string) (json.RawMessage, error) {
+	if c.blocksTmpl == "" {
+		return nil, nil
+	}
+
+	escapedFields := make(map[string]string, len(alertFields))
+	for k, v := range alertFields {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		escapedFields[k] = string(b[1 : len(b)-1])
+	}
+
+	blocks, _ := strtemplate.SubstituteLabels(c.blocksTmpl, escapedFields)
+	if !json.Valid([]byte(blocks)) {
+		return nil, fmt.Errorf("expanded blocks are not a valid JSON: %s", blocks)
+	}
+	return json.RawMessage(blocks), nil
 }
 
 // Notify sends a notification to Slack.
 func (c *Client) Notify(ctx context.Context, alertFields map[string]string) error {
 	message := createMessage(alertFields)
 
+	var err error
+	if message.Channel, err = c.channel(alertFields); err != nil {
+		return err
+	}
+	if message.Blocks, err = c.blocks(alertFields); err != nil {
+		return err
+	}
+
+	if c.limiter != nil && !c.limiter.allow(message.Channel) {
+		return fmt.Errorf("slack rate limit exceeded for channel %q, dropping message: %s", message.Channel, alertFields["summary"])
+	}
+
 	jsonBody, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.webhookURL, bytes.NewBuffer(jsonBody))
+	url := c.webhookURL
+	if c.botToken != "" {
+		url = c.apiURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if c.botToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.botToken)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -96,12 +235,27 @@ func (c *Client) Notify(ctx context.Context, alertFields map[string]string) erro
 	}
 	defer resp.Body.Close()
 
+	b, _ := io.ReadAll(resp.Body)
+
 	// check status code, return error if not 200
 	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("slack rate limited the request; retry after: %ss", resp.Header.Get("Retry-After"))
+		}
 		return fmt.Errorf("slack webhook returned error; statusCode: %d, response: %s", resp.StatusCode, string(b))
 	}
 
+	// chat.postMessage API returns errors with the status code 200.
+	if c.botToken != "" {
+		var apiResp apiResponse
+		if err := json.Unmarshal(b, &apiResp); err != nil {
+			return fmt.Errorf("error parsing slack API response (%s): %v", string(b), err)
+		}
+		if !apiResp.OK {
+			return fmt.Errorf("slack API returned error: %s", apiResp.Error)
+		}
+	}
+
 	return nil
 }
 
@@ -111,3 +265,44 @@ func createMessage(alertFields map[string]string) webhookMessage {
 		Text: alertFields["details"] + "\n\nDetails:\n" + alertinfo.FieldsToString(alertFields, "details", "summary"),
 	}
 }
+
+// rateLimiter limits the number of messages sent per key (channel) in a
+// sliding time window.
+type rateLimiter struct {
+	mu     sync.Mutex
+	max    int
+	window time.Duration
+	sent   map[string][]time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(max int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		max:    max,
+		window: window,
+		sent:   make(map[string][]time.Time),
+		now:    time.Now,
+	}
+}
+
+// allow reports whether a message can be sent for the key, and if so,
+// records it.
+func (rl *rateLimiter) allow(key string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	sent := rl.sent[key]
+	i := 0
+	for i < len(sent) && now.Sub(sent[i]) >= rl.window {
+		i++
+	}
+	sent = sent[i:]
+
+	if len(sent) >= rl.max {
+		rl.sent[key] = sent
+		return false
+	}
+	rl.sent[key] = append(sent, now)
+	return true
+}
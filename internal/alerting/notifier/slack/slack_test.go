@@ -2,11 +2,16 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSlackNew(t *testing.T) {
@@ -92,7 +97,7 @@ severity: critical`,
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			got := createMessage(tc.alertFields)
-			if got != tc.want {
+			if !reflect.DeepEqual(got, tc.want) {
 				t.Errorf("createMessage() = %v, want %v", got, tc.want)
 			}
 		})
@@ -151,3 +156,143 @@ func TestSlackNotifyError(t *testing.T) {
 		t.Errorf("Notify() error = %v, wantErr %v", err, true)
 	}
 }
+
+func TestSlackNewBotToken(t *testing.T) {
+	tests := map[string]struct {
+		cfg          *configpb.Slack
+		envVars      map[string]string
+		wantBotToken string
+		wantAPIURL   string
+		wantErr      bool
+	}{
+		"bot token": {
+			cfg: &configpb.Slack{
+				BotToken: "xoxb-test",
+				Channel:  "#alerts",
+			},
+			wantBotToken: "xoxb-test",
+			wantAPIURL:   DEFAULT_SLACK_API_URL,
+		},
+		"bot token env var": {
+			cfg: &configpb.Slack{
+				BotTokenEnvVar: "SLACK_BOT_TOKEN",
+				Channel:        "#alerts",
+				ApiUrl:         "http://localhost/api",
+			},
+			envVars:      map[string]string{"SLACK_BOT_TOKEN": "xoxb-test-env"},
+			wantBotToken: "xoxb-test-env",
+			wantAPIURL:   "http://localhost/api",
+		},
+		"bot token env var not set": {
+			cfg: &configpb.Slack{
+				BotTokenEnvVar: "SLACK_BOT_TOKEN_NOT_SET",
+				Channel:        "#alerts",
+			},
+			wantErr: true,
+		},
+		"bot token without channel": {
+			cfg: &configpb.Slack{
+				BotToken: "xoxb-test",
+			},
+			wantErr: true,
+		},
+		"invalid blocks template": {
+			cfg: &configpb.Slack{
+				BotToken:       "xoxb-test",
+				Channel:        "#alerts",
+				BlocksTemplate: `{"type": "section"}`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.envVars {
+				t.Setenv(k, v)
+			}
+
+			c, err := New(tc.cfg, nil)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBotToken, c.botToken)
+			assert.Equal(t, tc.wantAPIURL, c.apiURL)
+			assert.Equal(t, "", c.webhookURL)
+		})
+	}
+}
+
+func TestSlackNotifyBotToken(t *testing.T) {
+	var gotAuth string
+	var gotMsg webhookMessage
+	apiResp := `{"ok": true}`
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		gotMsg = webhookMessage{}
+		json.Unmarshal(b, &gotMsg)
+		w.Write([]byte(apiResp))
+	}))
+	defer httpServer.Close()
+
+	c, err := New(&configpb.Slack{
+		BotToken:        "xoxb-test",
+		ApiUrl:          httpServer.URL,
+		Channel:         "#alerts-@target.label.team@",
+		FallbackChannel: "#alerts",
+		BlocksTemplate:  `[{"type": "section", "text": {"type": "mrkdwn", "text": "@summary@"}}]`,
+	}, nil)
+	assert.NoError(t, err)
+
+	fields := map[string]string{
+		"summary":           `Cloudprober alert "test-alert" for "test-target"`,
+		"target.label.team": "infra",
+	}
+	assert.NoError(t, c.Notify(context.Background(), fields))
+	assert.Equal(t, "Bearer xoxb-test", gotAuth)
+	assert.Equal(t, "#alerts-infra", gotMsg.Channel)
+	assert.JSONEq(t, `[{"type": "section", "text": {"type": "mrkdwn", "text": "Cloudprober alert \"test-alert\" for \"test-target\""}}]`, string(gotMsg.Blocks))
+
+	// Target without the team label goes to the fallback channel.
+	delete(fields, "target.label.team")
+	assert.NoError(t, c.Notify(context.Background(), fields))
+	assert.Equal(t, "#alerts", gotMsg.Channel)
+
+	// API errors are returned with status code 200.
+	apiResp = `{"ok": false, "error": "channel_not_found"}`
+	assert.ErrorContains(t, c.Notify(context.Background(), fields), "channel_not_found")
+}
+
+func TestSlackNotifyRateLimit(t *testing.T) {
+	numRequests := 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+	}))
+	defer httpServer.Close()
+
+	c, err := New(&configpb.Slack{
+		WebhookUrl:           httpServer.URL,
+		Channel:              "#alerts-@probe@",
+		MaxMessagesPerMinute: 2,
+	}, nil)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	c.limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		err := c.Notify(context.Background(), map[string]string{"probe": "p1"})
+		assert.Equal(t, i >= 2, err != nil, "message %d, err: %v", i, err)
+	}
+	// Different channel has its own limit.
+	assert.NoError(t, c.Notify(context.Background(), map[string]string{"probe": "p2"}))
+	assert.Equal(t, 3, numRequests)
+
+	// Window moves forward.
+	now = now.Add(time.Minute)
+	assert.NoError(t, c.Notify(context.Background(), map[string]string{"probe": "p1"}))
+	assert.Equal(t, 4, numRequests)
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sns implements AWS SNS notifications for Cloudprober alert events.
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// publisher is the subset of the SNS client used by the notifier.
+type publisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// Client is an SNS client.
+type Client struct {
+	c      *configpb.SNS
+	fifo   bool
+	l      *logger.Logger
+	client publisher
+}
+
+// regionFromARN returns the region from the topic ARN, e.g.
+// arn:aws:sns:us-east-1:123456789012:my-topic.
+func regionFromARN(arn string) (string, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" {
+		return "", fmt.Errorf("invalid SNS topic ARN: %s", arn)
+	}
+	return parts[3], nil
+}
+
+// New creates a new SNS client.
+func New(snscfg *configpb.SNS, l *logger.Logger) (*Client, error) {
+	if snscfg.GetTopicArn() == "" {
+		return nil, errors.New("topic_arn is required")
+	}
+
+	region := snscfg.GetRegion()
+	if region == "" {
+		var err error
+		if region, err = regionFromARN(snscfg.GetTopicArn()); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %v", err)
+	}
+
+	return &Client{
+		c:      snscfg,
+		fifo:   strings.HasSuffix(snscfg.GetTopicArn(), ".fifo"),
+		l:      l,
+		client: sns.NewFromConfig(cfg),
+	}, nil
+}
+
+func (c *Client) publish(ctx context.Context, e *alertinfo.Event) error {
+	msg, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	input := &sns.PublishInput{
+		TopicArn:          aws.String(c.c.GetTopicArn()),
+		Message:           aws.String(string(msg)),
+		Subject:           aws.String(fmt.Sprintf("Cloudprober alert %s: %s", e.Status, e.Alert)),
+		MessageAttributes: make(map[string]types.MessageAttributeValue),
+	}
+	for k, v := range e.Attributes() {
+		input.MessageAttributes[k] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(v),
+		}
+	}
+	if c.fifo {
+		input.MessageGroupId = aws.String(e.DeduplicationID)
+		input.MessageDeduplicationId = aws.String(e.DeduplicationID + "-" + e.Status + "-" + strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+
+	out, err := c.client.Publish(ctx, input)
+	if err != nil {
+		return fmt.Errorf("error publishing to SNS topic %s: %v", c.c.GetTopicArn(), err)
+	}
+	c.l.Infof("Published alert event to SNS topic %s, message ID: %s", c.c.GetTopicArn(), aws.ToString(out.MessageId))
+	return nil
+}
+
+// Notify publishes an alert event to the SNS topic.
+func (c *Client) Notify(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	return c.publish(ctx, alertinfo.NewEvent(alertInfo, alertFields, false))
+}
+
+// NotifyResolve publishes an alert resolve event to the SNS topic.
+func (c *Client) NotifyResolve(ctx context.Context, alertInfo *alertinfo.AlertInfo, alertFields map[string]string) error {
+	if c.c.GetDisableSendResolved() {
+		return nil
+	}
+	return c.publish(ctx, alertinfo.NewEvent(alertInfo, alertFields, true))
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePublisher struct {
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (fp *fakePublisher) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	if fp.err != nil {
+		return nil, fp.err
+	}
+	fp.inputs = append(fp.inputs, params)
+	return &sns.PublishOutput{MessageId: aws.String("msg-id")}, nil
+}
+
+func TestRegionFromARN(t *testing.T) {
+	region, err := regionFromARN("arn:aws:sns:us-east-1:123456789012:my-topic")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", region)
+
+	_, err = regionFromARN("my-topic")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&configpb.SNS{}, nil)
+	assert.Error(t, err)
+
+	_, err = New(&configpb.SNS{TopicArn: "my-topic"}, nil)
+	assert.Error(t, err)
+
+	c, err := New(&configpb.SNS{TopicArn: "arn:aws:sns:us-east-1:123456789012:my-topic.fifo"}, nil)
+	assert.NoError(t, err)
+	assert.True(t, c.fifo)
+}
+
+func TestNotify(t *testing.T) {
+	alertInfo := &alertinfo.AlertInfo{
+		Name:            "test-alert",
+		ProbeName:       "test-probe",
+		Target:          endpoint.Endpoint{Name: "test-target"},
+		Failures:        2,
+		Total:           3,
+		DeduplicationID: "dedup-id",
+	}
+	fields := map[string]string{"alert": "test-alert", "severity": "CRITICAL"}
+
+	for _, fifo := range []bool{false, true} {
+		fp := &fakePublisher{}
+		c := &Client{
+			c:      &configpb.SNS{TopicArn: "arn:aws:sns:us-east-1:123456789012:my-topic"},
+			fifo:   fifo,
+			client: fp,
+		}
+
+		assert.NoError(t, c.Notify(context.Background(), alertInfo, fields))
+		assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, fields))
+		assert.Len(t, fp.inputs, 2)
+
+		for i, wantStatus := range []string{alertinfo.StatusFiring, alertinfo.StatusResolved} {
+			input := fp.inputs[i]
+			assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:my-topic", aws.ToString(input.TopicArn))
+
+			var e alertinfo.Event
+			assert.NoError(t, json.Unmarshal([]byte(aws.ToString(input.Message)), &e))
+			assert.Equal(t, wantStatus, e.Status)
+			assert.Equal(t, "test-alert", e.Alert)
+			assert.Equal(t, "test-target", e.Target)
+			assert.Equal(t, 2, e.Failures)
+			assert.Equal(t, fields, e.Fields)
+
+			assert.Equal(t, wantStatus, aws.ToString(input.MessageAttributes["status"].StringValue))
+			assert.Equal(t, "CRITICAL", aws.ToString(input.MessageAttributes["severity"].StringValue))
+
+			if fifo {
+				assert.Equal(t, "dedup-id", aws.ToString(input.MessageGroupId))
+				assert.NotEmpty(t, aws.ToString(input.MessageDeduplicationId))
+			} else {
+				assert.Nil(t, input.MessageGroupId)
+			}
+		}
+
+		c.c.DisableSendResolved = true
+		assert.NoError(t, c.NotifyResolve(context.Background(), alertInfo, fields))
+		assert.Len(t, fp.inputs, 2)
+
+		fp.err = errors.New("publish error")
+		assert.Error(t, c.Notify(context.Background(), alertInfo, fields))
+	}
+}
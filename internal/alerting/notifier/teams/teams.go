@@ -0,0 +1,264 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package teams implements Microsoft Teams notifications for Cloudprober
+// alert events.
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/cloudprober/cloudprober/common/strtemplate"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const (
+	// DEFAULT_TEAMS_WEBHOOK_URL_ENV_VAR is the default environment variable
+	// to use for the Teams webhook URL.
+	DEFAULT_TEAMS_WEBHOOK_URL_ENV_VAR = "TEAMS_WEBHOOK_URL"
+
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+// Client is a Microsoft Teams client.
+type Client struct {
+	httpClient   *http.Client
+	logger       *logger.Logger
+	webhookURL   string
+	cardTemplate string
+}
+
+// New creates a new Teams client.
+func New(teamscfg *configpb.Teams, l *logger.Logger) (*Client, error) {
+	webhookURL, err := lookupWebhookUrl(teamscfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if teamscfg.GetCardTemplate() != "" {
+		var card map[string]interface{}
+		if err := json.Unmarshal([]byte(teamscfg.GetCardTemplate()), &card); err != nil {
+			return nil, fmt.Errorf("card_template is not a valid JSON object: %v", err)
+		}
+	}
+
+	return &Client{
+		httpClient:   &http.Client{},
+		logger:       l,
+		webhookURL:   webhookURL,
+		cardTemplate: teamscfg.GetCardTemplate(),
+	}, nil
+}
+
+// lookupWebhookUrl looks up the webhook URL to use for the Teams client,
+// in order of precendence:
+// 1. Webhook URL in the config
+// 2. Webhook URL environment variable
+func lookupWebhookUrl(teamscfg *configpb.Teams) (string, error) {
+	if teamscfg.GetWebhookUrl() != "" {
+		return teamscfg.GetWebhookUrl(), nil
+	}
+
+	envVar := teamscfg.GetWebhookUrlEnvVar()
+	if envVar == "" {
+		envVar = DEFAULT_TEAMS_WEBHOOK_URL_ENV_VAR
+	}
+	if webhookURL, exists := os.LookupEnv(envVar); exists {
+		return webhookURL, nil
+	}
+
+	return "", fmt.Errorf("no Teams webhook URL found")
+}
+
+// message is the message that is sent to the Teams webhook.
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string          `json:"contentType"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// adaptiveCard is the subset of the adaptive card format that is used for
+// the default card.
+type adaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []interface{} `json:"body"`
+	Actions []cardAction  `json:"actions,omitempty"`
+}
+
+type textBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Wrap   bool   `json:"wrap"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Color  string `json:"color,omitempty"`
+}
+
+type fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type factSet struct {
+	Type  string `json:"type"`
+	Facts []fact `json:"facts"`
+}
+
+type cardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// failureRatio returns the failure ratio string, e.g. "3/5 (60%)".
+func failureRatio(alertFields map[string]string) string {
+	failures, total := alertFields["failures"], alertFields["total"]
+	if failures == "" || total == "" {
+		return ""
+	}
+	ratio := failures + "/" + total
+	f, err1 := strconv.Atoi(failures)
+	t, err2 := strconv.Atoi(total)
+	if err1 != nil || err2 != nil || t == 0 {
+		return ratio
+	}
+	return fmt.Sprintf("%s (%d%%)", ratio, f*100/t)
+}
+
+// defaultCard creates the default adaptive card from the alert fields.
+func defaultCard(alertFields map[string]string) ([]byte, error) {
+	card := adaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+	}
+
+	card.Body = append(card.Body, textBlock{
+		Type:   "TextBlock",
+		Text:   alertFields["summary"],
+		Wrap:   true,
+		Size:   "Large",
+		Weight: "Bolder",
+		Color:  "Attention",
+	})
+
+	fs := factSet{Type: "FactSet"}
+	for _, f := range []fact{
+		{"Probe", alertFields["probe"]},
+		{"Target", alertFields["target"]},
+		{"Failures", failureRatio(alertFields)},
+		{"Failing since", alertFields["since"]},
+		{"Severity", alertFields["severity"]},
+	} {
+		if f.Value != "" {
+			fs.Facts = append(fs.Facts, f)
+		}
+	}
+	card.Body = append(card.Body, fs)
+
+	for _, a := range []cardAction{
+		{"Action.OpenUrl", "Dashboard", alertFields["dashboard_url"]},
+		{"Action.OpenUrl", "Playbook", alertFields["playbook_url"]},
+	} {
+		if a.URL != "" {
+			card.Actions = append(card.Actions, a)
+		}
+	}
+
+	return json.Marshal(card)
+}
+
+// card creates the adaptive card for the alert, either from the configured
+// template or the default card.
+func (c *Client) card(alertFields map[string]string) ([]byte, error) {
+	fields := make(map[string]string, len(alertFields)+1)
+	for k, v := range alertFields {
+		fields[k] = v
+	}
+	fields["failure_ratio"] = failureRatio(alertFields)
+
+	if c.cardTemplate == "" {
+		return defaultCard(fields)
+	}
+
+	escapedFields := make(map[string]string, len(fields))
+	for k, v := range fields {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		escapedFields[k] = string(b[1 : len(b)-1])
+	}
+
+	card, _ := strtemplate.SubstituteLabels(c.cardTemplate, escapedFields)
+	if !json.Valid([]byte(card)) {
+		return nil, fmt.Errorf("expanded card is not a valid JSON: %s", card)
+	}
+	return []byte(card), nil
+}
+
+// Notify sends a notification to Teams.
+func (c *Client) Notify(ctx context.Context, alertFields map[string]string) error {
+	card, err := c.card(alertFields)
+	if err != nil {
+		return err
+	}
+
+	jsonBody, err := json.Marshal(message{
+		Type: "message",
+		Attachments: []attachment{
+			{ContentType: adaptiveCardContentType, Content: card},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhookURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Incoming webhooks return 200, while workflows return 202.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("teams webhook returned error; statusCode: %d, response: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamsNew(t *testing.T) {
+	tests := map[string]struct {
+		cfg         *configpb.Teams
+		envVars     map[string]string
+		wantWebhook string
+		wantErr     bool
+	}{
+		"webhook url": {
+			cfg:         &configpb.Teams{WebhookUrl: "test-webhook-url"},
+			envVars:     map[string]string{"TEAMS_WEBHOOK_URL": "test-webhook-url-env-var"},
+			wantWebhook: "test-webhook-url",
+		},
+		"env var": {
+			cfg:         &configpb.Teams{},
+			envVars:     map[string]string{"TEAMS_WEBHOOK_URL": "test-webhook-url-env-var"},
+			wantWebhook: "test-webhook-url-env-var",
+		},
+		"env var override": {
+			cfg: &configpb.Teams{WebhookUrlEnvVar: "TEAMS_WEBHOOK_URL_OVERRIDE"},
+			envVars: map[string]string{
+				"TEAMS_WEBHOOK_URL_OVERRIDE": "test-webhook-url-env-var",
+				"TEAMS_WEBHOOK_URL":          "test-webhook-url-env-var-2",
+			},
+			wantWebhook: "test-webhook-url-env-var",
+		},
+		"no webhook": {
+			cfg:     &configpb.Teams{},
+			wantErr: true,
+		},
+		"invalid card template": {
+			cfg:     &configpb.Teams{WebhookUrl: "test-webhook-url", CardTemplate: `[]`},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.envVars {
+				t.Setenv(k, v)
+			}
+
+			c, err := New(tc.cfg, nil)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantWebhook, c.webhookURL)
+		})
+	}
+}
+
+func TestFailureRatio(t *testing.T) {
+	assert.Equal(t, "3/5 (60%)", failureRatio(map[string]string{"failures": "3", "total": "5"}))
+	assert.Equal(t, "3/0", failureRatio(map[string]string{"failures": "3", "total": "0"}))
+	assert.Equal(t, "", failureRatio(map[string]string{}))
+}
+
+func TestTeamsCard(t *testing.T) {
+	alertFields := map[string]string{
+		"summary":       `Cloudprober alert "test-alert" for "test-target"`,
+		"probe":         "test-probe",
+		"target":        "test-target",
+		"failures":      "3",
+		"total":         "5",
+		"dashboard_url": "http://localhost:9313/status?probe=test-probe",
+	}
+
+	tests := map[string]struct {
+		cardTemplate string
+		want         string
+	}{
+		"default": {
+			want: `{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type": "AdaptiveCard",
+				"version": "1.4",
+				"body": [
+					{"type": "TextBlock", "text": "Cloudprober alert \"test-alert\" for \"test-target\"", "wrap": true, "size": "Large", "weight": "Bolder", "color": "Attention"},
+					{"type": "FactSet", "facts": [
+						{"title": "Probe", "value": "test-probe"},
+						{"title": "Target", "value": "test-target"},
+						{"title": "Failures", "value": "3/5 (60%)"}
+					]}
+				],
+				"actions": [
+					{"type": "Action.OpenUrl", "title": "Dashboard", "url": "http://localhost:9313/status?probe=test-probe"}
+				]
+			}`,
+		},
+		"template": {
+			cardTemplate: `{"type": "AdaptiveCard", "body": [{"type": "TextBlock", "text": "@summary@: @failure_ratio@"}]}`,
+			want:         `{"type": "AdaptiveCard", "body": [{"type": "TextBlock", "text": "Cloudprober alert \"test-alert\" for \"test-target\": 3/5 (60%)"}]}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{cardTemplate: tc.cardTemplate}
+			got, err := c.card(alertFields)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestTeamsNotify(t *testing.T) {
+	var gotMsg message
+	statusCode := http.StatusAccepted
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &gotMsg)
+		w.WriteHeader(statusCode)
+	}))
+	defer httpServer.Close()
+
+	c, err := New(&configpb.Teams{WebhookUrl: httpServer.URL}, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Notify(context.Background(), map[string]string{"summary": "test-summary"}))
+	assert.Equal(t, "message", gotMsg.Type)
+	assert.Len(t, gotMsg.Attachments, 1)
+	assert.Equal(t, adaptiveCardContentType, gotMsg.Attachments[0].ContentType)
+
+	statusCode = http.StatusBadRequest
+	assert.Error(t, c.Notify(context.Background(), map[string]string{"summary": "test-summary"}))
+}
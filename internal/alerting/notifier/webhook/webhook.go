@@ -0,0 +1,267 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook implements a generic webhook notifier for Cloudprober
+// alert events.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultTimeout        = 10 * time.Second
+)
+
+// Stats contains the cumulative delivery stats of a webhook client.
+type Stats struct {
+	Success int64
+	Failure int64
+	Retries int64
+}
+
+// Client is a generic webhook client.
+type Client struct {
+	httpClient     *http.Client
+	l              *logger.Logger
+	method         string
+	contentType    configpb.Webhook_ContentType
+	urlTmpl        *template.Template
+	headerTmpls    map[string]*template.Template
+	payloadTmpl    *template.Template
+	maxRetries     int
+	initialBackoff time.Duration
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+var funcMap = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"env": os.Getenv,
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	// "urlquery" is a built-in template function.
+	return template.New(name).Funcs(funcMap).Option("missingkey=zero").Parse(text)
+}
+
+// New creates a new webhook client.
+func New(cfg *configpb.Webhook, l *logger.Logger) (*Client, error) {
+	if cfg.GetUrl() == "" {
+		return nil, errors.New("webhook url is required")
+	}
+
+	c := &Client{
+		l:              l,
+		method:         cfg.GetMethod(),
+		contentType:    cfg.GetContentType(),
+		headerTmpls:    make(map[string]*template.Template),
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: defaultInitialBackoff,
+	}
+	if c.method == "" {
+		c.method = http.MethodPost
+	}
+	if cfg.MaxRetries != 0 {
+		c.maxRetries = int(cfg.GetMaxRetries())
+	}
+	if c.maxRetries < 0 {
+		return nil, fmt.Errorf("max_retries cannot be negative: %d", c.maxRetries)
+	}
+	if cfg.GetInitialBackoffMsec() > 0 {
+		c.initialBackoff = time.Duration(cfg.GetInitialBackoffMsec()) * time.Millisecond
+	}
+	timeout := defaultTimeout
+	if cfg.GetTimeoutMsec() > 0 {
+		timeout = time.Duration(cfg.GetTimeoutMsec()) * time.Millisecond
+	}
+	c.httpClient = &http.Client{Timeout: timeout}
+
+	var err error
+	if c.urlTmpl, err = parseTemplate("url", cfg.GetUrl()); err != nil {
+		return nil, fmt.Errorf("error parsing url template: %v", err)
+	}
+	for k, v := range cfg.GetHeader() {
+		if c.headerTmpls[k], err = parseTemplate("header_"+k, v); err != nil {
+			return nil, fmt.Errorf("error parsing header (%s) template: %v", k, err)
+		}
+	}
+	if cfg.GetPayloadTemplate() != "" {
+		if c.payloadTmpl, err = parseTemplate("payload", cfg.GetPayloadTemplate()); err != nil {
+			return nil, fmt.Errorf("error parsing payload template: %v", err)
+		}
+	}
+
+	return c, nil
+}
+
+func execute(tmpl *template.Template, fields map[string]string) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, fields); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// payload creates the request payload from the alert fields.
+func (c *Client) payload(fields map[string]string) ([]byte, error) {
+	if c.payloadTmpl == nil {
+		if c.contentType == configpb.Webhook_FORM {
+			form := url.Values{}
+			for k, v := range fields {
+				form.Set(k, v)
+			}
+			return []byte(form.Encode()), nil
+		}
+		return json.Marshal(fields)
+	}
+
+	payload, err := execute(c.payloadTmpl, fields)
+	if err != nil {
+		return nil, fmt.Errorf("error executing payload template: %v", err)
+	}
+	if c.contentType == configpb.Webhook_JSON && !json.Valid([]byte(payload)) {
+		return nil, fmt.Errorf("payload is not a valid JSON: %s", payload)
+	}
+	return []byte(payload), nil
+}
+
+// newRequest creates a new HTTP request for the alert fields. It's called
+// for every attempt as request body can't be reused.
+func (c *Client) newRequest(ctx context.Context, fields map[string]string, payload []byte) (*http.Request, error) {
+	url, err := execute(c.urlTmpl, fields)
+	if err != nil {
+		return nil, fmt.Errorf("error executing url template: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.contentType == configpb.Webhook_FORM {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, tmpl := range c.headerTmpls {
+		v, err := execute(tmpl, fields)
+		if err != nil {
+			return nil, fmt.Errorf("error executing header (%s) template: %v", k, err)
+		}
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// send sends the request once. It returns whether the request can be
+// retried along with the error.
+func (c *Client) send(req *http.Request) (retriable bool, err error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("webhook returned error; statusCode: %d, response: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+func (c *Client) updateStats(success bool, retries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if success {
+		c.stats.Success++
+	} else {
+		c.stats.Failure++
+	}
+	c.stats.Retries += int64(retries)
+}
+
+// Stats returns the delivery stats.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Notify sends a notification to the webhook, retrying on failures.
+func (c *Client) Notify(ctx context.Context, fields map[string]string) error {
+	_, err := c.NotifyWithRetries(ctx, fields)
+	return err
+}
+
+// NotifyWithRetries is like Notify, but also returns the number of retries.
+func (c *Client) NotifyWithRetries(ctx context.Context, fields map[string]string) (int, error) {
+	payload, err := c.payload(fields)
+	if err != nil {
+		c.updateStats(false, 0)
+		return 0, err
+	}
+
+	backoff := c.initialBackoff
+	var attempt int
+	for attempt = 0; ; attempt++ {
+		var req *http.Request
+		req, err = c.newRequest(ctx, fields, payload)
+		if err != nil {
+			break
+		}
+
+		var retriable bool
+		retriable, err = c.send(req)
+		if err == nil || !retriable || attempt >= c.maxRetries {
+			break
+		}
+
+		c.l.Warningf("Webhook notification failed (attempt %d), retrying in %v: %v", attempt+1, backoff, err)
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("%v (context done: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		backoff *= 2
+	}
+
+	c.updateStats(err == nil, attempt)
+	return attempt, err
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFields = map[string]string{
+	"alert":             "test-alert",
+	"summary":           `Cloudprober alert "test-alert" for "test-target"`,
+	"target.label.team": "infra",
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		cfg     *configpb.Webhook
+		wantErr bool
+	}{
+		"valid": {
+			cfg: &configpb.Webhook{Url: "http://localhost/{{.alert}}"},
+		},
+		"no url": {
+			cfg:     &configpb.Webhook{},
+			wantErr: true,
+		},
+		"bad payload template": {
+			cfg:     &configpb.Webhook{Url: "http://localhost", PayloadTemplate: "{{.alert"},
+			wantErr: true,
+		},
+		"negative retries": {
+			cfg:     &configpb.Webhook{Url: "http://localhost", MaxRetries: -1},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.cfg, nil)
+			assert.Equal(t, tc.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func TestPayload(t *testing.T) {
+	tests := map[string]struct {
+		cfg     *configpb.Webhook
+		want    string
+		wantErr bool
+	}{
+		"default json": {
+			cfg:  &configpb.Webhook{},
+			want: `{"alert":"test-alert","summary":"Cloudprober alert \"test-alert\" for \"test-target\"","target.label.team":"infra"}`,
+		},
+		"default form": {
+			cfg:  &configpb.Webhook{ContentType: configpb.Webhook_FORM},
+			want: "alert=test-alert&summary=Cloudprober+alert+%22test-alert%22+for+%22test-target%22&target.label.team=infra",
+		},
+		"json template": {
+			cfg:  &configpb.Webhook{PayloadTemplate: `{"title": {{json .summary}}, "team": {{json (index . "target.label.team")}}}`},
+			want: `{"title": "Cloudprober alert \"test-alert\" for \"test-target\"", "team": "infra"}`,
+		},
+		"form template": {
+			cfg:  &configpb.Webhook{ContentType: configpb.Webhook_FORM, PayloadTemplate: `title={{urlquery .summary}}`},
+			want: "title=Cloudprober+alert+%22test-alert%22+for+%22test-target%22",
+		},
+		"invalid json": {
+			cfg:     &configpb.Webhook{PayloadTemplate: `{"title": {{.summary}}}`},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.cfg.Url = "http://localhost"
+			c, err := New(tc.cfg, nil)
+			assert.NoError(t, err)
+
+			got, err := c.payload(testFields)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_TOKEN", "test-token")
+
+	var statusCodes []int
+	var gotPath, gotAuth, gotBody string
+	numRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(statusCodes[numRequests])
+		numRequests++
+	}))
+	defer ts.Close()
+
+	c, err := New(&configpb.Webhook{
+		Url:                ts.URL + "/alerts/{{.alert}}",
+		Header:             map[string]string{"Authorization": `Bearer {{env "TEST_WEBHOOK_TOKEN"}}`},
+		PayloadTemplate:    `{"title": {{json .summary}}}`,
+		MaxRetries:         2,
+		InitialBackoffMsec: 1,
+	}, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc         string
+		statusCodes  []int
+		wantErr      bool
+		wantRequests int
+		wantStats    Stats
+	}{
+		{
+			desc:         "success",
+			statusCodes:  []int{http.StatusOK},
+			wantRequests: 1,
+			wantStats:    Stats{Success: 1},
+		},
+		{
+			desc:         "success after retries",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusAccepted},
+			wantRequests: 3,
+			wantStats:    Stats{Success: 2, Retries: 2},
+		},
+		{
+			desc:         "retries exhausted",
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantErr:      true,
+			wantRequests: 3,
+			wantStats:    Stats{Success: 2, Failure: 1, Retries: 4},
+		},
+		{
+			desc:         "no retry on client error",
+			statusCodes:  []int{http.StatusBadRequest},
+			wantErr:      true,
+			wantRequests: 1,
+			wantStats:    Stats{Success: 2, Failure: 2, Retries: 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			statusCodes, numRequests = test.statusCodes, 0
+
+			err := c.Notify(context.Background(), testFields)
+			assert.Equal(t, test.wantErr, err != nil, "error: %v", err)
+			assert.Equal(t, test.wantRequests, numRequests)
+			assert.Equal(t, test.wantStats, c.Stats())
+
+			assert.Equal(t, "/alerts/test-alert", gotPath)
+			assert.Equal(t, "Bearer test-token", gotAuth)
+			assert.Equal(t, `{"title": "Cloudprober alert \"test-alert\" for \"test-target\""}`, gotBody)
+		})
+	}
+}
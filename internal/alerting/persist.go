@@ -0,0 +1,237 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// StateStore is a backend for persisting the alerts state. FileStore is the
+// default implementation, other implementations (e.g. a KV store) can be set
+// using SetStateStore.
+type StateStore interface {
+	// Load returns the persisted state, or nil if there is no state yet.
+	Load() ([]byte, error)
+	Save(data []byte) error
+}
+
+// FileStore persists the alerts state in a local file.
+type FileStore struct {
+	Path string
+}
+
+// Load implements StateStore.Load.
+func (s *FileStore) Load() ([]byte, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save implements StateStore.Save. It writes to a temporary file and renames
+// it, so that a crash while saving doesn't corrupt the state.
+func (s *FileStore) Save(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// persistedAlert is the persisted state of a firing alert.
+type persistedAlert struct {
+	AlertInfo    *alertinfo.AlertInfo `json:"alert_info"`
+	LastNotified time.Time            `json:"last_notified"`
+	Notified     bool                 `json:"notified"`
+}
+
+type persistedState struct {
+	Alerts   map[string]*persistedAlert `json:"alerts"` // Keyed by global key.
+	Silences []*Silence                 `json:"silences,omitempty"`
+}
+
+// persister keeps the persisted alerts state in sync with the store.
+type persister struct {
+	mu     sync.Mutex
+	store  StateStore
+	alerts map[string]*persistedAlert
+
+	// Restored alerts, not yet claimed by the alert handlers.
+	restored map[string]*persistedAlert
+	l        *logger.Logger
+}
+
+var globalPersister = &persister{}
+
+// SetStateStore sets the store to persist the alerts state to, and restores
+// the state from it. It should be called before the probes start running.
+func SetStateStore(store StateStore, l *logger.Logger) error {
+	return globalPersister.init(store, l)
+}
+
+// Init initializes the global alerting options.
+func Init(opts *configpb.AlertingOptions, l *logger.Logger) error {
+	globalEscalators.pagerDutySecret = opts.GetPagerdutyWebhookSecret()
+	notifier.SetDeadLetterFile(opts.GetDeadLetterFile())
+	if err := globalHistory.init(opts, l); err != nil {
+		return err
+	}
+
+	if opts.GetStateFile() == "" {
+		return nil
+	}
+	return SetStateStore(&FileStore{Path: opts.GetStateFile()}, l)
+}
+
+func (p *persister) init(store StateStore, l *logger.Logger) error {
+	data, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("error loading alerts state: %v", err)
+	}
+
+	var st persistedState
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &st); err != nil {
+			return fmt.Errorf("error parsing alerts state: %v", err)
+		}
+	}
+
+	for _, s := range st.Silences {
+		if err := globalSilences.restore(s); err != nil {
+			l.Warningf("Error restoring silence %s: %v", s.ID, err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.store, p.l = store, l
+	p.alerts = make(map[string]*persistedAlert)
+	p.restored = st.Alerts
+	l.Infof("Restored alerts state: %d alerts, %d silences", len(st.Alerts), len(st.Silences))
+	return nil
+}
+
+// claim returns the restored state for the alert, if any.
+func (p *persister) claim(key string) *persistedAlert {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pa := p.restored[key]
+	if pa != nil {
+		delete(p.restored, key)
+		p.alerts[key] = pa
+	}
+	return pa
+}
+
+// restoreState restores the target state from the persisted alert. Alert is
+// considered failing for the condition, so that it resolves only after the
+// target recovers.
+func (ah *AlertHandler) restoreState(ts *targetState, ep endpoint.Endpoint, pa *persistedAlert) {
+	ah.l.Infof("ALERT (%s): restored firing alert for target (%s), failing since (%v)", ah.name, ep.Name, pa.AlertInfo.FailingSince)
+
+	ts.alerted = true
+	ts.failingSince = pa.AlertInfo.FailingSince
+	ts.alertTS = pa.LastNotified
+	ts.notified = pa.Notified
+	ts.ep = ep
+	for i := len(ts.failures) - 1; i >= 0 && i >= len(ts.failures)-int(ah.condition.Failures); i-- {
+		ts.failures[i] = true
+	}
+
+	globalState.add(ah.globalKey(ep), pa.AlertInfo)
+	if ah.grouper != nil && pa.Notified {
+		ah.grouper.restore(pa.AlertInfo, pa.LastNotified)
+	}
+	if ah.composite == nil {
+		globalComposites.update(ah.name, ep, true)
+	}
+}
+
+// save saves the state to the store. It should be called with the lock held.
+func (p *persister) save() {
+	st := persistedState{
+		Alerts:   make(map[string]*persistedAlert, len(p.alerts)+len(p.restored)),
+		Silences: globalSilences.list(),
+	}
+	// Keep the restored alerts that are not claimed yet, e.g. if probe
+	// hasn't run yet.
+	for k, pa := range p.restored {
+		st.Alerts[k] = pa
+	}
+	for k, pa := range p.alerts {
+		st.Alerts[k] = pa
+	}
+
+	data, err := json.Marshal(st)
+	if err == nil {
+		err = p.store.Save(data)
+	}
+	if err != nil {
+		p.l.Errorf("Error saving alerts state: %v", err)
+	}
+}
+
+func (p *persister) setAlert(key string, pa *persistedAlert) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.store == nil {
+		return
+	}
+	p.alerts[key] = pa
+	p.save()
+}
+
+func (p *persister) deleteAlert(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.store == nil {
+		return
+	}
+	delete(p.alerts, key)
+	p.save()
+}
+
+// silencesChanged saves the state after the silences change.
+func (p *persister) silencesChanged() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.store == nil {
+		return
+	}
+	p.save()
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	s := &FileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+
+	data, err := s.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	assert.NoError(t, s.Save([]byte(`{"alerts":{}}`)))
+	data, err = s.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"alerts":{}}`, string(data))
+}
+
+func TestPersistedState(t *testing.T) {
+	oldPersister, oldSilences := globalPersister, globalSilences
+	defer func() { globalPersister, globalSilences = oldPersister, oldSilences }()
+
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	l := &logger.Logger{}
+
+	conf := &configpb.AlertConf{
+		Name:      "persist-test",
+		Condition: &configpb.Condition{Failures: 1, Total: 1},
+	}
+	ep := endpoint.Endpoint{Name: "target1", Port: 80}
+	record := func(ah *AlertHandler, total, success int64) {
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success))
+		ah.Record(ep, em)
+	}
+	newAH := func() *AlertHandler {
+		t.Helper()
+		ah, err := NewAlertHandler(conf, "test-probe", nil)
+		assert.NoError(t, err)
+		ah.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+		return ah
+	}
+
+	// First run: alert fires and a silence is added.
+	globalPersister, globalSilences = &persister{}, &silences{}
+	assert.NoError(t, SetStateStore(store, l))
+	assert.NoError(t, globalSilences.add(&Silence{
+		Matchers: []*configpb.Matcher{{Name: "probe", Value: "other-probe"}},
+		EndsAt:   time.Now().Add(time.Hour),
+	}))
+	globalPersister.silencesChanged()
+
+	ah := newAH()
+	record(ah, 1, 1)
+	record(ah, 2, 1)
+	assert.Len(t, ah.notifyCh, 1)
+	alertTS := ah.targets[ep.Key()].alertTS
+
+	// Restart: alert state is restored, and no duplicate notification is
+	// sent while the target keeps failing.
+	globalPersister, globalSilences = &persister{}, &silences{}
+	assert.NoError(t, SetStateStore(store, l))
+	assert.Len(t, globalSilences.list(), 1)
+
+	ah = newAH()
+	record(ah, 1, 0)
+	ts := ah.targets[ep.Key()]
+	assert.True(t, ts.alerted)
+	assert.True(t, ts.notified)
+	assert.True(t, ts.alertTS.Equal(alertTS), "alertTS=%v, want=%v", ts.alertTS, alertTS)
+	assert.NotNil(t, globalState.get(ah.globalKey(ep)))
+
+	record(ah, 2, 0)
+	assert.Len(t, ah.notifyCh, 0)
+
+	// Target recovers, alert resolves and is removed from the state.
+	record(ah, 3, 1)
+	assert.False(t, ah.targets[ep.Key()].alerted)
+	assert.Nil(t, globalState.get(ah.globalKey(ep)))
+
+	globalPersister = &persister{}
+	assert.NoError(t, SetStateStore(store, l))
+	assert.Len(t, globalPersister.restored, 0)
+}
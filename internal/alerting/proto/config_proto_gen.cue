@@ -19,6 +19,50 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 
 	// Default: Environment variable SMTP_PASSWORD
 	smtpPassword?: string @protobuf(5,string,name=smtp_password)
+
+	#TLSMode: {
+		// Use STARTTLS if server supports it.
+		"STARTTLS_OPPORTUNISTIC"
+		#enumValue: 0
+	} | {
+		// Require STARTTLS, fail if server doesn't support it.
+		"STARTTLS"
+		#enumValue: 1
+	} | {
+		// Use implicit TLS (SMTPS), usually on port 465.
+		"TLS"
+		#enumValue: 2
+	} | {
+		// Don't use TLS.
+		"NONE"
+		#enumValue: 3
+	}
+
+	#TLSMode_value: {
+		STARTTLS_OPPORTUNISTIC: 0
+		STARTTLS:               1
+		TLS:                    2
+		NONE:                   3
+	}
+	tlsMode?: #TLSMode @protobuf(6,TLSMode,name=tls_mode) // Default: STARTTLS_OPPORTUNISTIC
+
+	// Skip verification of the SMTP server's certificate.
+	insecureSkipVerify?: bool @protobuf(7,bool,name=insecure_skip_verify)
+
+	// Email subject and body templates. Templates can use the alert fields,
+	// e.g. "[@severity@] @alert@ failing for @target@".
+	// Default subject: "@summary@"
+	// Default body: details followed by all the alert fields.
+	subjectTemplate?: string @protobuf(8,string,name=subject_template)
+	bodyTemplate?:    string @protobuf(9,string,name=body_template)
+
+	// Recipients to use for the "to" addresses that can't be fully expanded,
+	// e.g. "@target.label.owner@" for a target without the owner label.
+	fallbackTo?: [...string] @protobuf(10,string,name=fallback_to)
+
+	// Whether to send resolve notifications or not. Default is to send resolve
+	// notifications.
+	disableSendResolved?: bool @protobuf(11,bool,name=disable_send_resolved) // Default: false
 }
 
 #Opsgenie: {
@@ -68,6 +112,32 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 	// Whether to send resolve notifications or not. Default is to send resolve
 	// notifications.
 	disableSendResolved?: bool @protobuf(5,bool,name=disable_send_resolved) // Default: false
+
+	// Mapping from the alert severity (CRITICAL, ERROR, WARNING, INFO) to the
+	// Opsgenie priority (P1 to P5). Default mapping is CRITICAL: P1, ERROR: P2,
+	// WARNING: P3, INFO: P4. Alerts without severity get P2, unless mapped
+	// using the UNKNOWN_SEVERITY key.
+	// Example:
+	//  priority_map {
+	//    key: "WARNING"
+	//    value: "P4"
+	//  }
+	priorityMap?: {
+		[string]: string
+	} @protobuf(6,map[string]string,priority_map)
+
+	// Responders from the alert labels: label name to responder type (user,
+	// team, escalation or schedule). Label values are used as the responder
+	// names, and can be comma-separated lists. For example, with the
+	// following config, alert label "team: db-oncall" adds the team
+	// "db-oncall" as responder:
+	//  responder_labels {
+	//    key: "team"
+	//    value: "team"
+	//  }
+	responderLabels?: {
+		[string]: string
+	} @protobuf(7,map[string]string,responder_labels)
 }
 
 #PagerDuty: {
@@ -104,6 +174,268 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 
 	// The environment variable that is used to contain the slack webhook URL.
 	webhookUrlEnvVar?: string @protobuf(2,string,name=webhook_url_env_var) // Default: SLACK_WEBHOOK_URL;
+
+	// Bot token
+	// If bot token is set (directly or through bot_token_env_var), Slack
+	// notifications are sent using the chat.postMessage API instead of the
+	// webhook. Bot token mode requires the channel to be set.
+	// https://api.slack.com/methods/chat.postMessage
+	botToken?: string @protobuf(3,string,name=bot_token)
+
+	// The environment variable that is used to contain the slack bot token.
+	// Unlike webhook_url_env_var, there is no default for this field.
+	botTokenEnvVar?: string @protobuf(4,string,name=bot_token_env_var)
+
+	// Channel to send the notifications to. Channel is required in the bot
+	// token mode, and is ignored for the webhooks that are tied to a channel.
+	// Channel can use alert fields for per-alert routing, for example:
+	//   channel: "#alerts-@target.label.team@"
+	channel?: string @protobuf(5,string)
+
+	// Channel to use if channel can't be fully expanded, e.g. if target
+	// doesn't have the label used in the channel.
+	fallbackChannel?: string @protobuf(6,string,name=fallback_channel)
+
+	// Block Kit blocks template, as a JSON array. Alert fields are substituted
+	// in the template (values are JSON escaped), for example:
+	//   blocks_template: '[{"type": "header", "text": {"type": "plain_text", "text": "@summary@"}}, '
+	//                    ' {"type": "section", "text": {"type": "mrkdwn", "text": "@details@"}}]'
+	// If not set, only a text message is sent.
+	// https://api.slack.com/block-kit
+	blocksTemplate?: string @protobuf(7,string,name=blocks_template)
+
+	// Maximum number of messages sent to a channel per minute. Messages over
+	// this limit are dropped. Set it to a negative value to disable rate
+	// limiting.
+	maxMessagesPerMinute?: int32 @protobuf(8,int32,name=max_messages_per_minute) // Default: 20
+
+	// Slack API URL, used in the bot token mode.
+	apiUrl?: string @protobuf(9,string,name=api_url) // Default: https://slack.com/api/chat.postMessage
+}
+
+#Teams: {
+	// Webhook URL
+	// Microsoft Teams notifications are sent as adaptive cards to a Teams
+	// incoming webhook. Webhook URL can be created using the "Incoming
+	// Webhook" connector or the Workflows app.
+	// https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/add-incoming-webhook
+	// Note: set either webhook_url or webhook_url_env_var. webhook_url
+	// takes precedence over webhook_url_env_var.
+	webhookUrl?: string @protobuf(1,string,name=webhook_url)
+
+	// The environment variable that is used to contain the Teams webhook URL.
+	webhookUrlEnvVar?: string @protobuf(2,string,name=webhook_url_env_var) // Default: TEAMS_WEBHOOK_URL;
+
+	// Adaptive card template, as a JSON object. Alert fields are substituted
+	// in the template (values are JSON escaped). In addition to the standard
+	// alert fields, @failure_ratio@ is available in the template. If not set,
+	// a default card with probe, target, failure ratio and links to the
+	// dashboard and playbook is used.
+	// https://adaptivecards.io/designer/
+	cardTemplate?: string @protobuf(3,string,name=card_template)
+}
+
+#Webhook: {
+	// URL to send the notification to. URL is expanded as a Go template
+	// over the alert fields, e.g. "https://alerts.example.com/{{.probe}}".
+	url?: string @protobuf(1,string)
+
+	// HTTP method.
+	method?: string @protobuf(2,string) // Default: POST
+
+	// HTTP headers, e.g. for authentication. Header values are expanded as
+	// Go templates, and "env" function can be used to read the environment
+	// variables, for example:
+	//   header {
+	//     key: "Authorization"
+	//     value: "Bearer {{env \"WEBHOOK_TOKEN\"}}"
+	//   }
+	header?: {
+		[string]: string
+	} @protobuf(3,map[string]string)
+
+	#ContentType: {"JSON", #enumValue: 0} |
+		{"FORM", #enumValue: 1}
+
+	#ContentType_value: {
+		JSON: 0
+		FORM: 1
+	}
+
+	// Payload content type. JSON payloads are validated before sending.
+	contentType?: #ContentType @protobuf(4,ContentType,name=content_type) // Default: JSON
+
+	// Payload template, a Go template over the alert fields. Field names with
+	// dots can be accessed using the index function, e.g.
+	// {{index . "target.label.team"}}. Helper functions "json" (JSON encode
+	// a value) and "urlquery" (URL encode a value) can be used to escape the
+	// fields. Example:
+	//   payload_template: '{"title": {{json .summary}}, "body": {{json .details}}}'
+	// If not set, for JSON all alert fields are sent as a JSON object, and for
+	// FORM all alert fields are sent as form fields.
+	payloadTemplate?: string @protobuf(5,string,name=payload_template)
+
+	// Maximum number of retries. Requests are retried on network errors,
+	// HTTP 429 and HTTP 5xx responses.
+	maxRetries?: int32 @protobuf(6,int32,name=max_retries) // Default: 3
+
+	// Initial backoff between retries. Backoff doubles after each retry.
+	initialBackoffMsec?: int32 @protobuf(7,int32,name=initial_backoff_msec) // Default: 500
+
+	// Timeout for each request.
+	timeoutMsec?: int32 @protobuf(8,int32,name=timeout_msec) // Default: 10000
+}
+
+#SNS: {
+	// ARN of the SNS topic to publish alert events to. Alert events are
+	// published as JSON objects, with status, alert, probe and severity
+	// message attributes that can be used in the subscription filter
+	// policies. For FIFO topics, deduplication ID of the alert is used as the
+	// message group ID.
+	topicArn?: string @protobuf(1,string,name=topic_arn)
+
+	// AWS region. If not set, region is derived from the topic ARN.
+	region?: string @protobuf(2,string)
+
+	// Whether to send resolve events or not. Default is to send resolve
+	// events.
+	disableSendResolved?: bool @protobuf(3,bool,name=disable_send_resolved) // Default: false
+}
+
+#PubSub: {
+	// GCP project of the topic. If not set, and running on GCE, project
+	// is discovered from the metadata server.
+	project?: string @protobuf(1,string)
+
+	// Pub/Sub topic name to publish alert events to. Alert events are
+	// published as JSON objects, with status, alert, probe and severity
+	// message attributes that can be used in the subscription filters.
+	topic?: string @protobuf(2,string)
+
+	// Whether to send resolve events or not. Default is to send resolve
+	// events.
+	disableSendResolved?: bool @protobuf(3,bool,name=disable_send_resolved) // Default: false
+}
+
+#Alertmanager: {
+	// Alertmanager URLs, e.g. "http://alertmanager:9093". Alerts are sent to
+	// the v2 API (/api/v2/alerts) of all the configured Alertmanagers, like
+	// Prometheus does for Alertmanager HA setups.
+	url?: [...string] @protobuf(1,string)
+
+	// Additional labels to attach to the alerts. Label values can use the
+	// alert fields, e.g.:
+	//   labels {
+	//     key: "team"
+	//     value: "@target.label.team@"
+	//   }
+	// Labels that can't be fully expanded are skipped. Following labels are
+	// always set: alertname, probe, target, and severity (if configured).
+	labels?: {
+		[string]: string
+	} @protobuf(2,map[string]string)
+
+	// HTTP headers to add to the requests, e.g. for authentication.
+	header?: {
+		[string]: string
+	} @protobuf(3,map[string]string)
+
+	// Firing alerts are re-sent to Alertmanager at this interval, as
+	// Alertmanager resolves the alerts that are not refreshed. Alerts are
+	// sent with endsAt set to 4 times the resend interval.
+	resendIntervalSec?: int32 @protobuf(4,int32,name=resend_interval_sec) // Default: 60
+}
+
+// Matcher matches an alert field, e.g. severity, alert name, target label or
+// a routing label.
+#Matcher: {
+	// Alert field name, e.g. "severity", "alert", "probe", "target",
+	// "target.label.<key>", or a key from the alert labels.
+	name?: string @protobuf(1,string)
+
+	// Value to match. If regex is set, value is treated as a RE2 regex
+	// anchored at both ends.
+	value?: string @protobuf(2,string)
+	regex?: bool   @protobuf(3,bool)
+
+	// Match if the field doesn't match the value.
+	negate?: bool @protobuf(4,bool)
+}
+
+// Global alerting options, configured at the top level of the cloudprober
+// config.
+#AlertingOptions: {
+	// File to persist the alerts state (firing alerts, notification times and
+	// silences) to, so that a cloudprober restart doesn't result in duplicate
+	// notifications for the ongoing incidents or in lost silences.
+	// State is restored on startup and saved on every change.
+	stateFile?: string @protobuf(1,string,name=state_file)
+
+	// Secret to verify the PagerDuty webhook (v3) signatures, received at the
+	// /alerts/ack/pagerduty endpoint. If not set, signatures are not verified.
+	pagerdutyWebhookSecret?: string @protobuf(2,string,name=pagerduty_webhook_secret)
+
+	// Number of alert events (fired, resolved, escalated, acknowledged) to
+	// keep in the alerts history, available at /alerts/history.
+	historySize?: int32 @protobuf(3,int32,name=history_size) // Default: 1000
+
+	// File to keep the alerts history in, so that it survives restarts. If
+	// not set, history is kept in memory only.
+	historyFile?: string @protobuf(4,string,name=history_file)
+
+	// File to write the undeliverable notifications to, one JSON object per
+	// line, e.g. to replay them or to look into the notifier failures.
+	deadLetterFile?: string @protobuf(5,string,name=dead_letter_file)
+}
+
+// Silence is a recurring silence window, e.g. for planned maintenance. While
+// an alert is silenced, its state is still tracked but notifications are not
+// sent. Ad-hoc silences can be added through the /alerts/silences HTTP API.
+// Example:
+//   silence {
+//     weekday: ["Sat", "Sun"]
+//     start_time: "22:00"
+//     end_time: "02:00"
+//     timezone: "Europe/London"
+//   }
+#Silence: {
+	// Silence only the alerts that match all the matchers. Default is to
+	// silence all the alerts.
+	matcher?: [...#Matcher] @protobuf(1,Matcher)
+
+	// Days of the week (e.g. "Mon", "Tuesday") the window starts on.
+	// Default is every day.
+	weekday?: [...string] @protobuf(2,string)
+
+	// Window start and end time, in HH:MM format. If end_time is before
+	// start_time, window ends on the next day.
+	startTime?: string @protobuf(3,string,name=start_time)
+	endTime?:   string @protobuf(4,string,name=end_time)
+
+	// Timezone for the window, e.g. "America/New_York". Default is UTC.
+	timezone?: string @protobuf(5,string)
+	comment?:  string @protobuf(6,string)
+}
+
+// Grouping groups the alerts for multiple targets into one notification. For
+// example, if a probe fails for 200 targets at once, a single notification
+// listing all the affected targets is sent.
+#Grouping: {
+	// Alert fields to group alerts by, e.g. "target.label.region". Default
+	// is to group all the targets of an alert together.
+	groupBy?: [...string] @protobuf(1,string,name=group_by)
+
+	// How long to wait for more alerts before sending the first notification
+	// for a group.
+	groupWaitSec?: int32 @protobuf(2,int32,name=group_wait_sec) // Default: 30s
+
+	// How long to wait before notifying about the changes (new or resolved
+	// targets) in a group that has already been notified.
+	groupIntervalSec?: int32 @protobuf(3,int32,name=group_interval_sec) // Default: 5min
+
+	// How often to repeat the notification for an unchanged group. Default
+	// is to use the alert's repeat_interval_sec.
+	repeatIntervalSec?: int32 @protobuf(4,int32,name=repeat_interval_sec)
 }
 
 #NotifyConfig: {
@@ -140,6 +472,22 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 	// Opsgenie configuration.
 	opsgenie?: #Opsgenie @protobuf(14,Opsgenie)
 
+	// Microsoft Teams configuration.
+	teams?: #Teams @protobuf(15,Teams)
+
+	// Generic webhook configuration. Unlike http_notify, webhook uses Go
+	// templates for the payload, and retries failed requests.
+	webhook?: #Webhook @protobuf(16,Webhook)
+
+	// AWS SNS configuration.
+	sns?: #SNS @protobuf(17,SNS)
+
+	// GCP Pub/Sub configuration.
+	pubsub?: #PubSub @protobuf(18,PubSub)
+
+	// Prometheus Alertmanager configuration.
+	alertmanager?: #Alertmanager @protobuf(19,Alertmanager)
+
 	// Notify using an HTTP request. HTTP request fields are expanded using the
 	// same template expansion rules as "command" above:
 	// For example, to send a notification using rest API:
@@ -153,11 +501,84 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 	//    data: "{\"message\": \"@alert@ fired for @target@\", \"details\": \"name\"}"
 	//  }
 	httpNotify?: proto.#HTTPRequest @protobuf(3,utils.httpreq.HTTPRequest,name=http_notify)
+
+	// Matchers for this notification config. If matchers are specified,
+	// notifications are sent only for the alerts that match all of them.
+	// Example, to page only for critical alerts:
+	//  matcher {
+	//    name: "severity"
+	//    value: "CRITICAL"
+	//  }
+	matcher?: [...#Matcher] @protobuf(20,Matcher)
+}
+
+// LatencyCondition is a threshold on the probe latency. With a latency
+// condition, each metrics export interval is considered a sample, and an
+// interval is considered failed if latency in that interval is above the
+// threshold. Condition's failures and total then refer to the intervals,
+// e.g. "p99 > 500ms for 5 of 10 intervals":
+//   condition {
+//     failures: 5
+//     total: 10
+//     latency {
+//       threshold_msec: 500
+//       percentile: 99
+//     }
+//   }
+#LatencyCondition: {
+	// Latency threshold in milliseconds.
+	thresholdMsec?: float64 @protobuf(1,double,name=threshold_msec)
+
+	// Latency percentile to compare with the threshold, e.g. 99 for p99.
+	// Percentiles require the latency distribution (latency_distribution in
+	// the probe config). If not specified, average latency is used.
+	percentile?: float64 @protobuf(2,double)
+
+	// Latency metric name.
+	metricName?: string @protobuf(3,string,name=metric_name) // Default: "latency"
+}
+
+// Composite alert condition, combining other alerts, possibly for different
+// probes, using boolean logic. Composite alerts are evaluated per target name,
+// e.g. expression "http_alert && ping_alert" will fire for a target only if
+// both the alerts "http_alert" and "ping_alert" are firing for it.
+#Composite: {
+	// Boolean expression over alert names. Supported operators: && (AND),
+	// || (OR), ! (NOT), and parentheses, e.g. "http && !maintenance".
+	expression?: string @protobuf(1,string)
+}
+
+// EscalationStage is a notification stage, notified if the alert is not
+// acknowledged within after_sec of the first notification.
+#EscalationStage: {
+	afterSec?: int32         @protobuf(1,int32,name=after_sec)
+	notify?:   #NotifyConfig @protobuf(2,NotifyConfig)
+}
+
+// Escalation policy for the alert. Alert's notify config is the first stage,
+// and the escalation stages are notified, in order, while the alert stays
+// unacknowledged. Alerts are acknowledged using the /alerts/ack HTTP API, or
+// through a PagerDuty webhook (/alerts/ack/pagerduty). Example:
+//   escalation {
+//     stage {
+//       after_sec: 900
+//       notify { slack { webhook_url: "..." } }
+//     }
+//     stage {
+//       after_sec: 1800
+//       notify { email { to: "oncall-manager@example.com" } }
+//     }
+//   }
+#Escalation: {
+	stage?: [...#EscalationStage] @protobuf(1,EscalationStage)
 }
 
 #Condition: {
 	failures?: int32 @protobuf(1,int32)
 	total?:    int32 @protobuf(2,int32)
+
+	// Alert on latency, instead of probe failures.
+	latency?: #LatencyCondition @protobuf(3,LatencyCondition)
 }
 
 #AlertConf: {
@@ -219,6 +640,34 @@ import "github.com/cloudprober/cloudprober/internal/httpreq/proto"
 	}
 	severity?: #Severity @protobuf(10,Severity)
 
+	// Routing labels of the alert. Label values are expanded using the
+	// alert fields, e.g. "@target.label.tier@". Labels are available as alert
+	// fields, can be used in the notification matchers, and are sent as
+	// labels/attributes by the notifiers that support labels (Alertmanager,
+	// SNS, Pub/Sub).
+	labels?: {
+		[string]: string
+	} @protobuf(11,map[string]string)
+
+	// Additional notification routes. Each route is a notify config, usually
+	// with matchers, e.g. page for critical alerts and send warnings to chat.
+	// Alerts are sent to all the matching routes, in addition to "notify".
+	route?: [...#NotifyConfig] @protobuf(12,NotifyConfig)
+
+	// Recurring silence windows for the alert.
+	silence?: [...#Silence] @protobuf(13,Silence)
+
+	// Group alerts for multiple targets into one notification.
+	grouping?: #Grouping @protobuf(14,Grouping)
+
+	// Composite alert, based on the other alerts' states. Composite alerts
+	// ignore the condition and the probe results.
+	composite?: #Composite @protobuf(15,Composite)
+
+	// Escalation policy: notify more receivers if the alert is not
+	// acknowledged in time.
+	escalation?: #Escalation @protobuf(16,Escalation)
+
 	// How often to repeat notification for the same alert. Default is 1hr.
 	// To disable any kind of notification throttling, set this to 0.
 	repeatIntervalSec?: int32 @protobuf(8,int32,name=repeat_interval_sec) // Default: 1hr
@@ -0,0 +1,316 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/notifier"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/google/uuid"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// recurringSilence is a config-defined recurring silence window.
+type recurringSilence struct {
+	matchers   []*notifier.Matcher
+	days       map[time.Weekday]bool // nil means every day.
+	start, end int                   // Minutes since midnight.
+	loc        *time.Location
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time (%s), should be in HH:MM format", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func newRecurringSilence(c *configpb.Silence) (*recurringSilence, error) {
+	rs := &recurringSilence{loc: time.UTC}
+
+	for _, m := range c.GetMatcher() {
+		nm, err := notifier.NewMatcher(m)
+		if err != nil {
+			return nil, err
+		}
+		rs.matchers = append(rs.matchers, nm)
+	}
+
+	for _, d := range c.GetWeekday() {
+		wd, ok := weekdays[strings.ToLower(d[:min(len(d), 3)])]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday: %s", d)
+		}
+		if rs.days == nil {
+			rs.days = make(map[time.Weekday]bool)
+		}
+		rs.days[wd] = true
+	}
+
+	if c.GetStartTime() == "" || c.GetEndTime() == "" {
+		return nil, errors.New("both start_time and end_time are required")
+	}
+	var err error
+	if rs.start, err = parseClock(c.GetStartTime()); err != nil {
+		return nil, err
+	}
+	if rs.end, err = parseClock(c.GetEndTime()); err != nil {
+		return nil, err
+	}
+
+	if c.GetTimezone() != "" {
+		if rs.loc, err = time.LoadLocation(c.GetTimezone()); err != nil {
+			return nil, fmt.Errorf("invalid timezone (%s): %v", c.GetTimezone(), err)
+		}
+	}
+
+	return rs, nil
+}
+
+func (rs *recurringSilence) dayOK(wd time.Weekday) bool {
+	return rs.days == nil || rs.days[wd]
+}
+
+// active returns true if the silence window is active at the given time.
+func (rs *recurringSilence) active(now time.Time) bool {
+	now = now.In(rs.loc)
+	mins := now.Hour()*60 + now.Minute()
+
+	if rs.start < rs.end {
+		return mins >= rs.start && mins < rs.end && rs.dayOK(now.Weekday())
+	}
+
+	// Window crosses midnight. If we are past midnight, the window started
+	// on the previous day.
+	if mins >= rs.start {
+		return rs.dayOK(now.Weekday())
+	}
+	return mins < rs.end && rs.dayOK((now.Weekday()+6)%7)
+}
+
+func (rs *recurringSilence) match(fields map[string]string, now time.Time) bool {
+	return rs.active(now) && notifier.MatchAll(rs.matchers, fields)
+}
+
+// Silence is an ad-hoc silence, added through the silences API.
+type Silence struct {
+	ID        string              `json:"id"`
+	Matchers  []*configpb.Matcher `json:"matchers"`
+	StartsAt  time.Time           `json:"starts_at"`
+	EndsAt    time.Time           `json:"ends_at"`
+	Comment   string              `json:"comment,omitempty"`
+	CreatedBy string              `json:"created_by,omitempty"`
+
+	matchers []*notifier.Matcher
+}
+
+func (s *Silence) match(fields map[string]string, now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt) && notifier.MatchAll(s.matchers, fields)
+}
+
+type silences struct {
+	mu       sync.Mutex
+	silences map[string]*Silence
+
+	// Used for testing.
+	timeNow func() time.Time
+}
+
+func (ss *silences) now() time.Time {
+	if ss.timeNow != nil {
+		return ss.timeNow()
+	}
+	return time.Now()
+}
+
+// expire removes the expired silences. It should be called with the lock
+// held.
+func (ss *silences) expire(now time.Time) {
+	for id, s := range ss.silences {
+		if !now.Before(s.EndsAt) {
+			delete(ss.silences, id)
+		}
+	}
+}
+
+func (ss *silences) add(s *Silence) error {
+	if len(s.Matchers) == 0 {
+		return errors.New("at least one matcher is required")
+	}
+	for _, m := range s.Matchers {
+		nm, err := notifier.NewMatcher(m)
+		if err != nil {
+			return err
+		}
+		s.matchers = append(s.matchers, nm)
+	}
+
+	now := ss.now()
+	if s.StartsAt.IsZero() {
+		s.StartsAt = now
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("silence should end after it starts")
+	}
+	s.ID = uuid.NewString()
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.silences == nil {
+		ss.silences = make(map[string]*Silence)
+	}
+	ss.silences[s.ID] = s
+	return nil
+}
+
+// restore restores a persisted silence, keeping its ID.
+func (ss *silences) restore(s *Silence) error {
+	for _, m := range s.Matchers {
+		nm, err := notifier.NewMatcher(m)
+		if err != nil {
+			return err
+		}
+		s.matchers = append(s.matchers, nm)
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.silences == nil {
+		ss.silences = make(map[string]*Silence)
+	}
+	ss.silences[s.ID] = s
+	ss.expire(ss.now())
+	return nil
+}
+
+func (ss *silences) delete(id string) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.silences[id] == nil {
+		return false
+	}
+	delete(ss.silences, id)
+	return true
+}
+
+func (ss *silences) list() []*Silence {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ss.expire(ss.now())
+	var result []*Silence
+	for _, s := range ss.silences {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+	return result
+}
+
+func (ss *silences) match(fields map[string]string) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	now := ss.now()
+	ss.expire(now)
+	for _, s := range ss.silences {
+		if s.match(fields, now) {
+			return true
+		}
+	}
+	return false
+}
+
+var globalSilences = &silences{}
+
+// silenceRequest is the request body to add a silence. Either duration
+// (e.g. "2h") or ends_at should be specified.
+type silenceRequest struct {
+	Silence
+	Duration string `json:"duration,omitempty"`
+}
+
+func (ss *silences) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ss.list())
+
+	case http.MethodPost:
+		var req silenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("error parsing silence: %v", err), http.StatusBadRequest)
+			return
+		}
+		s := req.Silence
+		if req.Duration != "" {
+			d, err := time.ParseDuration(req.Duration)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid duration: %v", err), http.StatusBadRequest)
+				return
+			}
+			if s.StartsAt.IsZero() {
+				s.StartsAt = ss.now()
+			}
+			s.EndsAt = s.StartsAt.Add(d)
+		}
+		if err := ss.add(&s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&s)
+
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if !ss.delete(id) {
+			http.Error(w, fmt.Sprintf("silence %s not found", id), http.StatusNotFound)
+		}
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// SilencesHandler serves the silences API:
+//
+//	GET: list the active and pending silences.
+//	POST: add a silence, e.g. {"matchers": [{"name": "probe", "value": "web"}], "duration": "2h"}.
+//	DELETE ?id=<silence-id>: remove a silence.
+func SilencesHandler(w http.ResponseWriter, r *http.Request) {
+	globalSilences.handler(w, r)
+	if r.Method == http.MethodPost || r.Method == http.MethodDelete {
+		globalPersister.silencesChanged()
+	}
+}
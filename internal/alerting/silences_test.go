@@ -0,0 +1,193 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/alerting/alertinfo"
+	configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringSilenceActive(t *testing.T) {
+	// 2024-03-02 is a Saturday.
+	sat := func(hhmm string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2024-03-02 "+hhmm)
+		return t
+	}
+
+	tests := []struct {
+		name    string
+		conf    *configpb.Silence
+		t       time.Time
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "in_window",
+			conf: &configpb.Silence{StartTime: "10:00", EndTime: "12:00"},
+			t:    sat("11:30"),
+			want: true,
+		},
+		{
+			name: "window_end",
+			conf: &configpb.Silence{StartTime: "10:00", EndTime: "12:00"},
+			t:    sat("12:00"),
+			want: false,
+		},
+		{
+			name: "weekday_mismatch",
+			conf: &configpb.Silence{Weekday: []string{"Sun"}, StartTime: "10:00", EndTime: "12:00"},
+			t:    sat("11:00"),
+			want: false,
+		},
+		{
+			name: "across_midnight_before",
+			conf: &configpb.Silence{Weekday: []string{"saturday"}, StartTime: "22:00", EndTime: "02:00"},
+			t:    sat("23:00"),
+			want: true,
+		},
+		{
+			name: "across_midnight_after",
+			conf: &configpb.Silence{Weekday: []string{"Fri"}, StartTime: "22:00", EndTime: "02:00"},
+			t:    sat("01:00"),
+			want: true,
+		},
+		{
+			name: "across_midnight_wrong_day",
+			conf: &configpb.Silence{Weekday: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"},
+			t:    sat("01:00"),
+			want: false,
+		},
+		{
+			name: "timezone",
+			conf: &configpb.Silence{StartTime: "10:00", EndTime: "12:00", Timezone: "Asia/Kolkata"},
+			t:    sat("05:00"), // 10:30 IST
+			want: true,
+		},
+		{
+			name:    "bad_time",
+			conf:    &configpb.Silence{StartTime: "25:00", EndTime: "02:00"},
+			wantErr: true,
+		},
+		{
+			name:    "bad_weekday",
+			conf:    &configpb.Silence{Weekday: []string{"Someday"}, StartTime: "10:00", EndTime: "12:00"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := newRecurringSilence(tt.conf)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rs.active(tt.t))
+		})
+	}
+}
+
+func TestSilencesHandler(t *testing.T) {
+	now := time.Now()
+	ss := &silences{timeNow: func() time.Time { return now }}
+
+	do := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		ss.handler(w, httptest.NewRequest(method, url, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPost, "/alerts/silences", `{"matchers": [{"name": "probe", "value": "web"}], "duration": "1h", "comment": "maintenance"}`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var s Silence
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &s))
+	assert.True(t, now.Add(time.Hour).Equal(s.EndsAt), "ends_at")
+
+	// Bad requests.
+	for _, body := range []string{
+		`{"duration": "1h"}`,
+		`{"matchers": [{"name": "probe", "value": "web"}]}`,
+		`{"matchers": [{"name": "probe", "value": "web"}], "duration": "1x"}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/alerts/silences", body).Code, body)
+	}
+
+	assert.True(t, ss.match(map[string]string{"probe": "web"}))
+	assert.False(t, ss.match(map[string]string{"probe": "dns"}))
+
+	w = do(http.MethodGet, "/alerts/silences", "")
+	var list []*Silence
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list, 1)
+
+	// Silence expires.
+	now = now.Add(2 * time.Hour)
+	assert.False(t, ss.match(map[string]string{"probe": "web"}))
+	assert.Len(t, ss.list(), 0)
+
+	// Delete.
+	now = now.Add(-2 * time.Hour)
+	w = do(http.MethodPost, "/alerts/silences", `{"matchers": [{"name": "probe", "value": "web"}], "duration": "1h"}`)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &s))
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/alerts/silences?id="+s.ID, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/alerts/silences?id="+s.ID, "").Code)
+	assert.False(t, ss.match(map[string]string{"probe": "web"}))
+}
+
+func TestAlertSilenced(t *testing.T) {
+	ah, err := NewAlertHandler(&configpb.AlertConf{
+		Name: "test-alert",
+		Silence: []*configpb.Silence{
+			{
+				Matcher:   []*configpb.Matcher{{Name: "target", Value: "silenced-target"}},
+				StartTime: "00:00",
+				EndTime:   "00:00",
+			},
+		},
+	}, "test-probe", nil)
+	assert.NoError(t, err)
+	ah.notifyCh = make(chan *alertinfo.AlertInfo, 10)
+
+	for _, target := range []string{"silenced-target", "target"} {
+		ep := endpoint.Endpoint{Name: target}
+		for i := 0; i < 2; i++ {
+			em := metrics.NewEventMetrics(time.Time{}.Add(time.Duration(i) * time.Second))
+			em.AddMetric("total", metrics.NewInt(int64(i+1)))
+			em.AddMetric("success", metrics.NewInt(0))
+			ah.Record(ep, em)
+		}
+		// Alert state is tracked for both targets.
+		assert.True(t, ah.targets[ep.Key()].alerted, target)
+		assert.NotNil(t, globalState.get(ah.globalKey(ep)), target)
+	}
+
+	assert.Len(t, ah.notifyCh, 1)
+	assert.Equal(t, "target", (<-ah.notifyCh).Target.Name)
+
+	sm := ah.SuppressedMetrics()
+	assert.NotNil(t, sm)
+	assert.Equal(t, int64(1), sm.Metric("suppressed").(*metrics.Int).Int64())
+	assert.Nil(t, ah.SuppressedMetrics())
+}
@@ -47,7 +47,11 @@ type readFunc func(path string) ([]byte, error)
 type modTimeFunc func(path string) (time.Time, error)
 
 var prefixToReadfunc = map[string]readFunc{
-	"gs://": readFileFromGCS,
+	"gs://":    readFileFromGCS,
+	"s3://":    readFileFromS3,
+	"k8s://":   readK8sConfigMap,
+	"http://":  func(path string) ([]byte, error) { return readHTTP("http://" + path) },
+	"https://": func(path string) ([]byte, error) { return readHTTP("https://" + path) },
 }
 
 var prefixToModTimeFunc = map[string]modTimeFunc{
@@ -82,7 +86,8 @@ func modTimeGCS(objectPath string) (time.Time, error) {
 // ReadFile returns file contents as a slice of bytes. It's similar to ioutil's
 // ReadFile, but includes support for files on non-disk locations. For example,
 // files with paths starting with gs:// are assumed to be on GCS, and are read
-// from GCS.
+// from GCS. Other supported locations are S3 (s3://<bucket>/<key>), HTTP(S)
+// URLs, and Kubernetes ConfigMaps (k8s://<namespace>/<configmap>/<key>).
 func ReadFile(fname string) ([]byte, error) {
 	for prefix, f := range prefixToReadfunc {
 		if strings.HasPrefix(fname, prefix) {
@@ -0,0 +1,139 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// Kubernetes service account files, used to read ConfigMaps from within the
+// cluster.
+var (
+	k8sTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	k8sCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+func httpGet(client *http.Client, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got error while retrieving %s, http status: %s", req.URL.Redacted(), res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func readHTTP(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return httpGet(httpClient, req)
+}
+
+func readFileFromS3(objectPath string) ([]byte, error) {
+	bucket, key, ok := strings.Cut(objectPath, "/")
+	if !ok || bucket == "" || key == "" {
+		return nil, fmt.Errorf("invalid S3 path: s3://%s, expected format: s3://<bucket>/<key>", objectPath)
+	}
+
+	ctx := context.Background()
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %v", err)
+	}
+	out, err := s3.NewFromConfig(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+// readK8sConfigMap reads a key from a Kubernetes ConfigMap, using the pod's
+// service account. Path format: <namespace>/<configmap>/<key>.
+func readK8sConfigMap(path string) ([]byte, error) {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid ConfigMap path: k8s://%s, expected format: k8s://<namespace>/<configmap>/<key>", path)
+	}
+	namespace, name, key := parts[0], parts[1], parts[2]
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables not set")
+	}
+
+	token, err := os.ReadFile(k8sTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account token: %v", err)
+	}
+	caCert, err := os.ReadFile(k8sCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster CA certificate: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("error parsing cluster CA certificate from %s", k8sCAFile)
+	}
+	client := &http.Client{
+		Timeout:   time.Minute,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}},
+	}
+
+	url := fmt.Sprintf("https://%s:%s/api/v1/namespaces/%s/configmaps/%s", host, port, namespace, name)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	b, err := httpGet(client, req)
+	if err != nil {
+		return nil, err
+	}
+	var cm struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(b, &cm); err != nil {
+		return nil, fmt.Errorf("error parsing ConfigMap %s/%s: %v", namespace, name, err)
+	}
+	v, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", key, namespace, name)
+	}
+	return []byte(v), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cloudprober.cfg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "probe {}")
+	}))
+	defer ts.Close()
+
+	b, err := ReadFile(ts.URL + "/cloudprober.cfg")
+	assert.NoError(t, err)
+	assert.Equal(t, "probe {}", string(b))
+
+	_, err = ReadFile(ts.URL + "/missing.cfg")
+	assert.Error(t, err)
+}
+
+func TestReadK8sConfigMap(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/namespaces/monitoring/configmaps/cloudprober" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "ConfigMap", "data": {"cloudprober.cfg": "probe {}"}}`)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	oldTokenFile, oldCAFile := k8sTokenFile, k8sCAFile
+	defer func() { k8sTokenFile, k8sCAFile = oldTokenFile, oldCAFile }()
+	k8sTokenFile, k8sCAFile = filepath.Join(dir, "token"), filepath.Join(dir, "ca.crt")
+
+	if err := os.WriteFile(k8sTokenFile, []byte("test-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(k8sCAFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	host, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	t.Setenv("KUBERNETES_SERVICE_HOST", host)
+	t.Setenv("KUBERNETES_SERVICE_PORT", port)
+
+	b, err := ReadFile("k8s://monitoring/cloudprober/cloudprober.cfg")
+	assert.NoError(t, err)
+	assert.Equal(t, "probe {}", string(b))
+
+	for _, path := range []string{
+		"k8s://monitoring/cloudprober/missing.cfg",
+		"k8s://monitoring/unknown/cloudprober.cfg",
+		"k8s://monitoring/cloudprober",
+	} {
+		_, err := ReadFile(path)
+		assert.Error(t, err, path)
+	}
+}
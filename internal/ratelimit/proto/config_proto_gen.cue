@@ -0,0 +1,37 @@
+package proto
+
+// RateLimit is a token-bucket limit on the outbound probe requests. Limits
+// configured at the top level of the config are shared by all probes, while
+// the limits configured within a probe are shared by its targets only.
+// Probe runs over the limit are skipped, and counted in the
+// "ratelimit_throttled" metric exported with the system variables.
+//
+// Example, to limit the probes to the internal network to 100 requests per
+// second in total:
+//   rate_limit {
+//     name: "internal"
+//     requests_per_sec: 100
+//     destination_network: "10.0.0.0/8"
+//   }
+#RateLimit: {
+	// Name of the limit, used in the metrics. Default: "global-<index>" for the
+	// top-level limits, and "<probe>-<index>" for the probe limits.
+	name?: string @protobuf(1,string)
+
+	// Average number of probe requests allowed per second.
+	requestsPerSec?: float64 @protobuf(2,double,name=requests_per_sec)
+
+	// Maximum number of requests allowed in a burst. Default: requests_per_sec,
+	// rounded up.
+	burst?: int32 @protobuf(3,int32)
+
+	// Limit applies only to the targets in these networks (CIDRs). Targets are
+	// matched using their IP, if the targets provider sets it, or their name if
+	// it's an IP address; they are not resolved for matching.
+	destinationNetwork?: [...string] @protobuf(4,string,name=destination_network)
+
+	// Limit applies only to the targets with these labels.
+	targetLabel?: {
+		[string]: string
+	} @protobuf(5,map[string]string,name=target_label)
+}
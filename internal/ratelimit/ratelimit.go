@@ -0,0 +1,252 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package ratelimit implements token-bucket rate limits on the outbound probe
+requests. Global limits, set through Init, are shared by all probes, while a
+probe's own limits are shared by its targets only.
+
+Probe runs over the limit are skipped by the probes, and counted per limit and
+probe. Counts are exported with the system variables, see ThrottledMetrics.
+*/
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/ratelimit/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// bucket is a token bucket, refilled at the rate tokens per second, up to
+// the burst.
+type bucket struct {
+	mu          sync.Mutex
+	rate, burst float64
+	tokens      float64
+	last        time.Time
+}
+
+// refill adds the tokens accumulated since the last refill. Caller should
+// hold the bucket's lock.
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+type limit struct {
+	name     string
+	b        *bucket
+	networks []*net.IPNet
+	labels   map[string]string
+}
+
+// applies returns true if the limit applies to the endpoint.
+func (lim *limit) applies(ep endpoint.Endpoint) bool {
+	for k, v := range lim.labels {
+		if ep.Labels[k] != v {
+			return false
+		}
+	}
+	if len(lim.networks) == 0 {
+		return true
+	}
+
+	ip := ep.IP
+	if ip == nil {
+		if ip = net.ParseIP(ep.Name); ip == nil {
+			return false
+		}
+	}
+	for _, n := range lim.networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func newLimit(c *configpb.RateLimit, defaultName string) (*limit, error) {
+	if c.GetRequestsPerSec() <= 0 {
+		return nil, fmt.Errorf("rate_limit: invalid requests_per_sec: %v", c.GetRequestsPerSec())
+	}
+	burst := float64(c.GetBurst())
+	if burst == 0 {
+		burst = math.Ceil(c.GetRequestsPerSec())
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("rate_limit: invalid burst: %d", c.GetBurst())
+	}
+
+	lim := &limit{
+		name:   c.GetName(),
+		b:      &bucket{rate: c.GetRequestsPerSec(), burst: burst, tokens: burst},
+		labels: c.GetTargetLabel(),
+	}
+	if lim.name == "" {
+		lim.name = defaultName
+	}
+	for _, s := range c.GetDestinationNetwork() {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("rate_limit: invalid destination_network (%s): %v", s, err)
+		}
+		lim.networks = append(lim.networks, n)
+	}
+	return lim, nil
+}
+
+func newLimits(confs []*configpb.RateLimit, prefix string) ([]*limit, error) {
+	var limits []*limit
+	for i, c := range confs {
+		lim, err := newLimit(c, fmt.Sprintf("%s-%d", prefix, i))
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, lim)
+	}
+	return limits, nil
+}
+
+type throttleKey struct {
+	limit, probe string
+}
+
+// global keeps the global limits and the throttle counts.
+var global = struct {
+	mu        sync.RWMutex
+	limits    []*limit
+	throttled map[throttleKey]int64
+}{
+	throttled: make(map[throttleKey]int64),
+}
+
+// Init sets up the global rate limits, shared by all probes.
+func Init(confs []*configpb.RateLimit) error {
+	limits, err := newLimits(confs, "global")
+	if err != nil {
+		return err
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.limits = limits
+	return nil
+}
+
+// Limiter applies the global and the probe's rate limits to the probe's
+// requests.
+type Limiter struct {
+	probe  string
+	limits []*limit
+}
+
+// New returns a limiter for the probe, with the probe's own limits.
+func New(confs []*configpb.RateLimit, probe string) (*Limiter, error) {
+	limits, err := newLimits(confs, probe)
+	if err != nil {
+		return nil, err
+	}
+	return &Limiter{probe: probe, limits: limits}, nil
+}
+
+// Allow returns true if a probe request to the endpoint is within the rate
+// limits that apply to it. Global limits are checked first. A nil Limiter
+// applies the global limits only.
+func (l *Limiter) Allow(ep endpoint.Endpoint) bool {
+	global.mu.RLock()
+	limits := global.limits
+	global.mu.RUnlock()
+	if l != nil {
+		limits = append(limits[:len(limits):len(limits)], l.limits...)
+	}
+	if len(limits) == 0 {
+		return true
+	}
+
+	var applicable []*limit
+	for _, lim := range limits {
+		if lim.applies(ep) {
+			applicable = append(applicable, lim)
+		}
+	}
+
+	// Buckets are locked in the limits order, globals first, so that
+	// concurrent callers can't deadlock.
+	now := time.Now()
+	for _, lim := range applicable {
+		lim.b.mu.Lock()
+		defer lim.b.mu.Unlock()
+	}
+
+	// Check all the limits first, so that a request rejected by one limit
+	// doesn't use up the tokens of the others.
+	for _, lim := range applicable {
+		lim.b.refill(now)
+		if lim.b.tokens >= 1 {
+			continue
+		}
+
+		probe := ""
+		if l != nil {
+			probe = l.probe
+		}
+		global.mu.Lock()
+		global.throttled[throttleKey{lim.name, probe}]++
+		global.mu.Unlock()
+		return false
+	}
+
+	for _, lim := range applicable {
+		lim.b.tokens--
+	}
+	return true
+}
+
+// ThrottledMetrics returns the number of probe requests throttled by each
+// rate limit, for each probe.
+func ThrottledMetrics(ts time.Time) []*metrics.EventMetrics {
+	global.mu.RLock()
+	defer global.mu.RUnlock()
+
+	keys := make([]throttleKey, 0, len(global.throttled))
+	for k := range global.throttled {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].limit != keys[j].limit {
+			return keys[i].limit < keys[j].limit
+		}
+		return keys[i].probe < keys[j].probe
+	})
+
+	var result []*metrics.EventMetrics
+	for _, k := range keys {
+		result = append(result, metrics.NewEventMetrics(ts).
+			AddMetric("ratelimit_throttled", metrics.NewInt(global.throttled[k])).
+			AddLabel("ptype", "sysvars").
+			AddLabel("probe", "sysvars").
+			AddLabel("rate_limit", k.limit).
+			AddLabel("throttled_probe", k.probe))
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/ratelimit/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+	b := &bucket{rate: 2, burst: 3, tokens: 3}
+	take := func(now time.Time) bool {
+		b.refill(now)
+		if b.tokens < 1 {
+			return false
+		}
+		b.tokens--
+		return true
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, take(now), "burst request %d", i)
+	}
+	assert.False(t, take(now))
+
+	// 2 tokens per second.
+	assert.False(t, take(now.Add(400*time.Millisecond)))
+	assert.True(t, take(now.Add(500*time.Millisecond)))
+	assert.False(t, take(now.Add(600*time.Millisecond)))
+
+	// Refill is capped at the burst.
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, take(later))
+	}
+	assert.False(t, take(later))
+}
+
+func TestLimitApplies(t *testing.T) {
+	lim, err := newLimit(&configpb.RateLimit{
+		RequestsPerSec:     proto.Float64(1),
+		DestinationNetwork: []string{"10.0.0.0/8", "fd00::/8"},
+		TargetLabel:        map[string]string{"env": "prod"},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test", lim.name)
+
+	prod := map[string]string{"env": "prod"}
+	for _, tt := range []struct {
+		ep   endpoint.Endpoint
+		want bool
+	}{
+		{endpoint.Endpoint{Name: "10.1.2.3", Labels: prod}, true},
+		{endpoint.Endpoint{Name: "fd00::1", Labels: prod}, true},
+		{endpoint.Endpoint{Name: "vm-1", IP: net.ParseIP("10.1.2.3"), Labels: prod}, true},
+		{endpoint.Endpoint{Name: "vm-1", Labels: prod}, false}, // Not resolved.
+		{endpoint.Endpoint{Name: "192.168.1.1", Labels: prod}, false},
+		{endpoint.Endpoint{Name: "10.1.2.3"}, false},
+	} {
+		assert.Equal(t, tt.want, lim.applies(tt.ep), "endpoint: %+v", tt.ep)
+	}
+}
+
+func TestNewLimitErrors(t *testing.T) {
+	for _, c := range []*configpb.RateLimit{
+		{RequestsPerSec: proto.Float64(0)},
+		{RequestsPerSec: proto.Float64(1), Burst: proto.Int32(-1)},
+		{RequestsPerSec: proto.Float64(1), DestinationNetwork: []string{"10.0.0.0"}},
+	} {
+		_, err := newLimit(c, "test")
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	if err := Init([]*configpb.RateLimit{{
+		Name:               proto.String("internal"),
+		RequestsPerSec:     proto.Float64(0.001),
+		Burst:              proto.Int32(2),
+		DestinationNetwork: []string{"10.0.0.0/8"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	defer Init(nil)
+
+	l1, err := New([]*configpb.RateLimit{{RequestsPerSec: proto.Float64(0.001)}}, "probe1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, _ := New(nil, "probe2")
+
+	internal, external := endpoint.Endpoint{Name: "10.0.0.1"}, endpoint.Endpoint{Name: "8.8.8.8"}
+
+	// Global limit is shared by the probes.
+	assert.True(t, l1.Allow(internal))
+	assert.True(t, l2.Allow(internal))
+	assert.False(t, l2.Allow(internal))
+
+	// Probe's own limit (burst 1).
+	assert.False(t, l1.Allow(internal))
+	assert.False(t, l1.Allow(external))
+	assert.True(t, l2.Allow(external))
+
+	got := make(map[string]int64)
+	for _, em := range ThrottledMetrics(time.Now()) {
+		got[em.Label("rate_limit")+"/"+em.Label("throttled_probe")] = em.Metric("ratelimit_throttled").(*metrics.Int).Int64()
+	}
+	assert.Equal(t, map[string]int64{
+		"internal/probe1": 1,
+		"internal/probe2": 1,
+		"probe1-0/probe1": 1,
+	}, got)
+}
+
+func TestLimiterRejectedRequestKeepsTokens(t *testing.T) {
+	if err := Init([]*configpb.RateLimit{{
+		Name:           proto.String("global"),
+		RequestsPerSec: proto.Float64(0.001),
+		Burst:          proto.Int32(2),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	defer Init(nil)
+
+	l1, _ := New([]*configpb.RateLimit{{RequestsPerSec: proto.Float64(0.001)}}, "probe1")
+	l2, _ := New(nil, "probe2")
+	ep := endpoint.Endpoint{Name: "10.0.0.1"}
+
+	assert.True(t, l1.Allow(ep))
+	// Rejected by the probe's own limit, global limit's token is not used.
+	assert.False(t, l1.Allow(ep))
+	assert.False(t, l1.Allow(ep))
+	assert.True(t, l2.Allow(ep))
+	assert.False(t, l2.Allow(ep))
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package aws implements an AWS resources provider for ResourceDiscovery server.
+
+See ResourceTypes variable for the list of supported resource types.
+
+AWS provider is configured through a protobuf based config file
+(proto/config.proto). Example config:
+
+	{
+		region: "us-east-1"
+		ecs_tasks {
+			cluster: "prod"
+		}
+		route53_records {
+			hosted_zone: "example.com"
+		}
+	}
+*/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "aws"
+
+// ResourceTypes declares resource types supported by the AWS provider.
+var ResourceTypes = struct {
+	ECSTasks, Route53Records string
+}{
+	"ecs_tasks",
+	"route53_records",
+}
+
+type lister interface {
+	listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error)
+}
+
+// Provider implements an AWS provider for a ResourceDiscovery server.
+type Provider struct {
+	listers map[string]lister
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resType := strings.SplitN(req.GetResourcePath(), "/", 2)[0]
+
+	lr := p.listers[resType]
+	if lr == nil {
+		return nil, fmt.Errorf("unknown resource type: %s", resType)
+	}
+
+	resources, err := lr.listResources(req)
+	return &pb.ListResourcesResponse{Resources: resources}, err
+}
+
+// New creates an AWS provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	// If region is not configured, we fall back to the EC2 instance metadata
+	// if it's not available in the environment either.
+	regionOpt := config.WithEC2IMDSRegion()
+	if c.Region != nil {
+		regionOpt = config.WithRegion(c.GetRegion())
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), regionOpt)
+	if err != nil {
+		return nil, fmt.Errorf("rds.aws.New(): error loading AWS config: %v", err)
+	}
+
+	p := &Provider{
+		listers: make(map[string]lister),
+	}
+
+	if c.GetEcsTasks() != nil {
+		lr, err := newECSTasksLister(c.GetEcsTasks(), ecs.NewFromConfig(cfg), ec2.NewFromConfig(cfg), l)
+		if err != nil {
+			return nil, err
+		}
+		p.listers[ResourceTypes.ECSTasks] = lr
+	}
+
+	if c.GetRoute53Records() != nil {
+		lr, err := newRoute53RecordsLister(c.GetRoute53Records(), route53.NewFromConfig(cfg), l)
+		if err != nil {
+			return nil, err
+		}
+		p.listers[ResourceTypes.Route53Records] = lr
+	}
+
+	return p, nil
+}
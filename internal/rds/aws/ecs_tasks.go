@@ -0,0 +1,461 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements support for discovering running ECS tasks.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// ECS Describe* APIs accept at most 100 items per call.
+const ecsDescribeBatchSize = 100
+
+/*
+ECSTasksFilters defines filters supported by the ecs_tasks resource type.
+Task tags are available as labels.
+
+	 Example:
+	 filter {
+		 key: "service"
+		 value: "web|api"
+	 }
+	 filter {
+		 key: "labels.env"
+		 value: "prod"
+	 }
+*/
+var ECSTasksFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name", "cluster", "service", "container"},
+	true,
+}
+
+// ecsAPI is the subset of the ECS API that we use.
+type ecsAPI interface {
+	ListTasks(context.Context, *ecs.ListTasksInput, ...func(*ecs.Options)) (*ecs.ListTasksOutput, error)
+	DescribeTasks(context.Context, *ecs.DescribeTasksInput, ...func(*ecs.Options)) (*ecs.DescribeTasksOutput, error)
+	DescribeTaskDefinition(context.Context, *ecs.DescribeTaskDefinitionInput, ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error)
+	DescribeContainerInstances(context.Context, *ecs.DescribeContainerInstancesInput, ...func(*ecs.Options)) (*ecs.DescribeContainerInstancesOutput, error)
+}
+
+// ec2API is the subset of the EC2 API that we use.
+type ec2API interface {
+	DescribeInstances(context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+}
+
+// ecsTasksLister is an ECS tasks lister. It implements a cache, that's
+// populated at a regular interval by making the ECS API calls. Listing
+// actually only returns the current contents of that cache.
+type ecsTasksLister struct {
+	c         *configpb.ECSTasks
+	ecsClient ecsAPI
+	ec2Client ec2API
+	l         *logger.Logger
+
+	// Task definitions are immutable, so we cache them forever.
+	taskDefs map[string]*types.TaskDefinition
+
+	mu              sync.RWMutex
+	cachePerCluster map[string][]*pb.Resource
+	lastUpdated     int64
+}
+
+func (tl *ecsTasksLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), ECSTasksFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.LabelsFilter
+
+	tl.mu.RLock()
+	defer tl.mu.RUnlock()
+
+	for _, cluster := range tl.c.GetCluster() {
+	resLoop:
+		for _, res := range tl.cachePerCluster[cluster] {
+			if nameFilter != nil && !nameFilter.Match(res.GetName(), tl.l) {
+				continue
+			}
+			for _, key := range []string{"cluster", "service", "container"} {
+				if f := allFilters.RegexFilters[key]; f != nil && !f.Match(res.GetLabels()[key], tl.l) {
+					continue resLoop
+				}
+			}
+			if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), tl.l) {
+				continue
+			}
+			resources = append(resources, res)
+		}
+	}
+
+	tl.l.Infof("ecs_tasks.listResources: returning %d resources", len(resources))
+	return resources, nil
+}
+
+// lastPart returns the part of an ARN after the last "/".
+func lastPart(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// taskENIIP returns the private IP of the task's elastic network interface,
+// i.e. for tasks using the awsvpc network mode (including all Fargate tasks).
+func taskENIIP(task *types.Task) string {
+	for _, att := range task.Attachments {
+		if aws.ToString(att.Type) != "ElasticNetworkInterface" {
+			continue
+		}
+		for _, kv := range att.Details {
+			if aws.ToString(kv.Name) == "privateIPv4Address" {
+				return aws.ToString(kv.Value)
+			}
+		}
+	}
+	for _, c := range task.Containers {
+		for _, ni := range c.NetworkInterfaces {
+			if ip := aws.ToString(ni.PrivateIpv4Address); ip != "" {
+				return ip
+			}
+		}
+	}
+	return ""
+}
+
+func taskLabels(task *types.Task) map[string]string {
+	labels := make(map[string]string, len(task.Tags)+8)
+	for _, tag := range task.Tags {
+		labels[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	labels["cluster"] = lastPart(aws.ToString(task.ClusterArn))
+	labels["task_id"] = lastPart(aws.ToString(task.TaskArn))
+	labels["launch_type"] = strings.ToLower(string(task.LaunchType))
+	if svc, ok := strings.CutPrefix(aws.ToString(task.Group), "service:"); ok {
+		labels["service"] = svc
+	}
+	if family, rev, ok := strings.Cut(lastPart(aws.ToString(task.TaskDefinitionArn)), ":"); ok {
+		labels["task_family"] = family
+		labels["task_revision"] = rev
+	}
+	if az := aws.ToString(task.AvailabilityZone); az != "" {
+		labels["availability_zone"] = az
+	}
+	return labels
+}
+
+// containerPorts returns the ports of each container of the task: container
+// ports from the task definition for awsvpc tasks, and host ports from
+// the network bindings for other tasks.
+func containerPorts(task *types.Task, taskDef *types.TaskDefinition, awsvpc bool) map[string][]int32 {
+	ports := make(map[string][]int32)
+
+	if awsvpc {
+		if taskDef == nil {
+			return ports
+		}
+		for _, cd := range taskDef.ContainerDefinitions {
+			for _, pm := range cd.PortMappings {
+				if pm.ContainerPort != nil {
+					ports[aws.ToString(cd.Name)] = append(ports[aws.ToString(cd.Name)], *pm.ContainerPort)
+				}
+			}
+		}
+		return ports
+	}
+
+	for _, c := range task.Containers {
+		seen := make(map[int32]bool)
+		for _, nb := range c.NetworkBindings {
+			// With IPv6 enabled, same port may show up twice, for 0.0.0.0
+			// and ::.
+			if nb.HostPort == nil || seen[*nb.HostPort] {
+				continue
+			}
+			seen[*nb.HostPort] = true
+			ports[aws.ToString(c.Name)] = append(ports[aws.ToString(c.Name)], *nb.HostPort)
+		}
+	}
+	return ports
+}
+
+// taskResources returns a resource for each port of each container of the
+// task, named <task_id>_<container>_<port>. Tasks without any ports get a
+// single resource named after the task id.
+func taskResources(task *types.Task, taskDef *types.TaskDefinition, ip string, awsvpc bool) []*pb.Resource {
+	labels := taskLabels(task)
+	taskID := labels["task_id"]
+
+	ports := containerPorts(task, taskDef, awsvpc)
+
+	var resources []*pb.Resource
+	for _, c := range task.Containers {
+		name := aws.ToString(c.Name)
+		for _, port := range ports[name] {
+			resLabels := make(map[string]string, len(labels)+1)
+			for k, v := range labels {
+				resLabels[k] = v
+			}
+			resLabels["container"] = name
+
+			resources = append(resources, &pb.Resource{
+				Name:   proto.String(fmt.Sprintf("%s_%s_%d", taskID, name, port)),
+				Id:     task.TaskArn,
+				Ip:     proto.String(ip),
+				Port:   proto.Int32(port),
+				Labels: resLabels,
+			})
+		}
+	}
+
+	if len(resources) == 0 {
+		resources = append(resources, &pb.Resource{
+			Name:   proto.String(taskID),
+			Id:     task.TaskArn,
+			Ip:     proto.String(ip),
+			Labels: labels,
+		})
+	}
+	return resources
+}
+
+func (tl *ecsTasksLister) listTaskARNs(ctx context.Context, cluster string) ([]string, error) {
+	services := tl.c.GetService()
+	if len(services) == 0 {
+		services = []string{""}
+	}
+
+	var arns []string
+	for _, svc := range services {
+		input := &ecs.ListTasksInput{
+			Cluster:       aws.String(cluster),
+			DesiredStatus: types.DesiredStatusRunning,
+		}
+		if svc != "" {
+			input.ServiceName = aws.String(svc)
+		}
+
+		for {
+			out, err := tl.ecsClient.ListTasks(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("error listing tasks (service: %q): %v", svc, err)
+			}
+			arns = append(arns, out.TaskArns...)
+			if out.NextToken == nil {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+	return arns, nil
+}
+
+func (tl *ecsTasksLister) describeTasks(ctx context.Context, cluster string, arns []string) ([]types.Task, error) {
+	var tasks []types.Task
+	for i := 0; i < len(arns); i += ecsDescribeBatchSize {
+		out, err := tl.ecsClient.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   arns[i:min(i+ecsDescribeBatchSize, len(arns))],
+			Include: []types.TaskField{types.TaskFieldTags},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing tasks: %v", err)
+		}
+		tasks = append(tasks, out.Tasks...)
+	}
+	return tasks, nil
+}
+
+func (tl *ecsTasksLister) taskDefinition(ctx context.Context, arn string) (*types.TaskDefinition, error) {
+	if td := tl.taskDefs[arn]; td != nil {
+		return td, nil
+	}
+	out, err := tl.ecsClient.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(arn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing task definition %s: %v", arn, err)
+	}
+	tl.taskDefs[arn] = out.TaskDefinition
+	return out.TaskDefinition, nil
+}
+
+// containerInstanceIPs returns the private IPs of the EC2 instances behind
+// the given container instances.
+func (tl *ecsTasksLister) containerInstanceIPs(ctx context.Context, cluster string, ciARNs []string) (map[string]string, error) {
+	ips := make(map[string]string)
+	if len(ciARNs) == 0 {
+		return ips, nil
+	}
+
+	ec2IDToCI := make(map[string][]string)
+	var ec2IDs []string
+	for i := 0; i < len(ciARNs); i += ecsDescribeBatchSize {
+		out, err := tl.ecsClient.DescribeContainerInstances(ctx, &ecs.DescribeContainerInstancesInput{
+			Cluster:            aws.String(cluster),
+			ContainerInstances: ciARNs[i:min(i+ecsDescribeBatchSize, len(ciARNs))],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing container instances: %v", err)
+		}
+		for _, ci := range out.ContainerInstances {
+			id := aws.ToString(ci.Ec2InstanceId)
+			if ec2IDToCI[id] == nil {
+				ec2IDs = append(ec2IDs, id)
+			}
+			ec2IDToCI[id] = append(ec2IDToCI[id], aws.ToString(ci.ContainerInstanceArn))
+		}
+	}
+
+	input := &ec2.DescribeInstancesInput{InstanceIds: ec2IDs}
+	for {
+		out, err := tl.ec2Client.DescribeInstances(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("error describing EC2 instances: %v", err)
+		}
+		for _, r := range out.Reservations {
+			for _, inst := range r.Instances {
+				for _, ci := range ec2IDToCI[aws.ToString(inst.InstanceId)] {
+					ips[ci] = aws.ToString(inst.PrivateIpAddress)
+				}
+			}
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return ips, nil
+}
+
+func (tl *ecsTasksLister) expandCluster(ctx context.Context, cluster string) ([]*pb.Resource, error) {
+	arns, err := tl.listTaskARNs(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := tl.describeTasks(ctx, cluster, arns)
+	if err != nil {
+		return nil, err
+	}
+
+	var ciARNs []string
+	seenCI := make(map[string]bool)
+	for i := range tasks {
+		ci := aws.ToString(tasks[i].ContainerInstanceArn)
+		if taskENIIP(&tasks[i]) == "" && ci != "" && !seenCI[ci] {
+			seenCI[ci] = true
+			ciARNs = append(ciARNs, ci)
+		}
+	}
+	ciIPs, err := tl.containerInstanceIPs(ctx, cluster, ciARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for i := range tasks {
+		task := &tasks[i]
+		if aws.ToString(task.LastStatus) != "RUNNING" {
+			continue
+		}
+
+		ip, awsvpc := taskENIIP(task), true
+		var taskDef *types.TaskDefinition
+		if ip != "" {
+			if taskDef, err = tl.taskDefinition(ctx, aws.ToString(task.TaskDefinitionArn)); err != nil {
+				return nil, err
+			}
+		} else {
+			ip, awsvpc = ciIPs[aws.ToString(task.ContainerInstanceArn)], false
+		}
+		if ip == "" {
+			tl.l.Warningf("ecs_tasks: couldn't determine IP address for the task: %s", aws.ToString(task.TaskArn))
+			continue
+		}
+
+		resources = append(resources, taskResources(task, taskDef, ip, awsvpc)...)
+	}
+	return resources, nil
+}
+
+func (tl *ecsTasksLister) expand(ctx context.Context) {
+	var numItems int
+	for _, cluster := range tl.c.GetCluster() {
+		resources, err := tl.expandCluster(ctx, cluster)
+		if err != nil {
+			// Keep using the existing resources for this cluster.
+			tl.l.Errorf("ecs_tasks.expand: error while expanding cluster %s: %v", cluster, err)
+			continue
+		}
+
+		tl.mu.Lock()
+		tl.cachePerCluster[cluster] = resources
+		tl.lastUpdated = time.Now().Unix()
+		tl.mu.Unlock()
+
+		numItems += len(resources)
+	}
+
+	tl.l.Infof("ecs_tasks.expand: got %d resources", numItems)
+}
+
+func newECSTasksLister(c *configpb.ECSTasks, ecsClient ecsAPI, ec2Client ec2API, l *logger.Logger) (*ecsTasksLister, error) {
+	if len(c.GetCluster()) == 0 {
+		return nil, fmt.Errorf("ecs_tasks: no cluster configured")
+	}
+
+	tl := &ecsTasksLister{
+		c:               c,
+		ecsClient:       ecsClient,
+		ec2Client:       ec2Client,
+		l:               l,
+		taskDefs:        make(map[string]*types.TaskDefinition),
+		cachePerCluster: make(map[string][]*pb.Resource),
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	go func() {
+		tl.expand(context.Background())
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls ECS
+		// API at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			tl.expand(context.Background())
+		}
+	}()
+	return tl, nil
+}
@@ -0,0 +1,348 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testClusterARN = "arn:aws:ecs:us-east-1:123456789012:cluster/prod"
+	testTDPrefix   = "arn:aws:ecs:us-east-1:123456789012:task-definition/"
+	testTaskPrefix = "arn:aws:ecs:us-east-1:123456789012:task/prod/"
+	testCIARN      = "arn:aws:ecs:us-east-1:123456789012:container-instance/prod/ci1"
+)
+
+// Test tasks:
+//   - web1: Fargate task of the "web" service, with a port in task definition.
+//   - api1: EC2 task of the "api" service in bridge mode, with dynamic port.
+//   - batch1: Fargate task without ports, not part of a service.
+//   - web2: Stopping task of the "web" service, should be skipped.
+func testTasks() map[string]types.Task {
+	return map[string]types.Task{
+		"web1": {
+			TaskArn:           aws.String(testTaskPrefix + "web1"),
+			ClusterArn:        aws.String(testClusterARN),
+			TaskDefinitionArn: aws.String(testTDPrefix + "web:3"),
+			Group:             aws.String("service:web"),
+			LaunchType:        types.LaunchTypeFargate,
+			LastStatus:        aws.String("RUNNING"),
+			AvailabilityZone:  aws.String("us-east-1a"),
+			Tags:              []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+			Attachments: []types.Attachment{{
+				Type: aws.String("ElasticNetworkInterface"),
+				Details: []types.KeyValuePair{
+					{Name: aws.String("subnetId"), Value: aws.String("subnet-1")},
+					{Name: aws.String("privateIPv4Address"), Value: aws.String("10.0.1.5")},
+				},
+			}},
+			Containers: []types.Container{{Name: aws.String("nginx")}, {Name: aws.String("sidecar")}},
+		},
+		"api1": {
+			TaskArn:              aws.String(testTaskPrefix + "api1"),
+			ClusterArn:           aws.String(testClusterARN),
+			TaskDefinitionArn:    aws.String(testTDPrefix + "api:7"),
+			Group:                aws.String("service:api"),
+			LaunchType:           types.LaunchTypeEc2,
+			LastStatus:           aws.String("RUNNING"),
+			ContainerInstanceArn: aws.String(testCIARN),
+			Tags:                 []types.Tag{{Key: aws.String("env"), Value: aws.String("staging")}},
+			Containers: []types.Container{{
+				Name: aws.String("api"),
+				NetworkBindings: []types.NetworkBinding{
+					{BindIP: aws.String("0.0.0.0"), ContainerPort: aws.Int32(8080), HostPort: aws.Int32(32768)},
+					{BindIP: aws.String("::"), ContainerPort: aws.Int32(8080), HostPort: aws.Int32(32768)},
+				},
+			}},
+		},
+		"batch1": {
+			TaskArn:           aws.String(testTaskPrefix + "batch1"),
+			ClusterArn:        aws.String(testClusterARN),
+			TaskDefinitionArn: aws.String(testTDPrefix + "batch:1"),
+			Group:             aws.String("family:batch"),
+			LaunchType:        types.LaunchTypeFargate,
+			LastStatus:        aws.String("RUNNING"),
+			Containers: []types.Container{{
+				Name:              aws.String("batch"),
+				NetworkInterfaces: []types.NetworkInterface{{PrivateIpv4Address: aws.String("10.0.1.9")}},
+			}},
+		},
+		"web2": {
+			TaskArn:           aws.String(testTaskPrefix + "web2"),
+			ClusterArn:        aws.String(testClusterARN),
+			TaskDefinitionArn: aws.String(testTDPrefix + "web:3"),
+			Group:             aws.String("service:web"),
+			LastStatus:        aws.String("DEACTIVATING"),
+		},
+	}
+}
+
+type fakeECS struct {
+	tasks             map[string]types.Task
+	taskDefCalls      int
+	listedServices    []string
+	describeTaskCalls int
+}
+
+func (f *fakeECS) ListTasks(_ context.Context, in *ecs.ListTasksInput, _ ...func(*ecs.Options)) (*ecs.ListTasksOutput, error) {
+	svc := aws.ToString(in.ServiceName)
+	f.listedServices = append(f.listedServices, svc)
+
+	var arns []string
+	for id, task := range f.tasks {
+		if svc == "" || aws.ToString(task.Group) == "service:"+svc {
+			arns = append(arns, testTaskPrefix+id)
+		}
+	}
+	sort.Strings(arns)
+
+	// Return one task per page to exercise pagination.
+	start := 0
+	if in.NextToken != nil {
+		fmt.Sscanf(*in.NextToken, "%d", &start)
+	}
+	out := &ecs.ListTasksOutput{}
+	if start < len(arns) {
+		out.TaskArns = arns[start : start+1]
+	}
+	if start+1 < len(arns) {
+		out.NextToken = aws.String(fmt.Sprint(start + 1))
+	}
+	return out, nil
+}
+
+func (f *fakeECS) DescribeTasks(_ context.Context, in *ecs.DescribeTasksInput, _ ...func(*ecs.Options)) (*ecs.DescribeTasksOutput, error) {
+	f.describeTaskCalls++
+	out := &ecs.DescribeTasksOutput{}
+	for _, arn := range in.Tasks {
+		out.Tasks = append(out.Tasks, f.tasks[lastPart(arn)])
+	}
+	return out, nil
+}
+
+func (f *fakeECS) DescribeTaskDefinition(_ context.Context, in *ecs.DescribeTaskDefinitionInput, _ ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error) {
+	f.taskDefCalls++
+	td := &types.TaskDefinition{TaskDefinitionArn: in.TaskDefinition, NetworkMode: types.NetworkModeAwsvpc}
+	if aws.ToString(in.TaskDefinition) == testTDPrefix+"web:3" {
+		td.ContainerDefinitions = []types.ContainerDefinition{
+			{Name: aws.String("nginx"), PortMappings: []types.PortMapping{{ContainerPort: aws.Int32(80)}, {ContainerPort: aws.Int32(443)}}},
+			{Name: aws.String("sidecar")},
+		}
+	}
+	return &ecs.DescribeTaskDefinitionOutput{TaskDefinition: td}, nil
+}
+
+func (f *fakeECS) DescribeContainerInstances(_ context.Context, in *ecs.DescribeContainerInstancesInput, _ ...func(*ecs.Options)) (*ecs.DescribeContainerInstancesOutput, error) {
+	out := &ecs.DescribeContainerInstancesOutput{}
+	for _, arn := range in.ContainerInstances {
+		out.ContainerInstances = append(out.ContainerInstances, types.ContainerInstance{
+			ContainerInstanceArn: aws.String(arn),
+			Ec2InstanceId:        aws.String("i-" + lastPart(arn)),
+		})
+	}
+	return out, nil
+}
+
+type fakeEC2 struct{}
+
+func (f *fakeEC2) DescribeInstances(_ context.Context, in *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	var instances []ec2types.Instance
+	for _, id := range in.InstanceIds {
+		instances = append(instances, ec2types.Instance{InstanceId: aws.String(id), PrivateIpAddress: aws.String("10.0.2.7")})
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: instances}}}, nil
+}
+
+func testLister(services []string) (*ecsTasksLister, *fakeECS) {
+	fe := &fakeECS{tasks: testTasks()}
+	return &ecsTasksLister{
+		c:               &configpb.ECSTasks{Cluster: []string{"prod"}, Service: services},
+		ecsClient:       fe,
+		ec2Client:       &fakeEC2{},
+		l:               &logger.Logger{},
+		taskDefs:        make(map[string]*types.TaskDefinition),
+		cachePerCluster: make(map[string][]*pb.Resource),
+	}, fe
+}
+
+func TestECSTasksExpand(t *testing.T) {
+	tl, fe := testLister(nil)
+	tl.expand(context.Background())
+
+	want := map[string]*pb.Resource{
+		"web1_nginx_80": {
+			Name: proto.String("web1_nginx_80"),
+			Id:   proto.String(testTaskPrefix + "web1"),
+			Ip:   proto.String("10.0.1.5"),
+			Port: proto.Int32(80),
+			Labels: map[string]string{
+				"env":               "prod",
+				"cluster":           "prod",
+				"service":           "web",
+				"task_id":           "web1",
+				"task_family":       "web",
+				"task_revision":     "3",
+				"launch_type":       "fargate",
+				"availability_zone": "us-east-1a",
+				"container":         "nginx",
+			},
+		},
+		"web1_nginx_443": nil,
+		"api1_api_32768": {
+			Name: proto.String("api1_api_32768"),
+			Id:   proto.String(testTaskPrefix + "api1"),
+			Ip:   proto.String("10.0.2.7"),
+			Port: proto.Int32(32768),
+			Labels: map[string]string{
+				"env":           "staging",
+				"cluster":       "prod",
+				"service":       "api",
+				"task_id":       "api1",
+				"task_family":   "api",
+				"task_revision": "7",
+				"launch_type":   "ec2",
+				"container":     "api",
+			},
+		},
+		"batch1": {
+			Name: proto.String("batch1"),
+			Id:   proto.String(testTaskPrefix + "batch1"),
+			Ip:   proto.String("10.0.1.9"),
+			Labels: map[string]string{
+				"cluster":       "prod",
+				"task_id":       "batch1",
+				"task_family":   "batch",
+				"task_revision": "1",
+				"launch_type":   "fargate",
+			},
+		},
+	}
+
+	resources, err := tl.listResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+
+	got := make(map[string]*pb.Resource)
+	for _, res := range resources {
+		got[res.GetName()] = res
+	}
+	assert.Len(t, got, len(want))
+	for name, wantRes := range want {
+		if assert.Contains(t, got, name) && wantRes != nil {
+			assert.Equal(t, wantRes, got[name])
+		}
+	}
+
+	// Task definitions are cached.
+	taskDefCalls := fe.taskDefCalls
+	tl.expand(context.Background())
+	assert.Equal(t, taskDefCalls, fe.taskDefCalls)
+}
+
+func TestECSTasksServices(t *testing.T) {
+	tl, fe := testLister([]string{"web", "api"})
+	tl.expand(context.Background())
+
+	assert.Equal(t, []string{"web", "web", "api"}, fe.listedServices, "expected two pages for web")
+
+	resources, err := tl.listResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+}
+
+func TestECSTasksFilters(t *testing.T) {
+	tl, _ := testLister(nil)
+	tl.expand(context.Background())
+
+	tests := []struct {
+		filters   map[string]string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			filters:   map[string]string{"service": "web"},
+			wantNames: []string{"web1_nginx_443", "web1_nginx_80"},
+		},
+		{
+			filters:   map[string]string{"labels.env": "staging"},
+			wantNames: []string{"api1_api_32768"},
+		},
+		{
+			filters:   map[string]string{"container": "nginx", "name": ".*_80"},
+			wantNames: []string{"web1_nginx_80"},
+		},
+		{
+			filters:   map[string]string{"cluster": "dev"},
+			wantNames: nil,
+		},
+		{
+			filters: map[string]string{"zone": "us-east-1a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.filters), func(t *testing.T) {
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+			resources, err := tl.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var names []string
+			for _, res := range resources {
+				names = append(names, res.GetName())
+			}
+			sort.Strings(names)
+			assert.Equal(t, test.wantNames, names)
+		})
+	}
+}
+
+func TestNewECSTasksLister(t *testing.T) {
+	_, err := newECSTasksLister(&configpb.ECSTasks{}, &fakeECS{}, &fakeEC2{}, &logger.Logger{})
+	assert.Error(t, err)
+}
+
+func TestProviderListResources(t *testing.T) {
+	tl, _ := testLister(nil)
+	tl.expand(context.Background())
+	p := &Provider{listers: map[string]lister{ResourceTypes.ECSTasks: tl}}
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("ecs_tasks")})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 4)
+
+	_, err = p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("ec2_instances")})
+	assert.Error(t, err)
+}
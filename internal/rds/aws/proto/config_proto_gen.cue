@@ -0,0 +1,65 @@
+// Configuration proto for AWS provider.
+// Example config:
+// {
+//   region: "us-east-1"
+//
+//   # ECS tasks of the services "web" and "api" in the cluster "prod".
+//   ecs_tasks {
+//     cluster: "prod"
+//     service: "web"
+//     service: "api"
+//   }
+//
+//   # Records in the hosted zone "example.com".
+//   route53_records {
+//     hosted_zone: "example.com"
+//   }
+// }
+package proto
+
+// ECS tasks discovery. Only running tasks are discovered, for both Fargate and
+// EC2 launch types.
+#ECSTasks: {
+	// ECS clusters (names or ARNs) to discover tasks in.
+	cluster?: [...string] @protobuf(1,string)
+
+	// Only discover tasks belonging to these services. Default is to discover
+	// all running tasks in the clusters.
+	service?: [...string] @protobuf(2,string)
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=60")
+}
+
+// Route53 record sets discovery. Each record name (A, AAAA or CNAME by
+// default) becomes a resource, so that all the hostnames in the hosted zones
+// are probed automatically as records are created.
+#Route53Records: {
+	// Hosted zones (IDs or domain names) to discover records in. Default is to
+	// discover records in all the hosted zones of the account.
+	hostedZone?: [...string] @protobuf(1,string,name=hosted_zone)
+
+	// Record types to discover. Default is A, AAAA and CNAME.
+	type?: [...string] @protobuf(2,string)
+
+	// Whether to include private hosted zones.
+	includePrivateZones?: bool @protobuf(3,bool,name=include_private_zones,"default=false")
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300")
+}
+
+// AWS provider config.
+#ProviderConfig: {
+	// AWS region. If not specified, we use the local region if running on EC2,
+	// and the default AWS config (e.g. AWS_REGION) otherwise.
+	region?: string @protobuf(1,string)
+
+	// ECS tasks discovery options. This field should be declared for the ECS
+	// tasks discovery to be enabled.
+	ecsTasks?: #ECSTasks @protobuf(2,ECSTasks,name=ecs_tasks)
+
+	// Route53 records discovery options. This field should be declared for the
+	// Route53 records discovery to be enabled.
+	route53Records?: #Route53Records @protobuf(3,Route53Records,name=route53_records)
+}
@@ -0,0 +1,346 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements support for discovering Route53 record sets.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+var defaultRecordTypes = []string{"A", "AAAA", "CNAME"}
+
+/*
+Route53RecordsFilters defines filters supported by the route53_records
+resource type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: ".*\\.example\\.com"
+	 }
+	 filter {
+		 key: "zone"
+		 value: "example.com"
+	 }
+	 filter {
+		 key: "type"
+		 value: "A|AAAA"
+	 }
+	 filter {
+		 key: "labels.zone_id"
+		 value: "Z0123456789"
+	 }
+*/
+var Route53RecordsFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the type filter matches if any of the record name's types matches.
+	[]string{"name", "zone", "type"},
+	true,
+}
+
+// route53API is the subset of the Route53 API that we use.
+type route53API interface {
+	ListHostedZones(context.Context, *route53.ListHostedZonesInput, ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error)
+	ListResourceRecordSets(context.Context, *route53.ListResourceRecordSetsInput, ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
+}
+
+// route53RecordsLister is a Route53 records lister. It implements a cache,
+// that's populated at a regular interval by making the Route53 API calls.
+// Listing actually only returns the current contents of that cache.
+type route53RecordsLister struct {
+	c      *configpb.Route53Records
+	client route53API
+	types  map[string]bool
+	l      *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*pb.Resource
+	lastUpdated int64
+}
+
+func (rl *route53RecordsLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), Route53RecordsFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, zoneFilter, typeFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["zone"], allFilters.RegexFilters["type"], allFilters.LabelsFilter
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	for _, res := range rl.cache {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), rl.l) {
+			continue
+		}
+		if zoneFilter != nil && !zoneFilter.Match(res.GetLabels()["zone"], rl.l) {
+			continue
+		}
+		if typeFilter != nil {
+			matched := false
+			for _, t := range strings.Split(res.GetLabels()["type"], ",") {
+				if typeFilter.Match(t, rl.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), rl.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	rl.l.Infof("route53_records.listResources: returning %d resources", len(resources))
+	return resources, nil
+}
+
+// zoneSelected returns true if the zone is one of the configured hosted zones,
+// or if no hosted zones are configured.
+func (rl *route53RecordsLister) zoneSelected(zone *types.HostedZone) bool {
+	if zone.Config != nil && zone.Config.PrivateZone && !rl.c.GetIncludePrivateZones() {
+		return false
+	}
+	if len(rl.c.GetHostedZone()) == 0 {
+		return true
+	}
+
+	id := strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/")
+	name := strings.TrimSuffix(aws.ToString(zone.Name), ".")
+	for _, hz := range rl.c.GetHostedZone() {
+		if strings.TrimPrefix(hz, "/hostedzone/") == id || strings.TrimSuffix(hz, ".") == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (rl *route53RecordsLister) listZones(ctx context.Context) ([]types.HostedZone, error) {
+	var zones []types.HostedZone
+
+	input := &route53.ListHostedZonesInput{}
+	for {
+		out, err := rl.client.ListHostedZones(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing hosted zones: %v", err)
+		}
+		for i := range out.HostedZones {
+			if rl.zoneSelected(&out.HostedZones[i]) {
+				zones = append(zones, out.HostedZones[i])
+			}
+		}
+		if !out.IsTruncated {
+			return zones, nil
+		}
+		input.Marker = out.NextMarker
+	}
+}
+
+// recordName returns the record name without the trailing dot, and false for
+// the records that can't be probed, e.g. wildcard records.
+func recordName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	// Route53 returns "*" as "\052".
+	if strings.HasPrefix(name, "*") || strings.HasPrefix(name, `\052`) {
+		return "", false
+	}
+	return name, true
+}
+
+// zoneResources returns a resource for each record name in the given record
+// sets. Record sets with the same name (different types or routing policies)
+// are merged into one resource.
+func (rl *route53RecordsLister) zoneResources(zone *types.HostedZone, rrsets []types.ResourceRecordSet) []*pb.Resource {
+	resMap := make(map[string]*pb.Resource)
+	var names []string
+	ips := make(map[string]map[string]string)
+
+	for _, rrset := range rrsets {
+		rrType := string(rrset.Type)
+		if !rl.types[rrType] {
+			continue
+		}
+		name, ok := recordName(aws.ToString(rrset.Name))
+		if !ok {
+			continue
+		}
+
+		res := resMap[name]
+		if res == nil {
+			res = &pb.Resource{
+				Name: proto.String(name),
+				Id:   proto.String(strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/") + "/" + name),
+				Labels: map[string]string{
+					"zone":    strings.TrimSuffix(aws.ToString(zone.Name), "."),
+					"zone_id": strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/"),
+				},
+			}
+			resMap[name], ips[name] = res, make(map[string]string)
+			names = append(names, name)
+		}
+
+		if !strings.Contains(","+res.Labels["type"]+",", ","+rrType+",") {
+			res.Labels["type"] = strings.TrimPrefix(res.Labels["type"]+","+rrType, ",")
+		}
+
+		if rrset.AliasTarget != nil {
+			res.Labels["alias_target"] = strings.TrimSuffix(aws.ToString(rrset.AliasTarget.DNSName), ".")
+			continue
+		}
+		if len(rrset.ResourceRecords) == 0 {
+			continue
+		}
+		value := aws.ToString(rrset.ResourceRecords[0].Value)
+		if rrType == "CNAME" {
+			res.Labels["cname"] = strings.TrimSuffix(value, ".")
+		} else if ips[name][rrType] == "" {
+			ips[name][rrType] = value
+		}
+	}
+
+	resources := make([]*pb.Resource, 0, len(names))
+	for _, name := range names {
+		res := resMap[name]
+		// Prefer IPv4 address. Resources without an address (CNAME and alias
+		// records) are resolved by name at probe time.
+		if ip := ips[name]["A"]; ip != "" {
+			res.Ip = proto.String(ip)
+		} else if ip := ips[name]["AAAA"]; ip != "" {
+			res.Ip = proto.String(ip)
+		}
+		resources = append(resources, res)
+	}
+	return resources
+}
+
+func (rl *route53RecordsLister) listRecordSets(ctx context.Context, zoneID *string) ([]types.ResourceRecordSet, error) {
+	var rrsets []types.ResourceRecordSet
+
+	input := &route53.ListResourceRecordSetsInput{HostedZoneId: zoneID}
+	for {
+		out, err := rl.client.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing record sets for the zone %s: %v", aws.ToString(zoneID), err)
+		}
+		rrsets = append(rrsets, out.ResourceRecordSets...)
+		if !out.IsTruncated {
+			return rrsets, nil
+		}
+		input.StartRecordName = out.NextRecordName
+		input.StartRecordType = out.NextRecordType
+		input.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+}
+
+func (rl *route53RecordsLister) fetch(ctx context.Context) ([]*pb.Resource, error) {
+	zones, err := rl.listZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for i := range zones {
+		rrsets, err := rl.listRecordSets(ctx, zones[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, rl.zoneResources(&zones[i], rrsets)...)
+	}
+	return resources, nil
+}
+
+func (rl *route53RecordsLister) expand(ctx context.Context) {
+	resources, err := rl.fetch(ctx)
+	if err != nil {
+		// Keep using the existing resources.
+		rl.l.Errorf("route53_records.expand: error while listing records: %v", err)
+		return
+	}
+
+	rl.l.Infof("route53_records.expand: got %d resources", len(resources))
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.cache = resources
+	rl.lastUpdated = time.Now().Unix()
+}
+
+// parseRecordTypes returns the set of record types to discover.
+func parseRecordTypes(rrTypes []string) (map[string]bool, error) {
+	if len(rrTypes) == 0 {
+		rrTypes = defaultRecordTypes
+	}
+
+	typeSet := make(map[string]bool)
+	for _, t := range rrTypes {
+		t = strings.ToUpper(t)
+		if t != "A" && t != "AAAA" && t != "CNAME" {
+			return nil, fmt.Errorf("route53_records: unsupported record type: %s", t)
+		}
+		typeSet[t] = true
+	}
+	return typeSet, nil
+}
+
+func newRoute53RecordsLister(c *configpb.Route53Records, client route53API, l *logger.Logger) (*route53RecordsLister, error) {
+	typeSet, err := parseRecordTypes(c.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	rl := &route53RecordsLister{
+		c:      c,
+		client: client,
+		types:  typeSet,
+		l:      l,
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	go func() {
+		rl.expand(context.Background())
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls Route53
+		// API at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			rl.expand(context.Background())
+		}
+	}()
+	return rl, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockRoute53 struct {
+	zones   []types.HostedZone
+	rrsets  map[string][]types.ResourceRecordSet
+	listErr error
+}
+
+// ListHostedZones returns one zone per page, to exercise pagination.
+func (m *mockRoute53) ListHostedZones(_ context.Context, in *route53.ListHostedZonesInput, _ ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	i := 0
+	if in.Marker != nil {
+		for i = range m.zones {
+			if aws.ToString(m.zones[i].Id) == aws.ToString(in.Marker) {
+				break
+			}
+		}
+	}
+	out := &route53.ListHostedZonesOutput{HostedZones: m.zones[i : i+1]}
+	if i+1 < len(m.zones) {
+		out.IsTruncated, out.NextMarker = true, m.zones[i+1].Id
+	}
+	return out, nil
+}
+
+// ListResourceRecordSets returns two record sets per page.
+func (m *mockRoute53) ListResourceRecordSets(_ context.Context, in *route53.ListResourceRecordSetsInput, _ ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	rrsets := m.rrsets[aws.ToString(in.HostedZoneId)]
+	i := 0
+	if in.StartRecordName != nil {
+		for i = range rrsets {
+			if aws.ToString(rrsets[i].Name) == aws.ToString(in.StartRecordName) && rrsets[i].Type == in.StartRecordType {
+				break
+			}
+		}
+	}
+	end := min(i+2, len(rrsets))
+	out := &route53.ListResourceRecordSetsOutput{ResourceRecordSets: rrsets[i:end]}
+	if end < len(rrsets) {
+		out.IsTruncated, out.NextRecordName, out.NextRecordType = true, rrsets[end].Name, rrsets[end].Type
+	}
+	return out, nil
+}
+
+func testRRSet(name string, rrType types.RRType, values ...string) types.ResourceRecordSet {
+	rrset := types.ResourceRecordSet{Name: aws.String(name), Type: rrType}
+	for _, v := range values {
+		rrset.ResourceRecords = append(rrset.ResourceRecords, types.ResourceRecord{Value: aws.String(v)})
+	}
+	return rrset
+}
+
+func testRoute53() *mockRoute53 {
+	alias := testRRSet("lb.example.com.", types.RRTypeA)
+	alias.AliasTarget = &types.AliasTarget{DNSName: aws.String("dualstack.my-lb-123.us-east-1.elb.amazonaws.com.")}
+
+	return &mockRoute53{
+		zones: []types.HostedZone{
+			{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com.")},
+			{Id: aws.String("/hostedzone/Z2"), Name: aws.String("internal.example.com."), Config: &types.HostedZoneConfig{PrivateZone: true}},
+			{Id: aws.String("/hostedzone/Z3"), Name: aws.String("example.org.")},
+		},
+		rrsets: map[string][]types.ResourceRecordSet{
+			"/hostedzone/Z1": {
+				testRRSet("example.com.", types.RRTypeNs, "ns-1.awsdns-1.com."),
+				testRRSet("www.example.com.", types.RRTypeA, "192.0.2.10", "192.0.2.11"),
+				testRRSet("www.example.com.", types.RRTypeAaaa, "2001:db8::10"),
+				testRRSet("v6.example.com.", types.RRTypeAaaa, "2001:db8::20"),
+				testRRSet("api.example.com.", types.RRTypeCname, "www.example.com."),
+				testRRSet(`\052.example.com.`, types.RRTypeA, "192.0.2.99"),
+				alias,
+			},
+			"/hostedzone/Z2": {
+				testRRSet("db.internal.example.com.", types.RRTypeA, "10.0.0.5"),
+			},
+			"/hostedzone/Z3": {
+				testRRSet("www.example.org.", types.RRTypeA, "198.51.100.1"),
+			},
+		},
+	}
+}
+
+func TestRoute53Records(t *testing.T) {
+	tests := []struct {
+		desc    string
+		conf    *configpb.Route53Records
+		filters map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "all_public_zones",
+			conf: &configpb.Route53Records{},
+			want: map[string]string{
+				"www.example.com": "192.0.2.10",
+				"v6.example.com":  "2001:db8::20",
+				"api.example.com": "",
+				"lb.example.com":  "",
+				"www.example.org": "198.51.100.1",
+			},
+		},
+		{
+			desc: "zones_by_name_and_id_with_private",
+			conf: &configpb.Route53Records{HostedZone: []string{"example.org", "Z2"}, IncludePrivateZones: proto.Bool(true)},
+			want: map[string]string{
+				"db.internal.example.com": "10.0.0.5",
+				"www.example.org":         "198.51.100.1",
+			},
+		},
+		{
+			desc: "types_config",
+			conf: &configpb.Route53Records{HostedZone: []string{"example.com."}, Type: []string{"cname"}},
+			want: map[string]string{"api.example.com": ""},
+		},
+		{
+			desc:    "type_and_name_filter",
+			conf:    &configpb.Route53Records{},
+			filters: map[string]string{"type": "AAAA", "name": "www.*"},
+			want:    map[string]string{"www.example.com": "192.0.2.10"},
+		},
+		{
+			desc:    "zone_filter",
+			conf:    &configpb.Route53Records{},
+			filters: map[string]string{"zone": "example.org"},
+			want:    map[string]string{"www.example.org": "198.51.100.1"},
+		},
+		{
+			desc:    "labels_filter",
+			conf:    &configpb.Route53Records{},
+			filters: map[string]string{"labels.zone_id": "Z3"},
+			want:    map[string]string{"www.example.org": "198.51.100.1"},
+		},
+		{
+			desc:    "unsupported_filter",
+			conf:    &configpb.Route53Records{},
+			filters: map[string]string{"region": "us-east-1"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			typeSet, err := parseRecordTypes(test.conf.GetType())
+			assert.NoError(t, err)
+
+			rl := &route53RecordsLister{
+				c:      test.conf,
+				client: testRoute53(),
+				types:  typeSet,
+				l:      &logger.Logger{},
+			}
+			rl.expand(context.Background())
+
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resources, err := rl.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			got := make(map[string]string)
+			for _, res := range resources {
+				got[res.GetName()] = res.GetIp()
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRoute53RecordLabels(t *testing.T) {
+	rl := &route53RecordsLister{
+		c:      &configpb.Route53Records{},
+		client: testRoute53(),
+		types:  map[string]bool{"A": true, "AAAA": true, "CNAME": true},
+		l:      &logger.Logger{},
+	}
+	rl.expand(context.Background())
+
+	labels := make(map[string]map[string]string)
+	for _, res := range rl.cache {
+		labels[res.GetName()] = res.GetLabels()
+	}
+
+	assert.Equal(t, map[string]string{"zone": "example.com", "zone_id": "Z1", "type": "A,AAAA"}, labels["www.example.com"])
+	assert.Equal(t, map[string]string{"zone": "example.com", "zone_id": "Z1", "type": "CNAME", "cname": "www.example.com"}, labels["api.example.com"])
+	assert.Equal(t, map[string]string{"zone": "example.com", "zone_id": "Z1", "type": "A", "alias_target": "dualstack.my-lb-123.us-east-1.elb.amazonaws.com"}, labels["lb.example.com"])
+}
+
+func TestRoute53RecordsExpandError(t *testing.T) {
+	client := testRoute53()
+	rl := &route53RecordsLister{
+		c:      &configpb.Route53Records{},
+		client: client,
+		types:  map[string]bool{"A": true},
+		l:      &logger.Logger{},
+	}
+	rl.expand(context.Background())
+	assert.Len(t, rl.cache, 3)
+
+	// On error, existing resources are kept.
+	client.listErr = assert.AnError
+	rl.expand(context.Background())
+	assert.Len(t, rl.cache, 3)
+}
+
+func TestParseRecordTypes(t *testing.T) {
+	typeSet, err := parseRecordTypes(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"A": true, "AAAA": true, "CNAME": true}, typeSet)
+
+	typeSet, err = parseRecordTypes([]string{"cname"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"CNAME": true}, typeSet)
+
+	_, err = parseRecordTypes([]string{"MX"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package azure implements an Azure resources provider for ResourceDiscovery
+server.
+
+See ResourceTypes variable for the list of supported resource types.
+
+Azure provider is configured through a protobuf based config file
+(proto/config.proto). Example config:
+
+	{
+		subscription_id: "00000000-0000-0000-0000-000000000000"
+		virtual_machines {}
+		load_balancers {}
+	}
+
+Resources carry Azure tags as labels, along with the "location" and
+"resource_group" labels.
+*/
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/azure/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"golang.org/x/oauth2"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "azure"
+
+// ResourceTypes declares resource types supported by the Azure provider.
+var ResourceTypes = struct {
+	VirtualMachines, ScaleSetInstances, LoadBalancers string
+}{
+	"virtual_machines",
+	"scale_set_instances",
+	"load_balancers",
+}
+
+type lister interface {
+	listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error)
+}
+
+// Provider implements an Azure provider for a ResourceDiscovery server.
+type Provider struct {
+	subscriptions []string
+	listers       map[string]map[string]lister
+}
+
+func (p *Provider) listerForResourcePath(resourcePath string) (lister, error) {
+	tok := strings.SplitN(resourcePath, "/", 2)
+	resType := tok[0]
+
+	var subscription string
+	if len(tok) == 2 {
+		subscription = tok[1]
+	}
+
+	if subscription == "" {
+		// If subscription is not specified, use the first one.
+		subscription = p.subscriptions[0]
+	}
+
+	subListers := p.listers[subscription]
+	if subListers == nil {
+		return nil, fmt.Errorf("no listers found for the subscription: %s", subscription)
+	}
+
+	lr := subListers[resType]
+	if lr == nil {
+		return nil, fmt.Errorf("unknown resource type: %s", resType)
+	}
+	return lr, nil
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	lr, err := p.listerForResourcePath(req.GetResourcePath())
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := lr.listResources(req)
+	return &pb.ListResourcesResponse{Resources: resources}, err
+}
+
+func initSubscription(ac *armClient, subscription string, c *configpb.ProviderConfig, l *logger.Logger) map[string]lister {
+	subListers := make(map[string]lister)
+
+	if vmc := c.GetVirtualMachines(); vmc != nil {
+		subListers[ResourceTypes.VirtualMachines] = newCachedLister(ResourceTypes.VirtualMachines, vmc.GetReEvalSec(), func() ([]*resourceInfo, error) {
+			return fetchVirtualMachines(ac, subscription, vmc.GetResourceGroup())
+		}, l)
+	}
+
+	if ssc := c.GetScaleSetInstances(); ssc != nil {
+		subListers[ResourceTypes.ScaleSetInstances] = newCachedLister(ResourceTypes.ScaleSetInstances, ssc.GetReEvalSec(), func() ([]*resourceInfo, error) {
+			return fetchScaleSetInstances(ac, subscription, ssc.GetResourceGroup())
+		}, l)
+	}
+
+	if lbc := c.GetLoadBalancers(); lbc != nil {
+		subListers[ResourceTypes.LoadBalancers] = newCachedLister(ResourceTypes.LoadBalancers, lbc.GetReEvalSec(), func() ([]*resourceInfo, error) {
+			return fetchLoadBalancers(ac, subscription, lbc.GetResourceGroup())
+		}, l)
+	}
+
+	return subListers
+}
+
+func newProvider(c *configpb.ProviderConfig, ts oauth2.TokenSource, l *logger.Logger) (*Provider, error) {
+	subscriptions := c.GetSubscriptionId()
+	if len(subscriptions) == 0 {
+		sub, err := localSubscriptionID()
+		if err != nil {
+			return nil, fmt.Errorf("rds.azure.New(): subscription not configured and couldn't get it from the instance metadata: %v", err)
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+
+	ac := newARMClient(c.GetApiEndpoint(), ts)
+
+	p := &Provider{
+		subscriptions: subscriptions,
+		listers:       make(map[string]map[string]lister),
+	}
+	for _, sub := range subscriptions {
+		p.listers[sub] = initSubscription(ac, sub, c, l)
+	}
+
+	return p, nil
+}
+
+// New creates an Azure provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	return newProvider(c, tokenSource(c.GetManagedIdentityClientId()), l)
+}
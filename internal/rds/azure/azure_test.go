@@ -0,0 +1,276 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/azure/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testSSPath = "/subscriptions/sub1/resourceGroups/web-rg/providers/Microsoft.Compute/virtualMachineScaleSets/web-ss"
+)
+
+// testARMServer returns a fake ARM API server, serving the testdata files.
+func testARMServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	files := map[string]string{
+		"/subscriptions/sub1/providers/Microsoft.Compute/virtualMachines":                    "vms.json",
+		"/subscriptions/sub1/providers/Microsoft.Network/networkInterfaces":                  "nics.json",
+		"/subscriptions/sub1/providers/Microsoft.Network/publicIPAddresses":                  "public_ips.json",
+		"/subscriptions/sub1/providers/Microsoft.Compute/virtualMachineScaleSets":            "scale_sets.json",
+		"/subscriptions/sub1/resourceGroups/lb-rg/providers/Microsoft.Network/loadBalancers": "lbs.json",
+		testSSPath + "/virtualMachines":                                                      "scale_set_instances.json",
+		testSSPath + "/networkInterfaces":                                                    "scale_set_nics.json",
+		testSSPath + "/publicIPAddresses":                                                    "scale_set_public_ips.json",
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file := files[r.URL.Path]
+		if file == "vms.json" && r.URL.Query().Get("page") == "2" {
+			file = "vms_page2.json"
+		}
+		if file == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		b, err := os.ReadFile("testdata/" + file)
+		if err != nil {
+			t.Errorf("Error reading testdata file: %v", err)
+		}
+		fmt.Fprint(w, strings.ReplaceAll(string(b), "{{.URL}}", srv.URL))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func testARMClient(t *testing.T) *armClient {
+	t.Helper()
+	srv := testARMServer(t)
+	return newARMClient(srv.URL, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}))
+}
+
+func TestFetchVirtualMachines(t *testing.T) {
+	resources, err := fetchVirtualMachines(testARMClient(t), "sub1", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*resourceInfo{
+		{
+			name:          "web-1",
+			id:            "/subscriptions/sub1/resourceGroups/web-rg/providers/Microsoft.Compute/virtualMachines/web-1",
+			location:      "westeurope",
+			resourceGroup: "web-rg",
+			labels:        map[string]string{"env": "prod", "app": "web", "location": "westeurope", "resource_group": "web-rg"},
+			nics:          []nicInfo{{privateIP: "10.0.0.4", publicIP: "20.1.1.1"}, {privateIP: "10.1.0.4"}},
+		},
+		{
+			name:          "db-1",
+			id:            "/subscriptions/sub1/resourceGroups/DB-RG/providers/Microsoft.Compute/virtualMachines/db-1",
+			location:      "northeurope",
+			resourceGroup: "DB-RG",
+			labels:        map[string]string{"env": "dev", "location": "northeurope", "resource_group": "DB-RG"},
+			nics:          []nicInfo{{privateIP: "10.0.1.4"}},
+		},
+	}, resources)
+}
+
+func TestFetchScaleSetInstances(t *testing.T) {
+	resources, err := fetchScaleSetInstances(testARMClient(t), "sub1", "")
+	assert.NoError(t, err)
+
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "web-ss_0", resources[0].name)
+	assert.Equal(t, map[string]string{"env": "prod", "location": "westeurope", "resource_group": "web-rg", "scale_set": "web-ss", "instance_id": "0"}, resources[0].labels)
+	// Primary NIC comes first.
+	assert.Equal(t, []nicInfo{{privateIP: "10.2.0.4", publicIP: "20.3.3.3"}, {privateIP: "10.2.1.4"}}, resources[0].nics)
+	assert.Equal(t, []nicInfo{{privateIP: "10.2.0.7"}}, resources[1].nics)
+}
+
+func TestFetchLoadBalancers(t *testing.T) {
+	resources, err := fetchLoadBalancers(testARMClient(t), "sub1", "lb-rg")
+	assert.NoError(t, err)
+
+	var names, ips []string
+	for _, ri := range resources {
+		names = append(names, ri.name)
+		ips = append(ips, ri.ip)
+	}
+	assert.Equal(t, []string{"web-lb_public-fe", "web-lb_internal-fe"}, names)
+	assert.Equal(t, []string{"20.2.2.2", "10.0.2.10"}, ips)
+	assert.Equal(t, map[string]string{"app": "web", "location": "westeurope", "resource_group": "lb-rg", "load_balancer": "web-lb", "frontend": "internal-fe"}, resources[1].labels)
+}
+
+func TestListResources(t *testing.T) {
+	ac := testARMClient(t)
+	cl := &cachedLister{
+		resType: ResourceTypes.VirtualMachines,
+		fetch:   func() ([]*resourceInfo, error) { return fetchVirtualMachines(ac, "sub1", "") },
+		l:       &logger.Logger{},
+	}
+	cl.expand()
+
+	tests := []struct {
+		desc     string
+		filters  map[string]string
+		ipConfig *pb.IPConfig
+		wantIPs  map[string]string
+		wantErr  bool
+	}{
+		{
+			desc:    "all",
+			wantIPs: map[string]string{"web-1": "10.0.0.4", "db-1": "10.0.1.4"},
+		},
+		{
+			desc:    "tag_filter",
+			filters: map[string]string{"labels.env": "prod"},
+			wantIPs: map[string]string{"web-1": "10.0.0.4"},
+		},
+		{
+			desc:    "location_and_rg_filter",
+			filters: map[string]string{"location": "north.*", "resource_group": "DB-RG"},
+			wantIPs: map[string]string{"db-1": "10.0.1.4"},
+		},
+		{
+			desc:     "public_ip",
+			filters:  map[string]string{"name": "web-.*"},
+			ipConfig: &pb.IPConfig{IpType: pb.IPConfig_PUBLIC.Enum()},
+			wantIPs:  map[string]string{"web-1": "20.1.1.1"},
+		},
+		{
+			desc:     "second_nic",
+			filters:  map[string]string{"name": "web-.*"},
+			ipConfig: &pb.IPConfig{NicIndex: proto.Int32(1)},
+			wantIPs:  map[string]string{"web-1": "10.1.0.4"},
+		},
+		{
+			desc:     "no_public_ip",
+			ipConfig: &pb.IPConfig{IpType: pb.IPConfig_PUBLIC.Enum()},
+			wantErr:  true,
+		},
+		{
+			desc:     "no_nic",
+			ipConfig: &pb.IPConfig{NicIndex: proto.Int32(2)},
+			wantErr:  true,
+		},
+		{
+			desc:    "bad_filter",
+			filters: map[string]string{"zone": "a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &pb.ListResourcesRequest{IpConfig: test.ipConfig}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resources, err := cl.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			gotIPs := make(map[string]string)
+			for _, res := range resources {
+				gotIPs[res.GetName()] = res.GetIp()
+			}
+			assert.Equal(t, test.wantIPs, gotIPs)
+		})
+	}
+}
+
+func TestProvider(t *testing.T) {
+	srv := testARMServer(t)
+	p, err := newProvider(&configpb.ProviderConfig{
+		SubscriptionId:  []string{"sub1"},
+		VirtualMachines: &configpb.VirtualMachines{},
+		LoadBalancers:   &configpb.LoadBalancers{ResourceGroup: proto.String("lb-rg")},
+		ApiEndpoint:     proto.String(srv.URL),
+	}, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), &logger.Logger{})
+	assert.NoError(t, err)
+
+	// Wait for the initial expansion.
+	for i := 0; i < 50; i++ {
+		resp, _ := p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("load_balancers")})
+		if len(resp.GetResources()) != 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("load_balancers/sub1")})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 2)
+
+	for _, resPath := range []string{"scale_set_instances", "virtual_machines/sub2"} {
+		_, err = p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String(resPath)})
+		assert.Error(t, err, resPath)
+	}
+}
+
+func TestManagedIdentityToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != armResource {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token": "mi-token-%s", "token_type": "Bearer", "expires_on": "1700000000"}`, r.URL.Query().Get("client_id"))
+	}))
+	defer srv.Close()
+
+	oldEndpoint := imdsEndpoint
+	imdsEndpoint = srv.URL
+	defer func() { imdsEndpoint = oldEndpoint }()
+
+	ts := &managedIdentityTokenSource{clientID: "c1", httpClient: http.DefaultClient}
+	tok, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "mi-token-c1", tok.AccessToken)
+	assert.Equal(t, time.Unix(1700000000, 0), tok.Expiry)
+}
+
+func TestResourceGroup(t *testing.T) {
+	assert.Equal(t, "web-rg", resourceGroup("/subscriptions/sub1/resourceGroups/web-rg/providers/Microsoft.Compute/virtualMachines/web-1"))
+	assert.Equal(t, "RG", resourceGroup("/subscriptions/sub1/resourcegroups/RG"))
+	assert.Equal(t, "", resourceGroup("/subscriptions/sub1"))
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements a minimal Azure Resource Manager (ARM) API client. We
+// talk to the REST API directly, instead of pulling in the Azure SDK, as we
+// need only a handful of list calls.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// imdsEndpoint is the Azure Instance Metadata Service endpoint. It's a
+// variable for testing.
+var imdsEndpoint = "http://169.254.169.254"
+
+const armResource = "https://management.azure.com/"
+
+// managedIdentityTokenSource implements oauth2.TokenSource, getting tokens
+// for the managed identity from the instance metadata service.
+type managedIdentityTokenSource struct {
+	clientID   string
+	httpClient *http.Client
+}
+
+func imdsGet(httpClient *http.Client, path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, imdsEndpoint+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return b, nil
+}
+
+func (ts *managedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	params := url.Values{}
+	params.Set("api-version", "2018-02-01")
+	params.Set("resource", armResource)
+	if ts.clientID != "" {
+		params.Set("client_id", ts.clientID)
+	}
+
+	b, err := imdsGet(ts.httpClient, "/metadata/identity/oauth2/token", params)
+	if err != nil {
+		return nil, fmt.Errorf("error getting managed identity token: %v", err)
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := json.Unmarshal(b, &tok); err != nil {
+		return nil, fmt.Errorf("error parsing managed identity token: %v", err)
+	}
+
+	expiresOn, err := strconv.ParseInt(tok.ExpiresOn, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expires_on (%s) in managed identity token: %v", tok.ExpiresOn, err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: tok.AccessToken,
+		TokenType:   tok.TokenType,
+		Expiry:      time.Unix(expiresOn, 0),
+	}, nil
+}
+
+// tokenSource returns the token source to authenticate to ARM with: service
+// principal's token source if its credentials are available in the
+// environment, managed identity's otherwise.
+func tokenSource(miClientID string) oauth2.TokenSource {
+	tenantID, clientID, secret := os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_CLIENT_ID"), os.Getenv("AZURE_CLIENT_SECRET")
+	if tenantID != "" && clientID != "" && secret != "" {
+		cc := &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: secret,
+			TokenURL:     "https://login.microsoftonline.com/" + tenantID + "/oauth2/v2.0/token",
+			Scopes:       []string{armResource + ".default"},
+		}
+		return cc.TokenSource(context.Background())
+	}
+
+	return oauth2.ReuseTokenSource(nil, &managedIdentityTokenSource{
+		clientID:   miClientID,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	})
+}
+
+// localSubscriptionID returns the subscription ID of the VM we are running
+// on, using the instance metadata service.
+func localSubscriptionID() (string, error) {
+	params := url.Values{}
+	params.Set("api-version", "2021-02-01")
+	params.Set("format", "text")
+	b, err := imdsGet(&http.Client{Timeout: 5 * time.Second}, "/metadata/instance/compute/subscriptionId", params)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+type armClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newARMClient(endpoint string, ts oauth2.TokenSource) *armClient {
+	httpClient := oauth2.NewClient(context.Background(), ts)
+	httpClient.Timeout = time.Minute
+	return &armClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+	}
+}
+
+func (ac *armClient) get(url string) ([]byte, error) {
+	resp, err := ac.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return b, nil
+}
+
+// list lists all the resources at the given path, following the nextLink
+// for paginated responses.
+func list[T any](ac *armClient, path, apiVersion string) ([]T, error) {
+	var items []T
+
+	nextURL := ac.endpoint + path + "?api-version=" + apiVersion
+	for nextURL != "" {
+		b, err := ac.get(nextURL)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Value    []T
+			NextLink string
+		}
+		if err := json.Unmarshal(b, &page); err != nil {
+			return nil, fmt.Errorf("error parsing response from %s: %v", path, err)
+		}
+		items = append(items, page.Value...)
+		nextURL = page.NextLink
+	}
+
+	return items, nil
+}
+
+// scopePath returns the ARM path prefix for the subscription, and optionally
+// the resource group.
+func scopePath(subscription, resourceGroup string) string {
+	if resourceGroup == "" {
+		return "/subscriptions/" + subscription
+	}
+	return "/subscriptions/" + subscription + "/resourceGroups/" + resourceGroup
+}
+
+// resourceGroup extracts the resource group name from an ARM resource ID,
+// e.g. /subscriptions/<sub>/resourceGroups/<rg>/providers/...
+func resourceGroup(id string) string {
+	tok := strings.Split(id, "/")
+	for i := 0; i < len(tok)-1; i++ {
+		if strings.EqualFold(tok[i], "resourceGroups") {
+			return tok[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+SupportedFilters defines filters supported by all the Azure resource types.
+Azure tags are available as labels, so they can be filtered using the labels
+filter.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "location"
+		 value: "westeurope"
+	 }
+	 filter {
+		 key: "labels.env"
+		 value: "prod"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name", "location", "resource_group"},
+	true,
+}
+
+type nicInfo struct {
+	privateIP, publicIP string
+}
+
+// resourceInfo represents resources that we store in cache.
+type resourceInfo struct {
+	name          string
+	id            string
+	location      string
+	resourceGroup string
+	labels        map[string]string
+
+	// For VMs and scale set instances, IP address is picked at the list time
+	// based on the request's IP config. Other resources have a single IP.
+	nics []nicInfo
+	ip   string
+}
+
+func (ri *resourceInfo) ipFor(ipConfig *pb.IPConfig) (string, error) {
+	if ri.nics == nil {
+		return ri.ip, nil
+	}
+
+	nicIndex := int(ipConfig.GetNicIndex())
+	if len(ri.nics) <= nicIndex {
+		return "", fmt.Errorf("no network interface at index %d", nicIndex)
+	}
+	nic := ri.nics[nicIndex]
+
+	switch ipConfig.GetIpType() {
+	case pb.IPConfig_DEFAULT:
+		return nic.privateIP, nil
+	case pb.IPConfig_PUBLIC:
+		if nic.publicIP == "" {
+			return "", fmt.Errorf("no public IP for NIC(%d)", nicIndex)
+		}
+		return nic.publicIP, nil
+	}
+	return "", fmt.Errorf("unsupported IP type: %s", ipConfig.GetIpType())
+}
+
+// cachedLister implements a cache of resources of a certain type, that's
+// populated at a regular interval using the fetch function. Listing actually
+// only returns the current contents of that cache.
+type cachedLister struct {
+	resType string
+	fetch   func() ([]*resourceInfo, error)
+	l       *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*resourceInfo
+	lastUpdated int64
+}
+
+func (cl *cachedLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, locationFilter, rgFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["location"], allFilters.RegexFilters["resource_group"], allFilters.LabelsFilter
+
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+
+	for _, ri := range cl.cache {
+		if nameFilter != nil && !nameFilter.Match(ri.name, cl.l) {
+			continue
+		}
+		if locationFilter != nil && !locationFilter.Match(ri.location, cl.l) {
+			continue
+		}
+		if rgFilter != nil && !rgFilter.Match(ri.resourceGroup, cl.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(ri.labels, cl.l) {
+			continue
+		}
+
+		ip, err := ri.ipFor(req.GetIpConfig())
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s): error while getting IP - %v", cl.resType, ri.name, err)
+		}
+
+		resources = append(resources, &pb.Resource{
+			Name:        proto.String(ri.name),
+			Id:          proto.String(ri.id),
+			Ip:          proto.String(ip),
+			Labels:      ri.labels,
+			LastUpdated: proto.Int64(cl.lastUpdated),
+		})
+	}
+
+	cl.l.Infof("%s.listResources: returning %d resources", cl.resType, len(resources))
+	return resources, nil
+}
+
+func (cl *cachedLister) expand() {
+	resources, err := cl.fetch()
+	if err != nil {
+		// Keep using the existing resources.
+		cl.l.Errorf("%s.expand: error while listing resources: %v", cl.resType, err)
+		return
+	}
+
+	cl.l.Infof("%s.expand: got %d resources", cl.resType, len(resources))
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.cache = resources
+	cl.lastUpdated = time.Now().Unix()
+}
+
+func newCachedLister(resType string, reEvalSec int32, fetch func() ([]*resourceInfo, error), l *logger.Logger) *cachedLister {
+	cl := &cachedLister{
+		resType: resType,
+		fetch:   fetch,
+		l:       l,
+	}
+
+	reEvalInterval := time.Duration(reEvalSec) * time.Second
+	go func() {
+		cl.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls Azure
+		// API at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			cl.expand()
+		}
+	}()
+
+	return cl
+}
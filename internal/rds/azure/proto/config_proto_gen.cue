@@ -0,0 +1,71 @@
+// Configuration proto for Azure provider.
+// Example config:
+// {
+//   subscription_id: "00000000-0000-0000-0000-000000000000"
+//
+//   # Virtual machines
+//   virtual_machines {}
+//
+//   # Virtual machine scale set instances, only in the resource group "web".
+//   scale_set_instances {
+//     resource_group: "web"
+//   }
+//
+//   # Load balancer frontends
+//   load_balancers {}
+// }
+package proto
+
+#VirtualMachines: {
+	// Resource group to limit the discovery to. Default is to discover virtual
+	// machines across all resource groups in the subscription.
+	resourceGroup?: string @protobuf(1,string,name=resource_group)
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+#ScaleSetInstances: {
+	// Resource group to limit the discovery to.
+	resourceGroup?: string @protobuf(1,string,name=resource_group)
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+#LoadBalancers: {
+	// Resource group to limit the discovery to.
+	resourceGroup?: string @protobuf(1,string,name=resource_group)
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+// Azure provider config.
+#ProviderConfig: {
+	// Azure subscription IDs. If running on Azure, it defaults to the local
+	// VM's subscription.
+	subscriptionId?: [...string] @protobuf(1,string,name=subscription_id)
+
+	// Virtual machines discovery options. This field should be declared for the
+	// virtual machines discovery to be enabled.
+	virtualMachines?: #VirtualMachines @protobuf(2,VirtualMachines,name=virtual_machines)
+
+	// Scale set instances discovery options.
+	scaleSetInstances?: #ScaleSetInstances @protobuf(3,ScaleSetInstances,name=scale_set_instances)
+
+	// Load balancer frontends discovery options.
+	loadBalancers?: #LoadBalancers @protobuf(4,LoadBalancers,name=load_balancers)
+
+	// Client ID of the user-assigned managed identity to use. If not set,
+	// system-assigned managed identity is used.
+	//
+	// Note: If AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET
+	// environment variables are set, we authenticate as that service principal
+	// instead of using managed identity. This is mainly useful when running
+	// outside of Azure.
+	managedIdentityClientId?: string @protobuf(5,string,name=managed_identity_client_id)
+
+	// Azure Resource Manager API endpoint.
+	apiEndpoint?: string @protobuf(100,string,name=api_endpoint,#"default="https://management.azure.com""#)
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements fetching of virtual machines, scale set instances and
+// load balancer frontends from the ARM API.
+
+package azure
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	computeAPIVersion = "2023-03-01"
+	networkAPIVersion = "2023-05-01"
+	// Scale set network resources are available only in this older API
+	// version of the Microsoft.Compute provider.
+	vmssNetworkAPIVersion = "2018-10-01"
+)
+
+type armMetadata struct {
+	ID       string
+	Name     string
+	Location string
+	Tags     map[string]string
+}
+
+type subResource struct {
+	ID string
+}
+
+type ipConfiguration struct {
+	Name       string
+	Properties struct {
+		Primary          bool
+		PrivateIPAddress string
+		PublicIPAddress  *subResource
+	}
+}
+
+type networkInterface struct {
+	armMetadata
+	Properties struct {
+		Primary          bool
+		VirtualMachine   *subResource
+		IPConfigurations []ipConfiguration
+	}
+}
+
+type publicIPAddress struct {
+	armMetadata
+	Properties struct {
+		IPAddress string
+	}
+}
+
+type virtualMachine struct {
+	armMetadata
+	InstanceID string
+	Properties struct {
+		NetworkProfile struct {
+			NetworkInterfaces []subResource
+		}
+	}
+}
+
+type loadBalancer struct {
+	armMetadata
+	Properties struct {
+		FrontendIPConfigurations []ipConfiguration
+	}
+}
+
+// ARM resource IDs are case-insensitive, and different APIs don't always
+// agree on the case, e.g. for resource groups.
+func idKey(id string) string {
+	return strings.ToLower(id)
+}
+
+// baseLabels returns the labels for a resource: its tags, location and
+// resource group.
+func baseLabels(md *armMetadata) map[string]string {
+	labels := make(map[string]string, len(md.Tags)+2)
+	for k, v := range md.Tags {
+		labels[k] = v
+	}
+	labels["location"] = md.Location
+	labels["resource_group"] = resourceGroup(md.ID)
+	return labels
+}
+
+func newResourceInfo(md *armMetadata) *resourceInfo {
+	return &resourceInfo{
+		name:          md.Name,
+		id:            md.ID,
+		location:      md.Location,
+		resourceGroup: resourceGroup(md.ID),
+		labels:        baseLabels(md),
+	}
+}
+
+func publicIPsByID(pips []*publicIPAddress) map[string]string {
+	m := make(map[string]string, len(pips))
+	for _, pip := range pips {
+		m[idKey(pip.ID)] = pip.Properties.IPAddress
+	}
+	return m
+}
+
+// primaryIPConfig returns the primary IP configuration, or the first one if
+// none is marked as primary.
+func primaryIPConfig(ipConfigs []ipConfiguration) *ipConfiguration {
+	if len(ipConfigs) == 0 {
+		return nil
+	}
+	for i := range ipConfigs {
+		if ipConfigs[i].Properties.Primary {
+			return &ipConfigs[i]
+		}
+	}
+	return &ipConfigs[0]
+}
+
+func frontendIP(ipc *ipConfiguration, publicIPs map[string]string) (privateIP, publicIP string) {
+	if ipc == nil {
+		return "", ""
+	}
+	if ipc.Properties.PublicIPAddress != nil {
+		publicIP = publicIPs[idKey(ipc.Properties.PublicIPAddress.ID)]
+	}
+	return ipc.Properties.PrivateIPAddress, publicIP
+}
+
+func nicIPs(nic *networkInterface, publicIPs map[string]string) nicInfo {
+	privateIP, publicIP := frontendIP(primaryIPConfig(nic.Properties.IPConfigurations), publicIPs)
+	return nicInfo{privateIP: privateIP, publicIP: publicIP}
+}
+
+// vmResources joins virtual machines with their network interfaces and
+// public IPs. NICs are ordered as in the VM's network profile.
+func vmResources(vms []*virtualMachine, nics []*networkInterface, pips []*publicIPAddress) []*resourceInfo {
+	publicIPs := publicIPsByID(pips)
+	nicsByID := make(map[string]*networkInterface, len(nics))
+	for _, nic := range nics {
+		nicsByID[idKey(nic.ID)] = nic
+	}
+
+	var resources []*resourceInfo
+	for _, vm := range vms {
+		ri := newResourceInfo(&vm.armMetadata)
+		ri.nics = []nicInfo{}
+		for _, ref := range vm.Properties.NetworkProfile.NetworkInterfaces {
+			if nic := nicsByID[idKey(ref.ID)]; nic != nil {
+				ri.nics = append(ri.nics, nicIPs(nic, publicIPs))
+			}
+		}
+		resources = append(resources, ri)
+	}
+	return resources
+}
+
+// scaleSetInstanceResources joins scale set instances with their network
+// interfaces and public IPs. Primary NIC comes first.
+func scaleSetInstanceResources(scaleSet string, instances []*virtualMachine, nics []*networkInterface, pips []*publicIPAddress) []*resourceInfo {
+	publicIPs := publicIPsByID(pips)
+	nicsByVM := make(map[string][]*networkInterface)
+	for _, nic := range nics {
+		if nic.Properties.VirtualMachine == nil {
+			continue
+		}
+		key := idKey(nic.Properties.VirtualMachine.ID)
+		nicsByVM[key] = append(nicsByVM[key], nic)
+	}
+
+	var resources []*resourceInfo
+	for _, inst := range instances {
+		vmNICs := nicsByVM[idKey(inst.ID)]
+		sort.SliceStable(vmNICs, func(i, j int) bool {
+			return vmNICs[i].Properties.Primary && !vmNICs[j].Properties.Primary
+		})
+
+		ri := newResourceInfo(&inst.armMetadata)
+		ri.labels["scale_set"] = scaleSet
+		ri.labels["instance_id"] = inst.InstanceID
+		ri.nics = []nicInfo{}
+		for _, nic := range vmNICs {
+			ri.nics = append(ri.nics, nicIPs(nic, publicIPs))
+		}
+		resources = append(resources, ri)
+	}
+	return resources
+}
+
+// lbResources returns a resource per load balancer frontend, named
+// <lb_name>_<frontend_name>.
+func lbResources(lbs []*loadBalancer, pips []*publicIPAddress) []*resourceInfo {
+	publicIPs := publicIPsByID(pips)
+
+	var resources []*resourceInfo
+	for _, lb := range lbs {
+		for i := range lb.Properties.FrontendIPConfigurations {
+			fe := &lb.Properties.FrontendIPConfigurations[i]
+
+			ri := newResourceInfo(&lb.armMetadata)
+			ri.name = lb.Name + "_" + fe.Name
+			ri.labels["load_balancer"] = lb.Name
+			ri.labels["frontend"] = fe.Name
+
+			privateIP, publicIP := frontendIP(fe, publicIPs)
+			ri.ip = privateIP
+			if publicIP != "" {
+				ri.ip = publicIP
+			}
+			resources = append(resources, ri)
+		}
+	}
+	return resources
+}
+
+// NICs and public IPs are always listed across the subscription, as they
+// don't need to be in the same resource group as the VMs or LBs using them.
+
+func fetchVirtualMachines(ac *armClient, subscription, rg string) ([]*resourceInfo, error) {
+	vms, err := list[*virtualMachine](ac, scopePath(subscription, rg)+"/providers/Microsoft.Compute/virtualMachines", computeAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	nics, err := list[*networkInterface](ac, scopePath(subscription, "")+"/providers/Microsoft.Network/networkInterfaces", networkAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	pips, err := list[*publicIPAddress](ac, scopePath(subscription, "")+"/providers/Microsoft.Network/publicIPAddresses", networkAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	return vmResources(vms, nics, pips), nil
+}
+
+func fetchScaleSetInstances(ac *armClient, subscription, rg string) ([]*resourceInfo, error) {
+	scaleSets, err := list[*armMetadata](ac, scopePath(subscription, rg)+"/providers/Microsoft.Compute/virtualMachineScaleSets", computeAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*resourceInfo
+	for _, ss := range scaleSets {
+		instances, err := list[*virtualMachine](ac, ss.ID+"/virtualMachines", computeAPIVersion)
+		if err != nil {
+			return nil, err
+		}
+		nics, err := list[*networkInterface](ac, ss.ID+"/networkInterfaces", vmssNetworkAPIVersion)
+		if err != nil {
+			return nil, err
+		}
+		pips, err := list[*publicIPAddress](ac, ss.ID+"/publicIPAddresses", vmssNetworkAPIVersion)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, scaleSetInstanceResources(ss.Name, instances, nics, pips)...)
+	}
+	return resources, nil
+}
+
+func fetchLoadBalancers(ac *armClient, subscription, rg string) ([]*resourceInfo, error) {
+	lbs, err := list[*loadBalancer](ac, scopePath(subscription, rg)+"/providers/Microsoft.Network/loadBalancers", networkAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	pips, err := list[*publicIPAddress](ac, scopePath(subscription, "")+"/providers/Microsoft.Network/publicIPAddresses", networkAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	return lbResources(lbs, pips), nil
+}
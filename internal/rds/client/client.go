@@ -63,16 +63,23 @@ const defaultRDSPort = "9314"
 
 // Client represents an RDS based client instance.
 type Client struct {
-	mu            sync.RWMutex
-	c             *configpb.ClientConf
-	serverOpts    *configpb.ClientConf_ServerOptions
-	dialOpts      []grpc.DialOption
-	cache         map[string]*cacheRecord
-	names         []string
-	listResources func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error)
-	lastModified  int64
-	resolver      *dnsRes.Resolver
-	l             *logger.Logger
+	mu             sync.RWMutex
+	c              *configpb.ClientConf
+	serverOpts     *configpb.ClientConf_ServerOptions
+	dialOpts       []grpc.DialOption
+	cache          map[string]*cacheRecord
+	names          []string
+	listResources  func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error)
+	watchResources func(context.Context, *pb.ListResourcesRequest) (spb.ResourceDiscovery_WatchResourcesClient, error)
+	lastModified   int64
+	lastRefreshed  time.Time
+	streaming      bool
+	resolver       *dnsRes.Resolver
+	l              *logger.Logger
+
+	// Canceled when client is closed, to stop the refresh goroutines.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // ListResourcesFunc is a function that takes ListResourcesRequest and returns
@@ -95,6 +102,25 @@ func (client *Client) refreshState(timeout time.Duration) {
 	client.updateState(response)
 }
 
+// staleness returns how stale the cached resources are, i.e. time since they
+// should have been refreshed last. It's 0 if resources are still fresh or if
+// cache TTL is not configured.
+func (client *Client) staleness() time.Duration {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	// Resources are always fresh while we are receiving updates over a watch
+	// stream.
+	if client.c.GetCacheTtlSec() <= 0 || client.lastRefreshed.IsZero() || client.streaming {
+		return 0
+	}
+	d := time.Since(client.lastRefreshed) - time.Duration(client.c.GetCacheTtlSec())*time.Second
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
 func parseIP(ipStr string) net.IP {
 	if strings.Contains(ipStr, "/") {
 		ip, _, err := net.ParseCIDR(ipStr)
@@ -110,6 +136,8 @@ func (client *Client) updateState(response *pb.ListResourcesResponse) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
+	client.lastRefreshed = time.Now()
+
 	// If server doesn't support caching, response's last_modified will be 0 and
 	// we'll skip the following block.
 	if response.GetLastModified() != 0 && response.GetLastModified() <= client.lastModified {
@@ -118,12 +146,29 @@ func (client *Client) updateState(response *pb.ListResourcesResponse) {
 		return
 	}
 
-	client.names = make([]string, len(response.GetResources()))
+	client.setResources(response.GetResources())
+	client.lastModified = response.GetLastModified()
+}
+
+func newCacheRecord(res *pb.Resource) *cacheRecord {
+	return &cacheRecord{
+		ip:          parseIP(res.GetIp()),
+		ipStr:       res.GetIp(),
+		port:        int(res.GetPort()),
+		labels:      res.Labels,
+		lastUpdated: time.Unix(res.GetLastUpdated(), 0),
+	}
+}
+
+// setResources replaces the cached resources. It should be called with the
+// client's lock held.
+func (client *Client) setResources(resources []*pb.Resource) {
+	client.names = make([]string, len(resources))
 	oldcache := client.cache
-	client.cache = make(map[string]*cacheRecord, len(response.GetResources()))
+	client.cache = make(map[string]*cacheRecord, len(resources))
 
 	i := 0
-	for _, res := range response.GetResources() {
+	for _, res := range resources {
 		if oldRes, ok := client.cache[res.GetName()]; ok {
 			client.l.Warningf("Got resource (%s) again, ignoring this instance: {%v}. Previous record: %+v.", res.GetName(), res, *oldRes)
 			continue
@@ -132,18 +177,11 @@ func (client *Client) updateState(response *pb.ListResourcesResponse) {
 			client.l.Infof("Resource (%s) ip has changed: %s -> %s.", res.GetName(), oldcache[res.GetName()].ipStr, res.GetIp())
 		}
 
-		client.cache[res.GetName()] = &cacheRecord{
-			ip:          parseIP(res.GetIp()),
-			ipStr:       res.GetIp(),
-			port:        int(res.GetPort()),
-			labels:      res.Labels,
-			lastUpdated: time.Unix(res.GetLastUpdated(), 0),
-		}
+		client.cache[res.GetName()] = newCacheRecord(res)
 		client.names[i] = res.GetName()
 		i++
 	}
 	client.names = client.names[:i]
-	client.lastModified = response.GetLastModified()
 }
 
 // ListEndpoints returns the list of resources.
@@ -153,6 +191,11 @@ func (client *Client) ListEndpoints() []endpoint.Endpoint {
 		client.refreshState(30 * time.Second)
 	}
 
+	if !client.c.GetServeStale() && client.staleness() > 0 {
+		client.l.Warningf("rds.client: resources are stale (last refreshed at: %v), not serving them", client.lastRefreshedTime())
+		return nil
+	}
+
 	client.mu.RLock()
 	defer client.mu.RUnlock()
 	result := make([]endpoint.Endpoint, len(client.names))
@@ -240,10 +283,63 @@ func (client *Client) initListResourcesFunc() error {
 	client.listResources = func(ctx context.Context, in *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
 		return spb.NewResourceDiscoveryClient(conn).ListResources(ctx, in)
 	}
+	client.watchResources = func(ctx context.Context, in *pb.ListResourcesRequest) (spb.ResourceDiscovery_WatchResourcesClient, error) {
+		return spb.NewResourceDiscoveryClient(conn).WatchResources(ctx, in)
+	}
 
 	return nil
 }
 
+func (client *Client) lastRefreshedTime() time.Time {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	return client.lastRefreshed
+}
+
+// jitter returns a random duration in the range [0.9*d, 1.1*d).
+func jitter(d time.Duration) time.Duration {
+	return d - d/10 + time.Duration(rand.Int63n(int64(d/5)+1))
+}
+
+// poll refreshes the client state every reEvalInterval.
+func (client *Client) poll(reEvalInterval time.Duration) {
+	// Introduce a random delay between 0-reEvalInterval before starting the
+	// refreshState loop. If there are multiple cloudprober instances, this will
+	// make sure that each instance calls RDS server at a different point of
+	// time.
+	rand.Seed(time.Now().UnixNano())
+	randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+	if !client.sleep(time.Duration(randomDelaySec) * time.Second) {
+		return
+	}
+
+	// Jitter each interval as well, so that the probes that started together
+	// (e.g. at the same config reload) don't stay in lock-step.
+	for client.sleep(jitter(reEvalInterval)) {
+		client.refreshState(reEvalInterval)
+	}
+}
+
+// sleep waits for the given duration. It returns false if client was closed
+// in the meantime.
+func (client *Client) sleep(d time.Duration) bool {
+	select {
+	case <-client.ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// Close stops the client's background refresh. Client keeps serving the
+// resources it already has.
+func (client *Client) Close() error {
+	if client.cancel != nil {
+		client.cancel()
+	}
+	return nil
+}
+
 // New creates an RDS (ResourceDiscovery service) client instance and set it up
 // for continuous refresh.
 func New(c *configpb.ClientConf, listResources ListResourcesFunc, l *logger.Logger) (*Client, error) {
@@ -255,6 +351,7 @@ func New(c *configpb.ClientConf, listResources ListResourcesFunc, l *logger.Logg
 		resolver:      globalResolver,
 		l:             l,
 	}
+	client.ctx, client.cancel = context.WithCancel(context.Background())
 
 	if err := client.initListResourcesFunc(); err != nil {
 		return nil, fmt.Errorf("rds/client: error initializing listListResource function: %v", err)
@@ -266,19 +363,16 @@ func New(c *configpb.ClientConf, listResources ListResourcesFunc, l *logger.Logg
 
 	reEvalInterval := time.Duration(client.c.GetReEvalSec()) * time.Second
 	client.refreshState(reEvalInterval)
-	go func() {
-		// Introduce a random delay between 0-reEvalInterval before starting the
-		// refreshState loop. If there are multiple cloudprober instances, this will
-		// make sure that each instance calls RDS server at a different point of
-		// time.
-		rand.Seed(time.Now().UnixNano())
-		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
-		time.Sleep(time.Duration(randomDelaySec) * time.Second)
-		for range time.Tick(reEvalInterval) {
-			client.refreshState(reEvalInterval)
+
+	if client.c.GetWatch() {
+		if client.watchResources != nil {
+			go client.watch(reEvalInterval)
+			return client, nil
 		}
-	}()
+		client.l.Warning("rds.client: watch is supported only for remote RDS servers, will poll for resources instead")
+	}
 
+	go client.poll(reEvalInterval)
 	return client, nil
 }
 
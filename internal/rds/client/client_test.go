@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/cloudprober/cloudprober/internal/rds/server"
 	serverpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	dnsRes "github.com/cloudprober/cloudprober/targets/resolver"
 	"github.com/stretchr/testify/assert"
@@ -412,3 +414,126 @@ func TestOnDemandRefresh(t *testing.T) {
 	runCount++
 	tp.verifyRequestResponse(t, runCount, 0, 0)
 }
+
+func TestStaleness(t *testing.T) {
+	for _, serveStale := range []bool{true, false} {
+		t.Run(fmt.Sprintf("serve_stale=%v", serveStale), func(t *testing.T) {
+			var serverDown bool
+			listResources := func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+				if serverDown {
+					return nil, fmt.Errorf("server down")
+				}
+				return &pb.ListResourcesResponse{Resources: testResources}, nil
+			}
+
+			c := &configpb.ClientConf{
+				Request:     &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+				ReEvalSec:   proto.Int32(3600),
+				CacheTtlSec: proto.Int32(60),
+				ServeStale:  proto.Bool(serveStale),
+			}
+			client, err := New(c, listResources, &logger.Logger{})
+			if err != nil {
+				t.Fatalf("Got error initializing RDS client: %v", err)
+			}
+			assert.Equal(t, time.Duration(0), client.staleness())
+			verifyEndpoints(t, client.ListEndpoints(), expectedList)
+
+			// Server goes down and TTL expires.
+			serverDown = true
+			client.refreshState(time.Second)
+			client.lastRefreshed = client.lastRefreshed.Add(-90 * time.Second)
+			assert.InDelta(t, 30, client.staleness().Seconds(), 1)
+			if serveStale {
+				verifyEndpoints(t, client.ListEndpoints(), expectedList)
+			} else {
+				assert.Empty(t, client.ListEndpoints())
+			}
+
+			em := client.stalenessMetrics(time.Now(), "http", "test-probe")
+			assert.Equal(t, "test-probe", em.Label("probe"))
+			assert.Equal(t, testProviderName, em.Label("provider"))
+			assert.InDelta(t, 30, em.Metric("rds_client_staleness_sec").(*metrics.Float).Float64(), 1)
+
+			// Server comes back.
+			serverDown = false
+			client.refreshState(time.Second)
+			assert.Equal(t, time.Duration(0), client.staleness())
+			verifyEndpoints(t, client.ListEndpoints(), expectedList)
+		})
+	}
+}
+
+func TestExportStaleness(t *testing.T) {
+	listResources := func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+		return &pb.ListResourcesResponse{Resources: testResources}, nil
+	}
+
+	// No cache TTL, nothing to export.
+	client, err := New(&configpb.ClientConf{
+		Request:   &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+		ReEvalSec: proto.Int32(3600),
+	}, listResources, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Got error initializing RDS client: %v", err)
+	}
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	client.ExportStaleness(context.Background(), time.Millisecond, "http", "test-probe", dataChan)
+	assert.Empty(t, dataChan)
+
+	client, err = New(&configpb.ClientConf{
+		Request:     &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+		ReEvalSec:   proto.Int32(3600),
+		CacheTtlSec: proto.Int32(60),
+	}, listResources, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Got error initializing RDS client: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		client.ExportStaleness(context.Background(), 10*time.Millisecond, "http", "test-probe", dataChan)
+		close(done)
+	}()
+	em := <-dataChan
+	assert.Equal(t, "test-probe", em.Label("probe"))
+	assert.Equal(t, float64(0), em.Metric("rds_client_staleness_sec").(*metrics.Float).Float64())
+
+	// Export stops with the client.
+	client.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("ExportStaleness didn't return after client was closed")
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitter(10 * time.Second)
+		assert.GreaterOrEqual(t, d, 9*time.Second)
+		assert.LessOrEqual(t, d, 11*time.Second)
+	}
+}
+
+func TestClose(t *testing.T) {
+	var numCalls int32
+	listResources := func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+		atomic.AddInt32(&numCalls, 1)
+		return &pb.ListResourcesResponse{Resources: testResources}, nil
+	}
+
+	client, err := New(&configpb.ClientConf{
+		Request:   &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+		ReEvalSec: proto.Int32(1),
+	}, listResources, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Got error initializing RDS client: %v", err)
+	}
+	assert.NoError(t, client.Close())
+
+	// No refreshes after the initial one.
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+	assert.NotEmpty(t, client.cache, "Client cache")
+}
@@ -13,7 +13,12 @@ import (
 	#ServerOptions: {
 		serverAddress?: string @protobuf(1,string,name=server_address)
 
-		// Optional oauth config for authentication.
+		// Optional oauth config for authentication. To authenticate with an RDS
+		// server configured with auth token files, use:
+		//   oauth_config {
+		//     bearer_token { file: "/path/to/token" }
+		//   }
+		// Note that bearer tokens are sent only over TLS (see tls_config).
 		oauthConfig?: proto.#Config @protobuf(2,oauth.Config,name=oauth_config)
 
 		// TLS config, it can be used to:
@@ -38,4 +43,21 @@ import (
 	// (specifically GCE instances/forwarding rules). This does not impact those
 	// caches.
 	reEvalSec?: int32 @protobuf(3,int32,name=re_eval_sec,"default=30")
+
+	// Use the WatchResources streaming RPC to receive changes from the server,
+	// instead of polling it every re_eval_sec. It's used only for the remote
+	// RDS servers. If server doesn't support WatchResources, or if stream
+	// breaks, we poll the server until the stream is re-established.
+	watch?: bool @protobuf(4,bool,"default=false")
+
+	// How long the cached resources remain fresh after the last successful
+	// refresh. Once this time has passed (e.g. because the RDS server is
+	// unreachable), the cached resources are considered stale. Staleness is
+	// exported as the rds_client_staleness_sec metric. A value of 0 means that
+	// resources never become stale.
+	cacheTtlSec?: int32 @protobuf(5,int32,name=cache_ttl_sec)
+
+	// Whether to keep serving stale resources. If set to false, client returns
+	// no resources once they become stale.
+	serveStale?: bool @protobuf(6,bool,name=serve_stale,"default=true")
 }
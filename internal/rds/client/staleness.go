@@ -0,0 +1,64 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+func (client *Client) stalenessMetrics(ts time.Time, ptype, probeName string) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("rds_client_staleness_sec", metrics.NewFloat(client.staleness().Seconds())).
+		AddLabel("ptype", ptype).
+		AddLabel("probe", probeName).
+		AddLabel("provider", client.c.GetRequest().GetProvider()).
+		AddLabel("resource_path", client.c.GetRequest().GetResourcePath())
+	em.Kind = metrics.GAUGE
+	return em
+}
+
+// ExportStaleness exports the staleness of the cached resources, i.e. the time
+// since their TTL expired, for the given probe at every interval. It returns
+// right away if cache TTL is not configured, otherwise it runs until the
+// context is canceled or the client is closed.
+func (client *Client) ExportStaleness(ctx context.Context, interval time.Duration, ptype, probeName string, dataChan chan<- *metrics.EventMetrics) {
+	if client.c.GetCacheTtlSec() <= 0 || client.c.GetReEvalSec() <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case dataChan <- client.stalenessMetrics(time.Now(), ptype, probeName):
+		case <-ctx.Done():
+			return
+		case <-client.ctx.Done():
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-client.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
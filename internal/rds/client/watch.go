@@ -0,0 +1,111 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// applyWatchResponse applies a WatchResources response to the client state.
+func (client *Client) applyWatchResponse(resp *pb.WatchResourcesResponse) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.lastModified = resp.GetLastModified()
+	client.lastRefreshed = time.Now()
+	client.streaming = true
+
+	if resp.GetFull() {
+		client.setResources(resp.GetResources())
+		return
+	}
+
+	for _, res := range resp.GetResources() {
+		if client.cache[res.GetName()] == nil {
+			client.names = append(client.names, res.GetName())
+		}
+		client.cache[res.GetName()] = newCacheRecord(res)
+	}
+
+	if len(resp.GetRemoved()) == 0 {
+		return
+	}
+	for _, name := range resp.GetRemoved() {
+		delete(client.cache, name)
+	}
+	names := client.names[:0]
+	for _, name := range client.names {
+		if client.cache[name] != nil {
+			names = append(names, name)
+		}
+	}
+	client.names = names
+}
+
+// watchStream receives resource changes over a WatchResources stream, until
+// the stream breaks.
+func (client *Client) watchStream() error {
+	ctx, cancel := context.WithCancel(client.ctx)
+	defer cancel()
+
+	stream, err := client.watchResources(ctx, client.c.GetRequest())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Resources were fresh until the stream broke.
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		if client.streaming {
+			client.lastRefreshed = time.Now()
+		}
+		client.streaming = false
+	}()
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		client.applyWatchResponse(resp)
+	}
+}
+
+// watch keeps the client state updated using the WatchResources stream. If
+// stream breaks, we refresh the state by polling the server, and try again
+// after reEvalInterval. If server doesn't implement WatchResources, we fall
+// back to polling.
+func (client *Client) watch(reEvalInterval time.Duration) {
+	for {
+		err := client.watchStream()
+		if client.ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			client.l.Warningf("rds.client: RDS server doesn't support WatchResources, will poll for resources instead: %v", err)
+			client.poll(reEvalInterval)
+			return
+		}
+		client.l.Warningf("rds.client: resources watch stream broke: %v. Will retry in %v.", err, reEvalInterval)
+		if !client.sleep(jitter(reEvalInterval)) {
+			return
+		}
+		client.refreshState(reEvalInterval)
+	}
+}
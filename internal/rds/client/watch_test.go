@@ -0,0 +1,175 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server"
+	serverpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type watchTestProvider struct {
+	mu        sync.Mutex
+	resources []*pb.Resource
+}
+
+func (p *watchTestProvider) ListResources(*pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &pb.ListResourcesResponse{Resources: append([]*pb.Resource{}, p.resources...)}, nil
+}
+
+func (p *watchTestProvider) setResources(resources []*pb.Resource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resources = resources
+}
+
+// listOnlyServer implements only the ListResources RPC.
+type listOnlyServer struct {
+	spb.UnimplementedResourceDiscoveryServer
+	srv *server.Server
+}
+
+func (s *listOnlyServer) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return s.srv.ListResources(ctx, req)
+}
+
+func startGRPCServer(t *testing.T, register func(*grpc.Server)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	register(grpcServer)
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+	return ln.Addr().String()
+}
+
+func endpointNames(client *Client) []string {
+	var names []string
+	for _, ep := range client.ListEndpoints() {
+		names = append(names, ep.Name)
+	}
+	return names
+}
+
+func TestApplyWatchResponse(t *testing.T) {
+	client := &Client{cache: make(map[string]*cacheRecord)}
+
+	client.applyWatchResponse(&pb.WatchResourcesResponse{
+		Full: proto.Bool(true),
+		Resources: []*pb.Resource{
+			{Name: proto.String("r1"), Ip: proto.String("10.0.0.1")},
+			{Name: proto.String("r2"), Ip: proto.String("10.0.0.2")},
+			{Name: proto.String("r3"), Ip: proto.String("10.0.0.3")},
+		},
+		LastModified: proto.Int64(10),
+	})
+	assert.Equal(t, []string{"r1", "r2", "r3"}, endpointNames(client))
+
+	client.applyWatchResponse(&pb.WatchResourcesResponse{
+		Resources: []*pb.Resource{
+			{Name: proto.String("r2"), Ip: proto.String("10.0.0.22")},
+			{Name: proto.String("r4"), Ip: proto.String("10.0.0.4")},
+		},
+		Removed:      []string{"r1"},
+		LastModified: proto.Int64(20),
+	})
+	assert.Equal(t, []string{"r2", "r3", "r4"}, endpointNames(client))
+	assert.Equal(t, "10.0.0.22", client.cache["r2"].ipStr)
+	assert.Equal(t, int64(20), client.lastModified)
+
+	// Full response replaces everything.
+	client.applyWatchResponse(&pb.WatchResourcesResponse{
+		Full:      proto.Bool(true),
+		Resources: []*pb.Resource{{Name: proto.String("r5")}},
+	})
+	assert.Equal(t, []string{"r5"}, endpointNames(client))
+}
+
+func TestWatch(t *testing.T) {
+	tp := &watchTestProvider{resources: []*pb.Resource{{Name: proto.String("r1")}}}
+	srv, err := server.New(context.Background(), &serverpb.ServerConf{
+		WatchCheckIntervalMsec: proto.Int32(10),
+	}, map[string]server.Provider{testProviderName: tp}, &logger.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		register  func(*grpc.Server)
+		reEvalSec int32
+	}{
+		{
+			// Long re_eval_sec, to make sure that changes come over the watch
+			// stream.
+			name:      "watch",
+			register:  srv.RegisterWithGRPC,
+			reEvalSec: 3600,
+		},
+		{
+			// Server doesn't support watch, client should fall back to
+			// polling.
+			name: "unimplemented",
+			register: func(s *grpc.Server) {
+				spb.RegisterResourceDiscoveryServer(s, &listOnlyServer{srv: srv})
+			},
+			reEvalSec: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp.setResources([]*pb.Resource{{Name: proto.String("r1")}})
+			addr := startGRPCServer(t, test.register)
+
+			client, err := New(&configpb.ClientConf{
+				ServerOptions: &configpb.ClientConf_ServerOptions{ServerAddress: proto.String(addr)},
+				Request:       &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+				ReEvalSec:     proto.Int32(test.reEvalSec),
+				Watch:         proto.Bool(true),
+			}, nil, &logger.Logger{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, []string{"r1"}, endpointNames(client))
+
+			tp.setResources([]*pb.Resource{{Name: proto.String("r1")}, {Name: proto.String("r2")}})
+
+			// With polling, it may take up to 2 re_eval intervals (random delay
+			// + refresh interval) for the changes to propagate.
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual([]string{"r1", "r2"}, endpointNames(client))
+			}, 3*time.Second, 10*time.Millisecond)
+		})
+	}
+}
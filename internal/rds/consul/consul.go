@@ -0,0 +1,503 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package consul implements a Consul based resources provider for
+ResourceDiscovery server.
+
+It discovers service instances using the Consul health API, and keeps them
+up-to-date using blocking queries, i.e. changes are picked up as soon as
+Consul sees them. If no services are configured explicitly, services are
+discovered from the Consul catalog.
+
+Each service instance becomes a resource named <service>_<address>_<port>,
+with the following labels: service, node, datacenter, health, tags (comma
+separated), and service metadata.
+*/
+package consul
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/consul/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "consul"
+
+// ResourceTypes declares resource types supported by the Consul provider.
+var ResourceTypes = struct {
+	Services string
+}{
+	"services",
+}
+
+/*
+SupportedFilters defines filters supported by this provider.
+
+	 Example filters:
+	 filter {
+		 key: "name"
+		 value: "web_.*"
+	 }
+	 filter {
+		 key: "service"
+		 value: "web|api"
+	 }
+	 filter {
+		 key: "tag"
+		 value: "canary"
+	 }
+	 filter {
+		 key: "labels.health"
+		 value: "passing"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the tag filter matches if any of the instance's tags matches.
+	[]string{"name", "service", "tag"},
+	true,
+}
+
+// retryInterval is the time to wait before retrying a failed query.
+var retryInterval = 5 * time.Second
+
+// instance is a service instance, as returned by the health API.
+type instance struct {
+	Node struct {
+		Node       string
+		Address    string
+		Datacenter string
+	}
+	Service struct {
+		ID      string
+		Service string
+		Tags    []string
+		Address string
+		Port    int
+		Meta    map[string]string
+	}
+	Checks []struct {
+		Status string
+	}
+}
+
+// health returns the aggregated health status of the instance's checks:
+// critical if any check is critical, warning if any check is warning, and
+// passing otherwise.
+func (inst *instance) health() string {
+	status := "passing"
+	for _, c := range inst.Checks {
+		switch c.Status {
+		case "critical", "maintenance":
+			return "critical"
+		case "warning":
+			status = "warning"
+		}
+	}
+	return status
+}
+
+func (inst *instance) resource() *pb.Resource {
+	addr := inst.Service.Address
+	if addr == "" {
+		addr = inst.Node.Address
+	}
+
+	labels := make(map[string]string, len(inst.Service.Meta)+5)
+	for k, v := range inst.Service.Meta {
+		labels[k] = v
+	}
+	labels["service"] = inst.Service.Service
+	labels["node"] = inst.Node.Node
+	labels["datacenter"] = inst.Node.Datacenter
+	labels["health"] = inst.health()
+	if len(inst.Service.Tags) != 0 {
+		labels["tags"] = strings.Join(inst.Service.Tags, ",")
+	}
+
+	return &pb.Resource{
+		Name:   proto.String(fmt.Sprintf("%s_%s_%d", inst.Service.Service, addr, inst.Service.Port)),
+		Ip:     proto.String(addr),
+		Port:   proto.Int32(int32(inst.Service.Port)),
+		Labels: labels,
+	}
+}
+
+// Provider implements a Consul provider for use with a ResourceDiscovery
+// server.
+type Provider struct {
+	c          *configpb.ProviderConfig
+	address    string
+	token      string
+	httpClient *http.Client
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	instances   map[string][]*instance // Keyed by service name.
+	lastUpdated time.Time
+	watchers    map[string]context.CancelFunc
+}
+
+// query runs a blocking query against the Consul API, and decodes the
+// response into v. It returns the X-Consul-Index of the response.
+func (p *Provider) query(ctx context.Context, path string, params url.Values, index uint64, v interface{}) (uint64, error) {
+	if p.c.GetDatacenter() != "" {
+		params.Set("dc", p.c.GetDatacenter())
+	}
+	if index != 0 {
+		params.Set("index", strconv.FormatUint(index, 10))
+		params.Set("wait", fmt.Sprintf("%ds", p.c.GetBlockingQueryWaitSec()))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Consul-Token", p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return 0, fmt.Errorf("error parsing response (%s): %v", string(b), err)
+	}
+
+	newIndex, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid X-Consul-Index header (%s): %v", resp.Header.Get("X-Consul-Index"), err)
+	}
+	return newIndex, nil
+}
+
+// nextIndex returns the index to use for the next blocking query, as per the
+// Consul recommendations: reset the index if it goes backwards, and make sure
+// it's always greater than 0.
+func nextIndex(oldIndex, newIndex uint64) uint64 {
+	if newIndex < oldIndex || newIndex == 0 {
+		return 1
+	}
+	return newIndex
+}
+
+// watch runs the blocking query loop until the context is canceled, calling
+// fn with the index to use for each query.
+func (p *Provider) watch(ctx context.Context, name string, fn func(index uint64) (uint64, error)) {
+	var index uint64
+	for ctx.Err() == nil {
+		newIndex, err := fn(index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.l.Warningf("consul: error querying %s: %v", name, err)
+			index = 0
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+		index = nextIndex(index, newIndex)
+	}
+}
+
+func (p *Provider) filterInstances(instances []*instance) []*instance {
+	var out []*instance
+	for _, inst := range instances {
+		switch p.c.GetHealthStatus() {
+		case configpb.ProviderConfig_PASSING:
+			if inst.health() != "passing" {
+				continue
+			}
+		case configpb.ProviderConfig_PASSING_OR_WARNING:
+			if inst.health() == "critical" {
+				continue
+			}
+		}
+		out = append(out, inst)
+	}
+	return out
+}
+
+func (p *Provider) watchService(ctx context.Context, service string) {
+	params := func() url.Values {
+		params := url.Values{}
+		for _, tag := range p.c.GetTag() {
+			params.Add("tag", tag)
+		}
+		for k, v := range p.c.GetNodeMeta() {
+			params.Add("node-meta", k+":"+v)
+		}
+		if p.c.GetHealthStatus() == configpb.ProviderConfig_PASSING {
+			params.Set("passing", "true")
+		}
+		return params
+	}
+
+	p.watch(ctx, "service "+service, func(index uint64) (uint64, error) {
+		var instances []*instance
+		newIndex, err := p.query(ctx, "/v1/health/service/"+url.PathEscape(service), params(), index, &instances)
+		if err != nil {
+			return 0, err
+		}
+		if newIndex != index {
+			p.updateInstances(service, p.filterInstances(instances))
+		}
+		return newIndex, nil
+	})
+}
+
+func (p *Provider) updateInstances(service string, instances []*instance) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.instances[service] = instances
+	p.lastUpdated = time.Now()
+}
+
+// hasTags reports whether tags include all the configured tags.
+func (p *Provider) hasTags(tags []string) bool {
+	for _, want := range p.c.GetTag() {
+		found := false
+		for _, t := range tags {
+			if t == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// updateWatchers starts watchers for the new services and stops the watchers
+// for the services that don't exist anymore.
+func (p *Provider) updateWatchers(ctx context.Context, services map[string][]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for svc, cancel := range p.watchers {
+		if tags, ok := services[svc]; !ok || !p.hasTags(tags) {
+			cancel()
+			delete(p.watchers, svc)
+			delete(p.instances, svc)
+			p.lastUpdated = time.Now()
+		}
+	}
+
+	for svc, tags := range services {
+		if p.watchers[svc] != nil || !p.hasTags(tags) {
+			continue
+		}
+		watcherCtx, cancel := context.WithCancel(ctx)
+		p.watchers[svc] = cancel
+		go p.watchService(watcherCtx, svc)
+	}
+}
+
+func (p *Provider) watchCatalog(ctx context.Context) {
+	p.watch(ctx, "catalog", func(index uint64) (uint64, error) {
+		services := make(map[string][]string)
+		params := url.Values{}
+		for k, v := range p.c.GetNodeMeta() {
+			params.Add("node-meta", k+":"+v)
+		}
+		newIndex, err := p.query(ctx, "/v1/catalog/services", params, index, &services)
+		if err != nil {
+			return 0, err
+		}
+		if newIndex != index {
+			p.updateWatchers(ctx, services)
+		}
+		return newIndex, nil
+	})
+}
+
+func (p *Provider) lastModified() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastUpdated.Unix()
+}
+
+// ListResources returns the list of resources from the cache.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	tok := strings.SplitN(req.GetResourcePath(), "/", 2)
+	if tok[0] != "" && tok[0] != ResourceTypes.Services {
+		return nil, fmt.Errorf("consul: unsupported resource type: %s", tok[0])
+	}
+	var svcName string
+	if len(tok) == 2 {
+		svcName = tok[1]
+	}
+
+	lastModified := p.lastModified()
+	if req.GetIfModifiedSince() != 0 && lastModified <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: proto.Int64(lastModified)}, nil
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, svcFilter, tagFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["service"], allFilters.RegexFilters["tag"], allFilters.LabelsFilter
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	services := make([]string, 0, len(p.instances))
+	for svc := range p.instances {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+
+	var resources []*pb.Resource
+	for _, svc := range services {
+		if svcName != "" && svc != svcName {
+			continue
+		}
+		if svcFilter != nil && !svcFilter.Match(svc, p.l) {
+			continue
+		}
+
+		for _, inst := range p.instances[svc] {
+			if tagFilter != nil {
+				matched := false
+				for _, t := range inst.Service.Tags {
+					if tagFilter.Match(t, p.l) {
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					continue
+				}
+			}
+
+			res := inst.resource()
+			if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+				continue
+			}
+			if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+				continue
+			}
+			resources = append(resources, res)
+		}
+	}
+
+	p.l.Debugf("consul.listResources: returning %d resources", len(resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(lastModified),
+	}, nil
+}
+
+func apiAddress(c *configpb.ProviderConfig) string {
+	addr := c.GetAddress()
+	if addr == "" {
+		addr = os.Getenv("CONSUL_HTTP_ADDR")
+	}
+	if addr == "" {
+		addr = "http://localhost:8500"
+	}
+	if !strings.Contains(addr, "://") {
+		scheme := "http"
+		if c.GetTlsConfig() != nil {
+			scheme = "https"
+		}
+		addr = scheme + "://" + addr
+	}
+	return strings.TrimSuffix(addr, "/")
+}
+
+// New creates a Consul provider for RDS server, based on the provided
+// config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetBlockingQueryWaitSec() <= 0 {
+		return nil, fmt.Errorf("consul: invalid blocking_query_wait_sec: %d", c.GetBlockingQueryWaitSec())
+	}
+
+	token := c.GetToken()
+	if token == "" {
+		token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("consul: error parsing TLS config: %v", err)
+		}
+	}
+
+	p := &Provider{
+		c:       c,
+		address: apiAddress(c),
+		token:   token,
+		httpClient: &http.Client{
+			Transport: transport,
+			// Blocking queries can take up to wait time, and Consul adds
+			// up to wait/16 of jitter to it.
+			Timeout: time.Duration(c.GetBlockingQueryWaitSec())*time.Second*17/16 + 10*time.Second,
+		},
+		l:         l,
+		instances: make(map[string][]*instance),
+		watchers:  make(map[string]context.CancelFunc),
+	}
+
+	ctx := context.Background()
+	if len(c.GetService()) == 0 {
+		go p.watchCatalog(ctx)
+		return p, nil
+	}
+
+	for _, svc := range c.GetService() {
+		go p.watchService(ctx, svc)
+	}
+	return p, nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/consul/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testWebInstances = `[
+  {
+    "Node": {"Node": "node-1", "Address": "10.0.0.1", "Datacenter": "dc1"},
+    "Service": {"ID": "web-1", "Service": "web", "Tags": ["prod", "v1"], "Address": "10.1.0.1", "Port": 8080, "Meta": {"version": "1.2"}},
+    "Checks": [{"Status": "passing"}, {"Status": "passing"}]
+  },
+  {
+    "Node": {"Node": "node-2", "Address": "10.0.0.2", "Datacenter": "dc1"},
+    "Service": {"ID": "web-2", "Service": "web", "Tags": ["canary"], "Address": "", "Port": 8080},
+    "Checks": [{"Status": "passing"}, {"Status": "warning"}]
+  },
+  {
+    "Node": {"Node": "node-3", "Address": "10.0.0.3", "Datacenter": "dc1"},
+    "Service": {"ID": "web-3", "Service": "web", "Tags": ["prod"], "Address": "10.1.0.3", "Port": 8080},
+    "Checks": [{"Status": "critical"}]
+  }
+]`
+
+const testDBInstances = `[
+  {
+    "Node": {"Node": "node-1", "Address": "10.0.0.1", "Datacenter": "dc1"},
+    "Service": {"ID": "db-1", "Service": "db", "Address": "10.1.0.5", "Port": 5432},
+    "Checks": []
+  }
+]`
+
+type fakeConsul struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	services map[string]string
+}
+
+func (fc *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fc.mu.Lock()
+	fc.requests = append(fc.requests, r)
+	fc.mu.Unlock()
+
+	// Nothing changes in this server, so blocking queries just wait a bit
+	// and return the same index.
+	if r.URL.Query().Get("index") != "" {
+		time.Sleep(time.Second)
+	}
+
+	w.Header().Set("X-Consul-Index", "10")
+	if r.URL.Path == "/v1/catalog/services" {
+		fmt.Fprint(w, `{"consul": [], "web": ["prod", "v1", "canary"], "db": []}`)
+		return
+	}
+	for svc, resp := range fc.services {
+		if r.URL.Path == "/v1/health/service/"+svc {
+			fmt.Fprint(w, resp)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (fc *fakeConsul) firstRequest(path string) *http.Request {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for _, r := range fc.requests {
+		if r.URL.Path == path {
+			return r
+		}
+	}
+	return nil
+}
+
+func testProvider(t *testing.T, c *configpb.ProviderConfig) (*Provider, *fakeConsul) {
+	t.Helper()
+
+	fc := &fakeConsul{services: map[string]string{"web": testWebInstances, "db": testDBInstances, "consul": "[]"}}
+	srv := httptest.NewServer(fc)
+	t.Cleanup(srv.Close)
+
+	c.Address = proto.String(srv.URL)
+	p, err := New(c, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating provider: %v", err)
+	}
+	return p, fc
+}
+
+func waitForResources(t *testing.T, p *Provider, n int) {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		resp, err := p.ListResources(&pb.ListResourcesRequest{})
+		if err == nil && len(resp.GetResources()) >= n {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d resources", n)
+}
+
+func resourceNames(resources []*pb.Resource) []string {
+	var names []string
+	for _, res := range resources {
+		names = append(names, res.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestListResources(t *testing.T) {
+	p, fc := testProvider(t, &configpb.ProviderConfig{
+		Service:      []string{"web", "db"},
+		Datacenter:   proto.String("dc1"),
+		Token:        proto.String("test-token"),
+		HealthStatus: configpb.ProviderConfig_PASSING_OR_WARNING.Enum(),
+	})
+	waitForResources(t, p, 3)
+
+	req := fc.firstRequest("/v1/health/service/web")
+	assert.Equal(t, "dc1", req.URL.Query().Get("dc"))
+	assert.Equal(t, "test-token", req.Header.Get("X-Consul-Token"))
+
+	tests := []struct {
+		desc      string
+		resPath   string
+		filters   map[string]string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			desc:      "all",
+			wantNames: []string{"db_10.1.0.5_5432", "web_10.0.0.2_8080", "web_10.1.0.1_8080"},
+		},
+		{
+			desc:      "service_path",
+			resPath:   "services/web",
+			wantNames: []string{"web_10.0.0.2_8080", "web_10.1.0.1_8080"},
+		},
+		{
+			desc:      "tag_filter",
+			filters:   map[string]string{"tag": "can.*"},
+			wantNames: []string{"web_10.0.0.2_8080"},
+		},
+		{
+			desc:      "service_filter",
+			filters:   map[string]string{"service": "db"},
+			wantNames: []string{"db_10.1.0.5_5432"},
+		},
+		{
+			desc:      "labels_filter",
+			filters:   map[string]string{"labels.health": "warning"},
+			wantNames: []string{"web_10.0.0.2_8080"},
+		},
+		{
+			desc:    "bad_resource_type",
+			resPath: "nodes",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &pb.ListResourcesRequest{ResourcePath: proto.String(test.resPath)}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+			resp, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantNames, resourceNames(resp.GetResources()))
+		})
+	}
+}
+
+func TestResourceLabels(t *testing.T) {
+	p, _ := testProvider(t, &configpb.ProviderConfig{
+		Service: []string{"web"},
+	})
+	waitForResources(t, p, 1)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 1, "only passing instances expected")
+	assert.Equal(t, &pb.Resource{
+		Name: proto.String("web_10.1.0.1_8080"),
+		Ip:   proto.String("10.1.0.1"),
+		Port: proto.Int32(8080),
+		Labels: map[string]string{
+			"service":    "web",
+			"node":       "node-1",
+			"datacenter": "dc1",
+			"health":     "passing",
+			"tags":       "prod,v1",
+			"version":    "1.2",
+		},
+	}, resp.GetResources()[0])
+
+	// If-modified-since.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(resp.GetLastModified())})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+}
+
+func TestCatalogDiscovery(t *testing.T) {
+	p, fc := testProvider(t, &configpb.ProviderConfig{
+		Tag:          []string{"prod"},
+		NodeMeta:     map[string]string{"rack": "r1"},
+		HealthStatus: configpb.ProviderConfig_ANY.Enum(),
+	})
+	waitForResources(t, p, 3)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	// Only the "web" service has the "prod" tag. Server-side tag filtering is
+	// not implemented by the fake server.
+	assert.Equal(t, []string{"web_10.0.0.2_8080", "web_10.1.0.1_8080", "web_10.1.0.3_8080"}, resourceNames(resp.GetResources()))
+
+	req := fc.firstRequest("/v1/health/service/web")
+	assert.Equal(t, "prod", req.URL.Query().Get("tag"))
+	assert.Equal(t, "rack:r1", req.URL.Query().Get("node-meta"))
+	assert.Nil(t, fc.firstRequest("/v1/health/service/db"))
+}
+
+func TestUpdateWatchers(t *testing.T) {
+	p := &Provider{
+		c:         &configpb.ProviderConfig{},
+		instances: map[string][]*instance{"old": nil},
+		watchers:  make(map[string]context.CancelFunc),
+	}
+	canceled := false
+	p.watchers["old"] = func() { canceled = true }
+
+	// Use an already canceled context to not start any queries.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.updateWatchers(ctx, map[string][]string{"web": nil})
+
+	assert.True(t, canceled)
+	assert.NotContains(t, p.instances, "old")
+	assert.Contains(t, p.watchers, "web")
+}
+
+func TestNextIndex(t *testing.T) {
+	for _, test := range []struct {
+		old, new, want uint64
+	}{
+		{0, 10, 10},
+		{10, 12, 12},
+		{12, 5, 1},
+		{5, 0, 1},
+	} {
+		t.Run(strconv.FormatUint(test.old, 10)+"_"+strconv.FormatUint(test.new, 10), func(t *testing.T) {
+			assert.Equal(t, test.want, nextIndex(test.old, test.new))
+		})
+	}
+}
+
+func TestAPIAddress(t *testing.T) {
+	t.Setenv("CONSUL_HTTP_ADDR", "")
+	assert.Equal(t, "http://localhost:8500", apiAddress(&configpb.ProviderConfig{}))
+
+	t.Setenv("CONSUL_HTTP_ADDR", "consul.local:8501")
+	assert.Equal(t, "http://consul.local:8501", apiAddress(&configpb.ProviderConfig{}))
+	assert.Equal(t, "https://consul.local:8500", apiAddress(&configpb.ProviderConfig{Address: proto.String("https://consul.local:8500/")}))
+}
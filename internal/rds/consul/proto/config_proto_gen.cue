@@ -0,0 +1,82 @@
+// Configuration proto for Consul provider.
+//
+// Example provider config:
+// {
+//   address: "http://consul.service:8500"
+//   service: "web"
+//   tag: "prod"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "consul://services/web"
+//       filter {
+//         key: "tag"
+//         value: "canary"
+//       }
+//     }
+//   }
+// }
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Consul provider config.
+#ProviderConfig: {
+	// Consul HTTP API address, e.g. "http://consul.service:8500". If not
+	// specified, CONSUL_HTTP_ADDR environment variable is used, and if that's
+	// not set either, "http://localhost:8500".
+	address?: string @protobuf(1,string)
+
+	// ACL token. If not specified, CONSUL_HTTP_TOKEN environment variable is
+	// used.
+	token?: string @protobuf(2,string)
+
+	// TLS config to talk to the Consul API.
+	tlsConfig?: proto.#TLSConfig @protobuf(3,tlsconfig.TLSConfig,name=tls_config)
+
+	// Datacenter to discover services in. Default is the datacenter of the
+	// agent we talk to.
+	datacenter?: string @protobuf(4,string)
+
+	// Services to discover. If not specified, all services in the catalog are
+	// discovered (and new services are picked up automatically).
+	service?: [...string] @protobuf(5,string)
+
+	// Only discover service instances that have all these tags.
+	tag?: [...string] @protobuf(6,string)
+
+	// Only discover service instances running on the nodes with this metadata.
+	nodeMeta?: {
+		[string]: string
+	} @protobuf(7,map[string]string,node_meta)
+
+	#HealthStatus: {
+		// Service instances with all health checks passing.
+		"PASSING"
+		#enumValue: 0
+	} | {
+		// Service instances with passing or warning health checks.
+		"PASSING_OR_WARNING"
+		#enumValue: 1
+	} | {
+		// All service instances, irrespective of the health. Health status is
+		// still available in the "health" label.
+		"ANY"
+		#enumValue: 2
+	}
+
+	#HealthStatus_value: {
+		PASSING:            0
+		PASSING_OR_WARNING: 1
+		ANY:                2
+	}
+	healthStatus?: #HealthStatus @protobuf(8,HealthStatus,name=health_status,"default=PASSING")
+
+	// Services are refreshed using Consul blocking queries, i.e. we get the
+	// updates as soon as they happen. This is the maximum time to wait for an
+	// update before re-issuing the query.
+	blockingQueryWaitSec?: int32 @protobuf(9,int32,name=blocking_query_wait_sec,"default=300")
+}
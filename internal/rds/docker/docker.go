@@ -0,0 +1,417 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package docker implements a resources provider for ResourceDiscovery server,
+that discovers running containers through the local Docker Engine API.
+
+Each container port becomes a resource named <container>_<port>, or just
+<container> if the container doesn't expose any port. Resources carry the
+container labels, and the following additional labels: container_id, image,
+network and networks (comma separated).
+*/
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/docker/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "docker"
+
+const defaultAddress = "unix:///var/run/docker.sock"
+
+// ResourceTypes declares resource types supported by the Docker provider.
+var ResourceTypes = struct {
+	Containers string
+}{
+	"containers",
+}
+
+/*
+SupportedFilters defines filters supported by this provider.
+
+	 Example filters:
+	 filter {
+		 key: "name"
+		 value: "web.*"
+	 }
+	 filter {
+		 key: "image"
+		 value: "nginx:.*"
+	 }
+	 filter {
+		 key: "network"
+		 value: "frontend"
+	 }
+	 filter {
+		 key: "labels.com.docker.compose.project"
+		 value: "shop"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the network filter matches if any of the container's networks
+	// matches.
+	[]string{"name", "image", "network"},
+	true,
+}
+
+// container is a container, as returned by the containers list API.
+type container struct {
+	ID     string
+	Names  []string
+	Image  string
+	Labels map[string]string
+	Ports  []struct {
+		IP          string
+		PrivatePort int
+		PublicPort  int
+		Type        string
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string
+		}
+	}
+}
+
+func (c *container) name() string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+func (c *container) networks() []string {
+	var networks []string
+	for nw := range c.NetworkSettings.Networks {
+		networks = append(networks, nw)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// ip returns the container's network and IP address on that network. If
+// network is empty, the first network with an IP address is used.
+func (c *container) ip(network string) (string, string) {
+	if network != "" {
+		return network, c.NetworkSettings.Networks[network].IPAddress
+	}
+	for _, nw := range c.networks() {
+		if ip := c.NetworkSettings.Networks[nw].IPAddress; ip != "" {
+			return nw, ip
+		}
+	}
+	return "", ""
+}
+
+type endpoint struct {
+	ip   string
+	port int
+}
+
+// endpoints returns container endpoints, either private or published ports.
+// Ports are deduplicated as Docker lists a published port once for each
+// address family.
+func (c *container) endpoints(ip string, published bool) []endpoint {
+	var eps []endpoint
+	seen := make(map[int]bool)
+	for _, p := range c.Ports {
+		epIP, port := ip, p.PrivatePort
+		if published {
+			if p.PublicPort == 0 {
+				continue
+			}
+			epIP, port = p.IP, p.PublicPort
+			if epIP == "" || epIP == "0.0.0.0" || epIP == "::" {
+				epIP = "127.0.0.1"
+			}
+		}
+		if seen[port] {
+			continue
+		}
+		seen[port] = true
+		eps = append(eps, endpoint{epIP, port})
+	}
+	sort.Slice(eps, func(i, j int) bool { return eps[i].port < eps[j].port })
+	return eps
+}
+
+func (c *container) resources(network string, published bool) []*pb.Resource {
+	network, ip := c.ip(network)
+	if ip == "" {
+		ip = "127.0.0.1"
+	}
+
+	labels := make(map[string]string, len(c.Labels)+4)
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	labels["container_id"] = c.ID
+	labels["image"] = c.Image
+	labels["network"] = network
+	labels["networks"] = strings.Join(c.networks(), ",")
+
+	eps := c.endpoints(ip, published)
+	if len(eps) == 0 {
+		if published {
+			return nil
+		}
+		return []*pb.Resource{{
+			Name:   proto.String(c.name()),
+			Ip:     proto.String(ip),
+			Id:     proto.String(c.ID),
+			Labels: labels,
+		}}
+	}
+
+	var resources []*pb.Resource
+	for _, ep := range eps {
+		resources = append(resources, &pb.Resource{
+			Name:   proto.String(fmt.Sprintf("%s_%d", c.name(), ep.port)),
+			Ip:     proto.String(ep.ip),
+			Port:   proto.Int32(int32(ep.port)),
+			Id:     proto.String(c.ID),
+			Labels: labels,
+		})
+	}
+	return resources
+}
+
+// Provider implements a Docker provider for use with a ResourceDiscovery
+// server.
+type Provider struct {
+	c          *configpb.ProviderConfig
+	baseURL    string
+	httpClient *http.Client
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*pb.Resource
+	lastUpdated int64
+}
+
+func (p *Provider) listContainers(ctx context.Context) ([]*container, error) {
+	filters := map[string][]string{"status": {"running"}}
+	if len(p.c.GetLabel()) != 0 {
+		filters["label"] = p.c.GetLabel()
+	}
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/containers/json?filters="+url.QueryEscape(string(filtersJSON)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+
+	var containers []*container
+	if err := json.Unmarshal(b, &containers); err != nil {
+		return nil, fmt.Errorf("error parsing response (%s): %v", string(b), err)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].name() < containers[j].name() })
+	return containers, nil
+}
+
+func (p *Provider) refresh(ctx context.Context) {
+	containers, err := p.listContainers(ctx)
+	if err != nil {
+		// Keep using the existing containers.
+		p.l.Errorf("docker: error listing containers: %v", err)
+		return
+	}
+
+	var resources []*pb.Resource
+	for _, c := range containers {
+		resources = append(resources, c.resources(p.c.GetNetwork(), p.c.GetUsePublishedPorts())...)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Update the modification time only if something changed, so that RDS
+	// clients don't have to reprocess the same resources.
+	if p.lastUpdated != 0 && resourcesEqual(p.cache, resources) {
+		return
+	}
+	p.cache = resources
+	p.lastUpdated = time.Now().Unix()
+}
+
+func resourcesEqual(a, b []*pb.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListResources returns the list of resources from the cache.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resType := strings.SplitN(req.GetResourcePath(), "/", 2)[0]
+	if resType != "" && resType != ResourceTypes.Containers {
+		return nil, fmt.Errorf("docker: unsupported resource type: %s", resType)
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, imageFilter, networkFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["image"], allFilters.RegexFilters["network"], allFilters.LabelsFilter
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: proto.Int64(p.lastUpdated)}, nil
+	}
+
+	var resources []*pb.Resource
+	for _, res := range p.cache {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+			continue
+		}
+		if imageFilter != nil && !imageFilter.Match(res.GetLabels()["image"], p.l) {
+			continue
+		}
+		if networkFilter != nil {
+			matched := false
+			for _, nw := range strings.Split(res.GetLabels()["networks"], ",") {
+				if networkFilter.Match(nw, p.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	p.l.Debugf("docker.listResources: returning %d resources", len(resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(p.lastUpdated),
+	}, nil
+}
+
+// httpClientForAddress returns the base URL and HTTP client to talk to the
+// Docker API at the given address.
+func httpClientForAddress(addr string) (string, *http.Client, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", nil, fmt.Errorf("docker: invalid address (%s): %v", addr, err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	switch u.Scheme {
+	case "unix":
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", u.Path)
+		}
+		client.Transport = transport
+		// Host doesn't matter for unix sockets.
+		return "http://docker", client, nil
+	case "tcp":
+		return "http://" + u.Host, client, nil
+	case "http", "https":
+		return strings.TrimSuffix(addr, "/"), client, nil
+	default:
+		return "", nil, fmt.Errorf("docker: unsupported address scheme (%s) in %s", u.Scheme, addr)
+	}
+}
+
+// New creates a Docker provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("docker: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	addr := c.GetAddress()
+	if addr == "" {
+		addr = os.Getenv("DOCKER_HOST")
+	}
+	if addr == "" {
+		addr = defaultAddress
+	}
+
+	baseURL, client, err := httpClientForAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		c:          c,
+		baseURL:    baseURL,
+		httpClient: client,
+		l:          l,
+	}
+
+	ctx := context.Background()
+	p.refresh(ctx)
+	go func() {
+		for range time.Tick(time.Duration(c.GetReEvalSec()) * time.Second) {
+			p.refresh(ctx)
+		}
+	}()
+
+	return p, nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/docker/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testContainers = `[
+	{
+		"Id": "c1",
+		"Names": ["/web"],
+		"Image": "nginx:1.25",
+		"Labels": {"app": "web"},
+		"Ports": [
+			{"IP": "0.0.0.0", "PrivatePort": 80, "PublicPort": 8080, "Type": "tcp"},
+			{"IP": "::", "PrivatePort": 80, "PublicPort": 8080, "Type": "tcp"},
+			{"PrivatePort": 443, "Type": "tcp"}
+		],
+		"NetworkSettings": {"Networks": {"frontend": {"IPAddress": "172.18.0.2"}, "backend": {"IPAddress": "172.19.0.2"}}}
+	},
+	{
+		"Id": "c2",
+		"Names": ["/db"],
+		"Image": "postgres:16",
+		"Labels": {"app": "db"},
+		"Ports": [{"PrivatePort": 5432, "Type": "tcp"}],
+		"NetworkSettings": {"Networks": {"backend": {"IPAddress": "172.19.0.3"}}}
+	},
+	{
+		"Id": "c3",
+		"Names": ["/agent"],
+		"Image": "agent:latest",
+		"NetworkSettings": {"Networks": {"host": {"IPAddress": ""}}}
+	}
+]`
+
+func testDockerServer(t *testing.T, wantLabels []string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var filters map[string][]string
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, []string{"running"}, filters["status"])
+		assert.Equal(t, wantLabels, filters["label"])
+		fmt.Fprint(w, testContainers)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testProvider(t *testing.T, c *configpb.ProviderConfig) *Provider {
+	t.Helper()
+
+	srv := testDockerServer(t, c.GetLabel())
+	c.Address = proto.String(srv.URL)
+	p, err := New(c, &logger.Logger{})
+	assert.NoError(t, err)
+	return p
+}
+
+func resourceIPs(resources []*pb.Resource) map[string]string {
+	ips := make(map[string]string)
+	for _, res := range resources {
+		ips[res.GetName()] = fmt.Sprintf("%s:%d", res.GetIp(), res.GetPort())
+	}
+	return ips
+}
+
+func TestListResources(t *testing.T) {
+	tests := []struct {
+		desc    string
+		conf    *configpb.ProviderConfig
+		filters map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "all",
+			conf: &configpb.ProviderConfig{},
+			want: map[string]string{
+				"agent":   "127.0.0.1:0",
+				"db_5432": "172.19.0.3:5432",
+				"web_80":  "172.19.0.2:80",
+				"web_443": "172.19.0.2:443",
+			},
+		},
+		{
+			desc: "network",
+			conf: &configpb.ProviderConfig{Network: proto.String("frontend"), Label: []string{"app"}},
+			filters: map[string]string{
+				"network": "frontend",
+			},
+			want: map[string]string{
+				"web_80":  "172.18.0.2:80",
+				"web_443": "172.18.0.2:443",
+			},
+		},
+		{
+			desc: "published_ports",
+			conf: &configpb.ProviderConfig{UsePublishedPorts: proto.Bool(true)},
+			want: map[string]string{
+				"web_8080": "127.0.0.1:8080",
+			},
+		},
+		{
+			desc:    "image_and_labels",
+			conf:    &configpb.ProviderConfig{},
+			filters: map[string]string{"image": "postgres:.*", "labels.app": "db"},
+			want: map[string]string{
+				"db_5432": "172.19.0.3:5432",
+			},
+		},
+		{
+			desc:    "bad_filter",
+			conf:    &configpb.ProviderConfig{},
+			filters: map[string]string{"zone": "a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p := testProvider(t, test.conf)
+
+			req := &pb.ListResourcesRequest{ResourcePath: proto.String("containers")}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resp, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, resourceIPs(resp.GetResources()))
+		})
+	}
+}
+
+func TestResourceLabels(t *testing.T) {
+	p := testProvider(t, &configpb.ProviderConfig{})
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{Filter: []*pb.Filter{{Key: proto.String("name"), Value: proto.String("web_80")}}})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 1)
+	assert.Equal(t, map[string]string{
+		"app":          "web",
+		"container_id": "c1",
+		"image":        "nginx:1.25",
+		"network":      "backend",
+		"networks":     "backend,frontend",
+	}, resp.GetResources()[0].GetLabels())
+}
+
+func TestIfModifiedSince(t *testing.T) {
+	p := testProvider(t, &configpb.ProviderConfig{})
+	lastUpdated := p.lastUpdated
+
+	// Refreshing with the same containers shouldn't update the modification
+	// time.
+	p.refresh(context.Background())
+	assert.Equal(t, lastUpdated, p.lastUpdated)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastUpdated)})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+	assert.Equal(t, lastUpdated, resp.GetLastModified())
+
+	_, err = p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("images")})
+	assert.Error(t, err)
+}
+
+func TestUnixSocket(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	srv := httptest.NewUnstartedServer(testDockerServer(t, nil).Config.Handler)
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+
+	p, err := New(&configpb.ProviderConfig{Address: proto.String("unix://" + sockPath)}, &logger.Logger{})
+	assert.NoError(t, err)
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 4)
+}
+
+func TestHTTPClientForAddress(t *testing.T) {
+	for addr, want := range map[string]string{
+		"unix:///var/run/docker.sock":  "http://docker",
+		"tcp://10.0.0.2:2375":          "http://10.0.0.2:2375",
+		"https://docker.example:2376/": "https://docker.example:2376",
+	} {
+		baseURL, _, err := httpClientForAddress(addr)
+		assert.NoError(t, err, addr)
+		assert.Equal(t, want, baseURL, addr)
+	}
+
+	_, _, err := httpClientForAddress("ssh://host")
+	assert.Error(t, err)
+}
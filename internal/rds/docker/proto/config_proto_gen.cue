@@ -0,0 +1,48 @@
+// Configuration proto for Docker provider.
+//
+// Example provider config:
+// {
+//   label: "cloudprober.probe=true"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "docker://containers"
+//       filter {
+//         key: "image"
+//         value: "nginx.*"
+//       }
+//     }
+//   }
+// }
+package proto
+
+// Docker provider config.
+#ProviderConfig: {
+	// Docker Engine API address, e.g. "unix:///var/run/docker.sock" or
+	// "tcp://10.0.0.2:2375". If not specified, DOCKER_HOST environment variable
+	// is used, and if that's not set either, "unix:///var/run/docker.sock".
+	// Any Docker-compatible API works, e.g. Podman's socket, or containerd
+	// through a Docker API shim.
+	address?: string @protobuf(1,string)
+
+	// Only discover containers with these labels. Labels can be specified as
+	// "key" (container has the label) or "key=value".
+	label?: [...string] @protobuf(2,string)
+
+	// Network to take the container IP from. If not specified, the first
+	// network (in alphabetical order) with an IP address is used. Containers
+	// without an IP address (e.g. host network mode) get 127.0.0.1.
+	network?: string @protobuf(3,string)
+
+	// If enabled, resources use the published (host) ports, and IPs are set to
+	// the host IP the port is published on (127.0.0.1 for the wildcard
+	// address). Containers without published ports are skipped. This is useful
+	// to probe containers the way their clients see them.
+	usePublishedPorts?: bool @protobuf(4,bool,name=use_published_ports,"default=false")
+
+	// How often to refresh the containers list.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=30")
+}
@@ -18,6 +18,7 @@ Package file implements a file-based targets provider for cloudprober.
 package file
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"path/filepath"
@@ -70,6 +71,9 @@ type lister struct {
 
 	lastUpdated  time.Time
 	checkModTime bool
+
+	// remote is set for remote (HTTP(S), GCS, S3) files.
+	remote *remoteFetcher
 }
 
 func (ls *lister) lastModified() int64 {
@@ -159,13 +163,23 @@ func (ls *lister) shouldReloadFile() bool {
 	return modTime.After(ls.lastUpdated)
 }
 
-func (ls *lister) refresh() error {
+func (ls *lister) readFile() ([]byte, error) {
+	if ls.remote != nil {
+		return ls.remote.fetch(context.Background())
+	}
+
 	if !ls.shouldReloadFile() {
+		return nil, errNotModified
+	}
+	return file.ReadFile(ls.filePath)
+}
+
+func (ls *lister) refresh() error {
+	b, err := ls.readFile()
+	if err == errNotModified {
 		ls.l.Infof("file(%s): Skipping reloading file as it has not changed since its last refresh at %v", ls.filePath, ls.lastUpdated)
 		return nil
 	}
-
-	b, err := file.ReadFile(ls.filePath)
 	if err != nil {
 		return fmt.Errorf("file(%s): error while reading file: %v", ls.filePath, err)
 	}
@@ -210,6 +224,14 @@ func newLister(filePath string, c *configpb.ProviderConfig, l *logger.Logger) (*
 		checkModTime: !c.GetDisableModifiedTimeCheck(),
 	}
 
+	if isRemote(filePath) {
+		rf, err := newRemoteFetcher(filePath, c, l)
+		if err != nil {
+			return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
+		}
+		ls.remote = rf
+	}
+
 	reEvalSec := c.GetReEvalSec()
 	if reEvalSec == 0 {
 		return ls, ls.refresh()
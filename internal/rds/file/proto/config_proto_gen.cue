@@ -1,10 +1,21 @@
 package proto
 
-import "github.com/cloudprober/cloudprober/internal/rds/proto"
+import (
+	"github.com/cloudprober/cloudprober/internal/oauth/proto"
+	proto_1 "github.com/cloudprober/cloudprober/internal/rds/proto"
+	proto_5 "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+)
 
 // File provider config.
 #ProviderConfig: {
-	// File that contains resources in either textproto or json format.
+	// File that contains resources in either textproto or json format. Besides
+	// local files, following remote locations are supported:
+	//   HTTP(S) URLs: "https://config.example.com/targets.json"
+	//   GCS objects: "gs://<bucket>/<object>"
+	//   S3 objects: "s3://<bucket>/<key>"
+	// Remote files are fetched conditionally (using ETag and Last-Modified), so
+	// they are downloaded again only if they change.
+	//
 	// Example in textproto format:
 	//
 	// resource {
@@ -46,10 +57,27 @@ import "github.com/cloudprober/cloudprober/internal/rds/proto"
 
 	// Whenever possible, we reload a file only if it has been modified since the
 	// last load. If following option is set, mod time check is disabled.
-	// Note that mod-time check doesn't work for GCS.
+	// For remote files, this disables conditional fetching.
 	disableModifiedTimeCheck?: bool @protobuf(4,bool,name=disable_modified_time_check)
+
+	// HTTP headers to add to the requests for HTTP(S) URLs, e.g.:
+	//   header {
+	//     key: "X-Api-Key"
+	//     value: "6f1a62f8"
+	//   }
+	header?: {
+		[string]: string
+	} @protobuf(5,map[string]string)
+
+	// OAuth config for HTTP(S) URLs and GCS objects. GCS objects are fetched
+	// using the application default credentials if it's not specified. S3
+	// objects are always fetched using the default AWS credentials chain.
+	oauthConfig?: proto.#Config @protobuf(6,.cloudprober.oauth.Config,name=oauth_config)
+
+	// TLS config for HTTP(S) URLs.
+	tlsConfig?: proto_5.#TLSConfig @protobuf(7,.cloudprober.tlsconfig.TLSConfig,name=tls_config)
 }
 
 #FileResources: {
-	resource?: [...proto.#Resource] @protobuf(1,.cloudprober.rds.Resource)
+	resource?: [...proto_1.#Resource] @protobuf(1,.cloudprober.rds.Resource)
 }
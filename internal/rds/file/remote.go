@@ -0,0 +1,206 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudprober/cloudprober/internal/oauth"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const gcsReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// errNotModified is returned by the remote fetcher if the file has not
+// changed since the last fetch.
+var errNotModified = errors.New("not modified")
+
+var remotePrefixes = []string{"http://", "https://", "gs://", "s3://"}
+
+func isRemote(path string) bool {
+	for _, prefix := range remotePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type s3GetObjectAPI interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// remoteFetcher fetches resources files from the remote locations: HTTP(S)
+// URLs, GCS and S3. Unless disabled, it uses conditional requests, based on
+// the ETag and Last-Modified of the last fetch, to avoid downloading files
+// that have not changed.
+type remoteFetcher struct {
+	path        string
+	conditional bool
+	header      map[string]string
+	httpClient  *http.Client
+	tokenSource oauth2.TokenSource
+	s3Client    s3GetObjectAPI
+	gcsBaseURL  string
+
+	etag         string
+	lastModified string
+}
+
+func (rf *remoteFetcher) fetch(ctx context.Context) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(rf.path, "s3://"):
+		return rf.fetchS3(ctx, strings.TrimPrefix(rf.path, "s3://"))
+	case strings.HasPrefix(rf.path, "gs://"):
+		return rf.fetchHTTP(ctx, rf.gcsBaseURL+"/"+strings.TrimPrefix(rf.path, "gs://"))
+	}
+	return rf.fetchHTTP(ctx, rf.path)
+}
+
+func (rf *remoteFetcher) fetchHTTP(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range rf.header {
+		req.Header.Set(k, v)
+	}
+	if rf.tokenSource != nil {
+		tok, err := rf.tokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error getting OAuth token: %v", err)
+		}
+		tok.SetAuthHeader(req)
+	}
+	if rf.conditional {
+		if rf.etag != "" {
+			req.Header.Set("If-None-Match", rf.etag)
+		}
+		if rf.lastModified != "" {
+			req.Header.Set("If-Modified-Since", rf.lastModified)
+		}
+	}
+
+	resp, err := rf.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status: %s", resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	rf.etag, rf.lastModified = resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	return b, nil
+}
+
+func (rf *remoteFetcher) fetchS3(ctx context.Context, objectPath string) ([]byte, error) {
+	bucket, key, ok := strings.Cut(objectPath, "/")
+	if !ok || bucket == "" || key == "" {
+		return nil, fmt.Errorf("invalid S3 path: s3://%s, expected format: s3://<bucket>/<key>", objectPath)
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if rf.conditional && rf.etag != "" {
+		input.IfNoneMatch = aws.String(rf.etag)
+	}
+
+	out, err := rf.s3Client.GetObject(ctx, input)
+	if err != nil {
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotModified {
+			return nil, errNotModified
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	rf.etag = aws.ToString(out.ETag)
+	return b, nil
+}
+
+func newRemoteFetcher(path string, c *configpb.ProviderConfig, l *logger.Logger) (*remoteFetcher, error) {
+	rf := &remoteFetcher{
+		path:        path,
+		conditional: !c.GetDisableModifiedTimeCheck(),
+		header:      c.GetHeader(),
+		gcsBaseURL:  "https://storage.googleapis.com",
+	}
+
+	if strings.HasPrefix(path, "s3://") {
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error loading AWS config: %v", err)
+		}
+		rf.s3Client = s3.NewFromConfig(cfg)
+		return rf, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("error parsing TLS config: %v", err)
+		}
+	}
+	rf.httpClient = &http.Client{Transport: transport, Timeout: time.Minute}
+
+	switch {
+	case c.GetOauthConfig() != nil:
+		ts, err := oauth.TokenSourceFromConfig(c.GetOauthConfig(), l)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OAuth token source: %v", err)
+		}
+		rf.tokenSource = ts
+	case strings.HasPrefix(path, "gs://"):
+		ts, err := google.DefaultTokenSource(context.Background(), gcsReadOnlyScope)
+		if err != nil {
+			return nil, fmt.Errorf("error getting default credentials for GCS: %v", err)
+		}
+		rf.tokenSource = ts
+	}
+
+	return rf, nil
+}
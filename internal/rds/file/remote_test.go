@@ -0,0 +1,191 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// testFileServer serves the given content with an ETag, and honors the
+// If-None-Match header.
+func testFileServer(t *testing.T, content *[]byte, etag *string, requests *[]*http.Request) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("If-None-Match") == *etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", *etag)
+		w.Write(*content)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestRemoteFetcherHTTP(t *testing.T) {
+	content, etag := []byte("v1"), `"1"`
+	var requests []*http.Request
+	ts := testFileServer(t, &content, &etag, &requests)
+
+	header := map[string]string{"X-Api-Key": "secret"}
+	httpRF, err := newRemoteFetcher(ts.URL+"/targets.json", &configpb.ProviderConfig{Header: header}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Skip newRemoteFetcher for GCS, as it requires default credentials.
+	gcsRF := &remoteFetcher{
+		path:        "gs://bucket/targets.json",
+		conditional: true,
+		header:      header,
+		httpClient:  http.DefaultClient,
+		gcsBaseURL:  ts.URL,
+	}
+
+	for _, rf := range []*remoteFetcher{httpRF, gcsRF} {
+		t.Run(rf.path, func(t *testing.T) {
+			content, etag = []byte("v1"), `"1"`
+			requests = nil
+
+			b, err := rf.fetch(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", string(b))
+
+			_, err = rf.fetch(context.Background())
+			assert.Equal(t, errNotModified, err)
+			assert.Equal(t, `"1"`, requests[1].Header.Get("If-None-Match"))
+			assert.Contains(t, requests[1].URL.Path, "targets.json")
+
+			content, etag = []byte("v2"), `"2"`
+			b, err = rf.fetch(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "v2", string(b))
+
+			// Unconditional fetch.
+			rf.conditional = false
+			b, err = rf.fetch(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "v2", string(b))
+			assert.Empty(t, requests[3].Header.Get("If-None-Match"))
+
+			rf.header = nil
+			_, err = rf.fetch(context.Background())
+			assert.ErrorContains(t, err, "403")
+		})
+	}
+}
+
+type fakeS3 struct {
+	content []byte
+	etag    string
+	inputs  []*s3.GetObjectInput
+}
+
+func (fs *fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	fs.inputs = append(fs.inputs, params)
+	if aws.ToString(params.IfNoneMatch) == fs.etag {
+		return nil, &smithy.OperationError{
+			ServiceID:     "S3",
+			OperationName: "GetObject",
+			Err: &awshttp.ResponseError{
+				ResponseError: &smithyhttp.ResponseError{
+					Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusNotModified}},
+				},
+			},
+		}
+	}
+	return &s3.GetObjectOutput{
+		Body: io.NopCloser(bytes.NewReader(fs.content)),
+		ETag: aws.String(fs.etag),
+	}, nil
+}
+
+func TestRemoteFetcherS3(t *testing.T) {
+	fs := &fakeS3{content: []byte("v1"), etag: `"1"`}
+	rf := &remoteFetcher{path: "s3://bucket/dir/targets.json", conditional: true, s3Client: fs}
+
+	b, err := rf.fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", string(b))
+	assert.Equal(t, "bucket", aws.ToString(fs.inputs[0].Bucket))
+	assert.Equal(t, "dir/targets.json", aws.ToString(fs.inputs[0].Key))
+
+	_, err = rf.fetch(context.Background())
+	assert.Equal(t, errNotModified, err)
+
+	fs.content, fs.etag = []byte("v2"), `"2"`
+	b, err = rf.fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", string(b))
+
+	rf.path = "s3://bucket"
+	_, err = rf.fetch(context.Background())
+	assert.Error(t, err)
+}
+
+func TestListResourcesRemote(t *testing.T) {
+	content, err := os.ReadFile("testdata/targets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	etag := `"1"`
+	var requests []*http.Request
+	ts := testFileServer(t, &content, &etag, &requests)
+
+	p, err := New(&configpb.ProviderConfig{
+		FilePath: []string{ts.URL + "/targets.json"},
+		Header:   map[string]string{"X-Api-Key": "secret"},
+	}, &logger.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := p.ListResources(&rdspb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	compareResourceList(t, resp.GetResources(), testExpectedResources)
+	lastModified := resp.GetLastModified()
+
+	// File didn't change, last-modified shouldn't change either.
+	ls := p.listers[ts.URL+"/targets.json"]
+	ls.lastUpdated = ls.lastUpdated.Add(-time.Second)
+	assert.NoError(t, ls.refresh())
+	assert.Equal(t, lastModified-1, ls.lastModified())
+
+	resp, err = p.ListResources(&rdspb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastModified)})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 0)
+}
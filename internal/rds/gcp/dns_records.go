@@ -0,0 +1,362 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements support for discovering Cloud DNS record sets.
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/gcp/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/protobuf/proto"
+)
+
+var cloudDNSAPIBase = "https://dns.googleapis.com/dns/v1/"
+
+var defaultDNSRecordTypes = []string{"A", "AAAA", "CNAME"}
+
+/*
+DNSRecordsFilters defines filters supported by the dns_records resource type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: ".*\\.example\\.com"
+	 }
+	 filter {
+		 key: "zone"
+		 value: "example.com"
+	 }
+	 filter {
+		 key: "type"
+		 value: "A|AAAA"
+	 }
+*/
+var DNSRecordsFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the type filter matches if any of the record name's types matches.
+	[]string{"name", "zone", "type"},
+	true,
+}
+
+// managedZone and rrset represent Cloud DNS managed zones and record sets, as
+// returned by the Cloud DNS API.
+type managedZone struct {
+	Name       string
+	DNSName    string
+	Visibility string
+}
+
+type rrset struct {
+	Name    string
+	Type    string
+	Rrdatas []string
+}
+
+// dnsRecordsLister lists Cloud DNS record sets. It implements a cache, that's
+// populated at a regular interval by making the API calls. Listing actually
+// only returns the current contents of that cache.
+type dnsRecordsLister struct {
+	project    string
+	c          *configpb.DNSRecords
+	types      map[string]bool
+	httpClient *http.Client
+	getURLFunc func(client *http.Client, url string) ([]byte, error)
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*pb.Resource
+	lastUpdated int64
+}
+
+func (dl *dnsRecordsLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), DNSRecordsFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, zoneFilter, typeFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["zone"], allFilters.RegexFilters["type"], allFilters.LabelsFilter
+
+	dl.mu.RLock()
+	defer dl.mu.RUnlock()
+
+	for _, res := range dl.cache {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), dl.l) {
+			continue
+		}
+		if zoneFilter != nil && !zoneFilter.Match(res.GetLabels()["zone"], dl.l) {
+			continue
+		}
+		if typeFilter != nil {
+			matched := false
+			for _, t := range strings.Split(res.GetLabels()["type"], ",") {
+				if typeFilter.Match(t, dl.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), dl.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	dl.l.Infof("dns_records.listResources: returning %d resources", len(resources))
+	return resources, nil
+}
+
+// listAll fetches all the pages of a Cloud DNS list call and adds the items
+// under the given key to items.
+func (dl *dnsRecordsLister) listAll(listURL, itemsKey string, items interface{ add(json.RawMessage) error }) error {
+	var pageToken string
+	for {
+		url := listURL
+		if pageToken != "" {
+			url += "?pageToken=" + neturl.QueryEscape(pageToken)
+		}
+
+		respBytes, err := dl.getURLFunc(dl.httpClient, url)
+		if err != nil {
+			return err
+		}
+
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(respBytes, &resp); err != nil {
+			return fmt.Errorf("error parsing response: %v", err)
+		}
+		if resp[itemsKey] != nil {
+			if err := items.add(resp[itemsKey]); err != nil {
+				return fmt.Errorf("error parsing %s: %v", itemsKey, err)
+			}
+		}
+
+		pageToken = ""
+		if resp["nextPageToken"] != nil {
+			json.Unmarshal(resp["nextPageToken"], &pageToken)
+		}
+		if pageToken == "" {
+			return nil
+		}
+	}
+}
+
+type managedZones []*managedZone
+
+func (mz *managedZones) add(b json.RawMessage) error {
+	var zones []*managedZone
+	if err := json.Unmarshal(b, &zones); err != nil {
+		return err
+	}
+	*mz = append(*mz, zones...)
+	return nil
+}
+
+type rrsets []*rrset
+
+func (rs *rrsets) add(b json.RawMessage) error {
+	var sets []*rrset
+	if err := json.Unmarshal(b, &sets); err != nil {
+		return err
+	}
+	*rs = append(*rs, sets...)
+	return nil
+}
+
+// zoneSelected returns true if the zone is one of the configured managed
+// zones, or if no managed zones are configured.
+func (dl *dnsRecordsLister) zoneSelected(zone *managedZone) bool {
+	if zone.Visibility == "private" && !dl.c.GetIncludePrivateZones() {
+		return false
+	}
+	if len(dl.c.GetManagedZone()) == 0 {
+		return true
+	}
+	for _, mz := range dl.c.GetManagedZone() {
+		if mz == zone.Name || strings.TrimSuffix(mz, ".") == strings.TrimSuffix(zone.DNSName, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneResources returns a resource for each record name in the given record
+// sets. Record sets with the same name (different types) are merged into one
+// resource.
+func (dl *dnsRecordsLister) zoneResources(zone *managedZone, sets []*rrset) []*pb.Resource {
+	resMap := make(map[string]*pb.Resource)
+	var names []string
+	ips := make(map[string]map[string]string)
+
+	for _, rs := range sets {
+		if !dl.types[rs.Type] {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSuffix(rs.Name, "."))
+		// Skip wildcard records, they can't be probed.
+		if strings.HasPrefix(name, "*") {
+			continue
+		}
+
+		res := resMap[name]
+		if res == nil {
+			res = &pb.Resource{
+				Name: proto.String(name),
+				Id:   proto.String(zone.Name + "/" + name),
+				Labels: map[string]string{
+					"zone":         strings.TrimSuffix(zone.DNSName, "."),
+					"managed_zone": zone.Name,
+				},
+			}
+			resMap[name], ips[name] = res, make(map[string]string)
+			names = append(names, name)
+		}
+
+		res.Labels["type"] = strings.TrimPrefix(res.Labels["type"]+","+rs.Type, ",")
+
+		if len(rs.Rrdatas) == 0 {
+			continue
+		}
+		if rs.Type == "CNAME" {
+			res.Labels["cname"] = strings.TrimSuffix(rs.Rrdatas[0], ".")
+		} else {
+			ips[name][rs.Type] = rs.Rrdatas[0]
+		}
+	}
+
+	resources := make([]*pb.Resource, 0, len(names))
+	for _, name := range names {
+		res := resMap[name]
+		// Prefer IPv4 address. Resources without an address (CNAME records) are
+		// resolved by name at probe time.
+		if ip := ips[name]["A"]; ip != "" {
+			res.Ip = proto.String(ip)
+		} else if ip := ips[name]["AAAA"]; ip != "" {
+			res.Ip = proto.String(ip)
+		}
+		resources = append(resources, res)
+	}
+	return resources
+}
+
+func (dl *dnsRecordsLister) fetch() ([]*pb.Resource, error) {
+	zonesURL := cloudDNSAPIBase + "projects/" + dl.project + "/managedZones"
+
+	var zones managedZones
+	if err := dl.listAll(zonesURL, "managedZones", &zones); err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for _, zone := range zones {
+		if !dl.zoneSelected(zone) {
+			continue
+		}
+		var sets rrsets
+		if err := dl.listAll(zonesURL+"/"+zone.Name+"/rrsets", "rrsets", &sets); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dl.zoneResources(zone, sets)...)
+	}
+	return resources, nil
+}
+
+func (dl *dnsRecordsLister) expand() {
+	resources, err := dl.fetch()
+	if err != nil {
+		// Keep using the existing resources.
+		dl.l.Errorf("dns_records.expand: error while listing records: %v", err)
+		return
+	}
+
+	dl.l.Infof("dns_records.expand: got %d resources", len(resources))
+
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	dl.cache = resources
+	dl.lastUpdated = time.Now().Unix()
+}
+
+// parseDNSRecordTypes returns the set of record types to discover.
+func parseDNSRecordTypes(rrTypes []string) (map[string]bool, error) {
+	if len(rrTypes) == 0 {
+		rrTypes = defaultDNSRecordTypes
+	}
+
+	typeSet := make(map[string]bool)
+	for _, t := range rrTypes {
+		t = strings.ToUpper(t)
+		if t != "A" && t != "AAAA" && t != "CNAME" {
+			return nil, fmt.Errorf("dns_records: unsupported record type: %s", t)
+		}
+		typeSet[t] = true
+	}
+	return typeSet, nil
+}
+
+func newDNSRecordsLister(project string, c *configpb.DNSRecords, l *logger.Logger) (*dnsRecordsLister, error) {
+	typeSet, err := parseDNSRecordTypes(c.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := google.DefaultClient(context.Background(), cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("error creating default HTTP OAuth client: %v", err)
+	}
+
+	dl := &dnsRecordsLister{
+		project:    project,
+		c:          c,
+		types:      typeSet,
+		httpClient: client,
+		getURLFunc: getURLWithClient,
+		l:          l,
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	go func() {
+		dl.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the API
+		// at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			dl.expand()
+		}
+	}()
+	return dl, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/gcp/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var testDNSResponses = map[string]string{
+	"managedZones": `{
+		"managedZones": [
+			{"name": "example-com", "dnsName": "example.com.", "visibility": "public"}
+		],
+		"nextPageToken": "page2"
+	}`,
+	"managedZones?pageToken=page2": `{
+		"managedZones": [
+			{"name": "internal", "dnsName": "internal.example.com.", "visibility": "private"},
+			{"name": "example-org", "dnsName": "example.org.", "visibility": "public"}
+		]
+	}`,
+	"managedZones/example-com/rrsets": `{
+		"rrsets": [
+			{"name": "example.com.", "type": "NS", "rrdatas": ["ns-cloud-a1.googledomains.com."]},
+			{"name": "www.example.com.", "type": "A", "rrdatas": ["192.0.2.10", "192.0.2.11"]}
+		],
+		"nextPageToken": "page2"
+	}`,
+	"managedZones/example-com/rrsets?pageToken=page2": `{
+		"rrsets": [
+			{"name": "www.example.com.", "type": "AAAA", "rrdatas": ["2001:db8::10"]},
+			{"name": "v6.example.com.", "type": "AAAA", "rrdatas": ["2001:db8::20"]},
+			{"name": "api.example.com.", "type": "CNAME", "rrdatas": ["www.example.com."]},
+			{"name": "*.example.com.", "type": "A", "rrdatas": ["192.0.2.99"]}
+		]
+	}`,
+	"managedZones/internal/rrsets": `{
+		"rrsets": [
+			{"name": "db.internal.example.com.", "type": "A", "rrdatas": ["10.0.0.5"]}
+		]
+	}`,
+	"managedZones/example-org/rrsets": `{
+		"rrsets": [
+			{"name": "www.example.org.", "type": "A", "rrdatas": ["198.51.100.1"]}
+		]
+	}`,
+}
+
+func testDNSRecordsLister(t *testing.T, c *configpb.DNSRecords) *dnsRecordsLister {
+	t.Helper()
+
+	typeSet, err := parseDNSRecordTypes(c.GetType())
+	assert.NoError(t, err)
+
+	return &dnsRecordsLister{
+		project: "p1",
+		c:       c,
+		types:   typeSet,
+		getURLFunc: func(_ *http.Client, url string) ([]byte, error) {
+			resp, ok := testDNSResponses[strings.TrimPrefix(url, cloudDNSAPIBase+"projects/p1/")]
+			if !ok {
+				return nil, fmt.Errorf("unexpected URL: %s", url)
+			}
+			return []byte(resp), nil
+		},
+		l: &logger.Logger{},
+	}
+}
+
+func TestDNSRecords(t *testing.T) {
+	tests := []struct {
+		desc    string
+		conf    *configpb.DNSRecords
+		filters map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "all_public_zones",
+			conf: &configpb.DNSRecords{},
+			want: map[string]string{
+				"www.example.com": "192.0.2.10",
+				"v6.example.com":  "2001:db8::20",
+				"api.example.com": "",
+				"www.example.org": "198.51.100.1",
+			},
+		},
+		{
+			desc: "zones_by_name_and_dns_name_with_private",
+			conf: &configpb.DNSRecords{ManagedZone: []string{"example.org.", "internal"}, IncludePrivateZones: proto.Bool(true)},
+			want: map[string]string{
+				"db.internal.example.com": "10.0.0.5",
+				"www.example.org":         "198.51.100.1",
+			},
+		},
+		{
+			desc: "types_config",
+			conf: &configpb.DNSRecords{ManagedZone: []string{"example-com"}, Type: []string{"cname"}},
+			want: map[string]string{"api.example.com": ""},
+		},
+		{
+			desc:    "type_and_name_filter",
+			conf:    &configpb.DNSRecords{},
+			filters: map[string]string{"type": "AAAA", "name": "www.*"},
+			want:    map[string]string{"www.example.com": "192.0.2.10"},
+		},
+		{
+			desc:    "labels_filter",
+			conf:    &configpb.DNSRecords{},
+			filters: map[string]string{"labels.managed_zone": "example-org"},
+			want:    map[string]string{"www.example.org": "198.51.100.1"},
+		},
+		{
+			desc:    "unsupported_filter",
+			conf:    &configpb.DNSRecords{},
+			filters: map[string]string{"region": "us-east1"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dl := testDNSRecordsLister(t, test.conf)
+			dl.expand()
+
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resources, err := dl.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			got := make(map[string]string)
+			for _, res := range resources {
+				got[res.GetName()] = res.GetIp()
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestDNSRecordLabels(t *testing.T) {
+	dl := testDNSRecordsLister(t, &configpb.DNSRecords{})
+	dl.expand()
+
+	labels := make(map[string]map[string]string)
+	for _, res := range dl.cache {
+		labels[res.GetName()] = res.GetLabels()
+	}
+
+	assert.Equal(t, map[string]string{"zone": "example.com", "managed_zone": "example-com", "type": "A,AAAA"}, labels["www.example.com"])
+	assert.Equal(t, map[string]string{"zone": "example.com", "managed_zone": "example-com", "type": "CNAME", "cname": "www.example.com"}, labels["api.example.com"])
+}
+
+func TestDNSRecordsExpandError(t *testing.T) {
+	dl := testDNSRecordsLister(t, &configpb.DNSRecords{})
+	dl.expand()
+	assert.Len(t, dl.cache, 4)
+
+	// On error, existing resources are kept.
+	dl.getURLFunc = func(_ *http.Client, url string) ([]byte, error) {
+		return nil, assert.AnError
+	}
+	dl.expand()
+	assert.Len(t, dl.cache, 4)
+}
@@ -23,6 +23,7 @@ import (
 	"net"
 	"net/http"
 	neturl "net/url"
+	"path"
 	"sync"
 	"time"
 
@@ -58,10 +59,31 @@ type networkInterface struct {
 // instanceInfo represents instance items that we fetch from the API.
 type instanceInfo struct {
 	Name              string
+	Zone              string
+	MachineType       string `json:"machineType"`
 	Labels            map[string]string
 	NetworkInterfaces []networkInterface
 }
 
+// resourceLabels returns instance labels, along with the instance's zone and
+// machine type as "zone" and "machine_type" labels, unless instance already
+// has labels with those keys.
+func (ii *instanceInfo) resourceLabels() map[string]string {
+	labels := make(map[string]string, len(ii.Labels)+2)
+	for k, v := range map[string]string{
+		"zone":         path.Base(ii.Zone),
+		"machine_type": path.Base(ii.MachineType),
+	} {
+		if v != "" && v != "." {
+			labels[k] = v
+		}
+	}
+	for k, v := range ii.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
 // instanceData represents objects that we store in cache.
 type instanceData struct {
 	ii          *instanceInfo
@@ -221,7 +243,7 @@ func (il *gceInstancesLister) listResources(req *pb.ListResourcesRequest) ([]*pb
 			resources = append(resources, &pb.Resource{
 				Name:        proto.String(name),
 				Ip:          proto.String(ip),
-				Labels:      ins.Labels,
+				Labels:      ins.resourceLabels(),
 				LastUpdated: proto.Int64(cache[name].lastUpdated),
 				// TODO(manugarg): Add support for returning instance id as well. I want to
 				// implement feature parity with the current targets first and then add
@@ -500,3 +500,18 @@ func TestExpandWithDeleteZone(t *testing.T) {
 	assert.Equal(t, 1, len(il.namesPerScope), "cache keys")
 	assert.Equal(t, 1, len(il.cachePerScope), "cache keys")
 }
+
+func TestInstanceResourceLabels(t *testing.T) {
+	ii := &instanceInfo{
+		Zone:        "https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a",
+		MachineType: "https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a/machineTypes/e2-medium",
+		Labels:      map[string]string{"team": "web"},
+	}
+	assert.Equal(t, map[string]string{"team": "web", "zone": "us-central1-a", "machine_type": "e2-medium"}, ii.resourceLabels())
+
+	// Instance labels take precedence.
+	ii.Labels["zone"] = "custom"
+	assert.Equal(t, "custom", ii.resourceLabels()["zone"])
+
+	assert.Equal(t, map[string]string{}, (&instanceInfo{}).resourceLabels())
+}
@@ -51,11 +51,15 @@ const DefaultProviderID = "gcp"
 // removed.
 var ResourceTypes = struct {
 	GCEInstances, ForwardingRules, RTCVariables, PubsubMessages string
+	CloudRunServices, CloudFunctions, DNSRecords                string
 }{
 	"gce_instances",
 	"forwarding_rules",
 	"rtc_variables",
 	"pubsub_messages",
+	"cloud_run_services",
+	"cloud_functions",
+	"dns_records",
 }
 
 type lister interface {
@@ -135,6 +139,33 @@ func initGCPProject(project string, c *configpb.ProviderConfig, l *logger.Logger
 		projectLister[ResourceTypes.PubsubMessages] = lr
 	}
 
+	// Enable Cloud Run services lister if configured.
+	if c.GetCloudRunServices() != nil {
+		lr, err := newCloudRunServicesLister(project, c.GetCloudRunServices().GetReEvalSec(), l)
+		if err != nil {
+			return nil, err
+		}
+		projectLister[ResourceTypes.CloudRunServices] = lr
+	}
+
+	// Enable Cloud Functions lister if configured.
+	if c.GetCloudFunctions() != nil {
+		lr, err := newCloudFunctionsLister(project, c.GetCloudFunctions().GetReEvalSec(), l)
+		if err != nil {
+			return nil, err
+		}
+		projectLister[ResourceTypes.CloudFunctions] = lr
+	}
+
+	// Enable Cloud DNS records lister if configured.
+	if c.GetDnsRecords() != nil {
+		lr, err := newDNSRecordsLister(project, c.GetDnsRecords(), l)
+		if err != nil {
+			return nil, err
+		}
+		projectLister[ResourceTypes.DNSRecords] = lr
+	}
+
 	// Enable RTC variables lister if configured.
 	if c.GetRtcVariables() != nil {
 		lr, err := newRTCVariablesLister(project, c.GetApiVersion(), c.GetRtcVariables(), l)
@@ -199,6 +230,21 @@ func DefaultProviderConfig(projects []string, resTypes map[string]string, reEval
 				ReEvalSec: proto.Int32(int32(reEvalSec)),
 			}
 
+		case ResourceTypes.CloudRunServices:
+			c.CloudRunServices = &configpb.CloudRunServices{
+				ReEvalSec: proto.Int32(int32(reEvalSec)),
+			}
+
+		case ResourceTypes.CloudFunctions:
+			c.CloudFunctions = &configpb.CloudFunctions{
+				ReEvalSec: proto.Int32(int32(reEvalSec)),
+			}
+
+		case ResourceTypes.DNSRecords:
+			c.DnsRecords = &configpb.DNSRecords{
+				ReEvalSec: proto.Int32(int32(reEvalSec)),
+			}
+
 		case ResourceTypes.RTCVariables:
 			c.RtcVariables = &configpb.RTCVariables{
 				RtcConfig: []*configpb.RTCVariables_RTCConfig{
@@ -276,6 +276,202 @@ func (x *PubSubMessages) GetApiEndpoint() string {
 	return ""
 }
 
+// Cloud Run services. Services are discovered across all regions, use the
+// "region" filter to limit them to specific regions.
+type CloudRunServices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often resources should be refreshed.
+	ReEvalSec *int32 `protobuf:"varint,98,opt,name=re_eval_sec,json=reEvalSec,def=300" json:"re_eval_sec,omitempty"` // default 5 min
+}
+
+// Default values for CloudRunServices fields.
+const (
+	Default_CloudRunServices_ReEvalSec = int32(300)
+)
+
+func (x *CloudRunServices) Reset() {
+	*x = CloudRunServices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudRunServices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudRunServices) ProtoMessage() {}
+
+func (x *CloudRunServices) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudRunServices.ProtoReflect.Descriptor instead.
+func (*CloudRunServices) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CloudRunServices) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_CloudRunServices_ReEvalSec
+}
+
+// HTTP-triggered Cloud Functions (both 1st and 2nd gen). Functions are
+// discovered across all regions.
+type CloudFunctions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often resources should be refreshed.
+	ReEvalSec *int32 `protobuf:"varint,98,opt,name=re_eval_sec,json=reEvalSec,def=300" json:"re_eval_sec,omitempty"` // default 5 min
+}
+
+// Default values for CloudFunctions fields.
+const (
+	Default_CloudFunctions_ReEvalSec = int32(300)
+)
+
+func (x *CloudFunctions) Reset() {
+	*x = CloudFunctions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudFunctions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudFunctions) ProtoMessage() {}
+
+func (x *CloudFunctions) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudFunctions.ProtoReflect.Descriptor instead.
+func (*CloudFunctions) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CloudFunctions) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_CloudFunctions_ReEvalSec
+}
+
+// Cloud DNS record sets. Each record name (A, AAAA or CNAME by default)
+// becomes a resource, so that all the hostnames in the managed zones are
+// probed automatically as records are created.
+type DNSRecords struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Managed zones (zone names, e.g. "prod-zone", or DNS names, e.g.
+	// "example.com") to discover records in. Default is to discover records in
+	// all the managed zones of the project.
+	ManagedZone []string `protobuf:"bytes,1,rep,name=managed_zone,json=managedZone" json:"managed_zone,omitempty"`
+	// Record types to discover. Default is A, AAAA and CNAME.
+	Type []string `protobuf:"bytes,2,rep,name=type" json:"type,omitempty"`
+	// Whether to include private managed zones.
+	IncludePrivateZones *bool `protobuf:"varint,3,opt,name=include_private_zones,json=includePrivateZones,def=0" json:"include_private_zones,omitempty"`
+	// How often resources should be refreshed.
+	ReEvalSec *int32 `protobuf:"varint,98,opt,name=re_eval_sec,json=reEvalSec,def=300" json:"re_eval_sec,omitempty"` // default 5 min
+}
+
+// Default values for DNSRecords fields.
+const (
+	Default_DNSRecords_IncludePrivateZones = bool(false)
+	Default_DNSRecords_ReEvalSec           = int32(300)
+)
+
+func (x *DNSRecords) Reset() {
+	*x = DNSRecords{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSRecords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRecords) ProtoMessage() {}
+
+func (x *DNSRecords) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRecords.ProtoReflect.Descriptor instead.
+func (*DNSRecords) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DNSRecords) GetManagedZone() []string {
+	if x != nil {
+		return x.ManagedZone
+	}
+	return nil
+}
+
+func (x *DNSRecords) GetType() []string {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *DNSRecords) GetIncludePrivateZones() bool {
+	if x != nil && x.IncludePrivateZones != nil {
+		return *x.IncludePrivateZones
+	}
+	return Default_DNSRecords_IncludePrivateZones
+}
+
+func (x *DNSRecords) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_DNSRecords_ReEvalSec
+}
+
 // GCP provider config.
 type ProviderConfig struct {
 	state         protoimpl.MessageState
@@ -295,6 +491,12 @@ type ProviderConfig struct {
 	RtcVariables *RTCVariables `protobuf:"bytes,4,opt,name=rtc_variables,json=rtcVariables" json:"rtc_variables,omitempty"`
 	// PubSub messages discovery options.
 	PubsubMessages *PubSubMessages `protobuf:"bytes,5,opt,name=pubsub_messages,json=pubsubMessages" json:"pubsub_messages,omitempty"`
+	// Cloud Run services discovery options.
+	CloudRunServices *CloudRunServices `protobuf:"bytes,6,opt,name=cloud_run_services,json=cloudRunServices" json:"cloud_run_services,omitempty"`
+	// Cloud Functions discovery options.
+	CloudFunctions *CloudFunctions `protobuf:"bytes,7,opt,name=cloud_functions,json=cloudFunctions" json:"cloud_functions,omitempty"`
+	// Cloud DNS records discovery options.
+	DnsRecords *DNSRecords `protobuf:"bytes,8,opt,name=dns_records,json=dnsRecords" json:"dns_records,omitempty"`
 	// Compute API version.
 	ApiVersion *string `protobuf:"bytes,99,opt,name=api_version,json=apiVersion,def=v1" json:"api_version,omitempty"`
 	// Compute API endpoint. Currently supported only for GCE instances and
@@ -311,7 +513,7 @@ const (
 func (x *ProviderConfig) Reset() {
 	*x = ProviderConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[4]
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -324,7 +526,7 @@ func (x *ProviderConfig) String() string {
 func (*ProviderConfig) ProtoMessage() {}
 
 func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[4]
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +539,7 @@ func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
 func (*ProviderConfig) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescGZIP(), []int{4}
+	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ProviderConfig) GetProject() []string {
@@ -375,6 +577,27 @@ func (x *ProviderConfig) GetPubsubMessages() *PubSubMessages {
 	return nil
 }
 
+func (x *ProviderConfig) GetCloudRunServices() *CloudRunServices {
+	if x != nil {
+		return x.CloudRunServices
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetCloudFunctions() *CloudFunctions {
+	if x != nil {
+		return x.CloudFunctions
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetDnsRecords() *DNSRecords {
+	if x != nil {
+		return x.DnsRecords
+	}
+	return nil
+}
+
 func (x *ProviderConfig) GetApiVersion() string {
 	if x != nil && x.ApiVersion != nil {
 		return *x.ApiVersion
@@ -407,7 +630,7 @@ const (
 func (x *RTCVariables_RTCConfig) Reset() {
 	*x = RTCVariables_RTCConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[5]
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -420,7 +643,7 @@ func (x *RTCVariables_RTCConfig) String() string {
 func (*RTCVariables_RTCConfig) ProtoMessage() {}
 
 func (x *RTCVariables_RTCConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[5]
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +696,7 @@ const (
 func (x *PubSubMessages_Subscription) Reset() {
 	*x = PubSubMessages_Subscription{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[6]
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -486,7 +709,7 @@ func (x *PubSubMessages_Subscription) String() string {
 func (*PubSubMessages_Subscription) ProtoMessage() {}
 
 func (x *PubSubMessages_Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[6]
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,40 +792,71 @@ var file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_
 	0x65, 0x12, 0x39, 0x0a, 0x16, 0x73, 0x65, 0x65, 0x6b, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x64,
 	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x05, 0x3a, 0x04, 0x33, 0x36, 0x30, 0x30, 0x52, 0x13, 0x73, 0x65, 0x65, 0x6b, 0x42, 0x61, 0x63,
-	0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x22, 0xc6, 0x03, 0x0a,
-	0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x46, 0x0a, 0x0d, 0x67, 0x63, 0x65,
-	0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72,
-	0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x47, 0x43, 0x45, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x52, 0x0c, 0x67, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f,
-	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63,
-	0x70, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6c, 0x65,
-	0x73, 0x52, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6c,
-	0x65, 0x73, 0x12, 0x46, 0x0a, 0x0d, 0x72, 0x74, 0x63, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x22, 0x37, 0x0a, 0x10,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18,
+	0x62, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x22, 0x35, 0x0a, 0x0e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x46, 0x75,
+	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76,
+	0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x62, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30,
+	0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x22, 0xa3, 0x01, 0x0a,
+	0x0a, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x39, 0x0a, 0x15, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x62, 0x20, 0x01,
+	0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53,
+	0x65, 0x63, 0x22, 0xab, 0x05, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12,
+	0x46, 0x0a, 0x0d, 0x67, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x47, 0x43, 0x45,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x0c, 0x67, 0x63, 0x65, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0d, 0x72, 0x74, 0x63, 0x5f,
+	0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64,
+	0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x52, 0x54, 0x43, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x52, 0x0c, 0x72, 0x74, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x4c, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
 	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e,
-	0x52, 0x54, 0x43, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x0c, 0x72, 0x74,
-	0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x70, 0x75,
-	0x62, 0x73, 0x75, 0x62, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x0e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x63, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x02, 0x76,
-	0x31, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a,
-	0x0c, 0x61, 0x70, 0x69, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x64, 0x20,
-	0x01, 0x28, 0x09, 0x3a, 0x23, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x77, 0x77, 0x77,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x2f, 0x52, 0x0b, 0x61, 0x70, 0x69, 0x45, 0x6e, 0x64,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x67, 0x63, 0x70, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f,
+	0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x0e,
+	0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x53,
+	0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70,
+	0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67,
+	0x63, 0x70, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x40, 0x0a, 0x0b, 0x64, 0x6e, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x67, 0x63, 0x70, 0x2e, 0x44, 0x4e, 0x53,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x0a, 0x64, 0x6e, 0x73, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x63, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x02, 0x76, 0x31, 0x52, 0x0a, 0x61, 0x70,
+	0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0c, 0x61, 0x70, 0x69, 0x5f,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x23,
+	0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x77, 0x77, 0x77, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x2f, 0x52, 0x0b, 0x61, 0x70, 0x69, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x72, 0x64, 0x73, 0x2f, 0x67, 0x63, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -617,28 +871,34 @@ func file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto
 	return file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_goTypes = []interface{}{
 	(*GCEInstances)(nil),                // 0: cloudprober.rds.gcp.GCEInstances
 	(*ForwardingRules)(nil),             // 1: cloudprober.rds.gcp.ForwardingRules
 	(*RTCVariables)(nil),                // 2: cloudprober.rds.gcp.RTCVariables
 	(*PubSubMessages)(nil),              // 3: cloudprober.rds.gcp.PubSubMessages
-	(*ProviderConfig)(nil),              // 4: cloudprober.rds.gcp.ProviderConfig
-	(*RTCVariables_RTCConfig)(nil),      // 5: cloudprober.rds.gcp.RTCVariables.RTCConfig
-	(*PubSubMessages_Subscription)(nil), // 6: cloudprober.rds.gcp.PubSubMessages.Subscription
+	(*CloudRunServices)(nil),            // 4: cloudprober.rds.gcp.CloudRunServices
+	(*CloudFunctions)(nil),              // 5: cloudprober.rds.gcp.CloudFunctions
+	(*DNSRecords)(nil),                  // 6: cloudprober.rds.gcp.DNSRecords
+	(*ProviderConfig)(nil),              // 7: cloudprober.rds.gcp.ProviderConfig
+	(*RTCVariables_RTCConfig)(nil),      // 8: cloudprober.rds.gcp.RTCVariables.RTCConfig
+	(*PubSubMessages_Subscription)(nil), // 9: cloudprober.rds.gcp.PubSubMessages.Subscription
 }
 var file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_depIdxs = []int32{
-	5, // 0: cloudprober.rds.gcp.RTCVariables.rtc_config:type_name -> cloudprober.rds.gcp.RTCVariables.RTCConfig
-	6, // 1: cloudprober.rds.gcp.PubSubMessages.subscription:type_name -> cloudprober.rds.gcp.PubSubMessages.Subscription
+	8, // 0: cloudprober.rds.gcp.RTCVariables.rtc_config:type_name -> cloudprober.rds.gcp.RTCVariables.RTCConfig
+	9, // 1: cloudprober.rds.gcp.PubSubMessages.subscription:type_name -> cloudprober.rds.gcp.PubSubMessages.Subscription
 	0, // 2: cloudprober.rds.gcp.ProviderConfig.gce_instances:type_name -> cloudprober.rds.gcp.GCEInstances
 	1, // 3: cloudprober.rds.gcp.ProviderConfig.forwarding_rules:type_name -> cloudprober.rds.gcp.ForwardingRules
 	2, // 4: cloudprober.rds.gcp.ProviderConfig.rtc_variables:type_name -> cloudprober.rds.gcp.RTCVariables
 	3, // 5: cloudprober.rds.gcp.ProviderConfig.pubsub_messages:type_name -> cloudprober.rds.gcp.PubSubMessages
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4, // 6: cloudprober.rds.gcp.ProviderConfig.cloud_run_services:type_name -> cloudprober.rds.gcp.CloudRunServices
+	5, // 7: cloudprober.rds.gcp.ProviderConfig.cloud_functions:type_name -> cloudprober.rds.gcp.CloudFunctions
+	6, // 8: cloudprober.rds.gcp.ProviderConfig.dns_records:type_name -> cloudprober.rds.gcp.DNSRecords
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_init() }
@@ -696,7 +956,7 @@ func file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto
 			}
 		}
 		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProviderConfig); i {
+			switch v := v.(*CloudRunServices); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -708,7 +968,7 @@ func file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto
 			}
 		}
 		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RTCVariables_RTCConfig); i {
+			switch v := v.(*CloudFunctions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -720,6 +980,42 @@ func file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto
 			}
 		}
 		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSRecords); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RTCVariables_RTCConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PubSubMessages_Subscription); i {
 			case 0:
 				return &v.state
@@ -738,7 +1034,7 @@ func file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_gcp_proto_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
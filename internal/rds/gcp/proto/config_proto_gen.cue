@@ -52,6 +52,39 @@ package proto
 	apiEndpoint?: string @protobuf(2,string,name=api_endpoint)
 }
 
+// Cloud Run services. Services are discovered across all regions, use the
+// "region" filter to limit them to specific regions.
+#CloudRunServices: {
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+// HTTP-triggered Cloud Functions (both 1st and 2nd gen). Functions are
+// discovered across all regions.
+#CloudFunctions: {
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+// Cloud DNS record sets. Each record name (A, AAAA or CNAME by default)
+// becomes a resource, so that all the hostnames in the managed zones are
+// probed automatically as records are created.
+#DNSRecords: {
+	// Managed zones (zone names, e.g. "prod-zone", or DNS names, e.g.
+	// "example.com") to discover records in. Default is to discover records in
+	// all the managed zones of the project.
+	managedZone?: [...string] @protobuf(1,string,name=managed_zone)
+
+	// Record types to discover. Default is A, AAAA and CNAME.
+	type?: [...string] @protobuf(2,string)
+
+	// Whether to include private managed zones.
+	includePrivateZones?: bool @protobuf(3,bool,name=include_private_zones,"default=false")
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
 // GCP provider config.
 #ProviderConfig: {
 	// GCP projects. If running on GCE, it defaults to the local project.
@@ -72,6 +105,15 @@ package proto
 	// PubSub messages discovery options.
 	pubsubMessages?: #PubSubMessages @protobuf(5,PubSubMessages,name=pubsub_messages)
 
+	// Cloud Run services discovery options.
+	cloudRunServices?: #CloudRunServices @protobuf(6,CloudRunServices,name=cloud_run_services)
+
+	// Cloud Functions discovery options.
+	cloudFunctions?: #CloudFunctions @protobuf(7,CloudFunctions,name=cloud_functions)
+
+	// Cloud DNS records discovery options.
+	dnsRecords?: #DNSRecords @protobuf(8,DNSRecords,name=dns_records)
+
 	// Compute API version.
 	apiVersion?: string @protobuf(99,string,name=api_version,#"default="v1""#)
 
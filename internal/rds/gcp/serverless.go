@@ -0,0 +1,300 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements support for discovering serverless endpoints: Cloud
+// Run services and HTTP-triggered Cloud Functions. These resources don't have
+// an IP address, instead they carry their URL in labels that HTTP probe
+// understands (__cp_scheme__, __cp_host__ and __cp_path__).
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/protobuf/proto"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+var (
+	cloudRunAPIBase       = "https://run.googleapis.com/v2/"
+	cloudFunctionsAPIBase = "https://cloudfunctions.googleapis.com/v2/"
+)
+
+/*
+ServerlessFilters defines filters supported by the cloud_run_services and
+cloud_functions resource types.
+
+	 Example:
+	 filter {
+		 key: "region"
+		 value: "us-.*"
+	 }
+	 filter {
+		 key: "labels.team"
+		 value: "payments"
+	 }
+*/
+var ServerlessFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name", "region"},
+	true,
+}
+
+// serverlessInfo represents Cloud Run services and Cloud Functions, as
+// returned by their respective APIs.
+type serverlessInfo struct {
+	Name   string
+	Labels map[string]string
+
+	// Cloud Run service's URL.
+	URI string
+	// Cloud Function's URL.
+	URL           string
+	State         string
+	EventTrigger  *struct{}
+	ServiceConfig struct {
+		URI string
+	}
+}
+
+// nameAndRegion extracts short name and region from the resource name of the
+// format: projects/<project>/locations/<region>/<type>/<name>.
+func (si *serverlessInfo) nameAndRegion() (string, string) {
+	tok := strings.Split(si.Name, "/")
+	if len(tok) != 6 {
+		return si.Name, ""
+	}
+	return tok[5], tok[3]
+}
+
+func (si *serverlessInfo) url() string {
+	for _, u := range []string{si.URI, si.URL, si.ServiceConfig.URI} {
+		if u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+func (si *serverlessInfo) resource() (*pb.Resource, error) {
+	name, region := si.nameAndRegion()
+
+	u, err := neturl.Parse(si.url())
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL (%s) for %s: %v", si.url(), si.Name, err)
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	labels := make(map[string]string, len(si.Labels)+5)
+	for k, v := range si.Labels {
+		labels[k] = v
+	}
+	labels["region"] = region
+	labels["url"] = si.url()
+	labels["__cp_scheme__"] = u.Scheme
+	labels["__cp_host__"] = u.Host
+	labels["__cp_path__"] = path
+
+	return &pb.Resource{
+		Name:   proto.String(name),
+		Id:     proto.String(si.Name),
+		Labels: labels,
+	}, nil
+}
+
+// serverlessLister lists Cloud Run services or Cloud Functions. It implements
+// a cache, that's populated at a regular interval by making the API calls.
+// Listing actually only returns the current contents of that cache.
+type serverlessLister struct {
+	resType    string
+	listURL    string
+	itemsKey   string
+	include    func(*serverlessInfo) bool
+	httpClient *http.Client
+	getURLFunc func(client *http.Client, url string) ([]byte, error)
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*pb.Resource
+	lastUpdated int64
+}
+
+func (sl *serverlessLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), ServerlessFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, regionFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["region"], allFilters.LabelsFilter
+
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	for _, res := range sl.cache {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), sl.l) {
+			continue
+		}
+		if regionFilter != nil && !regionFilter.Match(res.GetLabels()["region"], sl.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), sl.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	sl.l.Infof("%s.listResources: returning %d resources", sl.resType, len(resources))
+	return resources, nil
+}
+
+func (sl *serverlessLister) fetch() ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	var pageToken string
+	for {
+		url := sl.listURL
+		if pageToken != "" {
+			url += "?pageToken=" + neturl.QueryEscape(pageToken)
+		}
+
+		respBytes, err := sl.getURLFunc(sl.httpClient, url)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(respBytes, &resp); err != nil {
+			return nil, fmt.Errorf("error parsing response: %v", err)
+		}
+
+		var items []*serverlessInfo
+		if resp[sl.itemsKey] != nil {
+			if err := json.Unmarshal(resp[sl.itemsKey], &items); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %v", sl.itemsKey, err)
+			}
+		}
+
+		for _, item := range items {
+			if item.url() == "" || !sl.include(item) {
+				continue
+			}
+			res, err := item.resource()
+			if err != nil {
+				sl.l.Warningf("%s.expand: %v", sl.resType, err)
+				continue
+			}
+			resources = append(resources, res)
+		}
+
+		pageToken = ""
+		if resp["nextPageToken"] != nil {
+			json.Unmarshal(resp["nextPageToken"], &pageToken)
+		}
+		if pageToken == "" {
+			return resources, nil
+		}
+	}
+}
+
+func (sl *serverlessLister) expand() {
+	resources, err := sl.fetch()
+	if err != nil {
+		// Keep using the existing resources.
+		sl.l.Errorf("%s.expand: error while listing resources: %v", sl.resType, err)
+		return
+	}
+
+	sl.l.Infof("%s.expand: got %d resources", sl.resType, len(resources))
+
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.cache = resources
+	sl.lastUpdated = time.Now().Unix()
+}
+
+func (sl *serverlessLister) start(reEvalSec int32) {
+	reEvalInterval := time.Duration(reEvalSec) * time.Second
+	go func() {
+		sl.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the API
+		// at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			sl.expand()
+		}
+	}()
+}
+
+func newServerlessLister(resType, listURL, itemsKey string, include func(*serverlessInfo) bool, l *logger.Logger) (*serverlessLister, error) {
+	client, err := google.DefaultClient(context.Background(), cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("error creating default HTTP OAuth client: %v", err)
+	}
+
+	return &serverlessLister{
+		resType:    resType,
+		listURL:    listURL,
+		itemsKey:   itemsKey,
+		include:    include,
+		httpClient: client,
+		getURLFunc: getURLWithClient,
+		l:          l,
+	}, nil
+}
+
+func newCloudRunServicesLister(project string, reEvalSec int32, l *logger.Logger) (*serverlessLister, error) {
+	sl, err := newServerlessLister(ResourceTypes.CloudRunServices, cloudRunAPIBase+"projects/"+project+"/locations/-/services", "services", func(*serverlessInfo) bool { return true }, l)
+	if err != nil {
+		return nil, err
+	}
+	sl.start(reEvalSec)
+	return sl, nil
+}
+
+// includeFunction returns true for active HTTP-triggered functions.
+func includeFunction(si *serverlessInfo) bool {
+	return si.EventTrigger == nil && (si.State == "" || si.State == "ACTIVE")
+}
+
+func newCloudFunctionsLister(project string, reEvalSec int32, l *logger.Logger) (*serverlessLister, error) {
+	sl, err := newServerlessLister(ResourceTypes.CloudFunctions, cloudFunctionsAPIBase+"projects/"+project+"/locations/-/functions", "functions", includeFunction, l)
+	if err != nil {
+		return nil, err
+	}
+	sl.start(reEvalSec)
+	return sl, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var testServerlessResponses = map[string]string{
+	"run/services": `{
+		"services": [
+			{
+				"name": "projects/p1/locations/us-central1/services/frontend",
+				"uri": "https://frontend-abc-uc.a.run.app",
+				"labels": {"team": "web"}
+			},
+			{
+				"name": "projects/p1/locations/europe-west1/services/not-ready"
+			}
+		],
+		"nextPageToken": "page2"
+	}`,
+	"run/services?pageToken=page2": `{
+		"services": [
+			{
+				"name": "projects/p1/locations/europe-west1/services/backend",
+				"uri": "https://backend-abc-ew.a.run.app",
+				"labels": {"team": "api"}
+			}
+		]
+	}`,
+	"functions/functions": `{
+		"functions": [
+			{
+				"name": "projects/p1/locations/us-central1/functions/hello",
+				"url": "https://us-central1-p1.cloudfunctions.net/hello",
+				"state": "ACTIVE",
+				"labels": {"team": "web"}
+			},
+			{
+				"name": "projects/p1/locations/us-east1/functions/hello-v2",
+				"state": "ACTIVE",
+				"serviceConfig": {"uri": "https://hello-v2-abc-ue.a.run.app"}
+			},
+			{
+				"name": "projects/p1/locations/us-east1/functions/on-upload",
+				"url": "https://on-upload-abc-ue.a.run.app",
+				"state": "ACTIVE",
+				"eventTrigger": {"eventType": "google.cloud.storage.object.v1.finalized"}
+			},
+			{
+				"name": "projects/p1/locations/us-east1/functions/broken",
+				"url": "https://broken-abc-ue.a.run.app",
+				"state": "FAILED"
+			}
+		]
+	}`,
+}
+
+func testServerlessLister(resType, listURL, itemsKey string, include func(*serverlessInfo) bool) *serverlessLister {
+	return &serverlessLister{
+		resType:  resType,
+		listURL:  listURL,
+		itemsKey: itemsKey,
+		include:  include,
+		getURLFunc: func(_ *http.Client, url string) ([]byte, error) {
+			resp, ok := testServerlessResponses[url]
+			if !ok {
+				return nil, fmt.Errorf("unexpected URL: %s", url)
+			}
+			return []byte(resp), nil
+		},
+		l: &logger.Logger{},
+	}
+}
+
+func TestCloudRunServices(t *testing.T) {
+	sl := testServerlessLister(ResourceTypes.CloudRunServices, "run/services", "services", func(*serverlessInfo) bool { return true })
+	sl.expand()
+
+	tests := []struct {
+		desc    string
+		filters map[string]string
+		want    []*pb.Resource
+		wantErr bool
+	}{
+		{
+			desc: "all",
+			want: []*pb.Resource{
+				{
+					Name: proto.String("frontend"),
+					Id:   proto.String("projects/p1/locations/us-central1/services/frontend"),
+					Labels: map[string]string{
+						"team":          "web",
+						"region":        "us-central1",
+						"url":           "https://frontend-abc-uc.a.run.app",
+						"__cp_scheme__": "https",
+						"__cp_host__":   "frontend-abc-uc.a.run.app",
+						"__cp_path__":   "/",
+					},
+				},
+				{
+					Name: proto.String("backend"),
+					Id:   proto.String("projects/p1/locations/europe-west1/services/backend"),
+					Labels: map[string]string{
+						"team":          "api",
+						"region":        "europe-west1",
+						"url":           "https://backend-abc-ew.a.run.app",
+						"__cp_scheme__": "https",
+						"__cp_host__":   "backend-abc-ew.a.run.app",
+						"__cp_path__":   "/",
+					},
+				},
+			},
+		},
+		{
+			desc:    "region_filter",
+			filters: map[string]string{"region": "europe-.*"},
+			want:    []*pb.Resource{sl.cache[1]},
+		},
+		{
+			desc:    "label_filter",
+			filters: map[string]string{"labels.team": "web", "name": "front.*"},
+			want:    []*pb.Resource{sl.cache[0]},
+		},
+		{
+			desc:    "bad_filter",
+			filters: map[string]string{"zone": "us-central1-a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			got, err := sl.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCloudFunctions(t *testing.T) {
+	sl := testServerlessLister(ResourceTypes.CloudFunctions, "functions/functions", "functions", includeFunction)
+	sl.expand()
+
+	got, err := sl.listResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+
+	var names []string
+	for _, res := range got {
+		names = append(names, res.GetName())
+	}
+	assert.Equal(t, []string{"hello", "hello-v2"}, names)
+
+	// 1st gen functions URLs carry the function name in the path.
+	assert.Equal(t, "us-central1-p1.cloudfunctions.net", got[0].GetLabels()["__cp_host__"])
+	assert.Equal(t, "/hello", got[0].GetLabels()["__cp_path__"])
+	assert.Equal(t, "us-east1", got[1].GetLabels()["region"])
+	assert.Equal(t, "https://hello-v2-abc-ue.a.run.app", got[1].GetLabels()["url"])
+}
+
+func TestServerlessExpandError(t *testing.T) {
+	sl := testServerlessLister(ResourceTypes.CloudRunServices, "run/services", "services", func(*serverlessInfo) bool { return true })
+	sl.expand()
+	assert.Len(t, sl.cache, 2)
+
+	// Errors while listing should keep the existing resources.
+	sl.listURL = "run/unknown"
+	sl.expand()
+	assert.Len(t, sl.cache, 2)
+}
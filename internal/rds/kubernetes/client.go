@@ -56,9 +56,14 @@ func (c *client) httpRequest(url string) (*http.Request, error) {
 		req.Header.Add("Authorization", c.bearer)
 	}
 
-	if len(c.cfg.GetLabelSelector()) != 0 {
+	if len(c.cfg.GetLabelSelector()) != 0 || len(c.cfg.GetFieldSelector()) != 0 {
 		values := req.URL.Query()
-		values.Add("labelSelector", strings.Join(c.cfg.GetLabelSelector(), ","))
+		if len(c.cfg.GetLabelSelector()) != 0 {
+			values.Add("labelSelector", strings.Join(c.cfg.GetLabelSelector(), ","))
+		}
+		if len(c.cfg.GetFieldSelector()) != 0 {
+			values.Add("fieldSelector", strings.Join(c.cfg.GetFieldSelector(), ","))
+		}
 		req.URL.RawQuery = values.Encode()
 	}
 
@@ -70,7 +75,22 @@ func (c *client) getURL(url string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return c.do(req)
+}
+
+// getUnfilteredURL is similar to getURL, but it doesn't apply the configured
+// label and field selectors. It's used to get the resources that are not
+// probed themselves, e.g. gateways for HTTP routes.
+func (c *client) getUnfilteredURL(url string) ([]byte, error) {
+	req, err := c.httpRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = ""
+	return c.do(req)
+}
 
+func (c *client) do(req *http.Request) ([]byte, error) {
 	c.l.Debugf("kubernetes.client: getting URL: %s", req.URL.String())
 	resp, err := c.httpC.Do(req)
 	if err != nil {
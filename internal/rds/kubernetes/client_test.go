@@ -123,6 +123,7 @@ func TestClientHTTPRequest(t *testing.T) {
 	tests := []struct {
 		name          string
 		labelselector []string
+		fieldselector []string
 		wantURL       string
 	}{
 		{
@@ -139,6 +140,12 @@ func TestClientHTTPRequest(t *testing.T) {
 			labelselector: []string{"app=cloudprober", "env!=dev"},
 			wantURL:       "https://test-api-host/api/v1/pods?labelSelector=app%3Dcloudprober%2Cenv%21%3Ddev",
 		},
+		{
+			name:          "with-label-and-field",
+			labelselector: []string{"env in (prod,qa)"},
+			fieldselector: []string{"metadata.name!=kubernetes"},
+			wantURL:       "https://test-api-host/api/v1/pods?fieldSelector=metadata.name%21%3Dkubernetes&labelSelector=env+in+%28prod%2Cqa%29",
+		},
 	}
 
 	testAPIHost := "test-api-host"
@@ -148,6 +155,7 @@ func TestClientHTTPRequest(t *testing.T) {
 				apiHost: testAPIHost,
 				cfg: &cpb.ProviderConfig{
 					LabelSelector: test.labelselector,
+					FieldSelector: test.fieldselector,
 				},
 			}
 			req, err := tc.httpRequest("api/v1/pods")
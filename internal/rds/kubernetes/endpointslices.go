@@ -0,0 +1,316 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// serviceNameLabel is the label that EndpointSlice controller sets on the
+// EndpointSlices to point to the service they belong to.
+const serviceNameLabel = "kubernetes.io/service-name"
+
+type esLister struct {
+	c         *configpb.EndpointSlices
+	namespace string
+	kClient   *client
+
+	mu    sync.RWMutex // Mutex for names and cache
+	keys  []resourceKey
+	cache map[resourceKey][]*esInfo
+	l     *logger.Logger
+}
+
+func esURL(ns string) string {
+	if ns == "" {
+		return "apis/discovery.k8s.io/v1/endpointslices"
+	}
+	return fmt.Sprintf("apis/discovery.k8s.io/v1/namespaces/%s/endpointslices", ns)
+}
+
+func (lister *esLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	var svcName string
+	tok := strings.SplitN(req.GetResourcePath(), "/", 2)
+	if len(tok) == 2 {
+		svcName = tok[1]
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, nsFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["namespace"], allFilters.LabelsFilter
+
+	lister.mu.RLock()
+	defer lister.mu.RUnlock()
+
+	for _, key := range lister.keys {
+		if svcName != "" && key.name != svcName {
+			continue
+		}
+		if nameFilter != nil && !nameFilter.Match(key.name, lister.l) {
+			continue
+		}
+		if nsFilter != nil && !nsFilter.Match(key.namespace, lister.l) {
+			continue
+		}
+
+		slices := lister.cache[key]
+		if labelsFilter != nil && !labelsFilter.Match(slices[0].Metadata.Labels, lister.l) {
+			continue
+		}
+
+		resources = append(resources, esResources(key.name, slices, lister.c.GetIncludeNotReady(), allFilters.RegexFilters["port"], lister.l)...)
+	}
+
+	lister.l.Debugf("kubernetes.endpointslices.listResources: returning %d resources", len(resources))
+	return resources, nil
+}
+
+type esEndpoint struct {
+	Addresses  []string
+	Conditions struct {
+		// Nil ready condition should be interpreted as ready, as per the
+		// EndpointSlice API.
+		Ready       *bool
+		Serving     *bool
+		Terminating *bool
+	}
+	NodeName  string
+	Zone      string
+	TargetRef struct {
+		Kind string
+		Name string
+	}
+	Hints struct {
+		ForZones []struct {
+			Name string
+		}
+	}
+}
+
+func (ep *esEndpoint) ready() bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
+}
+
+func (ep *esEndpoint) serving() bool {
+	if ep.Conditions.Serving == nil {
+		return ep.ready()
+	}
+	return *ep.Conditions.Serving
+}
+
+func (ep *esEndpoint) terminating() bool {
+	return ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+}
+
+type esInfo struct {
+	Metadata    kMetadata
+	AddressType string
+	Endpoints   []esEndpoint
+	Ports       []struct {
+		Name *string
+		Port *int
+	}
+}
+
+// selectEndpoints returns the endpoints that kube-proxy would route to: ready
+// endpoints, or if there are none, serving endpoints that are terminating.
+func selectEndpoints(slices []*esInfo, includeNotReady bool) map[*esEndpoint]bool {
+	selected := make(map[*esEndpoint]bool)
+	var terminating []*esEndpoint
+	for _, es := range slices {
+		for i := range es.Endpoints {
+			ep := &es.Endpoints[i]
+			if includeNotReady || ep.ready() {
+				selected[ep] = true
+				continue
+			}
+			if ep.serving() && ep.terminating() {
+				terminating = append(terminating, ep)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		for _, ep := range terminating {
+			selected[ep] = true
+		}
+	}
+	return selected
+}
+
+// esResources returns RDS resources corresponding to the EndpointSlices of a
+// service. Similar to endpoints, each address and port combination becomes a
+// resource, named <service_name>_<IP>_<port>.
+func esResources(svcName string, slices []*esInfo, includeNotReady bool, portFilter *filter.RegexFilter, l *logger.Logger) (resources []*pb.Resource) {
+	selected := selectEndpoints(slices, includeNotReady)
+
+	for _, es := range slices {
+		for _, port := range es.Ports {
+			// Nil port means all ports, we can't probe that.
+			if port.Port == nil {
+				continue
+			}
+
+			// For unnamed ports, use port number.
+			portName := ""
+			if port.Name != nil {
+				portName = *port.Name
+			}
+			if portName == "" {
+				portName = strconv.Itoa(*port.Port)
+			}
+
+			if portFilter != nil && !portFilter.Match(portName, l) {
+				continue
+			}
+
+			for i := range es.Endpoints {
+				ep := &es.Endpoints[i]
+				if !selected[ep] {
+					continue
+				}
+
+				for _, addr := range ep.Addresses {
+					labels := make(map[string]string)
+					for k, v := range es.Metadata.Labels {
+						labels[k] = v
+					}
+					labels["ready"] = strconv.FormatBool(ep.ready())
+					labels["serving"] = strconv.FormatBool(ep.serving())
+					labels["terminating"] = strconv.FormatBool(ep.terminating())
+					if ep.NodeName != "" {
+						labels["node"] = ep.NodeName
+					}
+					if ep.Zone != "" {
+						labels["zone"] = ep.Zone
+					}
+					if len(ep.Hints.ForZones) != 0 {
+						var zones []string
+						for _, z := range ep.Hints.ForZones {
+							zones = append(zones, z.Name)
+						}
+						labels["zone_hints"] = strings.Join(zones, ",")
+					}
+					if ep.TargetRef.Kind == "Pod" {
+						labels["pod"] = ep.TargetRef.Name
+					}
+
+					resources = append(resources, &pb.Resource{
+						Name:   proto.String(fmt.Sprintf("%s_%s_%s", svcName, addr, portName)),
+						Ip:     proto.String(addr),
+						Port:   proto.Int32(int32(*port.Port)),
+						Labels: labels,
+					})
+				}
+			}
+		}
+	}
+	return
+}
+
+// parseEndpointSlicesJSON parses the EndpointSlices list and groups the
+// EndpointSlices by the service they belong to. EndpointSlices not belonging
+// to a service and FQDN EndpointSlices are skipped.
+func parseEndpointSlicesJSON(resp []byte) (keys []resourceKey, slices map[resourceKey][]*esInfo, err error) {
+	var itemList struct {
+		Items []*esInfo
+	}
+
+	if err = json.Unmarshal(resp, &itemList); err != nil {
+		return
+	}
+
+	slices = make(map[resourceKey][]*esInfo)
+	for _, item := range itemList.Items {
+		svcName := item.Metadata.Labels[serviceNameLabel]
+		if svcName == "" || item.AddressType == "FQDN" {
+			continue
+		}
+		key := resourceKey{item.Metadata.Namespace, svcName}
+		if slices[key] == nil {
+			keys = append(keys, key)
+		}
+		slices[key] = append(slices[key], item)
+	}
+
+	// Keep the resources order stable, irrespective of the slices order.
+	for _, key := range keys {
+		sort.Slice(slices[key], func(i, j int) bool {
+			return slices[key][i].Metadata.Name < slices[key][j].Metadata.Name
+		})
+	}
+
+	return
+}
+
+func (lister *esLister) expand() {
+	resp, err := lister.kClient.getURL(esURL(lister.namespace))
+	if err != nil {
+		lister.l.Warningf("esLister.expand(): error while getting endpointslices list from API: %v", err)
+	}
+
+	keys, slices, err := parseEndpointSlicesJSON(resp)
+	if err != nil {
+		lister.l.Warningf("esLister.expand(): error while parsing endpointslices API response (%s): %v", string(resp), err)
+	}
+
+	lister.l.Debugf("esLister.expand(): got endpointslices for %d services", len(keys))
+
+	lister.mu.Lock()
+	defer lister.mu.Unlock()
+	lister.keys = keys
+	lister.cache = slices
+}
+
+func newEndpointSlicesLister(c *configpb.EndpointSlices, namespace string, reEvalInterval time.Duration, kc *client, l *logger.Logger) (*esLister, error) {
+	lister := &esLister{
+		c:         c,
+		namespace: namespace,
+		kClient:   kc,
+		l:         l,
+	}
+
+	go func() {
+		lister.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the
+		// API server at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			lister.expand()
+		}
+	}()
+
+	return lister, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"os"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testESLister(t *testing.T, c *configpb.EndpointSlices) *esLister {
+	t.Helper()
+
+	data, err := os.ReadFile("./testdata/endpointslices.json")
+	if err != nil {
+		t.Fatalf("error reading test data file: %v", err)
+	}
+	keys, slices, err := parseEndpointSlicesJSON(data)
+	if err != nil {
+		t.Fatalf("error parsing test data: %v", err)
+	}
+	return &esLister{c: c, keys: keys, cache: slices, l: &logger.Logger{}}
+}
+
+func TestParseEndpointSlices(t *testing.T) {
+	lister := testESLister(t, nil)
+
+	// Slice without the service name label is skipped.
+	assert.Equal(t, []resourceKey{{"default", "web"}, {"default", "draining"}}, lister.keys)
+
+	// Slices are sorted by name.
+	var names []string
+	for _, es := range lister.cache[resourceKey{"default", "web"}] {
+		names = append(names, es.Metadata.Name)
+	}
+	assert.Equal(t, []string{"web-a1b2c", "web-x7k2p"}, names)
+}
+
+func TestEndpointSlicesListResources(t *testing.T) {
+	tests := []struct {
+		name            string
+		resourcePath    string
+		includeNotReady bool
+		wantNames       []string
+	}{
+		{
+			name:         "all",
+			resourcePath: "endpointslices",
+			wantNames: []string{
+				"web_10.28.2.6_http",
+				"web_10.28.0.3_http",
+				// No ready endpoints, fallback to serving-terminating.
+				"draining_10.28.1.4_9313",
+			},
+		},
+		{
+			name:         "web",
+			resourcePath: "endpointslices/web",
+			wantNames:    []string{"web_10.28.2.6_http", "web_10.28.0.3_http"},
+		},
+		{
+			name:            "include_not_ready",
+			resourcePath:    "endpointslices/web",
+			includeNotReady: true,
+			wantNames:       []string{"web_10.28.2.6_http", "web_10.28.0.3_http", "web_10.28.2.3_http"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lister := testESLister(t, &configpb.EndpointSlices{IncludeNotReady: proto.Bool(test.includeNotReady)})
+			resources, err := lister.listResources(&pb.ListResourcesRequest{ResourcePath: proto.String(test.resourcePath)})
+			assert.NoError(t, err)
+
+			var names []string
+			for _, res := range resources {
+				names = append(names, res.GetName())
+			}
+			assert.Equal(t, test.wantNames, names)
+		})
+	}
+}
+
+func TestEndpointSlicesLabels(t *testing.T) {
+	lister := testESLister(t, nil)
+
+	resources, err := lister.listResources(&pb.ListResourcesRequest{
+		ResourcePath: proto.String("endpointslices/web"),
+		Filter: []*pb.Filter{
+			{Key: proto.String("labels.app"), Value: proto.String("web")},
+			{Key: proto.String("port"), Value: proto.String("http")},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	res := resources[1]
+	assert.Equal(t, "10.28.0.3", res.GetIp())
+	assert.Equal(t, int32(8080), res.GetPort())
+	assert.Equal(t, map[string]string{
+		"app":                                    "web",
+		"endpointslice.kubernetes.io/managed-by": "endpointslice-controller.k8s.io",
+		"kubernetes.io/service-name":             "web",
+		"ready":                                  "true",
+		"serving":                                "true",
+		"terminating":                            "false",
+		"node":                                   "gke-cluster-1-default-pool-abd8ad35-ccr7",
+		"zone":                                   "us-central1-a",
+		"zone_hints":                             "us-central1-a",
+		"pod":                                    "web-577cf7bbcc-c7l5p",
+	}, res.GetLabels())
+
+	// Nil conditions are interpreted as ready.
+	assert.Equal(t, "true", resources[0].GetLabels()["ready"])
+	assert.Equal(t, "true", resources[0].GetLabels()["serving"])
+}
@@ -0,0 +1,319 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// httpRoutesLister lists Gateway API HTTPRoutes. Gateways are listed as well,
+// to get the routes' IP address and TLS status from their parent gateways.
+type httpRoutesLister struct {
+	c         *configpb.HTTPRoutes
+	namespace string
+	kClient   *client
+
+	mu       sync.RWMutex // Mutex for names and cache
+	keys     []resourceKey
+	cache    map[resourceKey]*httpRouteInfo
+	gateways map[resourceKey]*gatewayInfo
+	l        *logger.Logger
+}
+
+func gatewayAPIURL(ns, resource string) string {
+	if ns == "" {
+		return "apis/gateway.networking.k8s.io/v1/" + resource
+	}
+	return fmt.Sprintf("apis/gateway.networking.k8s.io/v1/namespaces/%s/%s", ns, resource)
+}
+
+func (lister *httpRoutesLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	var resName string
+	tok := strings.SplitN(req.GetResourcePath(), "/", 2)
+	if len(tok) == 2 {
+		resName = tok[1]
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, nsFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["namespace"], allFilters.LabelsFilter
+
+	lister.mu.RLock()
+	defer lister.mu.RUnlock()
+
+	for _, key := range lister.keys {
+		if resName != "" && key.name != resName {
+			continue
+		}
+
+		route := lister.cache[key]
+		if nsFilter != nil && !nsFilter.Match(route.Metadata.Namespace, lister.l) {
+			continue
+		}
+
+		for _, res := range route.resources(lister.gateways) {
+			if nameFilter != nil && !nameFilter.Match(res.GetName(), lister.l) {
+				continue
+			}
+			if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), lister.l) {
+				continue
+			}
+			resources = append(resources, res)
+		}
+	}
+
+	lister.l.Debugf("kubernetes.listResources: returning %d httproutes", len(resources))
+	return resources, nil
+}
+
+type gatewayInfo struct {
+	Metadata kMetadata
+	Spec     struct {
+		Listeners []struct {
+			Name     string
+			Hostname string
+			Protocol string
+		}
+	}
+	Status struct {
+		Addresses []struct {
+			Value string
+		}
+	}
+}
+
+type httpRouteInfo struct {
+	Metadata kMetadata
+	Spec     struct {
+		ParentRefs []struct {
+			Name        string
+			Namespace   string
+			SectionName string
+		}
+		Hostnames []string
+		Rules     []struct {
+			Matches []struct {
+				Path *struct {
+					Type  string
+					Value string
+				}
+			}
+			BackendRefs []struct {
+				Name string
+				Port int
+			}
+		}
+	}
+}
+
+// hostnameMatches reports whether a listener hostname, which may be a
+// wildcard like "*.example.com", matches the host.
+func hostnameMatches(listenerHostname, host string) bool {
+	if listenerHostname == "" || listenerHostname == host {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(listenerHostname, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return false
+}
+
+// parentInfo returns the IP address of the route's first parent gateway, and
+// whether the given host is served over TLS by any of the parent gateways.
+func (r *httpRouteInfo) parentInfo(gateways map[resourceKey]*gatewayInfo, host string) (ip string, tls bool) {
+	for _, ref := range r.Spec.ParentRefs {
+		ns := ref.Namespace
+		if ns == "" {
+			ns = r.Metadata.Namespace
+		}
+		gw := gateways[resourceKey{ns, ref.Name}]
+		if gw == nil {
+			continue
+		}
+
+		if ip == "" && len(gw.Status.Addresses) > 0 {
+			ip = gw.Status.Addresses[0].Value
+		}
+
+		for _, listener := range gw.Spec.Listeners {
+			if ref.SectionName != "" && listener.Name != ref.SectionName {
+				continue
+			}
+			if listener.Protocol == "HTTPS" && hostnameMatches(listener.Hostname, host) {
+				tls = true
+			}
+		}
+	}
+	return
+}
+
+// resources returns RDS resources corresponding to an HTTPRoute: one resource
+// per hostname and path. Only exact and prefix path matches are used, as
+// regex paths can't be probed directly.
+func (r *httpRouteInfo) resources(gateways map[resourceKey]*gatewayInfo) (resources []*pb.Resource) {
+	hosts := r.Spec.Hostnames
+	if len(hosts) == 0 {
+		hosts = []string{""}
+	}
+
+	for _, host := range hosts {
+		ip, tls := r.parentInfo(gateways, host)
+
+		nameWithHost := r.Metadata.Name
+		if host != "" {
+			nameWithHost += "_" + host
+		}
+
+		for _, rule := range r.Spec.Rules {
+			var backend backendRef
+			if len(rule.BackendRefs) > 0 {
+				backend.Name = rule.BackendRefs[0].Name
+				if rule.BackendRefs[0].Port != 0 {
+					backend.Port = strconv.Itoa(rule.BackendRefs[0].Port)
+				}
+			}
+
+			paths := []string{}
+			for _, m := range rule.Matches {
+				if m.Path == nil {
+					paths = append(paths, "/")
+					continue
+				}
+				if m.Path.Type == "RegularExpression" {
+					continue
+				}
+				paths = append(paths, m.Path.Value)
+			}
+			// No matches means match everything.
+			if len(rule.Matches) == 0 {
+				paths = append(paths, "/")
+			}
+
+			for _, path := range paths {
+				resources = append(resources, routeResource(nameWithHost, host, path, ip, tls, backend, r.Metadata.Labels))
+			}
+		}
+	}
+
+	return
+}
+
+func parseHTTPRoutesJSON(resp []byte) (keys []resourceKey, routes map[resourceKey]*httpRouteInfo, err error) {
+	var itemList struct {
+		Items []*httpRouteInfo
+	}
+
+	if err = json.Unmarshal(resp, &itemList); err != nil {
+		return
+	}
+
+	keys = make([]resourceKey, len(itemList.Items))
+	routes = make(map[resourceKey]*httpRouteInfo)
+	for i, item := range itemList.Items {
+		keys[i] = resourceKey{item.Metadata.Namespace, item.Metadata.Name}
+		routes[keys[i]] = item
+	}
+
+	return
+}
+
+func parseGatewaysJSON(resp []byte) (gateways map[resourceKey]*gatewayInfo, err error) {
+	var itemList struct {
+		Items []*gatewayInfo
+	}
+
+	if err = json.Unmarshal(resp, &itemList); err != nil {
+		return
+	}
+
+	gateways = make(map[resourceKey]*gatewayInfo)
+	for _, item := range itemList.Items {
+		gateways[resourceKey{item.Metadata.Namespace, item.Metadata.Name}] = item
+	}
+
+	return
+}
+
+func (lister *httpRoutesLister) expand() {
+	resp, err := lister.kClient.getURL(gatewayAPIURL(lister.namespace, "httproutes"))
+	if err != nil {
+		lister.l.Warningf("httpRoutesLister.expand(): error while getting httproutes list from API: %v", err)
+	}
+
+	keys, routes, err := parseHTTPRoutesJSON(resp)
+	if err != nil {
+		lister.l.Warningf("httpRoutesLister.expand(): error while parsing httproutes API response (%s): %v", string(resp), err)
+	}
+
+	// Routes may refer to gateways in other namespaces, so we always list
+	// gateways across all namespaces, and irrespective of the selectors.
+	resp, err = lister.kClient.getUnfilteredURL(gatewayAPIURL("", "gateways"))
+	if err != nil {
+		lister.l.Warningf("httpRoutesLister.expand(): error while getting gateways list from API: %v", err)
+	}
+
+	gateways, err := parseGatewaysJSON(resp)
+	if err != nil {
+		lister.l.Warningf("httpRoutesLister.expand(): error while parsing gateways API response (%s): %v", string(resp), err)
+	}
+
+	lister.l.Debugf("httpRoutesLister.expand(): got %d httproutes and %d gateways", len(keys), len(gateways))
+
+	lister.mu.Lock()
+	defer lister.mu.Unlock()
+	lister.keys = keys
+	lister.cache = routes
+	lister.gateways = gateways
+}
+
+func newHTTPRoutesLister(c *configpb.HTTPRoutes, namespace string, reEvalInterval time.Duration, kc *client, l *logger.Logger) (*httpRoutesLister, error) {
+	lister := &httpRoutesLister{
+		c:         c,
+		kClient:   kc,
+		namespace: namespace,
+		l:         l,
+	}
+
+	go func() {
+		lister.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the
+		// API server at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			lister.expand()
+		}
+	}()
+
+	return lister, nil
+}
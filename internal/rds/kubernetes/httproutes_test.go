@@ -0,0 +1,105 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"os"
+	"testing"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testHTTPRoutesLister(t *testing.T) *httpRoutesLister {
+	t.Helper()
+
+	data, err := os.ReadFile("./testdata/httproutes.json")
+	if err != nil {
+		t.Fatalf("error reading test data file: %v", err)
+	}
+	keys, routes, err := parseHTTPRoutesJSON(data)
+	if err != nil {
+		t.Fatalf("error parsing httproutes: %v", err)
+	}
+
+	data, err = os.ReadFile("./testdata/gateways.json")
+	if err != nil {
+		t.Fatalf("error reading test data file: %v", err)
+	}
+	gateways, err := parseGatewaysJSON(data)
+	if err != nil {
+		t.Fatalf("error parsing gateways: %v", err)
+	}
+
+	return &httpRoutesLister{keys: keys, cache: routes, gateways: gateways, l: &logger.Logger{}}
+}
+
+func TestHTTPRoutesListResources(t *testing.T) {
+	lister := testHTTPRoutesLister(t)
+
+	resources, err := lister.listResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+
+	var names, ips []string
+	for _, res := range resources {
+		names = append(names, res.GetName())
+		ips = append(ips, res.GetIp())
+	}
+	assert.Equal(t, []string{"store_store.example.com", "store_store.example.com__cart", "internal-api"}, names)
+	assert.Equal(t, []string{"34.120.51.35", "34.120.51.35", "10.128.0.10"}, ips)
+
+	assert.Equal(t, map[string]string{
+		"app":             "store",
+		"fqdn":            "store.example.com",
+		"relative_url":    "/cart",
+		"tls":             "true",
+		"__cp_scheme__":   "https",
+		"backend_service": "store-v1",
+		"backend_port":    "8080",
+	}, resources[1].GetLabels())
+
+	assert.Equal(t, map[string]string{
+		"relative_url":    "/",
+		"tls":             "false",
+		"backend_service": "api",
+		"backend_port":    "9090",
+	}, resources[2].GetLabels())
+
+	// Filter by resource name.
+	resources, err = lister.listResources(&pb.ListResourcesRequest{
+		ResourcePath: proto.String("httproutes/store"),
+		Filter:       []*pb.Filter{{Key: proto.String("labels.relative_url"), Value: proto.String("/cart")}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+}
+
+func TestHostnameMatches(t *testing.T) {
+	for _, test := range []struct {
+		listener, host string
+		want           bool
+	}{
+		{"", "foo.example.com", true},
+		{"foo.example.com", "foo.example.com", true},
+		{"*.example.com", "foo.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"bar.example.com", "foo.example.com", false},
+	} {
+		assert.Equal(t, test.want, hostnameMatches(test.listener, test.host), "listener: %s, host: %s", test.listener, test.host)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -91,11 +92,36 @@ func (lister *ingressesLister) listResources(req *pb.ListResourcesRequest) ([]*p
 	return resources, nil
 }
 
+// backendRef is a reference to the backend service of a route.
+type backendRef struct {
+	Name string
+	Port string
+}
+
+type ingressBackend struct {
+	Service struct {
+		Name string
+		Port struct {
+			Name   string
+			Number int
+		}
+	}
+}
+
+func (b *ingressBackend) ref() backendRef {
+	ref := backendRef{Name: b.Service.Name, Port: b.Service.Port.Name}
+	if ref.Port == "" && b.Service.Port.Number != 0 {
+		ref.Port = strconv.Itoa(b.Service.Port.Number)
+	}
+	return ref
+}
+
 type ingressRule struct {
 	Host string
 	HTTP struct {
 		Paths []struct {
-			Path string
+			Path    string
+			Backend ingressBackend
 		}
 	}
 }
@@ -103,6 +129,9 @@ type ingressRule struct {
 type ingressInfo struct {
 	Metadata kMetadata
 	Spec     struct {
+		TLS []struct {
+			Hosts []string
+		}
 		Rules []ingressRule
 	}
 	Status struct {
@@ -110,6 +139,55 @@ type ingressInfo struct {
 	}
 }
 
+// routeResource returns the RDS resource for a host and path combination of
+// an ingress or an HTTP route. Resource is named <nameWithHost>_<path>, with
+// "/" in the path replaced by "_", and the path is omitted if it's "/".
+func routeResource(nameWithHost, host, path, ip string, tls bool, backend backendRef, baseLabels map[string]string) *pb.Resource {
+	resName := nameWithHost
+	if path != "/" {
+		resName = fmt.Sprintf("%s_%s", resName, strings.Replace(path, "/", "_", -1))
+	}
+
+	// Add fqdn, url, tls and backend labels to the resources, without
+	// overriding the resource's own labels.
+	labels := make(map[string]string, len(baseLabels)+6)
+	for k, v := range baseLabels {
+		labels[k] = v
+	}
+	addLabel := func(k, v string) {
+		if _, ok := labels[k]; !ok && v != "" {
+			labels[k] = v
+		}
+	}
+	addLabel("fqdn", host)
+	addLabel("relative_url", path)
+	addLabel("tls", strconv.FormatBool(tls))
+	addLabel("backend_service", backend.Name)
+	addLabel("backend_port", backend.Port)
+	// Tells the HTTP probe to use HTTPS for this target, unless probe config
+	// specifies the scheme explicitly.
+	if tls {
+		addLabel("__cp_scheme__", "https")
+	}
+
+	return &pb.Resource{
+		Name:   proto.String(resName),
+		Labels: labels,
+		Ip:     proto.String(ip),
+	}
+}
+
+func (i *ingressInfo) tlsHost(host string) bool {
+	for _, tls := range i.Spec.TLS {
+		for _, h := range tls.Hosts {
+			if h == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // resources returns RDS resources corresponding to an ingress resource.
 func (i *ingressInfo) resources() (resources []*pb.Resource) {
 	resName := i.Metadata.Name
@@ -139,31 +217,8 @@ func (i *ingressInfo) resources() (resources []*pb.Resource) {
 	}
 
 	for _, rule := range i.Spec.Rules {
-		nameWithHost := fmt.Sprintf("%s_%s", resName, rule.Host)
-
 		for _, p := range rule.HTTP.Paths {
-			nameWithPath := nameWithHost
-			if p.Path != "/" {
-				nameWithPath = fmt.Sprintf("%s_%s", nameWithHost, strings.Replace(p.Path, "/", "_", -1))
-			}
-
-			// Add fqdn and url labels to the resources.
-			labels := make(map[string]string, len(baseLabels)+2)
-			for k, v := range baseLabels {
-				labels[k] = v
-			}
-			if _, ok := labels["fqdn"]; !ok {
-				labels["fqdn"] = rule.Host
-			}
-			if _, ok := labels["relative_url"]; !ok {
-				labels["relative_url"] = p.Path
-			}
-
-			resources = append(resources, &pb.Resource{
-				Name:   proto.String(nameWithPath),
-				Labels: labels,
-				Ip:     proto.String(ip),
-			})
+			resources = append(resources, routeResource(resName+"_"+rule.Host, rule.Host, p.Path, ip, i.tlsHost(rule.Host), p.Backend.ref(), baseLabels))
 		}
 	}
 
@@ -165,3 +165,25 @@ func TestListIngressResources(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressTLSAndBackendLabels(t *testing.T) {
+	lister := listerFromDataFile(t)
+
+	resources, err := lister.listResources(&pb.ListResourcesRequest{})
+	if err != nil {
+		t.Fatalf("Error while listing resources: %v", err)
+	}
+
+	wantLabels := []map[string]string{
+		{"fqdn": "foo.bar.com", "relative_url": "/health", "tls": "true", "__cp_scheme__": "https", "backend_service": "cloudprober-rds", "backend_port": "9313"},
+		{"fqdn": "foo.bar.com", "relative_url": "/rds", "tls": "true", "__cp_scheme__": "https", "backend_service": "cloudprober-rds", "backend_port": "rds"},
+		{"fqdn": "prometheus.bar.com", "relative_url": "/", "tls": "false"},
+	}
+	var gotLabels []map[string]string
+	for _, res := range resources {
+		gotLabels = append(gotLabels, res.GetLabels())
+	}
+	if !reflect.DeepEqual(gotLabels, wantLabels) {
+		t.Errorf("gotLabels: %v, wantLabels: %v", gotLabels, wantLabels)
+	}
+}
@@ -46,12 +46,14 @@ const DefaultProviderID = "k8s"
 
 // ResourceTypes declares resource types supported by the Kubernetes provider.
 var ResourceTypes = struct {
-	Pods, Endpoints, Services, Ingresses string
+	Pods, Endpoints, Services, Ingresses, EndpointSlices, HTTPRoutes string
 }{
 	"pods",
 	"endpoints",
 	"services",
 	"ingresses",
+	"endpointslices",
+	"httproutes",
 }
 
 /*
@@ -79,7 +81,8 @@ var SupportedFilters = struct {
 	RegexFilterKeys []string
 	LabelsFilter    bool
 }{
-	// Note: the port filter applies only to endpoints and services.
+	// Note: the port filter applies only to endpoints, endpointslices and
+	// services.
 	[]string{"name", "namespace", "port"},
 	true,
 }
@@ -122,6 +125,17 @@ func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResource
 // New creates a Kubernetes (k8s) provider for RDS server, based on the
 // provided config.
 func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	for _, s := range c.GetLabelSelector() {
+		if err := validateLabelSelector(s); err != nil {
+			return nil, fmt.Errorf("kubernetes: %v", err)
+		}
+	}
+	for _, s := range c.GetFieldSelector() {
+		if err := validateFieldSelector(s); err != nil {
+			return nil, fmt.Errorf("kubernetes: %v", err)
+		}
+	}
+
 	client, err := newClient(c, l)
 	if err != nil {
 		return nil, fmt.Errorf("error while creating the kubernetes client: %v", err)
@@ -169,5 +183,23 @@ func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
 		p.listers[ResourceTypes.Ingresses] = lr
 	}
 
+	// Enable EndpointSlices lister if configured.
+	if c.GetEndpointslices() != nil {
+		lr, err := newEndpointSlicesLister(c.GetEndpointslices(), c.GetNamespace(), reEvalInterval, client, l)
+		if err != nil {
+			return nil, err
+		}
+		p.listers[ResourceTypes.EndpointSlices] = lr
+	}
+
+	// Enable HTTPRoutes lister if configured.
+	if c.GetHttproutes() != nil {
+		lr, err := newHTTPRoutesLister(c.GetHttproutes(), c.GetNamespace(), reEvalInterval, client, l)
+		if err != nil {
+			return nil, err
+		}
+		p.listers[ResourceTypes.HTTPRoutes] = lr
+	}
+
 	return p, nil
 }
@@ -75,7 +75,7 @@ func (pl *podsLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resourc
 		resources = append(resources, &pb.Resource{
 			Name:   proto.String(key.name),
 			Ip:     proto.String(pod.Status.PodIP),
-			Labels: pod.Metadata.Labels,
+			Labels: pod.resourceLabels(),
 		})
 	}
 
@@ -85,12 +85,32 @@ func (pl *podsLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resourc
 
 type podInfo struct {
 	Metadata kMetadata
-	Status   struct {
+	Spec     struct {
+		NodeName string
+	}
+	Status struct {
 		Phase string
 		PodIP string
 	}
 }
 
+// resourceLabels returns pod labels, along with the pod's namespace and node
+// as "namespace" and "node" labels, unless pod already has labels with those
+// keys.
+func (pi *podInfo) resourceLabels() map[string]string {
+	labels := make(map[string]string, len(pi.Metadata.Labels)+2)
+	if pi.Metadata.Namespace != "" {
+		labels["namespace"] = pi.Metadata.Namespace
+	}
+	if pi.Spec.NodeName != "" {
+		labels["node"] = pi.Spec.NodeName
+	}
+	for k, v := range pi.Metadata.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
 func parsePodsJSON(resp []byte) (keys []resourceKey, pods map[resourceKey]*podInfo, err error) {
 	var itemList struct {
 		Items []*podInfo
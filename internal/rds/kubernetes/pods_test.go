@@ -166,3 +166,13 @@ func TestParseResourceList(t *testing.T) {
 		})
 	}
 }
+
+func TestPodResourceLabels(t *testing.T) {
+	pi := &podInfo{Metadata: kMetadata{Name: "podA", Namespace: "prod", Labels: map[string]string{"app": "appA"}}}
+	pi.Spec.NodeName = "node-1"
+	assert.Equal(t, map[string]string{"app": "appA", "namespace": "prod", "node": "node-1"}, pi.resourceLabels())
+
+	// Pod labels take precedence.
+	pi.Metadata.Labels["node"] = "custom"
+	assert.Equal(t, "custom", pi.resourceLabels()["node"])
+}
@@ -14,6 +14,17 @@ import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 #Ingresses: {
 }
 
+#HTTPRoutes: {
+}
+
+#EndpointSlices: {
+	// By default, like kube-proxy, we return only the ready endpoints, falling
+	// back to the serving-but-terminating endpoints if a service has no ready
+	// endpoints. Set this field to return all endpoints. Readiness is always
+	// available in the "ready", "serving" and "terminating" labels.
+	includeNotReady?: bool @protobuf(1,bool,name=include_not_ready)
+}
+
 // Kubernetes provider config.
 #ProviderConfig: {
 	// Namespace to list resources for. If not specified, we default to all
@@ -37,10 +48,28 @@ import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 	// Note: Ingress support is experimental and may change in future.
 	ingresses?: #Ingresses @protobuf(5,Ingresses)
 
+	// EndpointSlices discovery options. This field should be declared for the
+	// endpointslices discovery to be enabled. EndpointSlices are grouped by
+	// the service they belong to, i.e. resource name is the service name.
+	endpointslices?: #EndpointSlices @protobuf(6,EndpointSlices)
+
+	// Gateway API HTTPRoutes discovery options. This field should be declared
+	// for the httproutes discovery to be enabled. There is one resource per
+	// hostname and path of an HTTPRoute, and the resource IP is the parent
+	// gateway's address.
+	httproutes?: #HTTPRoutes @protobuf(7,HTTPRoutes)
+
 	// Label selectors to filter resources. This is useful for large clusters.
 	// label_selector: ["app=cloudprober", "env!=dev"]
+	// Set-based requirements are supported as well:
+	// label_selector: ["env in (prod,staging)", "!canary"]
 	labelSelector?: [...string] @protobuf(20,string,name=label_selector)
 
+	// Field selectors to filter resources, in the same format as kubectl's
+	// --field-selector flag. Supported fields depend on the resource type.
+	// field_selector: ["metadata.name!=kubernetes"]
+	fieldSelector?: [...string] @protobuf(21,string,name=field_selector)
+
 	// Kubernetes API server address. If not specified, we assume in-cluster mode
 	// and get it from the local environment variables.
 	apiServerAddress?: string @protobuf(91,string,name=api_server_address)
@@ -0,0 +1,78 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Selectors are evaluated by the API server. We validate them here only to
+// catch configuration errors at startup, instead of getting an HTTP 400 on
+// every refresh.
+const (
+	selectorKey   = `([a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?/)?[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?`
+	selectorValue = `(([a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?)?)`
+)
+
+var (
+	// Equality-based requirement, e.g. "app=web", "env!=dev", or an
+	// existence requirement, e.g. "canary", "!canary".
+	equalityReqRe = regexp.MustCompile(`^\s*(!?\s*` + selectorKey + `|` + selectorKey + `\s*(=|==|!=)\s*` + selectorValue + `)\s*$`)
+	// Set-based requirement, e.g. "env in (prod, staging)".
+	setReqRe = regexp.MustCompile(`^\s*` + selectorKey + `\s+(in|notin)\s+\(\s*` + selectorValue + `(\s*,\s*` + selectorValue + `)*\s*\)\s*$`)
+
+	fieldReqRe = regexp.MustCompile(`^\s*[a-zA-Z0-9.]+\s*(=|==|!=)\s*[^,=!]*$`)
+)
+
+// splitRequirements splits a selector into requirements, at the commas that
+// are not inside parentheses.
+func splitRequirements(selector string) []string {
+	var reqs []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				reqs = append(reqs, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(reqs, selector[start:])
+}
+
+func validateLabelSelector(selector string) error {
+	for _, req := range splitRequirements(selector) {
+		if !equalityReqRe.MatchString(req) && !setReqRe.MatchString(req) {
+			return fmt.Errorf("invalid label selector requirement %q in %q", strings.TrimSpace(req), selector)
+		}
+	}
+	return nil
+}
+
+func validateFieldSelector(selector string) error {
+	for _, req := range strings.Split(selector, ",") {
+		if !fieldReqRe.MatchString(req) {
+			return fmt.Errorf("invalid field selector requirement %q in %q", strings.TrimSpace(req), selector)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLabelSelector(t *testing.T) {
+	for _, s := range []string{
+		"app",
+		"!canary",
+		"app=web",
+		"app==web",
+		"env!=dev",
+		"app.kubernetes.io/name=web",
+		"env in (prod,staging)",
+		"env notin (dev, qa),app=web,!canary",
+		"tier in (frontend,)",
+	} {
+		assert.NoError(t, validateLabelSelector(s), s)
+	}
+
+	for _, s := range []string{
+		"",
+		"app=web,",
+		"env in prod",
+		"env in (prod",
+		"env = (prod)",
+		"app=web=1",
+		"-app",
+	} {
+		assert.Error(t, validateLabelSelector(s), s)
+	}
+}
+
+func TestValidateFieldSelector(t *testing.T) {
+	for _, s := range []string{
+		"metadata.name=web",
+		"metadata.name!=kubernetes,metadata.namespace==default",
+		"status.phase=Running",
+	} {
+		assert.NoError(t, validateFieldSelector(s), s)
+	}
+
+	for _, s := range []string{
+		"metadata.name",
+		"metadata.name in (a,b)",
+		"metadata.name=a=b",
+	} {
+		assert.Error(t, validateFieldSelector(s), s)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+)
+
+const defaultEtcdEndpoint = "http://127.0.0.1:2379"
+
+var errEtcdUnauthenticated = errors.New("unauthenticated")
+
+// etcdStore lists keys using the etcd v3 HTTP/JSON gateway.
+type etcdStore struct {
+	c          *configpb.EtcdConfig
+	endpoints  []string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+// prefixRangeEnd returns the range end to get all the keys with the given
+// prefix, i.e. the prefix with its last byte incremented.
+func prefixRangeEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// All bytes are 0xff (or prefix is empty): get all keys >= prefix.
+	return "\x00"
+}
+
+// post sends a request to the etcd gateway, and decodes the response into v.
+func (es *etcdStore) post(ctx context.Context, endpoint, path, token string, body, v interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := es.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return errEtcdUnauthenticated
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(respBody))
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("error parsing response (%s): %v", string(respBody), err)
+	}
+	return nil
+}
+
+func (es *etcdStore) authenticate(ctx context.Context, endpoint string) (string, error) {
+	var resp struct {
+		Token string
+	}
+	if err := es.post(ctx, endpoint, "/v3/auth/authenticate", "", map[string]string{"name": es.c.GetUsername(), "password": es.c.GetPassword()}, &resp); err != nil {
+		return "", fmt.Errorf("error authenticating with etcd: %v", err)
+	}
+	return resp.Token, nil
+}
+
+func (es *etcdStore) rangeKeys(ctx context.Context, endpoint, prefix string) (map[string][]byte, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if es.c.GetUsername() != "" && es.token == "" {
+		token, err := es.authenticate(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		es.token = token
+	}
+
+	var resp struct {
+		Kvs []struct {
+			Key   []byte // base64 encoded
+			Value []byte // base64 encoded
+		}
+	}
+	req := map[string]string{
+		"key":       base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end": base64.StdEncoding.EncodeToString([]byte(prefixRangeEnd(prefix))),
+	}
+
+	err := es.post(ctx, endpoint, "/v3/kv/range", es.token, req, &resp)
+	// Auth tokens expire. Re-authenticate and retry once.
+	if err == errEtcdUnauthenticated && es.c.GetUsername() != "" {
+		if es.token, err = es.authenticate(ctx, endpoint); err != nil {
+			return nil, err
+		}
+		err = es.post(ctx, endpoint, "/v3/kv/range", es.token, req, &resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = kv.Value
+	}
+	return kvs, nil
+}
+
+// list returns all the keys under the given prefix. Endpoints are tried in
+// order until one of them succeeds.
+func (es *etcdStore) list(ctx context.Context, prefix string) (map[string][]byte, error) {
+	var errs []string
+	for _, endpoint := range es.endpoints {
+		kvs, err := es.rangeKeys(ctx, endpoint, prefix)
+		if err == nil {
+			return kvs, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", endpoint, err))
+	}
+	return nil, fmt.Errorf("etcd: error listing keys: %s", strings.Join(errs, "; "))
+}
+
+func newEtcdStore(c *configpb.EtcdConfig) (*etcdStore, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("etcd: error parsing TLS config: %v", err)
+		}
+	}
+
+	es := &etcdStore{
+		c: c,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+	}
+
+	endpoints := c.GetEndpoint()
+	if len(endpoints) == 0 {
+		endpoints = []string{defaultEtcdEndpoint}
+	}
+	for _, ep := range endpoints {
+		if !strings.Contains(ep, "://") {
+			scheme := "http"
+			if c.GetTlsConfig() != nil {
+				scheme = "https"
+			}
+			ep = scheme + "://" + ep
+		}
+		es.endpoints = append(es.endpoints, strings.TrimSuffix(ep, "/"))
+	}
+
+	return es, nil
+}
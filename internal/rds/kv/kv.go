@@ -0,0 +1,320 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package kv implements a key-value store (etcd or ZooKeeper) based resources
+provider for ResourceDiscovery server.
+
+It's meant for the homegrown service registration schemes, where services
+register themselves by writing a key under a well-known prefix. Each key under
+the configured prefix becomes a resource. Values are expected to be JSON
+objects, e.g.:
+
+	{"address": "10.1.1.2", "port": 8080, "labels": {"zone": "us-east1"}}
+
+or "host:port" strings. Field names are configurable, see
+proto/config.proto. Resource name is the key relative to the prefix, unless
+name_field is configured. Resources get a "key" label (the full key), in
+addition to the labels from the value.
+
+Keys are re-read every re_eval_sec, and resources' last modified time changes
+only if the resources change.
+*/
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "kv"
+
+/*
+SupportedFilters defines filters supported by this provider.
+
+	 Example filters:
+	 filter {
+		 key: "name"
+		 value: "web/.*"
+	 }
+	 filter {
+		 key: "key"
+		 value: "/services/web/.*"
+	 }
+	 filter {
+		 key: "labels.zone"
+		 value: "us-east1"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name", "key"},
+	true,
+}
+
+// store is a key-value store backend.
+type store interface {
+	// list returns all the key-value pairs under the given prefix.
+	list(ctx context.Context, prefix string) (map[string][]byte, error)
+}
+
+// Provider implements a key-value store provider for use with a
+// ResourceDiscovery server.
+type Provider struct {
+	c     *configpb.ProviderConfig
+	store store
+	l     *logger.Logger
+
+	mu          sync.RWMutex
+	resources   []*pb.Resource
+	lastUpdated int64
+}
+
+// lookupField returns the value of the (possibly nested, dot-separated) field
+// in the given JSON object.
+func lookupField(obj map[string]interface{}, field string) (interface{}, bool) {
+	var v interface{} = obj
+	for _, f := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[f]; !ok || v == nil {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// relativeKey returns the key relative to the configured prefix.
+func (p *Provider) relativeKey(key string) string {
+	rel := strings.TrimLeft(strings.TrimPrefix(key, p.c.GetPrefix()), "/")
+	if rel == "" {
+		return key
+	}
+	return rel
+}
+
+// parseValue parses a key's value into a resource. It returns nil for the
+// keys with empty values, e.g. ZooKeeper's intermediate znodes.
+func (p *Provider) parseValue(key string, value []byte) (*pb.Resource, error) {
+	value = []byte(strings.TrimSpace(string(value)))
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	res := &pb.Resource{
+		Name:   proto.String(p.relativeKey(key)),
+		Labels: map[string]string{"key": key},
+	}
+
+	if value[0] != '{' {
+		host, port, err := net.SplitHostPort(string(value))
+		if err != nil {
+			res.Ip = proto.String(string(value))
+			return res, nil
+		}
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port (%s) in value: %s", port, string(value))
+		}
+		res.Ip, res.Port = proto.String(host), proto.Int32(int32(portNum))
+		return res, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(value, &obj); err != nil {
+		return nil, fmt.Errorf("error parsing value as JSON: %v", err)
+	}
+
+	if v, ok := lookupField(obj, p.c.GetAddressField()); ok {
+		res.Ip = proto.String(stringValue(v))
+	}
+
+	if v, ok := lookupField(obj, p.c.GetPortField()); ok {
+		portNum, err := strconv.Atoi(stringValue(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port: %v", v)
+		}
+		res.Port = proto.Int32(int32(portNum))
+	}
+
+	if p.c.GetNameField() != "" {
+		if v, ok := lookupField(obj, p.c.GetNameField()); ok {
+			res.Name = proto.String(stringValue(v))
+		}
+	}
+
+	if v, ok := lookupField(obj, p.c.GetLabelsField()); ok {
+		labels, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("labels field (%s) is not an object", p.c.GetLabelsField())
+		}
+		for k, lv := range labels {
+			res.Labels[k] = stringValue(lv)
+		}
+	}
+
+	return res, nil
+}
+
+func resourcesEqual(a, b []*pb.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Provider) refresh(ctx context.Context) {
+	kvs, err := p.store.list(ctx, p.c.GetPrefix())
+	if err != nil {
+		// Keep using the existing resources.
+		p.l.Errorf("kv: error listing keys under %s: %v", p.c.GetPrefix(), err)
+		return
+	}
+
+	var resources []*pb.Resource
+	for key, value := range kvs {
+		res, err := p.parseValue(key, value)
+		if err != nil {
+			p.l.Warningf("kv: skipping key %s: %v", key, err)
+			continue
+		}
+		if res != nil {
+			resources = append(resources, res)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Update the modification time only if something changed, so that RDS
+	// clients don't have to reprocess the same resources.
+	if p.lastUpdated == 0 || !resourcesEqual(p.resources, resources) {
+		p.resources = resources
+		p.lastUpdated = time.Now().Unix()
+	}
+}
+
+// ListResources returns the list of resources from the cache. Resource path,
+// if specified, is used as the resource name prefix.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, keyFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["key"], allFilters.LabelsFilter
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: proto.Int64(p.lastUpdated)}, nil
+	}
+
+	var resources []*pb.Resource
+	for _, res := range p.resources {
+		if !strings.HasPrefix(res.GetName(), req.GetResourcePath()) {
+			continue
+		}
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+			continue
+		}
+		if keyFilter != nil && !keyFilter.Match(res.GetLabels()["key"], p.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	p.l.Debugf("kv.listResources: returning %d resources", len(resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(p.lastUpdated),
+	}, nil
+}
+
+// New creates a key-value store provider for RDS server, based on the
+// provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("kv: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	var s store
+	var err error
+	switch c.Backend.(type) {
+	case *configpb.ProviderConfig_Etcd:
+		s, err = newEtcdStore(c.GetEtcd())
+	case *configpb.ProviderConfig_Zookeeper:
+		s, err = newZKStore(c.GetZookeeper(), l)
+	default:
+		err = fmt.Errorf("no backend (etcd or zookeeper) configured")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kv: %v", err)
+	}
+
+	return newProvider(c, s, l), nil
+}
+
+func newProvider(c *configpb.ProviderConfig, s store, l *logger.Logger) *Provider {
+	p := &Provider{
+		c:     c,
+		store: s,
+		l:     l,
+	}
+
+	ctx := context.Background()
+	p.refresh(ctx)
+	go func() {
+		for range time.Tick(time.Duration(c.GetReEvalSec()) * time.Second) {
+			p.refresh(ctx)
+		}
+	}()
+
+	return p
+}
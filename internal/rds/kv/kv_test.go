@@ -0,0 +1,323 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var testKVs = map[string]string{
+	"/services/web/i-1": `{"address": "10.0.0.1", "port": 8080, "labels": {"zone": "us-east1-b"}}`,
+	"/services/web/i-2": `{"address": "10.0.0.2", "port": "8080", "labels": {"zone": "us-east1-c", "canary": true}}`,
+	"/services/db/i-3":  "10.0.1.1:5432",
+	"/services/db/i-4":  "db-4.internal",
+	"/services/bad/i-5": `{"address": "10.0.2.1", "port": "http"}`,
+	"/other/x":          "10.9.9.9:80",
+}
+
+type fakeStore map[string]string
+
+func (fs fakeStore) list(_ context.Context, prefix string) (map[string][]byte, error) {
+	kvs := make(map[string][]byte)
+	for k, v := range fs {
+		if strings.HasPrefix(k, prefix) {
+			kvs[k] = []byte(v)
+		}
+	}
+	return kvs, nil
+}
+
+type testResource struct {
+	ip   string
+	port int32
+}
+
+func resourcesMap(resources []*pb.Resource) map[string]testResource {
+	m := make(map[string]testResource)
+	for _, res := range resources {
+		m[res.GetName()] = testResource{res.GetIp(), res.GetPort()}
+	}
+	return m
+}
+
+func testConfig(prefix string) *configpb.ProviderConfig {
+	return &configpb.ProviderConfig{Prefix: proto.String(prefix)}
+}
+
+func TestListResources(t *testing.T) {
+	tests := []struct {
+		desc    string
+		resPath string
+		filters map[string]string
+		want    map[string]testResource
+		wantErr bool
+	}{
+		{
+			desc: "all",
+			want: map[string]testResource{
+				"web/i-1": {"10.0.0.1", 8080},
+				"web/i-2": {"10.0.0.2", 8080},
+				"db/i-3":  {"10.0.1.1", 5432},
+				"db/i-4":  {"db-4.internal", 0},
+			},
+		},
+		{
+			desc:    "resource_path",
+			resPath: "db/",
+			want: map[string]testResource{
+				"db/i-3": {"10.0.1.1", 5432},
+				"db/i-4": {"db-4.internal", 0},
+			},
+		},
+		{
+			desc:    "labels_filter",
+			filters: map[string]string{"labels.canary": "true"},
+			want:    map[string]testResource{"web/i-2": {"10.0.0.2", 8080}},
+		},
+		{
+			desc:    "key_and_name_filter",
+			filters: map[string]string{"key": "/services/web/.*", "name": ".*-1"},
+			want:    map[string]testResource{"web/i-1": {"10.0.0.1", 8080}},
+		},
+		{
+			desc:    "unsupported_filter",
+			filters: map[string]string{"zone": "us"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p := newProvider(testConfig("/services/"), fakeStore(testKVs), &logger.Logger{})
+
+			req := &pb.ListResourcesRequest{ResourcePath: proto.String(test.resPath)}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resp, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, resourcesMap(resp.GetResources()))
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	c := testConfig("/services")
+	c.AddressField = proto.String("serviceEndpoint.host")
+	c.PortField = proto.String("serviceEndpoint.port")
+	c.LabelsField = proto.String("meta")
+	c.NameField = proto.String("id")
+	p := &Provider{c: c, l: &logger.Logger{}}
+
+	res, err := p.parseValue("/services/web/member_01", []byte(`{"id": "web-1", "serviceEndpoint": {"host": "10.0.0.1", "port": 8080}, "meta": {"shard": 3}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "web-1", res.GetName())
+	assert.Equal(t, "10.0.0.1", res.GetIp())
+	assert.Equal(t, int32(8080), res.GetPort())
+	assert.Equal(t, map[string]string{"key": "/services/web/member_01", "shard": "3"}, res.GetLabels())
+
+	// Name falls back to the relative key.
+	res, err = p.parseValue("/services/web/member_02", []byte(`{"serviceEndpoint": {"host": "10.0.0.2"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "web/member_02", res.GetName())
+
+	res, err = p.parseValue("/services/web", []byte(" "))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	_, err = p.parseValue("/services/web/member_03", []byte(`{"meta": "x"}`))
+	assert.Error(t, err)
+
+	_, err = p.parseValue("/services/web/member_04", []byte(`{"id": `))
+	assert.Error(t, err)
+}
+
+func TestIfModifiedSince(t *testing.T) {
+	store := fakeStore{"/services/web/i-1": "10.0.0.1:80"}
+	p := newProvider(testConfig("/services/"), store, &logger.Logger{})
+	lastUpdated := p.lastUpdated
+
+	// Same keys shouldn't change the modification time.
+	p.refresh(context.Background())
+	assert.Equal(t, lastUpdated, p.lastUpdated)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastUpdated)})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+
+	// Modified value should update the modification time.
+	store["/services/web/i-1"] = "10.0.0.2:80"
+	p.lastUpdated = 1
+	p.refresh(context.Background())
+	assert.NotEqual(t, int64(1), p.lastUpdated)
+	assert.Equal(t, "10.0.0.2", p.resources[0].GetIp())
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	assert.Equal(t, "/services0", prefixRangeEnd("/services/"))
+	assert.Equal(t, "b", prefixRangeEnd("a\xff"))
+	assert.Equal(t, "\x00", prefixRangeEnd(""))
+}
+
+func testEtcdServer(t *testing.T, token string) (*httptest.Server, *int) {
+	t.Helper()
+
+	authCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			authCalls++
+			json.NewEncoder(w).Encode(map[string]string{"token": token})
+		case "/v3/kv/range":
+			if token != "" && r.Header.Get("Authorization") != token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req struct {
+				Key      []byte `json:"key"`
+				RangeEnd []byte `json:"range_end"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			var kvs []map[string]string
+			for k, v := range testKVs {
+				if k >= string(req.Key) && k < string(req.RangeEnd) {
+					kvs = append(kvs, map[string]string{
+						"key":   base64.StdEncoding.EncodeToString([]byte(k)),
+						"value": base64.StdEncoding.EncodeToString([]byte(v)),
+					})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"kvs": kvs})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &authCalls
+}
+
+func TestEtcdStore(t *testing.T) {
+	srv, _ := testEtcdServer(t, "")
+
+	// First endpoint is unreachable, second one works.
+	es, err := newEtcdStore(&configpb.EtcdConfig{Endpoint: []string{"http://127.0.0.1:1", strings.TrimPrefix(srv.URL, "http://")}})
+	assert.NoError(t, err)
+
+	kvs, err := es.list(context.Background(), "/services/db/")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"/services/db/i-3": []byte("10.0.1.1:5432"),
+		"/services/db/i-4": []byte("db-4.internal"),
+	}, kvs)
+}
+
+func TestEtcdStoreAuth(t *testing.T) {
+	srv, authCalls := testEtcdServer(t, "tok-1")
+
+	es, err := newEtcdStore(&configpb.EtcdConfig{
+		Endpoint: []string{srv.URL},
+		Username: proto.String("prober"),
+		Password: proto.String("pass"),
+	})
+	assert.NoError(t, err)
+
+	kvs, err := es.list(context.Background(), "/other/")
+	assert.NoError(t, err)
+	assert.Len(t, kvs, 1)
+	assert.Equal(t, 1, *authCalls)
+
+	// Expired token: we should re-authenticate.
+	es.token = "expired"
+	_, err = es.list(context.Background(), "/other/")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *authCalls)
+
+	es.c.Username = nil
+	es.token = "expired"
+	_, err = es.list(context.Background(), "/other/")
+	assert.Error(t, err)
+}
+
+type fakeZKConn map[string]string
+
+func (fz fakeZKConn) Get(p string) ([]byte, *zk.Stat, error) {
+	for k, v := range fz {
+		if k == p {
+			return []byte(v), nil, nil
+		}
+		if strings.HasPrefix(k, p+"/") || p == "/" {
+			return nil, nil, nil
+		}
+	}
+	return nil, nil, zk.ErrNoNode
+}
+
+func (fz fakeZKConn) Children(p string) ([]string, *zk.Stat, error) {
+	children := make(map[string]bool)
+	for k := range fz {
+		rel, ok := strings.CutPrefix(k, strings.TrimSuffix(p, "/")+"/")
+		if ok {
+			children[strings.Split(rel, "/")[0]] = true
+		}
+	}
+	var names []string
+	for c := range children {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+	return names, nil, nil
+}
+
+func TestZKStore(t *testing.T) {
+	zs := &zkStore{conn: fakeZKConn(testKVs)}
+
+	kvs, err := zs.list(context.Background(), "/services/web/")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"/services/web/i-1": []byte(testKVs["/services/web/i-1"]),
+		"/services/web/i-2": []byte(testKVs["/services/web/i-2"]),
+	}, kvs)
+
+	kvs, err = zs.list(context.Background(), "/")
+	assert.NoError(t, err)
+	assert.Len(t, kvs, len(testKVs))
+
+	kvs, err = zs.list(context.Background(), "/missing")
+	assert.NoError(t, err)
+	assert.Empty(t, kvs)
+}
@@ -0,0 +1,76 @@
+// Configuration proto for the key-value store (etcd, ZooKeeper) provider.
+//
+// Example provider config:
+// {
+//   etcd {
+//     endpoint: "http://etcd-1:2379"
+//     endpoint: "http://etcd-2:2379"
+//   }
+//   prefix: "/services/"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "kv://web/"
+//     }
+//   }
+// }
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// etcd (v3) backend config. We use etcd's HTTP/JSON gateway.
+#EtcdConfig: {
+	// etcd endpoints. Endpoints are tried in order until one of them succeeds.
+	// Default is "http://127.0.0.1:2379".
+	endpoint?: [...string] @protobuf(1,string)
+
+	// Username and password, if etcd authentication is enabled.
+	username?: string @protobuf(2,string)
+	password?: string @protobuf(3,string)
+
+	// TLS config to talk to etcd.
+	tlsConfig?: proto.#TLSConfig @protobuf(4,tlsconfig.TLSConfig,name=tls_config)
+}
+
+// ZooKeeper backend config.
+#ZooKeeperConfig: {
+	// ZooKeeper servers, e.g. "zk-1:2181". Default is "127.0.0.1:2181".
+	server?: [...string] @protobuf(1,string)
+
+	// ZooKeeper session timeout.
+	sessionTimeoutSec?: int32 @protobuf(2,int32,name=session_timeout_sec,"default=10")
+}
+
+#ProviderConfig: {
+	{} | {
+		etcd: #EtcdConfig @protobuf(1,EtcdConfig)
+	} | {
+		zookeeper: #ZooKeeperConfig @protobuf(2,ZooKeeperConfig)
+	}
+
+	// Key prefix where services register themselves, e.g. "/services/". For
+	// ZooKeeper, it's the parent znode, and all its descendants with data are
+	// discovered.
+	prefix?: string @protobuf(3,string,#"default="/""#)
+
+	// Values are expected to be JSON objects, e.g.
+	//   {"address": "10.1.1.2", "port": 8080, "labels": {"zone": "us-east1"}}
+	// or "host:port" strings. Following fields specify where to find the
+	// resource attributes in the JSON objects. Nested fields are specified
+	// using dots, e.g. "serviceEndpoint.host".
+	addressField?: string @protobuf(4,string,name=address_field,#"default="address""#)
+	portField?:    string @protobuf(5,string,name=port_field,#"default="port""#)
+
+	// Field containing the labels (a JSON object with string values).
+	labelsField?: string @protobuf(6,string,name=labels_field,#"default="labels""#)
+
+	// Field containing the resource name. If not specified, or if the field is
+	// missing, key (relative to the prefix) is used as the resource name.
+	nameField?: string @protobuf(7,string,name=name_field)
+
+	// How often to re-read the keys.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=30")
+}
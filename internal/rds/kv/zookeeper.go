@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/go-zookeeper/zk"
+)
+
+const defaultZKServer = "127.0.0.1:2181"
+
+// zkConn is the subset of the ZooKeeper client that we use.
+type zkConn interface {
+	Children(path string) ([]string, *zk.Stat, error)
+	Get(path string) ([]byte, *zk.Stat, error)
+}
+
+// zkStore lists znodes. ZooKeeper client maintains the session and
+// reconnects on its own.
+type zkStore struct {
+	conn zkConn
+}
+
+// walk adds data of the znode at the given path, and of all its descendants,
+// to kvs.
+func (zs *zkStore) walk(ctx context.Context, p string, kvs map[string][]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, _, err := zs.conn.Get(p)
+	if err != nil {
+		// Znode may have been deleted after we listed its parent.
+		if errors.Is(err, zk.ErrNoNode) {
+			return nil
+		}
+		return fmt.Errorf("error getting znode %s: %v", p, err)
+	}
+	if len(data) != 0 {
+		kvs[p] = data
+	}
+
+	children, _, err := zs.conn.Children(p)
+	if err != nil {
+		if errors.Is(err, zk.ErrNoNode) {
+			return nil
+		}
+		return fmt.Errorf("error listing children of znode %s: %v", p, err)
+	}
+	for _, child := range children {
+		if err := zs.walk(ctx, path.Join(p, child), kvs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (zs *zkStore) list(ctx context.Context, prefix string) (map[string][]byte, error) {
+	root := "/" + strings.Trim(prefix, "/")
+
+	kvs := make(map[string][]byte)
+	if err := zs.walk(ctx, root, kvs); err != nil {
+		return nil, fmt.Errorf("zookeeper: %v", err)
+	}
+	return kvs, nil
+}
+
+// zkLogger adapts our logger to the ZooKeeper client's logger.
+type zkLogger struct {
+	l *logger.Logger
+}
+
+func (zl zkLogger) Printf(format string, args ...interface{}) {
+	zl.l.Debugf("zookeeper: "+format, args...)
+}
+
+func newZKStore(c *configpb.ZooKeeperConfig, l *logger.Logger) (*zkStore, error) {
+	servers := c.GetServer()
+	if len(servers) == 0 {
+		servers = []string{defaultZKServer}
+	}
+
+	conn, _, err := zk.Connect(servers, time.Duration(c.GetSessionTimeoutSec())*time.Second, zk.WithLogger(zkLogger{l}))
+	if err != nil {
+		return nil, fmt.Errorf("zookeeper: error connecting to %v: %v", servers, err)
+	}
+	return &zkStore{conn: conn}, nil
+}
@@ -0,0 +1,521 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package nomad implements a Nomad based resources provider for
+ResourceDiscovery server.
+
+It discovers services registered with Nomad's native service discovery, and
+optionally, running allocations. Unlike the Consul provider, it doesn't need
+Consul; it talks to the Nomad HTTP API directly.
+
+Each service registration becomes a resource named
+<service>_<address>_<port>, with the following labels: service, namespace,
+job, alloc_id, node_id, datacenter and tags (comma separated).
+
+Each allocation port becomes a resource named <allocation>_<port label>, with
+the following labels: namespace, job, task_group, node, alloc_id and port.
+*/
+package nomad
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "nomad"
+
+// ResourceTypes declares resource types supported by the Nomad provider.
+var ResourceTypes = struct {
+	Services, Allocations string
+}{
+	"services",
+	"allocations",
+}
+
+/*
+SupportedFilters defines filters supported by this provider.
+
+	 Example filters:
+	 filter {
+		 key: "name"
+		 value: "web_.*"
+	 }
+	 filter {
+		 key: "namespace"
+		 value: "prod"
+	 }
+	 filter {
+		 key: "service"
+		 value: "web|api"
+	 }
+	 filter {
+		 key: "job"
+		 value: "frontend"
+	 }
+	 filter {
+		 key: "tag"
+		 value: "canary"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the tag filter matches if any of the service's tags matches.
+	[]string{"name", "namespace", "service", "job", "tag"},
+	true,
+}
+
+// serviceRegistration is a service instance, as returned by the services
+// API.
+type serviceRegistration struct {
+	ServiceName string
+	Namespace   string
+	NodeID      string
+	Datacenter  string
+	JobID       string
+	AllocID     string
+	Tags        []string
+	Address     string
+	Port        int
+}
+
+func (sr *serviceRegistration) resource() *pb.Resource {
+	labels := map[string]string{
+		"service":    sr.ServiceName,
+		"namespace":  sr.Namespace,
+		"job":        sr.JobID,
+		"alloc_id":   sr.AllocID,
+		"node_id":    sr.NodeID,
+		"datacenter": sr.Datacenter,
+	}
+	if len(sr.Tags) != 0 {
+		labels["tags"] = strings.Join(sr.Tags, ",")
+	}
+
+	return &pb.Resource{
+		Name:   proto.String(fmt.Sprintf("%s_%s_%d", sr.ServiceName, sr.Address, sr.Port)),
+		Ip:     proto.String(sr.Address),
+		Port:   proto.Int32(int32(sr.Port)),
+		Labels: labels,
+	}
+}
+
+// allocation is an allocation, as returned by the allocations list API.
+type allocation struct {
+	ID                 string
+	Name               string
+	Namespace          string
+	NodeName           string
+	JobID              string
+	TaskGroup          string
+	ClientStatus       string
+	AllocatedResources struct {
+		Shared struct {
+			Networks []struct {
+				IP string
+			}
+			Ports []struct {
+				Label  string
+				Value  int
+				HostIP string
+			}
+		}
+	}
+}
+
+func (a *allocation) resources() []*pb.Resource {
+	var ip string
+	if len(a.AllocatedResources.Shared.Networks) != 0 {
+		ip = a.AllocatedResources.Shared.Networks[0].IP
+	}
+
+	labels := func() map[string]string {
+		return map[string]string{
+			"namespace":  a.Namespace,
+			"job":        a.JobID,
+			"task_group": a.TaskGroup,
+			"node":       a.NodeName,
+			"alloc_id":   a.ID,
+		}
+	}
+
+	ports := a.AllocatedResources.Shared.Ports
+	if len(ports) == 0 {
+		return []*pb.Resource{{
+			Name:   proto.String(a.Name),
+			Ip:     proto.String(ip),
+			Labels: labels(),
+		}}
+	}
+
+	var resources []*pb.Resource
+	for _, p := range ports {
+		portIP := p.HostIP
+		if portIP == "" {
+			portIP = ip
+		}
+		res := &pb.Resource{
+			Name:   proto.String(a.Name + "_" + p.Label),
+			Ip:     proto.String(portIP),
+			Port:   proto.Int32(int32(p.Value)),
+			Labels: labels(),
+		}
+		res.Labels["port"] = p.Label
+		resources = append(resources, res)
+	}
+	return resources
+}
+
+// Provider implements a Nomad provider for use with a ResourceDiscovery
+// server.
+type Provider struct {
+	c          *configpb.ProviderConfig
+	address    string
+	token      string
+	httpClient *http.Client
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	cache       map[string][]*pb.Resource // Keyed by resource type.
+	lastUpdated int64
+}
+
+// query runs a query against the Nomad API, and decodes the response into v.
+func (p *Provider) query(ctx context.Context, path string, params url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Nomad-Token", p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("error parsing response (%s): %v", string(b), err)
+	}
+	return nil
+}
+
+func (p *Provider) namespaces() []string {
+	if len(p.c.GetNamespace()) == 0 {
+		return []string{"*"}
+	}
+	return p.c.GetNamespace()
+}
+
+// hasTags reports whether tags include all the configured tags.
+func (p *Provider) hasTags(tags []string) bool {
+	for _, want := range p.c.GetTag() {
+		found := false
+		for _, t := range tags {
+			if t == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Provider) wantService(name string) bool {
+	if len(p.c.GetService()) == 0 {
+		return true
+	}
+	for _, svc := range p.c.GetService() {
+		if svc == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) listServices(ctx context.Context) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	for _, ns := range p.namespaces() {
+		var nsServices []struct {
+			Namespace string
+			Services  []struct {
+				ServiceName string
+				Tags        []string
+			}
+		}
+		if err := p.query(ctx, "/v1/services", url.Values{"namespace": {ns}}, &nsServices); err != nil {
+			return nil, err
+		}
+
+		for _, nss := range nsServices {
+			for _, svc := range nss.Services {
+				// Tags in the services list are the union of the tags of all
+				// the instances, so we check them again for each instance.
+				if !p.wantService(svc.ServiceName) || !p.hasTags(svc.Tags) {
+					continue
+				}
+
+				var regs []*serviceRegistration
+				if err := p.query(ctx, "/v1/service/"+url.PathEscape(svc.ServiceName), url.Values{"namespace": {nss.Namespace}}, &regs); err != nil {
+					return nil, err
+				}
+				for _, sr := range regs {
+					if p.hasTags(sr.Tags) {
+						resources = append(resources, sr.resource())
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+	return resources, nil
+}
+
+func (p *Provider) listAllocations(ctx context.Context) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	for _, ns := range p.namespaces() {
+		var allocs []*allocation
+		if err := p.query(ctx, "/v1/allocations", url.Values{"namespace": {ns}, "resources": {"true"}}, &allocs); err != nil {
+			return nil, err
+		}
+		for _, a := range allocs {
+			if a.ClientStatus != "running" {
+				continue
+			}
+			resources = append(resources, a.resources()...)
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+	return resources, nil
+}
+
+func resourcesEqual(a, b []*pb.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Provider) refresh(ctx context.Context) {
+	listers := map[string]func(context.Context) ([]*pb.Resource, error){
+		ResourceTypes.Services: p.listServices,
+	}
+	if p.c.GetDiscoverAllocations() {
+		listers[ResourceTypes.Allocations] = p.listAllocations
+	}
+
+	for resType, lister := range listers {
+		resources, err := lister(ctx)
+		if err != nil {
+			// Keep using the existing resources.
+			p.l.Errorf("nomad: error listing %s: %v", resType, err)
+			continue
+		}
+
+		p.mu.Lock()
+		// Update the modification time only if something changed, so that
+		// RDS clients don't have to reprocess the same resources.
+		if _, ok := p.cache[resType]; !ok || !resourcesEqual(p.cache[resType], resources) {
+			p.cache[resType] = resources
+			p.lastUpdated = time.Now().Unix()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// ListResources returns the list of resources from the cache.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	tok := strings.SplitN(req.GetResourcePath(), "/", 2)
+	resType := tok[0]
+	if resType == "" {
+		resType = ResourceTypes.Services
+	}
+	if resType != ResourceTypes.Services && resType != ResourceTypes.Allocations {
+		return nil, fmt.Errorf("nomad: unsupported resource type: %s", resType)
+	}
+	if resType == ResourceTypes.Allocations && !p.c.GetDiscoverAllocations() {
+		return nil, fmt.Errorf("nomad: allocations discovery is not enabled")
+	}
+
+	// Resource path can narrow down the resources to a service (for
+	// services) or a job (for allocations).
+	var pathKey, pathValue string
+	if len(tok) == 2 {
+		pathValue = tok[1]
+		pathKey = "service"
+		if resType == ResourceTypes.Allocations {
+			pathKey = "job"
+		}
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, tagFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["tag"], allFilters.LabelsFilter
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: proto.Int64(p.lastUpdated)}, nil
+	}
+
+	var resources []*pb.Resource
+	for _, res := range p.cache[resType] {
+		if pathValue != "" && res.GetLabels()[pathKey] != pathValue {
+			continue
+		}
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+			continue
+		}
+
+		matched := true
+		for _, key := range []string{"namespace", "service", "job"} {
+			if f := allFilters.RegexFilters[key]; f != nil && !f.Match(res.GetLabels()[key], p.l) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		if tagFilter != nil {
+			matched := false
+			for _, t := range strings.Split(res.GetLabels()["tags"], ",") {
+				if t != "" && tagFilter.Match(t, p.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	p.l.Debugf("nomad.listResources: returning %d resources", len(resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(p.lastUpdated),
+	}, nil
+}
+
+func apiAddress(c *configpb.ProviderConfig) string {
+	addr := c.GetAddress()
+	if addr == "" {
+		addr = os.Getenv("NOMAD_ADDR")
+	}
+	if addr == "" {
+		addr = "http://127.0.0.1:4646"
+	}
+	if !strings.Contains(addr, "://") {
+		scheme := "http"
+		if c.GetTlsConfig() != nil {
+			scheme = "https"
+		}
+		addr = scheme + "://" + addr
+	}
+	return strings.TrimSuffix(addr, "/")
+}
+
+// New creates a Nomad provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("nomad: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	token := c.GetToken()
+	if token == "" {
+		token = os.Getenv("NOMAD_TOKEN")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("nomad: error parsing TLS config: %v", err)
+		}
+	}
+
+	p := &Provider{
+		c:       c,
+		address: apiAddress(c),
+		token:   token,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+		l:     l,
+		cache: make(map[string][]*pb.Resource),
+	}
+
+	ctx := context.Background()
+	p.refresh(ctx)
+	go func() {
+		for range time.Tick(time.Duration(c.GetReEvalSec()) * time.Second) {
+			p.refresh(ctx)
+		}
+	}()
+
+	return p, nil
+}
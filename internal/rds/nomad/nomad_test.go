@@ -0,0 +1,254 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var testResponses = map[string]string{
+	"/v1/services?namespace=*": `[
+		{"Namespace": "default", "Services": [{"ServiceName": "web", "Tags": ["http", "canary"]}, {"ServiceName": "db", "Tags": []}]},
+		{"Namespace": "batch", "Services": [{"ServiceName": "worker", "Tags": ["http"]}]}
+	]`,
+	"/v1/services?namespace=batch": `[
+		{"Namespace": "batch", "Services": [{"ServiceName": "worker", "Tags": ["http"]}]}
+	]`,
+	"/v1/service/web?namespace=default": `[
+		{"ServiceName": "web", "Namespace": "default", "NodeID": "n1", "Datacenter": "dc1", "JobID": "frontend", "AllocID": "a1", "Tags": ["http"], "Address": "10.0.0.1", "Port": 21000},
+		{"ServiceName": "web", "Namespace": "default", "NodeID": "n2", "Datacenter": "dc1", "JobID": "frontend", "AllocID": "a2", "Tags": ["http", "canary"], "Address": "10.0.0.2", "Port": 22000}
+	]`,
+	"/v1/service/db?namespace=default": `[
+		{"ServiceName": "db", "Namespace": "default", "NodeID": "n1", "Datacenter": "dc1", "JobID": "db", "AllocID": "a3", "Address": "10.0.0.1", "Port": 5432}
+	]`,
+	"/v1/service/worker?namespace=batch": `[
+		{"ServiceName": "worker", "Namespace": "batch", "NodeID": "n3", "Datacenter": "dc2", "JobID": "worker", "AllocID": "a4", "Tags": ["http"], "Address": "10.0.0.3", "Port": 8080}
+	]`,
+	"/v1/allocations?namespace=*&resources=true": `[
+		{
+			"ID": "a1", "Name": "frontend.web[0]", "Namespace": "default", "NodeName": "node-1", "JobID": "frontend", "TaskGroup": "web", "ClientStatus": "running",
+			"AllocatedResources": {"Shared": {"Networks": [{"IP": "10.0.0.1"}], "Ports": [{"Label": "http", "Value": 21000, "HostIP": "10.0.0.1"}, {"Label": "admin", "Value": 21001}]}}
+		},
+		{
+			"ID": "a5", "Name": "frontend.web[1]", "Namespace": "default", "NodeName": "node-2", "JobID": "frontend", "TaskGroup": "web", "ClientStatus": "complete"
+		},
+		{
+			"ID": "a6", "Name": "cron.run[0]", "Namespace": "batch", "NodeName": "node-3", "JobID": "cron", "TaskGroup": "run", "ClientStatus": "running",
+			"AllocatedResources": {"Shared": {"Networks": [{"IP": "10.0.0.3"}]}}
+		}
+	]`,
+}
+
+func testProvider(t *testing.T, c *configpb.ProviderConfig) *Provider {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Nomad-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		query, _ := url.QueryUnescape(r.URL.RawQuery)
+		resp, ok := testResponses[r.URL.Path+"?"+query]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	c.Address = proto.String(srv.URL)
+	c.Token = proto.String("test-token")
+	p, err := New(c, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating provider: %v", err)
+	}
+	return p
+}
+
+func resourceNames(resources []*pb.Resource) []string {
+	var names []string
+	for _, res := range resources {
+		names = append(names, res.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestListServices(t *testing.T) {
+	tests := []struct {
+		desc      string
+		conf      *configpb.ProviderConfig
+		resPath   string
+		filters   map[string]string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			desc:      "all",
+			conf:      &configpb.ProviderConfig{},
+			wantNames: []string{"db_10.0.0.1_5432", "web_10.0.0.1_21000", "web_10.0.0.2_22000", "worker_10.0.0.3_8080"},
+		},
+		{
+			desc:      "config_tag",
+			conf:      &configpb.ProviderConfig{Tag: []string{"http"}},
+			wantNames: []string{"web_10.0.0.1_21000", "web_10.0.0.2_22000", "worker_10.0.0.3_8080"},
+		},
+		{
+			desc:      "config_service_and_tags",
+			conf:      &configpb.ProviderConfig{Service: []string{"web"}, Tag: []string{"http", "canary"}},
+			wantNames: []string{"web_10.0.0.2_22000"},
+		},
+		{
+			desc:      "config_namespace",
+			conf:      &configpb.ProviderConfig{Namespace: []string{"batch"}},
+			wantNames: []string{"worker_10.0.0.3_8080"},
+		},
+		{
+			desc:      "resource_path",
+			conf:      &configpb.ProviderConfig{},
+			resPath:   "services/web",
+			wantNames: []string{"web_10.0.0.1_21000", "web_10.0.0.2_22000"},
+		},
+		{
+			desc:      "filters",
+			conf:      &configpb.ProviderConfig{},
+			filters:   map[string]string{"namespace": "default", "tag": "canary|http"},
+			wantNames: []string{"web_10.0.0.1_21000", "web_10.0.0.2_22000"},
+		},
+		{
+			desc:      "job_and_labels_filter",
+			conf:      &configpb.ProviderConfig{},
+			filters:   map[string]string{"job": "frontend", "labels.node_id": "n2"},
+			wantNames: []string{"web_10.0.0.2_22000"},
+		},
+		{
+			desc:    "allocations_not_enabled",
+			conf:    &configpb.ProviderConfig{},
+			resPath: "allocations",
+			wantErr: true,
+		},
+		{
+			desc:    "bad_filter",
+			conf:    &configpb.ProviderConfig{},
+			filters: map[string]string{"zone": "a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p := testProvider(t, test.conf)
+
+			req := &pb.ListResourcesRequest{ResourcePath: proto.String(test.resPath)}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resp, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantNames, resourceNames(resp.GetResources()))
+		})
+	}
+}
+
+func TestServiceResource(t *testing.T) {
+	p := testProvider(t, &configpb.ProviderConfig{Service: []string{"worker"}})
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 1)
+
+	res := resp.GetResources()[0]
+	assert.Equal(t, "10.0.0.3", res.GetIp())
+	assert.Equal(t, int32(8080), res.GetPort())
+	assert.Equal(t, map[string]string{
+		"service":    "worker",
+		"namespace":  "batch",
+		"job":        "worker",
+		"alloc_id":   "a4",
+		"node_id":    "n3",
+		"datacenter": "dc2",
+		"tags":       "http",
+	}, res.GetLabels())
+}
+
+func TestListAllocations(t *testing.T) {
+	p := testProvider(t, &configpb.ProviderConfig{DiscoverAllocations: proto.Bool(true)})
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("allocations")})
+	assert.NoError(t, err)
+
+	got := make(map[string]string)
+	for _, res := range resp.GetResources() {
+		got[res.GetName()] = fmt.Sprintf("%s:%d", res.GetIp(), res.GetPort())
+	}
+	assert.Equal(t, map[string]string{
+		"cron.run[0]":           "10.0.0.3:0",
+		"frontend.web[0]_admin": "10.0.0.1:21001",
+		"frontend.web[0]_http":  "10.0.0.1:21000",
+	}, got)
+
+	resp, err = p.ListResources(&pb.ListResourcesRequest{ResourcePath: proto.String("allocations/cron")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cron.run[0]"}, resourceNames(resp.GetResources()))
+	assert.Equal(t, map[string]string{
+		"namespace":  "batch",
+		"job":        "cron",
+		"task_group": "run",
+		"node":       "node-3",
+		"alloc_id":   "a6",
+	}, resp.GetResources()[0].GetLabels())
+}
+
+func TestIfModifiedSince(t *testing.T) {
+	p := testProvider(t, &configpb.ProviderConfig{})
+	lastUpdated := p.lastUpdated
+
+	// Refreshing with the same data shouldn't update the modification time.
+	p.refresh(context.Background())
+	assert.Equal(t, lastUpdated, p.lastUpdated)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastUpdated)})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+	assert.Equal(t, lastUpdated, resp.GetLastModified())
+}
+
+func TestAPIAddress(t *testing.T) {
+	os.Unsetenv("NOMAD_ADDR")
+	assert.Equal(t, "http://127.0.0.1:4646", apiAddress(&configpb.ProviderConfig{}))
+
+	t.Setenv("NOMAD_ADDR", "nomad.service:4646")
+	assert.Equal(t, "http://nomad.service:4646", apiAddress(&configpb.ProviderConfig{}))
+	assert.Equal(t, "https://nomad:4646", apiAddress(&configpb.ProviderConfig{Address: proto.String("https://nomad:4646/")}))
+}
@@ -0,0 +1,52 @@
+// Configuration proto for Nomad provider.
+//
+// Example provider config:
+// {
+//   address: "http://nomad.service:4646"
+//   namespace: "prod"
+//   tag: "http"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "nomad://services/web"
+//     }
+//   }
+// }
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Nomad provider config.
+#ProviderConfig: {
+	// Nomad HTTP API address, e.g. "http://nomad.service:4646". If not
+	// specified, NOMAD_ADDR environment variable is used, and if that's not set
+	// either, "http://127.0.0.1:4646".
+	address?: string @protobuf(1,string)
+
+	// ACL token. If not specified, NOMAD_TOKEN environment variable is used.
+	token?: string @protobuf(2,string)
+
+	// TLS config to talk to the Nomad API.
+	tlsConfig?: proto.#TLSConfig @protobuf(3,tlsconfig.TLSConfig,name=tls_config)
+
+	// Namespaces to discover services and allocations in. If not specified, all
+	// namespaces are used.
+	namespace?: [...string] @protobuf(4,string)
+
+	// Services to discover. If not specified, all services registered with
+	// Nomad's native service discovery are discovered.
+	service?: [...string] @protobuf(5,string)
+
+	// Only discover services that have all these tags.
+	tag?: [...string] @protobuf(6,string)
+
+	// Discover running allocations as well ("allocations" resource type). Each
+	// allocation port becomes a resource.
+	discoverAllocations?: bool @protobuf(7,bool,name=discover_allocations,"default=false")
+
+	// How often to refresh services and allocations.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=30")
+}
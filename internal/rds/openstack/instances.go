@@ -0,0 +1,288 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/openstack/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+InstancesFilters defines filters supported by the instances resource type.
+Instance metadata is available as labels, so it can be filtered using the
+labels filter.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "availability_zone"
+		 value: "nova"
+	 }
+	 filter {
+		 key: "network"
+		 value: "private"
+	 }
+	 filter {
+		 key: "labels.role"
+		 value: "frontend"
+	 }
+*/
+var InstancesFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the network filter matches if any of the instance's networks
+	// matches.
+	[]string{"name", "availability_zone", "network"},
+	true,
+}
+
+// address is a server address, as returned by the Nova API.
+type address struct {
+	Addr    string
+	Version int
+	Type    string `json:"OS-EXT-IPS:type"`
+}
+
+// server is a Nova server, as returned by the servers detail API.
+type server struct {
+	ID               string
+	Name             string
+	Status           string
+	Metadata         map[string]string
+	AvailabilityZone string `json:"OS-EXT-AZ:availability_zone"`
+	Addresses        map[string][]address
+}
+
+func (s *server) networks() []string {
+	var networks []string
+	for nw := range s.Addresses {
+		networks = append(networks, nw)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// ip returns the server's IP address based on the IP config. NIC index
+// selects the network (in alphabetical order), IP type selects between fixed
+// (DEFAULT) and floating (PUBLIC) addresses.
+func (s *server) ip(ipConfig *pb.IPConfig) (string, error) {
+	networks := s.networks()
+	nicIndex := int(ipConfig.GetNicIndex())
+	if len(networks) <= nicIndex {
+		return "", fmt.Errorf("instance %s doesn't have network with index %d", s.Name, nicIndex)
+	}
+
+	addrType := "fixed"
+	switch ipConfig.GetIpType() {
+	case pb.IPConfig_PUBLIC:
+		addrType = "floating"
+	case pb.IPConfig_ALIAS:
+		return "", fmt.Errorf("instance %s: alias IPs are not supported by OpenStack provider", s.Name)
+	}
+
+	version := 4
+	if ipConfig.GetIpVersion() == pb.IPConfig_IPV6 {
+		version = 6
+	}
+
+	var fallback string
+	for _, addr := range s.Addresses[networks[nicIndex]] {
+		if addr.Type != "" && addr.Type != addrType {
+			continue
+		}
+		if addr.Version == version {
+			return addr.Addr, nil
+		}
+		// If IP version is not specified, use an address of the other
+		// version if there is no IPv4 address.
+		if fallback == "" && ipConfig.GetIpVersion() == pb.IPConfig_IP_VERSION_UNSPECIFIED {
+			fallback = addr.Addr
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("instance %s doesn't have a %s IPv%d address on network %s", s.Name, addrType, version, networks[nicIndex])
+}
+
+// instancesLister is a Nova instances lister. It implements a cache, that's
+// populated at a regular interval by making the Nova API calls. Listing
+// actually only returns the current contents of that cache.
+type instancesLister struct {
+	c      *configpb.Instances
+	client *computeClient
+	l      *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*server
+	lastUpdated int64
+}
+
+func (il *instancesLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), InstancesFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, azFilter, networkFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["availability_zone"], allFilters.RegexFilters["network"], allFilters.LabelsFilter
+
+	il.mu.RLock()
+	defer il.mu.RUnlock()
+
+	for _, s := range il.cache {
+		if nameFilter != nil && !nameFilter.Match(s.Name, il.l) {
+			continue
+		}
+		if azFilter != nil && !azFilter.Match(s.AvailabilityZone, il.l) {
+			continue
+		}
+		if networkFilter != nil {
+			matched := false
+			for _, nw := range s.networks() {
+				if networkFilter.Match(nw, il.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		labels := make(map[string]string, len(s.Metadata)+1)
+		for k, v := range s.Metadata {
+			labels[k] = v
+		}
+		labels["availability_zone"] = s.AvailabilityZone
+
+		if labelsFilter != nil && !labelsFilter.Match(labels, il.l) {
+			continue
+		}
+
+		ip, err := s.ip(req.GetIpConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, &pb.Resource{
+			Name:        proto.String(s.Name),
+			Id:          proto.String(s.ID),
+			Ip:          proto.String(ip),
+			Labels:      labels,
+			LastUpdated: proto.Int64(il.lastUpdated),
+		})
+	}
+
+	il.l.Infof("openstack.instances.listResources: returning %d instances", len(resources))
+	return resources, nil
+}
+
+func (il *instancesLister) fetch(ctx context.Context) ([]*server, error) {
+	var servers []*server
+
+	next := "/servers/detail?status=ACTIVE"
+	for next != "" {
+		b, err := il.client.get(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp struct {
+			Servers      []*server
+			ServersLinks []struct {
+				Rel  string
+				Href string
+			} `json:"servers_links"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			return nil, fmt.Errorf("error parsing servers list: %v", err)
+		}
+
+		for _, s := range resp.Servers {
+			// Status filter is applied by the API as well, but let's not
+			// rely on that.
+			if s.Status == "ACTIVE" {
+				servers = append(servers, s)
+			}
+		}
+
+		next = ""
+		for _, link := range resp.ServersLinks {
+			if link.Rel == "next" {
+				next = link.Href
+			}
+		}
+	}
+
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	return servers, nil
+}
+
+func (il *instancesLister) expand(ctx context.Context) {
+	servers, err := il.fetch(ctx)
+	if err != nil {
+		// Keep using the existing instances.
+		il.l.Errorf("openstack.instances.expand: error while listing instances: %v", err)
+		return
+	}
+
+	il.l.Infof("openstack.instances.expand: got %d instances", len(servers))
+
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	il.cache = servers
+	il.lastUpdated = time.Now().Unix()
+}
+
+func newInstancesLister(c *configpb.Instances, client *computeClient, l *logger.Logger) *instancesLister {
+	il := &instancesLister{
+		c:      c,
+		client: client,
+		l:      l,
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	go func() {
+		ctx := context.Background()
+		il.expand(ctx)
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the API
+		// at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			il.expand(ctx)
+		}
+	}()
+
+	return il
+}
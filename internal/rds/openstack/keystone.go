@@ -0,0 +1,239 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/openstack/proto"
+)
+
+// tokenExpiryDelta is how long before the token expiry we re-authenticate.
+const tokenExpiryDelta = 5 * time.Minute
+
+// authOptions holds the resolved Keystone auth options, i.e. config fields
+// with the OS_* environment variables fallback.
+type authOptions struct {
+	authURL                               string
+	username, password, userDomainName    string
+	projectName, projectID, projectDomain string
+	appCredID, appCredSecret              string
+	region, iface                         string
+}
+
+func configOrEnv(v, envVar string) string {
+	if v != "" {
+		return v
+	}
+	return os.Getenv(envVar)
+}
+
+func newAuthOptions(c *configpb.ProviderConfig) (*authOptions, error) {
+	opts := &authOptions{
+		authURL:        strings.TrimSuffix(configOrEnv(c.GetAuthUrl(), "OS_AUTH_URL"), "/"),
+		username:       configOrEnv(c.GetUsername(), "OS_USERNAME"),
+		password:       configOrEnv(c.GetPassword(), "OS_PASSWORD"),
+		userDomainName: c.GetUserDomainName(),
+		projectName:    configOrEnv(c.GetProjectName(), "OS_PROJECT_NAME"),
+		projectID:      configOrEnv(c.GetProjectId(), "OS_PROJECT_ID"),
+		projectDomain:  c.GetProjectDomainName(),
+		appCredID:      configOrEnv(c.GetApplicationCredentialId(), "OS_APPLICATION_CREDENTIAL_ID"),
+		appCredSecret:  configOrEnv(c.GetApplicationCredentialSecret(), "OS_APPLICATION_CREDENTIAL_SECRET"),
+		region:         configOrEnv(c.GetRegion(), "OS_REGION_NAME"),
+		iface:          c.GetInterface(),
+	}
+
+	// Domain names have non-empty defaults in the config, so environment
+	// variables override the defaults only if fields are not set explicitly.
+	if c.UserDomainName == nil && os.Getenv("OS_USER_DOMAIN_NAME") != "" {
+		opts.userDomainName = os.Getenv("OS_USER_DOMAIN_NAME")
+	}
+	if c.ProjectDomainName == nil && os.Getenv("OS_PROJECT_DOMAIN_NAME") != "" {
+		opts.projectDomain = os.Getenv("OS_PROJECT_DOMAIN_NAME")
+	}
+
+	if opts.authURL == "" {
+		return nil, errors.New("auth_url is not configured and OS_AUTH_URL is not set")
+	}
+	if opts.appCredID == "" && (opts.username == "" || opts.password == "") {
+		return nil, errors.New("neither application credential nor username and password are configured")
+	}
+	return opts, nil
+}
+
+// authRequest returns the body of the Keystone v3 token request.
+func (opts *authOptions) authRequest() map[string]interface{} {
+	if opts.appCredID != "" {
+		// Application credentials are already scoped to a project.
+		return map[string]interface{}{
+			"auth": map[string]interface{}{
+				"identity": map[string]interface{}{
+					"methods": []string{"application_credential"},
+					"application_credential": map[string]string{
+						"id":     opts.appCredID,
+						"secret": opts.appCredSecret,
+					},
+				},
+			},
+		}
+	}
+
+	auth := map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods": []string{"password"},
+			"password": map[string]interface{}{
+				"user": map[string]interface{}{
+					"name":     opts.username,
+					"password": opts.password,
+					"domain":   map[string]string{"name": opts.userDomainName},
+				},
+			},
+		},
+	}
+
+	switch {
+	case opts.projectID != "":
+		auth["scope"] = map[string]interface{}{
+			"project": map[string]string{"id": opts.projectID},
+		}
+	case opts.projectName != "":
+		auth["scope"] = map[string]interface{}{
+			"project": map[string]interface{}{
+				"name":   opts.projectName,
+				"domain": map[string]string{"name": opts.projectDomain},
+			},
+		}
+	}
+
+	return map[string]interface{}{"auth": auth}
+}
+
+type catalogEntry struct {
+	Type      string
+	Endpoints []struct {
+		Interface string
+		Region    string
+		RegionID  string `json:"region_id"`
+		URL       string
+	}
+}
+
+// endpoint returns the endpoint URL for the given service type from the
+// service catalog.
+func endpoint(catalog []catalogEntry, svcType, region, iface string) (string, error) {
+	for _, entry := range catalog {
+		if entry.Type != svcType {
+			continue
+		}
+		for _, ep := range entry.Endpoints {
+			if ep.Interface != iface {
+				continue
+			}
+			if region != "" && ep.Region != region && ep.RegionID != region {
+				continue
+			}
+			return strings.TrimSuffix(ep.URL, "/"), nil
+		}
+	}
+	return "", fmt.Errorf("no %s endpoint found in the service catalog (region: %s, interface: %s)", svcType, region, iface)
+}
+
+// keystoneAuth gets tokens from Keystone and caches them until they are
+// about to expire.
+type keystoneAuth struct {
+	opts       *authOptions
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+	catalog []catalogEntry
+}
+
+func (ka *keystoneAuth) authenticate(ctx context.Context) error {
+	body, err := json.Marshal(ka.opts.authRequest())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ka.opts.authURL+"/auth/tokens", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ka.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("keystone auth failed, HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+
+	var tokenResp struct {
+		Token struct {
+			ExpiresAt time.Time `json:"expires_at"`
+			Catalog   []catalogEntry
+		}
+	}
+	if err := json.Unmarshal(b, &tokenResp); err != nil {
+		return fmt.Errorf("error parsing keystone response: %v", err)
+	}
+
+	token := resp.Header.Get("X-Subject-Token")
+	if token == "" {
+		return errors.New("keystone response is missing the X-Subject-Token header")
+	}
+
+	ka.token, ka.expiry, ka.catalog = token, tokenResp.Token.ExpiresAt, tokenResp.Token.Catalog
+	return nil
+}
+
+// tokenAndCatalog returns a valid token and the service catalog,
+// re-authenticating if required.
+func (ka *keystoneAuth) tokenAndCatalog(ctx context.Context) (string, []catalogEntry, error) {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+
+	if ka.token == "" || time.Now().Add(tokenExpiryDelta).After(ka.expiry) {
+		if err := ka.authenticate(ctx); err != nil {
+			return "", nil, err
+		}
+	}
+	return ka.token, ka.catalog, nil
+}
+
+// invalidate forgets the current token, e.g. after a 401 response.
+func (ka *keystoneAuth) invalidate() {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	ka.token = ""
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package openstack implements an OpenStack resources provider for
+ResourceDiscovery server.
+
+See ResourceTypes variable for the list of supported resource types.
+
+OpenStack provider is configured through a protobuf based config file
+(proto/config.proto). Example config:
+
+	{
+		auth_url: "https://keystone.example.com:5000/v3"
+		project_name: "prod"
+		instances {}
+	}
+*/
+package openstack
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/openstack/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "openstack"
+
+// ResourceTypes declares resource types supported by the OpenStack provider.
+var ResourceTypes = struct {
+	Instances string
+}{
+	"instances",
+}
+
+type lister interface {
+	listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error)
+}
+
+// computeClient makes authenticated requests to the Nova API.
+type computeClient struct {
+	auth       *keystoneAuth
+	endpoint   string // Overrides the endpoint from the service catalog.
+	httpClient *http.Client
+}
+
+func (cc *computeClient) computeURL(catalog []catalogEntry) (string, error) {
+	if cc.endpoint != "" {
+		return cc.endpoint, nil
+	}
+	return endpoint(catalog, "compute", cc.auth.opts.region, cc.auth.opts.iface)
+}
+
+// get fetches the given path, relative to the compute endpoint. Absolute
+// URLs, e.g. pagination links, are used as it is.
+func (cc *computeClient) get(ctx context.Context, path string) ([]byte, error) {
+	token, catalog, err := cc.auth.tokenAndCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	url := path
+	if !strings.Contains(path, "://") {
+		baseURL, err := cc.computeURL(catalog)
+		if err != nil {
+			return nil, err
+		}
+		url = baseURL + path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Auth-Token", token)
+
+	resp, err := cc.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// Token may have been revoked, get a new one next time.
+		cc.auth.invalidate()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return b, nil
+}
+
+// Provider implements an OpenStack provider for a ResourceDiscovery server.
+type Provider struct {
+	listers map[string]lister
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resType := strings.SplitN(req.GetResourcePath(), "/", 2)[0]
+
+	lr := p.listers[resType]
+	if lr == nil {
+		return nil, fmt.Errorf("unknown resource type: %s", resType)
+	}
+
+	resources, err := lr.listResources(req)
+	return &pb.ListResourcesResponse{Resources: resources}, err
+}
+
+// New creates an OpenStack provider for RDS server, based on the provided
+// config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	opts, err := newAuthOptions(c)
+	if err != nil {
+		return nil, fmt.Errorf("rds.openstack.New(): %v", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("rds.openstack.New(): error parsing TLS config: %v", err)
+		}
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+
+	client := &computeClient{
+		auth:       &keystoneAuth{opts: opts, httpClient: httpClient},
+		endpoint:   strings.TrimSuffix(c.GetComputeEndpoint(), "/"),
+		httpClient: httpClient,
+	}
+
+	p := &Provider{
+		listers: make(map[string]lister),
+	}
+
+	if c.GetInstances() != nil {
+		if c.GetInstances().GetReEvalSec() <= 0 {
+			return nil, fmt.Errorf("rds.openstack.New(): invalid re_eval_sec: %d", c.GetInstances().GetReEvalSec())
+		}
+		p.listers[ResourceTypes.Instances] = newInstancesLister(c.GetInstances(), client, l)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,351 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/openstack/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testServersPage1 = `{
+	"servers": [
+		{
+			"id": "id-web-1",
+			"name": "web-1",
+			"status": "ACTIVE",
+			"metadata": {"role": "frontend"},
+			"OS-EXT-AZ:availability_zone": "az1",
+			"addresses": {
+				"private": [
+					{"addr": "10.0.0.5", "version": 4, "OS-EXT-IPS:type": "fixed"},
+					{"addr": "fd00::5", "version": 6, "OS-EXT-IPS:type": "fixed"},
+					{"addr": "203.0.113.5", "version": 4, "OS-EXT-IPS:type": "floating"}
+				],
+				"storage": [
+					{"addr": "192.168.0.5", "version": 4, "OS-EXT-IPS:type": "fixed"}
+				]
+			}
+		},
+		{
+			"id": "id-web-2",
+			"name": "web-2",
+			"status": "SHUTOFF",
+			"addresses": {"private": [{"addr": "10.0.0.6", "version": 4}]}
+		}
+	],
+	"servers_links": [{"rel": "next", "href": "{{.URL}}/compute/v2.1/servers/detail?status=ACTIVE&marker=id-web-2"}]
+}`
+
+const testServersPage2 = `{
+	"servers": [
+		{
+			"id": "id-db-1",
+			"name": "db-1",
+			"status": "ACTIVE",
+			"metadata": {"role": "database"},
+			"OS-EXT-AZ:availability_zone": "az2",
+			"addresses": {
+				"private": [{"addr": "10.0.0.7", "version": 4, "OS-EXT-IPS:type": "fixed"}]
+			}
+		}
+	]
+}`
+
+type fakeOpenStack struct {
+	mu         sync.Mutex
+	authReqs   []map[string]interface{}
+	expiresIn  time.Duration
+	tokenCount int
+}
+
+func (fo *fakeOpenStack) handler(t *testing.T, srvURL func() string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fo.mu.Lock()
+		defer fo.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/identity/v3/auth/tokens" && r.Method == http.MethodPost:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fo.authReqs = append(fo.authReqs, body)
+			fo.tokenCount++
+
+			w.Header().Set("X-Subject-Token", fmt.Sprintf("token-%d", fo.tokenCount))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": {"expires_at": %q, "catalog": [
+				{"type": "identity", "endpoints": [{"interface": "public", "region": "RegionOne", "url": "%s/identity/v3"}]},
+				{"type": "compute", "endpoints": [
+					{"interface": "internal", "region": "RegionOne", "url": "http://internal.invalid"},
+					{"interface": "public", "region": "RegionTwo", "url": "http://region-two.invalid"},
+					{"interface": "public", "region": "RegionOne", "url": "%s/compute/v2.1/"}
+				]}
+			]}}`, time.Now().Add(fo.expiresIn).UTC().Format(time.RFC3339), srvURL(), srvURL())
+
+		case strings.HasPrefix(r.URL.Path, "/compute/v2.1/servers/detail"):
+			if r.Header.Get("X-Auth-Token") != fmt.Sprintf("token-%d", fo.tokenCount) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
+			if r.URL.Query().Get("marker") == "id-web-2" {
+				fmt.Fprint(w, testServersPage2)
+				return
+			}
+			fmt.Fprint(w, strings.ReplaceAll(testServersPage1, "{{.URL}}", srvURL()))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func testClient(t *testing.T, c *configpb.ProviderConfig, fo *fakeOpenStack) *computeClient {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(fo.handler(t, func() string { return srv.URL }))
+	t.Cleanup(srv.Close)
+
+	c.AuthUrl = proto.String(srv.URL + "/identity/v3/")
+	opts, err := newAuthOptions(c)
+	if err != nil {
+		t.Fatalf("Error creating auth options: %v", err)
+	}
+	return &computeClient{
+		auth:       &keystoneAuth{opts: opts, httpClient: http.DefaultClient},
+		httpClient: http.DefaultClient,
+	}
+}
+
+func testConfig() *configpb.ProviderConfig {
+	return &configpb.ProviderConfig{
+		Username:    proto.String("prober"),
+		Password:    proto.String("secret"),
+		ProjectName: proto.String("prod"),
+		Region:      proto.String("RegionOne"),
+	}
+}
+
+func TestListInstances(t *testing.T) {
+	fo := &fakeOpenStack{expiresIn: time.Hour}
+	il := &instancesLister{client: testClient(t, testConfig(), fo), l: &logger.Logger{}}
+	il.expand(context.Background())
+
+	tests := []struct {
+		desc     string
+		filters  map[string]string
+		ipConfig *pb.IPConfig
+		wantIPs  map[string]string
+		wantErr  bool
+	}{
+		{
+			desc:    "all",
+			wantIPs: map[string]string{"web-1": "10.0.0.5", "db-1": "10.0.0.7"},
+		},
+		{
+			desc:    "metadata_filter",
+			filters: map[string]string{"labels.role": "frontend"},
+			wantIPs: map[string]string{"web-1": "10.0.0.5"},
+		},
+		{
+			desc:    "az_and_network_filter",
+			filters: map[string]string{"availability_zone": "az2", "network": "priv.*"},
+			wantIPs: map[string]string{"db-1": "10.0.0.7"},
+		},
+		{
+			desc:     "floating_ip",
+			filters:  map[string]string{"name": "web-.*"},
+			ipConfig: &pb.IPConfig{IpType: pb.IPConfig_PUBLIC.Enum()},
+			wantIPs:  map[string]string{"web-1": "203.0.113.5"},
+		},
+		{
+			desc:     "ipv6",
+			filters:  map[string]string{"name": "web-.*"},
+			ipConfig: &pb.IPConfig{IpVersion: pb.IPConfig_IPV6.Enum()},
+			wantIPs:  map[string]string{"web-1": "fd00::5"},
+		},
+		{
+			desc:     "second_network",
+			filters:  map[string]string{"name": "web-.*"},
+			ipConfig: &pb.IPConfig{NicIndex: proto.Int32(1)},
+			wantIPs:  map[string]string{"web-1": "192.168.0.5"},
+		},
+		{
+			desc:     "no_floating_ip",
+			ipConfig: &pb.IPConfig{IpType: pb.IPConfig_PUBLIC.Enum()},
+			wantErr:  true,
+		},
+		{
+			desc:    "bad_filter",
+			filters: map[string]string{"zone": "a"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &pb.ListResourcesRequest{IpConfig: test.ipConfig}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resources, err := il.listResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			gotIPs := make(map[string]string)
+			for _, res := range resources {
+				gotIPs[res.GetName()] = res.GetIp()
+			}
+			assert.Equal(t, test.wantIPs, gotIPs)
+		})
+	}
+
+	resources, _ := il.listResources(&pb.ListResourcesRequest{Filter: []*pb.Filter{{Key: proto.String("name"), Value: proto.String("db-1")}}})
+	assert.Equal(t, "id-db-1", resources[0].GetId())
+	assert.Equal(t, map[string]string{"role": "database", "availability_zone": "az2"}, resources[0].GetLabels())
+}
+
+func TestKeystoneAuth(t *testing.T) {
+	// Token expires within the expiry delta, so every request should
+	// re-authenticate.
+	fo := &fakeOpenStack{expiresIn: time.Minute}
+	cc := testClient(t, testConfig(), fo)
+
+	for i := 0; i < 2; i++ {
+		_, err := cc.get(context.Background(), "/servers/detail?status=ACTIVE")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, fo.tokenCount)
+
+	wantAuth := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []interface{}{"password"},
+				"password": map[string]interface{}{
+					"user": map[string]interface{}{
+						"name":     "prober",
+						"password": "secret",
+						"domain":   map[string]interface{}{"name": "Default"},
+					},
+				},
+			},
+			"scope": map[string]interface{}{
+				"project": map[string]interface{}{
+					"name":   "prod",
+					"domain": map[string]interface{}{"name": "Default"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, wantAuth, fo.authReqs[0])
+
+	// Long-lived token is cached.
+	fo = &fakeOpenStack{expiresIn: time.Hour}
+	cc = testClient(t, testConfig(), fo)
+	for i := 0; i < 2; i++ {
+		_, err := cc.get(context.Background(), "/servers/detail?status=ACTIVE")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, fo.tokenCount)
+
+	// Revoked token is replaced after a 401.
+	fo.tokenCount++
+	_, err := cc.get(context.Background(), "/servers/detail?status=ACTIVE")
+	assert.Error(t, err)
+	_, err = cc.get(context.Background(), "/servers/detail?status=ACTIVE")
+	assert.NoError(t, err)
+}
+
+func TestApplicationCredentialAuth(t *testing.T) {
+	fo := &fakeOpenStack{expiresIn: time.Hour}
+	cc := testClient(t, &configpb.ProviderConfig{
+		ApplicationCredentialId:     proto.String("app-id"),
+		ApplicationCredentialSecret: proto.String("app-secret"),
+		Region:                      proto.String("RegionOne"),
+	}, fo)
+
+	_, err := cc.get(context.Background(), "/servers/detail?status=ACTIVE")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []interface{}{"application_credential"},
+				"application_credential": map[string]interface{}{
+					"id":     "app-id",
+					"secret": "app-secret",
+				},
+			},
+		},
+	}, fo.authReqs[0])
+}
+
+func TestEndpoint(t *testing.T) {
+	catalog := []catalogEntry{{Type: "compute"}}
+	catalog[0].Endpoints = append(catalog[0].Endpoints, struct {
+		Interface string
+		Region    string
+		RegionID  string `json:"region_id"`
+		URL       string
+	}{Interface: "public", RegionID: "r1", URL: "https://nova/v2.1/"})
+
+	ep, err := endpoint(catalog, "compute", "r1", "public")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://nova/v2.1", ep)
+
+	for _, args := range [][]string{{"compute", "r2", "public"}, {"compute", "", "admin"}, {"network", "", "public"}} {
+		_, err := endpoint(catalog, args[0], args[1], args[2])
+		assert.Error(t, err, args)
+	}
+}
+
+func TestNewAuthOptions(t *testing.T) {
+	t.Setenv("OS_AUTH_URL", "")
+	_, err := newAuthOptions(testConfig())
+	assert.Error(t, err, "missing auth_url")
+
+	t.Setenv("OS_AUTH_URL", "https://keystone/v3")
+	t.Setenv("OS_PASSWORD", "env-secret")
+	t.Setenv("OS_USER_DOMAIN_NAME", "users")
+	opts, err := newAuthOptions(&configpb.ProviderConfig{Username: proto.String("prober")})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://keystone/v3", opts.authURL)
+	assert.Equal(t, "env-secret", opts.password)
+	assert.Equal(t, "users", opts.userDomainName)
+	assert.Equal(t, "public", opts.iface)
+
+	t.Setenv("OS_PASSWORD", "")
+	_, err = newAuthOptions(&configpb.ProviderConfig{Username: proto.String("prober")})
+	assert.Error(t, err, "missing password")
+}
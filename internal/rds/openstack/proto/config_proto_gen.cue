@@ -0,0 +1,79 @@
+// Configuration proto for OpenStack provider.
+//
+// Example provider config:
+// {
+//   auth_url: "https://keystone.example.com:5000/v3"
+//   project_name: "prod"
+//   region: "RegionOne"
+//   instances {}
+// }
+//
+// Credentials can also be provided through the standard OS_* environment
+// variables, e.g. OS_AUTH_URL, OS_USERNAME, OS_PASSWORD, OS_PROJECT_NAME.
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "openstack://instances"
+//       filter {
+//         key: "labels.role"
+//         value: "frontend"
+//       }
+//     }
+//   }
+// }
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Nova instances discovery options. Only ACTIVE instances are discovered.
+// Instance metadata is available as labels.
+#Instances: {
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+// OpenStack provider config.
+#ProviderConfig: {
+	// Keystone (identity v3) endpoint, e.g.
+	// "https://keystone.example.com:5000/v3". Default: OS_AUTH_URL
+	// environment variable.
+	authUrl?: string @protobuf(1,string,name=auth_url)
+
+	// Password authentication. Defaults: OS_USERNAME, OS_PASSWORD and
+	// OS_USER_DOMAIN_NAME environment variables.
+	username?:       string @protobuf(2,string)
+	password?:       string @protobuf(3,string)
+	userDomainName?: string @protobuf(4,string,name=user_domain_name,#"default="Default""#)
+
+	// Project to scope the token to. Defaults: OS_PROJECT_NAME (or
+	// OS_PROJECT_ID) and OS_PROJECT_DOMAIN_NAME environment variables.
+	projectName?:       string @protobuf(5,string,name=project_name)
+	projectId?:         string @protobuf(6,string,name=project_id)
+	projectDomainName?: string @protobuf(7,string,name=project_domain_name,#"default="Default""#)
+
+	// Application credential authentication. If set, it's used instead of
+	// password authentication. Defaults: OS_APPLICATION_CREDENTIAL_ID and
+	// OS_APPLICATION_CREDENTIAL_SECRET environment variables.
+	applicationCredentialId?:     string @protobuf(8,string,name=application_credential_id)
+	applicationCredentialSecret?: string @protobuf(9,string,name=application_credential_secret)
+
+	// Region to pick the compute endpoint for from the service catalog.
+	// Default: OS_REGION_NAME environment variable. If not set, the first
+	// compute endpoint is used.
+	region?: string @protobuf(10,string)
+
+	// Endpoint interface to use: public, internal or admin.
+	interface?: string @protobuf(11,string,#"default="public""#)
+
+	// Compute (Nova) endpoint. If set, it overrides the endpoint from the
+	// service catalog.
+	computeEndpoint?: string @protobuf(12,string,name=compute_endpoint)
+
+	// TLS config to talk to the OpenStack APIs.
+	tlsConfig?: proto.#TLSConfig @protobuf(13,tlsconfig.TLSConfig,name=tls_config)
+
+	// Nova instances discovery.
+	instances?: #Instances @protobuf(14,Instances)
+}
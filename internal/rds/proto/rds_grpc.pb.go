@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ResourceDiscovery_ListResources_FullMethodName = "/cloudprober.rds.ResourceDiscovery/ListResources"
+	ResourceDiscovery_ListResources_FullMethodName  = "/cloudprober.rds.ResourceDiscovery/ListResources"
+	ResourceDiscovery_WatchResources_FullMethodName = "/cloudprober.rds.ResourceDiscovery/WatchResources"
 )
 
 // ResourceDiscoveryClient is the client API for ResourceDiscovery service.
@@ -29,6 +30,11 @@ type ResourceDiscoveryClient interface {
 	// ListResources returns the list of resources matching the URI provided in
 	// the request.
 	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	// WatchResources streams the changes in the resources matching the request.
+	// First response on the stream contains the full list of resources, and
+	// subsequent responses, sent only when resources change, contain only the
+	// changes.
+	WatchResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (ResourceDiscovery_WatchResourcesClient, error)
 }
 
 type resourceDiscoveryClient struct {
@@ -48,6 +54,38 @@ func (c *resourceDiscoveryClient) ListResources(ctx context.Context, in *ListRes
 	return out, nil
 }
 
+func (c *resourceDiscoveryClient) WatchResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (ResourceDiscovery_WatchResourcesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ResourceDiscovery_ServiceDesc.Streams[0], ResourceDiscovery_WatchResources_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceDiscoveryWatchResourcesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceDiscovery_WatchResourcesClient interface {
+	Recv() (*WatchResourcesResponse, error)
+	grpc.ClientStream
+}
+
+type resourceDiscoveryWatchResourcesClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceDiscoveryWatchResourcesClient) Recv() (*WatchResourcesResponse, error) {
+	m := new(WatchResourcesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ResourceDiscoveryServer is the server API for ResourceDiscovery service.
 // All implementations must embed UnimplementedResourceDiscoveryServer
 // for forward compatibility
@@ -55,6 +93,11 @@ type ResourceDiscoveryServer interface {
 	// ListResources returns the list of resources matching the URI provided in
 	// the request.
 	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error)
+	// WatchResources streams the changes in the resources matching the request.
+	// First response on the stream contains the full list of resources, and
+	// subsequent responses, sent only when resources change, contain only the
+	// changes.
+	WatchResources(*ListResourcesRequest, ResourceDiscovery_WatchResourcesServer) error
 	mustEmbedUnimplementedResourceDiscoveryServer()
 }
 
@@ -65,6 +108,9 @@ type UnimplementedResourceDiscoveryServer struct {
 func (UnimplementedResourceDiscoveryServer) ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListResources not implemented")
 }
+func (UnimplementedResourceDiscoveryServer) WatchResources(*ListResourcesRequest, ResourceDiscovery_WatchResourcesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResources not implemented")
+}
 func (UnimplementedResourceDiscoveryServer) mustEmbedUnimplementedResourceDiscoveryServer() {}
 
 // UnsafeResourceDiscoveryServer may be embedded to opt out of forward compatibility for this service.
@@ -96,6 +142,27 @@ func _ResourceDiscovery_ListResources_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceDiscovery_WatchResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListResourcesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceDiscoveryServer).WatchResources(m, &resourceDiscoveryWatchResourcesServer{stream})
+}
+
+type ResourceDiscovery_WatchResourcesServer interface {
+	Send(*WatchResourcesResponse) error
+	grpc.ServerStream
+}
+
+type resourceDiscoveryWatchResourcesServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceDiscoveryWatchResourcesServer) Send(m *WatchResourcesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ResourceDiscovery_ServiceDesc is the grpc.ServiceDesc for ResourceDiscovery service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -108,6 +175,12 @@ var ResourceDiscovery_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ResourceDiscovery_ListResources_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResources",
+			Handler:       _ResourceDiscovery_WatchResources_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "github.com/cloudprober/cloudprober/internal/rds/proto/rds.proto",
 }
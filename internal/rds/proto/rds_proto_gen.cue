@@ -121,3 +121,20 @@ package proto
 	// resources.
 	lastModified?: int64 @protobuf(2,int64,name=last_modified)
 }
+
+#WatchResourcesResponse: {
+	// If true, resources field contains the full list of resources and replaces
+	// any resources received earlier on the stream. It's set for the first
+	// response on a stream.
+	full?: bool @protobuf(1,bool)
+
+	// Resources that were added or updated since the last response (or all the
+	// resources if "full" is set).
+	resources?: [...#Resource] @protobuf(2,Resource)
+
+	// Names of the resources that were removed since the last response.
+	removed?: [...string] @protobuf(3,string)
+
+	// When were resources last modified, if known.
+	lastModified?: int64 @protobuf(4,int64,name=last_modified)
+}
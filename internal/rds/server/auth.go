@@ -0,0 +1,135 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const tokenReloadInterval = time.Minute
+
+// authenticator authenticates the remote RDS requests.
+type authenticator struct {
+	c            *configpb.AuthConfig
+	allowedCerts map[string]bool
+	l            *logger.Logger
+}
+
+func newAuthenticator(c *configpb.AuthConfig, l *logger.Logger) (*authenticator, error) {
+	if len(c.GetTokenFile()) == 0 && len(c.GetAllowedClientCert()) == 0 && !c.GetAllowAnyClientCert() {
+		return nil, fmt.Errorf("rds.server: auth config doesn't specify any authentication method")
+	}
+
+	// Verify early that we can read the token files.
+	for _, f := range c.GetTokenFile() {
+		if _, err := file.ReadWithCache(f, tokenReloadInterval); err != nil {
+			return nil, fmt.Errorf("rds.server: error reading token file (%s): %v", f, err)
+		}
+	}
+
+	a := &authenticator{
+		c:            c,
+		allowedCerts: make(map[string]bool),
+		l:            l,
+	}
+	for _, id := range c.GetAllowedClientCert() {
+		a.allowedCerts[id] = true
+	}
+	return a, nil
+}
+
+func (a *authenticator) tokenAllowed(md metadata.MD) bool {
+	for _, v := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(v, "Bearer ")
+		if !ok || token == "" {
+			continue
+		}
+		for _, f := range a.c.GetTokenFile() {
+			b, err := file.ReadWithCache(f, tokenReloadInterval)
+			if err != nil {
+				a.l.Warningf("rds.server: error reading token file (%s): %v", f, err)
+				continue
+			}
+			if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(string(b))), []byte(token)) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *authenticator) certAllowed(cert *x509.Certificate) bool {
+	if a.c.GetAllowAnyClientCert() || a.allowedCerts[cert.Subject.CommonName] {
+		return true
+	}
+	for _, name := range cert.DNSNames {
+		if a.allowedCerts[name] {
+			return true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if a.allowedCerts[uri.String()] {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns an error if the request in the given context is not
+// allowed. Requests without peer information are local (in-process) requests
+// and are always allowed.
+func (a *authenticator) authenticate(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if len(a.c.GetTokenFile()) > 0 {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && a.tokenAllowed(md) {
+			return nil
+		}
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		// VerifiedChains are populated only if client certificate was verified
+		// against the server's client CAs.
+		for _, chain := range tlsInfo.State.VerifiedChains {
+			if len(chain) > 0 && a.certAllowed(chain[0]) {
+				return nil
+			}
+		}
+	}
+
+	a.l.Warningf("rds.server: unauthenticated request from %v", p.Addr)
+	return status.Error(codes.Unauthenticated, "rds.server: request not authenticated")
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func testPeerContext(token string, cert *x509.Certificate) context.Context {
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}}
+	if cert != nil {
+		p.AuthInfo = credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	return ctx
+}
+
+func TestAuthenticate(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("s3cret\n"), 0600))
+
+	spiffeID, _ := url.Parse("spiffe://example.org/prober")
+	certs := map[string]*x509.Certificate{
+		"cn":  {Subject: pkix.Name{CommonName: "prober-1"}},
+		"dns": {DNSNames: []string{"other", "prober.example.com"}},
+		"uri": {URIs: []*url.URL{spiffeID}},
+	}
+
+	tests := []struct {
+		desc    string
+		conf    *configpb.AuthConfig
+		ctx     context.Context
+		wantErr bool
+	}{
+		{
+			desc: "local_request",
+			conf: &configpb.AuthConfig{TokenFile: []string{tokenFile}},
+			ctx:  context.Background(),
+		},
+		{
+			desc: "valid_token",
+			conf: &configpb.AuthConfig{TokenFile: []string{tokenFile}},
+			ctx:  testPeerContext("s3cret", nil),
+		},
+		{
+			desc:    "invalid_token",
+			conf:    &configpb.AuthConfig{TokenFile: []string{tokenFile}},
+			ctx:     testPeerContext("wrong", nil),
+			wantErr: true,
+		},
+		{
+			desc:    "no_token",
+			conf:    &configpb.AuthConfig{TokenFile: []string{tokenFile}},
+			ctx:     testPeerContext("", nil),
+			wantErr: true,
+		},
+		{
+			desc: "cert_cn",
+			conf: &configpb.AuthConfig{AllowedClientCert: []string{"prober-1"}},
+			ctx:  testPeerContext("", certs["cn"]),
+		},
+		{
+			desc: "cert_dns_san",
+			conf: &configpb.AuthConfig{AllowedClientCert: []string{"prober.example.com"}},
+			ctx:  testPeerContext("", certs["dns"]),
+		},
+		{
+			desc: "cert_uri_san",
+			conf: &configpb.AuthConfig{AllowedClientCert: []string{"spiffe://example.org/prober"}},
+			ctx:  testPeerContext("", certs["uri"]),
+		},
+		{
+			desc:    "cert_not_allowed",
+			conf:    &configpb.AuthConfig{AllowedClientCert: []string{"prober-2"}},
+			ctx:     testPeerContext("", certs["cn"]),
+			wantErr: true,
+		},
+		{
+			desc: "any_cert",
+			conf: &configpb.AuthConfig{AllowAnyClientCert: proto.Bool(true)},
+			ctx:  testPeerContext("", certs["dns"]),
+		},
+		{
+			desc:    "any_cert_no_cert",
+			conf:    &configpb.AuthConfig{AllowAnyClientCert: proto.Bool(true)},
+			ctx:     testPeerContext("s3cret", nil),
+			wantErr: true,
+		},
+		{
+			desc: "token_or_cert",
+			conf: &configpb.AuthConfig{TokenFile: []string{tokenFile}, AllowedClientCert: []string{"prober-2"}},
+			ctx:  testPeerContext("s3cret", certs["cn"]),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			a, err := newAuthenticator(test.conf, &logger.Logger{})
+			assert.NoError(t, err)
+
+			err = a.authenticate(test.ctx)
+			if test.wantErr {
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewAuthenticatorErrors(t *testing.T) {
+	_, err := newAuthenticator(&configpb.AuthConfig{}, &logger.Logger{})
+	assert.Error(t, err, "no auth method")
+
+	_, err = newAuthenticator(&configpb.AuthConfig{TokenFile: []string{filepath.Join(t.TempDir(), "missing")}}, &logger.Logger{})
+	assert.Error(t, err, "missing token file")
+}
+
+func TestListResourcesAuth(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("s3cret"), 0600))
+
+	srv, err := New(context.Background(), &configpb.ServerConf{
+		Auth: &configpb.AuthConfig{TokenFile: []string{tokenFile}},
+	}, map[string]Provider{"test_provider": &testProvider{}}, &logger.Logger{})
+	assert.NoError(t, err)
+
+	req := &pb.ListResourcesRequest{Provider: proto.String("test_provider")}
+
+	_, err = srv.ListResources(testPeerContext("wrong", nil), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = srv.ListResources(testPeerContext("s3cret", nil), req)
+	assert.NoError(t, err)
+}
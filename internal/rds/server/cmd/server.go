@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
 	"net"
 	"os"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/cloudprober/cloudprober/internal/rds/server"
 	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 	"github.com/cloudprober/cloudprober/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -20,8 +23,9 @@ import (
 var (
 	config      = flag.String("config_file", "", "Config file (ServerConf)")
 	addr        = flag.String("addr", ":0", "Port for the gRPC server")
-	tlsCertFile = flag.String("tls_cert_file", ":0", "Port for the gRPC server")
-	tlsKeyFile  = flag.String("tls_key_file", ":0", "Port for the gRPC server")
+	tlsCertFile = flag.String("tls_cert_file", "", "TLS cert file for the gRPC server")
+	tlsKeyFile  = flag.String("tls_key_file", "", "TLS key file for the gRPC server")
+	caCertFile  = flag.String("client_ca_cert_file", "", "CA cert file to verify client certs (mTLS)")
 )
 
 func main() {
@@ -49,11 +53,20 @@ func main() {
 	var serverOpts []grpc.ServerOption
 
 	if *tlsCertFile != "" {
-		creds, err := credentials.NewServerTLSFromFile(*tlsCertFile, *tlsKeyFile)
+		tlsConfig := &tls.Config{}
+		err := tlsconfig.UpdateTLSConfig(tlsConfig, &tlsconfigpb.TLSConfig{
+			TlsCertFile: tlsCertFile,
+			TlsKeyFile:  tlsKeyFile,
+			CaCertFile:  caCertFile,
+		})
 		if err != nil {
 			log.Fatalf("error initializing gRPC server TLS credentials: %v", err)
 		}
-		serverOpts = append(serverOpts, grpc.Creds(creds))
+		if *caCertFile != "" {
+			tlsConfig.ClientCAs = tlsConfig.RootCAs
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
 	grpcServer := grpc.NewServer(serverOpts...)
@@ -4,11 +4,62 @@ import (
 	"github.com/cloudprober/cloudprober/internal/rds/file/proto"
 	proto_1 "github.com/cloudprober/cloudprober/internal/rds/gcp/proto"
 	proto_5 "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	proto_9 "github.com/cloudprober/cloudprober/internal/rds/consul/proto"
+	proto_A "github.com/cloudprober/cloudprober/internal/rds/azure/proto"
+	proto_B "github.com/cloudprober/cloudprober/internal/rds/aws/proto"
+	proto_C "github.com/cloudprober/cloudprober/internal/rds/docker/proto"
+	proto_D "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	proto_E "github.com/cloudprober/cloudprober/internal/rds/openstack/proto"
+	proto_F "github.com/cloudprober/cloudprober/internal/rds/vsphere/proto"
+	proto_G "github.com/cloudprober/cloudprober/internal/rds/tailscale/proto"
+	proto_H "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
 )
 
 #ServerConf: {
 	// List of providers that server supports.
 	provider?: [...#Provider] @protobuf(1,Provider)
+
+	// How often to check providers for changes, for the WatchResources streams.
+	// Checks are cheap for the providers that cache resources and support
+	// if_modified_since (most providers).
+	watchCheckIntervalMsec?: int32 @protobuf(2,int32,name=watch_check_interval_msec,"default=1000")
+
+	// Authentication for the remote RDS requests. If not configured, all
+	// requests are allowed. Note that requests from the local (in-process) RDS
+	// clients are never authenticated.
+	auth?: #AuthConfig @protobuf(3,AuthConfig)
+}
+
+// AuthConfig specifies how RDS server authenticates remote clients. If
+// multiple methods are configured, a request is allowed if it passes any of
+// them.
+#AuthConfig: {
+	// Files containing the allowed bearer tokens, one token per file. Clients
+	// send the token in the "authorization: Bearer <token>" gRPC metadata, e.g.
+	// using:
+	//   rds_server_options {
+	//     oauth_config {
+	//       bearer_token { file: "/etc/cloudprober/rds-token" }
+	//     }
+	//     tls_config {...}
+	//   }
+	// Token files are re-read every minute, so tokens can be rotated without
+	// restarting the server.
+	tokenFile?: [...string] @protobuf(1,string,name=token_file)
+
+	// Allowed client certificate identities. A client is allowed if the subject
+	// common name, or one of the DNS or URI SANs of its verified certificate
+	// matches one of these values. Client certificates are verified by the gRPC
+	// server using the CA configured in its TLS config, e.g.:
+	//   grpc_tls_config {
+	//     ca_cert_file: "/etc/cloudprober/client_ca.crt"
+	//     ...
+	//   }
+	allowedClientCert?: [...string] @protobuf(2,string,name=allowed_client_cert)
+
+	// Allow any client with a verified certificate. This is useful if client CA
+	// is dedicated to RDS clients.
+	allowAnyClientCert?: bool @protobuf(3,bool,name=allow_any_client_cert)
 }
 
 #Provider: {
@@ -21,5 +72,23 @@ import (
 		gcpConfig: proto_1.#ProviderConfig @protobuf(2,gcp.ProviderConfig,name=gcp_config)
 	} | {
 		kubernetesConfig: proto_5.#ProviderConfig @protobuf(3,kubernetes.ProviderConfig,name=kubernetes_config)
+	} | {
+		consulConfig: proto_9.#ProviderConfig @protobuf(5,consul.ProviderConfig,name=consul_config)
+	} | {
+		azureConfig: proto_A.#ProviderConfig @protobuf(6,azure.ProviderConfig,name=azure_config)
+	} | {
+		awsConfig: proto_B.#ProviderConfig @protobuf(7,aws.ProviderConfig,name=aws_config)
+	} | {
+		dockerConfig: proto_C.#ProviderConfig @protobuf(8,docker.ProviderConfig,name=docker_config)
+	} | {
+		nomadConfig: proto_D.#ProviderConfig @protobuf(9,nomad.ProviderConfig,name=nomad_config)
+	} | {
+		openstackConfig: proto_E.#ProviderConfig @protobuf(10,openstack.ProviderConfig,name=openstack_config)
+	} | {
+		vsphereConfig: proto_F.#ProviderConfig @protobuf(11,vsphere.ProviderConfig,name=vsphere_config)
+	} | {
+		tailscaleConfig: proto_G.#ProviderConfig @protobuf(12,tailscale.ProviderConfig,name=tailscale_config)
+	} | {
+		kvConfig: proto_H.#ProviderConfig @protobuf(13,kv.ProviderConfig,name=kv_config)
 	}
 }
@@ -22,21 +22,33 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/cloudprober/cloudprober/internal/rds/aws"
+	"github.com/cloudprober/cloudprober/internal/rds/azure"
+	"github.com/cloudprober/cloudprober/internal/rds/consul"
+	"github.com/cloudprober/cloudprober/internal/rds/docker"
 	"github.com/cloudprober/cloudprober/internal/rds/file"
 	"github.com/cloudprober/cloudprober/internal/rds/gcp"
 	"github.com/cloudprober/cloudprober/internal/rds/kubernetes"
+	"github.com/cloudprober/cloudprober/internal/rds/kv"
+	"github.com/cloudprober/cloudprober/internal/rds/nomad"
+	"github.com/cloudprober/cloudprober/internal/rds/openstack"
 	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/tailscale"
+	"github.com/cloudprober/cloudprober/internal/rds/vsphere"
 	"github.com/cloudprober/cloudprober/logger"
 	"google.golang.org/grpc"
 )
 
 // Server implements a ResourceDiscovery gRPC server.
 type Server struct {
-	providers map[string]Provider
-	l         *logger.Logger
+	providers          map[string]Provider
+	watchCheckInterval time.Duration
+	auth               *authenticator
+	l                  *logger.Logger
 
 	// Required for all gRPC server implementations.
 	spb.UnimplementedResourceDiscoveryServer
@@ -50,6 +62,10 @@ type Provider interface {
 // ListResources implements the ListResources method of the ResourceDiscovery
 // service.
 func (s *Server) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	if err := s.auth.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
 	p := s.providers[req.GetProvider()]
 	if p == nil {
 		return nil, fmt.Errorf("provider %s is not supported", req.GetProvider())
@@ -87,6 +103,78 @@ func (s *Server) initProviders(c *configpb.ServerConf) error {
 			if p, err = kubernetes.New(pc.GetKubernetesConfig(), s.l); err != nil {
 				return err
 			}
+		case *configpb.Provider_AwsConfig:
+			if id == "" {
+				id = aws.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding AWS provider with id: %s", id)
+			if p, err = aws.New(pc.GetAwsConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_AzureConfig:
+			if id == "" {
+				id = azure.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Azure provider with id: %s", id)
+			if p, err = azure.New(pc.GetAzureConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_ConsulConfig:
+			if id == "" {
+				id = consul.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Consul provider with id: %s", id)
+			if p, err = consul.New(pc.GetConsulConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_DockerConfig:
+			if id == "" {
+				id = docker.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Docker provider with id: %s", id)
+			if p, err = docker.New(pc.GetDockerConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_NomadConfig:
+			if id == "" {
+				id = nomad.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Nomad provider with id: %s", id)
+			if p, err = nomad.New(pc.GetNomadConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_OpenstackConfig:
+			if id == "" {
+				id = openstack.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding OpenStack provider with id: %s", id)
+			if p, err = openstack.New(pc.GetOpenstackConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_VsphereConfig:
+			if id == "" {
+				id = vsphere.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding vSphere provider with id: %s", id)
+			if p, err = vsphere.New(pc.GetVsphereConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_TailscaleConfig:
+			if id == "" {
+				id = tailscale.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Tailscale provider with id: %s", id)
+			if p, err = tailscale.New(pc.GetTailscaleConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_KvConfig:
+			if id == "" {
+				id = kv.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding key-value store provider with id: %s", id)
+			if p, err = kv.New(pc.GetKvConfig(), s.l); err != nil {
+				return err
+			}
 		}
 		s.providers[id] = p
 	}
@@ -97,12 +185,19 @@ func (s *Server) initProviders(c *configpb.ServerConf) error {
 // conf.
 func New(initCtx context.Context, c *configpb.ServerConf, providers map[string]Provider, l *logger.Logger) (*Server, error) {
 	srv := &Server{
-		providers: make(map[string]Provider),
-		l:         l,
+		providers:          make(map[string]Provider),
+		watchCheckInterval: time.Duration(c.GetWatchCheckIntervalMsec()) * time.Millisecond,
+		l:                  l,
 	}
 
 	var err error
 
+	if c.GetAuth() != nil {
+		if srv.auth, err = newAuthenticator(c.GetAuth(), l); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = srv.initProviders(c); err != nil {
 		return nil, err
 	}
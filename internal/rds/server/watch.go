@@ -0,0 +1,117 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// resourcesDelta computes the changes between the old and the new resources.
+// It updates the old resources map in place.
+func resourcesDelta(old map[string]*pb.Resource, resources []*pb.Resource) (updated []*pb.Resource, removed []string) {
+	current := make(map[string]bool, len(resources))
+	for _, res := range resources {
+		current[res.GetName()] = true
+		if oldRes, ok := old[res.GetName()]; !ok || !proto.Equal(oldRes, res) {
+			updated = append(updated, res)
+			old[res.GetName()] = res
+		}
+	}
+
+	for name := range old {
+		if !current[name] {
+			removed = append(removed, name)
+			delete(old, name)
+		}
+	}
+	sort.Strings(removed)
+	return updated, removed
+}
+
+// WatchResources implements the WatchResources method of the
+// ResourceDiscovery service. Since providers are not event-driven, we check
+// the provider for changes every watchCheckInterval, and stream the changes
+// to the client.
+func (s *Server) WatchResources(req *pb.ListResourcesRequest, stream spb.ResourceDiscovery_WatchResourcesServer) error {
+	if err := s.auth.authenticate(stream.Context()); err != nil {
+		return err
+	}
+
+	p := s.providers[req.GetProvider()]
+	if p == nil {
+		return fmt.Errorf("provider %s is not supported", req.GetProvider())
+	}
+
+	req = proto.Clone(req).(*pb.ListResourcesRequest)
+	req.IfModifiedSince = nil
+
+	ticker := time.NewTicker(s.watchCheckInterval)
+	defer ticker.Stop()
+
+	var resources map[string]*pb.Resource
+	var lastModified int64
+
+	for {
+		resp, err := p.ListResources(req)
+		if err != nil {
+			// If we haven't sent anything yet, return the error to the client.
+			if resources == nil {
+				return err
+			}
+			s.l.Warningf("rds.server: error listing resources for the watch (provider: %s, resource_path: %s): %v", req.GetProvider(), req.GetResourcePath(), err)
+		}
+
+		// If provider supports last-modified, nothing has changed if
+		// last-modified hasn't changed.
+		if err == nil && (resources == nil || resp.GetLastModified() == 0 || resp.GetLastModified() > lastModified) {
+			if resources == nil {
+				resources = make(map[string]*pb.Resource)
+				resourcesDelta(resources, resp.GetResources())
+				if err := stream.Send(&pb.WatchResourcesResponse{
+					Full:         proto.Bool(true),
+					Resources:    resp.GetResources(),
+					LastModified: proto.Int64(resp.GetLastModified()),
+				}); err != nil {
+					return err
+				}
+			} else if updated, removed := resourcesDelta(resources, resp.GetResources()); len(updated) != 0 || len(removed) != 0 {
+				if err := stream.Send(&pb.WatchResourcesResponse{
+					Resources:    updated,
+					Removed:      removed,
+					LastModified: proto.Int64(resp.GetLastModified()),
+				}); err != nil {
+					return err
+				}
+			}
+
+			lastModified = resp.GetLastModified()
+			if lastModified != 0 {
+				req.IfModifiedSince = proto.Int64(lastModified)
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
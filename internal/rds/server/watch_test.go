@@ -0,0 +1,130 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestResourcesDelta(t *testing.T) {
+	old := make(map[string]*pb.Resource)
+
+	updated, removed := resourcesDelta(old, []*pb.Resource{
+		{Name: proto.String("r1"), Ip: proto.String("10.0.0.1")},
+		{Name: proto.String("r2"), Ip: proto.String("10.0.0.2")},
+	})
+	assert.Len(t, updated, 2)
+	assert.Empty(t, removed)
+
+	updated, removed = resourcesDelta(old, []*pb.Resource{
+		{Name: proto.String("r2"), Ip: proto.String("10.0.0.22")},
+		{Name: proto.String("r3"), Ip: proto.String("10.0.0.3")},
+	})
+	assert.Equal(t, []string{"r2", "r3"}, []string{updated[0].GetName(), updated[1].GetName()})
+	assert.Equal(t, []string{"r1"}, removed)
+
+	updated, removed = resourcesDelta(old, []*pb.Resource{
+		{Name: proto.String("r2"), Ip: proto.String("10.0.0.22")},
+		{Name: proto.String("r3"), Ip: proto.String("10.0.0.3")},
+	})
+	assert.Empty(t, updated)
+	assert.Empty(t, removed)
+}
+
+// testWatchStream implements spb.ResourceDiscovery_WatchResourcesServer.
+type testWatchStream struct {
+	spb.ResourceDiscovery_WatchResourcesServer
+	ctx       context.Context
+	responses chan *pb.WatchResourcesResponse
+}
+
+func (s *testWatchStream) Context() context.Context { return s.ctx }
+
+func (s *testWatchStream) Send(resp *pb.WatchResourcesResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+type mutableProvider struct {
+	mu           sync.Mutex
+	resources    []*pb.Resource
+	lastModified int64
+}
+
+func (p *mutableProvider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resp := &pb.ListResourcesResponse{LastModified: proto.Int64(p.lastModified)}
+	if req.GetIfModifiedSince() < p.lastModified {
+		resp.Resources = p.resources
+	}
+	return resp, nil
+}
+
+func (p *mutableProvider) set(resources []*pb.Resource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resources = resources
+	p.lastModified++
+}
+
+func TestWatchResources(t *testing.T) {
+	tp := &mutableProvider{}
+	tp.set([]*pb.Resource{{Name: proto.String("r1")}, {Name: proto.String("r2")}})
+
+	srv := &Server{
+		providers:          map[string]Provider{"test": tp},
+		watchCheckInterval: 10 * time.Millisecond,
+		l:                  &logger.Logger{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testWatchStream{ctx: ctx, responses: make(chan *pb.WatchResourcesResponse, 10)}
+	errCh := make(chan error)
+	go func() {
+		errCh <- srv.WatchResources(&pb.ListResourcesRequest{Provider: proto.String("test")}, stream)
+	}()
+
+	resp := <-stream.responses
+	assert.True(t, resp.GetFull())
+	assert.Len(t, resp.GetResources(), 2)
+	assert.Equal(t, int64(1), resp.GetLastModified())
+
+	tp.set([]*pb.Resource{{Name: proto.String("r2")}, {Name: proto.String("r3")}})
+	resp = <-stream.responses
+	assert.False(t, resp.GetFull())
+	assert.Len(t, resp.GetResources(), 1)
+	assert.Equal(t, "r3", resp.GetResources()[0].GetName())
+	assert.Equal(t, []string{"r1"}, resp.GetRemoved())
+
+	// No changes, nothing should be sent.
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, stream.responses, 0)
+
+	cancel()
+	assert.NoError(t, <-errCh)
+
+	// Unknown provider.
+	assert.Error(t, srv.WatchResources(&pb.ListResourcesRequest{Provider: proto.String("unknown")}, stream))
+}
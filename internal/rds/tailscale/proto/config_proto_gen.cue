@@ -0,0 +1,50 @@
+// Configuration proto for Tailscale provider.
+//
+// Example provider config:
+// {
+//   tag: "tag:web"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "tailscale://peers"
+//       filter {
+//         key: "os"
+//         value: "linux"
+//       }
+//     }
+//   }
+// }
+package proto
+
+// Tailscale provider config.
+#ProviderConfig: {
+	// Tailscale local API address, e.g.
+	// "unix:///var/run/tailscale/tailscaled.sock" (default) or
+	// "http://localhost:41112" (tailscaled's --debug or a proxy to the local
+	// API). Ignored if wireguard_config is set.
+	address?: string @protobuf(1,string)
+
+	// WireGuard config file to read peers from, e.g. "/etc/wireguard/wg0.conf".
+	// If set, peers are read from this file instead of the Tailscale local API.
+	// Peer names are taken from a "# Name = <name>" comment in the [Peer]
+	// section, and default to the peer's first allowed IP. WireGuard config
+	// doesn't have online status, so all peers are considered online.
+	wireguardConfig?: string @protobuf(2,string,name=wireguard_config)
+
+	// Only discover peers with these tags, e.g. "tag:web". A peer matches if it
+	// has any of the given tags.
+	tag?: [...string] @protobuf(3,string)
+
+	// Include offline peers as well. By default, only peers that are online
+	// according to the coordination server are discovered.
+	includeOffline?: bool @protobuf(4,bool,name=include_offline,"default=false")
+
+	// Include this node itself.
+	includeSelf?: bool @protobuf(5,bool,name=include_self,"default=false")
+
+	// How often to refresh the peers list.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=30")
+}
@@ -0,0 +1,451 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package tailscale implements a resources provider for ResourceDiscovery server,
+that discovers mesh network peers, either through the local Tailscale API or
+from a WireGuard config file.
+
+Tailscale peers are named after their MagicDNS name (without the tailnet
+domain) and carry the following labels: hostname, dns_name, os, online, tags
+(comma separated), and ipv6 (if the peer has an IPv6 address). WireGuard peers
+carry the public_key and endpoint labels.
+*/
+package tailscale
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/tailscale/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "tailscale"
+
+const defaultAddress = "unix:///var/run/tailscale/tailscaled.sock"
+
+// ResourceTypes declares resource types supported by the Tailscale provider.
+var ResourceTypes = struct {
+	Peers string
+}{
+	"peers",
+}
+
+/*
+SupportedFilters defines filters supported by this provider.
+
+	 Example filters:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "tag"
+		 value: "tag:prod"
+	 }
+	 filter {
+		 key: "os"
+		 value: "linux"
+	 }
+	 filter {
+		 key: "labels.online"
+		 value: "true"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	// Note: the tag filter matches if any of the peer's tags matches.
+	[]string{"name", "tag", "os"},
+	true,
+}
+
+// peerStatus is a node's status, as returned by the Tailscale local API.
+type peerStatus struct {
+	ID           string
+	HostName     string
+	DNSName      string
+	OS           string
+	TailscaleIPs []string
+	Tags         []string
+	Online       bool
+}
+
+// status is the Tailscale local API's status response.
+type status struct {
+	Self *peerStatus
+	Peer map[string]*peerStatus
+}
+
+func (ps *peerStatus) name() string {
+	if ps.DNSName != "" {
+		return strings.SplitN(ps.DNSName, ".", 2)[0]
+	}
+	return ps.HostName
+}
+
+func (ps *peerStatus) hasTag(tags []string) bool {
+	for _, want := range tags {
+		for _, tag := range ps.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ps *peerStatus) resource() *pb.Resource {
+	labels := map[string]string{
+		"hostname": ps.HostName,
+		"dns_name": strings.TrimSuffix(ps.DNSName, "."),
+		"os":       ps.OS,
+		"online":   strconv.FormatBool(ps.Online),
+		"tags":     strings.Join(ps.Tags, ","),
+	}
+
+	var ip string
+	for _, addr := range ps.TailscaleIPs {
+		if strings.Contains(addr, ":") {
+			if labels["ipv6"] == "" {
+				labels["ipv6"] = addr
+			}
+			continue
+		}
+		if ip == "" {
+			ip = addr
+		}
+	}
+	if ip == "" {
+		ip = labels["ipv6"]
+	}
+
+	return &pb.Resource{
+		Name:   proto.String(ps.name()),
+		Ip:     proto.String(ip),
+		Id:     proto.String(ps.ID),
+		Labels: labels,
+	}
+}
+
+// parseWireGuardConfig returns resources for the peers in the given
+// WireGuard config.
+func parseWireGuardConfig(b []byte) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+	var peer *pb.Resource
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			peer = nil
+			if strings.EqualFold(line, "[Peer]") {
+				peer = &pb.Resource{Labels: make(map[string]string)}
+				resources = append(resources, peer)
+			}
+			continue
+		}
+		if peer == nil {
+			continue
+		}
+
+		isComment := strings.HasPrefix(line, "#")
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "#"), "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+
+		switch {
+		case isComment && key == "name":
+			peer.Name = proto.String(value)
+		case isComment:
+			continue
+		case key == "publickey":
+			peer.Labels["public_key"] = value
+			peer.Id = proto.String(value)
+		case key == "endpoint":
+			peer.Labels["endpoint"] = value
+		case key == "allowedips":
+			ip, _, _ := strings.Cut(strings.TrimSpace(strings.Split(value, ",")[0]), "/")
+			peer.Ip = proto.String(ip)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, peer := range resources {
+		if peer.GetName() == "" {
+			if peer.GetIp() == "" {
+				return nil, fmt.Errorf("peer (public key: %s) has neither name nor allowed IPs", peer.GetId())
+			}
+			peer.Name = proto.String(peer.GetIp())
+		}
+	}
+	return resources, nil
+}
+
+// Provider implements a Tailscale provider for use with a ResourceDiscovery
+// server.
+type Provider struct {
+	c          *configpb.ProviderConfig
+	baseURL    string
+	httpClient *http.Client
+	l          *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*pb.Resource
+	lastUpdated int64
+}
+
+func (p *Provider) getStatus(ctx context.Context) (*status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/localapi/v0/status", nil)
+	if err != nil {
+		return nil, err
+	}
+	// tailscaled expects this host for the local API requests over the socket.
+	req.Host = "local-tailscaled.sock"
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP response status code: %d, response: %s", resp.StatusCode, string(b))
+	}
+
+	st := &status{}
+	if err := json.Unmarshal(b, st); err != nil {
+		return nil, fmt.Errorf("error parsing response (%s): %v", string(b), err)
+	}
+	return st, nil
+}
+
+func (p *Provider) listPeers(ctx context.Context) ([]*pb.Resource, error) {
+	if p.c.GetWireguardConfig() != "" {
+		b, err := os.ReadFile(p.c.GetWireguardConfig())
+		if err != nil {
+			return nil, err
+		}
+		return parseWireGuardConfig(b)
+	}
+
+	st, err := p.getStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]*peerStatus, 0, len(st.Peer)+1)
+	for _, ps := range st.Peer {
+		peers = append(peers, ps)
+	}
+	if p.c.GetIncludeSelf() && st.Self != nil {
+		peers = append(peers, st.Self)
+	}
+
+	var resources []*pb.Resource
+	for _, ps := range peers {
+		if !ps.Online && !p.c.GetIncludeOffline() {
+			continue
+		}
+		if len(p.c.GetTag()) != 0 && !ps.hasTag(p.c.GetTag()) {
+			continue
+		}
+		resources = append(resources, ps.resource())
+	}
+	return resources, nil
+}
+
+func (p *Provider) refresh(ctx context.Context) {
+	resources, err := p.listPeers(ctx)
+	if err != nil {
+		// Keep using the existing peers.
+		p.l.Errorf("tailscale: error listing peers: %v", err)
+		return
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Update the modification time only if something changed, so that RDS
+	// clients don't have to reprocess the same resources.
+	if p.lastUpdated != 0 && resourcesEqual(p.cache, resources) {
+		return
+	}
+	p.cache = resources
+	p.lastUpdated = time.Now().Unix()
+}
+
+func resourcesEqual(a, b []*pb.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListResources returns the list of resources from the cache.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resType := strings.SplitN(req.GetResourcePath(), "/", 2)[0]
+	if resType != "" && resType != ResourceTypes.Peers {
+		return nil, fmt.Errorf("tailscale: unsupported resource type: %s", resType)
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, tagFilter, osFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["tag"], allFilters.RegexFilters["os"], allFilters.LabelsFilter
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: proto.Int64(p.lastUpdated)}, nil
+	}
+
+	var resources []*pb.Resource
+	for _, res := range p.cache {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+			continue
+		}
+		if osFilter != nil && !osFilter.Match(res.GetLabels()["os"], p.l) {
+			continue
+		}
+		if tagFilter != nil {
+			matched := false
+			for _, tag := range strings.Split(res.GetLabels()["tags"], ",") {
+				if tagFilter.Match(tag, p.l) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	p.l.Debugf("tailscale.listResources: returning %d resources", len(resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(p.lastUpdated),
+	}, nil
+}
+
+// httpClientForAddress returns the base URL and HTTP client to talk to the
+// Tailscale local API at the given address.
+func httpClientForAddress(addr string) (string, *http.Client, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", nil, fmt.Errorf("tailscale: invalid address (%s): %v", addr, err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	switch u.Scheme {
+	case "unix":
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", u.Path)
+		}
+		client.Transport = transport
+		// Host doesn't matter for unix sockets.
+		return "http://local-tailscaled.sock", client, nil
+	case "http", "https":
+		return strings.TrimSuffix(addr, "/"), client, nil
+	default:
+		return "", nil, fmt.Errorf("tailscale: unsupported address scheme (%s) in %s", u.Scheme, addr)
+	}
+}
+
+// New creates a Tailscale provider for RDS server, based on the provided
+// config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("tailscale: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	p := &Provider{
+		c: c,
+		l: l,
+	}
+
+	if c.GetWireguardConfig() != "" {
+		if len(c.GetTag()) != 0 {
+			return nil, fmt.Errorf("tailscale: tag is not supported with wireguard_config")
+		}
+	} else {
+		addr := c.GetAddress()
+		if addr == "" {
+			addr = defaultAddress
+		}
+
+		var err error
+		if p.baseURL, p.httpClient, err = httpClientForAddress(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := context.Background()
+	p.refresh(ctx)
+	go func() {
+		for range time.Tick(time.Duration(c.GetReEvalSec()) * time.Second) {
+			p.refresh(ctx)
+		}
+	}()
+
+	return p, nil
+}
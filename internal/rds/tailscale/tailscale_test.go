@@ -0,0 +1,276 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailscale
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/tailscale/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testStatus = `{
+	"Self": {
+		"ID": "n0",
+		"HostName": "prober",
+		"DNSName": "prober.tail1234.ts.net.",
+		"OS": "linux",
+		"TailscaleIPs": ["100.64.0.1", "fd7a:115c:a1e0::1"],
+		"Online": true
+	},
+	"Peer": {
+		"nodekey:1": {
+			"ID": "n1",
+			"HostName": "web-1",
+			"DNSName": "web-1.tail1234.ts.net.",
+			"OS": "linux",
+			"TailscaleIPs": ["100.64.0.2", "fd7a:115c:a1e0::2"],
+			"Tags": ["tag:web", "tag:prod"],
+			"Online": true
+		},
+		"nodekey:2": {
+			"ID": "n2",
+			"HostName": "My Laptop",
+			"DNSName": "my-laptop.tail1234.ts.net.",
+			"OS": "macOS",
+			"TailscaleIPs": ["100.64.0.3"],
+			"Online": true
+		},
+		"nodekey:3": {
+			"ID": "n3",
+			"HostName": "web-2",
+			"DNSName": "web-2.tail1234.ts.net.",
+			"OS": "linux",
+			"TailscaleIPs": ["100.64.0.4"],
+			"Tags": ["tag:web"],
+			"Online": false
+		}
+	}
+}`
+
+const testWireGuardConfig = `
+[Interface]
+PrivateKey = cHJpdmF0ZQ==
+Address = 10.0.0.1/24
+
+[Peer]
+# Name = db-1
+PublicKey = a2V5MQ==
+AllowedIPs = 10.0.0.2/32, fd00::2/128
+Endpoint = db-1.example.com:51820
+
+[Peer]
+PublicKey = a2V5Mg==
+AllowedIPs = 10.0.0.3/32
+`
+
+func testTailscaleServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/localapi/v0/status" || r.Host != "local-tailscaled.sock" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, testStatus)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func resourceIPs(resources []*pb.Resource) map[string]string {
+	ips := make(map[string]string)
+	for _, res := range resources {
+		ips[res.GetName()] = res.GetIp()
+	}
+	return ips
+}
+
+func TestListResources(t *testing.T) {
+	tests := []struct {
+		desc    string
+		conf    *configpb.ProviderConfig
+		filters map[string]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			desc: "online_peers",
+			conf: &configpb.ProviderConfig{},
+			want: map[string]string{"web-1": "100.64.0.2", "my-laptop": "100.64.0.3"},
+		},
+		{
+			desc: "include_offline_and_self",
+			conf: &configpb.ProviderConfig{IncludeOffline: proto.Bool(true), IncludeSelf: proto.Bool(true)},
+			want: map[string]string{"prober": "100.64.0.1", "web-1": "100.64.0.2", "my-laptop": "100.64.0.3", "web-2": "100.64.0.4"},
+		},
+		{
+			desc: "tag_config",
+			conf: &configpb.ProviderConfig{Tag: []string{"tag:web"}, IncludeOffline: proto.Bool(true)},
+			want: map[string]string{"web-1": "100.64.0.2", "web-2": "100.64.0.4"},
+		},
+		{
+			desc:    "tag_filter",
+			conf:    &configpb.ProviderConfig{},
+			filters: map[string]string{"tag": "tag:prod"},
+			want:    map[string]string{"web-1": "100.64.0.2"},
+		},
+		{
+			desc:    "os_and_name_filter",
+			conf:    &configpb.ProviderConfig{IncludeOffline: proto.Bool(true)},
+			filters: map[string]string{"os": "linux", "name": "web-.*"},
+			want:    map[string]string{"web-1": "100.64.0.2", "web-2": "100.64.0.4"},
+		},
+		{
+			desc:    "labels_filter",
+			conf:    &configpb.ProviderConfig{IncludeOffline: proto.Bool(true)},
+			filters: map[string]string{"labels.online": "false"},
+			want:    map[string]string{"web-2": "100.64.0.4"},
+		},
+		{
+			desc:    "unsupported_filter",
+			conf:    &configpb.ProviderConfig{},
+			filters: map[string]string{"zone": "us"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.conf.Address = proto.String(testTailscaleServer(t).URL)
+			p, err := New(test.conf, &logger.Logger{})
+			assert.NoError(t, err)
+
+			req := &pb.ListResourcesRequest{ResourcePath: proto.String("peers")}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			resp, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, resourceIPs(resp.GetResources()))
+		})
+	}
+}
+
+func TestResourceLabels(t *testing.T) {
+	p, err := New(&configpb.ProviderConfig{Address: proto.String(testTailscaleServer(t).URL)}, &logger.Logger{})
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{Filter: []*pb.Filter{{Key: proto.String("name"), Value: proto.String("web-1")}}})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 1)
+	assert.Equal(t, "n1", resp.GetResources()[0].GetId())
+	assert.Equal(t, map[string]string{
+		"hostname": "web-1",
+		"dns_name": "web-1.tail1234.ts.net",
+		"os":       "linux",
+		"online":   "true",
+		"tags":     "tag:web,tag:prod",
+		"ipv6":     "fd7a:115c:a1e0::2",
+	}, resp.GetResources()[0].GetLabels())
+}
+
+func TestIfModifiedSince(t *testing.T) {
+	p, err := New(&configpb.ProviderConfig{Address: proto.String(testTailscaleServer(t).URL)}, &logger.Logger{})
+	assert.NoError(t, err)
+
+	lastUpdated := p.lastUpdated
+
+	// Refreshing the same peers shouldn't change the modification time.
+	p.refresh(context.Background())
+	assert.Equal(t, lastUpdated, p.lastUpdated)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastUpdated)})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+	assert.Equal(t, lastUpdated, resp.GetLastModified())
+}
+
+func TestUnixSocket(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "tailscaled.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+	srv := httptest.NewUnstartedServer(testTailscaleServer(t).Config.Handler)
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+
+	p, err := New(&configpb.ProviderConfig{Address: proto.String("unix://" + sockPath)}, &logger.Logger{})
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 2)
+}
+
+func TestWireGuardConfig(t *testing.T) {
+	wgConf := filepath.Join(t.TempDir(), "wg0.conf")
+	assert.NoError(t, os.WriteFile(wgConf, []byte(testWireGuardConfig), 0600))
+
+	_, err := New(&configpb.ProviderConfig{WireguardConfig: proto.String(wgConf), Tag: []string{"tag:web"}}, &logger.Logger{})
+	assert.Error(t, err, "tag with wireguard_config")
+
+	p, err := New(&configpb.ProviderConfig{WireguardConfig: proto.String(wgConf)}, &logger.Logger{})
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"db-1": "10.0.0.2", "10.0.0.3": "10.0.0.3"}, resourceIPs(resp.GetResources()))
+	assert.Equal(t, map[string]string{"public_key": "a2V5MQ==", "endpoint": "db-1.example.com:51820"}, resp.GetResources()[1].GetLabels())
+
+	_, err = parseWireGuardConfig([]byte("[Peer]\nPublicKey = a2V5Mw==\n"))
+	assert.Error(t, err, "peer without name and allowed IPs")
+}
+
+func TestHTTPClientForAddress(t *testing.T) {
+	tests := []struct {
+		addr    string
+		wantURL string
+		wantErr bool
+	}{
+		{addr: "unix:///var/run/tailscale/tailscaled.sock", wantURL: "http://local-tailscaled.sock"},
+		{addr: "http://localhost:41112/", wantURL: "http://localhost:41112"},
+		{addr: "tcp://localhost:41112", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr, func(t *testing.T) {
+			baseURL, _, err := httpClientForAddress(test.addr)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantURL, baseURL)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Configuration proto for vSphere provider.
+//
+// Example provider config:
+// {
+//   url: "https://vcenter.example.com/sdk"
+//   virtual_machines {
+//     folder: "/DC0/vm/web"
+//     tag: "env:prod"
+//   }
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "vsphere://virtual_machines"
+//       filter {
+//         key: "labels.role"
+//         value: "frontend"
+//       }
+//     }
+//   }
+// }
+package proto
+
+// Virtual machines discovery options. Only powered on VMs (templates
+// excluded) are discovered. IP addresses are taken from the guest info, so
+// VMware Tools need to be running in the VMs. VM custom attributes are
+// available as labels.
+#VirtualMachines: {
+	// Inventory paths of the folders to discover VMs in, e.g. "/DC0/vm/web".
+	// VMs are discovered recursively. If not specified, all VMs are discovered.
+	folder?: [...string] @protobuf(1,string)
+
+	// Only discover VMs that have all these tags attached. Tags can be
+	// specified as "<category>:<tag>" or just "<tag>". If tags are configured,
+	// attached tags are also available as "tags" label (comma separated
+	// "<category>:<tag>" values). Tags require vCenter (vSphere Automation
+	// API).
+	tag?: [...string] @protobuf(2,string)
+
+	// Only discover VMs with these custom attribute values.
+	customAttribute?: {
+		[string]: string
+	} @protobuf(3,map[string]string,custom_attribute)
+
+	// How often resources should be refreshed.
+	reEvalSec?: int32 @protobuf(98,int32,name=re_eval_sec,"default=300") // default 5 min
+}
+
+// vSphere provider config.
+#ProviderConfig: {
+	// vCenter or ESXi SDK URL, e.g. "https://vcenter.example.com/sdk".
+	// Default: VSPHERE_URL environment variable.
+	url?: string @protobuf(1,string)
+
+	// Credentials. Defaults: VSPHERE_USERNAME and VSPHERE_PASSWORD environment
+	// variables.
+	username?: string @protobuf(2,string)
+	password?: string @protobuf(3,string)
+
+	// Skip verification of the server certificate.
+	insecureSkipVerify?: bool @protobuf(4,bool,name=insecure_skip_verify,"default=false")
+
+	// CA certificates (PEM file) to verify the server certificate with.
+	caCertFile?: string @protobuf(5,string,name=ca_cert_file)
+
+	// Virtual machines discovery.
+	virtualMachines?: #VirtualMachines @protobuf(6,VirtualMachines,name=virtual_machines)
+}
@@ -0,0 +1,391 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/vsphere/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+VMFilters defines filters supported by the virtual_machines resource type. VM
+custom attributes (and tags, if configured) are available as labels, so they
+can be filtered using the labels filter.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.role"
+		 value: "frontend"
+	 }
+*/
+var VMFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+var vmProperties = []string{"name", "runtime.powerState", "config.template", "guest.ipAddress", "guest.net", "customValue"}
+
+// vmInfo represents VMs that we store in cache.
+type vmInfo struct {
+	name      string
+	id        string
+	labels    map[string]string
+	primaryIP string
+	nics      [][]string // IP addresses, per NIC.
+}
+
+// ipFor returns VM's IP address based on the IP config.
+func (vm *vmInfo) ipFor(ipConfig *pb.IPConfig) (string, error) {
+	if ipConfig.GetIpType() != pb.IPConfig_DEFAULT {
+		return "", fmt.Errorf("VM %s: IP type %s is not supported by vSphere provider", vm.name, ipConfig.GetIpType())
+	}
+
+	nicIndex := int(ipConfig.GetNicIndex())
+	if nicIndex == 0 && ipConfig.GetIpVersion() == pb.IPConfig_IP_VERSION_UNSPECIFIED && vm.primaryIP != "" {
+		return vm.primaryIP, nil
+	}
+	if len(vm.nics) <= nicIndex {
+		return "", fmt.Errorf("VM %s doesn't have NIC with index %d", vm.name, nicIndex)
+	}
+
+	ips := vm.nics[nicIndex]
+	for _, ip := range ips {
+		isIPv4 := net.ParseIP(ip).To4() != nil
+		switch ipConfig.GetIpVersion() {
+		case pb.IPConfig_IPV6:
+			if !isIPv4 {
+				return ip, nil
+			}
+		default:
+			if isIPv4 {
+				return ip, nil
+			}
+		}
+	}
+	if ipConfig.GetIpVersion() == pb.IPConfig_IP_VERSION_UNSPECIFIED && len(ips) != 0 {
+		return ips[0], nil
+	}
+	return "", fmt.Errorf("VM %s doesn't have a matching IP address on NIC %d", vm.name, nicIndex)
+}
+
+// vmLister is a vSphere VMs lister. It implements a cache, that's populated
+// at a regular interval by making the vSphere API calls. Listing actually only
+// returns the current contents of that cache.
+type vmLister struct {
+	c       *configpb.VirtualMachines
+	clients clientsFunc
+	l       *logger.Logger
+
+	mu          sync.RWMutex
+	cache       []*vmInfo
+	lastUpdated int64
+}
+
+func (vl *vmLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), VMFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.LabelsFilter
+
+	vl.mu.RLock()
+	defer vl.mu.RUnlock()
+
+	for _, vm := range vl.cache {
+		if nameFilter != nil && !nameFilter.Match(vm.name, vl.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(vm.labels, vl.l) {
+			continue
+		}
+
+		ip, err := vm.ipFor(req.GetIpConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, &pb.Resource{
+			Name:        proto.String(vm.name),
+			Id:          proto.String(vm.id),
+			Ip:          proto.String(ip),
+			Labels:      vm.labels,
+			LastUpdated: proto.Int64(vl.lastUpdated),
+		})
+	}
+
+	vl.l.Infof("vsphere.virtual_machines.listResources: returning %d VMs", len(resources))
+	return resources, nil
+}
+
+// retrieveVMs retrieves VMs from the configured folders, or from the whole
+// inventory if no folder is configured.
+func (vl *vmLister) retrieveVMs(ctx context.Context, vc *vim25.Client) ([]mo.VirtualMachine, error) {
+	roots := []types.ManagedObjectReference{vc.ServiceContent.RootFolder}
+	if len(vl.c.GetFolder()) != 0 {
+		roots = nil
+		finder := find.NewFinder(vc, false)
+		for _, path := range vl.c.GetFolder() {
+			f, err := finder.Folder(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("error finding folder %s: %v", path, err)
+			}
+			roots = append(roots, f.Reference())
+		}
+	}
+
+	var vms []mo.VirtualMachine
+	seen := make(map[string]bool)
+	m := view.NewManager(vc)
+	for _, root := range roots {
+		v, err := m.CreateContainerView(ctx, root, []string{"VirtualMachine"}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		var rootVMs []mo.VirtualMachine
+		err = v.Retrieve(ctx, []string{"VirtualMachine"}, vmProperties, &rootVMs)
+		v.Destroy(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Folders may be nested.
+		for _, vm := range rootVMs {
+			if !seen[vm.Self.Value] {
+				seen[vm.Self.Value] = true
+				vms = append(vms, vm)
+			}
+		}
+	}
+	return vms, nil
+}
+
+// attachedTags returns the tags attached to the VMs, as "<category>:<tag>",
+// keyed by the VM managed object ID.
+func attachedTags(ctx context.Context, rc *rest.Client, vms []mo.VirtualMachine) (map[string][]string, error) {
+	tm := tags.NewManager(rc)
+
+	categories, err := tm.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag categories: %v", err)
+	}
+	categoryNames := make(map[string]string)
+	for _, cat := range categories {
+		categoryNames[cat.ID] = cat.Name
+	}
+
+	refs := make([]mo.Reference, len(vms))
+	for i := range vms {
+		refs[i] = vms[i].Self
+	}
+	attached, err := tm.GetAttachedTagsOnObjects(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attached tags: %v", err)
+	}
+
+	vmTags := make(map[string][]string)
+	for _, at := range attached {
+		for _, t := range at.Tags {
+			id := at.ObjectID.Reference().Value
+			vmTags[id] = append(vmTags[id], categoryNames[t.CategoryID]+":"+t.Name)
+		}
+	}
+	for _, t := range vmTags {
+		sort.Strings(t)
+	}
+	return vmTags, nil
+}
+
+// hasTag reports whether the given "<category>:<tag>" values include the
+// tag, specified as "<category>:<tag>" or just "<tag>".
+func hasTag(vmTags []string, want string) bool {
+	for _, t := range vmTags {
+		if t == want || (!strings.Contains(want, ":") && strings.SplitN(t, ":", 2)[1] == want) {
+			return true
+		}
+	}
+	return false
+}
+
+func (vl *vmLister) vmInfo(vm *mo.VirtualMachine, fieldNames map[int32]string, vmTags []string) *vmInfo {
+	labels := make(map[string]string)
+	for _, cv := range vm.CustomValue {
+		if sv, ok := cv.(*types.CustomFieldStringValue); ok && fieldNames[sv.Key] != "" {
+			labels[fieldNames[sv.Key]] = sv.Value
+		}
+	}
+	if len(vmTags) != 0 {
+		labels["tags"] = strings.Join(vmTags, ",")
+	}
+
+	vi := &vmInfo{
+		name:   vm.Name,
+		id:     vm.Self.Value,
+		labels: labels,
+	}
+
+	if vm.Guest != nil {
+		vi.primaryIP = vm.Guest.IpAddress
+		for _, nic := range vm.Guest.Net {
+			// Skip guest interfaces that are not backed by a virtual
+			// device, e.g. docker bridges.
+			if nic.DeviceConfigId < 0 {
+				continue
+			}
+			var ips []string
+			for _, ip := range nic.IpAddress {
+				if parsed := net.ParseIP(ip); parsed != nil && !parsed.IsLinkLocalUnicast() {
+					ips = append(ips, ip)
+				}
+			}
+			vi.nics = append(vi.nics, ips)
+		}
+	}
+	return vi
+}
+
+func (vl *vmLister) fetch(ctx context.Context) ([]*vmInfo, error) {
+	vc, rc, err := vl.clients(ctx, len(vl.c.GetTag()) != 0)
+	if err != nil {
+		return nil, err
+	}
+
+	vms, err := vl.retrieveVMs(ctx, vc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Custom fields are not available on standalone ESXi hosts.
+	fieldNames := make(map[int32]string)
+	if cfm, err := object.GetCustomFieldsManager(vc); err == nil {
+		fields, err := cfm.Field(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting custom fields: %v", err)
+		}
+		for _, f := range fields {
+			fieldNames[f.Key] = f.Name
+		}
+	}
+
+	var vmTags map[string][]string
+	if rc != nil {
+		if vmTags, err = attachedTags(ctx, rc, vms); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []*vmInfo
+	for i := range vms {
+		vm := &vms[i]
+		if vm.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn || (vm.Config != nil && vm.Config.Template) {
+			continue
+		}
+
+		matched := true
+		for _, t := range vl.c.GetTag() {
+			if !hasTag(vmTags[vm.Self.Value], t) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		vi := vl.vmInfo(vm, fieldNames, vmTags[vm.Self.Value])
+		for k, v := range vl.c.GetCustomAttribute() {
+			if vi.labels[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, vi)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result, nil
+}
+
+func (vl *vmLister) expand(ctx context.Context) {
+	vms, err := vl.fetch(ctx)
+	if err != nil {
+		// Keep using the existing VMs.
+		vl.l.Errorf("vsphere.virtual_machines.expand: error while listing VMs: %v", err)
+		return
+	}
+
+	vl.l.Infof("vsphere.virtual_machines.expand: got %d VMs", len(vms))
+
+	vl.mu.Lock()
+	defer vl.mu.Unlock()
+	vl.cache = vms
+	vl.lastUpdated = time.Now().Unix()
+}
+
+func newVMLister(c *configpb.VirtualMachines, clients clientsFunc, l *logger.Logger) *vmLister {
+	vl := &vmLister{
+		c:       c,
+		clients: clients,
+		l:       l,
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	go func() {
+		ctx := context.Background()
+		vl.expand(ctx)
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the API
+		// at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			vl.expand(ctx)
+		}
+	}()
+
+	return vl
+}
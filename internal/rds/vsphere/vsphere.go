@@ -0,0 +1,221 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package vsphere implements a vSphere resources provider for ResourceDiscovery
+server.
+
+See ResourceTypes variable for the list of supported resource types.
+
+vSphere provider is configured through a protobuf based config file
+(proto/config.proto). Example config:
+
+	{
+		url: "https://vcenter.example.com/sdk"
+		virtual_machines {
+			folder: "/DC0/vm/web"
+		}
+	}
+*/
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/vsphere/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "vsphere"
+
+// ResourceTypes declares resource types supported by the vSphere provider.
+var ResourceTypes = struct {
+	VirtualMachines string
+}{
+	"virtual_machines",
+}
+
+type lister interface {
+	listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error)
+}
+
+// clientsFunc returns the vSphere API clients. REST (vSphere Automation API)
+// client is returned only if withREST is true.
+type clientsFunc func(ctx context.Context, withREST bool) (*vim25.Client, *rest.Client, error)
+
+// connection maintains the vSphere API sessions, logging in again if a
+// session expires.
+type connection struct {
+	u        *url.URL
+	insecure bool
+	caFile   string
+
+	mu sync.Mutex
+	vc *vim25.Client
+	rc *rest.Client
+}
+
+func (conn *connection) vimClient(ctx context.Context) (*vim25.Client, error) {
+	if conn.vc != nil {
+		us, err := session.NewManager(conn.vc).UserSession(ctx)
+		if err == nil && us != nil {
+			return conn.vc, nil
+		}
+		conn.vc, conn.rc = nil, nil
+	}
+
+	sc := soap.NewClient(conn.u, conn.insecure)
+	if conn.caFile != "" {
+		if err := sc.SetRootCAs(conn.caFile); err != nil {
+			return nil, fmt.Errorf("error loading CA certs from %s: %v", conn.caFile, err)
+		}
+	}
+
+	vc, err := vim25.NewClient(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.NewManager(vc).Login(ctx, conn.u.User); err != nil {
+		return nil, fmt.Errorf("error logging in to %s: %v", conn.u.Redacted(), err)
+	}
+
+	conn.vc = vc
+	return vc, nil
+}
+
+func (conn *connection) restClient(ctx context.Context, vc *vim25.Client) (*rest.Client, error) {
+	if conn.rc != nil {
+		s, err := conn.rc.Session(ctx)
+		if err == nil && s != nil {
+			return conn.rc, nil
+		}
+	}
+
+	rc := rest.NewClient(vc)
+	if err := rc.Login(ctx, conn.u.User); err != nil {
+		return nil, fmt.Errorf("error logging in to the vSphere Automation API: %v", err)
+	}
+	conn.rc = rc
+	return rc, nil
+}
+
+func (conn *connection) clients(ctx context.Context, withREST bool) (*vim25.Client, *rest.Client, error) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	vc, err := conn.vimClient(ctx)
+	if err != nil || !withREST {
+		return vc, nil, err
+	}
+
+	rc, err := conn.restClient(ctx, vc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vc, rc, nil
+}
+
+func configOrEnv(v, envVar string) string {
+	if v != "" {
+		return v
+	}
+	return os.Getenv(envVar)
+}
+
+func newConnection(c *configpb.ProviderConfig) (*connection, error) {
+	rawURL := configOrEnv(c.GetUrl(), "VSPHERE_URL")
+	if rawURL == "" {
+		return nil, errors.New("url is not configured and VSPHERE_URL is not set")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := soap.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL (%s): %v", rawURL, err)
+	}
+
+	username := configOrEnv(c.GetUsername(), "VSPHERE_USERNAME")
+	if username == "" && u.User != nil {
+		username = u.User.Username()
+	}
+	password := configOrEnv(c.GetPassword(), "VSPHERE_PASSWORD")
+	if password == "" && u.User != nil {
+		password, _ = u.User.Password()
+	}
+	if username == "" {
+		return nil, errors.New("username is not configured and VSPHERE_USERNAME is not set")
+	}
+	u.User = url.UserPassword(username, password)
+
+	return &connection{
+		u:        u,
+		insecure: c.GetInsecureSkipVerify(),
+		caFile:   c.GetCaCertFile(),
+	}, nil
+}
+
+// Provider implements a vSphere provider for a ResourceDiscovery server.
+type Provider struct {
+	listers map[string]lister
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resType := strings.SplitN(req.GetResourcePath(), "/", 2)[0]
+
+	lr := p.listers[resType]
+	if lr == nil {
+		return nil, fmt.Errorf("unknown resource type: %s", resType)
+	}
+
+	resources, err := lr.listResources(req)
+	return &pb.ListResourcesResponse{Resources: resources}, err
+}
+
+// New creates a vSphere provider for RDS server, based on the provided
+// config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	conn, err := newConnection(c)
+	if err != nil {
+		return nil, fmt.Errorf("rds.vsphere.New(): %v", err)
+	}
+
+	p := &Provider{
+		listers: make(map[string]lister),
+	}
+
+	if c.GetVirtualMachines() != nil {
+		if c.GetVirtualMachines().GetReEvalSec() <= 0 {
+			return nil, fmt.Errorf("rds.vsphere.New(): invalid re_eval_sec: %d", c.GetVirtualMachines().GetReEvalSec())
+		}
+		p.listers[ResourceTypes.VirtualMachines] = newVMLister(c.GetVirtualMachines(), conn.clients, l)
+	}
+
+	return p, nil
+}
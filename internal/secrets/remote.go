@@ -0,0 +1,189 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"golang.org/x/oauth2/google"
+)
+
+// Secret stores' endpoints and clients, overridden in tests.
+var (
+	gcpBaseURL    = "https://secretmanager.googleapis.com/v1"
+	gcpHTTPClient = func(ctx context.Context) (*http.Client, error) {
+		return google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	}
+
+	awsEndpoint = func(region string) string {
+		return "https://secretsmanager." + region + ".amazonaws.com"
+	}
+
+	httpClient = &http.Client{}
+)
+
+// doRequest sends the request and decodes the JSON response into out.
+func doRequest(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, bytes.TrimSpace(b))
+	}
+	return json.Unmarshal(b, out)
+}
+
+// fromGCP reads a secret version from GCP Secret Manager. The path is the
+// secret version's resource name, with the version defaulting to "latest":
+// projects/<project>/secrets/<secret>[/versions/<version>].
+func fromGCP(ctx context.Context, name, key string) (string, error) {
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	client, err := gcpHTTPClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error creating GCP client: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpBaseURL+"/"+name+":access", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := doRequest(client, req, &resp); err != nil {
+		return "", err
+	}
+	b, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("error decoding secret payload: %v", err)
+	}
+	return jsonKey(string(b), key)
+}
+
+// fromAWS reads a secret from AWS Secrets Manager. The path is the secret's
+// name or ARN. Region is taken from the ARN, or from the default AWS config.
+func fromAWS(ctx context.Context, secretID, key string) (string, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error loading AWS config: %v", err)
+	}
+	region := cfg.Region
+	if parts := strings.Split(secretID, ":"); len(parts) > 3 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	if region == "" {
+		return "", errors.New("AWS region not configured")
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, awsEndpoint(region), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting AWS credentials: %v", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), "secretsmanager", region, time.Now()); err != nil {
+		return "", fmt.Errorf("error signing AWS request: %v", err)
+	}
+
+	var resp struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary []byte `json:"SecretBinary"`
+	}
+	if err := doRequest(httpClient, req, &resp); err != nil {
+		return "", err
+	}
+	if resp.SecretString == "" && resp.SecretBinary != nil {
+		return jsonKey(string(resp.SecretBinary), key)
+	}
+	return jsonKey(resp.SecretString, key)
+}
+
+// fromVault reads a secret from HashiCorp Vault, using the VAULT_ADDR and
+// VAULT_TOKEN environment variables. Both KV version 1 and version 2 secrets
+// are supported; for version 2, path includes "data", e.g. secret/data/foo.
+func fromVault(ctx context.Context, path, key string) (string, error) {
+	addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", errors.New("VAULT_ADDR and VAULT_TOKEN environment variables are required for Vault secrets")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := doRequest(httpClient, req, &resp); err != nil {
+		return "", err
+	}
+
+	// KV version 2 nests the secret under data.data.
+	data := resp.Data
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = inner
+		}
+	}
+
+	if key == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("secret has %d keys, select one using #<key>", len(data))
+		}
+		for k := range data {
+			key = k
+		}
+	}
+	return stringValue(data, key)
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package secrets resolves secret references to their values. A secret
+reference has the form secret://<store>/<path>, where store is one of:
+
+	env:   secret://env/DB_PASSWORD
+	file:  secret://file/etc/cloudprober/db_password
+	gcp:   secret://gcp/projects/my-project/secrets/db-password/versions/latest
+	aws:   secret://aws/prod/db-password
+	vault: secret://vault/secret/data/cloudprober#db_password
+
+For the gcp, aws and vault stores, an optional #<key> suffix selects a key
+from the secret's JSON value. Vault secrets are key-value maps, so the key
+is required unless the secret has only one key.
+*/
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix is the prefix of the secret references.
+const Prefix = "secret://"
+
+// Timeout for fetching a secret from a remote store.
+var fetchTimeout = 30 * time.Second
+
+// store fetches a secret. Key, if not empty, selects a key from the secret's
+// JSON value.
+type store func(ctx context.Context, path, key string) (string, error)
+
+var stores = map[string]store{
+	"env":   fromEnv,
+	"file":  fromFile,
+	"gcp":   fromGCP,
+	"aws":   fromAWS,
+	"vault": fromVault,
+}
+
+// Stores that support selecting a key from the secret value.
+var keyedStores = map[string]bool{"gcp": true, "aws": true, "vault": true}
+
+var (
+	resolvedMu sync.Mutex
+	resolved   = make(map[string]string)
+)
+
+// IsReference tells if the value is a secret reference.
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// Resolve resolves the secret reference to the secret value.
+func Resolve(ctx context.Context, ref string) (string, error) {
+	storeName, path, ok := strings.Cut(strings.TrimPrefix(ref, Prefix), "/")
+	if !IsReference(ref) || !ok || path == "" {
+		return "", fmt.Errorf("invalid secret reference: %s, expected format: %s<store>/<path>", ref, Prefix)
+	}
+	fetch := stores[storeName]
+	if fetch == nil {
+		return "", fmt.Errorf("unknown secret store %q in %s", storeName, ref)
+	}
+
+	var key string
+	if keyedStores[storeName] {
+		path, key, _ = strings.Cut(path, "#")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	value, err := fetch(ctx, path, key)
+	if err != nil {
+		return "", fmt.Errorf("error resolving secret %s: %v", ref, err)
+	}
+
+	resolvedMu.Lock()
+	resolved[ref] = value
+	resolvedMu.Unlock()
+	return value, nil
+}
+
+// Resolved returns the values of the secret references resolved so far,
+// keyed by the references. It's used to keep the secret values out of the
+// config shown to the users.
+func Resolved() map[string]string {
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+	m := make(map[string]string, len(resolved))
+	for k, v := range resolved {
+		m[k] = v
+	}
+	return m
+}
+
+// jsonKey returns the key's value from the JSON object. If key is empty,
+// value is returned as is.
+func jsonKey(value, key string) (string, error) {
+	if key == "" {
+		return value, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &m); err != nil {
+		return "", fmt.Errorf("secret value is not a JSON object: %v", err)
+	}
+	return stringValue(m, key)
+}
+
+func stringValue(m map[string]interface{}, key string) (string, error) {
+	v, ok := m[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in the secret", key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func fromEnv(_ context.Context, name, _ string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s not defined", name)
+	}
+	return v, nil
+}
+
+func fromFile(_ context.Context, path, _ string) (string, error) {
+	// References have the form secret://file/<absolute path>, e.g.
+	// secret://file/etc/secret for /etc/secret.
+	b, err := os.ReadFile("/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("TEST_SECRET", "env-value")
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Vault server with a KV v1 and a KV v2 secret.
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/db":
+			fmt.Fprint(w, `{"data": {"password": "v1-value"}}`)
+		case "/v1/secret/data/db":
+			fmt.Fprint(w, `{"data": {"data": {"user": "admin", "password": "v2-value"}, "metadata": {"version": 3}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	// GCP Secret Manager server.
+	gcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/p/secrets/db/versions/latest:access" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"payload": {"data": %q}}`, base64.StdEncoding.EncodeToString([]byte(`{"password": "gcp-value"}`)))
+	}))
+	defer gcp.Close()
+	oldBaseURL, oldClient := gcpBaseURL, gcpHTTPClient
+	defer func() { gcpBaseURL, gcpHTTPClient = oldBaseURL, oldClient }()
+	gcpBaseURL = gcp.URL
+	gcpHTTPClient = func(context.Context) (*http.Client, error) { return gcp.Client(), nil }
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "secret://env/TEST_SECRET", want: "env-value"},
+		{ref: "secret://env/TEST_SECRET_UNDEFINED", wantErr: true},
+		{ref: "secret://file" + secretFile, want: "file-value"},
+		{ref: "secret://file/nonexistent/secret", wantErr: true},
+		{ref: "secret://vault/kv/db", want: "v1-value"},
+		{ref: "secret://vault/secret/data/db#password", want: "v2-value"},
+		{ref: "secret://vault/secret/data/db", wantErr: true}, // Multiple keys.
+		{ref: "secret://vault/secret/data/db#token", wantErr: true},
+		{ref: "secret://vault/kv/unknown", wantErr: true},
+		{ref: "secret://gcp/projects/p/secrets/db#password", want: "gcp-value"},
+		{ref: "secret://gcp/projects/p/secrets/db", want: `{"password": "gcp-value"}`},
+		{ref: "secret://unknown/foo", wantErr: true},
+		{ref: "secret://env", wantErr: true},
+		{ref: "env/TEST_SECRET", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := Resolve(context.Background(), tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, Resolved()[tt.ref])
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"time"
 
@@ -57,10 +58,80 @@ var (
 	msgPattern = []byte("cloudprober")
 )
 
-// Echo reflects back the incoming message.
+// responseBlob returns the response blob for the echo RPCs: blob of the
+// given size, or server's response_size, or the request blob itself.
+func (s *Server) responseBlob(reqBlob []byte, size int32) ([]byte, error) {
+	if size == 0 {
+		size = s.c.GetResponseSize()
+	}
+	if size == 0 {
+		return reqBlob, nil
+	}
+	if size < 0 || size > int32(maxMsgSize) {
+		return nil, fmt.Errorf("response size (%d) should be between 0 and max size (%d)", size, maxMsgSize)
+	}
+	return s.msg[0:size], nil
+}
+
+// Echo reflects back the incoming message, or responds with a blob of the
+// configured response size.
 // TODO: return error if EchoMessage is greater than maxMsgSize.
 func (s *Server) Echo(ctx context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
-	return req, nil
+	if s.c.GetResponseSize() == 0 {
+		return req, nil
+	}
+	blob, err := s.responseBlob(req.GetBlob(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EchoMessage{Blob: blob}, nil
+}
+
+// ServerStreamEcho sends request's count responses, interval_ms apart.
+func (s *Server) ServerStreamEcho(req *pb.StreamRequest, stream pb.Prober_ServerStreamEchoServer) error {
+	if req.GetCount() < 1 {
+		return fmt.Errorf("invalid count (%d), should be at least 1", req.GetCount())
+	}
+	blob, err := s.responseBlob(req.GetBlob(), req.GetResponseSize())
+	if err != nil {
+		return err
+	}
+
+	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
+	for seq := int64(0); seq < int64(req.GetCount()); seq++ {
+		if seq > 0 && interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			}
+		}
+		if err := stream.Send(&pb.StreamResponse{Blob: blob, Seq: proto.Int64(seq)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BidiStreamEcho sends a response for every request in the stream, until the
+// client closes the stream.
+func (s *Server) BidiStreamEcho(stream pb.Prober_BidiStreamEchoServer) error {
+	for seq := int64(0); ; seq++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		blob, err := s.responseBlob(req.GetBlob(), req.GetResponseSize())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.StreamResponse{Blob: blob, Seq: proto.Int64(seq)}); err != nil {
+			return err
+		}
+	}
 }
 
 // BlobRead returns a blob of data.
@@ -95,6 +166,9 @@ func (s *Server) BlobWrite(ctx context.Context, req *pb.BlobWriteRequest) (*pb.B
 
 // New returns a Server.
 func New(initCtx context.Context, c *configpb.ServerConf, l *logger.Logger) (*Server, error) {
+	if c.GetResponseSize() < 0 || int(c.GetResponseSize()) > maxMsgSize {
+		return nil, fmt.Errorf("response_size (%d) should be between 0 and max size (%d)", c.GetResponseSize(), maxMsgSize)
+	}
 	srv := &Server{
 		c: c,
 		l: l,
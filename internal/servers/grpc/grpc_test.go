@@ -18,6 +18,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
 	"reflect"
 	"sync"
@@ -241,3 +242,100 @@ func TestSizeError(t *testing.T) {
 	}
 
 }
+
+func TestStreamingEcho(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// global config setup is necessary for gRPC probe server.
+	if _, err := globalGRPCServer(); err != nil {
+		t.Fatalf("Error initializing global config: %v", err)
+	}
+	cfg := &configpb.ServerConf{
+		Port:         proto.Int32(0),
+		ResponseSize: proto.Int32(16),
+	}
+
+	srv, err := New(ctx, cfg, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Unable to create grpc server: %v", err)
+	}
+	go srv.Start(ctx, nil)
+
+	listenAddr := srv.ln.Addr().String()
+	conn, err := grpc.Dial(listenAddr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Unable to connect to grpc server at %v: %v", listenAddr, err)
+	}
+
+	client := spb.NewProberClient(conn)
+	timedCtx, timedCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer timedCancel()
+
+	// Echo responds with the configured response size.
+	echoResp, err := client.Echo(timedCtx, &pb.EchoMessage{Blob: []byte("test message")})
+	if err != nil {
+		t.Errorf("Echo call error: %v", err)
+	}
+	if len(echoResp.GetBlob()) != 16 {
+		t.Errorf("Echo response size: got %d want %d", len(echoResp.GetBlob()), 16)
+	}
+
+	// Server streaming: request's response_size overrides the server's.
+	stream, err := client.ServerStreamEcho(timedCtx, &pb.StreamRequest{
+		ResponseSize: proto.Int32(8),
+		Count:        proto.Int32(3),
+		IntervalMs:   proto.Int32(10),
+	})
+	if err != nil {
+		t.Fatalf("ServerStreamEcho call error: %v", err)
+	}
+	for i := int64(0); ; i++ {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			if i != 3 {
+				t.Errorf("ServerStreamEcho responses: got %d want %d", i, 3)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("ServerStreamEcho recv error: %v", err)
+		}
+		if resp.GetSeq() != i || len(resp.GetBlob()) != 8 {
+			t.Errorf("ServerStreamEcho response: got seq=%d, size=%d, want seq=%d, size=%d", resp.GetSeq(), len(resp.GetBlob()), i, 8)
+		}
+	}
+
+	stream, err = client.ServerStreamEcho(timedCtx, &pb.StreamRequest{ResponseSize: proto.Int32(int32(maxMsgSize + 1))})
+	if err == nil {
+		if _, err = stream.Recv(); err == nil {
+			t.Error("ServerStreamEcho unexpectedly succeeded for large response size")
+		}
+	}
+
+	// Bidirectional streaming.
+	bidi, err := client.BidiStreamEcho(timedCtx)
+	if err != nil {
+		t.Fatalf("BidiStreamEcho call error: %v", err)
+	}
+	for i, size := range []int32{0, 32} {
+		if err := bidi.Send(&pb.StreamRequest{ResponseSize: proto.Int32(size)}); err != nil {
+			t.Fatalf("BidiStreamEcho send error: %v", err)
+		}
+		resp, err := bidi.Recv()
+		if err != nil {
+			t.Fatalf("BidiStreamEcho recv error: %v", err)
+		}
+		wantSize := int(size)
+		if size == 0 {
+			wantSize = 16
+		}
+		if resp.GetSeq() != int64(i) || len(resp.GetBlob()) != wantSize {
+			t.Errorf("BidiStreamEcho response: got seq=%d, size=%d, want seq=%d, size=%d", resp.GetSeq(), len(resp.GetBlob()), i, wantSize)
+		}
+	}
+	bidi.CloseSend()
+	if _, err := bidi.Recv(); err != io.EOF {
+		t.Errorf("BidiStreamEcho: got %v, want io.EOF after close", err)
+	}
+}
@@ -33,6 +33,11 @@ type ServerConf struct {
 	// to handle probes. Otherwise, attempt to reuse gRPC server from runconfig
 	// if that was set.
 	UseDedicatedServer *bool `protobuf:"varint,3,opt,name=use_dedicated_server,json=useDedicatedServer,def=1" json:"use_dedicated_server,omitempty"`
+	// Response payload size for the echo RPCs (Echo, ServerStreamEcho and
+	// BidiStreamEcho). If set, responses carry a payload of this size, instead
+	// of the request's payload. Streaming requests can override it using their
+	// response_size field. Max size is 1MB.
+	ResponseSize *int32 `protobuf:"varint,4,opt,name=response_size,json=responseSize" json:"response_size,omitempty"`
 }
 
 // Default values for ServerConf fields.
@@ -95,6 +100,13 @@ func (x *ServerConf) GetUseDedicatedServer() bool {
 	return Default_ServerConf_UseDedicatedServer
 }
 
+func (x *ServerConf) GetResponseSize() int32 {
+	if x != nil && x.ResponseSize != nil {
+		return *x.ResponseSize
+	}
+	return 0
+}
+
 var File_github_com_cloudprober_cloudprober_internal_servers_grpc_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_internal_servers_grpc_proto_config_proto_rawDesc = []byte{
@@ -104,7 +116,7 @@ var file_github_com_cloudprober_cloudprober_internal_servers_grpc_proto_config_p
 	0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x63,
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x73, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x22, 0x92, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76,
+	0x72, 0x73, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x22, 0xb7, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x33, 0x31, 0x34, 0x32, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
 	0x12, 0x32, 0x0a, 0x11, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x6c, 0x65,
@@ -113,11 +125,14 @@ var file_github_com_cloudprober_cloudprober_internal_servers_grpc_proto_config_p
 	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x14, 0x75, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x64, 0x69,
 	0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x12, 0x75, 0x73, 0x65, 0x44, 0x65, 0x64,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x42, 0x40, 0x5a, 0x3e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
 }
 
 var (
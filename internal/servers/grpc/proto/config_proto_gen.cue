@@ -11,4 +11,10 @@ package proto
 	// to handle probes. Otherwise, attempt to reuse gRPC server from runconfig
 	// if that was set.
 	useDedicatedServer?: bool @protobuf(3,bool,name=use_dedicated_server,default)
+
+	// Response payload size for the echo RPCs (Echo, ServerStreamEcho and
+	// BidiStreamEcho). If set, responses carry a payload of this size, instead
+	// of the request's payload. Streaming requests can override it using their
+	// response_size field. Max size is 1MB.
+	responseSize?: int32 @protobuf(4,int32,name=response_size)
 }
@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Prober_Echo_FullMethodName         = "/cloudprober.servers.grpc.Prober/Echo"
-	Prober_BlobRead_FullMethodName     = "/cloudprober.servers.grpc.Prober/BlobRead"
-	Prober_ServerStatus_FullMethodName = "/cloudprober.servers.grpc.Prober/ServerStatus"
-	Prober_BlobWrite_FullMethodName    = "/cloudprober.servers.grpc.Prober/BlobWrite"
+	Prober_Echo_FullMethodName             = "/cloudprober.servers.grpc.Prober/Echo"
+	Prober_BlobRead_FullMethodName         = "/cloudprober.servers.grpc.Prober/BlobRead"
+	Prober_ServerStatus_FullMethodName     = "/cloudprober.servers.grpc.Prober/ServerStatus"
+	Prober_BlobWrite_FullMethodName        = "/cloudprober.servers.grpc.Prober/BlobWrite"
+	Prober_ServerStreamEcho_FullMethodName = "/cloudprober.servers.grpc.Prober/ServerStreamEcho"
+	Prober_BidiStreamEcho_FullMethodName   = "/cloudprober.servers.grpc.Prober/BidiStreamEcho"
 )
 
 // ProberClient is the client API for Prober service.
@@ -37,6 +39,10 @@ type ProberClient interface {
 	ServerStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	// BlobWrite allows client to write a blob to the server.
 	BlobWrite(ctx context.Context, in *BlobWriteRequest, opts ...grpc.CallOption) (*BlobWriteResponse, error)
+	// ServerStreamEcho sends request's count responses, interval_ms apart.
+	ServerStreamEcho(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Prober_ServerStreamEchoClient, error)
+	// BidiStreamEcho sends a response for every request in the stream.
+	BidiStreamEcho(ctx context.Context, opts ...grpc.CallOption) (Prober_BidiStreamEchoClient, error)
 }
 
 type proberClient struct {
@@ -83,6 +89,69 @@ func (c *proberClient) BlobWrite(ctx context.Context, in *BlobWriteRequest, opts
 	return out, nil
 }
 
+func (c *proberClient) ServerStreamEcho(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Prober_ServerStreamEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prober_ServiceDesc.Streams[0], Prober_ServerStreamEcho_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proberServerStreamEchoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Prober_ServerStreamEchoClient interface {
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type proberServerStreamEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *proberServerStreamEchoClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *proberClient) BidiStreamEcho(ctx context.Context, opts ...grpc.CallOption) (Prober_BidiStreamEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Prober_ServiceDesc.Streams[1], Prober_BidiStreamEcho_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proberBidiStreamEchoClient{stream}
+	return x, nil
+}
+
+type Prober_BidiStreamEchoClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type proberBidiStreamEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *proberBidiStreamEchoClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *proberBidiStreamEchoClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ProberServer is the server API for Prober service.
 // All implementations must embed UnimplementedProberServer
 // for forward compatibility
@@ -95,6 +164,10 @@ type ProberServer interface {
 	ServerStatus(context.Context, *StatusRequest) (*StatusResponse, error)
 	// BlobWrite allows client to write a blob to the server.
 	BlobWrite(context.Context, *BlobWriteRequest) (*BlobWriteResponse, error)
+	// ServerStreamEcho sends request's count responses, interval_ms apart.
+	ServerStreamEcho(*StreamRequest, Prober_ServerStreamEchoServer) error
+	// BidiStreamEcho sends a response for every request in the stream.
+	BidiStreamEcho(Prober_BidiStreamEchoServer) error
 	mustEmbedUnimplementedProberServer()
 }
 
@@ -114,6 +187,12 @@ func (UnimplementedProberServer) ServerStatus(context.Context, *StatusRequest) (
 func (UnimplementedProberServer) BlobWrite(context.Context, *BlobWriteRequest) (*BlobWriteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlobWrite not implemented")
 }
+func (UnimplementedProberServer) ServerStreamEcho(*StreamRequest, Prober_ServerStreamEchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method ServerStreamEcho not implemented")
+}
+func (UnimplementedProberServer) BidiStreamEcho(Prober_BidiStreamEchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method BidiStreamEcho not implemented")
+}
 func (UnimplementedProberServer) mustEmbedUnimplementedProberServer() {}
 
 // UnsafeProberServer may be embedded to opt out of forward compatibility for this service.
@@ -199,6 +278,53 @@ func _Prober_BlobWrite_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Prober_ServerStreamEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProberServer).ServerStreamEcho(m, &proberServerStreamEchoServer{stream})
+}
+
+type Prober_ServerStreamEchoServer interface {
+	Send(*StreamResponse) error
+	grpc.ServerStream
+}
+
+type proberServerStreamEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *proberServerStreamEchoServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Prober_BidiStreamEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProberServer).BidiStreamEcho(&proberBidiStreamEchoServer{stream})
+}
+
+type Prober_BidiStreamEchoServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type proberBidiStreamEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *proberBidiStreamEchoServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *proberBidiStreamEchoServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Prober_ServiceDesc is the grpc.ServiceDesc for Prober service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -223,6 +349,18 @@ var Prober_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Prober_BlobWrite_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerStreamEcho",
+			Handler:       _Prober_ServerStreamEcho_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BidiStreamEcho",
+			Handler:       _Prober_BidiStreamEcho_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "github.com/cloudprober/cloudprober/internal/servers/grpc/proto/grpcservice.proto",
 }
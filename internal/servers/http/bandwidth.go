@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudprober/cloudprober/probes/probeutils"
+)
+
+const (
+	maxDownloadSize  = 1 << 30
+	bandwidthBufSize = 64 * 1024
+)
+
+// bandwidthBuf is the payload buffer used for the downloads. It's read-only
+// after initialization, so it's shared across requests.
+var bandwidthBuf = func() []byte {
+	b := make([]byte, bandwidthBufSize)
+	probeutils.PatternPayload(b, []byte("cloudprober"))
+	return b
+}()
+
+// downloadHandler streams a payload of the requested size:
+// /download?size=<bytes>.
+func (s *Server) downloadHandler(w http.ResponseWriter, r *http.Request) {
+	size, err := queryInt(r.URL.Query(), "size", bandwidthBufSize, 0, maxDownloadSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	for size > 0 {
+		n := min(size, bandwidthBufSize)
+		if _, err := w.Write(bandwidthBuf[:n]); err != nil {
+			return
+		}
+		size -= n
+	}
+}
+
+// UploadResult is the response of the upload endpoint.
+type UploadResult struct {
+	Bytes      int64   `json:"bytes"`
+	DurationMs float64 `json:"duration_ms"`
+	// Throughput in megabits per second, as measured by the server.
+	ThroughputMbps float64 `json:"throughput_mbps"`
+}
+
+// uploadHandler reads the request body and responds with the number of bytes
+// received and the time taken to receive them, measured from the first byte
+// of the body.
+func (s *Server) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	buf := make([]byte, bandwidthBufSize)
+	var res UploadResult
+	var start time.Time
+	for {
+		n, err := r.Body.Read(buf)
+		if n > 0 && start.IsZero() {
+			start = time.Now()
+		}
+		res.Bytes += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !start.IsZero() {
+		d := time.Since(start)
+		res.DurationMs = float64(d) / float64(time.Millisecond)
+		if d > 0 {
+			res.ThroughputMbps = float64(res.Bytes*8) / d.Seconds() / 1e6
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&res)
+}
+
+// bandwidthHandler returns the bandwidth test handler for the URL path, if
+// bandwidth test is enabled.
+func (s *Server) bandwidthHandler(path string) http.HandlerFunc {
+	if !s.c.GetEnableBandwidthTest() {
+		return nil
+	}
+	return map[string]http.HandlerFunc{
+		"/download": s.downloadHandler,
+		"/upload":   s.uploadHandler,
+	}[path]
+}
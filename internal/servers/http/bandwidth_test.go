@@ -0,0 +1,68 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/http/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBandwidthTest(t *testing.T) {
+	s := &Server{
+		c:                 &configpb.ServerConf{EnableBandwidthTest: proto.Bool(true)},
+		reqMetric:         metrics.NewMap("url"),
+		staticURLResTable: map[string][]byte{"/": []byte(OK)},
+	}
+
+	do := func(method, url string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.handler(w, httptest.NewRequest(method, url, bytes.NewReader(body)))
+		return w
+	}
+
+	t.Run("download", func(t *testing.T) {
+		for _, size := range []int{0, 100, bandwidthBufSize, 3*bandwidthBufSize + 7} {
+			w := do(http.MethodGet, "/download?size="+strconv.Itoa(size), nil)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Len(t, w.Body.Bytes(), size)
+			assert.Equal(t, strconv.Itoa(size), w.Header().Get("Content-Length"))
+		}
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/download?size=-1", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodGet, "/download?size=2147483648", nil).Code)
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		w := do(http.MethodPost, "/upload", make([]byte, 200000))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res UploadResult
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, int64(200000), res.Bytes)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodGet, "/upload", nil).Code)
+	})
+
+	// Bandwidth test endpoints are disabled by default.
+	s.c = &configpb.ServerConf{}
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/download", nil).Code)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cloudprober/cloudprober/probes/probeutils"
+)
+
+const (
+	maxFaultDelay    = 5 * time.Minute
+	maxDripBytes     = 10 * 1024 * 1024
+	defaultDripChunk = 10
+)
+
+// queryInt parses an integer query parameter, returning the default value if
+// the parameter is not set.
+func queryInt(q url.Values, name string, def, min, max int64) (int64, error) {
+	s := q.Get(name)
+	if s == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid %s (%s), should be an integer in [%d, %d]", name, s, min, max)
+	}
+	return v, nil
+}
+
+func queryMsec(q url.Values, name string) (time.Duration, error) {
+	ms, err := queryInt(q, name, 0, 0, maxFaultDelay.Milliseconds())
+	return time.Duration(ms) * time.Millisecond, err
+}
+
+// sleep sleeps for the duration, returning early if the request is canceled.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// delayHandler responds after a delay: /delay?ms=<delay>&jitter_ms=<jitter>.
+// Actual delay is uniformly distributed in [delay-jitter, delay+jitter].
+func (s *Server) delayHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	delay, err := queryMsec(q, "ms")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jitter, err := queryMsec(q, "jitter_ms")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(2*jitter)+1)) - jitter
+	}
+	if sleep(r.Context(), max(delay, 0)) != nil {
+		return
+	}
+	w.Write([]byte(OK))
+}
+
+// errorHandler fails a fraction of the requests: /error?rate=<0-1>&code=<status>.
+// Default is to fail all the requests with status 500.
+func (s *Server) errorHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	rate := 1.0
+	if rs := q.Get("rate"); rs != "" {
+		var err error
+		if rate, err = strconv.ParseFloat(rs, 64); err != nil || rate < 0 || rate > 1 {
+			http.Error(w, fmt.Sprintf("invalid rate (%s), should be in [0, 1]", rs), http.StatusBadRequest)
+			return
+		}
+	}
+	code, err := queryInt(q, "code", http.StatusInternalServerError, 100, 599)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if rand.Float64() < rate {
+		http.Error(w, http.StatusText(int(code)), int(code))
+		return
+	}
+	w.Write([]byte(OK))
+}
+
+// dripHandler sends the response slowly, in chunks spread over the duration:
+// /drip?bytes=<size>&duration_ms=<duration>&chunks=<n>.
+func (s *Server) dripHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	size, err := queryInt(q, "bytes", int64(len(OK)), 1, maxDripBytes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	duration, err := queryMsec(q, "duration_ms")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chunks, err := queryInt(q, "chunks", defaultDripChunk, 1, size)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payload := make([]byte, size)
+	probeutils.PatternPayload(payload, []byte("cloudprober"))
+
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	interval := duration / time.Duration(chunks)
+	for i := int64(0); i < chunks; i++ {
+		if i > 0 && sleep(r.Context(), interval) != nil {
+			return
+		}
+		start, end := i*size/chunks, (i+1)*size/chunks
+		if _, err := w.Write(payload[start:end]); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// faultHandler returns the fault injection handler for the URL path, if
+// fault injection is enabled.
+func (s *Server) faultHandler(path string) http.HandlerFunc {
+	if !s.c.GetEnableFaultInjection() {
+		return nil
+	}
+	return map[string]http.HandlerFunc{
+		"/delay": s.delayHandler,
+		"/error": s.errorHandler,
+		"/drip":  s.dripHandler,
+	}[path]
+}
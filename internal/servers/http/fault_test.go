@@ -0,0 +1,76 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/http/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFaultInjection(t *testing.T) {
+	s := &Server{
+		c:                 &configpb.ServerConf{EnableFaultInjection: proto.Bool(true)},
+		reqMetric:         metrics.NewMap("url"),
+		staticURLResTable: map[string][]byte{"/": []byte(OK)},
+	}
+
+	tests := []struct {
+		url         string
+		wantCode    int
+		wantBody    string
+		wantBodyLen int
+		minDuration time.Duration
+	}{
+		{url: "/delay?ms=50", wantCode: http.StatusOK, wantBody: OK, minDuration: 50 * time.Millisecond},
+		{url: "/delay?ms=50&jitter_ms=20", wantCode: http.StatusOK, wantBody: OK, minDuration: 30 * time.Millisecond},
+		{url: "/delay?ms=-1", wantCode: http.StatusBadRequest},
+		{url: "/delay?ms=abc", wantCode: http.StatusBadRequest},
+		{url: "/error", wantCode: http.StatusInternalServerError},
+		{url: "/error?rate=1&code=503", wantCode: http.StatusServiceUnavailable},
+		{url: "/error?rate=0", wantCode: http.StatusOK, wantBody: OK},
+		{url: "/error?rate=2", wantCode: http.StatusBadRequest},
+		{url: "/drip?bytes=100&duration_ms=40&chunks=5", wantCode: http.StatusOK, wantBodyLen: 100, minDuration: 32 * time.Millisecond},
+		{url: "/drip?bytes=10&chunks=20", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			start := time.Now()
+			s.handler(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.GreaterOrEqual(t, time.Since(start), tt.minDuration)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			if tt.wantBodyLen != 0 {
+				assert.Len(t, w.Body.Bytes(), tt.wantBodyLen)
+			}
+		})
+	}
+
+	// Fault injection endpoints are disabled by default.
+	s.c = &configpb.ServerConf{}
+	w := httptest.NewRecorder()
+	s.handler(w, httptest.NewRequest(http.MethodGet, "/error", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
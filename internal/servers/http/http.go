@@ -117,6 +117,14 @@ func (s *Server) handler(w http.ResponseWriter, r *http.Request) {
 	case "/metadata":
 		s.metadataHandler(w, r)
 	default:
+		if fh := s.faultHandler(r.URL.Path); fh != nil {
+			fh(w, r)
+			break
+		}
+		if bh := s.bandwidthHandler(r.URL.Path); bh != nil {
+			bh(w, r)
+			break
+		}
 		res, ok := s.staticURLResTable[r.URL.Path]
 		if !ok {
 			http.Error(w, "not found", http.StatusNotFound)
@@ -131,6 +139,7 @@ func (s *Server) handler(w http.ResponseWriter, r *http.Request) {
 type Server struct {
 	c                 *configpb.ServerConf
 	ln                net.Listener
+	tlsConfig         *tls.Config // Set only for HTTPS servers.
 	instanceName      string
 	sysVars           map[string]string
 	staticURLResTable map[string][]byte
@@ -154,9 +163,11 @@ func New(initCtx context.Context, c *configpb.ServerConf, l *logger.Logger) (*Se
 		l.Warning(err.Error())
 	}
 
+	var tlsConfig *tls.Config
 	if c.GetProtocol() == configpb.ServerConf_HTTPS {
-		if c.GetTlsCertFile() == "" || c.GetTlsKeyFile() == "" {
-			return nil, errors.New("tls_cert_file and tls_key_file are required for HTTPS servers")
+		if tlsConfig, err = newTLSConfig(c); err != nil {
+			ln.Close()
+			return nil, err
 		}
 	}
 
@@ -173,6 +184,7 @@ func New(initCtx context.Context, c *configpb.ServerConf, l *logger.Logger) (*Se
 		l:             l,
 		ln:            ln,
 		ldLister:      ldLister,
+		tlsConfig:     tlsConfig,
 		sysVars:       sysVars,
 		reqMetric:     metrics.NewMap("url"),
 		statsInterval: statsExportInterval,
@@ -226,5 +238,6 @@ func (s *Server) Start(ctx context.Context, dataChan chan<- *metrics.EventMetric
 	if s.c.GetProtocol() == configpb.ServerConf_HTTP {
 		return srv.Serve(s.ln)
 	}
-	return srv.ServeTLS(s.ln, s.c.GetTlsCertFile(), s.c.GetTlsKeyFile())
+	srv.TLSConfig = s.tlsConfig
+	return srv.ServeTLS(s.ln, "", "")
 }
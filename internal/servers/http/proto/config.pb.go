@@ -91,7 +91,74 @@ func (ServerConf_ProtocolType) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescGZIP(), []int{0, 0}
 }
 
-// Next available tag = 10
+// Client certificate policy for HTTPS servers. These map to the Go's
+// tls.ClientAuthType.
+type ServerConf_ClientAuth int32
+
+const (
+	ServerConf_NO_CLIENT_CERT                 ServerConf_ClientAuth = 0
+	ServerConf_REQUEST_CLIENT_CERT            ServerConf_ClientAuth = 1
+	ServerConf_REQUIRE_ANY_CLIENT_CERT        ServerConf_ClientAuth = 2
+	ServerConf_VERIFY_CLIENT_CERT_IF_GIVEN    ServerConf_ClientAuth = 3
+	ServerConf_REQUIRE_AND_VERIFY_CLIENT_CERT ServerConf_ClientAuth = 4
+)
+
+// Enum value maps for ServerConf_ClientAuth.
+var (
+	ServerConf_ClientAuth_name = map[int32]string{
+		0: "NO_CLIENT_CERT",
+		1: "REQUEST_CLIENT_CERT",
+		2: "REQUIRE_ANY_CLIENT_CERT",
+		3: "VERIFY_CLIENT_CERT_IF_GIVEN",
+		4: "REQUIRE_AND_VERIFY_CLIENT_CERT",
+	}
+	ServerConf_ClientAuth_value = map[string]int32{
+		"NO_CLIENT_CERT":                 0,
+		"REQUEST_CLIENT_CERT":            1,
+		"REQUIRE_ANY_CLIENT_CERT":        2,
+		"VERIFY_CLIENT_CERT_IF_GIVEN":    3,
+		"REQUIRE_AND_VERIFY_CLIENT_CERT": 4,
+	}
+)
+
+func (x ServerConf_ClientAuth) Enum() *ServerConf_ClientAuth {
+	p := new(ServerConf_ClientAuth)
+	*p = x
+	return p
+}
+
+func (x ServerConf_ClientAuth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerConf_ClientAuth) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_enumTypes[1].Descriptor()
+}
+
+func (ServerConf_ClientAuth) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_enumTypes[1]
+}
+
+func (x ServerConf_ClientAuth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ServerConf_ClientAuth) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ServerConf_ClientAuth(num)
+	return nil
+}
+
+// Deprecated: Use ServerConf_ClientAuth.Descriptor instead.
+func (ServerConf_ClientAuth) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescGZIP(), []int{0, 1}
+}
+
+// Next available tag = 15
 type ServerConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -112,6 +179,33 @@ type ServerConf struct {
 	TlsKeyFile *string `protobuf:"bytes,8,opt,name=tls_key_file,json=tlsKeyFile" json:"tls_key_file,omitempty"`
 	// Disable HTTP/2 for HTTPS servers.
 	DisableHttp2 *bool `protobuf:"varint,9,opt,name=disable_http2,json=disableHttp2" json:"disable_http2,omitempty"`
+	// Additional certificates for HTTPS servers, selected by the server name
+	// that the client indicates in the TLS handshake (SNI). Certificate
+	// configured through tls_cert_file and tls_key_file is served if no
+	// certificate matches the server name.
+	SniCert []*ServerConf_SNICertificate `protobuf:"bytes,12,rep,name=sni_cert,json=sniCert" json:"sni_cert,omitempty"`
+	// CA certificate file to verify the client certificates with.
+	ClientCaCertFile *string `protobuf:"bytes,13,opt,name=client_ca_cert_file,json=clientCaCertFile" json:"client_ca_cert_file,omitempty"`
+	// Default is REQUIRE_AND_VERIFY_CLIENT_CERT if client_ca_cert_file is set,
+	// NO_CLIENT_CERT otherwise.
+	ClientAuth *ServerConf_ClientAuth `protobuf:"varint,14,opt,name=client_auth,json=clientAuth,enum=cloudprober.servers.http.ServerConf_ClientAuth" json:"client_auth,omitempty"`
+	// Enable the fault injection endpoints, to test the probes and the client
+	// timeouts against a controllable peer:
+	//
+	//	/delay?ms=<delay>&jitter_ms=<jitter>: respond after a delay.
+	//	/error?rate=<0-1>&code=<status>: fail a fraction of the requests.
+	//	/drip?bytes=<size>&duration_ms=<duration>&chunks=<n>: send the response
+	//	slowly, in chunks spread over the duration.
+	//
+	// Note that the delays are bounded by the write_timeout_ms.
+	EnableFaultInjection *bool `protobuf:"varint,10,opt,name=enable_fault_injection,json=enableFaultInjection" json:"enable_fault_injection,omitempty"`
+	// Enable the bandwidth test endpoints, for the throughput probes between
+	// cloudprober instances:
+	//
+	//	/download?size=<bytes>: stream a payload of the given size (max 1GiB).
+	//	/upload: read the request body and respond with the number of bytes
+	//	received and the time taken, as JSON.
+	EnableBandwidthTest *bool `protobuf:"varint,11,opt,name=enable_bandwidth_test,json=enableBandwidthTest" json:"enable_bandwidth_test,omitempty"`
 	// Pattern data handler returns pattern data at the url /data_<size_in_bytes>,
 	// e.g. "/data_2048".
 	PatternDataHandler []*ServerConf_PatternDataHandler `protobuf:"bytes,5,rep,name=pattern_data_handler,json=patternDataHandler" json:"pattern_data_handler,omitempty"`
@@ -214,6 +308,41 @@ func (x *ServerConf) GetDisableHttp2() bool {
 	return false
 }
 
+func (x *ServerConf) GetSniCert() []*ServerConf_SNICertificate {
+	if x != nil {
+		return x.SniCert
+	}
+	return nil
+}
+
+func (x *ServerConf) GetClientCaCertFile() string {
+	if x != nil && x.ClientCaCertFile != nil {
+		return *x.ClientCaCertFile
+	}
+	return ""
+}
+
+func (x *ServerConf) GetClientAuth() ServerConf_ClientAuth {
+	if x != nil && x.ClientAuth != nil {
+		return *x.ClientAuth
+	}
+	return ServerConf_NO_CLIENT_CERT
+}
+
+func (x *ServerConf) GetEnableFaultInjection() bool {
+	if x != nil && x.EnableFaultInjection != nil {
+		return *x.EnableFaultInjection
+	}
+	return false
+}
+
+func (x *ServerConf) GetEnableBandwidthTest() bool {
+	if x != nil && x.EnableBandwidthTest != nil {
+		return *x.EnableBandwidthTest
+	}
+	return false
+}
+
 func (x *ServerConf) GetPatternDataHandler() []*ServerConf_PatternDataHandler {
 	if x != nil {
 		return x.PatternDataHandler
@@ -221,6 +350,71 @@ func (x *ServerConf) GetPatternDataHandler() []*ServerConf_PatternDataHandler {
 	return nil
 }
 
+type ServerConf_SNICertificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Server names to serve this certificate for. If not set, certificate is
+	// served if the server name matches one of its DNS names.
+	ServerName  []string `protobuf:"bytes,1,rep,name=server_name,json=serverName" json:"server_name,omitempty"`
+	TlsCertFile *string  `protobuf:"bytes,2,req,name=tls_cert_file,json=tlsCertFile" json:"tls_cert_file,omitempty"`
+	TlsKeyFile  *string  `protobuf:"bytes,3,req,name=tls_key_file,json=tlsKeyFile" json:"tls_key_file,omitempty"`
+}
+
+func (x *ServerConf_SNICertificate) Reset() {
+	*x = ServerConf_SNICertificate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerConf_SNICertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerConf_SNICertificate) ProtoMessage() {}
+
+func (x *ServerConf_SNICertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerConf_SNICertificate.ProtoReflect.Descriptor instead.
+func (*ServerConf_SNICertificate) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ServerConf_SNICertificate) GetServerName() []string {
+	if x != nil {
+		return x.ServerName
+	}
+	return nil
+}
+
+func (x *ServerConf_SNICertificate) GetTlsCertFile() string {
+	if x != nil && x.TlsCertFile != nil {
+		return *x.TlsCertFile
+	}
+	return ""
+}
+
+func (x *ServerConf_SNICertificate) GetTlsKeyFile() string {
+	if x != nil && x.TlsKeyFile != nil {
+		return *x.TlsKeyFile
+	}
+	return ""
+}
+
 type ServerConf_PatternDataHandler struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -241,7 +435,7 @@ const (
 func (x *ServerConf_PatternDataHandler) Reset() {
 	*x = ServerConf_PatternDataHandler{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[1]
+		mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -254,7 +448,7 @@ func (x *ServerConf_PatternDataHandler) String() string {
 func (*ServerConf_PatternDataHandler) ProtoMessage() {}
 
 func (x *ServerConf_PatternDataHandler) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[1]
+	mi := &file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,7 +461,7 @@ func (x *ServerConf_PatternDataHandler) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerConf_PatternDataHandler.ProtoReflect.Descriptor instead.
 func (*ServerConf_PatternDataHandler) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescGZIP(), []int{0, 0}
+	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescGZIP(), []int{0, 1}
 }
 
 func (x *ServerConf_PatternDataHandler) GetResponseSize() int32 {
@@ -293,7 +487,7 @@ var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_p
 	0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x63,
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x22, 0xe7, 0x04, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76,
+	0x72, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x22, 0xb9, 0x09, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x33, 0x31, 0x34, 0x31, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
 	0x12, 0x53, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01,
@@ -317,26 +511,63 @@ var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_p
 	0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69,
 	0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x32, 0x18, 0x09, 0x20, 0x01, 0x28,
 	0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x74, 0x74, 0x70, 0x32, 0x12,
-	0x69, 0x0a, 0x14, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f,
-	0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48,
-	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x12, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x1a, 0x60, 0x0a, 0x12, 0x50, 0x61,
-	0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
-	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x23, 0x0a, 0x0c,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04,
-	0x48, 0x54, 0x54, 0x50, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10,
-	0x01, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f,
+	0x4e, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x33, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x4e, 0x49, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x43, 0x65, 0x72, 0x74, 0x12,
+	0x2d, 0x0a, 0x13, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72,
+	0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x43, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x50,
+	0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68,
+	0x12, 0x34, 0x0a, 0x16, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x14, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6e,
+	0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x54, 0x65, 0x73, 0x74, 0x12, 0x69, 0x0a, 0x14, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2e, 0x68,
+	0x74, 0x74, 0x70, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x72, 0x52, 0x12, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x72, 0x1a, 0x77, 0x0a, 0x0e, 0x53, 0x4e, 0x49, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6c, 0x73, 0x5f,
+	0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52,
+	0x0b, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0c,
+	0x74, 0x6c, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x02,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x1a, 0x60,
+	0x0a, 0x12, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x22, 0x23, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x54,
+	0x54, 0x50, 0x53, 0x10, 0x01, 0x22, 0x9b, 0x01, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x41, 0x75, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e,
+	0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x10,
+	0x01, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x5f, 0x41, 0x4e, 0x59,
+	0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x10, 0x02, 0x12, 0x1f,
+	0x0a, 0x1b, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f,
+	0x43, 0x45, 0x52, 0x54, 0x5f, 0x49, 0x46, 0x5f, 0x47, 0x49, 0x56, 0x45, 0x4e, 0x10, 0x03, 0x12,
+	0x22, 0x0a, 0x1e, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x52,
+	0x54, 0x10, 0x04, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -351,21 +582,25 @@ func file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_
 	return file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_goTypes = []interface{}{
 	(ServerConf_ProtocolType)(0),          // 0: cloudprober.servers.http.ServerConf.ProtocolType
-	(*ServerConf)(nil),                    // 1: cloudprober.servers.http.ServerConf
-	(*ServerConf_PatternDataHandler)(nil), // 2: cloudprober.servers.http.ServerConf.PatternDataHandler
+	(ServerConf_ClientAuth)(0),            // 1: cloudprober.servers.http.ServerConf.ClientAuth
+	(*ServerConf)(nil),                    // 2: cloudprober.servers.http.ServerConf
+	(*ServerConf_SNICertificate)(nil),     // 3: cloudprober.servers.http.ServerConf.SNICertificate
+	(*ServerConf_PatternDataHandler)(nil), // 4: cloudprober.servers.http.ServerConf.PatternDataHandler
 }
 var file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_depIdxs = []int32{
 	0, // 0: cloudprober.servers.http.ServerConf.protocol:type_name -> cloudprober.servers.http.ServerConf.ProtocolType
-	2, // 1: cloudprober.servers.http.ServerConf.pattern_data_handler:type_name -> cloudprober.servers.http.ServerConf.PatternDataHandler
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 1: cloudprober.servers.http.ServerConf.sni_cert:type_name -> cloudprober.servers.http.ServerConf.SNICertificate
+	1, // 2: cloudprober.servers.http.ServerConf.client_auth:type_name -> cloudprober.servers.http.ServerConf.ClientAuth
+	4, // 3: cloudprober.servers.http.ServerConf.pattern_data_handler:type_name -> cloudprober.servers.http.ServerConf.PatternDataHandler
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_init() }
@@ -387,6 +622,18 @@ func file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_
 			}
 		}
 		file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerConf_SNICertificate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerConf_PatternDataHandler); i {
 			case 0:
 				return &v.state
@@ -404,8 +651,8 @@ func file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_servers_http_proto_config_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
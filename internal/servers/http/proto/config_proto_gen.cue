@@ -13,7 +13,7 @@
 // limitations under the License.
 package proto
 
-// Next available tag = 10
+// Next available tag = 15
 #ServerConf: {
 	port?: int32 @protobuf(1,int32,"default=3141")
 
@@ -46,6 +46,59 @@ package proto
 	// Disable HTTP/2 for HTTPS servers.
 	disableHttp2?: bool @protobuf(9,bool,name=disable_http2)
 
+	#SNICertificate: {
+		// Server names to serve this certificate for. If not set, certificate is
+		// served if the server name matches one of its DNS names.
+		serverName?: [...string] @protobuf(1,string,name=server_name)
+		tlsCertFile?: string @protobuf(2,string,name=tls_cert_file)
+		tlsKeyFile?:  string @protobuf(3,string,name=tls_key_file)
+	}
+
+	// Additional certificates for HTTPS servers, selected by the server name
+	// that the client indicates in the TLS handshake (SNI). Certificate
+	// configured through tls_cert_file and tls_key_file is served if no
+	// certificate matches the server name.
+	sniCert?: [...#SNICertificate] @protobuf(12,SNICertificate,name=sni_cert)
+
+	// CA certificate file to verify the client certificates with.
+	clientCaCertFile?: string @protobuf(13,string,name=client_ca_cert_file)
+
+	// Client certificate policy for HTTPS servers. These map to the Go's
+	// tls.ClientAuthType.
+	#ClientAuth: {"NO_CLIENT_CERT", #enumValue: 0} |
+		{"REQUEST_CLIENT_CERT", #enumValue: 1} |
+		{"REQUIRE_ANY_CLIENT_CERT", #enumValue: 2} |
+		{"VERIFY_CLIENT_CERT_IF_GIVEN", #enumValue: 3} |
+		{"REQUIRE_AND_VERIFY_CLIENT_CERT", #enumValue: 4}
+
+	#ClientAuth_value: {
+		NO_CLIENT_CERT:                 0
+		REQUEST_CLIENT_CERT:            1
+		REQUIRE_ANY_CLIENT_CERT:        2
+		VERIFY_CLIENT_CERT_IF_GIVEN:    3
+		REQUIRE_AND_VERIFY_CLIENT_CERT: 4
+	}
+
+	// Default is REQUIRE_AND_VERIFY_CLIENT_CERT if client_ca_cert_file is set,
+	// NO_CLIENT_CERT otherwise.
+	clientAuth?: #ClientAuth @protobuf(14,ClientAuth,name=client_auth)
+
+	// Enable the fault injection endpoints, to test the probes and the client
+	// timeouts against a controllable peer:
+	//   /delay?ms=<delay>&jitter_ms=<jitter>: respond after a delay.
+	//   /error?rate=<0-1>&code=<status>: fail a fraction of the requests.
+	//   /drip?bytes=<size>&duration_ms=<duration>&chunks=<n>: send the response
+	//   slowly, in chunks spread over the duration.
+	// Note that the delays are bounded by the write_timeout_ms.
+	enableFaultInjection?: bool @protobuf(10,bool,name=enable_fault_injection)
+
+	// Enable the bandwidth test endpoints, for the throughput probes between
+	// cloudprober instances:
+	//   /download?size=<bytes>: stream a payload of the given size (max 1GiB).
+	//   /upload: read the request body and respond with the number of bytes
+	//   received and the time taken, as JSON.
+	enableBandwidthTest?: bool @protobuf(11,bool,name=enable_bandwidth_test)
+
 	#PatternDataHandler: {
 		// Response sizes to server, e.g. 1024.
 		responseSize?: int32 @protobuf(1,int32,name=response_size)
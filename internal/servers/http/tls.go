@@ -0,0 +1,98 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/http/proto"
+)
+
+// newTLSConfig builds the TLS config for the HTTPS server: default
+// certificate, SNI certificates and the client certificates policy.
+func newTLSConfig(c *configpb.ServerConf) (*tls.Config, error) {
+	if c.GetTlsCertFile() == "" || c.GetTlsKeyFile() == "" {
+		return nil, errors.New("tls_cert_file and tls_key_file are required for HTTPS servers")
+	}
+	cert, err := tls.LoadX509KeyPair(c.GetTlsCertFile(), c.GetTlsKeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("error loading TLS certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(c.GetSniCert()) > 0 {
+		sniCerts, nameToCert := []*tls.Certificate{}, make(map[string]*tls.Certificate)
+		for _, sc := range c.GetSniCert() {
+			sniCert, err := tls.LoadX509KeyPair(sc.GetTlsCertFile(), sc.GetTlsKeyFile())
+			if err != nil {
+				return nil, fmt.Errorf("error loading SNI certificate (%s): %v", sc.GetTlsCertFile(), err)
+			}
+			if len(sc.GetServerName()) == 0 {
+				sniCerts = append(sniCerts, &sniCert)
+			}
+			for _, name := range sc.GetServerName() {
+				nameToCert[name] = &sniCert
+			}
+		}
+		tlsConfig.GetCertificate = sniCertSelector(nameToCert, sniCerts, &cert)
+	}
+
+	if c.GetClientCaCertFile() != "" {
+		caCert, err := os.ReadFile(c.GetClientCaCertFile())
+		if err != nil {
+			return nil, fmt.Errorf("error reading client CA cert file (%s): %v", c.GetClientCaCertFile(), err)
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("error while adding client CA certs from: %s", c.GetClientCaCertFile())
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if c.ClientAuth != nil {
+		tlsConfig.ClientAuth = tls.ClientAuthType(c.GetClientAuth())
+	}
+	if tlsConfig.ClientCAs == nil && (tlsConfig.ClientAuth == tls.VerifyClientCertIfGiven || tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert) {
+		return nil, fmt.Errorf("client_ca_cert_file is required for client_auth %s", c.GetClientAuth())
+	}
+
+	return tlsConfig, nil
+}
+
+// sniCertSelector returns a function that selects the certificate for the
+// server name indicated by the client: a certificate configured explicitly for
+// the name, or the first certificate valid for the name, or the default
+// certificate.
+func sniCertSelector(nameToCert map[string]*tls.Certificate, certs []*tls.Certificate, defaultCert *tls.Certificate) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if hello.ServerName == "" {
+			return defaultCert, nil
+		}
+		if cert := nameToCert[hello.ServerName]; cert != nil {
+			return cert, nil
+		}
+		for _, cert := range certs {
+			if hello.SupportsCertificate(cert) == nil {
+				return cert, nil
+			}
+		}
+		return defaultCert, nil
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/http/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// writeTestCert writes a self-signed certificate and its key to the
+// directory, and returns their paths.
+func writeTestCert(t *testing.T, dir, cn string, dnsNames ...string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, cn+".crt"), filepath.Join(dir, cn+".key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestHTTPSServer(t *testing.T) {
+	dir := t.TempDir()
+	defCert, defKey := writeTestCert(t, dir, "default")
+	aCert, aKey := writeTestCert(t, dir, "a", "a.example.com")
+	bCert, bKey := writeTestCert(t, dir, "b", "other.example.com")
+	clientCert, clientKey := writeTestCert(t, dir, "client")
+
+	c := &configpb.ServerConf{
+		Port:        proto.Int32(0),
+		Protocol:    configpb.ServerConf_HTTPS.Enum(),
+		TlsCertFile: proto.String(defCert),
+		TlsKeyFile:  proto.String(defKey),
+		SniCert: []*configpb.ServerConf_SNICertificate{
+			{TlsCertFile: proto.String(aCert), TlsKeyFile: proto.String(aKey)},
+			{ServerName: []string{"b.example.com"}, TlsCertFile: proto.String(bCert), TlsKeyFile: proto.String(bKey)},
+		},
+		ClientCaCertFile: proto.String(clientCert),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := New(ctx, c, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating HTTPS server: %v", err)
+	}
+	go s.Start(ctx, nil)
+
+	cc, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		serverName string
+		noCert     bool
+		wantCN     string
+		wantErr    bool
+	}{
+		{serverName: "a.example.com", wantCN: "a"},
+		{serverName: "b.example.com", wantCN: "b"},
+		{serverName: "c.example.com", wantCN: "default"},
+		{serverName: "", wantCN: "default"},
+		{serverName: "a.example.com", noCert: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serverName, func(t *testing.T) {
+			tlsConfig := &tls.Config{
+				InsecureSkipVerify: true,
+				ServerName:         tt.serverName,
+			}
+			if !tt.noCert {
+				tlsConfig.Certificates = []tls.Certificate{cc}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+
+			resp, err := client.Get("https://" + listenerAddr(s.ln) + "/")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.wantCN, resp.TLS.PeerCertificates[0].Subject.CommonName)
+		})
+	}
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeTestCert(t, dir, "default")
+
+	tests := []struct {
+		name string
+		c    *configpb.ServerConf
+	}{
+		{
+			name: "no_cert",
+			c:    &configpb.ServerConf{},
+		},
+		{
+			name: "bad_sni_cert",
+			c: &configpb.ServerConf{
+				TlsCertFile: proto.String(cert),
+				TlsKeyFile:  proto.String(key),
+				SniCert: []*configpb.ServerConf_SNICertificate{
+					{TlsCertFile: proto.String(filepath.Join(dir, "missing.crt")), TlsKeyFile: proto.String(key)},
+				},
+			},
+		},
+		{
+			name: "verify_without_ca",
+			c: &configpb.ServerConf{
+				TlsCertFile: proto.String(cert),
+				TlsKeyFile:  proto.String(key),
+				ClientAuth:  configpb.ServerConf_REQUIRE_AND_VERIFY_CLIENT_CERT.Enum(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTLSConfig(tt.c)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -5,19 +5,22 @@ import (
 	proto_1 "github.com/cloudprober/cloudprober/internal/servers/udp/proto"
 	proto_5 "github.com/cloudprober/cloudprober/internal/servers/grpc/proto"
 	proto_A "github.com/cloudprober/cloudprober/internal/servers/external/proto"
+	proto_8 "github.com/cloudprober/cloudprober/internal/servers/tcp/proto"
 )
 
 #ServerDef: {
 	#Type: {"HTTP", #enumValue: 0} |
 		{"UDP", #enumValue: 1} |
 		{"GRPC", #enumValue: 2} |
-		{"EXTERNAL", #enumValue: 3}
+		{"EXTERNAL", #enumValue: 3} |
+		{"TCP", #enumValue: 4}
 
 	#Type_value: {
 		HTTP:     0
 		UDP:      1
 		GRPC:     2
 		EXTERNAL: 3
+		TCP:      4
 	}
 	type?: #Type @protobuf(1,Type)
 	{} | {
@@ -28,5 +31,7 @@ import (
 		grpcServer: proto_5.#ServerConf @protobuf(4,grpc.ServerConf,name=grpc_server)
 	} | {
 		externalServer: proto_A.#ServerConf @protobuf(5,external.ServerConf,name=external_server)
+	} | {
+		tcpServer: proto_8.#ServerConf @protobuf(6,tcp.ServerConf,name=tcp_server)
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/cloudprober/cloudprober/internal/servers/grpc"
 	"github.com/cloudprober/cloudprober/internal/servers/http"
 	configpb "github.com/cloudprober/cloudprober/internal/servers/proto"
+	"github.com/cloudprober/cloudprober/internal/servers/tcp"
 	"github.com/cloudprober/cloudprober/internal/servers/udp"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
@@ -89,6 +90,9 @@ func Init(initCtx context.Context, serverDefs []*configpb.ServerDef) (servers []
 		case configpb.ServerDef_EXTERNAL:
 			server, err = external.New(initCtx, serverDef.GetExternalServer(), l)
 			conf = serverDef.GetExternalServer()
+		case configpb.ServerDef_TCP:
+			server, err = tcp.New(initCtx, serverDef.GetTcpServer(), l)
+			conf = serverDef.GetTcpServer()
 		}
 		if err != nil {
 			return
@@ -0,0 +1,31 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+#ServerConf: {
+	port?: int32 @protobuf(1,int32)
+
+	#Type: {
+		// Echos the incoming data back.
+		"ECHO"
+		#enumValue: 0
+	} | {
+		// Discard the incoming data. Return nothing.
+		"DISCARD"
+		#enumValue: 1
+	}
+
+	#Type_value: {
+		ECHO:    0
+		DISCARD: 1
+	}
+	type?: #Type @protobuf(2,Type,"default=ECHO")
+
+	// If set, server accepts only TLS connections. tls_cert_file and
+	// tls_key_file are required. If ca_cert_file is set, clients are required
+	// to present a certificate signed by that CA.
+	tlsConfig?: proto.#TLSConfig @protobuf(3,tlsconfig.TLSConfig,name=tls_config)
+
+	// Connections with no data for this long are closed. Set to 0 to disable.
+	idleTimeoutMs?: int32 @protobuf(4,int32,name=idle_timeout_ms,"default=60000") // default: 1m
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package tcp implements a TCP server. It listens on a given port and either
+echos or discards whatever it receives. It's a first-party peer for the TCP
+probes.
+*/
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/tcp/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+const readBufSize = 32 * 1024
+
+// Server implements a basic TCP server.
+type Server struct {
+	c           *configpb.ServerConf
+	ln          net.Listener
+	l           *logger.Logger
+	idleTimeout time.Duration
+}
+
+// New returns a TCP server.
+func New(initCtx context.Context, c *configpb.ServerConf, l *logger.Logger) (*Server, error) {
+	var tlsConfig *tls.Config
+	if c.GetTlsConfig() != nil {
+		if c.GetTlsConfig().GetTlsCertFile() == "" || c.GetTlsConfig().GetTlsKeyFile() == "" {
+			return nil, errors.New("tls_cert_file and tls_key_file are required for TLS")
+		}
+		tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(tlsConfig, c.GetTlsConfig()); err != nil {
+			return nil, err
+		}
+		if tlsConfig.RootCAs != nil {
+			tlsConfig.ClientCAs = tlsConfig.RootCAs
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", c.GetPort()))
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+	go func() {
+		<-initCtx.Done()
+		ln.Close()
+	}()
+
+	return &Server{
+		c:           c,
+		ln:          ln,
+		l:           l,
+		idleTimeout: time.Duration(c.GetIdleTimeoutMs()) * time.Millisecond,
+	}, nil
+}
+
+// handleConn echos or discards the data received on the connection, until
+// the client closes it, it's idle for too long, or the context is canceled.
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	buf := make([]byte, readBufSize)
+	for {
+		if s.idleTimeout > 0 {
+			conn.SetDeadline(time.Now().Add(s.idleTimeout))
+		}
+		n, err := conn.Read(buf)
+		if n > 0 && s.c.GetType() == configpb.ServerConf_ECHO {
+			if _, err := conn.Write(buf[:n]); err != nil {
+				s.l.Debugf("Error writing to %s: %v", conn.RemoteAddr(), err)
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				s.l.Debugf("Closing connection from %s: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+	}
+}
+
+// Start starts the TCP server. It returns only when context is canceled.
+func (s *Server) Start(ctx context.Context, dataChan chan<- *metrics.EventMetrics) error {
+	go func() {
+		<-ctx.Done()
+		s.ln.Close()
+	}()
+
+	s.l.Infof("Starting TCP %s server on %s", s.c.GetType(), s.ln.Addr())
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			s.l.Errorf("Error accepting connection: %v", err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleConn(ctx, conn)
+		}()
+	}
+}
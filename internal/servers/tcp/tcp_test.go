@@ -0,0 +1,140 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/servers/tcp/proto"
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// writeTestCert writes a self-signed certificate and its key to a temporary
+// directory, and returns their paths.
+func writeTestCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestServer(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+
+	tests := []struct {
+		name     string
+		typ      configpb.ServerConf_Type
+		tls      bool
+		wantEcho bool
+	}{
+		{name: "echo", typ: configpb.ServerConf_ECHO, wantEcho: true},
+		{name: "discard", typ: configpb.ServerConf_DISCARD},
+		{name: "echo_tls", typ: configpb.ServerConf_ECHO, tls: true, wantEcho: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			c := &configpb.ServerConf{
+				Port:          proto.Int32(0),
+				Type:          tt.typ.Enum(),
+				IdleTimeoutMs: proto.Int32(200),
+			}
+			if tt.tls {
+				c.TlsConfig = &tlsconfigpb.TLSConfig{
+					TlsCertFile: proto.String(certFile),
+					TlsKeyFile:  proto.String(keyFile),
+				}
+			}
+
+			s, err := New(ctx, c, &logger.Logger{})
+			if err != nil {
+				t.Fatalf("Error creating TCP server: %v", err)
+			}
+			go s.Start(ctx, nil)
+
+			addr := s.ln.Addr().String()
+			var conn net.Conn
+			if tt.tls {
+				conn, err = tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+			} else {
+				conn, err = net.Dial("tcp", addr)
+			}
+			if err != nil {
+				t.Fatalf("Error connecting to %s: %v", addr, err)
+			}
+			defer conn.Close()
+
+			data := []byte("cloudprober-tcp-test")
+			_, err = conn.Write(data)
+			assert.NoError(t, err)
+
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			buf := make([]byte, len(data))
+			_, err = io.ReadFull(conn, buf)
+			if tt.wantEcho {
+				assert.NoError(t, err)
+				assert.Equal(t, data, buf)
+			} else {
+				// Server closes the connection after idle timeout, without
+				// responding.
+				assert.ErrorIs(t, err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestServerTLSConfigError(t *testing.T) {
+	_, err := New(context.Background(), &configpb.ServerConf{
+		Port:      proto.Int32(0),
+		TlsConfig: &tlsconfigpb.TLSConfig{},
+	}, &logger.Logger{})
+	assert.Error(t, err)
+}
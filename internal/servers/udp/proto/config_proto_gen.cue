@@ -13,11 +13,19 @@ package proto
 		// Discard the incoming packet. Return nothing.
 		"DISCARD"
 		#enumValue: 1
+	} | {
+		// Echos the incoming packet back, with server's receive and transmit
+		// timestamps appended to it (16 bytes: two big-endian unix timestamps in
+		// nanoseconds). UDP probe uses these timestamps to compute one-way delay
+		// and server processing time, if configured with server_timestamps.
+		"TIMESTAMP"
+		#enumValue: 2
 	}
 
 	#Type_value: {
-		ECHO:    0
-		DISCARD: 1
+		ECHO:      0
+		DISCARD:   1
+		TIMESTAMP: 2
 	}
 	type?: #Type @protobuf(2,Type)
 }
@@ -0,0 +1,43 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TimestampTrailerSize is the size of the trailer that server appends to the
+// echoed packets in TIMESTAMP mode: receive and transmit timestamps, each as
+// big-endian unix nanoseconds.
+const TimestampTrailerSize = 16
+
+func appendTimestamps(b []byte, rxTS, txTS time.Time) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(rxTS.UnixNano()))
+	return binary.BigEndian.AppendUint64(b, uint64(txTS.UnixNano()))
+}
+
+// ParseTimestamps splits a packet echoed by the server in TIMESTAMP mode into
+// the original packet and the server's receive and transmit timestamps.
+func ParseTimestamps(b []byte) ([]byte, time.Time, time.Time, error) {
+	if len(b) < TimestampTrailerSize {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("packet too short for timestamps: %d bytes", len(b))
+	}
+	n := len(b) - TimestampTrailerSize
+	rxTS := time.Unix(0, int64(binary.BigEndian.Uint64(b[n:])))
+	txTS := time.Unix(0, int64(binary.BigEndian.Uint64(b[n+8:])))
+	return b[:n], rxTS, txTS, nil
+}
@@ -25,6 +25,7 @@ import (
 	"net"
 	"runtime"
 	"strings"
+	"time"
 
 	configpb "github.com/cloudprober/cloudprober/internal/servers/udp/proto"
 	"github.com/cloudprober/cloudprober/logger"
@@ -141,6 +142,7 @@ func (s *Server) readAndEchoBatch(ms []ipv6.Message) *readWriteErr {
 	if err != nil {
 		return &readWriteErr{"error reading packets", err}
 	}
+	rxTS := time.Now()
 	ms = ms[:n]
 
 	// Resize buffers to match amount read.
@@ -149,6 +151,15 @@ func (s *Server) readAndEchoBatch(ms []ipv6.Message) *readWriteErr {
 		m.Buffers[0] = m.Buffers[0][:m.N]
 	}
 
+	// In TIMESTAMP mode, append receive and transmit timestamps. Buffers have
+	// enough capacity for the timestamps.
+	if s.c.GetType() == configpb.ServerConf_TIMESTAMP {
+		txTS := time.Now()
+		for _, m := range ms {
+			m.Buffers[0] = appendTimestamps(m.Buffers[0], rxTS, txTS)
+		}
+	}
+
 	for remaining := len(ms); remaining > 0; {
 		n, err := s.p6.WriteBatch(ms, 0)
 		if err != nil {
@@ -164,7 +175,7 @@ func (s *Server) readAndEchoBatch(ms []ipv6.Message) *readWriteErr {
 	for _, m := range ms {
 		b := m.Buffers[0]
 		// We only allocated a 0th buffer.
-		m.Buffers[0] = b[:cap(b)]
+		m.Buffers[0] = b[:maxPacketSize]
 	}
 
 	return nil
@@ -177,11 +188,18 @@ func (s *Server) readAndEchoSimple(buf []byte) *readWriteErr {
 	if err != nil {
 		return &readWriteErr{"error reading packet", err}
 	}
+	rxTS := time.Now()
 	if inLen == 0 {
 		return &readWriteErr{"read 0 length packet", nil}
 	}
 
-	n, err := s.conn.WriteToUDP(buf[:inLen], addr)
+	out := buf[:inLen]
+	if s.c.GetType() == configpb.ServerConf_TIMESTAMP {
+		out = appendTimestamps(out, rxTS, time.Now())
+		inLen = len(out)
+	}
+
+	n, err := s.conn.WriteToUDP(out, addr)
 	if err != nil {
 		return &readWriteErr{"error writing packet", err}
 	}
@@ -200,13 +218,16 @@ func connClosed(err error) bool {
 
 // Start starts the UDP server. It returns only when context is canceled.
 func (s *Server) Start(ctx context.Context, dataChan chan<- *metrics.EventMetrics) error {
-	var ms []ipv6.Message              // Used for batch read-write
-	buf := make([]byte, maxPacketSize) // Used for single packet read-write (windows)
+	// Buffers have extra capacity for the timestamps added in TIMESTAMP mode.
+	bufCap := maxPacketSize + TimestampTrailerSize
+
+	var ms []ipv6.Message                      // Used for batch read-write
+	buf := make([]byte, maxPacketSize, bufCap) // Used for single packet read-write (windows)
 
 	if s.advancedReadWrite {
 		ms = make([]ipv6.Message, batchSize)
 		for i := 0; i < batchSize; i++ {
-			ms[i].Buffers = [][]byte{make([]byte, maxPacketSize)}
+			ms[i].Buffers = [][]byte{make([]byte, maxPacketSize, bufCap)}
 			ms[i].OOB = ipv6.NewControlMessage(ipv6.FlagDst)
 		}
 	}
@@ -222,8 +243,8 @@ func (s *Server) Start(ctx context.Context, dataChan chan<- *metrics.EventMetric
 
 	switch s.c.GetType() {
 
-	case configpb.ServerConf_ECHO:
-		s.l.Infof("Starting UDP ECHO server on port %d", int(s.c.GetPort()))
+	case configpb.ServerConf_ECHO, configpb.ServerConf_TIMESTAMP:
+		s.l.Infof("Starting UDP %s server on port %d", s.c.GetType(), int(s.c.GetPort()))
 
 		var rwerr *readWriteErr
 		for {
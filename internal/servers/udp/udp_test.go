@@ -68,6 +68,23 @@ func sendAndTestResponse(t *testing.T, c *configpb.ServerConf, conn net.Conn) {
 		if !bytes.Equal(data, rcvd) {
 			t.Errorf("Data mismatch: Sent '%v', Got '%v'", data, rcvd)
 		}
+	case configpb.ServerConf_TIMESTAMP:
+		rcvd := make([]byte, size+TimestampTrailerSize)
+		n, err := conn.Read(rcvd)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pkt, rxTS, txTS, err := ParseTimestamps(rcvd[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, pkt) {
+			t.Errorf("Data mismatch: Sent '%v', Got '%v'", data, pkt)
+		}
+		if txTS.Before(rxTS) || time.Since(rxTS) > timeout {
+			t.Errorf("Bad server timestamps: rx=%v, tx=%v", rxTS, txTS)
+		}
 	case configpb.ServerConf_DISCARD:
 		rcvd := make([]byte, size)
 		n, err := conn.Read(rcvd)
@@ -92,6 +109,31 @@ func TestEchoServer(t *testing.T) {
 	testServer(t, testConfig)
 }
 
+func TestTimestampServer(t *testing.T) {
+	testConfig := &configpb.ServerConf{
+		Port: proto.Int32(int32(0)),
+		Type: configpb.ServerConf_TIMESTAMP.Enum(),
+	}
+	testServer(t, testConfig)
+}
+
+func TestParseTimestamps(t *testing.T) {
+	rxTS := time.Unix(1700000000, 123456789)
+	txTS := rxTS.Add(50 * time.Microsecond)
+
+	pkt, gotRx, gotTx, err := ParseTimestamps(appendTimestamps([]byte("hello"), rxTS, txTS))
+	if err != nil {
+		t.Fatalf("ParseTimestamps() error: %v", err)
+	}
+	if string(pkt) != "hello" || !gotRx.Equal(rxTS) || !gotTx.Equal(txTS) {
+		t.Errorf("ParseTimestamps()=%q, %v, %v, want %q, %v, %v", pkt, gotRx, gotTx, "hello", rxTS, txTS)
+	}
+
+	if _, _, _, err := ParseTimestamps([]byte("short")); err == nil {
+		t.Error("ParseTimestamps() expected error for a short packet")
+	}
+}
+
 func TestDiscardServer(t *testing.T) {
 	testConfig := &configpb.ServerConf{
 		Port: proto.Int32(int32(0)),
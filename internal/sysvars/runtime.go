@@ -18,8 +18,10 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/cloudprober/cloudprober/internal/ratelimit"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/health"
 )
 
 func runtimeVars(dataChan chan *metrics.EventMetrics, l *logger.Logger) {
@@ -29,6 +31,18 @@ func runtimeVars(dataChan chan *metrics.EventMetrics, l *logger.Logger) {
 	osRuntimeVars(dataChan, l)
 	counterRuntimeVars(dataChan, ts, m, l)
 	gaugeRuntimeVars(dataChan, ts, m, l)
+
+	// Export number of targets excluded by the health gates.
+	for _, em := range health.GatedOutMetrics(ts) {
+		dataChan <- em
+		l.Debug(em.String())
+	}
+
+	// Export number of probe requests throttled by the rate limits.
+	for _, em := range ratelimit.ThrottledMetrics(ts) {
+		dataChan <- em
+		l.Debug(em.String())
+	}
 }
 
 // counterRuntimeVars exports counter runtime stats, stats that grow through
@@ -0,0 +1,125 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cel provides a CEL (Common Expression Language) validator for the
+// Cloudprober's validator framework.
+package cel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/cel/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// Validator implements a CEL validator.
+type Validator struct {
+	expr    string
+	program cel.Program
+	l       *logger.Logger
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("body", cel.StringType),
+		cel.Variable("json", cel.DynType),
+		cel.Variable("status", cel.IntType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("latency", cel.DurationType),
+	)
+}
+
+// Init initializes the CEL validator. It compiles the expression and returns
+// an error if it doesn't compile, or if it doesn't evaluate to a boolean.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	cfg, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid CEL validator config", config)
+	}
+	if cfg.GetExpression() == "" {
+		return fmt.Errorf("CEL validator: expression is required")
+	}
+
+	env, err := newEnv()
+	if err != nil {
+		return fmt.Errorf("CEL validator: error creating environment: %v", err)
+	}
+
+	ast, iss := env.Compile(cfg.GetExpression())
+	if iss.Err() != nil {
+		return fmt.Errorf("CEL validator: error compiling expression (%s): %v", cfg.GetExpression(), iss.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return fmt.Errorf("CEL validator: expression (%s) evaluates to %v, not bool", cfg.GetExpression(), ast.OutputType())
+	}
+
+	v.program, err = env.Program(ast)
+	if err != nil {
+		return fmt.Errorf("CEL validator: error creating program: %v", err)
+	}
+
+	v.expr = cfg.GetExpression()
+	v.l = l
+	return nil
+}
+
+// vars returns the variables for the CEL expression.
+func vars(resp interface{}, body []byte, latency time.Duration) map[string]interface{} {
+	var jsonVal interface{} = types.NullValue
+	var parsed interface{}
+	if json.Unmarshal(body, &parsed) == nil && parsed != nil {
+		jsonVal = parsed
+	}
+
+	var status int64
+	headers := make(map[string]string)
+	if httpResp, ok := resp.(*http.Response); ok && httpResp != nil {
+		status = int64(httpResp.StatusCode)
+		for k, vals := range httpResp.Header {
+			headers[strings.ToLower(k)] = strings.Join(vals, ", ")
+		}
+	}
+
+	return map[string]interface{}{
+		"body":    string(body),
+		"json":    jsonVal,
+		"status":  status,
+		"headers": headers,
+		"latency": latency,
+	}
+}
+
+// Validate evaluates the CEL expression against the response. resp is the
+// probe response, e.g. *http.Response for HTTP probes.
+func (v *Validator) Validate(resp interface{}, body []byte, latency time.Duration) (bool, error) {
+	out, _, err := v.program.Eval(vars(resp, body, latency))
+	if err != nil {
+		return false, fmt.Errorf("error evaluating CEL expression (%s): %v", v.expr, err)
+	}
+
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("CEL expression (%s) didn't evaluate to bool: %v", v.expr, out)
+	}
+	if !b {
+		v.l.Warningf("CEL validation failure: expression %s evaluated to false", v.expr)
+	}
+	return b, nil
+}
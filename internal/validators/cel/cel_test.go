@@ -0,0 +1,136 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/cel/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	httpResp := &http.Response{
+		StatusCode: 200,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			"X-Multi":      []string{"a", "b"},
+		},
+	}
+	jsonBody := []byte(`{"status": "ok", "items": [1, 2, 3], "version": 2.5}`)
+
+	tests := []struct {
+		desc        string
+		expr        string
+		resp        interface{}
+		body        []byte
+		latency     time.Duration
+		wantInitErr bool
+		wantErr     bool
+		want        bool
+	}{
+		{
+			desc: "status",
+			expr: "status == 200",
+			resp: httpResp,
+			want: true,
+		},
+		{
+			desc: "status_no_http",
+			expr: "status == 0",
+			want: true,
+		},
+		{
+			desc: "headers",
+			expr: `headers["content-type"].startsWith("application/json") && headers["x-multi"] == "a, b"`,
+			resp: httpResp,
+			want: true,
+		},
+		{
+			desc: "json",
+			expr: `json.status == "ok" && size(json.items) == 3 && json.version > 2.0`,
+			body: jsonBody,
+			want: true,
+		},
+		{
+			desc: "json_false",
+			expr: `json.status == "down"`,
+			body: jsonBody,
+			want: false,
+		},
+		{
+			desc: "body",
+			expr: `body.contains("items")`,
+			body: jsonBody,
+			want: true,
+		},
+		{
+			desc:    "latency",
+			expr:    `latency < duration("500ms")`,
+			latency: 100 * time.Millisecond,
+			want:    true,
+		},
+		{
+			desc:    "latency_exceeded",
+			expr:    `latency < duration("500ms")`,
+			latency: time.Second,
+			want:    false,
+		},
+		{
+			desc:    "json_null",
+			expr:    `json.status == "ok"`,
+			body:    []byte("not json"),
+			wantErr: true,
+		},
+		{
+			desc:        "non_bool",
+			expr:        "status + 1",
+			wantInitErr: true,
+		},
+		{
+			desc:        "compile_error",
+			expr:        "status ==",
+			wantInitErr: true,
+		},
+		{
+			desc:        "unknown_variable",
+			expr:        "code == 200",
+			wantInitErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := &Validator{}
+			err := v.Init(&configpb.Validator{Expression: test.expr}, &logger.Logger{})
+			if test.wantInitErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			got, err := v.Validate(test.resp, test.body, test.latency)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package proto
+
+// CEL (Common Expression Language) validator configuration.
+#Validator: {
+	// CEL expression that should evaluate to a boolean. Validation passes if
+	// expression evaluates to true. Following variables are available to the
+	// expression:
+	//   body:       response body (string).
+	//   json:       parsed response body, if it's a valid JSON, null otherwise.
+	//   status:     HTTP status code (int), 0 for non-HTTP probes.
+	//   headers:    HTTP response headers (map of string to string), with
+	//               lowercase names. Multiple values are joined using ", ".
+	//   latency:    request latency (duration), if available.
+	// Example:
+	//   expression: "status == 200 && json.items.size() > 0 && latency < duration('500ms')"
+	// See https://github.com/google/cel-spec for the language definition.
+	expression?: string @protobuf(1,string)
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cert provides a certificate chain validator for the Cloudprober's
+// validator framework.
+package cert
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/validators/cert/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// Names of the individual checks.
+const (
+	CheckIssuer             = "issuer"
+	CheckSAN                = "san"
+	CheckExpiry             = "expiry"
+	CheckKeySize            = "key_size"
+	CheckSignatureAlgorithm = "signature_algorithm"
+	CheckChain              = "chain"
+)
+
+// For testing.
+var timeNow = time.Now
+
+// Validator implements a certificate chain validator.
+type Validator struct {
+	c        *configpb.Validator
+	issuerRe *regexp.Regexp
+	sigAlgs  map[x509.SignatureAlgorithm]bool
+	roots    *x509.CertPool
+	checks   []string
+	l        *logger.Logger
+}
+
+// signatureAlgorithms returns the known signature algorithms, keyed by their
+// names.
+func signatureAlgorithms() map[string]x509.SignatureAlgorithm {
+	algs := make(map[string]x509.SignatureAlgorithm)
+	// Algorithms are not necessarily contiguous, e.g. retired ones may not
+	// have a name anymore, so we go well past the last known one.
+	for i := 1; i < 64; i++ {
+		alg := x509.SignatureAlgorithm(i)
+		// Unknown algorithms are stringified as their numeric values.
+		if alg.String() != strconv.Itoa(i) {
+			algs[alg.String()] = alg
+		}
+	}
+	return algs
+}
+
+// Init initializes the certificate chain validator.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	c, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid certificate validator config", config)
+	}
+	v.c = c
+	v.l = l
+
+	if c.GetIssuerRegex() != "" {
+		re, err := regexp.Compile(c.GetIssuerRegex())
+		if err != nil {
+			return fmt.Errorf("error compiling issuer regex (%s): %v", c.GetIssuerRegex(), err)
+		}
+		v.issuerRe = re
+		v.checks = append(v.checks, CheckIssuer)
+	}
+
+	if c.GetCheckSan() || len(c.GetSan()) != 0 {
+		v.checks = append(v.checks, CheckSAN)
+	}
+
+	if c.GetMinDaysToExpiry() != 0 {
+		v.checks = append(v.checks, CheckExpiry)
+	}
+
+	if c.GetMinRsaKeySize() != 0 || c.GetMinEcdsaKeySize() != 0 {
+		v.checks = append(v.checks, CheckKeySize)
+	}
+
+	if len(c.GetAllowedSignatureAlgorithm()) != 0 {
+		known := signatureAlgorithms()
+		v.sigAlgs = make(map[x509.SignatureAlgorithm]bool)
+		for _, name := range c.GetAllowedSignatureAlgorithm() {
+			alg, ok := known[name]
+			if !ok {
+				return fmt.Errorf("unknown signature algorithm: %s", name)
+			}
+			v.sigAlgs[alg] = true
+		}
+		v.checks = append(v.checks, CheckSignatureAlgorithm)
+	}
+
+	if c.GetCaCertFile() != "" && !c.GetVerifyChain() {
+		return errors.New("ca_cert_file is set but verify_chain is not enabled")
+	}
+	if c.GetVerifyChain() {
+		if c.GetCaCertFile() != "" {
+			caCert, err := file.ReadFile(c.GetCaCertFile())
+			if err != nil {
+				return fmt.Errorf("error reading CA cert file (%s): %v", c.GetCaCertFile(), err)
+			}
+			v.roots = x509.NewCertPool()
+			if !v.roots.AppendCertsFromPEM(caCert) {
+				return fmt.Errorf("error while adding CA certs from: %s", c.GetCaCertFile())
+			}
+		}
+		v.checks = append(v.checks, CheckChain)
+	}
+
+	if len(v.checks) == 0 {
+		return errors.New("no checks configured for the certificate validator")
+	}
+	return nil
+}
+
+// Checks returns the names of the configured checks.
+func (v *Validator) Checks() []string {
+	return v.checks
+}
+
+// connState returns the TLS connection state and the target name from the
+// probe response.
+func connState(resp interface{}) (*tls.ConnectionState, string, error) {
+	var state *tls.ConnectionState
+	var serverName string
+
+	switch r := resp.(type) {
+	case *http.Response:
+		if r == nil {
+			return nil, "", errors.New("no response")
+		}
+		state = r.TLS
+		if r.Request != nil && r.Request.URL != nil {
+			serverName = r.Request.URL.Hostname()
+		}
+	case *tls.ConnectionState:
+		state = r
+	case tls.ConnectionState:
+		state = &r
+	default:
+		return nil, "", fmt.Errorf("unsupported response type for certificate validation: %T", resp)
+	}
+
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil, "", errors.New("no TLS certificates in the response")
+	}
+	if state.ServerName != "" {
+		serverName = state.ServerName
+	}
+	return state, serverName, nil
+}
+
+func selfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject)
+}
+
+func (v *Validator) checkIssuer(certs []*x509.Certificate, _ string) error {
+	issuer := certs[0].Issuer.String()
+	if !v.issuerRe.MatchString(issuer) {
+		return fmt.Errorf("issuer (%s) doesn't match the regex (%s)", issuer, v.issuerRe.String())
+	}
+	return nil
+}
+
+func (v *Validator) checkSAN(certs []*x509.Certificate, serverName string) error {
+	names := v.c.GetSan()
+	if v.c.GetCheckSan() {
+		if serverName == "" {
+			return errors.New("target name is not known")
+		}
+		names = append([]string{serverName}, names...)
+	}
+	for _, name := range names {
+		if err := certs[0].VerifyHostname(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Validator) checkExpiry(certs []*x509.Certificate, _ string) error {
+	minNotAfter := timeNow().Add(time.Duration(v.c.GetMinDaysToExpiry()) * 24 * time.Hour)
+	for _, cert := range certs {
+		if cert.NotAfter.Before(minNotAfter) {
+			return fmt.Errorf("certificate (%s) expires at %s, less than %d days from now", cert.Subject, cert.NotAfter.Format(time.RFC3339), v.c.GetMinDaysToExpiry())
+		}
+	}
+	return nil
+}
+
+func (v *Validator) checkKeySize(certs []*x509.Certificate, _ string) error {
+	for _, cert := range certs {
+		switch pub := cert.PublicKey.(type) {
+		case *rsa.PublicKey:
+			if bits := pub.N.BitLen(); bits < int(v.c.GetMinRsaKeySize()) {
+				return fmt.Errorf("certificate (%s) RSA key size %d is less than %d", cert.Subject, bits, v.c.GetMinRsaKeySize())
+			}
+		case *ecdsa.PublicKey:
+			if bits := pub.Curve.Params().BitSize; bits < int(v.c.GetMinEcdsaKeySize()) {
+				return fmt.Errorf("certificate (%s) ECDSA key size %d is less than %d", cert.Subject, bits, v.c.GetMinEcdsaKeySize())
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Validator) checkSignatureAlgorithm(certs []*x509.Certificate, _ string) error {
+	for _, cert := range certs {
+		// Signatures of the self-signed roots are not used for verification.
+		if selfSigned(cert) {
+			continue
+		}
+		if !v.sigAlgs[cert.SignatureAlgorithm] {
+			return fmt.Errorf("certificate (%s) signature algorithm %s is not allowed", cert.Subject, cert.SignatureAlgorithm)
+		}
+	}
+	return nil
+}
+
+func (v *Validator) checkChain(certs []*x509.Certificate, _ string) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   timeNow(),
+	})
+	return err
+}
+
+// Validate validates the certificate chain of the TLS connection that
+// produced the response. It returns the names of the checks that failed.
+// Supported response types are *http.Response and *tls.ConnectionState.
+func (v *Validator) Validate(resp interface{}) ([]string, error) {
+	state, serverName, err := connState(resp)
+	if err != nil {
+		return nil, err
+	}
+	certs := state.PeerCertificates
+
+	checkFuncs := map[string]func([]*x509.Certificate, string) error{
+		CheckIssuer:             v.checkIssuer,
+		CheckSAN:                v.checkSAN,
+		CheckExpiry:             v.checkExpiry,
+		CheckKeySize:            v.checkKeySize,
+		CheckSignatureAlgorithm: v.checkSignatureAlgorithm,
+		CheckChain:              v.checkChain,
+	}
+
+	var failed []string
+	for _, check := range v.checks {
+		if err := checkFuncs[check](certs, serverName); err != nil {
+			v.l.Warningf("Certificate validation failure, check %s: %v", check, err)
+			failed = append(failed, check)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/cert/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testCert(t *testing.T, tmpl *x509.Certificate, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("error creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %v", err)
+	}
+	return cert
+}
+
+func caTemplate(cn string, serial int64) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"Cloudprober Test"}},
+		NotBefore:             testNow.Add(-24 * time.Hour),
+		NotAfter:              testNow.Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+}
+
+// testChain returns a leaf, intermediate and root certificate chain. Leaf
+// uses a 1024-bit RSA key and expires in 10 days.
+func testChain(t *testing.T) (leaf, intermediate, root *x509.Certificate) {
+	t.Helper()
+
+	rootKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	root = testCert(t, caTemplate("Test Root", 1), rootKey, nil, nil)
+
+	intKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	intermediate = testCert(t, caTemplate("Test Intermediate", 2), intKey, root, rootKey)
+
+	leafKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	leaf = testCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		DNSNames:     []string{"www.example.com", "*.api.example.com"},
+		NotBefore:    testNow.Add(-24 * time.Hour),
+		NotAfter:     testNow.Add(10 * 24 * time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, leafKey, intermediate, intKey)
+
+	return leaf, intermediate, root
+}
+
+func writeCAFile(t *testing.T, cert *x509.Certificate) string {
+	t.Helper()
+	f := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestValidate(t *testing.T) {
+	oldTimeNow := timeNow
+	timeNow = func() time.Time { return testNow }
+	defer func() { timeNow = oldTimeNow }()
+
+	leaf, intermediate, root := testChain(t)
+	caFile := writeCAFile(t, root)
+
+	fullChain := &tls.ConnectionState{
+		ServerName:       "www.example.com",
+		PeerCertificates: []*x509.Certificate{leaf, intermediate},
+	}
+	leafOnly := &tls.ConnectionState{
+		ServerName:       "www.example.com",
+		PeerCertificates: []*x509.Certificate{leaf},
+	}
+	httpResp := &http.Response{
+		TLS:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, intermediate}},
+		Request: &http.Request{URL: &url.URL{Host: "v1.api.example.com:443"}},
+	}
+
+	tests := []struct {
+		desc        string
+		conf        *configpb.Validator
+		resp        interface{}
+		wantChecks  []string
+		wantFailed  []string
+		wantInitErr bool
+		wantErr     bool
+	}{
+		{
+			desc:       "issuer_and_san",
+			conf:       &configpb.Validator{IssuerRegex: "CN=Test Intermediate", CheckSan: true},
+			resp:       fullChain,
+			wantChecks: []string{CheckIssuer, CheckSAN},
+		},
+		{
+			desc:       "issuer_mismatch",
+			conf:       &configpb.Validator{IssuerRegex: "Let's Encrypt"},
+			resp:       fullChain,
+			wantChecks: []string{CheckIssuer},
+			wantFailed: []string{CheckIssuer},
+		},
+		{
+			desc:       "san_from_http_url",
+			conf:       &configpb.Validator{CheckSan: true},
+			resp:       httpResp,
+			wantChecks: []string{CheckSAN},
+		},
+		{
+			desc:       "san_additional_names",
+			conf:       &configpb.Validator{San: []string{"www.example.com", "example.com"}},
+			resp:       fullChain,
+			wantChecks: []string{CheckSAN},
+			wantFailed: []string{CheckSAN},
+		},
+		{
+			desc:       "expiry",
+			conf:       &configpb.Validator{MinDaysToExpiry: 30},
+			resp:       fullChain,
+			wantChecks: []string{CheckExpiry},
+			wantFailed: []string{CheckExpiry},
+		},
+		{
+			desc:       "expiry_ok",
+			conf:       &configpb.Validator{MinDaysToExpiry: 7},
+			resp:       fullChain,
+			wantChecks: []string{CheckExpiry},
+		},
+		{
+			desc:       "rsa_key_size",
+			conf:       &configpb.Validator{MinRsaKeySize: 2048},
+			resp:       fullChain,
+			wantChecks: []string{CheckKeySize},
+			wantFailed: []string{CheckKeySize},
+		},
+		{
+			desc:       "ecdsa_key_size",
+			conf:       &configpb.Validator{MinRsaKeySize: 1024, MinEcdsaKeySize: 384},
+			resp:       fullChain,
+			wantChecks: []string{CheckKeySize},
+			wantFailed: []string{CheckKeySize},
+		},
+		{
+			desc:       "signature_algorithm",
+			conf:       &configpb.Validator{AllowedSignatureAlgorithm: []string{"ECDSA-SHA256", "ECDSA-SHA384"}},
+			resp:       fullChain,
+			wantChecks: []string{CheckSignatureAlgorithm},
+		},
+		{
+			desc:       "signature_algorithm_not_allowed",
+			conf:       &configpb.Validator{AllowedSignatureAlgorithm: []string{"SHA256-RSA"}},
+			resp:       fullChain,
+			wantChecks: []string{CheckSignatureAlgorithm},
+			wantFailed: []string{CheckSignatureAlgorithm},
+		},
+		{
+			desc:       "chain",
+			conf:       &configpb.Validator{VerifyChain: true, CaCertFile: caFile},
+			resp:       fullChain,
+			wantChecks: []string{CheckChain},
+		},
+		{
+			desc:       "chain_incomplete",
+			conf:       &configpb.Validator{VerifyChain: true, CaCertFile: caFile, CheckSan: true},
+			resp:       leafOnly,
+			wantChecks: []string{CheckSAN, CheckChain},
+			wantFailed: []string{CheckChain},
+		},
+		{
+			desc:    "no_tls",
+			conf:    &configpb.Validator{CheckSan: true},
+			resp:    &http.Response{},
+			wantErr: true,
+		},
+		{
+			desc:        "no_checks",
+			conf:        &configpb.Validator{},
+			wantInitErr: true,
+		},
+		{
+			desc:        "unknown_signature_algorithm",
+			conf:        &configpb.Validator{AllowedSignatureAlgorithm: []string{"SHA256"}},
+			wantInitErr: true,
+		},
+		{
+			desc:        "ca_cert_without_verify_chain",
+			conf:        &configpb.Validator{CaCertFile: caFile},
+			wantInitErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := &Validator{}
+			err := v.Init(test.conf, &logger.Logger{})
+			if test.wantInitErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			failed, err := v.Validate(test.resp)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantChecks, v.Checks())
+			assert.Equal(t, test.wantFailed, failed)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package proto
+
+// Certificate chain validator configuration. It validates the certificate
+// chain presented by the server in the TLS handshake. All configured checks
+// should pass for the validator to succeed. Failures of the individual checks
+// are also counted separately, in the validation_failure metric with
+// validator="<validator_name>.<check>".
+#Validator: {
+	// Regex that leaf certificate's issuer should match. Issuer is matched in
+	// its RFC 2253 string form, e.g. "CN=R3,O=Let's Encrypt,C=US".
+	// Check: issuer
+	issuerRegex?: string @protobuf(1,string,name=issuer_regex)
+
+	// Verify that the leaf certificate covers the target name. Target name is
+	// the TLS server name (SNI), or the request URL's host for HTTP probes.
+	// Check: san
+	checkSan?: bool @protobuf(2,bool,name=check_san)
+
+	// Additional names that the leaf certificate should cover.
+	// Check: san
+	san?: [...string] @protobuf(3,string)
+
+	// Minimum number of days before expiry, for all the certificates in the
+	// chain.
+	// Check: expiry
+	minDaysToExpiry?: int32 @protobuf(4,int32,name=min_days_to_expiry)
+
+	// Minimum key size in bits for RSA keys. ECDSA keys are checked against
+	// min_ecdsa_key_size.
+	// Check: key_size
+	minRsaKeySize?: int32 @protobuf(5,int32,name=min_rsa_key_size)
+
+	// Minimum key size (curve size) in bits for ECDSA keys.
+	// Check: key_size
+	minEcdsaKeySize?: int32 @protobuf(6,int32,name=min_ecdsa_key_size)
+
+	// Allowed signature algorithms, e.g. "SHA256-RSA", "ECDSA-SHA384". See
+	// https://pkg.go.dev/crypto/x509#SignatureAlgorithm for the names. Applies
+	// to all the certificates presented by the server.
+	// Check: signature_algorithm
+	allowedSignatureAlgorithm?: [...string] @protobuf(7,string,name=allowed_signature_algorithm)
+
+	// Verify that the presented certificates form a complete chain to a
+	// trusted root, even if the probe itself skips the TLS verification.
+	// Check: chain
+	verifyChain?: bool @protobuf(8,bool,name=verify_chain)
+
+	// CA certificate file to verify the chain against. System roots are used
+	// if not specified.
+	caCertFile?: string @protobuf(9,string,name=ca_cert_file)
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package command provides an external command validator for the
+// Cloudprober's validator framework.
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/command/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/google/shlex"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultMaxConcurrency = 10
+)
+
+// Validator implements an external command validator.
+type Validator struct {
+	cmdParts []string
+	timeout  time.Duration
+	outputRe *regexp.Regexp
+	sem      chan struct{}
+	l        *logger.Logger
+}
+
+// Init initializes the command validator.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	c, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid command validator config", config)
+	}
+
+	parts, err := shlex.Split(c.GetCommand())
+	if err != nil {
+		return fmt.Errorf("error parsing command (%s): %v", c.GetCommand(), err)
+	}
+	if len(parts) == 0 {
+		return errors.New("command is required")
+	}
+	v.cmdParts = parts
+
+	v.timeout = defaultTimeout
+	if c.GetTimeoutMsec() > 0 {
+		v.timeout = time.Duration(c.GetTimeoutMsec()) * time.Millisecond
+	}
+
+	maxConcurrency := defaultMaxConcurrency
+	if c.GetMaxConcurrency() > 0 {
+		maxConcurrency = int(c.GetMaxConcurrency())
+	}
+	v.sem = make(chan struct{}, maxConcurrency)
+
+	if c.GetOutputRegex() != "" {
+		if v.outputRe, err = regexp.Compile(c.GetOutputRegex()); err != nil {
+			return fmt.Errorf("error compiling output regex (%s): %v", c.GetOutputRegex(), err)
+		}
+	}
+
+	v.l = l
+	return nil
+}
+
+func env(resp interface{}, latency time.Duration) []string {
+	env := os.Environ()
+	if latency > 0 {
+		env = append(env, "CLOUDPROBER_RESPONSE_LATENCY_MSEC="+strconv.FormatInt(latency.Milliseconds(), 10))
+	}
+	if httpResp, ok := resp.(*http.Response); ok && httpResp != nil {
+		env = append(env, "CLOUDPROBER_HTTP_STATUS_CODE="+strconv.Itoa(httpResp.StatusCode))
+	}
+	return env
+}
+
+// Validate runs the command with the response body on its standard input. It
+// returns an error if the command couldn't be run.
+func (v *Validator) Validate(resp interface{}, body []byte, latency time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+
+	select {
+	case v.sem <- struct{}{}:
+		defer func() { <-v.sem }()
+	case <-ctx.Done():
+		return false, fmt.Errorf("timed out waiting for a command slot, %d commands already running", cap(v.sem))
+	}
+
+	cmd := exec.CommandContext(ctx, v.cmdParts[0], v.cmdParts[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = env(resp, latency)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		v.l.Warningf("Command validation failure: command timed out after %v", v.timeout)
+		return false, nil
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return false, fmt.Errorf("error running command (%v): %v", v.cmdParts, err)
+		}
+		v.l.Warningf("Command validation failure: command exited with status %d, stderr: %s", exitErr.ExitCode(), stderr.String())
+		return false, nil
+	}
+
+	if v.outputRe != nil && !v.outputRe.Match(stdout.Bytes()) {
+		v.l.Warningf("Command validation failure: output (%s) doesn't match the regex (%s)", stdout.String(), v.outputRe.String())
+		return false, nil
+	}
+	return true, nil
+}
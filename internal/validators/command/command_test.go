@@ -0,0 +1,130 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/command/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping command validator test on Windows")
+	}
+
+	tests := []struct {
+		desc        string
+		conf        *configpb.Validator
+		resp        interface{}
+		body        string
+		latency     time.Duration
+		want        bool
+		wantErr     bool
+		wantInitErr bool
+	}{
+		{
+			desc: "exit_0",
+			conf: &configpb.Validator{Command: `sh -c "grep -q ok"`},
+			body: "status: ok",
+			want: true,
+		},
+		{
+			desc: "exit_1",
+			conf: &configpb.Validator{Command: `sh -c "grep -q ok"`},
+			body: "status: down",
+			want: false,
+		},
+		{
+			desc:    "env",
+			conf:    &configpb.Validator{Command: `sh -c 'test "$CLOUDPROBER_HTTP_STATUS_CODE" = 200 && test "$CLOUDPROBER_RESPONSE_LATENCY_MSEC" = 150'`},
+			resp:    &http.Response{StatusCode: 200},
+			latency: 150 * time.Millisecond,
+			want:    true,
+		},
+		{
+			desc: "output_regex",
+			conf: &configpb.Validator{Command: "cat", OutputRegex: "^items: [1-9]"},
+			body: "items: 5",
+			want: true,
+		},
+		{
+			desc: "output_regex_mismatch",
+			conf: &configpb.Validator{Command: "cat", OutputRegex: "^items: [1-9]"},
+			body: "items: 0",
+			want: false,
+		},
+		{
+			desc: "timeout",
+			conf: &configpb.Validator{Command: "sleep 5", TimeoutMsec: 100},
+			want: false,
+		},
+		{
+			desc:    "command_not_found",
+			conf:    &configpb.Validator{Command: "/nonexistent/validator"},
+			wantErr: true,
+		},
+		{
+			desc:        "empty_command",
+			conf:        &configpb.Validator{},
+			wantInitErr: true,
+		},
+		{
+			desc:        "bad_quoting",
+			conf:        &configpb.Validator{Command: `sh -c "unterminated`},
+			wantInitErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := &Validator{}
+			err := v.Init(test.conf, &logger.Logger{})
+			if test.wantInitErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			got, err := v.Validate(test.resp, []byte(test.body), test.latency)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestValidateConcurrencyLimit(t *testing.T) {
+	v := &Validator{}
+	assert.NoError(t, v.Init(&configpb.Validator{Command: "true", TimeoutMsec: 100, MaxConcurrency: 1}, &logger.Logger{}))
+
+	// Occupy the only slot.
+	v.sem <- struct{}{}
+	_, err := v.Validate(nil, nil, 0)
+	assert.Error(t, err)
+
+	<-v.sem
+	got, err := v.Validate(nil, nil, 0)
+	assert.NoError(t, err)
+	assert.True(t, got)
+}
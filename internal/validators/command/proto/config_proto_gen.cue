@@ -0,0 +1,28 @@
+package proto
+
+// Command validator runs an external command with the response payload on
+// its standard input. Validation succeeds if the command exits with status 0
+// (and, if output_regex is set, its output matches the regex). This is
+// mainly meant to reuse the existing validation scripts.
+//
+// Following environment variables are set for the command:
+//   CLOUDPROBER_RESPONSE_LATENCY_MSEC: request latency, if available.
+//   CLOUDPROBER_HTTP_STATUS_CODE: HTTP status code, for HTTP probes.
+#Validator: {
+	// Command to run, e.g. "/opt/validators/check_inventory.sh --strict".
+	// Command line is split using shell quoting rules, but it's not run
+	// through a shell.
+	command?: string @protobuf(1,string)
+
+	// Command timeout in milliseconds. Command is killed, and validation fails,
+	// if it doesn't finish within the timeout. Default is 10s.
+	timeoutMsec?: int32 @protobuf(2,int32,name=timeout_msec)
+
+	// Maximum number of command instances running at the same time. If the
+	// limit is reached, validator waits for a slot until the timeout, and
+	// reports an error if it doesn't get one. Default is 10.
+	maxConcurrency?: int32 @protobuf(3,int32,name=max_concurrency)
+
+	// If set, command's standard output should also match this regex.
+	outputRegex?: string @protobuf(4,string,name=output_regex)
+}
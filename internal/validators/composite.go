@@ -0,0 +1,97 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"fmt"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// initComposite initializes a composite validator. Child validators are
+// exposed as checks of the composite validator, with keys "<child>" and
+// "<child>.<check>" for the child's own checks.
+func initComposite(validator *Validator, c *configpb.CompositeValidator, l *logger.Logger) (*Validator, error) {
+	children, err := Init(c.GetValidator(), l)
+	if err != nil {
+		return nil, fmt.Errorf("composite validator %s: %v", validator.Name, err)
+	}
+
+	switch c.GetOperator() {
+	case configpb.CompositeValidator_NOT:
+		if len(children) != 1 {
+			return nil, fmt.Errorf("composite validator %s: NOT requires exactly one validator, got %d", validator.Name, len(children))
+		}
+	default:
+		if len(children) == 0 {
+			return nil, fmt.Errorf("composite validator %s: no validators configured", validator.Name)
+		}
+	}
+
+	for _, child := range children {
+		validator.Checks = append(validator.Checks, child.Name)
+		for _, check := range child.Checks {
+			validator.Checks = append(validator.Checks, child.Name+"."+check)
+		}
+	}
+
+	op := c.GetOperator()
+	// All children are evaluated, without short-circuiting, so that their
+	// results are always reported.
+	validator.validateChecks = func(input *Input) (bool, []string, error) {
+		var failedChecks []string
+		var results []bool
+
+		for _, child := range children {
+			success, childFailedChecks, err := child.run(input)
+			if err != nil {
+				return false, nil, fmt.Errorf("%s: %v", child.Name, err)
+			}
+			if !success {
+				failedChecks = append(failedChecks, child.Name)
+			}
+			for _, check := range childFailedChecks {
+				failedChecks = append(failedChecks, child.Name+"."+check)
+			}
+			results = append(results, success)
+		}
+
+		switch op {
+		case configpb.CompositeValidator_OR:
+			for _, r := range results {
+				if r {
+					return true, failedChecks, nil
+				}
+			}
+			return false, failedChecks, nil
+		case configpb.CompositeValidator_NOT:
+			return !results[0], failedChecks, nil
+		default:
+			for _, r := range results {
+				if !r {
+					return false, failedChecks, nil
+				}
+			}
+			return true, failedChecks, nil
+		}
+	}
+	validator.Validate = func(input *Input) (bool, error) {
+		success, _, err := validator.validateChecks(input)
+		return success, err
+	}
+
+	return validator, nil
+}
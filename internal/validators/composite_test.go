@@ -0,0 +1,129 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"net/http"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+const testCompositeConf = `
+	name: "healthy"
+	composite_validator {
+		operator: AND
+		validator {
+			name: "status_200"
+			http_validator {
+				success_status_codes: "200"
+			}
+		}
+		validator {
+			name: "a_or_b"
+			composite_validator {
+				operator: OR
+				validator { name: "a" regex: "A" }
+				validator { name: "b" regex: "B" }
+			}
+		}
+		validator {
+			name: "not_c"
+			composite_validator {
+				operator: NOT
+				validator { name: "c" regex: "C" }
+			}
+		}
+	}
+`
+
+func TestCompositeValidator(t *testing.T) {
+	conf := &configpb.Validator{}
+	assert.NoError(t, prototext.Unmarshal([]byte(testCompositeConf), conf))
+
+	vs, err := Init([]*configpb.Validator{conf}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"status_200", "a_or_b", "a_or_b.a", "a_or_b.b", "not_c", "not_c.c"}, vs[0].Checks)
+
+	tests := []struct {
+		body         string
+		status       int
+		wantSuccess  bool
+		wantFailures map[string]int64
+	}{
+		{
+			body:        "A",
+			status:      200,
+			wantSuccess: true,
+			wantFailures: map[string]int64{
+				"healthy.a_or_b.b": 1,
+				"healthy.not_c.c":  1,
+			},
+		},
+		{
+			body:        "BC",
+			status:      200,
+			wantSuccess: false,
+			wantFailures: map[string]int64{
+				"healthy":          1,
+				"healthy.a_or_b.a": 1,
+				"healthy.not_c":    1,
+			},
+		},
+		{
+			body:        "D",
+			status:      500,
+			wantSuccess: false,
+			wantFailures: map[string]int64{
+				"healthy":            1,
+				"healthy.status_200": 1,
+				"healthy.a_or_b":     1,
+				"healthy.a_or_b.a":   1,
+				"healthy.a_or_b.b":   1,
+				"healthy.not_c.c":    1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			vfMap := ValidationFailureMap(vs)
+			input := &Input{Response: &http.Response{StatusCode: test.status}, ResponseBody: []byte(test.body)}
+
+			failures := RunValidators(vs, input, vfMap, nil)
+			assert.Equal(t, test.wantSuccess, len(failures) == 0)
+
+			for _, k := range vfMap.Keys() {
+				assert.Equal(t, test.wantFailures[k], vfMap.GetKey(k), "key: %s", k)
+			}
+		})
+	}
+}
+
+func TestCompositeValidatorInitErrors(t *testing.T) {
+	for _, conf := range []string{
+		`name: "not" composite_validator { operator: NOT }`,
+		`name: "and" composite_validator { operator: AND }`,
+		`name: "dup" composite_validator { validator { name: "a" regex: "A" } validator { name: "a" regex: "B" } }`,
+		`name: "bad_child" composite_validator { validator { regex: "A" } }`,
+	} {
+		c := &configpb.Validator{}
+		assert.NoError(t, prototext.Unmarshal([]byte(conf), c))
+		_, err := Init([]*configpb.Validator{c}, nil)
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/validators/integrity/proto"
+)
+
+const defaultChecksumRefreshInterval = 300 * time.Second
+
+// checksum verifies the checksum of the payload.
+type checksum struct {
+	c               *configpb.Checksum
+	refreshInterval time.Duration
+	httpClient      *http.Client
+
+	mu          sync.Mutex
+	expected    string
+	lastRefresh time.Time
+}
+
+func newChecksum(c *configpb.Checksum) (*checksum, error) {
+	cs := &checksum{
+		c:               c,
+		refreshInterval: defaultChecksumRefreshInterval,
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+	}
+	if c.GetRefreshIntervalSec() > 0 {
+		cs.refreshInterval = time.Duration(c.GetRefreshIntervalSec()) * time.Second
+	}
+
+	switch c.GetExpectedSource().(type) {
+	case *configpb.Checksum_Expected:
+		expected, err := cs.parseExpected([]byte(c.GetExpected()))
+		if err != nil {
+			return nil, err
+		}
+		cs.expected = expected
+	case *configpb.Checksum_ExpectedUrl:
+		if c.GetExpectedUrl() == "" {
+			return nil, errors.New("checksum expected_url cannot be empty")
+		}
+	default:
+		return nil, errors.New("one of checksum expected or expected_url should be set")
+	}
+	return cs, nil
+}
+
+func (cs *checksum) compute(data []byte) string {
+	if cs.c.GetAlgorithm() == configpb.Checksum_CRC32 {
+		return fmt.Sprintf("%08x", crc32.ChecksumIEEE(data))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseExpected parses the expected checksum: first whitespace separated
+// token, hex-encoded, of the right length for the algorithm.
+func (cs *checksum) parseExpected(b []byte) (string, error) {
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return "", errors.New("expected checksum is empty")
+	}
+	expected := strings.ToLower(fields[0])
+
+	wantLen := 2 * sha256.Size
+	if cs.c.GetAlgorithm() == configpb.Checksum_CRC32 {
+		wantLen = 2 * crc32.Size
+	}
+	if _, err := hex.DecodeString(expected); err != nil || len(expected) != wantLen {
+		return "", fmt.Errorf("invalid %v checksum: %s", cs.c.GetAlgorithm(), expected)
+	}
+	return expected, nil
+}
+
+func (cs *checksum) fetch(loc string) ([]byte, error) {
+	if !strings.HasPrefix(loc, "http://") && !strings.HasPrefix(loc, "https://") {
+		return file.ReadFile(loc)
+	}
+
+	resp, err := cs.httpClient.Get(loc)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got HTTP status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 4096))
+}
+
+// expectedChecksum returns the expected checksum, refreshing it from
+// expected_url if it's time to do so. If refresh fails, last known checksum
+// is used.
+func (cs *checksum) expectedChecksum() (string, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.c.GetExpectedUrl() == "" || time.Since(cs.lastRefresh) < cs.refreshInterval {
+		return cs.expected, nil
+	}
+
+	b, err := cs.fetch(cs.c.GetExpectedUrl())
+	if err == nil {
+		var expected string
+		if expected, err = cs.parseExpected(b); err == nil {
+			cs.expected, cs.lastRefresh = expected, time.Now()
+			return cs.expected, nil
+		}
+	}
+
+	if cs.expected == "" {
+		return "", fmt.Errorf("error getting expected checksum from %s: %v", cs.c.GetExpectedUrl(), err)
+	}
+	// Keep using the last known checksum until the next refresh.
+	cs.lastRefresh = time.Now()
+	return cs.expected, nil
+}
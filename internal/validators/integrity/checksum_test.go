@@ -0,0 +1,138 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/integrity/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPayload = []byte("cloudprober artifact\n")
+
+func checksumValidator(t *testing.T, c *configpb.Checksum) *Validator {
+	t.Helper()
+	v := &Validator{}
+	if err := v.Init(&configpb.Validator{Pattern: &configpb.Validator_Checksum{Checksum: c}}, &logger.Logger{}); err != nil {
+		t.Fatalf("Error initializing validator: %v", err)
+	}
+	return v
+}
+
+func TestChecksumExpected(t *testing.T) {
+	sha := (&checksum{c: &configpb.Checksum{}}).compute(testPayload)
+	crc := (&checksum{c: &configpb.Checksum{Algorithm: configpb.Checksum_CRC32}}).compute(testPayload)
+	assert.Len(t, sha, 64)
+	assert.Len(t, crc, 8)
+
+	tests := []struct {
+		desc string
+		c    *configpb.Checksum
+		want bool
+	}{
+		{
+			desc: "sha256",
+			c:    &configpb.Checksum{ExpectedSource: &configpb.Checksum_Expected{Expected: sha}},
+			want: true,
+		},
+		{
+			desc: "sha256sum_format",
+			c:    &configpb.Checksum{ExpectedSource: &configpb.Checksum_Expected{Expected: "  " + sha + "  artifact.tar.gz\n"}},
+			want: true,
+		},
+		{
+			desc: "crc32",
+			c:    &configpb.Checksum{Algorithm: configpb.Checksum_CRC32, ExpectedSource: &configpb.Checksum_Expected{Expected: crc}},
+			want: true,
+		},
+		{
+			desc: "crc32_mismatch",
+			c:    &configpb.Checksum{Algorithm: configpb.Checksum_CRC32, ExpectedSource: &configpb.Checksum_Expected{Expected: "00000000"}},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := checksumValidator(t, test.c)
+			got, err := v.Validate(testPayload)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	for _, c := range []*configpb.Checksum{
+		{},
+		{ExpectedSource: &configpb.Checksum_Expected{Expected: crc}},
+		{ExpectedSource: &configpb.Checksum_Expected{Expected: "zz" + sha[2:]}},
+		{ExpectedSource: &configpb.Checksum_ExpectedUrl{}},
+	} {
+		v := &Validator{}
+		assert.Error(t, v.Init(&configpb.Validator{Pattern: &configpb.Validator_Checksum{Checksum: c}}, &logger.Logger{}), c.String())
+	}
+}
+
+func TestChecksumExpectedURL(t *testing.T) {
+	sha := (&checksum{c: &configpb.Checksum{}}).compute(testPayload)
+
+	sidecar := sha + "  artifact.tar.gz\n"
+	fetches := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if sidecar == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(sidecar))
+	}))
+	defer ts.Close()
+
+	v := checksumValidator(t, &configpb.Checksum{ExpectedSource: &configpb.Checksum_ExpectedUrl{ExpectedUrl: ts.URL}})
+	got, err := v.Validate(testPayload)
+	assert.NoError(t, err)
+	assert.True(t, got)
+
+	got, _ = v.Validate([]byte("corrupted"))
+	assert.False(t, got)
+	assert.Equal(t, 1, fetches, "expected checksum should be cached")
+
+	// Failed refresh: last known checksum is used.
+	sidecar = ""
+	v.checksum.lastRefresh = time.Time{}
+	got, err = v.Validate(testPayload)
+	assert.NoError(t, err)
+	assert.True(t, got)
+	assert.Equal(t, 2, fetches)
+
+	// No known checksum: error.
+	v = checksumValidator(t, &configpb.Checksum{ExpectedSource: &configpb.Checksum_ExpectedUrl{ExpectedUrl: ts.URL}})
+	_, err = v.Validate(testPayload)
+	assert.Error(t, err)
+
+	// Local file.
+	f := filepath.Join(t.TempDir(), "artifact.sha256")
+	assert.NoError(t, os.WriteFile(f, []byte(sha+"\n"), 0600))
+	v = checksumValidator(t, &configpb.Checksum{ExpectedSource: &configpb.Checksum_ExpectedUrl{ExpectedUrl: f}})
+	got, err = v.Validate(testPayload)
+	assert.NoError(t, err)
+	assert.True(t, got)
+}
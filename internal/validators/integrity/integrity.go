@@ -28,6 +28,7 @@ import (
 type Validator struct {
 	pattern         []byte
 	patternNumBytes int32
+	checksum        *checksum
 
 	l *logger.Logger
 }
@@ -44,10 +45,16 @@ func (v *Validator) Init(config interface{}, l *logger.Logger) error {
 		v.pattern = []byte(c.GetPatternString())
 	case *configpb.Validator_PatternNumBytes:
 		v.patternNumBytes = c.GetPatternNumBytes()
+	case *configpb.Validator_Checksum:
+		cs, err := newChecksum(c.GetChecksum())
+		if err != nil {
+			return fmt.Errorf("bad integrity validator config: %v", err)
+		}
+		v.checksum = cs
 	}
 
-	if len(v.pattern) == 0 && v.patternNumBytes == 0 {
-		return fmt.Errorf("bad integrity validator config (%v): one of pattern_string and pattern_num_bytes should be set", c)
+	if len(v.pattern) == 0 && v.patternNumBytes == 0 && v.checksum == nil {
+		return fmt.Errorf("bad integrity validator config (%v): one of pattern_string, pattern_num_bytes and checksum should be set", c)
 	}
 
 	v.l = l
@@ -57,6 +64,18 @@ func (v *Validator) Init(config interface{}, l *logger.Logger) error {
 // Validate validates the provided responseBody for data integrity errors, for
 // example, data corruption.
 func (v *Validator) Validate(responseBody []byte) (bool, error) {
+	if v.checksum != nil {
+		expected, err := v.checksum.expectedChecksum()
+		if err != nil {
+			return false, err
+		}
+		if got := v.checksum.compute(responseBody); got != expected {
+			v.l.Warningf("Integrity validation failure: %v checksum mismatch, got: %s, expected: %s", v.checksum.c.GetAlgorithm(), got, expected)
+			return false, nil
+		}
+		return true, nil
+	}
+
 	pattern := v.pattern
 	if len(pattern) == 0 {
 		if len(responseBody) < int(v.patternNumBytes) {
@@ -20,6 +20,52 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Checksum_Algorithm int32
+
+const (
+	Checksum_SHA256 Checksum_Algorithm = 0
+	Checksum_CRC32  Checksum_Algorithm = 1 // IEEE polynomial, as used by zip, gzip and PNG.
+)
+
+// Enum value maps for Checksum_Algorithm.
+var (
+	Checksum_Algorithm_name = map[int32]string{
+		0: "SHA256",
+		1: "CRC32",
+	}
+	Checksum_Algorithm_value = map[string]int32{
+		"SHA256": 0,
+		"CRC32":  1,
+	}
+)
+
+func (x Checksum_Algorithm) Enum() *Checksum_Algorithm {
+	p := new(Checksum_Algorithm)
+	*p = x
+	return p
+}
+
+func (x Checksum_Algorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Checksum_Algorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (Checksum_Algorithm) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_enumTypes[0]
+}
+
+func (x Checksum_Algorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Checksum_Algorithm.Descriptor instead.
+func (Checksum_Algorithm) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_rawDescGZIP(), []int{1, 0}
+}
+
 type Validator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -37,6 +83,7 @@ type Validator struct {
 	//
 	//	*Validator_PatternString
 	//	*Validator_PatternNumBytes
+	//	*Validator_Checksum
 	Pattern isValidator_Pattern `protobuf_oneof:"pattern"`
 }
 
@@ -93,6 +140,13 @@ func (x *Validator) GetPatternNumBytes() int32 {
 	return 0
 }
 
+func (x *Validator) GetChecksum() *Checksum {
+	if x, ok := x.GetPattern().(*Validator_Checksum); ok {
+		return x.Checksum
+	}
+	return nil
+}
+
 type isValidator_Pattern interface {
 	isValidator_Pattern()
 }
@@ -111,10 +165,123 @@ type Validator_PatternNumBytes struct {
 	PatternNumBytes int32 `protobuf:"varint,2,opt,name=pattern_num_bytes,json=patternNumBytes,proto3,oneof"`
 }
 
+type Validator_Checksum struct {
+	// Validate the response against an expected checksum, e.g. to verify
+	// artifact mirrors or CDN content.
+	Checksum *Checksum `protobuf:"bytes,3,opt,name=checksum,proto3,oneof"`
+}
+
 func (*Validator_PatternString) isValidator_Pattern() {}
 
 func (*Validator_PatternNumBytes) isValidator_Pattern() {}
 
+func (*Validator_Checksum) isValidator_Pattern() {}
+
+type Checksum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm Checksum_Algorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=cloudprober.validators.integrity.Checksum_Algorithm" json:"algorithm,omitempty"`
+	// Types that are assignable to ExpectedSource:
+	//
+	//	*Checksum_Expected
+	//	*Checksum_ExpectedUrl
+	ExpectedSource isChecksum_ExpectedSource `protobuf_oneof:"expected_source"`
+	// How often to refresh the expected checksum from expected_url. Default is
+	// 300s.
+	RefreshIntervalSec int32 `protobuf:"varint,4,opt,name=refresh_interval_sec,json=refreshIntervalSec,proto3" json:"refresh_interval_sec,omitempty"`
+}
+
+func (x *Checksum) Reset() {
+	*x = Checksum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Checksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checksum) ProtoMessage() {}
+
+func (x *Checksum) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checksum.ProtoReflect.Descriptor instead.
+func (*Checksum) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Checksum) GetAlgorithm() Checksum_Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return Checksum_SHA256
+}
+
+func (m *Checksum) GetExpectedSource() isChecksum_ExpectedSource {
+	if m != nil {
+		return m.ExpectedSource
+	}
+	return nil
+}
+
+func (x *Checksum) GetExpected() string {
+	if x, ok := x.GetExpectedSource().(*Checksum_Expected); ok {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *Checksum) GetExpectedUrl() string {
+	if x, ok := x.GetExpectedSource().(*Checksum_ExpectedUrl); ok {
+		return x.ExpectedUrl
+	}
+	return ""
+}
+
+func (x *Checksum) GetRefreshIntervalSec() int32 {
+	if x != nil {
+		return x.RefreshIntervalSec
+	}
+	return 0
+}
+
+type isChecksum_ExpectedSource interface {
+	isChecksum_ExpectedSource()
+}
+
+type Checksum_Expected struct {
+	// Expected checksum, hex-encoded.
+	Expected string `protobuf:"bytes,2,opt,name=expected,proto3,oneof"`
+}
+
+type Checksum_ExpectedUrl struct {
+	// Location of the file containing the expected checksum, e.g.
+	// "https://mirror.example.com/pkg.tar.gz.sha256". It can be an HTTP(S)
+	// URL, a GCS path (gs://), or a local file. The first whitespace
+	// separated token of the file is used, so the sha256sum output format
+	// works.
+	ExpectedUrl string `protobuf:"bytes,3,opt,name=expected_url,json=expectedUrl,proto3,oneof"`
+}
+
+func (*Checksum_Expected) isChecksum_ExpectedSource() {}
+
+func (*Checksum_ExpectedUrl) isChecksum_ExpectedSource() {}
+
 var File_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_rawDesc = []byte{
@@ -125,19 +292,40 @@ var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_
 	0x74, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x20, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
 	0x65, 0x72, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x69, 0x6e,
-	0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x22, 0x6d, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0e, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f,
-	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d,
-	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a,
-	0x11, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x74, 0x74,
-	0x65, 0x72, 0x6e, 0x4e, 0x75, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x70,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x22, 0xb7, 0x01, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0e, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0d, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2c,
+	0x0a, 0x11, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x4e, 0x75, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74,
+	0x79, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x48, 0x00, 0x52, 0x08, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x22, 0x8a, 0x02, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x52,
+	0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x34, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x67,
+	0x72, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x2e, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x12, 0x1c, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x23, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x22, 0x22, 0x0a, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x43, 0x52, 0x43, 0x33, 0x32, 0x10, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x48,
+	0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69,
+	0x74, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -152,16 +340,21 @@ func file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto
 	return file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_goTypes = []interface{}{
-	(*Validator)(nil), // 0: cloudprober.validators.integrity.Validator
+	(Checksum_Algorithm)(0), // 0: cloudprober.validators.integrity.Checksum.Algorithm
+	(*Validator)(nil),       // 1: cloudprober.validators.integrity.Validator
+	(*Checksum)(nil),        // 2: cloudprober.validators.integrity.Checksum
 }
 var file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: cloudprober.validators.integrity.Validator.checksum:type_name -> cloudprober.validators.integrity.Checksum
+	0, // 1: cloudprober.validators.integrity.Checksum.algorithm:type_name -> cloudprober.validators.integrity.Checksum.Algorithm
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() {
@@ -184,23 +377,41 @@ func file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checksum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Validator_PatternString)(nil),
 		(*Validator_PatternNumBytes)(nil),
+		(*Validator_Checksum)(nil),
+	}
+	file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Checksum_Expected)(nil),
+		(*Checksum_ExpectedUrl)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_internal_validators_integrity_proto_config_proto = out.File
@@ -18,5 +18,40 @@ package proto
 		// ping probe repeates the timestamp (8 bytes) in the packet payload.
 		// An error is returned if response is smaller than pattern_num_bytes.
 		patternNumBytes: int32 @protobuf(2,int32,name=pattern_num_bytes)
+	} | {
+		// Validate the response against an expected checksum, e.g. to verify
+		// artifact mirrors or CDN content.
+		checksum: #Checksum @protobuf(3,Checksum)
+	}
+}
+
+#Checksum: {
+	#Algorithm: {
+		"SHA256"
+		#enumValue: 0
+	} | {
+		"CRC32"// IEEE polynomial, as used by zip, gzip and PNG.
+		#enumValue: 1
 	}
+
+	#Algorithm_value: {
+		SHA256: 0
+		CRC32:  1
+	}
+	algorithm?: #Algorithm @protobuf(1,Algorithm)
+	{} | {
+		// Expected checksum, hex-encoded.
+		expected: string @protobuf(2,string)
+	} | {
+		// Location of the file containing the expected checksum, e.g.
+		// "https://mirror.example.com/pkg.tar.gz.sha256". It can be an HTTP(S)
+		// URL, a GCS path (gs://), or a local file. The first whitespace
+		// separated token of the file is used, so the sha256sum output format
+		// works.
+		expectedUrl: string @protobuf(3,string,name=expected_url)
+	}
+
+	// How often to refresh the expected checksum from expected_url. Default is
+	// 300s.
+	refreshIntervalSec?: int32 @protobuf(4,int32,name=refresh_interval_sec)
 }
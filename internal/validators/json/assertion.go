@@ -0,0 +1,272 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/json/proto"
+)
+
+// pathStep is a step in a JSONPath expression: an object member, an array
+// index, or a wildcard.
+type pathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parsePath parses a (subset of) JSONPath expression into steps.
+func parsePath(path string) ([]pathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path (%s) must start with $", path)
+	}
+
+	var steps []pathStep
+	for s := path[1:]; s != ""; {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			return nil, fmt.Errorf("recursive descent (..) is not supported: %s", path)
+
+		case s[0] == '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			name := s[:end]
+			if name == "" {
+				return nil, fmt.Errorf("empty member name in path: %s", path)
+			}
+			if name == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+			} else {
+				steps = append(steps, pathStep{key: name})
+			}
+			s = s[end:]
+
+		case s[0] == '[':
+			end := strings.Index(s, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("missing ] in path: %s", path)
+			}
+			sel := s[1:end]
+			// Quoted names may contain "]", find the closing quote first.
+			if len(sel) > 0 && (sel[0] == '\'' || sel[0] == '"') {
+				closing := strings.IndexByte(s[2:], sel[0])
+				if closing == -1 || !strings.HasPrefix(s[2+closing+1:], "]") {
+					return nil, fmt.Errorf("invalid quoted name in path: %s", path)
+				}
+				steps = append(steps, pathStep{key: s[2 : 2+closing]})
+				s = s[2+closing+2:]
+				continue
+			}
+			if sel == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+			} else {
+				i, err := strconv.Atoi(sel)
+				if err != nil {
+					return nil, fmt.Errorf("invalid array index (%s) in path: %s", sel, path)
+				}
+				steps = append(steps, pathStep{index: i, isIndex: true})
+			}
+			s = s[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected character (%c) in path: %s", s[0], path)
+		}
+	}
+	return steps, nil
+}
+
+// evalPath returns the values selected by the given steps.
+func evalPath(steps []pathStep, input interface{}) []interface{} {
+	values := []interface{}{input}
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range values {
+			switch tv := v.(type) {
+			case map[string]interface{}:
+				if step.wildcard {
+					// Sort keys for a deterministic order.
+					keys := make([]string, 0, len(tv))
+					for k := range tv {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						next = append(next, tv[k])
+					}
+				} else if mv, ok := tv[step.key]; ok && !step.isIndex {
+					next = append(next, mv)
+				}
+			case []interface{}:
+				if step.wildcard {
+					next = append(next, tv...)
+				} else if step.isIndex {
+					i := step.index
+					if i < 0 {
+						i += len(tv)
+					}
+					if i >= 0 && i < len(tv) {
+						next = append(next, tv[i])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// Path is a compiled JSONPath expression. It supports the same subset of
+// JSONPath as the JSON validator assertions.
+type Path struct {
+	steps []pathStep
+}
+
+// CompilePath compiles the given JSONPath expression.
+func CompilePath(path string) (*Path, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{steps: steps}, nil
+}
+
+// Select returns the values selected by the path from the parsed JSON input.
+func (p *Path) Select(input interface{}) []interface{} {
+	return evalPath(p.steps, input)
+}
+
+// assertion is a parsed JSON validator assertion.
+type assertion struct {
+	c     *configpb.Assertion
+	steps []pathStep
+	re    *regexp.Regexp
+}
+
+func newAssertion(c *configpb.Assertion) (*assertion, error) {
+	steps, err := parsePath(c.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	a := &assertion{c: c, steps: steps}
+
+	if c.GetRegex() != "" {
+		if a.re, err = regexp.Compile(c.GetRegex()); err != nil {
+			return nil, fmt.Errorf("error compiling regex (%s): %v", c.GetRegex(), err)
+		}
+	}
+	return a, nil
+}
+
+// stringValue returns the string for the string values, and JSON
+// representation for the other values.
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func numberValue(v interface{}) (float64, bool) {
+	switch tv := v.(type) {
+	case float64:
+		return tv, true
+	case string:
+		f, err := strconv.ParseFloat(tv, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func lengthValue(v interface{}) (int64, bool) {
+	switch tv := v.(type) {
+	case []interface{}:
+		return int64(len(tv)), true
+	case map[string]interface{}:
+		return int64(len(tv)), true
+	case string:
+		return int64(utf8.RuneCountInString(tv)), true
+	}
+	return 0, false
+}
+
+func (a *assertion) checkValue(v interface{}) error {
+	c := a.c
+	s := stringValue(v)
+
+	if c.Equals != nil && s != c.GetEquals() {
+		return fmt.Errorf("value %s is not equal to %s", s, c.GetEquals())
+	}
+
+	if a.re != nil && !a.re.MatchString(s) {
+		return fmt.Errorf("value %s doesn't match the regex %s", s, a.re.String())
+	}
+
+	if c.Min != nil || c.Max != nil {
+		f, ok := numberValue(v)
+		if !ok {
+			return fmt.Errorf("value %s is not a number", s)
+		}
+		if c.Min != nil && f < c.GetMin() {
+			return fmt.Errorf("value %s is less than %v", s, c.GetMin())
+		}
+		if c.Max != nil && f > c.GetMax() {
+			return fmt.Errorf("value %s is greater than %v", s, c.GetMax())
+		}
+	}
+
+	if c.Length != nil || c.MinLength != nil || c.MaxLength != nil {
+		n, ok := lengthValue(v)
+		if !ok {
+			return fmt.Errorf("value %s doesn't have a length", s)
+		}
+		if c.Length != nil && n != c.GetLength() {
+			return fmt.Errorf("length %d is not equal to %d", n, c.GetLength())
+		}
+		if c.MinLength != nil && n < c.GetMinLength() {
+			return fmt.Errorf("length %d is less than %d", n, c.GetMinLength())
+		}
+		if c.MaxLength != nil && n > c.GetMaxLength() {
+			return fmt.Errorf("length %d is greater than %d", n, c.GetMaxLength())
+		}
+	}
+
+	return nil
+}
+
+// check returns an error if the assertion fails for the given input.
+func (a *assertion) check(input interface{}) error {
+	values := evalPath(a.steps, input)
+	if len(values) == 0 {
+		return fmt.Errorf("%s: no value found", a.c.GetPath())
+	}
+	for _, v := range values {
+		if err := a.checkValue(v); err != nil {
+			return fmt.Errorf("%s: %v", a.c.GetPath(), err)
+		}
+	}
+	return nil
+}
@@ -27,8 +27,9 @@ import (
 
 // Validator implements a regex validator.
 type Validator struct {
-	jqQuery *gojq.Query
-	l       *logger.Logger
+	jqQuery    *gojq.Query
+	assertions []*assertion
+	l          *logger.Logger
 }
 
 // Init initializes the JSON validator.
@@ -49,14 +50,23 @@ func (v *Validator) Init(config interface{}, l *logger.Logger) error {
 		v.jqQuery = q
 	}
 
+	for _, ac := range cfg.GetAssertion() {
+		a, err := newAssertion(ac)
+		if err != nil {
+			return fmt.Errorf("invalid assertion: %v", err)
+		}
+		v.assertions = append(v.assertions, a)
+	}
+
 	v.l = l
 
 	return nil
 }
 
-// Validate the provided responseBody. If no jq filter is configured, it
-// returns true if responseBody is a valid JSON. If jq filter is configured,
-// validator returns true if jq filter returns true.
+// Validate the provided responseBody. If no jq filter or assertions are
+// configured, it returns true if responseBody is a valid JSON. If jq filter is
+// configured, validator returns true only if jq filter returns true, and if
+// assertions are configured, only if all of them pass.
 func (v *Validator) Validate(responseBody []byte) (bool, error) {
 	var input interface{}
 	err := json.Unmarshal(responseBody, &input)
@@ -65,6 +75,13 @@ func (v *Validator) Validate(responseBody []byte) (bool, error) {
 		return false, err
 	}
 
+	for _, a := range v.assertions {
+		if err := a.check(input); err != nil {
+			v.l.Warningf("JSON validation failure: %v", err)
+			return false, nil
+		}
+	}
+
 	if v.jqQuery != nil {
 		iter := v.jqQuery.Run(input)
 
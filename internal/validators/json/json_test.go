@@ -121,3 +121,84 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestAssertions(t *testing.T) {
+	input := `{
+		"status": "ok",
+		"version": 3,
+		"healthy": true,
+		"content-type": "application/json",
+		"items": [
+			{"name": "a", "latency_ms": 120, "tags": ["x", "y"]},
+			{"name": "b", "latency_ms": "480"},
+			{"name": "c", "latency_ms": 250}
+		]
+	}`
+
+	f := func(v float64) *float64 { return &v }
+	n := func(v int64) *int64 { return &v }
+	s := func(v string) *string { return &v }
+
+	var tests = []struct {
+		desc      string
+		assertion *configpb.Assertion
+		initErr   bool
+		retFalse  bool
+	}{
+		{desc: "equals_string", assertion: &configpb.Assertion{Path: "$.status", Equals: s("ok")}},
+		{desc: "equals_number", assertion: &configpb.Assertion{Path: "$.version", Equals: s("3")}},
+		{desc: "equals_bool", assertion: &configpb.Assertion{Path: "$.healthy", Equals: s("true")}},
+		{desc: "equals_mismatch", assertion: &configpb.Assertion{Path: "$.status", Equals: s("degraded")}, retFalse: true},
+		{desc: "quoted_name", assertion: &configpb.Assertion{Path: "$['content-type']", Regex: "^application/json"}},
+		{desc: "index", assertion: &configpb.Assertion{Path: "$.items[0].name", Equals: s("a")}},
+		{desc: "negative_index", assertion: &configpb.Assertion{Path: "$.items[-1].name", Equals: s("c")}},
+		{desc: "wildcard_regex", assertion: &configpb.Assertion{Path: "$.items[*].name", Regex: "^[a-c]$"}},
+		{desc: "wildcard_range", assertion: &configpb.Assertion{Path: "$.items[*].latency_ms", Min: f(100), Max: f(500)}},
+		{desc: "wildcard_range_fail", assertion: &configpb.Assertion{Path: "$.items[*].latency_ms", Max: f(300)}, retFalse: true},
+		{desc: "range_not_number", assertion: &configpb.Assertion{Path: "$.status", Min: f(0)}, retFalse: true},
+		{desc: "array_length", assertion: &configpb.Assertion{Path: "$.items", Length: n(3)}},
+		{desc: "array_min_length", assertion: &configpb.Assertion{Path: "$.items[0].tags", MinLength: n(1), MaxLength: n(2)}},
+		{desc: "array_max_length_fail", assertion: &configpb.Assertion{Path: "$.items", MaxLength: n(2)}, retFalse: true},
+		{desc: "object_members", assertion: &configpb.Assertion{Path: "$.items[0].*", Regex: ".+"}},
+		{desc: "object_length", assertion: &configpb.Assertion{Path: "$.items[1]", Length: n(2)}},
+		{desc: "missing_path", assertion: &configpb.Assertion{Path: "$.items[5].name"}, retFalse: true},
+		{desc: "bad_path", assertion: &configpb.Assertion{Path: "items"}, initErr: true},
+		{desc: "recursive_path", assertion: &configpb.Assertion{Path: "$..name"}, initErr: true},
+		{desc: "bad_regex", assertion: &configpb.Assertion{Path: "$.status", Regex: "("}, initErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := Validator{}
+			err := v.Init(&configpb.Validator{
+				Assertion: []*configpb.Assertion{test.assertion},
+			}, nil)
+			if test.initErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			ret, err := v.Validate([]byte(input))
+			assert.NoError(t, err)
+			assert.Equal(t, !test.retFalse, ret)
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	steps, err := parsePath(`$.a["b.c"][2][*].*`)
+	assert.NoError(t, err)
+	assert.Equal(t, []pathStep{
+		{key: "a"},
+		{key: "b.c"},
+		{index: 2, isIndex: true},
+		{wildcard: true},
+		{wildcard: true},
+	}, steps)
+
+	for _, path := range []string{"$.", "$[x]", "$['a'", "$[1"} {
+		_, err := parsePath(path)
+		assert.Error(t, err, path)
+	}
+}
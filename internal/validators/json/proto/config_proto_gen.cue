@@ -7,4 +7,55 @@ package proto
 	// See the following test file for some examples:
 	// https://github.com/cloudprober/cloudprober/blob/master/validators/json/json_test.go
 	jqFilter?: string @protobuf(1,string,name=jq_filter)
+
+	// Assertions on the values in the JSON document. Validation passes only if
+	// all the assertions pass (and jq_filter, if specified, returns true).
+	// Example:
+	//   json_validator {
+	//     assertion {
+	//       path: "$.status"
+	//       equals: "ok"
+	//     }
+	//     assertion {
+	//       path: "$.items[*].latency_ms"
+	//       max: 500
+	//     }
+	//     assertion {
+	//       path: "$.items"
+	//       min_length: 1
+	//     }
+	//   }
+	assertion?: [...#Assertion] @protobuf(2,Assertion)
+}
+
+// Assertion extracts values from the JSON document using a JSONPath
+// expression, and checks them. If multiple checks are specified, all of them
+// must pass.
+#Assertion: {
+	// JSONPath expression. Supported syntax: "$" (root), ".name" or "['name']"
+	// (object member), "[n]" (array element, negative n counts from the end),
+	// and "[*]" or ".*" (all elements or members). For example:
+	//   $.items[0].name, $.items[*].state, $['content-type'], $.items[-1]
+	// If path selects multiple values, all of them must pass the checks. It's an
+	// error if path doesn't select any value.
+	path?: string @protobuf(1,string)
+
+	// Value must be equal to this string. Non-string values are compared using
+	// their JSON representation, e.g. "true", "42", "null".
+	equals?: string @protobuf(2,string)
+
+	// Value (string, or JSON representation for non-strings) must match this
+	// regex.
+	regex?: string @protobuf(3,string)
+
+	// Value must be a number (or a string containing a number) within these
+	// bounds (inclusive).
+	min?: float64 @protobuf(4,double)
+	max?: float64 @protobuf(5,double)
+
+	// Length of the value (array, object or string) must be exactly length, or
+	// within min_length and max_length (inclusive).
+	length?:    int64 @protobuf(6,int64)
+	minLength?: int64 @protobuf(7,int64,name=min_length)
+	maxLength?: int64 @protobuf(8,int64,name=max_length)
 }
@@ -0,0 +1,142 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema provides a JSON Schema validator for the Cloudprober's
+// validator framework.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// inlineSchemaURL is the URL used for the inline schemas.
+const inlineSchemaURL = "inline-schema.json"
+
+// Validator implements a JSON Schema validator.
+type Validator struct {
+	schema *jsonschema.Schema
+	l      *logger.Logger
+}
+
+// Init initializes the JSON Schema validator. It compiles the schema and
+// returns an error if it's not a valid schema.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	c, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid JSON Schema validator config", config)
+	}
+
+	var url string
+	var schema []byte
+	switch c.SchemaSource.(type) {
+	case *configpb.Validator_SchemaFile:
+		b, err := file.ReadFile(c.GetSchemaFile())
+		if err != nil {
+			return fmt.Errorf("error reading schema file (%s): %v", c.GetSchemaFile(), err)
+		}
+		schema, url = b, c.GetSchemaFile()
+		if !strings.Contains(url, "://") {
+			if url, err = filepath.Abs(url); err != nil {
+				return err
+			}
+		}
+	case *configpb.Validator_Schema:
+		schema, url = []byte(c.GetSchema()), inlineSchemaURL
+	default:
+		return errors.New("one of schema_file or schema is required")
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(url, bytes.NewReader(schema)); err != nil {
+		return fmt.Errorf("error parsing schema: %v", err)
+	}
+	s, err := compiler.Compile(url)
+	if err != nil {
+		return fmt.Errorf("error compiling schema: %v", err)
+	}
+
+	v.schema = s
+	v.l = l
+	return nil
+}
+
+// keyword returns the keyword from the keyword location, e.g. "type" for
+// "/properties/id/type".
+func keyword(keywordLocation string) string {
+	if i := strings.LastIndex(keywordLocation, "/"); i != -1 && i != len(keywordLocation)-1 {
+		return keywordLocation[i+1:]
+	}
+	// Empty location refers to the root schema itself, e.g. "false" schema.
+	return "schema"
+}
+
+// leafErrors returns the leaf validation errors, i.e. the errors that are
+// not caused by other errors.
+func leafErrors(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range ve.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// Validate validates the response body against the schema. It returns the
+// keywords that failed, if any. Response body that is not a valid JSON fails
+// with the "json" keyword.
+func (v *Validator) Validate(responseBody []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(responseBody))
+	dec.UseNumber()
+	var input interface{}
+	if err := dec.Decode(&input); err != nil {
+		v.l.Warningf("JSON Schema validation failure: response is not a valid JSON: %v", err)
+		return []string{"json"}, nil
+	}
+
+	err := v.schema.Validate(input)
+	if err == nil {
+		return nil, nil
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return nil, err
+	}
+
+	keywords := make(map[string]bool)
+	for _, leaf := range leafErrors(ve) {
+		v.l.Warningf("JSON Schema validation failure: %s: %s (keyword: %s)", leaf.InstanceLocation, leaf.Message, leaf.KeywordLocation)
+		keywords[keyword(leaf.KeywordLocation)] = true
+	}
+
+	var failed []string
+	for k := range keywords {
+		failed = append(failed, k)
+	}
+	sort.Strings(failed)
+	return failed, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "items"],
+  "properties": {
+    "id": {"type": "string"},
+    "items": {
+      "type": "array",
+      "prefixItems": [{"$ref": "#/$defs/item"}],
+      "minItems": 1
+    }
+  },
+  "$defs": {
+    "item": {
+      "type": "object",
+      "properties": {"price": {"type": "number", "minimum": 0}}
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		body string
+		want []string
+	}{
+		{
+			desc: "valid",
+			body: `{"id": "a1", "items": [{"price": 1.5}]}`,
+		},
+		{
+			desc: "missing_required",
+			body: `{"items": [{"price": 1}]}`,
+			want: []string{"required"},
+		},
+		{
+			desc: "multiple_keywords",
+			body: `{"id": 1, "items": [{"price": -1}]}`,
+			want: []string{"minimum", "type"},
+		},
+		{
+			desc: "min_items",
+			body: `{"id": "a1", "items": []}`,
+			want: []string{"minItems"},
+		},
+		{
+			desc: "invalid_json",
+			body: `{"id": `,
+			want: []string{"json"},
+		},
+	}
+
+	v := &Validator{}
+	assert.NoError(t, v.Init(&configpb.Validator{SchemaSource: &configpb.Validator_Schema{Schema: testSchema}}, &logger.Logger{}))
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := v.Validate([]byte(test.body))
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestInitSchemaFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "item.json"), []byte(`{"type": "object", "required": ["price"]}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"type": "array", "items": {"$ref": "item.json"}}`), 0600))
+
+	v := &Validator{}
+	assert.NoError(t, v.Init(&configpb.Validator{SchemaSource: &configpb.Validator_SchemaFile{SchemaFile: filepath.Join(dir, "schema.json")}}, &logger.Logger{}))
+
+	got, err := v.Validate([]byte(`[{"price": 1}, {"name": "x"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"required"}, got)
+
+	for _, c := range []*configpb.Validator{
+		{},
+		{SchemaSource: &configpb.Validator_Schema{Schema: `{"type": 5}`}},
+		{SchemaSource: &configpb.Validator_Schema{Schema: `{"type": `}},
+		{SchemaSource: &configpb.Validator_SchemaFile{SchemaFile: filepath.Join(dir, "missing.json")}},
+	} {
+		assert.Error(t, (&Validator{}).Init(c, &logger.Logger{}), c.String())
+	}
+}
+
+func TestKeyword(t *testing.T) {
+	assert.Equal(t, "type", keyword("/properties/id/type"))
+	assert.Equal(t, "required", keyword("/required"))
+	assert.Equal(t, "schema", keyword(""))
+}
@@ -0,0 +1,15 @@
+package proto
+
+// JSON Schema validator validates the response body against a JSON Schema.
+// Schemas without $schema are treated as draft 2020-12. Failing keywords are
+// counted separately in the validation_failure metric, with
+// validator="<validator_name>.<keyword>", e.g. "api_contract.required".
+#Validator: {
+	{} | {
+		// JSON Schema file. Relative $refs are resolved relative to this file.
+		schemaFile: string @protobuf(1,string,name=schema_file)
+	} | {
+		// Inline JSON Schema.
+		schema: string @protobuf(2,string)
+	}
+}
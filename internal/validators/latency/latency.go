@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package latency provides a latency budget validator for the Cloudprober's
+// validator framework.
+package latency
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/latency/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// Names of the request phases. These are also the names of the checks.
+const (
+	PhaseTotal   = "total"
+	PhaseDNS     = "dns"
+	PhaseConnect = "connect"
+	PhaseTLS     = "tls"
+	PhaseTTFB    = "ttfb"
+)
+
+type budget struct {
+	phase  string
+	budget time.Duration
+}
+
+// Validator implements a latency budget validator.
+type Validator struct {
+	budgets []budget
+	l       *logger.Logger
+}
+
+// Init initializes the latency budget validator.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	c, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid latency validator config", config)
+	}
+
+	for _, b := range []struct {
+		phase string
+		msec  int32
+	}{
+		{PhaseTotal, c.GetTotalMsec()},
+		{PhaseDNS, c.GetDnsMsec()},
+		{PhaseConnect, c.GetConnectMsec()},
+		{PhaseTLS, c.GetTlsMsec()},
+		{PhaseTTFB, c.GetTtfbMsec()},
+	} {
+		if b.msec < 0 {
+			return fmt.Errorf("%s budget cannot be negative: %d", b.phase, b.msec)
+		}
+		if b.msec > 0 {
+			v.budgets = append(v.budgets, budget{b.phase, time.Duration(b.msec) * time.Millisecond})
+		}
+	}
+	if len(v.budgets) == 0 {
+		return errors.New("no latency budget configured")
+	}
+
+	v.l = l
+	return nil
+}
+
+// Checks returns the names of the configured checks.
+func (v *Validator) Checks() []string {
+	var checks []string
+	for _, b := range v.budgets {
+		checks = append(checks, b.phase)
+	}
+	return checks
+}
+
+// Validate checks the total latency and the phase latencies against the
+// budgets. It returns the phases that exceeded their budgets. Phases that
+// are not available are not checked.
+func (v *Validator) Validate(total time.Duration, phases map[string]time.Duration) []string {
+	var failed []string
+	for _, b := range v.budgets {
+		latency := total
+		if b.phase != PhaseTotal {
+			latency = phases[b.phase]
+		}
+		if latency == 0 {
+			continue
+		}
+		if latency > b.budget {
+			v.l.Warningf("Latency validation failure: %s latency %v exceeded the budget %v", b.phase, latency, b.budget)
+			failed = append(failed, b.phase)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latency
+
+import (
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/latency/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	ms := time.Millisecond
+	conf := &configpb.Validator{TotalMsec: 500, TlsMsec: 100, TtfbMsec: 300}
+
+	tests := []struct {
+		desc   string
+		total  time.Duration
+		phases map[string]time.Duration
+		want   []string
+	}{
+		{
+			desc:   "within_budget",
+			total:  400 * ms,
+			phases: map[string]time.Duration{PhaseDNS: 900 * ms, PhaseTLS: 50 * ms, PhaseTTFB: 250 * ms},
+		},
+		{
+			desc:   "tls_and_total_exceeded",
+			total:  600 * ms,
+			phases: map[string]time.Duration{PhaseTLS: 150 * ms, PhaseTTFB: 250 * ms},
+			want:   []string{PhaseTotal, PhaseTLS},
+		},
+		{
+			desc:   "no_phases",
+			total:  200 * ms,
+			phases: nil,
+		},
+		{
+			desc:   "ttfb_exceeded_reused_conn",
+			total:  450 * ms,
+			phases: map[string]time.Duration{PhaseTTFB: 350 * ms},
+			want:   []string{PhaseTTFB},
+		},
+	}
+
+	v := &Validator{}
+	assert.NoError(t, v.Init(conf, &logger.Logger{}))
+	assert.Equal(t, []string{PhaseTotal, PhaseTLS, PhaseTTFB}, v.Checks())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.want, v.Validate(test.total, test.phases))
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	assert.Error(t, (&Validator{}).Init(&configpb.Validator{}, nil))
+	assert.Error(t, (&Validator{}).Init(&configpb.Validator{DnsMsec: -1}, nil))
+}
@@ -0,0 +1,27 @@
+package proto
+
+// Latency budget validator fails the probe if the request latency, or the
+// latency of a specific phase of the request, exceeds the configured budget.
+// Each configured budget is a separate check, and its failures are counted
+// in the validation_failure metric with validator="<validator_name>.<check>",
+// where check is one of: total, dns, connect, tls, ttfb.
+//
+// Phase latencies are reported by the HTTP probe (all phases) and the TCP
+// probe (connect and tls). A phase that didn't happen for a request (e.g. no
+// DNS lookup or TLS handshake on a reused connection) is not checked.
+#Validator: {
+	// Total request latency budget.
+	totalMsec?: int32 @protobuf(1,int32,name=total_msec)
+
+	// DNS resolution budget.
+	dnsMsec?: int32 @protobuf(2,int32,name=dns_msec)
+
+	// TCP connection setup budget.
+	connectMsec?: int32 @protobuf(3,int32,name=connect_msec)
+
+	// TLS handshake budget.
+	tlsMsec?: int32 @protobuf(4,int32,name=tls_msec)
+
+	// Time to first response byte, measured from the start of the request.
+	ttfbMsec?: int32 @protobuf(5,int32,name=ttfb_msec)
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package numeric provides a numeric threshold validator for the
+// Cloudprober's validator framework.
+package numeric
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	jsonvalidator "github.com/cloudprober/cloudprober/internal/validators/json"
+	configpb "github.com/cloudprober/cloudprober/internal/validators/numeric/proto"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// Validator implements a numeric threshold validator.
+type Validator struct {
+	c        *configpb.Validator
+	re       *regexp.Regexp
+	jsonPath *jsonvalidator.Path
+	l        *logger.Logger
+}
+
+// Init initializes the numeric validator.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	c, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid numeric validator config", config)
+	}
+
+	var err error
+	switch c.Source.(type) {
+	case *configpb.Validator_Regex:
+		if v.re, err = regexp.Compile(c.GetRegex()); err != nil {
+			return fmt.Errorf("error compiling regex (%s): %v", c.GetRegex(), err)
+		}
+	case *configpb.Validator_JsonPath:
+		if v.jsonPath, err = jsonvalidator.CompilePath(c.GetJsonPath()); err != nil {
+			return fmt.Errorf("invalid json_path: %v", err)
+		}
+	case *configpb.Validator_Header:
+		if c.GetHeader() == "" {
+			return errors.New("header name cannot be empty")
+		}
+	default:
+		return errors.New("one of regex, json_path or header is required")
+	}
+
+	if c.Min != nil && c.Max != nil && c.GetMin() > c.GetMax() {
+		return fmt.Errorf("min (%v) is greater than max (%v)", c.GetMin(), c.GetMax())
+	}
+
+	v.c = c
+	v.l = l
+	return nil
+}
+
+func parseNumber(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return f, nil
+}
+
+// extract extracts the number from the response.
+func (v *Validator) extract(resp interface{}, body []byte) (float64, error) {
+	switch {
+	case v.re != nil:
+		matches := v.re.FindSubmatch(body)
+		if matches == nil {
+			return 0, fmt.Errorf("regex (%s) didn't match", v.re.String())
+		}
+		if len(matches) > 1 {
+			return parseNumber(string(matches[1]))
+		}
+		return parseNumber(string(matches[0]))
+
+	case v.jsonPath != nil:
+		var input interface{}
+		if err := json.Unmarshal(body, &input); err != nil {
+			return 0, fmt.Errorf("response is not a valid JSON: %v", err)
+		}
+		values := v.jsonPath.Select(input)
+		if len(values) != 1 {
+			return 0, fmt.Errorf("json_path (%s) selected %d values, expected 1", v.c.GetJsonPath(), len(values))
+		}
+		switch val := values[0].(type) {
+		case float64:
+			return val, nil
+		case string:
+			return parseNumber(val)
+		}
+		return 0, fmt.Errorf("json_path (%s) selected a non-numeric value: %v", v.c.GetJsonPath(), values[0])
+
+	default:
+		httpResp, ok := resp.(*http.Response)
+		if !ok || httpResp == nil {
+			return 0, fmt.Errorf("header source requires an HTTP response, got %T", resp)
+		}
+		val := httpResp.Header.Get(v.c.GetHeader())
+		if val == "" {
+			return 0, fmt.Errorf("header %s not found", v.c.GetHeader())
+		}
+		return parseNumber(val)
+	}
+}
+
+// Validate extracts the number from the response and checks it against the
+// thresholds. It returns the extracted value (if extracted is true) and the
+// validation result. Failure to extract the number fails the validation.
+func (v *Validator) Validate(resp interface{}, body []byte) (value float64, extracted, success bool) {
+	value, err := v.extract(resp, body)
+	if err != nil {
+		v.l.Warningf("Numeric validation failure: %v", err)
+		return 0, false, false
+	}
+
+	if v.c.Min != nil && value < v.c.GetMin() {
+		v.l.Warningf("Numeric validation failure: value %v is less than %v", value, v.c.GetMin())
+		return value, true, false
+	}
+	if v.c.Max != nil && value > v.c.GetMax() {
+		v.l.Warningf("Numeric validation failure: value %v is greater than %v", value, v.c.GetMax())
+		return value, true, false
+	}
+	return value, true, true
+}
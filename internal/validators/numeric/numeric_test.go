@@ -0,0 +1,131 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numeric
+
+import (
+	"net/http"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/numeric/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidate(t *testing.T) {
+	body := []byte(`{"queues": {"ingest": {"depth": 42}, "retry": {"depth": "7"}}, "status": "ok"}`)
+	resp := &http.Response{Header: http.Header{"X-Queue-Depth": []string{"120"}}}
+
+	tests := []struct {
+		desc          string
+		conf          *configpb.Validator
+		body          []byte
+		resp          interface{}
+		wantValue     float64
+		wantExtracted bool
+		wantSuccess   bool
+		wantInitErr   bool
+	}{
+		{
+			desc:          "json_path",
+			conf:          &configpb.Validator{Source: &configpb.Validator_JsonPath{JsonPath: "$.queues.ingest.depth"}, Max: proto.Float64(100)},
+			body:          body,
+			wantValue:     42,
+			wantExtracted: true,
+			wantSuccess:   true,
+		},
+		{
+			desc:          "json_path_string_below_min",
+			conf:          &configpb.Validator{Source: &configpb.Validator_JsonPath{JsonPath: "$.queues.retry.depth"}, Min: proto.Float64(10)},
+			body:          body,
+			wantValue:     7,
+			wantExtracted: true,
+		},
+		{
+			desc: "json_path_multiple_values",
+			conf: &configpb.Validator{Source: &configpb.Validator_JsonPath{JsonPath: "$.queues.*.depth"}},
+			body: body,
+		},
+		{
+			desc: "json_path_non_numeric",
+			conf: &configpb.Validator{Source: &configpb.Validator_JsonPath{JsonPath: "$.status"}},
+			body: body,
+		},
+		{
+			desc:          "regex_group",
+			conf:          &configpb.Validator{Source: &configpb.Validator_Regex{Regex: `queue_depth=(\d+)`}, Min: proto.Float64(0), Max: proto.Float64(10)},
+			body:          []byte("healthy queue_depth=3 workers=8"),
+			wantValue:     3,
+			wantExtracted: true,
+			wantSuccess:   true,
+		},
+		{
+			desc:          "regex_whole_match",
+			conf:          &configpb.Validator{Source: &configpb.Validator_Regex{Regex: `[0-9.]+`}},
+			body:          []byte("load: 0.75"),
+			wantValue:     0.75,
+			wantExtracted: true,
+			wantSuccess:   true,
+		},
+		{
+			desc: "regex_no_match",
+			conf: &configpb.Validator{Source: &configpb.Validator_Regex{Regex: `queue_depth=(\d+)`}},
+			body: []byte("healthy"),
+		},
+		{
+			desc:          "header_above_max",
+			conf:          &configpb.Validator{Source: &configpb.Validator_Header{Header: "x-queue-depth"}, Max: proto.Float64(100)},
+			resp:          resp,
+			wantValue:     120,
+			wantExtracted: true,
+		},
+		{
+			desc: "header_non_http",
+			conf: &configpb.Validator{Source: &configpb.Validator_Header{Header: "x-queue-depth"}},
+		},
+		{
+			desc:        "no_source",
+			conf:        &configpb.Validator{},
+			wantInitErr: true,
+		},
+		{
+			desc:        "min_greater_than_max",
+			conf:        &configpb.Validator{Source: &configpb.Validator_Regex{Regex: `\d+`}, Min: proto.Float64(10), Max: proto.Float64(1)},
+			wantInitErr: true,
+		},
+		{
+			desc:        "bad_json_path",
+			conf:        &configpb.Validator{Source: &configpb.Validator_JsonPath{JsonPath: "queues"}},
+			wantInitErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := &Validator{}
+			err := v.Init(test.conf, &logger.Logger{})
+			if test.wantInitErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			value, extracted, success := v.Validate(test.resp, test.body)
+			assert.Equal(t, test.wantValue, value)
+			assert.Equal(t, test.wantExtracted, extracted)
+			assert.Equal(t, test.wantSuccess, success)
+		})
+	}
+}
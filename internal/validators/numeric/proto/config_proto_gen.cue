@@ -0,0 +1,27 @@
+package proto
+
+// Numeric validator extracts a number from the response and checks it
+// against the configured thresholds. Validation fails if the number cannot be
+// extracted. Extracted value is also exported as a gauge metric
+// (validator_value) by the HTTP probe.
+#Validator: {
+	// Where to extract the number from.
+	{} | {
+		// Regex to match the response body with. Number is taken from the first
+		// capture group, or from the whole match if regex has no groups.
+		regex: string @protobuf(1,string)
+	} | {
+		// JSONPath expression (same syntax as JSON validator assertions) that
+		// should select exactly one number, or a numeric string.
+		jsonPath: string @protobuf(2,string,name=json_path)
+	} | {
+		// HTTP response header to read the number from.
+		header: string @protobuf(3,string)
+	}
+
+	// Minimum (inclusive) value.
+	min?: float64 @protobuf(4,double)
+
+	// Maximum (inclusive) value.
+	max?: float64 @protobuf(5,double)
+}
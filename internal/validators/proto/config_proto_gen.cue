@@ -4,6 +4,13 @@ import (
 	"github.com/cloudprober/cloudprober/internal/validators/http/proto"
 	proto_1 "github.com/cloudprober/cloudprober/internal/validators/integrity/proto"
 	proto_5 "github.com/cloudprober/cloudprober/internal/validators/json/proto"
+	proto_8 "github.com/cloudprober/cloudprober/internal/validators/cel/proto"
+	proto_A "github.com/cloudprober/cloudprober/internal/validators/cert/proto"
+	proto_B "github.com/cloudprober/cloudprober/internal/validators/numeric/proto"
+	proto_C "github.com/cloudprober/cloudprober/internal/validators/command/proto"
+	proto_D "github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto"
+	proto_E "github.com/cloudprober/cloudprober/internal/validators/latency/proto"
+	proto_F "github.com/cloudprober/cloudprober/internal/validators/regex/proto"
 )
 
 #Validator: {
@@ -19,5 +26,81 @@ import (
 	} | {
 		// Regex validator
 		regex: string @protobuf(4,string)
+	} | {
+		// Regex validator with multiple patterns, negative matches and flags.
+		regexValidator: proto_F.#Validator @protobuf(13,regex.Validator,name=regex_validator)
+	} | {
+		// CEL (Common Expression Language) validator
+		celValidator: proto_8.#Validator @protobuf(6,cel.Validator,name=cel_validator)
+	} | {
+		// Certificate chain validator
+		certValidator: proto_A.#Validator @protobuf(7,cert.Validator,name=cert_validator)
+	} | {
+		// Composite validator, combines other validators using AND, OR, or NOT.
+		compositeValidator: #CompositeValidator @protobuf(8,CompositeValidator,name=composite_validator)
+	} | {
+		// Numeric validator, checks a number extracted from the response.
+		numericValidator: proto_B.#Validator @protobuf(9,numeric.Validator,name=numeric_validator)
+	} | {
+		// External command validator.
+		commandValidator: proto_C.#Validator @protobuf(10,command.Validator,name=command_validator)
+	} | {
+		// JSON Schema validator.
+		jsonSchemaValidator: proto_D.#Validator @protobuf(11,jsonschema.Validator,name=json_schema_validator)
+	} | {
+		// Latency budget validator.
+		latencyValidator: proto_E.#Validator @protobuf(12,latency.Validator,name=latency_validator)
 	}
 }
+
+// Composite validator combines validators into boolean expressions, e.g.
+// "status_200 AND (regex_a OR regex_b) AND NOT regex_c":
+//   validator {
+//     name: "healthy"
+//     composite_validator {
+//       operator: AND
+//       validator { name: "status_200" http_validator {...} }
+//       validator {
+//         name: "a_or_b"
+//         composite_validator {
+//           operator: OR
+//           validator { name: "a" regex: "A" }
+//           validator { name: "b" regex: "B" }
+//         }
+//       }
+//       validator {
+//         name: "not_c"
+//         composite_validator {
+//           operator: NOT
+//           validator { name: "c" regex: "C" }
+//         }
+//       }
+//     }
+//   }
+// Results of the individual validators are exported as well, with keys
+// "<parent>.<child>", e.g. "healthy.a_or_b.a". Note that these reflect the
+// results of the validators themselves, i.e. "healthy.not_c.c" counts the
+// times regex "C" didn't match.
+#CompositeValidator: {
+	#Operator: {
+		"AND"
+		#enumValue: 0
+	} | {
+		"OR"
+		#enumValue: 1
+	} | {
+		"NOT"// Requires exactly one validator.
+		#enumValue: 2
+	}
+
+	#Operator_value: {
+		AND: 0
+		OR:  1
+		NOT: 2
+	}
+	operator?: #Operator @protobuf(1,Operator)
+
+	// Validators to combine. Names should be unique within a composite
+	// validator.
+	validator?: [...#Validator] @protobuf(2,Validator)
+}
@@ -0,0 +1,29 @@
+package proto
+
+// Regex validator configuration. For the validator to succeed, all patterns
+// should pass: patterns should match the response, and patterns with
+// must_not_match should not match it. Failures of the individual patterns are
+// counted separately in the validation_failure metric, with
+// validator="<validator_name>.<pattern_name>".
+#Validator: {
+	#Pattern: {
+		// Pattern name, used in the failure metrics. Defaults to "pattern<i>",
+		// where i is the 0-based index of the pattern.
+		name?: string @protobuf(1,string)
+
+		// Regex (RE2 syntax).
+		regex?: string @protobuf(2,string)
+
+		// If set, response should NOT match the regex. Useful to detect error
+		// strings in otherwise successful responses.
+		mustNotMatch?: bool @protobuf(3,bool,name=must_not_match)
+	}
+	pattern?: [...#Pattern] @protobuf(1,Pattern)
+
+	// Make ^ and $ match at the beginning and end of lines, in addition to the
+	// beginning and end of the response (RE2 "m" flag).
+	multiline?: bool @protobuf(2,bool)
+
+	// Make . match newlines as well (RE2 "s" flag).
+	dotAll?: bool @protobuf(3,bool,name=dot_all)
+}
@@ -21,43 +21,120 @@ import (
 	"fmt"
 	"regexp"
 
+	configpb "github.com/cloudprober/cloudprober/internal/validators/regex/proto"
 	"github.com/cloudprober/cloudprober/logger"
 )
 
+type pattern struct {
+	name         string
+	r            *regexp.Regexp
+	mustNotMatch bool
+}
+
 // Validator implements a regex validator.
 type Validator struct {
-	r *regexp.Regexp
-	l *logger.Logger
+	patterns []*pattern
+	l        *logger.Logger
 }
 
-// Init initializes the regex validator.
-// It compiles the regex in the configuration and returns an error if regex
-// doesn't compile for some reason.
-func (v *Validator) Init(config interface{}, l *logger.Logger) error {
-	regexStr, ok := config.(string)
-	if !ok {
-		return fmt.Errorf("%v is not a valid regex validator config", config)
-	}
+func compile(regexStr, flags string) (*regexp.Regexp, error) {
 	if regexStr == "" {
-		return errors.New("validator regex string cannot be empty")
+		return nil, errors.New("validator regex string cannot be empty")
+	}
+	if flags != "" {
+		regexStr = "(?" + flags + ")" + regexStr
 	}
-
 	r, err := regexp.Compile(regexStr)
 	if err != nil {
-		return fmt.Errorf("error compiling the given regex (%s): %v", regexStr, err)
+		return nil, fmt.Errorf("error compiling the given regex (%s): %v", regexStr, err)
 	}
+	return r, nil
+}
 
-	v.r = r
+// Init initializes the regex validator. Config can either be a regex string,
+// or a regex validator config.
+// It compiles the regexes in the configuration and returns an error if a
+// regex doesn't compile for some reason.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
 	v.l = l
-	return nil
+
+	switch c := config.(type) {
+	case string:
+		r, err := compile(c, "")
+		if err != nil {
+			return err
+		}
+		v.patterns = []*pattern{{r: r}}
+		return nil
+
+	case *configpb.Validator:
+		if len(c.GetPattern()) == 0 {
+			return errors.New("at least one pattern is required")
+		}
+		var flags string
+		if c.GetMultiline() {
+			flags += "m"
+		}
+		if c.GetDotAll() {
+			flags += "s"
+		}
+
+		names := make(map[string]bool)
+		for i, pc := range c.GetPattern() {
+			r, err := compile(pc.GetRegex(), flags)
+			if err != nil {
+				return err
+			}
+			p := &pattern{name: pc.GetName(), r: r, mustNotMatch: pc.GetMustNotMatch()}
+			if p.name == "" {
+				p.name = fmt.Sprintf("pattern%d", i)
+			}
+			if names[p.name] {
+				return fmt.Errorf("pattern %s is defined twice", p.name)
+			}
+			names[p.name] = true
+			v.patterns = append(v.patterns, p)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%v is not a valid regex validator config", config)
+	}
 }
 
-// Validate the provided responseBody and return true if responseBody matches
-// the configured regex.
-func (v *Validator) Validate(responseBody []byte) (bool, error) {
-	matched := v.r.Match(responseBody)
-	if !matched {
-		v.l.Warningf("Regex validation failure: response %s didn't match the regex %s", string(responseBody), v.r.String())
+// PatternNames returns the names of the configured patterns. It's empty if
+// the validator was configured using a regex string.
+func (v *Validator) PatternNames() []string {
+	var names []string
+	for _, p := range v.patterns {
+		if p.name != "" {
+			names = append(names, p.name)
+		}
 	}
-	return matched, nil
+	return names
+}
+
+// FailedPatterns returns the names of the patterns that failed for the
+// provided responseBody.
+func (v *Validator) FailedPatterns(responseBody []byte) []string {
+	var failed []string
+	for _, p := range v.patterns {
+		matched := p.r.Match(responseBody)
+		if matched == !p.mustNotMatch {
+			continue
+		}
+		if p.mustNotMatch {
+			v.l.Warningf("Regex validation failure: response %s matched the regex %s", string(responseBody), p.r.String())
+		} else {
+			v.l.Warningf("Regex validation failure: response %s didn't match the regex %s", string(responseBody), p.r.String())
+		}
+		failed = append(failed, p.name)
+	}
+	return failed
+}
+
+// Validate the provided responseBody and return true if responseBody passes
+// all the configured patterns.
+func (v *Validator) Validate(responseBody []byte) (bool, error) {
+	return len(v.FailedPatterns(responseBody)) == 0, nil
 }
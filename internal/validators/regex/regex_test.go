@@ -17,7 +17,9 @@ package regex
 import (
 	"testing"
 
+	configpb "github.com/cloudprober/cloudprober/internal/validators/regex/proto"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInvalidConfig(t *testing.T) {
@@ -82,3 +84,55 @@ func TestPatternString(t *testing.T) {
 	}
 
 }
+
+func TestRegexValidatorConfig(t *testing.T) {
+	conf := &configpb.Validator{
+		Pattern: []*configpb.Validator_Pattern{
+			{Name: "status_ok", Regex: `^status: ok$`},
+			{Name: "no_errors", Regex: `(?i)error|exception`, MustNotMatch: true},
+			{Regex: `<body>.*</body>`},
+		},
+		Multiline: true,
+		DotAll:    true,
+	}
+
+	v := Validator{}
+	assert.NoError(t, v.Init(conf, &logger.Logger{}))
+	assert.Equal(t, []string{"status_ok", "no_errors", "pattern2"}, v.PatternNames())
+
+	tests := []struct {
+		body string
+		want []string
+	}{
+		{
+			body: "<body>\nstatus: ok\n</body>",
+		},
+		{
+			body: "<body>\nstatus: ok\nInternal Error\n</body>",
+			want: []string{"no_errors"},
+		},
+		{
+			body: "status: degraded\n<body>",
+			want: []string{"status_ok", "pattern2"},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, v.FailedPatterns([]byte(test.body)), test.body)
+		got, _ := v.Validate([]byte(test.body))
+		assert.Equal(t, len(test.want) == 0, got)
+	}
+
+	// Without flags, ^ and $ match only at the beginning and end of the
+	// response.
+	v = Validator{}
+	assert.NoError(t, v.Init(&configpb.Validator{Pattern: []*configpb.Validator_Pattern{{Regex: `^status: ok$`}}}, &logger.Logger{}))
+	assert.Equal(t, []string{"pattern0"}, v.FailedPatterns([]byte("<body>\nstatus: ok\n</body>")))
+
+	for _, c := range []*configpb.Validator{
+		{},
+		{Pattern: []*configpb.Validator_Pattern{{Name: "a", Regex: "x"}, {Name: "a", Regex: "y"}}},
+		{Pattern: []*configpb.Validator_Pattern{{Name: "a"}}},
+	} {
+		assert.Error(t, (&Validator{}).Init(c, &logger.Logger{}))
+	}
+}
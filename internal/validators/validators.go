@@ -18,10 +18,17 @@ package validators
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/cloudprober/cloudprober/internal/validators/cel"
+	"github.com/cloudprober/cloudprober/internal/validators/cert"
+	"github.com/cloudprober/cloudprober/internal/validators/command"
 	"github.com/cloudprober/cloudprober/internal/validators/http"
 	"github.com/cloudprober/cloudprober/internal/validators/integrity"
 	"github.com/cloudprober/cloudprober/internal/validators/json"
+	"github.com/cloudprober/cloudprober/internal/validators/jsonschema"
+	"github.com/cloudprober/cloudprober/internal/validators/latency"
+	"github.com/cloudprober/cloudprober/internal/validators/numeric"
 	configpb "github.com/cloudprober/cloudprober/internal/validators/proto"
 	"github.com/cloudprober/cloudprober/internal/validators/regex"
 	"github.com/cloudprober/cloudprober/logger"
@@ -36,6 +43,24 @@ import (
 type Validator struct {
 	Name     string
 	Validate func(input *Input) (bool, error)
+
+	// Checks are the names of the individual checks performed by the
+	// validator, if it reports them. Failures of these checks are counted
+	// separately, with keys "<name>.<check>".
+	Checks []string
+
+	// validateChecks, if set, runs the validator and returns the result along
+	// with the failed checks.
+	validateChecks func(input *Input) (bool, []string, error)
+}
+
+// run runs the validator and returns the result and the failed checks.
+func (v *Validator) run(input *Input) (bool, []string, error) {
+	if v.validateChecks != nil {
+		return v.validateChecks(input)
+	}
+	success, err := v.Validate(input)
+	return success, nil, err
 }
 
 // Init initializes the validators defined in the config.
@@ -98,6 +123,106 @@ func initValidator(validatorConf *configpb.Validator, l *logger.Logger) (validat
 		}
 		return
 
+	case *configpb.Validator_CelValidator:
+		v := &cel.Validator{}
+		if err := v.Init(validatorConf.GetCelValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			return v.Validate(input.Response, input.ResponseBody, input.Latency)
+		}
+		return
+
+	case *configpb.Validator_CertValidator:
+		v := &cert.Validator{}
+		if err := v.Init(validatorConf.GetCertValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Checks = v.Checks()
+		validator.validateChecks = func(input *Input) (bool, []string, error) {
+			failed, err := v.Validate(input.Response)
+			return len(failed) == 0, failed, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			success, _, err := validator.validateChecks(input)
+			return success, err
+		}
+		return
+
+	case *configpb.Validator_NumericValidator:
+		v := &numeric.Validator{}
+		if err := v.Init(validatorConf.GetNumericValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			value, extracted, success := v.Validate(input.Response, input.ResponseBody)
+			if extracted && input.Values != nil {
+				input.Values[validator.Name] = value
+			}
+			return success, nil
+		}
+		return
+
+	case *configpb.Validator_CommandValidator:
+		v := &command.Validator{}
+		if err := v.Init(validatorConf.GetCommandValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			return v.Validate(input.Response, input.ResponseBody, input.Latency)
+		}
+		return
+
+	case *configpb.Validator_JsonSchemaValidator:
+		v := &jsonschema.Validator{}
+		if err := v.Init(validatorConf.GetJsonSchemaValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.validateChecks = func(input *Input) (bool, []string, error) {
+			failed, err := v.Validate(input.ResponseBody)
+			return len(failed) == 0, failed, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			success, _, err := validator.validateChecks(input)
+			return success, err
+		}
+		return
+
+	case *configpb.Validator_LatencyValidator:
+		v := &latency.Validator{}
+		if err := v.Init(validatorConf.GetLatencyValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Checks = v.Checks()
+		validator.validateChecks = func(input *Input) (bool, []string, error) {
+			failed := v.Validate(input.Latency, input.PhaseLatency)
+			return len(failed) == 0, failed, nil
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			success, _, err := validator.validateChecks(input)
+			return success, err
+		}
+		return
+
+	case *configpb.Validator_CompositeValidator:
+		return initComposite(validator, validatorConf.GetCompositeValidator(), l)
+
+	case *configpb.Validator_RegexValidator:
+		v := &regex.Validator{}
+		if err := v.Init(validatorConf.GetRegexValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Checks = v.PatternNames()
+		validator.validateChecks = func(input *Input) (bool, []string, error) {
+			failed := v.FailedPatterns(input.ResponseBody)
+			return len(failed) == 0, failed, nil
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			success, _, err := validator.validateChecks(input)
+			return success, err
+		}
+		return
+
 	case *configpb.Validator_Regex:
 		v := &regex.Validator{}
 		if err := v.Init(validatorConf.GetRegex(), l); err != nil {
@@ -117,6 +242,17 @@ func initValidator(validatorConf *configpb.Validator, l *logger.Logger) (validat
 type Input struct {
 	Response     interface{}
 	ResponseBody []byte
+
+	// Latency of the request, if available.
+	Latency time.Duration
+
+	// PhaseLatency is the latency of the request phases, if available, keyed
+	// by the phase name: dns, connect, tls, ttfb.
+	PhaseLatency map[string]time.Duration
+
+	// Values, if not nil, receives the values extracted by the validators
+	// (e.g. numeric validator), keyed by the validator name.
+	Values map[string]float64
 }
 
 // RunValidators runs the list of validators on the given response and
@@ -126,11 +262,14 @@ func RunValidators(vs []*Validator, input *Input, validationFailure *metrics.Map
 	var failures []string
 
 	for _, v := range vs {
-		success, err := v.Validate(input)
+		success, failedChecks, err := v.run(input)
 		if err != nil {
 			l.Error("Error while running the validator ", v.Name, ": ", err.Error())
 			continue
 		}
+		for _, check := range failedChecks {
+			validationFailure.IncKey(v.Name + "." + check)
+		}
 		if !success {
 			validationFailure.IncKey(v.Name)
 			failures = append(failures, v.Name)
@@ -147,6 +286,9 @@ func ValidationFailureMap(vs []*Validator) *metrics.Map[int64] {
 	// export the metrics.
 	for _, v := range vs {
 		m.IncKeyBy(v.Name, 0)
+		for _, check := range v.Checks {
+			m.IncKeyBy(v.Name+"."+check, 0)
+		}
 	}
 	return m
 }
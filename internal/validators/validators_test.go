@@ -51,6 +51,37 @@ func TestRunValidators(t *testing.T) {
 	}
 }
 
+func TestRunValidatorsWithChecks(t *testing.T) {
+	v := &Validator{
+		Name:   "cert",
+		Checks: []string{"san", "expiry"},
+		validateChecks: func(input *Input) (bool, []string, error) {
+			return false, []string{"expiry"}, nil
+		},
+	}
+	vfMap := ValidationFailureMap([]*Validator{v})
+	assert.Equal(t, []string{"cert", "cert.expiry", "cert.san"}, vfMap.Keys())
+
+	failures := RunValidators([]*Validator{v}, &Input{}, vfMap, nil)
+	assert.Equal(t, []string{"cert"}, failures)
+	assert.Equal(t, int64(1), vfMap.GetKey("cert"))
+	assert.Equal(t, int64(1), vfMap.GetKey("cert.expiry"))
+	assert.Equal(t, int64(0), vfMap.GetKey("cert.san"))
+}
+
+func TestRunValidatorsValues(t *testing.T) {
+	c := &configpb.Validator{}
+	assert.NoError(t, prototext.Unmarshal([]byte(`name: "depth" numeric_validator { regex: "depth=(\\d+)" max: 10 }`), c))
+	vs, err := Init([]*configpb.Validator{c}, nil)
+	assert.NoError(t, err)
+
+	values := make(map[string]float64)
+	vfMap := ValidationFailureMap(vs)
+	failures := RunValidators(vs, &Input{ResponseBody: []byte("depth=12"), Values: values}, vfMap, nil)
+	assert.Equal(t, []string{"depth"}, failures)
+	assert.Equal(t, map[string]float64{"depth": 12}, values)
+}
+
 func TestValidatorFailureMap(t *testing.T) {
 	vfMap := ValidationFailureMap(testValidators)
 
@@ -141,10 +141,11 @@ func (em *EventMetrics) Clone() *EventMetrics {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	newEM := &EventMetrics{
-		Timestamp: em.Timestamp,
-		Kind:      em.Kind,
-		metrics:   make(map[string]Value),
-		labels:    make(map[string]string),
+		Timestamp:   em.Timestamp,
+		Kind:        em.Kind,
+		metrics:     make(map[string]Value),
+		labels:      make(map[string]string),
+		LatencyUnit: em.LatencyUnit,
 	}
 	for _, lk := range em.labelsKeys {
 		newEM.labels[lk] = em.labels[lk]
@@ -0,0 +1,96 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "time"
+
+// ResultsDelta is the change in a target's probe results between two updates.
+type ResultsDelta struct {
+	Total, Success int64
+
+	// Average latency of the probe runs since the last update, 0 if not
+	// known.
+	Latency time.Duration
+
+	// Reset is true if the cumulative results went down, i.e. probe was
+	// probably restarted. Delta is then computed from 0.
+	Reset bool
+}
+
+// ResultsTracker keeps the last seen cumulative results (total, success and
+// latency) of a probe target, to compute how they changed with each update.
+type ResultsTracker struct {
+	total, success int64
+	latencySum     float64 // In nanoseconds.
+	latencyCount   int64
+}
+
+// latencySumAndCount returns the cumulative latency sum, in nanoseconds, and
+// the number of samples it includes.
+func latencySumAndCount(em *EventMetrics, success int64) (float64, int64, bool) {
+	// Default latency unit is microsecond.
+	unit := em.LatencyUnit
+	if unit == 0 {
+		unit = time.Microsecond
+	}
+
+	switch v := em.Metric("latency").(type) {
+	case *Distribution:
+		d := v.Data()
+		return d.Sum * float64(unit), d.Count, true
+	case NumValue:
+		return v.Float64() * float64(unit), success, true
+	}
+	return 0, 0, false
+}
+
+// Update updates the tracker from the cumulative results in em, and returns
+// the change since the last update. It returns false if em doesn't have the
+// total and success metrics.
+func (rt *ResultsTracker) Update(em *EventMetrics) (ResultsDelta, bool) {
+	totalV, totalOK := em.Metric("total").(NumValue)
+	successV, successOK := em.Metric("success").(NumValue)
+	if !totalOK || !successOK {
+		return ResultsDelta{}, false
+	}
+	total, success := totalV.Int64(), successV.Int64()
+
+	var delta ResultsDelta
+	if total < rt.total {
+		*rt = ResultsTracker{}
+		delta.Reset = true
+	}
+	delta.Total, delta.Success = total-rt.total, success-rt.success
+
+	if sum, count, ok := latencySumAndCount(em, success); ok {
+		if count > rt.latencyCount && sum >= rt.latencySum {
+			delta.Latency = time.Duration((sum - rt.latencySum) / float64(count-rt.latencyCount))
+		}
+		rt.latencySum, rt.latencyCount = sum, count
+	}
+
+	rt.total, rt.success = total, success
+	return delta, true
+}
+
+// Total returns the last seen cumulative total.
+func (rt *ResultsTracker) Total() int64 {
+	return rt.total
+}
+
+// Success returns the last seen cumulative success.
+func (rt *ResultsTracker) Success() int64 {
+	return rt.success
+}
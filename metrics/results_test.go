@@ -0,0 +1,90 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsTracker(t *testing.T) {
+	em := func(total, success int64, latency Value, unit time.Duration) *EventMetrics {
+		em := NewEventMetrics(time.Now()).
+			AddMetric("total", NewInt(total)).
+			AddMetric("success", NewInt(success))
+		if latency != nil {
+			em.AddMetric("latency", latency)
+		}
+		em.LatencyUnit = unit
+		return em
+	}
+
+	latencyDist := func(samples ...float64) *Distribution {
+		d := NewDistribution([]float64{1, 10, 100})
+		for _, s := range samples {
+			d.AddFloat64(s)
+		}
+		return d
+	}
+
+	rt := &ResultsTracker{}
+
+	_, ok := rt.Update(NewEventMetrics(time.Now()))
+	assert.False(t, ok, "update without total and success")
+
+	tests := []struct {
+		desc string
+		em   *EventMetrics
+		want ResultsDelta
+	}{
+		{
+			desc: "first update, default unit",
+			em:   em(10, 10, NewFloat(1000), 0),
+			want: ResultsDelta{Total: 10, Success: 10, Latency: 100 * time.Microsecond},
+		},
+		{
+			desc: "failures",
+			em:   em(20, 18, NewFloat(3000), 0),
+			want: ResultsDelta{Total: 10, Success: 8, Latency: 250 * time.Microsecond},
+		},
+		{
+			desc: "no new runs",
+			em:   em(20, 18, NewFloat(3000), 0),
+			want: ResultsDelta{},
+		},
+		{
+			desc: "probe restart",
+			em:   em(5, 5, NewFloat(50), time.Millisecond),
+			want: ResultsDelta{Total: 5, Success: 5, Latency: 10 * time.Millisecond, Reset: true},
+		},
+		{
+			desc: "latency distribution",
+			em:   em(7, 6, latencyDist(10, 20, 30, 40, 50, 60, 70), time.Millisecond),
+			want: ResultsDelta{Total: 2, Success: 1, Latency: 115 * time.Millisecond},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, ok := rt.Update(test.em)
+			assert.True(t, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+	assert.Equal(t, int64(7), rt.Total())
+	assert.Equal(t, int64(6), rt.Success())
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var promNameRe = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// promName converts a metric or label name to a valid prometheus name, the
+// same way prometheus surfacer does. It returns an empty string if that's not
+// possible.
+func promName(name string) string {
+	name = strings.ReplaceAll(name, "-", "_")
+	if !promNameRe.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+func promLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// writePromMetrics writes the probe run metrics in the prometheus text format,
+// along with the blackbox_exporter's probe_success and probe_duration_seconds
+// metrics.
+func writePromMetrics(w io.Writer, ems []*metrics.EventMetrics, duration time.Duration) {
+	success := len(ems) > 0
+	for _, em := range ems {
+		total, ok := em.Metric("total").(metrics.NumValue)
+		if !ok {
+			continue
+		}
+		succ, _ := em.Metric("success").(metrics.NumValue)
+		if succ == nil || total.Int64() == 0 || succ.Int64() < total.Int64() {
+			success = false
+		}
+	}
+
+	successVal := 0
+	if success {
+		successVal = 1
+	}
+	fmt.Fprintf(w, "# HELP probe_success Displays whether or not the probe was a success\n")
+	fmt.Fprintf(w, "# TYPE probe_success gauge\n")
+	fmt.Fprintf(w, "probe_success %d\n", successVal)
+	fmt.Fprintf(w, "# HELP probe_duration_seconds Returns how long the probe took to complete in seconds\n")
+	fmt.Fprintf(w, "# TYPE probe_duration_seconds gauge\n")
+	fmt.Fprintf(w, "probe_duration_seconds %s\n", strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+
+	for _, em := range ems {
+		var labels []string
+		for _, k := range em.LabelsKeys() {
+			if name := promName(k); name != "" {
+				labels = append(labels, name+"=\""+em.Label(k)+"\"")
+			}
+		}
+
+		for _, k := range em.MetricsKeys() {
+			name := promName(k)
+			if name == "" {
+				continue
+			}
+
+			switch v := em.Metric(k).(type) {
+			case *metrics.Map[int64]:
+				writePromMap(w, name, v, labels)
+			case *metrics.Map[float64]:
+				writePromMap(w, name, v, labels)
+			case *metrics.Distribution:
+				d := v.Data()
+				fmt.Fprintf(w, "%s_sum%s %s\n", name, promLabels(labels), strconv.FormatFloat(d.Sum, 'f', -1, 64))
+				fmt.Fprintf(w, "%s_count%s %d\n", name, promLabels(labels), d.Count)
+			case metrics.NumValue:
+				fmt.Fprintf(w, "%s%s %s\n", name, promLabels(labels), v.String())
+			}
+		}
+	}
+}
+
+func writePromMap[T int64 | float64](w io.Writer, name string, m *metrics.Map[T], labels []string) {
+	labelName := promName(m.MapName)
+	if labelName == "" {
+		return
+	}
+	for _, k := range m.Keys() {
+		mapLabels := append(append([]string(nil), labels...), labelName+"=\""+k+"\"")
+		fmt.Fprintf(w, "%s%s %s\n", name, promLabels(mapLabels), metrics.MapValueToString(m.GetKey(k)))
+	}
+}
+
+// httpStatusCode maps the gRPC status of the probe run errors to the HTTP
+// status codes.
+func httpStatusCode(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// BlackboxProbeHandler implements a blackbox_exporter compatible /probe
+// endpoint: /probe?module=<probe_name>&target=<target>. It runs the probe
+// once against the target, and returns the results in the prometheus text
+// format, including the probe_success and probe_duration_seconds metrics.
+func (pr *Prober) BlackboxProbeHandler(w http.ResponseWriter, r *http.Request) {
+	module, target := r.FormValue("module"), r.FormValue("target")
+	if module == "" || target == "" {
+		http.Error(w, "module and target parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	pr.mu.Lock()
+	probeInfo := pr.Probes[module]
+	pr.mu.Unlock()
+	if probeInfo == nil {
+		http.Error(w, fmt.Sprintf("unknown module %q", module), http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	ems, err := pr.runProbeOnce(r.Context(), oneOffProbeDef(probeInfo.ProbeDef, target))
+	if err != nil && status.Code(err) != codes.DeadlineExceeded {
+		http.Error(w, status.Convert(err).Message(), httpStatusCode(err))
+		return
+	}
+
+	// Like blackbox_exporter, a probe that times out is reported as failed
+	// through probe_success, instead of an HTTP error.
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writePromMetrics(w, ems, time.Since(start))
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
+	tcppb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWritePromMetrics(t *testing.T) {
+	respCodes := metrics.NewMap("code")
+	respCodes.IncKey("200")
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(2)).
+		AddMetric("success", metrics.NewInt(2)).
+		AddMetric("resp-code", respCodes).
+		AddLabel("probe", "test-probe").
+		AddLabel("dst", "localhost")
+
+	var b strings.Builder
+	writePromMetrics(&b, []*metrics.EventMetrics{em}, 1500*time.Millisecond)
+	assert.Contains(t, b.String(), "probe_success 1\n")
+	assert.Contains(t, b.String(), "probe_duration_seconds 1.5\n")
+	assert.Contains(t, b.String(), "total{probe=\"test-probe\",dst=\"localhost\"} 2\n")
+	assert.Contains(t, b.String(), "resp_code{probe=\"test-probe\",dst=\"localhost\",code=\"200\"} 1\n")
+
+	failedEM := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(2)).
+		AddMetric("success", metrics.NewInt(1))
+	b.Reset()
+	writePromMetrics(&b, []*metrics.EventMetrics{em, failedEM}, time.Second)
+	assert.Contains(t, b.String(), "probe_success 0\n")
+
+	// No metrics, e.g. probe timed out.
+	b.Reset()
+	writePromMetrics(&b, nil, time.Second)
+	assert.Contains(t, b.String(), "probe_success 0\n")
+}
+
+func TestBlackboxProbeHandler(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Error starting TCP listener: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	pr := testProber()
+	assert.NoError(t, pr.addProbe(&probes_configpb.ProbeDef{
+		Name:         proto.String("tcp_connect"),
+		Type:         probes_configpb.ProbeDef_TCP.Enum(),
+		IntervalMsec: proto.Int32(500),
+		TimeoutMsec:  proto.Int32(400),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: "unused.example.com"},
+		},
+		Probe: &probes_configpb.ProbeDef_TcpProbe{
+			TcpProbe: &tcppb.ProbeConf{Port: proto.Int32(int32(ln.Addr().(*net.TCPAddr).Port))},
+		},
+	}))
+
+	tests := []struct {
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{url: "/probe?module=tcp_connect&target=localhost", wantCode: http.StatusOK, wantBody: "probe_success 1\n"},
+		{url: "/probe?module=tcp_connect", wantCode: http.StatusBadRequest},
+		{url: "/probe?module=unknown&target=localhost", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			pr.BlackboxProbeHandler(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
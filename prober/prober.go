@@ -23,14 +23,18 @@ package prober
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	configpb "github.com/cloudprober/cloudprober/config/proto"
 	"github.com/cloudprober/cloudprober/config/runconfig"
+	"github.com/cloudprober/cloudprober/internal/alerting"
+	"github.com/cloudprober/cloudprober/internal/ratelimit"
 	rdsserver "github.com/cloudprober/cloudprober/internal/rds/server"
 	"github.com/cloudprober/cloudprober/internal/servers"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
@@ -69,6 +73,19 @@ type Prober struct {
 	// dataChan for passing metrics between probes and main goroutine.
 	dataChan chan *metrics.EventMetrics
 
+	// Contexts used to start the new probes and surfacers on config reload.
+	startCtx     context.Context
+	surfacersCtx context.Context
+
+	// surfacersMu protects Surfacers, which may be replaced by config reload.
+	surfacersMu sync.RWMutex
+
+	// reloadMu serializes the config reloads.
+	reloadMu sync.Mutex
+
+	// Targets' status, based on the probe results, for the targets page.
+	targetStatus *targetStatusTracker
+
 	// Used by GetConfig for /config handler.
 	TextConfig string
 
@@ -104,19 +121,47 @@ func (pr *Prober) addProbe(p *probes_configpb.ProbeDef) error {
 		return status.Errorf(codes.AlreadyExists, "probe %s is already defined", p.GetName())
 	}
 
+	probeInfo, err := pr.createProbe(p)
+	if err != nil {
+		return err
+	}
+	pr.Probes[p.GetName()] = probeInfo
+
+	return nil
+}
+
+// checkDependencies verifies that the probes that other probes depend on
+// (depends_on) are defined in the config. A typo in the dependency name would
+// otherwise silently disable the suppression.
+func checkDependencies(probeDefs []*probes_configpb.ProbeDef) error {
+	names := make(map[string]bool, len(probeDefs))
+	for _, p := range probeDefs {
+		names[p.GetName()] = true
+	}
+	for _, p := range probeDefs {
+		for _, d := range p.GetDependsOn() {
+			if d.GetProbe() != "" && !names[d.GetProbe()] {
+				return fmt.Errorf("probe %s: depends_on: unknown probe %s", p.GetName(), d.GetProbe())
+			}
+		}
+	}
+	return nil
+}
+
+// createProbe builds the probe options and creates (and initializes) a new
+// probe from the given definition. It doesn't add the probe to the prober.
+func (pr *Prober) createProbe(p *probes_configpb.ProbeDef) (*probes.ProbeInfo, error) {
 	opts, err := options.BuildProbeOptions(p, pr.ldLister, pr.c.GetGlobalTargetsOptions(), pr.l)
 	if err != nil {
-		return status.Errorf(codes.Unknown, err.Error())
+		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
 	pr.l.Infof("Creating a %s probe: %s", p.GetType(), p.GetName())
 	probeInfo, err := probes.CreateProbe(p, opts)
 	if err != nil {
-		return status.Errorf(codes.Unknown, err.Error())
+		return nil, status.Error(codes.Unknown, err.Error())
 	}
-	pr.Probes[p.GetName()] = probeInfo
-
-	return nil
+	return probeInfo, nil
 }
 
 // Init initialize prober with the given config file.
@@ -163,6 +208,14 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 		}
 	}
 
+	if err := alerting.Init(pr.c.GetAlertingOptions(), logger.NewWithAttrs(slog.String("component", "alerting"))); err != nil {
+		return err
+	}
+
+	if err := ratelimit.Init(pr.c.GetRateLimit()); err != nil {
+		return err
+	}
+
 	var err error
 
 	// Initialize shared targets
@@ -177,6 +230,10 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 	// Initiliaze probes
 	pr.Probes = make(map[string]*probes.ProbeInfo)
 	pr.probeCancelFunc = make(map[string]context.CancelFunc)
+	pr.targetStatus = newTargetStatusTracker()
+	if err := checkDependencies(pr.c.GetProbe()); err != nil {
+		return err
+	}
 	for _, p := range pr.c.GetProbe() {
 		if err := pr.addProbe(p); err != nil {
 			return err
@@ -189,6 +246,7 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 		return err
 	}
 
+	pr.surfacersCtx = ctx
 	pr.Surfacers, err = surfacers.Init(ctx, pr.c.GetSurfacer())
 	if err != nil {
 		return err
@@ -200,19 +258,26 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 // Start starts a previously initialized Cloudprober.
 func (pr *Prober) Start(ctx context.Context) {
 	pr.dataChan = make(chan *metrics.EventMetrics, 100000)
+	pr.mu.Lock()
+	pr.startCtx = ctx
+	pr.mu.Unlock()
 
 	go func() {
 		var em *metrics.EventMetrics
 		for {
 			em = <-pr.dataChan
 
+			pr.targetStatus.record(em)
+
 			// Replicate the surfacer message to every surfacer we have
 			// registered. Note that s.Write() is expected to be
 			// non-blocking to avoid blocking of EventMetrics message
 			// processing.
+			pr.surfacersMu.RLock()
 			for _, surfacer := range pr.Surfacers {
 				surfacer.Write(context.Background(), em)
 			}
+			pr.surfacersMu.RUnlock()
 		}
 	}()
 
@@ -247,13 +312,46 @@ func (pr *Prober) Start(ctx context.Context) {
 	}
 }
 
+// startProbe starts the named probe. Probes are started after pr.mu has been
+// released, so by now the probe may have been removed, or replaced and
+// started by a concurrent config reload or UpdateProbe call.
 func (pr *Prober) startProbe(ctx context.Context, name string) {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
 
+	p := pr.Probes[name]
+	if p == nil {
+		pr.l.Warningf("Probe %s was removed before it could be started", name)
+		return
+	}
+	if pr.probeCancelFunc[name] != nil {
+		return
+	}
+
 	probeCtx, cancelFunc := context.WithCancel(ctx)
 	pr.probeCancelFunc[name] = cancelFunc
-	go pr.Probes[name].Start(probeCtx, pr.dataChan)
+	go p.Start(probeCtx, pr.dataChan)
+	if p.Options != nil {
+		go p.Options.ExportScheduleStatus(probeCtx, strings.ToLower(p.Type), name, pr.dataChan)
+		go p.Options.ExportTargetsStaleness(probeCtx, strings.ToLower(p.Type), name, pr.dataChan)
+	}
+}
+
+// closeProbe releases the resources held by a probe that has been stopped,
+// or that was never started.
+func closeProbe(p *probes.ProbeInfo) {
+	if p != nil && p.Options != nil {
+		p.Options.Close()
+	}
+}
+
+// stopProbe cancels the probe's context, if it's running. It should be
+// called with pr.mu held.
+func (pr *Prober) stopProbe(name string) {
+	if cancelFunc := pr.probeCancelFunc[name]; cancelFunc != nil {
+		cancelFunc()
+		delete(pr.probeCancelFunc, name)
+	}
 }
 
 func randomDuration(duration, ceiling time.Duration) time.Duration {
@@ -19,7 +19,9 @@ import (
 	"testing"
 	"time"
 
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestRandomDuration(t *testing.T) {
@@ -72,3 +74,16 @@ func TestInterProbeWait(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDependencies(t *testing.T) {
+	probeDef := func(name string, deps ...string) *probes_configpb.ProbeDef {
+		p := &probes_configpb.ProbeDef{Name: proto.String(name)}
+		for _, d := range deps {
+			p.DependsOn = append(p.DependsOn, &probes_configpb.Dependency{Probe: proto.String(d)})
+		}
+		return p
+	}
+
+	assert.NoError(t, checkDependencies([]*probes_configpb.ProbeDef{probeDef("app", "gw"), probeDef("gw")}))
+	assert.Error(t, checkDependencies([]*probes_configpb.ProbeDef{probeDef("app", "gateway"), probeDef("gw")}))
+}
@@ -19,9 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Cloudprober_AddProbe_FullMethodName    = "/cloudprober.Cloudprober/AddProbe"
-	Cloudprober_RemoveProbe_FullMethodName = "/cloudprober.Cloudprober/RemoveProbe"
-	Cloudprober_ListProbes_FullMethodName  = "/cloudprober.Cloudprober/ListProbes"
+	Cloudprober_AddProbe_FullMethodName      = "/cloudprober.Cloudprober/AddProbe"
+	Cloudprober_RemoveProbe_FullMethodName   = "/cloudprober.Cloudprober/RemoveProbe"
+	Cloudprober_UpdateProbe_FullMethodName   = "/cloudprober.Cloudprober/UpdateProbe"
+	Cloudprober_ListProbes_FullMethodName    = "/cloudprober.Cloudprober/ListProbes"
+	Cloudprober_SetLameduck_FullMethodName   = "/cloudprober.Cloudprober/SetLameduck"
+	Cloudprober_ListLameducks_FullMethodName = "/cloudprober.Cloudprober/ListLameducks"
+	Cloudprober_RunProbe_FullMethodName      = "/cloudprober.Cloudprober/RunProbe"
 )
 
 // CloudproberClient is the client API for Cloudprober service.
@@ -33,8 +37,22 @@ type CloudproberClient interface {
 	AddProbe(ctx context.Context, in *AddProbeRequest, opts ...grpc.CallOption) (*AddProbeResponse, error)
 	// RemoveProbe stops the probe and removes it from the in-memory database.
 	RemoveProbe(ctx context.Context, in *RemoveProbeRequest, opts ...grpc.CallOption) (*RemoveProbeResponse, error)
+	// UpdateProbe replaces an existing probe with the given config. The new
+	// probe is initialized before the old one is stopped, so on error, the old
+	// probe keeps running unchanged.
+	UpdateProbe(ctx context.Context, in *UpdateProbeRequest, opts ...grpc.CallOption) (*UpdateProbeResponse, error)
 	// ListProbes lists active probes.
 	ListProbes(ctx context.Context, in *ListProbesRequest, opts ...grpc.CallOption) (*ListProbesResponse, error)
+	// SetLameduck lameducks or un-lameducks a target. It requires lameduck
+	// admin API to be enabled through lame_duck_options.
+	SetLameduck(ctx context.Context, in *SetLameduckRequest, opts ...grpc.CallOption) (*SetLameduckResponse, error)
+	// ListLameducks lists targets lameducked through the lameduck admin API or
+	// the lameduck file.
+	ListLameducks(ctx context.Context, in *ListLameducksRequest, opts ...grpc.CallOption) (*ListLameducksResponse, error)
+	// RunProbe runs a configured probe once, optionally against the given
+	// target, and returns the resulting metrics. Results of these runs are not
+	// exported to surfacers, and don't trigger alerts.
+	RunProbe(ctx context.Context, in *RunProbeRequest, opts ...grpc.CallOption) (*RunProbeResponse, error)
 }
 
 type cloudproberClient struct {
@@ -63,6 +81,15 @@ func (c *cloudproberClient) RemoveProbe(ctx context.Context, in *RemoveProbeRequ
 	return out, nil
 }
 
+func (c *cloudproberClient) UpdateProbe(ctx context.Context, in *UpdateProbeRequest, opts ...grpc.CallOption) (*UpdateProbeResponse, error) {
+	out := new(UpdateProbeResponse)
+	err := c.cc.Invoke(ctx, Cloudprober_UpdateProbe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cloudproberClient) ListProbes(ctx context.Context, in *ListProbesRequest, opts ...grpc.CallOption) (*ListProbesResponse, error) {
 	out := new(ListProbesResponse)
 	err := c.cc.Invoke(ctx, Cloudprober_ListProbes_FullMethodName, in, out, opts...)
@@ -72,6 +99,33 @@ func (c *cloudproberClient) ListProbes(ctx context.Context, in *ListProbesReques
 	return out, nil
 }
 
+func (c *cloudproberClient) SetLameduck(ctx context.Context, in *SetLameduckRequest, opts ...grpc.CallOption) (*SetLameduckResponse, error) {
+	out := new(SetLameduckResponse)
+	err := c.cc.Invoke(ctx, Cloudprober_SetLameduck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudproberClient) ListLameducks(ctx context.Context, in *ListLameducksRequest, opts ...grpc.CallOption) (*ListLameducksResponse, error) {
+	out := new(ListLameducksResponse)
+	err := c.cc.Invoke(ctx, Cloudprober_ListLameducks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudproberClient) RunProbe(ctx context.Context, in *RunProbeRequest, opts ...grpc.CallOption) (*RunProbeResponse, error) {
+	out := new(RunProbeResponse)
+	err := c.cc.Invoke(ctx, Cloudprober_RunProbe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CloudproberServer is the server API for Cloudprober service.
 // All implementations must embed UnimplementedCloudproberServer
 // for forward compatibility
@@ -81,8 +135,22 @@ type CloudproberServer interface {
 	AddProbe(context.Context, *AddProbeRequest) (*AddProbeResponse, error)
 	// RemoveProbe stops the probe and removes it from the in-memory database.
 	RemoveProbe(context.Context, *RemoveProbeRequest) (*RemoveProbeResponse, error)
+	// UpdateProbe replaces an existing probe with the given config. The new
+	// probe is initialized before the old one is stopped, so on error, the old
+	// probe keeps running unchanged.
+	UpdateProbe(context.Context, *UpdateProbeRequest) (*UpdateProbeResponse, error)
 	// ListProbes lists active probes.
 	ListProbes(context.Context, *ListProbesRequest) (*ListProbesResponse, error)
+	// SetLameduck lameducks or un-lameducks a target. It requires lameduck
+	// admin API to be enabled through lame_duck_options.
+	SetLameduck(context.Context, *SetLameduckRequest) (*SetLameduckResponse, error)
+	// ListLameducks lists targets lameducked through the lameduck admin API or
+	// the lameduck file.
+	ListLameducks(context.Context, *ListLameducksRequest) (*ListLameducksResponse, error)
+	// RunProbe runs a configured probe once, optionally against the given
+	// target, and returns the resulting metrics. Results of these runs are not
+	// exported to surfacers, and don't trigger alerts.
+	RunProbe(context.Context, *RunProbeRequest) (*RunProbeResponse, error)
 	mustEmbedUnimplementedCloudproberServer()
 }
 
@@ -96,9 +164,21 @@ func (UnimplementedCloudproberServer) AddProbe(context.Context, *AddProbeRequest
 func (UnimplementedCloudproberServer) RemoveProbe(context.Context, *RemoveProbeRequest) (*RemoveProbeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveProbe not implemented")
 }
+func (UnimplementedCloudproberServer) UpdateProbe(context.Context, *UpdateProbeRequest) (*UpdateProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProbe not implemented")
+}
 func (UnimplementedCloudproberServer) ListProbes(context.Context, *ListProbesRequest) (*ListProbesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProbes not implemented")
 }
+func (UnimplementedCloudproberServer) SetLameduck(context.Context, *SetLameduckRequest) (*SetLameduckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLameduck not implemented")
+}
+func (UnimplementedCloudproberServer) ListLameducks(context.Context, *ListLameducksRequest) (*ListLameducksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLameducks not implemented")
+}
+func (UnimplementedCloudproberServer) RunProbe(context.Context, *RunProbeRequest) (*RunProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunProbe not implemented")
+}
 func (UnimplementedCloudproberServer) mustEmbedUnimplementedCloudproberServer() {}
 
 // UnsafeCloudproberServer may be embedded to opt out of forward compatibility for this service.
@@ -148,6 +228,24 @@ func _Cloudprober_RemoveProbe_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cloudprober_UpdateProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudproberServer).UpdateProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cloudprober_UpdateProbe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudproberServer).UpdateProbe(ctx, req.(*UpdateProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cloudprober_ListProbes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListProbesRequest)
 	if err := dec(in); err != nil {
@@ -166,6 +264,60 @@ func _Cloudprober_ListProbes_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cloudprober_SetLameduck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLameduckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudproberServer).SetLameduck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cloudprober_SetLameduck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudproberServer).SetLameduck(ctx, req.(*SetLameduckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloudprober_ListLameducks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLameducksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudproberServer).ListLameducks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cloudprober_ListLameducks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudproberServer).ListLameducks(ctx, req.(*ListLameducksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloudprober_RunProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudproberServer).RunProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cloudprober_RunProbe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudproberServer).RunProbe(ctx, req.(*RunProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Cloudprober_ServiceDesc is the grpc.ServiceDesc for Cloudprober service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,10 +333,26 @@ var Cloudprober_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveProbe",
 			Handler:    _Cloudprober_RemoveProbe_Handler,
 		},
+		{
+			MethodName: "UpdateProbe",
+			Handler:    _Cloudprober_UpdateProbe_Handler,
+		},
 		{
 			MethodName: "ListProbes",
 			Handler:    _Cloudprober_ListProbes_Handler,
 		},
+		{
+			MethodName: "SetLameduck",
+			Handler:    _Cloudprober_SetLameduck_Handler,
+		},
+		{
+			MethodName: "ListLameducks",
+			Handler:    _Cloudprober_ListLameducks_Handler,
+		},
+		{
+			MethodName: "RunProbe",
+			Handler:    _Cloudprober_RunProbe_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/cloudprober/cloudprober/prober/proto/service.proto",
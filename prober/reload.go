@@ -0,0 +1,147 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"errors"
+	"fmt"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/cloudprober/cloudprober/internal/sysvars"
+	"github.com/cloudprober/cloudprober/probes"
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/surfacers"
+	"google.golang.org/protobuf/proto"
+)
+
+// restartRequired tells whether the config change requires a restart, i.e.
+// anything other than probes and surfacers has changed.
+func restartRequired(oldCfg, newCfg *configpb.ProberConfig) bool {
+	oldCfg = proto.Clone(oldCfg).(*configpb.ProberConfig)
+	newCfg = proto.Clone(newCfg).(*configpb.ProberConfig)
+	oldCfg.Probe, newCfg.Probe = nil, nil
+	oldCfg.Surfacer, newCfg.Surfacer = nil, nil
+	return !proto.Equal(oldCfg, newCfg)
+}
+
+// closeProbes releases the resources held by the probes that were created
+// but never started.
+func closeProbes(created map[string]*probes.ProbeInfo) {
+	for _, probeInfo := range created {
+		closeProbe(probeInfo)
+	}
+}
+
+// Reload applies the new config to a running prober: new probes and surfacers
+// are started, removed ones are stopped, and changed ones are restarted.
+// Probes and surfacers that didn't change keep running untouched, preserving
+// their state. Changes to other parts of the config require a restart.
+//
+// All new probes and surfacers are created before anything is stopped, so
+// that on error, prober keeps running with the current config.
+func (pr *Prober) Reload(cfg *configpb.ProberConfig) error {
+	pr.reloadMu.Lock()
+	defer pr.reloadMu.Unlock()
+
+	pr.mu.Lock()
+	oldCfg, startCtx := pr.c, pr.startCtx
+	current := make(map[string]*probes_configpb.ProbeDef, len(pr.Probes))
+	for name, p := range pr.Probes {
+		current[name] = p.ProbeDef
+	}
+	pr.mu.Unlock()
+
+	if startCtx == nil {
+		return errors.New("prober is not started yet")
+	}
+	if restartRequired(oldCfg, cfg) {
+		return errors.New("config changes other than probes and surfacers require a restart")
+	}
+	if err := checkDependencies(cfg.GetProbe()); err != nil {
+		return err
+	}
+
+	hostname := sysvars.Vars()["hostname"]
+	newDefs := make(map[string]*probes_configpb.ProbeDef)
+	for _, p := range cfg.GetProbe() {
+		runHere, err := runOnThisHost(p.GetRunOn(), hostname)
+		if err != nil {
+			return err
+		}
+		if !runHere {
+			continue
+		}
+		if newDefs[p.GetName()] != nil {
+			return fmt.Errorf("probe %s is defined more than once", p.GetName())
+		}
+		newDefs[p.GetName()] = p
+	}
+
+	created := make(map[string]*probes.ProbeInfo)
+	for name, p := range newDefs {
+		if current[name] != nil && proto.Equal(current[name], p) {
+			continue
+		}
+		probeInfo, err := pr.createProbe(p)
+		if err != nil {
+			closeProbes(created)
+			return fmt.Errorf("error creating probe %s: %v", name, err)
+		}
+		created[name] = probeInfo
+	}
+
+	// Only the probes from the previous config are removed. Probes added
+	// through the gRPC service are left alone.
+	var removed []string
+	for _, p := range oldCfg.GetProbe() {
+		if newDefs[p.GetName()] == nil && current[p.GetName()] != nil {
+			removed = append(removed, p.GetName())
+		}
+	}
+
+	pr.surfacersMu.RLock()
+	newSurfacers, err := surfacers.Reload(pr.surfacersCtx, pr.Surfacers, cfg.GetSurfacer())
+	pr.surfacersMu.RUnlock()
+	if err != nil {
+		closeProbes(created)
+		return err
+	}
+
+	pr.surfacersMu.Lock()
+	pr.Surfacers = newSurfacers
+	pr.surfacersMu.Unlock()
+
+	pr.mu.Lock()
+	for _, name := range removed {
+		pr.l.Infof("Config reload: removing probe %s", name)
+		pr.stopProbe(name)
+		closeProbe(pr.Probes[name])
+		delete(pr.Probes, name)
+		pr.targetStatus.deleteProbe(name)
+	}
+	for name, probeInfo := range created {
+		pr.l.Infof("Config reload: starting probe %s", name)
+		pr.stopProbe(name)
+		closeProbe(pr.Probes[name])
+		pr.Probes[name] = probeInfo
+	}
+	pr.c = cfg
+	pr.mu.Unlock()
+
+	for name := range created {
+		pr.startProbe(startCtx, name)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	pb "github.com/cloudprober/cloudprober/prober/proto"
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRestartRequired(t *testing.T) {
+	oldCfg := &configpb.ProberConfig{
+		Probe: []*probes_configpb.ProbeDef{testProbeDef("p1")},
+	}
+
+	assert.False(t, restartRequired(oldCfg, &configpb.ProberConfig{
+		Probe:    []*probes_configpb.ProbeDef{testProbeDef("p2")},
+		Surfacer: []*surfacerpb.SurfacerDef{{}},
+	}))
+	assert.True(t, restartRequired(oldCfg, &configpb.ProberConfig{
+		Probe:         []*probes_configpb.ProbeDef{testProbeDef("p1")},
+		DisableJitter: proto.Bool(true),
+	}))
+}
+
+func TestReload(t *testing.T) {
+	pr := testProber()
+	pr.startCtx, pr.surfacersCtx = context.Background(), context.Background()
+
+	surfacerDefs := []*surfacerpb.SurfacerDef{{}} // No surfacers.
+	pr.c = &configpb.ProberConfig{Surfacer: surfacerDefs}
+
+	running := make(map[string]*testProbe)
+	for _, name := range []string{"p1", "p2", "p3", "grpc-probe"} {
+		_, err := pr.AddProbe(context.Background(), &pb.AddProbeRequest{ProbeConfig: testProbeDef(name)})
+		if err != nil {
+			t.Fatalf("error while adding probe %s: %v", name, err)
+		}
+		if name != "grpc-probe" {
+			pr.c.Probe = append(pr.c.Probe, testProbeDef(name))
+		}
+		running[name] = pr.Probes[name].Probe.(*testProbe)
+		verifyProbeRunningStatus(t, running[name], true)
+	}
+
+	// Invalid config: nothing should change.
+	invalidDef := testProbeDef("p4")
+	invalidDef.IntervalMsec, invalidDef.TimeoutMsec = proto.Int32(1000), proto.Int32(2000)
+	err := pr.Reload(&configpb.ProberConfig{
+		Probe:    []*probes_configpb.ProbeDef{testProbeDef("p1"), invalidDef},
+		Surfacer: surfacerDefs,
+	})
+	assert.Error(t, err)
+	assert.Len(t, pr.Probes, 4)
+
+	// p1: unchanged, p2: changed, p3: removed, p4: added.
+	p2Def := testProbeDef("p2")
+	p2Def.IntervalMsec = proto.Int32(5000)
+	err = pr.Reload(&configpb.ProberConfig{
+		Probe:    []*probes_configpb.ProbeDef{testProbeDef("p1"), p2Def, testProbeDef("p4")},
+		Surfacer: surfacerDefs,
+	})
+	assert.NoError(t, err)
+
+	assert.Same(t, running["p1"], pr.Probes["p1"].Probe, "unchanged probe was replaced")
+
+	verifyProbeRunningStatus(t, running["p2"], false)
+	verifyProbeRunningStatus(t, pr.Probes["p2"].Probe.(*testProbe), true)
+	assert.Equal(t, int32(5000), pr.Probes["p2"].ProbeDef.GetIntervalMsec())
+
+	verifyProbeRunningStatus(t, running["p3"], false)
+	assert.Nil(t, pr.Probes["p3"])
+
+	verifyProbeRunningStatus(t, pr.Probes["p4"].Probe.(*testProbe), true)
+
+	// Probes added through gRPC are left alone.
+	assert.Same(t, running["grpc-probe"], pr.Probes["grpc-probe"].Probe)
+
+	// Surfacers reload error: new probes are discarded.
+	err = pr.Reload(&configpb.ProberConfig{
+		Probe:    append(pr.c.GetProbe(), testProbeDef("p5")),
+		Surfacer: []*surfacerpb.SurfacerDef{{Type: surfacerpb.Type_PROMETHEUS.Enum()}},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, pr.Probes["p5"])
+
+	// Changes other than probes and surfacers are rejected.
+	err = pr.Reload(&configpb.ProberConfig{
+		Probe:         pr.c.GetProbe(),
+		Surfacer:      surfacerDefs,
+		DisableJitter: proto.Bool(true),
+	})
+	assert.Error(t, err)
+}
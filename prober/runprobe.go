@@ -0,0 +1,166 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	pb "github.com/cloudprober/cloudprober/prober/proto"
+	"github.com/cloudprober/cloudprober/probes"
+	"github.com/cloudprober/cloudprober/probes/options"
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// oneOffProbeDef returns the definition for a one-off run of the probe:
+// without alerts and schedules, and with the target overridden, if provided.
+func oneOffProbeDef(p *probes_configpb.ProbeDef, target string) *probes_configpb.ProbeDef {
+	p = proto.Clone(p).(*probes_configpb.ProbeDef)
+	p.Alert = nil
+	p.Schedule = nil
+	p.RunOn = nil
+	if target != "" {
+		p.Targets = &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: target},
+		}
+	}
+	return p
+}
+
+// runProbeOnce runs a new instance of the probe with the given definition,
+// and returns the metrics from the first run for each target. It returns
+// early if context is canceled.
+func (pr *Prober) runProbeOnce(ctx context.Context, p *probes_configpb.ProbeDef) ([]*metrics.EventMetrics, error) {
+	opts, err := options.BuildProbeOptions(p, pr.ldLister, pr.c.GetGlobalTargetsOptions(), pr.l)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.OneOff = true
+
+	// Export stats after every run, or as soon as the probe type allows.
+	opts.StatsExportInterval = max(opts.Interval, opts.Timeout)
+	if p.GetType() == probes_configpb.ProbeDef_UDP {
+		opts.StatsExportInterval *= 2
+	}
+
+	targets := opts.Targets.ListEndpoints()
+	if len(targets) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "probe %s has no targets", p.GetName())
+	}
+
+	probeInfo, err := probes.CreateProbe(p, opts)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.StatsExportInterval+opts.Timeout+time.Second)
+	defer cancel()
+
+	dataChan := make(chan *metrics.EventMetrics, 100)
+	probeDone := make(chan struct{})
+	go func() {
+		probeInfo.Start(runCtx, dataChan)
+		close(probeDone)
+	}()
+
+	var ems []*metrics.EventMetrics
+	seen := make(map[string]bool)
+collect:
+	for len(seen) < len(targets) {
+		select {
+		case em := <-dataChan:
+			ems = append(ems, em)
+			seen[em.Label("dst")] = true
+		case <-runCtx.Done():
+			break collect
+		case <-probeDone:
+			break collect
+		}
+	}
+
+	// Stop the probe, making sure it's not blocked on the data channel.
+	cancel()
+	go func() {
+		for {
+			select {
+			case <-dataChan:
+			case <-probeDone:
+				return
+			}
+		}
+	}()
+
+	if len(ems) == 0 {
+		return nil, status.Errorf(codes.DeadlineExceeded, "probe %s didn't produce any metrics", p.GetName())
+	}
+	return ems, nil
+}
+
+// RunProbe runs a configured probe once, optionally against the given target,
+// and returns the resulting metrics.
+func (pr *Prober) RunProbe(ctx context.Context, req *pb.RunProbeRequest) (*pb.RunProbeResponse, error) {
+	name := req.GetProbeName()
+	if name == "" {
+		return &pb.RunProbeResponse{}, status.Errorf(codes.InvalidArgument, "probe name cannot be empty")
+	}
+
+	pr.mu.Lock()
+	probeInfo := pr.Probes[name]
+	pr.mu.Unlock()
+	if probeInfo == nil {
+		return &pb.RunProbeResponse{}, status.Errorf(codes.NotFound, "probe %s not found", name)
+	}
+
+	ems, err := pr.runProbeOnce(ctx, oneOffProbeDef(probeInfo.ProbeDef, req.GetTarget()))
+	if err != nil {
+		return &pb.RunProbeResponse{}, err
+	}
+
+	resp := &pb.RunProbeResponse{}
+	for _, em := range ems {
+		resp.Metrics = append(resp.Metrics, em.String())
+	}
+	return resp, nil
+}
+
+// RunProbeHandler is the HTTP handler for the on-demand probe runs:
+// POST /probes/run?probe=<name>&target=<target>. It responds with the
+// resulting metrics, one per line.
+func (pr *Prober) RunProbeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST method is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := pr.RunProbe(r.Context(), &pb.RunProbeRequest{
+		ProbeName: proto.String(r.FormValue("probe")),
+		Target:    proto.String(r.FormValue("target")),
+	})
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), httpStatusCode(err))
+		return
+	}
+
+	for _, m := range resp.GetMetrics() {
+		fmt.Fprintln(w, m)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	alertingpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	pb "github.com/cloudprober/cloudprober/prober/proto"
+	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
+	tcppb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOneOffProbeDef(t *testing.T) {
+	p := &probes_configpb.ProbeDef{
+		Name: proto.String("test-probe"),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: "a,b"},
+		},
+		Alert: []*alertingpb.AlertConf{{Name: "test-alert"}},
+	}
+
+	got := oneOffProbeDef(p, "")
+	assert.Nil(t, got.GetAlert())
+	assert.Equal(t, "a,b", got.GetTargets().GetHostNames())
+
+	got = oneOffProbeDef(p, "c")
+	assert.Equal(t, "c", got.GetTargets().GetHostNames())
+
+	// Original definition is not modified.
+	assert.Len(t, p.GetAlert(), 1)
+	assert.Equal(t, "a,b", p.GetTargets().GetHostNames())
+}
+
+func TestRunProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Error starting TCP listener: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	pr := testProber()
+	assert.NoError(t, pr.addProbe(&probes_configpb.ProbeDef{
+		Name:         proto.String("tcp-probe"),
+		Type:         probes_configpb.ProbeDef_TCP.Enum(),
+		IntervalMsec: proto.Int32(500),
+		TimeoutMsec:  proto.Int32(400),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: "unused.example.com"},
+		},
+		Probe: &probes_configpb.ProbeDef_TcpProbe{
+			TcpProbe: &tcppb.ProbeConf{Port: proto.Int32(int32(ln.Addr().(*net.TCPAddr).Port))},
+		},
+	}))
+
+	resp, err := pr.RunProbe(context.Background(), &pb.RunProbeRequest{
+		ProbeName: proto.String("tcp-probe"),
+		Target:    proto.String("localhost"),
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetMetrics(), 1) {
+		m := resp.GetMetrics()[0]
+		assert.Contains(t, m, "probe=tcp-probe")
+		assert.Contains(t, m, "dst=localhost")
+		assert.Contains(t, m, "success=1")
+	}
+
+	_, err = pr.RunProbe(context.Background(), &pb.RunProbeRequest{ProbeName: proto.String("unknown")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// HTTP handler
+	w := httptest.NewRecorder()
+	pr.RunProbeHandler(w, httptest.NewRequest(http.MethodPost, "/probes/run?probe=tcp-probe&target=localhost", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, strings.TrimSpace(w.Body.String()), "success=1")
+
+	w = httptest.NewRecorder()
+	pr.RunProbeHandler(w, httptest.NewRequest(http.MethodGet, "/probes/run?probe=tcp-probe", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	pr.RunProbeHandler(w, httptest.NewRequest(http.MethodPost, "/probes/run", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -17,7 +17,9 @@ package prober
 import (
 	"context"
 
+	"github.com/cloudprober/cloudprober/internal/sysvars"
 	pb "github.com/cloudprober/cloudprober/prober/proto"
+	"github.com/cloudprober/cloudprober/targets/lameduck"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -30,6 +32,9 @@ func (pr *Prober) AddProbe(ctx context.Context, req *pb.AddProbeRequest) (*pb.Ad
 	if p == nil {
 		return &pb.AddProbeResponse{}, status.Errorf(codes.InvalidArgument, "probe config cannot be nil")
 	}
+	if p.GetName() == "" {
+		return &pb.AddProbeResponse{}, status.Errorf(codes.InvalidArgument, "probe name cannot be empty")
+	}
 
 	if err := pr.addProbe(p); err != nil {
 		return &pb.AddProbeResponse{}, err
@@ -58,12 +63,63 @@ func (pr *Prober) RemoveProbe(ctx context.Context, req *pb.RemoveProbeRequest) (
 		return &pb.RemoveProbeResponse{}, status.Errorf(codes.NotFound, "probe %s not found", name)
 	}
 
-	pr.probeCancelFunc[name]()
+	pr.stopProbe(name)
 	delete(pr.Probes, name)
+	pr.targetStatus.deleteProbe(name)
 
 	return &pb.RemoveProbeResponse{}, nil
 }
 
+// UpdateProbe gRPC method replaces an existing probe with a new one created
+// from the given config. New probe is created and initialized before the old
+// probe is stopped, so that an invalid config leaves the old probe running.
+func (pr *Prober) UpdateProbe(ctx context.Context, req *pb.UpdateProbeRequest) (*pb.UpdateProbeResponse, error) {
+	p := req.GetProbeConfig()
+
+	if p == nil {
+		return &pb.UpdateProbeResponse{}, status.Errorf(codes.InvalidArgument, "probe config cannot be nil")
+	}
+	name := p.GetName()
+	if name == "" {
+		return &pb.UpdateProbeResponse{}, status.Errorf(codes.InvalidArgument, "probe name cannot be empty")
+	}
+
+	pr.mu.Lock()
+	exists := pr.Probes[name] != nil
+	pr.mu.Unlock()
+	if !exists {
+		return &pb.UpdateProbeResponse{}, status.Errorf(codes.NotFound, "probe %s not found", name)
+	}
+
+	runHere, err := runOnThisHost(p.GetRunOn(), sysvars.Vars()["hostname"])
+	if err != nil {
+		return &pb.UpdateProbeResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !runHere {
+		return &pb.UpdateProbeResponse{}, status.Errorf(codes.FailedPrecondition, "probe %s is not configured to run on this host", name)
+	}
+
+	// Create the new probe outside the lock, as probe initialization may
+	// take a while.
+	probeInfo, err := pr.createProbe(p)
+	if err != nil {
+		return &pb.UpdateProbeResponse{}, err
+	}
+
+	pr.mu.Lock()
+	if pr.Probes[name] == nil {
+		pr.mu.Unlock()
+		return &pb.UpdateProbeResponse{}, status.Errorf(codes.NotFound, "probe %s was removed during the update", name)
+	}
+	pr.stopProbe(name)
+	pr.Probes[name] = probeInfo
+	pr.mu.Unlock()
+
+	pr.grpcStartProbeCh <- name
+
+	return &pb.UpdateProbeResponse{}, nil
+}
+
 // ListProbes gRPC method returns the list of probes from the in-memory database.
 func (pr *Prober) ListProbes(ctx context.Context, req *pb.ListProbesRequest) (*pb.ListProbesResponse, error) {
 	pr.mu.Lock()
@@ -80,3 +136,36 @@ func (pr *Prober) ListProbes(ctx context.Context, req *pb.ListProbesRequest) (*p
 
 	return resp, nil
 }
+
+// SetLameduck gRPC method lameducks or un-lameducks the given target through
+// the lameduck admin API.
+func (pr *Prober) SetLameduck(ctx context.Context, req *pb.SetLameduckRequest) (*pb.SetLameduckResponse, error) {
+	target := req.GetTarget()
+
+	if target == "" {
+		return &pb.SetLameduckResponse{}, status.Errorf(codes.InvalidArgument, "target cannot be empty")
+	}
+
+	var err error
+	if req.GetLameduck() {
+		err = lameduck.Lameduck(target)
+	} else {
+		err = lameduck.Unlameduck(target)
+	}
+	if err != nil {
+		return &pb.SetLameduckResponse{}, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	return &pb.SetLameduckResponse{}, nil
+}
+
+// ListLameducks gRPC method returns the targets lameducked through the
+// lameduck admin API or the lameduck file.
+func (pr *Prober) ListLameducks(ctx context.Context, req *pb.ListLameducksRequest) (*pb.ListLameducksResponse, error) {
+	targets, err := lameduck.List()
+	if err != nil {
+		return &pb.ListLameducksResponse{}, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	return &pb.ListLameducksResponse{Target: targets}, nil
+}
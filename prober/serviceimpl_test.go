@@ -16,6 +16,7 @@ package prober
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -28,6 +29,8 @@ import (
 	probes_configpb "github.com/cloudprober/cloudprober/probes/proto"
 	testdatapb "github.com/cloudprober/cloudprober/probes/testdata"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -36,6 +39,7 @@ func testProber() *Prober {
 		Probes:           make(map[string]*probes.ProbeInfo),
 		probeCancelFunc:  make(map[string]context.CancelFunc),
 		grpcStartProbeCh: make(chan string),
+		targetStatus:     newTargetStatusTracker(),
 	}
 
 	// Start a never-ending loop to clear pr.grpcStartProbeCh channel.
@@ -222,6 +226,75 @@ func TestRemoveProbes(t *testing.T) {
 	verifyProbeRunningStatus(t, p, false)
 }
 
+func TestUpdateProbe(t *testing.T) {
+	pr := testProber()
+
+	testProbeName := "test-probe"
+
+	// Update a non-existent probe, should result in error.
+	_, err := pr.UpdateProbe(context.Background(), &pb.UpdateProbeRequest{ProbeConfig: testProbeDef(testProbeName)})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("updating non-existent probe: got error %v, expected NotFound", err)
+	}
+
+	_, err = pr.AddProbe(context.Background(), &pb.AddProbeRequest{ProbeConfig: testProbeDef(testProbeName)})
+	if err != nil {
+		t.Errorf("error while adding test probe: %v", err)
+	}
+	oldProbe := pr.Probes[testProbeName].Probe.(*testProbe)
+	verifyProbeRunningStatus(t, oldProbe, true)
+
+	// Invalid config should result in error, and keep the old probe running.
+	invalidDef := testProbeDef(testProbeName)
+	invalidDef.IntervalMsec, invalidDef.TimeoutMsec = proto.Int32(1000), proto.Int32(2000)
+	_, err = pr.UpdateProbe(context.Background(), &pb.UpdateProbeRequest{ProbeConfig: invalidDef})
+	if err == nil {
+		t.Error("updating probe with invalid config didn't result in error")
+	}
+	if pr.Probes[testProbeName].Probe != oldProbe {
+		t.Error("probe replaced despite the invalid config")
+	}
+
+	newDef := testProbeDef(testProbeName)
+	newDef.IntervalMsec = proto.Int32(5000)
+	_, err = pr.UpdateProbe(context.Background(), &pb.UpdateProbeRequest{ProbeConfig: newDef})
+	if err != nil {
+		t.Errorf("error while updating probe: %v", err)
+	}
+
+	// Old probe should stop, and the new probe should start.
+	verifyProbeRunningStatus(t, oldProbe, false)
+	newProbe := pr.Probes[testProbeName].Probe.(*testProbe)
+	verifyProbeRunningStatus(t, newProbe, true)
+	if got := pr.Probes[testProbeName].ProbeDef.GetIntervalMsec(); got != 5000 {
+		t.Errorf("updated probe interval_msec=%d, expected=5000", got)
+	}
+}
+
+func TestStartProbeRemovedOrRunning(t *testing.T) {
+	pr := testProber()
+
+	// Probe removed before it could be started.
+	pr.startProbe(context.Background(), "removed-probe")
+	if pr.probeCancelFunc["removed-probe"] != nil {
+		t.Error("removed probe was started")
+	}
+
+	_, err := pr.AddProbe(context.Background(), &pb.AddProbeRequest{ProbeConfig: testProbeDef("test-probe")})
+	if err != nil {
+		t.Fatalf("error while adding test probe: %v", err)
+	}
+	p := pr.Probes["test-probe"].Probe.(*testProbe)
+	verifyProbeRunningStatus(t, p, true)
+
+	// Starting an already running probe is a no-op.
+	cancelFunc := pr.probeCancelFunc["test-probe"]
+	pr.startProbe(context.Background(), "test-probe")
+	if fmt.Sprintf("%p", pr.probeCancelFunc["test-probe"]) != fmt.Sprintf("%p", cancelFunc) {
+		t.Error("running probe was started again")
+	}
+}
+
 func init() {
 	// Register extension probe.
 	probes.RegisterProbeType(200, func() probes.Probe {
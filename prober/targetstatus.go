@@ -0,0 +1,158 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// TargetStatus is the current status of a probe target, based on the probe
+// results.
+type TargetStatus struct {
+	Probe  string
+	Target string
+	Labels map[string]string
+
+	// Status is known only after the first probe results for the target.
+	Known   bool
+	Healthy bool
+
+	// Failures since the last success. Probe results are exported at the
+	// stats export interval, so if there were both failures and successes in
+	// an interval, failures are assumed to be the latest.
+	ConsecutiveFailures int64
+	LastLatency         time.Duration
+	LastUpdated         time.Time
+}
+
+// targetStatusTracker tracks the targets' status from the probe results.
+type targetStatusTracker struct {
+	mu       sync.Mutex
+	status   map[string]map[string]*TargetStatus
+	trackers map[string]map[string]*metrics.ResultsTracker
+}
+
+func newTargetStatusTracker() *targetStatusTracker {
+	return &targetStatusTracker{
+		status:   make(map[string]map[string]*TargetStatus),
+		trackers: make(map[string]map[string]*metrics.ResultsTracker),
+	}
+}
+
+func (t *targetStatusTracker) record(em *metrics.EventMetrics) {
+	probeName, targetName := em.Label("probe"), em.Label("dst")
+	if probeName == "" || targetName == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts, rt := t.status[probeName][targetName], t.trackers[probeName][targetName]
+	if ts == nil {
+		ts, rt = &TargetStatus{Probe: probeName, Target: targetName}, &metrics.ResultsTracker{}
+	}
+	delta, ok := rt.Update(em)
+	if !ok {
+		return
+	}
+
+	if t.status[probeName] == nil {
+		t.status[probeName] = make(map[string]*TargetStatus)
+		t.trackers[probeName] = make(map[string]*metrics.ResultsTracker)
+	}
+	t.status[probeName][targetName], t.trackers[probeName][targetName] = ts, rt
+
+	if delta.Total > 0 {
+		ts.Known, ts.Healthy = true, delta.Success == delta.Total
+		if delta.Success == 0 {
+			ts.ConsecutiveFailures += delta.Total
+		} else {
+			ts.ConsecutiveFailures = delta.Total - delta.Success
+		}
+	}
+	if delta.Latency > 0 {
+		ts.LastLatency = delta.Latency
+	}
+	ts.LastUpdated = em.Timestamp
+}
+
+// deleteProbe forgets the probe's targets, e.g. when probe is removed.
+func (t *targetStatusTracker) deleteProbe(probeName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.status, probeName)
+	delete(t.trackers, probeName)
+}
+
+// TargetsStatus returns the status of all the probes' targets, sorted by
+// probe and target names. Targets are the probes' current targets, along
+// with their labels, and the targets that probe results have been seen for.
+func (pr *Prober) TargetsStatus() []*TargetStatus {
+	// Probes without targets (nil endpoints) show all the targets that
+	// probe results have been seen for.
+	pr.mu.Lock()
+	probeTargets := make(map[string][]endpoint.Endpoint)
+	for name, p := range pr.Probes {
+		probeTargets[name] = nil
+		if p.Options != nil && p.Options.Targets != nil {
+			probeTargets[name] = append([]endpoint.Endpoint{}, p.Options.Targets.ListEndpoints()...)
+		}
+	}
+	pr.mu.Unlock()
+
+	pr.targetStatus.mu.Lock()
+	defer pr.targetStatus.mu.Unlock()
+
+	var result []*TargetStatus
+	for probeName, endpoints := range probeTargets {
+		seen := pr.targetStatus.status[probeName]
+		if endpoints == nil {
+			for _, ts := range seen {
+				status := *ts
+				result = append(result, &status)
+			}
+			continue
+		}
+
+		// Probes report targets either by name or by name:port.
+		for _, ep := range endpoints {
+			status := &TargetStatus{Probe: probeName, Target: ep.Dst()}
+			ts := seen[ep.Dst()]
+			if ts == nil {
+				ts = seen[ep.Name]
+			}
+			if ts != nil {
+				s := *ts
+				status = &s
+			}
+			status.Labels = ep.Labels
+			result = append(result, status)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Probe != result[j].Probe {
+			return result[i].Probe < result[j].Probe
+		}
+		return result[i].Target < result[j].Target
+	})
+	return result
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTargetEM(probe, dst string, total, success int64, latencyUs float64) *metrics.EventMetrics {
+	return metrics.NewEventMetrics(time.Now()).
+		AddLabel("probe", probe).
+		AddLabel("dst", dst).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("success", metrics.NewInt(success)).
+		AddMetric("latency", metrics.NewFloat(latencyUs))
+}
+
+func TestTargetStatusTrackerRecord(t *testing.T) {
+	tracker := newTargetStatusTracker()
+	status := func() *TargetStatus { return tracker.status["p1"]["t1"] }
+
+	tracker.record(testTargetEM("p1", "t1", 10, 10, 10000))
+	assert.True(t, status().Known)
+	assert.True(t, status().Healthy)
+	assert.Equal(t, time.Millisecond, status().LastLatency)
+
+	tracker.record(testTargetEM("p1", "t1", 20, 15, 20000))
+	assert.False(t, status().Healthy)
+	assert.Equal(t, int64(5), status().ConsecutiveFailures)
+	assert.Equal(t, 2*time.Millisecond, status().LastLatency)
+
+	tracker.record(testTargetEM("p1", "t1", 30, 15, 20000))
+	assert.Equal(t, int64(15), status().ConsecutiveFailures)
+
+	// Probe restart.
+	tracker.record(testTargetEM("p1", "t1", 5, 5, 15000))
+	assert.True(t, status().Healthy)
+	assert.Equal(t, int64(0), status().ConsecutiveFailures)
+	assert.Equal(t, 3*time.Millisecond, status().LastLatency)
+
+	// EventMetrics without probe results are ignored.
+	tracker.record(metrics.NewEventMetrics(time.Now()).AddLabel("probe", "p2").AddLabel("dst", "t1"))
+	assert.Nil(t, tracker.status["p2"])
+}
+
+func TestTargetsStatus(t *testing.T) {
+	pr := testProber()
+	pr.Probes["p1"] = &probes.ProbeInfo{
+		Options: &options.Options{
+			Targets: targets.StaticEndpoints([]endpoint.Endpoint{
+				{Name: "t1", Labels: map[string]string{"zone": "a"}},
+				{Name: "t2", Port: 8080, Labels: map[string]string{"zone": "b"}},
+				{Name: "t3"},
+			}),
+		},
+	}
+	pr.Probes["p2"] = &probes.ProbeInfo{Options: &options.Options{}}
+
+	pr.targetStatus.record(testTargetEM("p1", "t1", 10, 10, 1000))
+	pr.targetStatus.record(testTargetEM("p1", "t2", 10, 0, 0))
+	pr.targetStatus.record(testTargetEM("p1", "removed", 10, 10, 1000))
+	pr.targetStatus.record(testTargetEM("p2", "t1", 10, 10, 1000))
+
+	var got []TargetStatus
+	for _, ts := range pr.TargetsStatus() {
+		got = append(got, TargetStatus{
+			Probe:   ts.Probe,
+			Target:  ts.Target,
+			Labels:  ts.Labels,
+			Known:   ts.Known,
+			Healthy: ts.Healthy,
+		})
+	}
+	assert.Equal(t, []TargetStatus{
+		{Probe: "p1", Target: "t1", Labels: map[string]string{"zone": "a"}, Known: true, Healthy: true},
+		{Probe: "p1", Target: "t2", Labels: map[string]string{"zone": "b"}, Known: true},
+		{Probe: "p1", Target: "t3"},
+		{Probe: "p2", Target: "t1", Known: true, Healthy: true},
+	}, got)
+}
@@ -0,0 +1,339 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package arp implements a neighbor liveness probe. It sends ARP requests (for
+IPv4 targets) or ICMPv6 neighbor solicitations (for IPv6 targets) to targets
+on the local network segment, and reports statistics on requests sent, replies
+received and the latency experienced.
+
+As neither ARP nor NDP messages carry a sequence number, replies are matched
+to the oldest outstanding request for the target.
+*/
+package arp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/iputils"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/arp/proto"
+	"github.com/cloudprober/cloudprober/probes/common/sched"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// neighborConn is the interface implemented by ARP and NDP connections. It
+// allows us to test the probe without raw sockets.
+type neighborConn interface {
+	// sendRequest sends a neighbor request for the given IP.
+	sendRequest(ip net.IP) error
+	// readReply reads the next neighbor reply and returns the IP address it
+	// was sent for.
+	readReply(deadline time.Time) (net.IP, time.Time, error)
+	close() error
+}
+
+// Probe holds aggregate information about all probe runs, per-target.
+type Probe struct {
+	name string
+	opts *options.Options
+	c    *configpb.ProbeConf
+	l    *logger.Logger
+
+	requestsInterval time.Duration
+
+	// newConn creates a new neighbor connection. It's a field to allow
+	// overriding it in tests.
+	newConn func(iface *net.Interface, srcIP net.IP, ipVer int) (neighborConn, error)
+	// interfaceForIP determines the interface to use for the target IP.
+	interfaceForIP func(ip net.IP) (*net.Interface, net.IP, error)
+}
+
+type probeResult struct {
+	total, success int64
+	latency        metrics.LatencyValue
+}
+
+func (p *Probe) newResult() sched.ProbeResult {
+	result := &probeResult{}
+
+	if p.opts.LatencyDist != nil {
+		result.latency = p.opts.LatencyDist.CloneDist()
+	} else {
+		result.latency = metrics.NewFloat(0)
+	}
+
+	return result
+}
+
+func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	return metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(result.total)).
+		AddMetric("success", metrics.NewInt(result.success)).
+		AddMetric(opts.LatencyMetricName, result.latency.Clone()).
+		AddLabel("ptype", "arp")
+}
+
+// Init initializes the probe with the given params.
+func (p *Probe) Init(name string, opts *options.Options) error {
+	if opts.ProbeConf == nil {
+		opts.ProbeConf = &configpb.ProbeConf{}
+	}
+
+	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
+	if !ok {
+		return fmt.Errorf("not arp probe config")
+	}
+	p.name = name
+	p.opts = opts
+	if p.l = opts.Logger; p.l == nil {
+		p.l = &logger.Logger{}
+	}
+	p.c = c
+
+	if p.c.GetRequestsPerProbe() < 1 {
+		return fmt.Errorf("requests_per_probe (%d) should be at least 1", p.c.GetRequestsPerProbe())
+	}
+	p.requestsInterval = time.Duration(p.c.GetRequestsIntervalMsec()) * time.Millisecond
+
+	// Make sure all requests can be sent before the probe times out.
+	if sendTime := time.Duration(p.c.GetRequestsPerProbe()-1) * p.requestsInterval; sendTime >= p.opts.Timeout {
+		return fmt.Errorf("time required to send all the requests (%v) is more than the probe timeout (%v)", sendTime, p.opts.Timeout)
+	}
+
+	if p.newConn == nil {
+		p.newConn = newNeighborConn
+	}
+
+	if p.interfaceForIP == nil {
+		p.interfaceForIP = p.findInterface
+	}
+
+	if p.c.GetInterface() != "" {
+		if _, err := net.InterfaceByName(p.c.GetInterface()); err != nil {
+			return fmt.Errorf("error getting interface %s: %v", p.c.GetInterface(), err)
+		}
+	}
+
+	return nil
+}
+
+func newNeighborConn(iface *net.Interface, srcIP net.IP, ipVer int) (neighborConn, error) {
+	if ipVer == 6 {
+		return newNDPConn(iface, srcIP)
+	}
+	return newARPConn(iface, srcIP)
+}
+
+// matchIfaceAddr returns the address on the interface that matches the given
+// filter.
+func matchIfaceAddr(iface *net.Interface, match func(*net.IPNet) bool) (net.IP, error) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && match(ipNet) {
+			return ipNet.IP, nil
+		}
+	}
+	return nil, nil
+}
+
+// findInterface finds the interface and the source IP that should be used to
+// reach the given target IP.
+func (p *Probe) findInterface(ip net.IP) (*net.Interface, net.IP, error) {
+	ipVer := iputils.IPVersion(ip)
+
+	var ifaces []net.Interface
+	if p.c.GetInterface() != "" {
+		iface, err := net.InterfaceByName(p.c.GetInterface())
+		if err != nil {
+			return nil, nil, err
+		}
+		ifaces = []net.Interface{*iface}
+	} else {
+		var err error
+		if ifaces, err = net.Interfaces(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// If source IP is configured, we look for the interface that has it. If
+	// interface was configured explicitly, we don't need to match the target
+	// IP against interface's subnets.
+	match := func(ipNet *net.IPNet) bool {
+		if p.opts.SourceIP != nil {
+			return ipNet.IP.Equal(p.opts.SourceIP)
+		}
+		return iputils.IPVersion(ipNet.IP) == ipVer && (p.c.GetInterface() != "" || ipNet.Contains(ip))
+	}
+
+	for i := range ifaces {
+		iface := &ifaces[i]
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		srcIP, err := matchIfaceAddr(iface, match)
+		if err != nil {
+			return nil, nil, err
+		}
+		if srcIP != nil {
+			return iface, srcIP, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no suitable interface found for %s; target may not be on the local network", ip.String())
+}
+
+// pendingRequests keeps track of the send times of the requests that have not
+// been replied to yet.
+type pendingRequests struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (pr *pendingRequests) push(t time.Time) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.times = append(pr.times, t)
+}
+
+// remove removes the given send time, used if a request could not be sent.
+func (pr *pendingRequests) remove(t time.Time) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	for i := range pr.times {
+		if pr.times[i].Equal(t) {
+			pr.times = append(pr.times[:i], pr.times[i+1:]...)
+			return
+		}
+	}
+}
+
+// pop returns the send time of the oldest outstanding request.
+func (pr *pendingRequests) pop() (time.Time, bool) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if len(pr.times) == 0 {
+		return time.Time{}, false
+	}
+	t := pr.times[0]
+	pr.times = pr.times[1:]
+	return t, true
+}
+
+// sendRequests sends requests to the target and records their send times.
+func (p *Probe) sendRequests(conn neighborConn, ip net.IP, pending *pendingRequests) {
+	for i := int32(0); i < p.c.GetRequestsPerProbe(); i++ {
+		if i > 0 {
+			time.Sleep(p.requestsInterval)
+		}
+		// Record send time before sending the request, so that it's available
+		// even if the reply arrives before sendRequest returns.
+		sendTime := time.Now()
+		pending.push(sendTime)
+		if err := conn.sendRequest(ip); err != nil {
+			pending.remove(sendTime)
+			p.l.Warningf("Error sending request to %s: %v", ip.String(), err)
+		}
+	}
+}
+
+func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
+	result := res.(*probeResult)
+	result.total += int64(p.c.GetRequestsPerProbe())
+
+	ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+	if err != nil {
+		p.l.Error("target: ", target.Name, ", resolve error: ", err.Error())
+		return
+	}
+
+	iface, srcIP, err := p.interfaceForIP(ip)
+	if err != nil {
+		p.l.Warning("target: ", target.Name, ", error finding interface: ", err.Error())
+		return
+	}
+
+	conn, err := p.newConn(iface, srcIP, iputils.IPVersion(ip))
+	if err != nil {
+		p.l.Error("target: ", target.Name, ", error creating connection: ", err.Error())
+		return
+	}
+	defer conn.close()
+
+	for _, al := range p.opts.AdditionalLabels {
+		al.UpdateForTarget(target, ip.String(), 0)
+	}
+
+	deadline := time.Now().Add(p.opts.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	pending := &pendingRequests{}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.sendRequests(conn, ip, pending)
+	}()
+
+	for received := int32(0); received < p.c.GetRequestsPerProbe(); {
+		replyIP, recvTime, err := conn.readReply(deadline)
+		if err != nil {
+			if !errors.Is(err, errTimeout) {
+				p.l.Warning("target: ", target.Name, ", error reading reply: ", err.Error())
+			}
+			break
+		}
+		if !replyIP.Equal(ip) {
+			continue
+		}
+
+		// Reply without an outstanding request, e.g. a duplicate reply.
+		sendTime, ok := pending.pop()
+		if !ok {
+			continue
+		}
+		received++
+		result.success++
+		result.latency.AddFloat64(recvTime.Sub(sendTime).Seconds() / p.opts.LatencyUnit.Seconds())
+	}
+
+	wg.Wait()
+}
+
+// Start starts and runs the probe indefinitely.
+func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	s := &sched.Scheduler{
+		ProbeName:              p.name,
+		DataChan:               dataChan,
+		Opts:                   p.opts,
+		NewResult:              p.newResult,
+		RunProbeForTarget:      p.runProbe,
+		IntervalBetweenTargets: time.Duration(p.c.GetIntervalBetweenTargetsMsec()) * time.Millisecond,
+	}
+	s.UpdateTargetsAndStartProbes(ctx)
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/arp/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// testConn replies to requests for IPs in the replyFor map. Value in the map
+// is the number of replies to send for each request.
+type testConn struct {
+	replyFor map[string]int
+	replies  chan net.IP
+	sent     []string
+}
+
+func (tc *testConn) sendRequest(ip net.IP) error {
+	tc.sent = append(tc.sent, ip.String())
+	for i := 0; i < tc.replyFor[ip.String()]; i++ {
+		tc.replies <- ip
+	}
+	return nil
+}
+
+func (tc *testConn) readReply(deadline time.Time) (net.IP, time.Time, error) {
+	select {
+	case ip := <-tc.replies:
+		return ip, time.Now(), nil
+	case <-time.After(time.Until(deadline)):
+		return nil, time.Time{}, errTimeout
+	}
+}
+
+func (tc *testConn) close() error { return nil }
+
+func TestARPPacket(t *testing.T) {
+	srcMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	b, err := arpRequest(srcMAC, net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"))
+	if err != nil {
+		t.Fatalf("arpRequest(): unexpected error: %v", err)
+	}
+	assert.Equal(t, arpPacketSize, len(b))
+
+	// Parsing a request should fail.
+	_, err = parseARPReply(b)
+	assert.Error(t, err, "parseARPReply() on a request")
+
+	// Convert request into a reply from the target.
+	b[7] = arpOpReply
+	copy(b[14:18], net.ParseIP("10.0.0.2").To4())
+	ip, err := parseARPReply(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", ip.String())
+
+	_, err = arpRequest(srcMAC, net.ParseIP("10.0.0.1"), net.ParseIP("::1"))
+	assert.Error(t, err, "arpRequest() for IPv6 target")
+
+	_, err = parseARPReply(b[:10])
+	assert.Error(t, err, "parseARPReply() on a short packet")
+}
+
+func TestNDPPacket(t *testing.T) {
+	assert.Equal(t, "ff02::1:ffcd:ef01", solicitedNodeAddr(net.ParseIP("2001:db8::ab:cd:ef01")).String())
+
+	srcMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	body := neighborSolicitationBody(srcMAC, net.ParseIP("fe80::1"))
+	assert.Equal(t, 28, len(body), "body length")
+	assert.Equal(t, []byte{ndpOptSourceLinkAddr, 1}, body[20:22], "source link-layer address option header")
+	assert.Equal(t, []byte(srcMAC), body[22:28])
+
+	// Advertisement body has the same layout as the solicitation body for the
+	// target address.
+	ip, err := parseNeighborAdvertisementBody(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "fe80::1", ip.String())
+
+	_, err = parseNeighborAdvertisementBody(body[:10])
+	assert.Error(t, err)
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *configpb.ProbeConf
+		timeout time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "default",
+			conf:    &configpb.ProbeConf{},
+			timeout: time.Second,
+		},
+		{
+			name:    "zero_requests",
+			conf:    &configpb.ProbeConf{RequestsPerProbe: proto.Int32(0)},
+			timeout: time.Second,
+			wantErr: true,
+		},
+		{
+			name:    "requests_dont_fit_in_timeout",
+			conf:    &configpb.ProbeConf{RequestsPerProbe: proto.Int32(5), RequestsIntervalMsec: proto.Int32(300)},
+			timeout: time.Second,
+			wantErr: true,
+		},
+		{
+			name:    "bad_interface",
+			conf:    &configpb.ProbeConf{Interface: proto.String("nonexistent-intf0")},
+			timeout: time.Second,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.Timeout = test.timeout
+			opts.ProbeConf = test.conf
+
+			p := &Probe{}
+			err := p.Init("test_arp", opts)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Init() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunProbe(t *testing.T) {
+	tests := []struct {
+		target      string
+		replyFor    map[string]int
+		wantTotal   int64
+		wantSuccess int64
+	}{
+		{
+			target:      "10.0.0.2",
+			replyFor:    map[string]int{"10.0.0.2": 1},
+			wantTotal:   3,
+			wantSuccess: 3,
+		},
+		{
+			target:      "10.0.0.3",
+			replyFor:    map[string]int{"10.0.0.2": 1},
+			wantTotal:   3,
+			wantSuccess: 0,
+		},
+		{
+			// Duplicate replies should not be counted.
+			target:      "fe80::2",
+			replyFor:    map[string]int{"fe80::2": 2},
+			wantTotal:   3,
+			wantSuccess: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.Timeout = 100 * time.Millisecond
+			opts.Targets = targets.StaticTargets(test.target)
+			opts.ProbeConf = &configpb.ProbeConf{
+				RequestsPerProbe:     proto.Int32(3),
+				RequestsIntervalMsec: proto.Int32(1),
+			}
+
+			tc := &testConn{
+				replyFor: test.replyFor,
+				replies:  make(chan net.IP, 10),
+			}
+
+			var gotIPVer int
+			p := &Probe{
+				newConn: func(_ *net.Interface, _ net.IP, ipVer int) (neighborConn, error) {
+					gotIPVer = ipVer
+					return tc, nil
+				},
+				interfaceForIP: func(ip net.IP) (*net.Interface, net.IP, error) {
+					return &net.Interface{Name: "eth0"}, nil, nil
+				},
+			}
+			if err := p.Init("test_arp", opts); err != nil {
+				t.Fatalf("Error initializing probe: %v", err)
+			}
+
+			result := p.newResult().(*probeResult)
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: test.target}, result)
+
+			wantIPVer := 4
+			if net.ParseIP(test.target).To4() == nil {
+				wantIPVer = 6
+			}
+			assert.Equal(t, wantIPVer, gotIPVer, "IP version")
+			assert.Equal(t, []string{test.target, test.target, test.target}, tc.sent)
+			assert.Equal(t, test.wantTotal, result.total, "total")
+			assert.Equal(t, test.wantSuccess, result.success, "success")
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package arp
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// arpConn sends and receives ARP packets over an AF_PACKET datagram socket.
+// Kernel takes care of the ethernet header for datagram packet sockets.
+type arpConn struct {
+	fd     int
+	iface  *net.Interface
+	srcIP  net.IP
+	srcMAC net.HardwareAddr
+}
+
+func htons(i uint16) uint16 {
+	return (i<<8)&0xff00 | i>>8
+}
+
+func newARPConn(iface *net.Interface, srcIP net.IP) (neighborConn, error) {
+	if srcIP.To4() == nil {
+		return nil, fmt.Errorf("source IP (%s) is not an IPv4 address", srcIP.String())
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, int(htons(etherTypeARP)))
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	// Bind to the interface so that we receive ARP packets only from it.
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(etherTypeARP), Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	return &arpConn{fd: fd, iface: iface, srcIP: srcIP.To4(), srcMAC: iface.HardwareAddr}, nil
+}
+
+func (ac *arpConn) sendRequest(ip net.IP) error {
+	b, err := arpRequest(ac.srcMAC, ac.srcIP, ip)
+	if err != nil {
+		return err
+	}
+
+	dst := &unix.SockaddrLinklayer{
+		Protocol: htons(etherTypeARP),
+		Ifindex:  ac.iface.Index,
+		Halen:    6,
+	}
+	copy(dst.Addr[:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	return os.NewSyscallError("sendto", unix.Sendto(ac.fd, b, 0, dst))
+}
+
+func (ac *arpConn) readReply(deadline time.Time) (net.IP, time.Time, error) {
+	buf := make([]byte, 128)
+	for {
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			return nil, time.Time{}, errTimeout
+		}
+		tv := unix.NsecToTimeval(timeout.Nanoseconds())
+		if err := unix.SetsockoptTimeval(ac.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+			return nil, time.Time{}, os.NewSyscallError("setsockopt", err)
+		}
+
+		n, _, err := unix.Recvfrom(ac.fd, buf, 0)
+		if err != nil {
+			if err == unix.EAGAIN || err == unix.EWOULDBLOCK || err == unix.EINTR {
+				continue
+			}
+			return nil, time.Time{}, os.NewSyscallError("recvfrom", err)
+		}
+		recvTime := time.Now()
+
+		ip, err := parseARPReply(buf[:n])
+		if err != nil {
+			continue
+		}
+		return ip, recvTime, nil
+	}
+}
+
+func (ac *arpConn) close() error {
+	return unix.Close(ac.fd)
+}
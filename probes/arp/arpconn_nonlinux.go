@@ -0,0 +1,27 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package arp
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+)
+
+func newARPConn(iface *net.Interface, srcIP net.IP) (neighborConn, error) {
+	return nil, fmt.Errorf("ARP probes are not supported on %s, only IPv6 (NDP) targets can be probed", runtime.GOOS)
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv6"
+)
+
+const protocolIPv6ICMP = 58
+
+// ndpConn sends neighbor solicitations and receives neighbor advertisements
+// over a raw ICMPv6 socket.
+type ndpConn struct {
+	c      *icmp.PacketConn
+	iface  *net.Interface
+	srcMAC net.HardwareAddr
+}
+
+func newNDPConn(iface *net.Interface, srcIP net.IP) (neighborConn, error) {
+	addr := srcIP.String()
+	if srcIP.IsLinkLocalUnicast() {
+		addr += "%" + iface.Name
+	}
+
+	c, err := icmp.ListenPacket("ip6:ipv6-icmp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Neighbor discovery messages must be sent with hop limit 255 (RFC 4861).
+	pc := c.IPv6PacketConn()
+	if err := pc.SetMulticastHopLimit(255); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("error setting multicast hop limit: %v", err)
+	}
+	if err := pc.SetHopLimit(255); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("error setting hop limit: %v", err)
+	}
+
+	// Let only neighbor advertisements through.
+	var f ipv6.ICMPFilter
+	f.SetAll(true)
+	f.Accept(ipv6.ICMPTypeNeighborAdvertisement)
+	if err := pc.SetICMPFilter(&f); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("error setting ICMP filter: %v", err)
+	}
+
+	return &ndpConn{c: c, iface: iface, srcMAC: iface.HardwareAddr}, nil
+}
+
+func (nc *ndpConn) sendRequest(ip net.IP) error {
+	msg := &icmp.Message{
+		Type: ipv6.ICMPTypeNeighborSolicitation,
+		Body: &icmp.RawBody{Data: neighborSolicitationBody(nc.srcMAC, ip)},
+	}
+	// Checksum is computed by the kernel for ICMPv6 raw sockets.
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return err
+	}
+	_, err = nc.c.WriteTo(b, &net.IPAddr{IP: solicitedNodeAddr(ip), Zone: nc.iface.Name})
+	return err
+}
+
+func (nc *ndpConn) readReply(deadline time.Time) (net.IP, time.Time, error) {
+	if err := nc.c.SetReadDeadline(deadline); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := nc.c.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return nil, time.Time{}, errTimeout
+			}
+			return nil, time.Time{}, err
+		}
+		recvTime := time.Now()
+
+		msg, err := icmp.ParseMessage(protocolIPv6ICMP, buf[:n])
+		if err != nil || msg.Type != ipv6.ICMPTypeNeighborAdvertisement {
+			continue
+		}
+		body, ok := msg.Body.(*icmp.RawBody)
+		if !ok {
+			continue
+		}
+		ip, err := parseNeighborAdvertisementBody(body.Data)
+		if err != nil {
+			continue
+		}
+		return ip, recvTime, nil
+	}
+}
+
+func (nc *ndpConn) close() error {
+	return nc.c.Close()
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv4 = 0x0800
+
+	arpHardwareEthernet = 1
+	arpOpRequest        = 1
+	arpOpReply          = 2
+	arpPacketSize       = 28
+
+	ndpOptSourceLinkAddr = 1
+	ndpNSBodySize        = 20 // 4 reserved bytes + 16 bytes target address.
+)
+
+var errTimeout = errors.New("timed out waiting for reply")
+
+// arpRequest returns an ARP request packet (without the ethernet header) for
+// the target IP.
+func arpRequest(srcMAC net.HardwareAddr, srcIP, targetIP net.IP) ([]byte, error) {
+	if len(srcMAC) != 6 {
+		return nil, fmt.Errorf("unsupported hardware address: %s", srcMAC.String())
+	}
+	if srcIP.To4() == nil || targetIP.To4() == nil {
+		return nil, fmt.Errorf("ARP supports only IPv4 addresses, got source: %s, target: %s", srcIP, targetIP)
+	}
+
+	b := make([]byte, arpPacketSize)
+	binary.BigEndian.PutUint16(b[0:2], arpHardwareEthernet)
+	binary.BigEndian.PutUint16(b[2:4], etherTypeIPv4)
+	b[4], b[5] = 6, 4 // Hardware and protocol address lengths.
+	binary.BigEndian.PutUint16(b[6:8], arpOpRequest)
+	copy(b[8:14], srcMAC)
+	copy(b[14:18], srcIP.To4())
+	// Target hardware address (b[18:24]) is left zero.
+	copy(b[24:28], targetIP.To4())
+	return b, nil
+}
+
+// parseARPReply parses an ARP packet (without the ethernet header) and returns
+// the sender IP address if it's an ARP reply.
+func parseARPReply(b []byte) (net.IP, error) {
+	if len(b) < arpPacketSize {
+		return nil, fmt.Errorf("packet too short for ARP: %d bytes", len(b))
+	}
+	if binary.BigEndian.Uint16(b[2:4]) != etherTypeIPv4 || b[4] != 6 || b[5] != 4 {
+		return nil, fmt.Errorf("not an IPv4 over ethernet ARP packet")
+	}
+	if op := binary.BigEndian.Uint16(b[6:8]); op != arpOpReply {
+		return nil, fmt.Errorf("not an ARP reply, operation: %d", op)
+	}
+	return net.IPv4(b[14], b[15], b[16], b[17]), nil
+}
+
+// solicitedNodeAddr returns the solicited-node multicast address for the
+// given IPv6 address (RFC 4291, section 2.7.1).
+func solicitedNodeAddr(ip net.IP) net.IP {
+	addr := net.ParseIP("ff02::1:ff00:0")
+	copy(addr[13:], ip.To16()[13:])
+	return addr
+}
+
+// neighborSolicitationBody returns the body (everything after the ICMPv6
+// header) of a neighbor solicitation message for the target IP.
+func neighborSolicitationBody(srcMAC net.HardwareAddr, targetIP net.IP) []byte {
+	b := make([]byte, ndpNSBodySize, ndpNSBodySize+2+len(srcMAC))
+	copy(b[4:20], targetIP.To16())
+
+	// Source link-layer address option. Option length is in units of 8 bytes.
+	if len(srcMAC) != 0 {
+		optLen := 2 + len(srcMAC)
+		b = append(b, ndpOptSourceLinkAddr, byte((optLen+7)/8))
+		b = append(b, srcMAC...)
+		b = append(b, make([]byte, ((optLen+7)/8)*8-optLen)...)
+	}
+	return b
+}
+
+// parseNeighborAdvertisementBody parses the body of a neighbor advertisement
+// message and returns the target address in it.
+func parseNeighborAdvertisementBody(b []byte) (net.IP, error) {
+	if len(b) < 20 {
+		return nil, fmt.Errorf("neighbor advertisement too short: %d bytes", len(b))
+	}
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, b[4:20])
+	return ip, nil
+}
@@ -0,0 +1,27 @@
+package proto
+
+// ARP probe sends ARP requests (IPv4) or neighbor solicitations (IPv6) to
+// targets on the local network segment and reports reply latency and loss.
+// This is useful to verify L2 adjacency in environments where ICMP is
+// filtered.
+//
+// Note: ARP probes require raw packet access, i.e. root privileges or the
+// CAP_NET_RAW capability, and are supported only on Linux. IPv6 neighbor
+// discovery probes are supported on all unix-like systems.
+// Next tag: 5
+#ProbeConf: {
+	// Network interface to send requests on. If not specified, we use the
+	// interface that has the source IP (if configured) or the interface whose
+	// subnet contains the target IP.
+	interface?: string @protobuf(1,string)
+
+	// Requests per probe. Each probe run sends these many requests to the
+	// target and all of them count towards the "total" metric.
+	requestsPerProbe?: int32 @protobuf(2,int32,name=requests_per_probe,"default=2")
+
+	// How long to wait between two requests to the same target.
+	requestsIntervalMsec?: int32 @protobuf(3,int32,name=requests_interval_msec,"default=25")
+
+	// Interval between targets.
+	intervalBetweenTargetsMsec?: int32 @protobuf(4,int32,name=interval_between_targets_msec,"default=10")
+}
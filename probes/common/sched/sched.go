@@ -92,6 +92,18 @@ func (s *Scheduler) gapBetweenTargets() time.Duration {
 	return interTargetGap
 }
 
+// resultCounts returns the result's total and success counters.
+func resultCounts(result ProbeResult, ts time.Time, opts *options.Options) (total, success int64) {
+	em := result.Metrics(ts, opts)
+	if v, ok := em.Metric("total").(*metrics.Int); ok {
+		total = v.Int64()
+	}
+	if v, ok := em.Metric("success").(*metrics.Int); ok {
+		success = v.Int64()
+	}
+	return
+}
+
 func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint) {
 	s.Opts.Logger.Debug("Starting probing for the target ", target.Name)
 
@@ -100,7 +112,9 @@ func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint
 
 	result := s.NewResult()
 
-	ticker := time.NewTicker(s.Opts.Interval)
+	interval := s.Opts.Interval
+	ti := s.Opts.AdaptiveInterval.ForTarget()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for ts := time.Now(); true; ts = <-ticker.C {
@@ -108,11 +122,19 @@ func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint
 		if ctxDone(ctx) {
 			return
 		}
-		if !s.Opts.IsScheduled() {
+		if !s.Opts.IsScheduled() || !s.Opts.RateLimiter.Allow(target) {
 			continue
 		}
 		s.RunProbeForTarget(ctx, target, result)
 
+		// Shorten or extend the interval based on the last run's result.
+		if ti != nil {
+			if next := ti.Next(resultCounts(result, ts, s.Opts)); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+
 		// Export stats if it's the time to do so.
 		runCnt++
 		if (runCnt % s.statsExportFrequency) == 0 {
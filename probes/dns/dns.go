@@ -237,6 +237,9 @@ func (p *Probe) runProbe(resultsChan chan<- statskeeper.ProbeResult) {
 
 	wg := sync.WaitGroup{}
 	for _, target := range p.targets {
+		if !p.opts.RateLimiter.Allow(target) {
+			continue
+		}
 		wg.Add(1)
 
 		// Launch a separate goroutine for each target.
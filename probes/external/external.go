@@ -378,7 +378,7 @@ type probeStatus struct {
 
 func (p *Probe) processProbeResult(ps *probeStatus, result *result) {
 	if ps.success && p.opts.Validators != nil {
-		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(ps.payload)}, result.validationFailure, p.l)
+		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(ps.payload), Latency: ps.latency}, result.validationFailure, p.l)
 
 		// If any validation failed, log and set success to false.
 		if len(failedValidations) > 0 {
@@ -476,6 +476,9 @@ func (p *Probe) runServerProbe(ctx, startCtx context.Context) {
 
 	// Send probe requests
 	for _, target := range p.targets {
+		if !p.opts.RateLimiter.Allow(target) {
+			continue
+		}
 		p.requestID++
 		p.results[target.Key()].total++
 		outstandingReqsMu.Lock()
@@ -507,6 +510,9 @@ func (p *Probe) runOnceProbe(ctx context.Context) {
 	var wg sync.WaitGroup
 
 	for _, target := range p.targets {
+		if !p.opts.RateLimiter.Allow(target) {
+			continue
+		}
 		wg.Add(1)
 		go func(target endpoint.Endpoint, result *result) {
 			defer wg.Done()
@@ -62,10 +62,12 @@ func TestGenericRequest(t *testing.T) {
 				},
 			},
 			wantResp: strings.Join([]string{
+				"cloudprober.servers.grpc.Prober.BidiStreamEcho",
 				"cloudprober.servers.grpc.Prober.BlobRead",
 				"cloudprober.servers.grpc.Prober.BlobWrite",
 				"cloudprober.servers.grpc.Prober.Echo",
 				"cloudprober.servers.grpc.Prober.ServerStatus",
+				"cloudprober.servers.grpc.Prober.ServerStreamEcho",
 			}, ","),
 		},
 		{
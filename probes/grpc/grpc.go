@@ -358,7 +358,7 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 		case <-ticker.C:
 		}
 
-		if !p.opts.IsScheduled() {
+		if !p.opts.IsScheduled() || !p.opts.RateLimiter.Allow(tgt) {
 			continue
 		}
 
@@ -410,7 +410,12 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 		}
 
 		if p.opts.Validators != nil {
-			failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(r.String())}, result.validationFailure, p.l)
+			input := &validators.Input{ResponseBody: []byte(r.String()), Latency: delta}
+			// TLS connection state is used by the certificate validator.
+			if tlsInfo, ok := peer.AuthInfo.(credentials.TLSInfo); ok {
+				input.Response = &tlsInfo.State
+			}
+			failedValidations := validators.RunValidators(p.opts.Validators, input, result.validationFailure, p.l)
 
 			if len(failedValidations) > 0 {
 				p.l.DebugAttrs("Some validations failed", append(logAttrs, slog.String("failed_validations", strings.Join(failedValidations, ",")))...)
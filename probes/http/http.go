@@ -94,6 +94,7 @@ type probeResult struct {
 	respCodes                    *metrics.Map[int64]
 	respBodies                   *metrics.Map[int64]
 	validationFailure            *metrics.Map[int64]
+	validatorValues              map[string]float64
 	sslEarliestExpirationSeconds int64
 }
 
@@ -266,7 +267,17 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
 	}
 
+	// Phase latencies are used only by the validators.
+	var pt *phaseTimer
+	if p.opts.Validators != nil {
+		pt = newPhaseTimer()
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), pt.trace()))
+	}
+
 	start := time.Now()
+	if pt != nil {
+		pt.start = start
+	}
 	resp, err := client.Do(req)
 	latency := time.Since(start)
 
@@ -315,7 +326,14 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 	}
 
 	if p.opts.Validators != nil {
-		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{Response: resp, ResponseBody: respBody}, result.validationFailure, p.l)
+		input := &validators.Input{
+			Response:     resp,
+			ResponseBody: respBody,
+			Latency:      latency,
+			PhaseLatency: pt.latencies(),
+			Values:       result.validatorValues,
+		}
+		failedValidations := validators.RunValidators(p.opts.Validators, input, result.validationFailure, p.l)
 
 		// If any validation failed, return now, leaving the success and latency
 		// counters unchanged.
@@ -333,7 +351,7 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 }
 
 func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, clients []*http.Client, req *http.Request, result *probeResult) {
-	reqCtx, cancelReqCtx := context.WithTimeout(ctx, p.opts.Timeout)
+	reqCtx, cancelReqCtx := context.WithTimeout(ctx, p.opts.TimeoutForTarget(target))
 	defer cancelReqCtx()
 
 	if p.c.GetRequestsPerProbe() == 1 {
@@ -368,6 +386,7 @@ func (p *Probe) newResult() *probeResult {
 
 	if p.opts.Validators != nil {
 		result.validationFailure = validators.ValidationFailureMap(p.opts.Validators)
+		result.validatorValues = make(map[string]float64)
 	}
 
 	if p.opts.LatencyDist != nil {
@@ -415,6 +434,19 @@ func (p *Probe) exportMetrics(ts time.Time, result *probeResult, target endpoint
 		em.AddLabel("ptype", "http").AddLabel("probe", p.name).AddLabel("dst", target.Name)
 		p.opts.RecordMetrics(target, em, dataChan, options.WithNoAlert())
 	}
+
+	// Values extracted by the validators (e.g. numeric validator) are gauges
+	// as well.
+	if len(result.validatorValues) > 0 {
+		m := metrics.NewMapFloat("validator")
+		for k, v := range result.validatorValues {
+			m.IncKeyBy(k, v)
+		}
+		em := metrics.NewEventMetrics(ts).AddMetric("validator_value", m)
+		em.Kind = metrics.GAUGE
+		em.AddLabel("ptype", "http").AddLabel("probe", p.name).AddLabel("dst", target.Name)
+		p.opts.RecordMetrics(target, em, dataChan, options.WithNoAlert())
+	}
 }
 
 // Returns clients for a target. We use a different HTTP client (transport) for
@@ -462,7 +494,9 @@ func (p *Probe) startForTarget(ctx context.Context, target endpoint.Endpoint, da
 
 	result := p.newResult()
 	req := p.httpRequestForTarget(target)
-	ticker := time.NewTicker(p.opts.Interval)
+	interval := p.opts.Interval
+	ti := p.opts.AdaptiveInterval.ForTarget()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	clients := p.clientsForTarget(target)
@@ -472,7 +506,7 @@ func (p *Probe) startForTarget(ctx context.Context, target endpoint.Endpoint, da
 			return
 		}
 
-		if !p.opts.IsScheduled() {
+		if !p.opts.IsScheduled() || !p.opts.RateLimiter.Allow(target) {
 			continue
 		}
 
@@ -485,6 +519,14 @@ func (p *Probe) startForTarget(ctx context.Context, target endpoint.Endpoint, da
 			result.total += int64(p.c.GetRequestsPerProbe())
 		}
 
+		// Shorten or extend the interval based on the last run's result.
+		if ti != nil {
+			if next := ti.Next(result.total, result.success); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+
 		// Export stats if it's the time to do so.
 		runCnt++
 		if (runCnt % p.statsExportFrequency) == 0 {
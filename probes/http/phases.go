@@ -0,0 +1,83 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/validators/latency"
+)
+
+// phaseTimer records the latency of the HTTP request phases, for the
+// validators. Trace hooks may be called concurrently, e.g. for parallel
+// connection attempts, hence the lock.
+type phaseTimer struct {
+	mu                               sync.Mutex
+	start                            time.Time
+	dnsStart, connectStart, tlsStart time.Time
+	phases                           map[string]time.Duration
+}
+
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{phases: make(map[string]time.Duration)}
+}
+
+func (pt *phaseTimer) set(t *time.Time) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	*t = time.Now()
+}
+
+func (pt *phaseTimer) record(phase string, start *time.Time) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.phases[phase] = time.Since(*start)
+}
+
+func (pt *phaseTimer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { pt.set(&pt.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { pt.record(latency.PhaseDNS, &pt.dnsStart) },
+		ConnectStart: func(_, _ string) {
+			pt.set(&pt.connectStart)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				pt.record(latency.PhaseConnect, &pt.connectStart)
+			}
+		},
+		TLSHandshakeStart: func() { pt.set(&pt.tlsStart) },
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				pt.record(latency.PhaseTLS, &pt.tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() { pt.record(latency.PhaseTTFB, &pt.start) },
+	}
+}
+
+// latencies returns the recorded phase latencies.
+func (pt *phaseTimer) latencies() map[string]time.Duration {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	phases := make(map[string]time.Duration, len(pt.phases))
+	for k, v := range pt.phases {
+		phases[k] = v
+	}
+	return phases
+}
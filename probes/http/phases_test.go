@@ -0,0 +1,62 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/validators/latency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseTimer(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	// Use localhost instead of IP to get a DNS lookup.
+	url := strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)
+	client := ts.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.ServerName = "example.com"
+
+	for _, reused := range []bool{false, true} {
+		pt := newPhaseTimer()
+		req, _ := http.NewRequest("GET", url, nil)
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), pt.trace()))
+
+		pt.start = time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Error making request: %v", err)
+		}
+		resp.Body.Close()
+
+		phases := pt.latencies()
+		assert.GreaterOrEqual(t, phases[latency.PhaseTTFB], 20*time.Millisecond)
+		if reused {
+			assert.Len(t, phases, 1, "phases: %v", phases)
+			continue
+		}
+		for _, phase := range []string{latency.PhaseDNS, latency.PhaseConnect, latency.PhaseTLS} {
+			assert.Greater(t, phases[phase], time.Duration(0), "phase: %s", phase)
+		}
+	}
+}
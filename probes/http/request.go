@@ -146,12 +146,17 @@ func (p *Probe) urlHostAndIPLabel(target endpoint.Endpoint, host string) (string
 }
 
 func (p *Probe) httpRequestForTarget(target endpoint.Endpoint) *http.Request {
+	tgo := p.opts.TargetOverrides(target)
+
 	// Prepare HTTP.Request for Client.Do
 	port := int(p.c.GetPort())
 	// If port is not configured explicitly, use target's port if available.
 	if port == 0 {
 		port = target.Port
 	}
+	if tgo.Port != 0 {
+		port = tgo.Port
+	}
 
 	host := hostForTarget(target)
 
@@ -166,9 +171,21 @@ func (p *Probe) httpRequestForTarget(target endpoint.Endpoint) *http.Request {
 		al.UpdateForTarget(target, ipForLabel, port)
 	}
 
-	url := fmt.Sprintf("%s://%s%s", p.schemeForTarget(target), hostWithPort(urlHost, port), pathForTarget(target, p.url))
+	path := pathForTarget(target, p.url)
+	if tgo.Path != "" {
+		path = tgo.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+	}
+	url := fmt.Sprintf("%s://%s%s", p.schemeForTarget(target), hostWithPort(urlHost, port), path)
+
+	reqBody := p.requestBody
+	if tgo.Body != nil {
+		reqBody = httpreq.NewRequestBody(*tgo.Body)
+	}
 
-	req, err := httpreq.NewRequest(p.method, url, p.requestBody)
+	req, err := httpreq.NewRequest(p.method, url, reqBody)
 	if err != nil {
 		p.l.Error("target: ", target.Name, ", error creating HTTP request: ", err.Error())
 		return nil
@@ -178,6 +195,13 @@ func (p *Probe) httpRequestForTarget(target endpoint.Endpoint) *http.Request {
 	if p.c.GetUserAgent() != "" {
 		req.Header.Set("User-Agent", p.c.GetUserAgent())
 	}
+	for k, v := range tgo.Header {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
 
 	return req
 }
@@ -208,7 +232,7 @@ func (p *Probe) prepareRequest(req *http.Request) *http.Request {
 	//      share it across multiple requests.
 	//   -- if OAuth token is used, each request gets its own Authorization
 	//      header.
-	if p.oauthTS == nil && p.requestBody.Len() == 0 {
+	if p.oauthTS == nil && req.GetBody == nil {
 		return req
 	}
 
@@ -226,7 +250,9 @@ func (p *Probe) prepareRequest(req *http.Request) *http.Request {
 		req.Header.Set("Authorization", "Bearer "+tok)
 	}
 
-	req.Body = p.requestBody.Reader()
+	if req.GetBody != nil {
+		req.Body, _ = req.GetBody()
+	}
 
 	return req
 }
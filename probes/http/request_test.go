@@ -16,6 +16,7 @@ package http
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"testing"
@@ -501,3 +502,50 @@ func TestResolveFirst(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestTargetOverrides(t *testing.T) {
+	p := &Probe{}
+	opts := &options.Options{
+		Targets:  targets.StaticTargets("test.com"),
+		Interval: 10 * time.Millisecond,
+		ProbeConf: &configpb.ProbeConf{
+			Port:        proto.Int32(8080),
+			RelativeUrl: proto.String("/status"),
+			Header:      map[string]string{"X-Env": "prod", "X-Probe": "http"},
+			Body:        []string{"probe-body"},
+		},
+	}
+	if err := p.Init("http_test", opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	target := endpoint.Endpoint{
+		Name: "test.com",
+		Labels: map[string]string{
+			"cloudprober_port":          "9090",
+			"cloudprober_path":          "healthz",
+			"cloudprober_body":          "target-body",
+			"cloudprober_header_x-env":  "staging",
+			"cloudprober_header_x-team": "web",
+		},
+	}
+
+	req := p.httpRequestForTarget(target)
+	assert.Equal(t, "http://test.com:9090/healthz", req.URL.String())
+	assert.Equal(t, "staging", req.Header.Get("X-Env"))
+	assert.Equal(t, "http", req.Header.Get("X-Probe"))
+	assert.Equal(t, "web", req.Header.Get("X-Team"))
+
+	for i := 0; i < 2; i++ {
+		body, err := io.ReadAll(p.prepareRequest(req).Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "target-body", string(body))
+	}
+
+	// Without overrides.
+	req = p.httpRequestForTarget(endpoint.Endpoint{Name: "test.com"})
+	assert.Equal(t, "http://test.com:8080/status", req.URL.String())
+	body, err := io.ReadAll(p.prepareRequest(req).Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "probe-body", string(body))
+}
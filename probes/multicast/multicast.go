@@ -0,0 +1,390 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package multicast implements a multicast probe. In the receive mode (default),
+it joins the multicast groups specified by the targets, and for every probe
+interval verifies that at least a configured number of packets arrived. In the
+send mode, it sends sequenced packets to the multicast groups.
+
+Packets sent by the sender mode carry a small header with a sequence number and
+a send timestamp. Receivers use the sequence number to detect lost packets.
+Packets without this header (e.g. from a third-party sender) are still counted,
+but lost packets can't be detected for them.
+
+Receive mode exports the following metrics:
+  - total: number of probe intervals.
+  - success: number of intervals with at least min_packets_per_interval packets.
+  - packets: number of packets received. Use its rate for the packet rate.
+  - lost: number of packets detected as lost, using the sequence numbers.
+  - latency: inter-arrival gaps between packets. Configure a latency
+    distribution to get a gap histogram.
+*/
+package multicast
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/common/sched"
+	configpb "github.com/cloudprober/cloudprober/probes/multicast/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	maxPacketSize = 65536
+
+	// Header: magic (4 bytes) + sequence number (8 bytes) + timestamp (8 bytes)
+	headerSize = 20
+	magic      = 0x43504d43 // "CPMC"
+)
+
+// Probe holds aggregate information about all probe runs, per-target.
+type Probe struct {
+	name  string
+	opts  *options.Options
+	c     *configpb.ProbeConf
+	l     *logger.Logger
+	iface *net.Interface
+
+	// listen joins the multicast group and returns a connection to read
+	// packets from. It's a field to allow overriding it in tests.
+	listen func(group *net.UDPAddr) (net.PacketConn, error)
+	// dial returns a connection to send packets to the multicast group.
+	dial func(group *net.UDPAddr) (net.Conn, error)
+}
+
+// groupListener receives packets for a multicast group and keeps track of the
+// packets received since the last probe run.
+type groupListener struct {
+	mu           sync.Mutex
+	packets      int64
+	lost         int64
+	gaps         []time.Duration
+	lastRecvTime time.Time
+	nextSeq      uint64
+	seqInit      bool
+}
+
+// record records a packet received at the given time.
+func (gl *groupListener) record(pkt []byte, recvTime time.Time) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.packets++
+	if !gl.lastRecvTime.IsZero() {
+		gl.gaps = append(gl.gaps, recvTime.Sub(gl.lastRecvTime))
+	}
+	gl.lastRecvTime = recvTime
+
+	seq, _, ok := parseHeader(pkt)
+	if !ok {
+		return
+	}
+	if gl.seqInit && seq > gl.nextSeq {
+		gl.lost += int64(seq - gl.nextSeq)
+	}
+	// Ignore out of order and duplicate packets for sequence tracking.
+	if !gl.seqInit || seq >= gl.nextSeq {
+		gl.nextSeq = seq + 1
+		gl.seqInit = true
+	}
+}
+
+// flush returns the stats recorded since the last flush and resets them.
+func (gl *groupListener) flush() (packets, lost int64, gaps []time.Duration) {
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	packets, lost, gaps = gl.packets, gl.lost, gl.gaps
+	gl.packets, gl.lost, gl.gaps = 0, 0, nil
+	return
+}
+
+type probeResult struct {
+	total, success, packets, lost int64
+	latency                       metrics.LatencyValue
+	sendMode                      bool
+
+	// Only one of these is used, depending on the mode.
+	listener *groupListener
+	conn     net.Conn
+	seq      uint64
+}
+
+func (p *Probe) newResult() sched.ProbeResult {
+	result := &probeResult{
+		sendMode: p.c.GetMode() == configpb.ProbeConf_SEND,
+	}
+
+	if p.opts.LatencyDist != nil {
+		result.latency = p.opts.LatencyDist.CloneDist()
+	} else {
+		result.latency = metrics.NewFloat(0)
+	}
+
+	return result
+}
+
+func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(result.total)).
+		AddMetric("success", metrics.NewInt(result.success)).
+		AddLabel("ptype", "multicast")
+
+	// Packet metrics are relevant only in receive mode.
+	if !result.sendMode {
+		em.AddMetric("packets", metrics.NewInt(result.packets)).
+			AddMetric("lost", metrics.NewInt(result.lost)).
+			AddMetric(opts.LatencyMetricName, result.latency.Clone())
+	}
+
+	return em
+}
+
+// Init initializes the probe with the given params.
+func (p *Probe) Init(name string, opts *options.Options) error {
+	if opts.ProbeConf == nil {
+		opts.ProbeConf = &configpb.ProbeConf{}
+	}
+
+	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
+	if !ok {
+		return fmt.Errorf("not multicast probe config")
+	}
+	p.name = name
+	p.opts = opts
+	if p.l = opts.Logger; p.l == nil {
+		p.l = &logger.Logger{}
+	}
+	p.c = c
+
+	if p.c.GetPayloadSize() < headerSize || p.c.GetPayloadSize() > maxPacketSize {
+		return fmt.Errorf("payload_size (%d) should be between %d and %d", p.c.GetPayloadSize(), headerSize, maxPacketSize)
+	}
+
+	if p.c.GetMode() == configpb.ProbeConf_SEND {
+		sendTime := time.Duration(p.c.GetPacketsPerProbe()-1) * time.Duration(p.c.GetPacketsIntervalMsec()) * time.Millisecond
+		if sendTime >= p.opts.Interval {
+			return fmt.Errorf("time required to send all the packets (%v) is more than the probe interval (%v)", sendTime, p.opts.Interval)
+		}
+	}
+
+	if p.c.GetInterface() != "" {
+		iface, err := net.InterfaceByName(p.c.GetInterface())
+		if err != nil {
+			return fmt.Errorf("error getting interface %s: %v", p.c.GetInterface(), err)
+		}
+		p.iface = iface
+	}
+
+	if p.listen == nil {
+		p.listen = p.listenMulticast
+	}
+	if p.dial == nil {
+		p.dial = p.dialMulticast
+	}
+
+	return nil
+}
+
+func (p *Probe) listenMulticast(group *net.UDPAddr) (net.PacketConn, error) {
+	network := "udp4"
+	if group.IP.To4() == nil {
+		network = "udp6"
+	}
+	return net.ListenMulticastUDP(network, p.iface, group)
+}
+
+func (p *Probe) dialMulticast(group *net.UDPAddr) (net.Conn, error) {
+	conn, err := net.DialUDP("udp", nil, group)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.IP.To4() != nil {
+		pc := ipv4.NewPacketConn(conn)
+		err = pc.SetMulticastTTL(int(p.c.GetTtl()))
+		if err == nil && p.iface != nil {
+			err = pc.SetMulticastInterface(p.iface)
+		}
+	} else {
+		pc := ipv6.NewPacketConn(conn)
+		err = pc.SetMulticastHopLimit(int(p.c.GetTtl()))
+		if err == nil && p.iface != nil {
+			err = pc.SetMulticastInterface(p.iface)
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error setting multicast options: %v", err)
+	}
+
+	return conn, nil
+}
+
+func (p *Probe) groupAddr(target endpoint.Endpoint) (*net.UDPAddr, error) {
+	ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+	if err != nil {
+		return nil, err
+	}
+	if !ip.IsMulticast() {
+		return nil, fmt.Errorf("%s is not a multicast address", ip.String())
+	}
+
+	port := int(p.c.GetPort())
+	if port == 0 {
+		port = target.Port
+	}
+	if port == 0 {
+		return nil, fmt.Errorf("no port configured for the target %s", target.Name)
+	}
+	return &net.UDPAddr{IP: ip, Port: port}, nil
+}
+
+// newHeader returns the header for the packet with the given sequence number.
+func newHeader(seq uint64, ts time.Time) []byte {
+	b := make([]byte, headerSize)
+	binary.BigEndian.PutUint32(b[0:4], magic)
+	binary.BigEndian.PutUint64(b[4:12], seq)
+	binary.BigEndian.PutUint64(b[12:20], uint64(ts.UnixNano()))
+	return b
+}
+
+// parseHeader parses the probe header from the packet, if present.
+func parseHeader(pkt []byte) (seq uint64, ts time.Time, ok bool) {
+	if len(pkt) < headerSize || binary.BigEndian.Uint32(pkt[0:4]) != magic {
+		return 0, time.Time{}, false
+	}
+	return binary.BigEndian.Uint64(pkt[4:12]), time.Unix(0, int64(binary.BigEndian.Uint64(pkt[12:20]))), true
+}
+
+// recvLoop receives packets for a multicast group until the context is
+// canceled.
+func (p *Probe) recvLoop(ctx context.Context, conn net.PacketConn, gl *groupListener) {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.l.Warningf("Error reading multicast packet: %v", err)
+			continue
+		}
+		gl.record(buf[:n], time.Now())
+	}
+}
+
+func (p *Probe) runReceive(ctx context.Context, target endpoint.Endpoint, result *probeResult) {
+	// We start listening on the first run. Stats are collected from the next
+	// run onwards, so that we evaluate only complete intervals.
+	if result.listener == nil {
+		group, err := p.groupAddr(target)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", error: ", err.Error())
+			return
+		}
+		conn, err := p.listen(group)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", error joining multicast group: ", err.Error())
+			return
+		}
+		result.listener = &groupListener{}
+		go p.recvLoop(ctx, conn, result.listener)
+		return
+	}
+
+	packets, lost, gaps := result.listener.flush()
+
+	result.total++
+	if packets >= int64(p.c.GetMinPacketsPerInterval()) {
+		result.success++
+	} else {
+		p.l.Warningf("target: %s, received %d packets in the last interval, expected at least %d", target.Name, packets, p.c.GetMinPacketsPerInterval())
+	}
+	result.packets += packets
+	result.lost += lost
+	for _, gap := range gaps {
+		result.latency.AddFloat64(gap.Seconds() / p.opts.LatencyUnit.Seconds())
+	}
+}
+
+func (p *Probe) runSend(ctx context.Context, target endpoint.Endpoint, result *probeResult) {
+	if result.conn == nil {
+		group, err := p.groupAddr(target)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", error: ", err.Error())
+			return
+		}
+		if result.conn, err = p.dial(group); err != nil {
+			p.l.Error("target: ", target.Name, ", error creating connection: ", err.Error())
+			return
+		}
+		go func(conn net.Conn) {
+			<-ctx.Done()
+			conn.Close()
+		}(result.conn)
+	}
+
+	pkt := make([]byte, p.c.GetPayloadSize())
+	for i := int32(0); i < p.c.GetPacketsPerProbe(); i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(p.c.GetPacketsIntervalMsec()) * time.Millisecond)
+		}
+		result.total++
+		copy(pkt, newHeader(result.seq, time.Now()))
+		if _, err := result.conn.Write(pkt); err != nil {
+			p.l.Warning("target: ", target.Name, ", error sending packet: ", err.Error())
+			continue
+		}
+		result.seq++
+		result.success++
+	}
+}
+
+func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
+	result := res.(*probeResult)
+	if p.c.GetMode() == configpb.ProbeConf_SEND {
+		p.runSend(ctx, target, result)
+		return
+	}
+	p.runReceive(ctx, target, result)
+}
+
+// Start starts and runs the probe indefinitely.
+func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	s := &sched.Scheduler{
+		ProbeName:         p.name,
+		DataChan:          dataChan,
+		Opts:              p.opts,
+		NewResult:         p.newResult,
+		RunProbeForTarget: p.runProbe,
+	}
+	s.UpdateTargetsAndStartProbes(ctx)
+}
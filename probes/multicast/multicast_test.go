@@ -0,0 +1,181 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicast
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/multicast/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGroupListenerRecord(t *testing.T) {
+	gl := &groupListener{}
+	start := time.Now()
+
+	// Sequence numbers 0, 1, 4 (2 and 3 lost), 3 (out of order), 5, and a
+	// packet without header.
+	for i, seq := range []uint64{0, 1, 4, 3, 5} {
+		gl.record(newHeader(seq, start), start.Add(time.Duration(i)*time.Second))
+	}
+	gl.record([]byte("no-header"), start.Add(10*time.Second))
+
+	packets, lost, gaps := gl.flush()
+	assert.Equal(t, int64(6), packets, "packets")
+	assert.Equal(t, int64(2), lost, "lost")
+	assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second, time.Second, 6 * time.Second}, gaps, "gaps")
+
+	packets, lost, gaps = gl.flush()
+	assert.Equal(t, int64(0), packets, "packets after flush")
+	assert.Equal(t, int64(0), lost, "lost after flush")
+	assert.Nil(t, gaps, "gaps after flush")
+}
+
+func TestHeader(t *testing.T) {
+	ts := time.Unix(1700000000, 123)
+	seq, gotTS, ok := parseHeader(append(newHeader(42, ts), []byte("payload")...))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), seq)
+	assert.True(t, ts.Equal(gotTS), "timestamp")
+
+	_, _, ok = parseHeader([]byte("some random payload in the packet"))
+	assert.False(t, ok)
+}
+
+func testProbe(t *testing.T, conf *configpb.ProbeConf) (*Probe, net.PacketConn) {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error creating local UDP connection: %v", err)
+	}
+	t.Cleanup(func() { pc.Close() })
+
+	opts := options.DefaultOptions()
+	opts.Targets = targets.StaticTargets("239.1.1.1")
+	opts.ProbeConf = conf
+
+	p := &Probe{
+		listen: func(group *net.UDPAddr) (net.PacketConn, error) {
+			return pc, nil
+		},
+		dial: func(group *net.UDPAddr) (net.Conn, error) {
+			return net.Dial("udp", pc.LocalAddr().String())
+		},
+	}
+	if err := p.Init("test_multicast", opts); err != nil {
+		t.Fatalf("Error initializing probe: %v", err)
+	}
+	return p, pc
+}
+
+func TestReceive(t *testing.T) {
+	p, pc := testProbe(t, &configpb.ProbeConf{
+		Port:                  proto.Int32(30001),
+		MinPacketsPerInterval: proto.Int32(2),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	target := endpoint.Endpoint{Name: "239.1.1.1"}
+	result := p.newResult().(*probeResult)
+
+	// First run only joins the group.
+	p.runProbe(ctx, target, result)
+	assert.Equal(t, int64(0), result.total, "total after first run")
+
+	sender, err := net.Dial("udp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("error creating sender: %v", err)
+	}
+	defer sender.Close()
+	for _, seq := range []uint64{0, 1, 3} {
+		sender.Write(newHeader(seq, time.Now()))
+	}
+
+	assert.Eventually(t, func() bool {
+		result.listener.mu.Lock()
+		defer result.listener.mu.Unlock()
+		return result.listener.packets == 3
+	}, time.Second, 10*time.Millisecond)
+
+	p.runProbe(ctx, target, result)
+	// No more packets, so this interval should fail.
+	p.runProbe(ctx, target, result)
+
+	assert.Equal(t, int64(2), result.total, "total")
+	assert.Equal(t, int64(1), result.success, "success")
+	assert.Equal(t, int64(3), result.packets, "packets")
+	assert.Equal(t, int64(1), result.lost, "lost")
+
+	em := result.Metrics(time.Now(), p.opts)
+	assert.Equal(t, "3", em.Metric("packets").String())
+}
+
+func TestSend(t *testing.T) {
+	p, pc := testProbe(t, &configpb.ProbeConf{
+		Mode:                configpb.ProbeConf_SEND.Enum(),
+		Port:                proto.Int32(30001),
+		PacketsPerProbe:     proto.Int32(2),
+		PacketsIntervalMsec: proto.Int32(1),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result := p.newResult().(*probeResult)
+	p.runProbe(ctx, endpoint.Endpoint{Name: "239.1.1.1"}, result)
+	p.runProbe(ctx, endpoint.Endpoint{Name: "239.1.1.1"}, result)
+
+	assert.Equal(t, int64(4), result.total, "total")
+	assert.Equal(t, int64(4), result.success, "success")
+
+	buf := make([]byte, 1024)
+	for i := uint64(0); i < 4; i++ {
+		pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("error reading packet: %v", err)
+		}
+		assert.Equal(t, 64, n, "packet size")
+		seq, _, ok := parseHeader(buf[:n])
+		assert.True(t, ok)
+		assert.Equal(t, i, seq)
+	}
+
+	em := result.Metrics(time.Now(), p.opts)
+	assert.Nil(t, em.Metric("packets"), "packets metric in send mode")
+}
+
+func TestInitErrors(t *testing.T) {
+	for _, conf := range []*configpb.ProbeConf{
+		{PayloadSize: proto.Int32(10)},
+		{Mode: configpb.ProbeConf_SEND.Enum(), PacketsPerProbe: proto.Int32(100), PacketsIntervalMsec: proto.Int32(100)},
+		{Interface: proto.String("nonexistent-intf0")},
+	} {
+		opts := options.DefaultOptions()
+		opts.ProbeConf = conf
+		p := &Probe{}
+		assert.Error(t, p.Init("test_multicast", opts), "conf: %v", conf)
+	}
+}
@@ -0,0 +1,63 @@
+package proto
+
+// Multicast probe joins the multicast groups specified by the targets and
+// verifies that packets arrive within each probe interval. It can also run in
+// the sender mode, in which case it sends sequenced packets to the multicast
+// groups, allowing receivers to detect gaps.
+//
+// Example receiver config:
+//   probe {
+//     name: "market_data_feed"
+//     type: MULTICAST
+//     targets {
+//       host_names: "239.1.1.1"
+//     }
+//     interval: "5s"
+//     multicast_probe {
+//       port: 30001
+//       interface: "eth1"
+//     }
+//   }
+// Next tag: 9
+#ProbeConf: {
+	#Mode: {
+		// Join the multicast group and receive packets.
+		"RECEIVE"
+		#enumValue: 0
+	} | {
+		// Send packets to the multicast group.
+		"SEND"
+		#enumValue: 1
+	}
+
+	#Mode_value: {
+		RECEIVE: 0
+		SEND:    1
+	}
+	mode?: #Mode @protobuf(1,Mode,"default=RECEIVE")
+
+	// Port to receive packets on, or to send packets to. If not specified, and
+	// port is provided by the targets, that port is used.
+	port?: int32 @protobuf(2,int32)
+
+	// Network interface to join the multicast group on, or to send packets
+	// from. If not specified, system picks the interface.
+	interface?: string @protobuf(3,string)
+
+	// (Receive mode) Minimum number of packets that should be received in a
+	// probe interval for the interval to be counted as a success.
+	minPacketsPerInterval?: int32 @protobuf(4,int32,name=min_packets_per_interval,"default=1")
+
+	// (Send mode) Number of packets sent in every probe interval.
+	packetsPerProbe?: int32 @protobuf(5,int32,name=packets_per_probe,"default=1")
+
+	// (Send mode) How long to wait between two packets.
+	packetsIntervalMsec?: int32 @protobuf(6,int32,name=packets_interval_msec,"default=10")
+
+	// (Send mode) Multicast TTL for the outgoing packets.
+	ttl?: int32 @protobuf(7,int32,"default=1")
+
+	// (Send mode) Size of the packets sent. It cannot be smaller than the
+	// header size (20 bytes).
+	payloadSize?: int32 @protobuf(8,int32,name=payload_size,"default=64")
+}
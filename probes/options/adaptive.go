@@ -0,0 +1,100 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	multicastpb "github.com/cloudprober/cloudprober/probes/multicast/proto"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+)
+
+var adaptiveIntervalSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_HTTP:      true,
+	configpb.ProbeDef_TCP:       true,
+	configpb.ProbeDef_ARP:       true,
+	configpb.ProbeDef_MULTICAST: true,
+}
+
+// AdaptiveInterval shortens the probe interval for the failing targets, down
+// to the minimum interval, and extends it back to the probe interval once they
+// are healthy again.
+type AdaptiveInterval struct {
+	interval, minInterval time.Duration
+	factor                float64
+}
+
+func parseAdaptiveInterval(p *configpb.ProbeDef, opts *Options) (*AdaptiveInterval, error) {
+	c := p.GetAdaptiveInterval()
+	if !adaptiveIntervalSupported[p.GetType()] {
+		return nil, fmt.Errorf("adaptive_interval is not supported for %s probes", p.GetType())
+	}
+	// Receivers expect min_packets_per_interval packets in every interval, a
+	// shorter interval after a failure would only cause more failures.
+	if p.GetType() == configpb.ProbeDef_MULTICAST && p.GetMulticastProbe().GetMode() == multicastpb.ProbeConf_RECEIVE {
+		return nil, errors.New("adaptive_interval is not supported for MULTICAST probes in RECEIVE mode")
+	}
+
+	minInterval, err := time.ParseDuration(c.GetMinInterval())
+	if err != nil {
+		return nil, fmt.Errorf("invalid adaptive_interval min_interval (%s): %v", c.GetMinInterval(), err)
+	}
+	if minInterval >= opts.Interval || minInterval < opts.Timeout {
+		return nil, fmt.Errorf("adaptive_interval min_interval (%v) should be smaller than the probe interval (%v), and not smaller than the timeout (%v)", minInterval, opts.Interval, opts.Timeout)
+	}
+	if c.GetFactor() <= 1 {
+		return nil, fmt.Errorf("adaptive_interval factor (%v) should be greater than 1", c.GetFactor())
+	}
+
+	return &AdaptiveInterval{
+		interval:    opts.Interval,
+		minInterval: minInterval,
+		factor:      float64(c.GetFactor()),
+	}, nil
+}
+
+// TargetInterval tracks a target's probe interval. It's not concurrency safe,
+// and is meant to be used from the target's probe loop.
+type TargetInterval struct {
+	ai             *AdaptiveInterval
+	cur            time.Duration
+	total, success int64
+}
+
+// ForTarget returns a new TargetInterval, starting at the probe interval. It
+// returns nil for a nil AdaptiveInterval.
+func (ai *AdaptiveInterval) ForTarget() *TargetInterval {
+	if ai == nil {
+		return nil
+	}
+	return &TargetInterval{ai: ai, cur: ai.interval}
+}
+
+// Next returns the interval to wait for before the next probe run, given the
+// target's cumulative total and success counters after the last run. The last
+// run is considered failed if any of its attempts failed.
+func (ti *TargetInterval) Next(total, success int64) time.Duration {
+	failed := success-ti.success < total-ti.total
+	ti.total, ti.success = total, success
+
+	if failed {
+		ti.cur = max(time.Duration(float64(ti.cur)/ti.ai.factor), ti.ai.minInterval)
+	} else {
+		ti.cur = min(time.Duration(float64(ti.cur)*ti.ai.factor), ti.ai.interval)
+	}
+	return ti.cur
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	ratelimitpb "github.com/cloudprober/cloudprober/internal/ratelimit/proto"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	probeDef := func(ptype configpb.ProbeDef_Type, minInterval string, factor float32) *configpb.ProbeDef {
+		return &configpb.ProbeDef{
+			Name:     proto.String("test-probe"),
+			Type:     ptype.Enum(),
+			Interval: proto.String("40s"),
+			Timeout:  proto.String("2s"),
+			Targets: &targetspb.TargetsDef{
+				Type: &targetspb.TargetsDef_DummyTargets{},
+			},
+			AdaptiveInterval: &configpb.AdaptiveInterval{
+				MinInterval: proto.String(minInterval),
+				Factor:      proto.Float32(factor),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		p    *configpb.ProbeDef
+	}{
+		{name: "unsupported-probe", p: probeDef(configpb.ProbeDef_PING, "5s", 2)},
+		{name: "multicast-receive-mode", p: probeDef(configpb.ProbeDef_MULTICAST, "5s", 2)},
+		{name: "bad-min-interval", p: probeDef(configpb.ProbeDef_HTTP, "5", 2)},
+		{name: "min-interval-too-big", p: probeDef(configpb.ProbeDef_HTTP, "40s", 2)},
+		{name: "min-interval-smaller-than-timeout", p: probeDef(configpb.ProbeDef_HTTP, "1s", 2)},
+		{name: "bad-factor", p: probeDef(configpb.ProbeDef_HTTP, "5s", 1)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildProbeOptions(tt.p, nil, nil, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	opts, err := BuildProbeOptions(probeDef(configpb.ProbeDef_HTTP, "8s", 2), nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ti := opts.AdaptiveInterval.ForTarget()
+
+	var total, success int64
+	run := func(ok bool) time.Duration {
+		total++
+		if ok {
+			success++
+		}
+		return ti.Next(total, success)
+	}
+	assert.Equal(t, 40*time.Second, run(true))
+	assert.Equal(t, 20*time.Second, run(false))
+	assert.Equal(t, 10*time.Second, run(false))
+	assert.Equal(t, 8*time.Second, run(false), "capped at min_interval")
+	assert.Equal(t, 8*time.Second, run(false))
+	assert.Equal(t, 16*time.Second, run(true))
+	assert.Equal(t, 32*time.Second, run(true))
+	assert.Equal(t, 40*time.Second, run(true), "capped at interval")
+
+	// Any failed attempt in a run makes the run failed.
+	total, success = total+2, success+1
+	assert.Equal(t, 20*time.Second, ti.Next(total, success))
+
+	assert.Nil(t, DefaultOptions().AdaptiveInterval.ForTarget())
+}
+
+func TestRateLimitSupport(t *testing.T) {
+	for _, ptype := range []configpb.ProbeDef_Type{configpb.ProbeDef_HTTP, configpb.ProbeDef_PING, configpb.ProbeDef_UDP_LISTENER} {
+		p := &configpb.ProbeDef{
+			Name: proto.String("test-probe"),
+			Type: ptype.Enum(),
+			Targets: &targetspb.TargetsDef{
+				Type: &targetspb.TargetsDef_DummyTargets{},
+			},
+			RateLimit: []*ratelimitpb.RateLimit{{RequestsPerSec: proto.Float64(10)}},
+		}
+		opts, err := BuildProbeOptions(p, nil, nil, nil)
+		if rateLimitUnsupported[ptype] {
+			assert.Error(t, err, ptype.String())
+			continue
+		}
+		assert.NoError(t, err)
+		assert.NotNil(t, opts.RateLimiter)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes a cron spec field: its range and value names.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names for the values starting at min.
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	// 7 is also Sunday.
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// cronSpec is a parsed cron spec. Each field is a bitset of the matching
+// values.
+type cronSpec struct {
+	spec                          string
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+func (f *cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s value: %s", f.name, s)
+	}
+	return v, nil
+}
+
+// parse parses a cron spec field: a comma separated list of values, ranges
+// (a-b) and wildcards (*), with an optional step (e.g. */15, 8-18/2).
+func (f *cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, item)
+			}
+		}
+
+		start, end := f.min, f.max
+		if rng != "*" {
+			startStr, endStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = f.value(startStr); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = f.value(endStr); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// a/n is a/n till the max, as in the most cron implementations.
+				end = f.max
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range in %s field: %s", f.name, item)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseCron parses a standard 5-field cron spec.
+func parseCron(spec string) (*cronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron spec %q: expected 5 fields (minute hour day-of-month month day-of-week)", spec)
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		var err error
+		if bits[i], err = cronFields[i].parse(f); err != nil {
+			return nil, fmt.Errorf("invalid cron spec %q: %v", spec, err)
+		}
+	}
+
+	c := &cronSpec{
+		spec:    spec,
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	// Sunday can be specified as 0 or 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// matches returns true if the time's minute matches the cron spec. As in
+// cron, if both day of month and day of week are restricted, matching either
+// of them is enough.
+func (c *cronSpec) matches(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 || c.hour&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	return c.dayMatches(t)
+}
+
+// dayMatches returns true if the day of the provided time matches the spec's
+// month, day of month and day of week fields.
+func (c *cronSpec) dayMatches(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// lastBitUpTo returns the highest value, not greater than n, set in the
+// bitset, or -1 if there is none.
+func lastBitUpTo(bitset uint64, n int) int {
+	return bits.Len64(bitset&(2<<uint(n)-1)) - 1
+}
+
+// prev returns the most recent minute, at or before t and not before limit,
+// that matches the spec. Instead of checking every minute, it skips the
+// non-matching days and hours at once.
+func (c *cronSpec) prev(t, limit time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+
+	// back moves t to the given time, making sure that we always move back,
+	// even across DST changes.
+	back := func(next time.Time) {
+		if !next.Before(t) {
+			next = t.Add(-time.Minute)
+		}
+		t = next
+	}
+
+	for !t.Before(limit) {
+		y, mon, d := t.Date()
+		dayStart := time.Date(y, mon, d, 0, 0, 0, 0, t.Location())
+		if !c.dayMatches(t) {
+			back(dayStart.Add(-time.Minute))
+			continue
+		}
+
+		h := lastBitUpTo(c.hour, t.Hour())
+		if h < 0 {
+			back(dayStart.Add(-time.Minute))
+			continue
+		}
+		if h != t.Hour() {
+			back(time.Date(y, mon, d, h, 59, 0, 0, t.Location()))
+			continue
+		}
+
+		m := lastBitUpTo(c.minute, t.Minute())
+		if m < 0 {
+			back(time.Date(y, mon, d, h, 0, 0, 0, t.Location()).Add(-time.Minute))
+			continue
+		}
+		match := time.Date(y, mon, d, h, m, 0, 0, t.Location())
+		return match, !match.Before(limit)
+	}
+	return time.Time{}, false
+}
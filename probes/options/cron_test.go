@@ -0,0 +1,122 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		spec    string
+		results map[string]bool
+		wantErr bool
+	}{
+		{
+			spec: "*/15 9-17 * * MON-FRI",
+			results: map[string]bool{
+				"2023-12-11 09:00": true,  // Mon
+				"2023-12-11 09:45": true,  // Mon
+				"2023-12-11 09:46": false, // Mon
+				"2023-12-11 18:00": false, // Mon
+				"2023-12-16 10:00": false, // Sat
+			},
+		},
+		{
+			spec: "0 2 1,15 jan,jul *",
+			results: map[string]bool{
+				"2024-01-01 02:00": true,
+				"2024-07-15 02:00": true,
+				"2024-07-16 02:00": false,
+				"2024-02-01 02:00": false,
+			},
+		},
+		{
+			// Sunday as 7, and day of month or day of week.
+			spec: "30 23 1 * 7",
+			results: map[string]bool{
+				"2023-12-17 23:30": true,  // Sun
+				"2023-12-01 23:30": true,  // Fri, 1st
+				"2023-12-02 23:30": false, // Sat
+			},
+		},
+		{
+			spec: "5/20 0 * * *",
+			results: map[string]bool{
+				"2023-12-11 00:05": true,
+				"2023-12-11 00:45": true,
+				"2023-12-11 00:00": false,
+			},
+		},
+		{spec: "* * * *", wantErr: true},
+		{spec: "60 * * * *", wantErr: true},
+		{spec: "* * 0 * *", wantErr: true},
+		{spec: "* 17-9 * * *", wantErr: true},
+		{spec: "*/0 * * * *", wantErr: true},
+		{spec: "* * * * FUN", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			c, err := parseCron(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for timeStr, want := range tt.results {
+				ttime, _ := time.Parse("2006-01-02 15:04", timeStr)
+				assert.Equal(t, want, c.matches(ttime), timeStr)
+			}
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	// Walking back minute by minute should give the same result.
+	prevByMinute := func(c *cronSpec, t, limit time.Time) (time.Time, bool) {
+		for t = t.Truncate(time.Minute); !t.Before(limit); t = t.Add(-time.Minute) {
+			if c.matches(t) {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	start := time.Date(2024, 3, 9, 22, 17, 0, 0, loc) // Just before DST.
+	for _, spec := range []string{"*/15 * * * *", "30 2 * * *", "0 9-17 * * MON-FRI", "45 23 1 * 7", "0 0 29 2 *", "5/20 0,12 * * *"} {
+		c, err := parseCron(spec)
+		if err != nil {
+			t.Fatalf("parseCron(%s): %v", spec, err)
+		}
+		for i := 0; i < 20; i++ {
+			ts := start.Add(time.Duration(i) * 7 * time.Hour)
+			for _, d := range []time.Duration{time.Minute, time.Hour, 3 * time.Hour, 24 * time.Hour, maxCronDuration} {
+				limit := ts.Truncate(time.Minute).Add(time.Minute - d)
+				wantT, wantOK := prevByMinute(c, ts, limit)
+				gotT, gotOK := c.prev(ts, limit)
+				assert.Equal(t, wantOK, gotOK, "spec: %s, time: %v, duration: %v", spec, ts, d)
+				if wantOK {
+					assert.True(t, wantT.Equal(gotT), "spec: %s, time: %v, duration: %v, got: %v, want: %v", spec, ts, d, gotT, wantT)
+				}
+			}
+		}
+	}
+}
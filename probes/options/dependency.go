@@ -0,0 +1,111 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/cloudprober/cloudprober/targets/health"
+)
+
+// Label added to the probe results when the probe has dependencies. It's set
+// to "true" while a dependency is failing.
+const suppressedLabel = "suppressed"
+
+type dependency struct {
+	probe     string
+	perTarget bool
+	threshold int
+	skip      bool
+}
+
+func (d *dependency) failing(ep endpoint.Endpoint) bool {
+	target := ""
+	if d.perTarget {
+		target = ep.Name
+	}
+	return health.Failing(d.probe, target, d.threshold)
+}
+
+func parseDependencies(p *configpb.ProbeDef) ([]*dependency, error) {
+	var deps []*dependency
+	for _, c := range p.GetDependsOn() {
+		if c.GetProbe() == "" {
+			return nil, fmt.Errorf("depends_on: probe name is required")
+		}
+		if c.GetProbe() == p.GetName() {
+			return nil, fmt.Errorf("depends_on: probe (%s) can't depend on itself", p.GetName())
+		}
+		if c.GetFailureThreshold() <= 0 {
+			return nil, fmt.Errorf("depends_on: invalid failure_threshold: %d", c.GetFailureThreshold())
+		}
+
+		health.Track(c.GetProbe())
+		deps = append(deps, &dependency{
+			probe:     c.GetProbe(),
+			perTarget: c.GetPerTarget(),
+			threshold: int(c.GetFailureThreshold()),
+			skip:      c.GetSkip(),
+		})
+	}
+	return deps, nil
+}
+
+// dependencyFailing returns true if any of the probe's dependencies is failing
+// for the target. If skipOnly is true, only the dependencies with skip set
+// are considered.
+func (opts *Options) dependencyFailing(ep endpoint.Endpoint, skipOnly bool) bool {
+	for _, d := range opts.dependencies {
+		if skipOnly && !d.skip {
+			continue
+		}
+		if d.failing(ep) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyTargets wraps the probe's targets to skip the targets for which
+// a dependency with skip set is failing.
+type dependencyTargets struct {
+	targets.Targets
+	opts    *Options
+	skipped int64
+	l       *logger.Logger
+}
+
+func (dt *dependencyTargets) ListEndpoints() []endpoint.Endpoint {
+	eps := dt.Targets.ListEndpoints()
+
+	var result []endpoint.Endpoint
+	for _, ep := range eps {
+		if dt.opts.dependencyFailing(ep, true) {
+			continue
+		}
+		result = append(result, ep)
+	}
+
+	skipped := len(eps) - len(result)
+	if old := atomic.SwapInt64(&dt.skipped, int64(skipped)); old != int64(skipped) {
+		dt.l.Infof("depends_on: skipping %d targets because of the failing dependencies", skipped)
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/cloudprober/cloudprober/targets/health"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testDependencyProbeDef(deps ...*configpb.Dependency) *configpb.ProbeDef {
+	return &configpb.ProbeDef{
+		Name: proto.String("app"),
+		Type: configpb.ProbeDef_HTTP.Enum(),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: "vm-1,vm-2"},
+		},
+		DependsOn: deps,
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	for _, deps := range [][]*configpb.Dependency{
+		{{}},
+		{{Probe: proto.String("app")}},
+		{{Probe: proto.String("gw"), FailureThreshold: proto.Int32(0)}},
+	} {
+		_, err := BuildProbeOptions(testDependencyProbeDef(deps...), nil, nil, nil)
+		assert.Error(t, err, "depends_on: %v", deps)
+	}
+
+	opts, err := BuildProbeOptions(testDependencyProbeDef(
+		&configpb.Dependency{Probe: proto.String("dep-gw")},
+		&configpb.Dependency{Probe: proto.String("dep-vm"), PerTarget: proto.Bool(true), Skip: proto.Bool(true)},
+	), nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := func(ep endpoint.Endpoint) *metrics.EventMetrics {
+		dataChan := make(chan *metrics.EventMetrics, 1)
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(1)).
+			AddMetric("success", metrics.NewInt(0))
+		opts.RecordMetrics(ep, em, dataChan)
+		return <-dataChan
+	}
+	depResult := func(probe, target string, total, success int64) {
+		health.Record(endpoint.Endpoint{Name: target}, metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success)).
+			AddLabel("probe", probe))
+	}
+	vm1, vm2 := endpoint.Endpoint{Name: "vm-1"}, endpoint.Endpoint{Name: "vm-2"}
+
+	assert.Equal(t, "false", record(vm1).Label("suppressed"))
+	assert.Len(t, opts.Targets.ListEndpoints(), 2)
+
+	// Global dependency failing: all results suppressed, nothing skipped.
+	depResult("dep-gw", "gateway", 1, 0)
+	assert.Equal(t, "true", record(vm1).Label("suppressed"))
+	assert.Equal(t, "true", record(vm2).Label("suppressed"))
+	assert.Len(t, opts.Targets.ListEndpoints(), 2)
+	depResult("dep-gw", "gateway", 2, 1)
+
+	// Per-target dependency with skip, failing for vm-2 only.
+	depResult("dep-vm", "vm-2", 1, 0)
+	assert.Equal(t, "false", record(vm1).Label("suppressed"))
+	assert.Equal(t, "true", record(vm2).Label("suppressed"))
+	eps := opts.Targets.ListEndpoints()
+	assert.Len(t, eps, 1)
+	assert.Equal(t, "vm-1", eps[0].Name)
+
+	depResult("dep-vm", "vm-2", 2, 1)
+	assert.Len(t, opts.Targets.ListEndpoints(), 2)
+}
@@ -16,6 +16,7 @@ package options
 
 import (
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +24,7 @@ import (
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 
 	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 // targetLabelType for target based additional labels
@@ -174,5 +176,18 @@ func parseAdditionalLabels(p *configpb.ProbeDef) []*AdditionalLabel {
 		aLabels = append(aLabels, ParseAdditionalLabel(pb))
 	}
 
+	// Sort target_labels keys for a deterministic label order.
+	var keys []string
+	for k := range p.GetTargetLabels() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		aLabels = append(aLabels, ParseAdditionalLabel(&configpb.AdditionalLabel{
+			Key:   proto.String(k),
+			Value: proto.String("@target.label." + p.GetTargetLabels()[k] + "@"),
+		}))
+	}
+
 	return aLabels
 }
@@ -176,3 +176,27 @@ func TestUpdateAdditionalLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestTargetLabels(t *testing.T) {
+	aLabels := parseAdditionalLabels(&configpb.ProbeDef{
+		TargetLabels: map[string]string{
+			"zone":          "zone",
+			"k8s_ns":        "namespace",
+			"instance_type": "machine_type",
+		},
+	})
+
+	ep := endpoint.Endpoint{Name: "vm-1", Labels: map[string]string{"zone": "us-east1-b", "machine_type": "e2-small"}}
+
+	var gotLabels [][2]string
+	for _, al := range aLabels {
+		al.UpdateForTarget(ep, "", 0)
+		k, v := al.KeyValueForTarget(ep)
+		gotLabels = append(gotLabels, [2]string{k, v})
+	}
+
+	wantLabels := [][2]string{{"instance_type", "e2-small"}, {"k8s_ns", ""}, {"zone", "us-east1-b"}}
+	if !reflect.DeepEqual(gotLabels, wantLabels) {
+		t.Errorf("Got labels=%v, want=%v", gotLabels, wantLabels)
+	}
+}
@@ -18,22 +18,35 @@ Package options provides a shared interface to common probe options.
 package options
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/alerting"
+	"github.com/cloudprober/cloudprober/internal/ratelimit"
 	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/cloudprober/cloudprober/targets/health"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
 )
 
+// Probe types that don't apply the rate limits: UDP_LISTENER doesn't send any
+// requests, while EXTENSION and USER_DEFINED probes run their own probe loops.
+var rateLimitUnsupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_UDP_LISTENER: true,
+	configpb.ProbeDef_EXTENSION:    true,
+	configpb.ProbeDef_USER_DEFINED: true,
+}
+
 // Options encapsulates common probe options.
 type Options struct {
 	Targets             targets.Targets
@@ -50,8 +63,20 @@ type Options struct {
 	LogMetrics          func(*metrics.EventMetrics)
 	AdditionalLabels    []*AdditionalLabel
 	Schedule            *Schedule
+	AdaptiveInterval    *AdaptiveInterval
+	RateLimiter         *ratelimit.Limiter
 	NegativeTest        bool
 	AlertHandlers       []*alerting.AlertHandler
+
+	// OneOff is set for the on-demand probe runs. Their results are not
+	// recorded in the targets' health or sent to the alert handlers.
+	OneOff bool
+
+	targetOverrides []*targetOverride
+	dependencies    []*dependency
+
+	// Targets before they are wrapped, e.g. by health gate, for closing them.
+	baseTargets targets.Targets
 }
 
 const defaultStatsExtportIntv = 10 * time.Second
@@ -181,6 +206,30 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 	if opts.Targets, err = targets.New(p.GetTargets(), ldLister, globalTargetsOpts, l, opts.Logger); err != nil {
 		return nil, err
 	}
+	opts.baseTargets = opts.Targets
+
+	if p.GetTargets().GetHealthGate() != nil {
+		if opts.Targets, err = health.New(opts.Targets, p.GetTargets().GetHealthGate(), p.GetName(), opts.Logger); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.GetRateLimit()) != 0 && rateLimitUnsupported[p.GetType()] {
+		return nil, fmt.Errorf("rate_limit is not supported for %s probes", p.GetType())
+	}
+	if opts.RateLimiter, err = ratelimit.New(p.GetRateLimit(), p.GetName()); err != nil {
+		return nil, err
+	}
+
+	if opts.dependencies, err = parseDependencies(p); err != nil {
+		return nil, err
+	}
+	for _, d := range opts.dependencies {
+		if d.skip {
+			opts.Targets = &dependencyTargets{Targets: opts.Targets, opts: opts, l: opts.Logger}
+			break
+		}
+	}
 
 	if latencyDist := p.GetLatencyDistribution(); latencyDist != nil {
 		var d *metrics.Distribution
@@ -224,6 +273,10 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 
 	opts.AdditionalLabels = parseAdditionalLabels(p)
 
+	if opts.targetOverrides, err = parseTargetOverrides(p, opts.Interval); err != nil {
+		return nil, err
+	}
+
 	for _, alertConf := range p.GetAlert() {
 		ah, err := alerting.NewAlertHandler(alertConf, p.GetName(), opts.Logger)
 		if err != nil {
@@ -239,6 +292,12 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 		}
 	}
 
+	if p.GetAdaptiveInterval() != nil {
+		if opts.AdaptiveInterval, err = parseAdaptiveInterval(p, opts); err != nil {
+			return nil, err
+		}
+	}
+
 	if !p.GetDebugOptions().GetLogMetrics() {
 		opts.LogMetrics = func(em *metrics.EventMetrics) {}
 	} else {
@@ -287,22 +346,93 @@ func (opts *Options) IsScheduled() bool {
 	return opts.Schedule.isIn(time.Now())
 }
 
+// Close releases the resources held by the probe options, e.g. the targets'
+// refresh goroutines. It should be called once the probe has stopped, or if
+// it's never going to be started.
+func (opts *Options) Close() {
+	if c, ok := opts.baseTargets.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			opts.Logger.Warningf("Error closing probe targets: %v", err)
+		}
+	}
+}
+
+// ExportTargetsStaleness exports the staleness of the probe's targets, if
+// targets cache their resources, at every stats export interval. It runs until
+// the context is canceled or targets are closed.
+func (opts *Options) ExportTargetsStaleness(ctx context.Context, ptype, probeName string, dataChan chan<- *metrics.EventMetrics) {
+	if se, ok := opts.baseTargets.(targets.StalenessExporter); ok {
+		se.ExportStaleness(ctx, opts.StatsExportInterval, ptype, probeName, dataChan)
+	}
+}
+
+// ExportScheduleStatus exports the probe's "paused" metric, set to 1 when
+// the probe is outside of its schedule, at every stats export interval. It
+// returns right away if the probe has no schedule, otherwise it runs until
+// the context is canceled.
+func (opts *Options) ExportScheduleStatus(ctx context.Context, ptype, probeName string, dataChan chan<- *metrics.EventMetrics) {
+	if opts.Schedule == nil {
+		return
+	}
+
+	ticker := time.NewTicker(opts.StatsExportInterval)
+	defer ticker.Stop()
+
+	for {
+		var paused int64
+		if !opts.IsScheduled() {
+			paused = 1
+		}
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("paused", metrics.NewInt(paused)).
+			AddLabel("ptype", ptype).
+			AddLabel("probe", probeName)
+		em.Kind = metrics.GAUGE
+		dataChan <- em
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (opts *Options) RecordMetrics(ep endpoint.Endpoint, em *metrics.EventMetrics, dataChan chan<- *metrics.EventMetrics, ropts ...RecordOptions) {
 	em.LatencyUnit = opts.LatencyUnit
 	for _, al := range opts.AdditionalLabels {
 		em.AddLabel(al.KeyValueForTarget(ep))
 	}
 
+	// Results recorded while a dependency is failing don't trigger alerts.
+	suppressed := false
+	if len(opts.dependencies) != 0 {
+		suppressed = opts.dependencyFailing(ep, false)
+		em.AddLabel(suppressedLabel, strconv.FormatBool(suppressed))
+	}
+
 	opts.LogMetrics(em)
 	dataChan <- em.Clone()
 
-	ro := &recordOptions{}
+	if opts.OneOff {
+		return
+	}
+
+	health.Record(ep, em)
+
+	ro := &recordOptions{NoAlert: suppressed}
 	for _, ropt := range ropts {
 		ropt(ro)
 	}
 	if !ro.NoAlert {
 		for _, ah := range opts.AlertHandlers {
 			ah.Record(ep, em)
+			for _, dm := range ah.DeliveryMetrics() {
+				dataChan <- dm
+			}
+			if sm := ah.SuppressedMetrics(); sm != nil {
+				dataChan <- sm
+			}
 		}
 	}
 }
@@ -16,7 +16,9 @@ package options
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"net"
 	"testing"
 	"time"
@@ -425,6 +427,36 @@ func TestRecordMetrics(t *testing.T) {
 	}
 }
 
+func TestExportScheduleStatus(t *testing.T) {
+	opts := DefaultOptions()
+	opts.StatsExportInterval = time.Millisecond
+	dataChan := make(chan *metrics.EventMetrics, 10)
+
+	// No schedule, returns right away.
+	opts.ExportScheduleStatus(context.Background(), "http", "test-probe", dataChan)
+	assert.Len(t, dataChan, 0)
+
+	// Disabled all the time.
+	var err error
+	opts.Schedule, err = NewSchedule([]*configpb.Schedule{{Type: configpb.Schedule_DISABLE.Enum(), Cron: proto.String("* * * * *")}}, nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		opts.ExportScheduleStatus(ctx, "http", "test-probe", dataChan)
+		close(done)
+	}()
+	em := <-dataChan
+	cancel()
+	<-done
+
+	assert.Equal(t, int64(1), em.Metric("paused").(*metrics.Int).Int64())
+	assert.Equal(t, metrics.Kind(metrics.GAUGE), em.Kind)
+	assert.Equal(t, "http", em.Label("ptype"))
+	assert.Equal(t, "test-probe", em.Label("probe"))
+}
+
 func TestNilTargets(t *testing.T) {
 	tests := []struct {
 		cfg           *configpb.ProbeDef
@@ -485,3 +517,79 @@ func TestNilTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetOverrides(t *testing.T) {
+	p := &configpb.ProbeDef{
+		Name:    proto.String("test-probe"),
+		Type:    configpb.ProbeDef_HTTP.Enum(),
+		Targets: &targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: "a"}},
+		TargetOverride: []*configpb.TargetOverride{
+			{
+				TargetRegex: proto.String("web-.*"),
+				Port:        proto.Int32(8080),
+				Header:      map[string]string{"X-Env": "prod"},
+			},
+			{
+				TargetRegex: proto.String("web-2"),
+				Timeout:     proto.String("500ms"),
+				Body:        proto.String("body2"),
+			},
+		},
+	}
+	opts, err := BuildProbeOptions(p, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	body := "label-body"
+	tests := []struct {
+		ep   endpoint.Endpoint
+		want *TargetOverrides
+	}{
+		{
+			ep:   endpoint.Endpoint{Name: "db-1"},
+			want: &TargetOverrides{},
+		},
+		{
+			ep: endpoint.Endpoint{Name: "db-1", Labels: map[string]string{
+				"cloudprober_port":            "5432",
+				"cloudprober_timeout":         "1s",
+				"cloudprober_body":            "label-body",
+				"cloudprober_header_x-custom": "v1",
+			}},
+			want: &TargetOverrides{Port: 5432, Timeout: time.Second, Body: &body, Header: map[string]string{"x-custom": "v1"}},
+		},
+		{
+			ep:   endpoint.Endpoint{Name: "db-1", Labels: map[string]string{"cloudprober_port": "abc", "cloudprober_timeout": "1h"}},
+			want: &TargetOverrides{},
+		},
+		{
+			ep:   endpoint.Endpoint{Name: "web-1", Labels: map[string]string{"cloudprober_port": "9090"}},
+			want: &TargetOverrides{Port: 8080, Header: map[string]string{"X-Env": "prod"}},
+		},
+		{
+			ep:   endpoint.Endpoint{Name: "web-2"},
+			want: &TargetOverrides{Port: 8080, Timeout: 500 * time.Millisecond, Body: proto.String("body2"), Header: map[string]string{"X-Env": "prod"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s:%v", test.ep.Name, test.ep.Labels), func(t *testing.T) {
+			assert.Equal(t, test.want, opts.TargetOverrides(test.ep))
+		})
+	}
+
+	assert.Equal(t, 500*time.Millisecond, opts.TimeoutForTarget(endpoint.Endpoint{Name: "web-2"}))
+	assert.Equal(t, opts.Timeout, opts.TimeoutForTarget(endpoint.Endpoint{Name: "web-1"}))
+
+	// Invalid overrides.
+	for _, to := range []*configpb.TargetOverride{
+		{TargetRegex: proto.String("(")},
+		{TargetRegex: proto.String(".*"), Timeout: proto.String("x")},
+		{TargetRegex: proto.String(".*"), Timeout: proto.String("1h")},
+	} {
+		p.TargetOverride = []*configpb.TargetOverride{to}
+		_, err := BuildProbeOptions(p, nil, nil, nil)
+		assert.Error(t, err, "target_override: %v", to)
+	}
+}
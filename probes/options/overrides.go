@@ -0,0 +1,146 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// Target labels to override probe options.
+const (
+	portOverrideLabel         = "cloudprober_port"
+	timeoutOverrideLabel      = "cloudprober_timeout"
+	pathOverrideLabel         = "cloudprober_path"
+	bodyOverrideLabel         = "cloudprober_body"
+	headerOverrideLabelPrefix = "cloudprober_header_"
+)
+
+// TargetOverrides are the probe options overridden for a target. Zero values
+// mean no override.
+type TargetOverrides struct {
+	Port    int
+	Timeout time.Duration
+	Path    string
+	Header  map[string]string
+	Body    *string
+}
+
+type targetOverride struct {
+	re      *regexp.Regexp
+	timeout time.Duration
+	c       *configpb.TargetOverride
+}
+
+func parseTargetOverrides(p *configpb.ProbeDef, interval time.Duration) ([]*targetOverride, error) {
+	var result []*targetOverride
+	for _, c := range p.GetTargetOverride() {
+		re, err := regexp.Compile(c.GetTargetRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid target_override regex (%s): %v", c.GetTargetRegex(), err)
+		}
+		to := &targetOverride{re: re, c: c}
+		if c.GetTimeout() != "" {
+			if to.timeout, err = time.ParseDuration(c.GetTimeout()); err != nil {
+				return nil, fmt.Errorf("invalid target_override timeout (%s): %v", c.GetTimeout(), err)
+			}
+			if to.timeout <= 0 || to.timeout > interval {
+				return nil, fmt.Errorf("target_override timeout (%v) should be positive and not bigger than the probe interval (%v)", to.timeout, interval)
+			}
+		}
+		result = append(result, to)
+	}
+	return result, nil
+}
+
+func (opts *Options) overridesFromLabels(ep endpoint.Endpoint, tgo *TargetOverrides) {
+	for k, v := range ep.Labels {
+		switch {
+		case k == portOverrideLabel:
+			port, err := strconv.Atoi(v)
+			if err != nil || port <= 0 || port > 65535 {
+				opts.Logger.Warningf("target (%s): invalid %s label value: %s", ep.Name, k, v)
+				continue
+			}
+			tgo.Port = port
+		case k == timeoutOverrideLabel:
+			timeout, err := time.ParseDuration(v)
+			if err != nil || timeout <= 0 || timeout > opts.Interval {
+				opts.Logger.Warningf("target (%s): invalid %s label value: %s", ep.Name, k, v)
+				continue
+			}
+			tgo.Timeout = timeout
+		case k == pathOverrideLabel:
+			tgo.Path = v
+		case k == bodyOverrideLabel:
+			body := v
+			tgo.Body = &body
+		case strings.HasPrefix(k, headerOverrideLabelPrefix):
+			if tgo.Header == nil {
+				tgo.Header = make(map[string]string)
+			}
+			tgo.Header[strings.TrimPrefix(k, headerOverrideLabelPrefix)] = v
+		}
+	}
+}
+
+// TargetOverrides returns the probe options overridden for the given target,
+// either through the target labels, or through the target_override config.
+// Config overrides take precedence over the labels.
+func (opts *Options) TargetOverrides(ep endpoint.Endpoint) *TargetOverrides {
+	tgo := &TargetOverrides{}
+	opts.overridesFromLabels(ep, tgo)
+
+	for _, to := range opts.targetOverrides {
+		if !to.re.MatchString(ep.Name) {
+			continue
+		}
+		if to.c.GetPort() != 0 {
+			tgo.Port = int(to.c.GetPort())
+		}
+		if to.timeout != 0 {
+			tgo.Timeout = to.timeout
+		}
+		if to.c.GetRelativeUrl() != "" {
+			tgo.Path = to.c.GetRelativeUrl()
+		}
+		if to.c.Body != nil {
+			tgo.Body = to.c.Body
+		}
+		for k, v := range to.c.GetHeader() {
+			if tgo.Header == nil {
+				tgo.Header = make(map[string]string)
+			}
+			tgo.Header[k] = v
+		}
+	}
+
+	return tgo
+}
+
+// TimeoutForTarget returns the probe timeout for the given target, taking the
+// overrides into account.
+func (opts *Options) TimeoutForTarget(ep endpoint.Endpoint) time.Duration {
+	if timeout := opts.TargetOverrides(ep).Timeout; timeout != 0 {
+		return timeout
+	}
+	return opts.Timeout
+}
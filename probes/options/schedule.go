@@ -30,8 +30,15 @@ type period struct {
 	everyDay           bool
 	startTime, endTime time.Time
 	l                  *logger.Logger
+
+	// Cron based period, if cron is set.
+	cron     *cronSpec
+	duration time.Duration
 }
 
+// Maximum duration of a cron based period.
+const maxCronDuration = 7 * 24 * time.Hour
+
 // normalizeTime is the most tricky part of the schedule implementation. It
 // moves the provided time to baseTime's reference frame. Base time is set to
 // Jan 1, 2023 00:00:00, which was a Sunday. For example, if provided time is
@@ -56,6 +63,10 @@ func (p *period) isInPeriod(t time.Time) bool {
 	// Convert the provided time to the same timezone as the period.
 	t = t.In(p.loc)
 
+	if p.cron != nil {
+		return p.isInCronPeriod(t)
+	}
+
 	nt := p.normalizeTime(int(t.Weekday()), t.Hour(), t.Minute())
 
 	// For times between Sunday 00:00 and the start of the schedule.
@@ -71,9 +82,54 @@ func (p *period) isInPeriod(t time.Time) bool {
 	return nt.After(p.startTime) && nt.Before(p.endTime)
 }
 
+// isInCronPeriod returns true if the provided time's minute matches the cron
+// spec, or if the duration is set, if a period started at a matching minute
+// within the last duration.
+func (p *period) isInCronPeriod(t time.Time) bool {
+	t = t.Truncate(time.Minute)
+	if p.duration == 0 {
+		return p.cron.matches(t)
+	}
+	_, ok := p.cron.prev(t, t.Add(time.Minute-p.duration))
+	return ok
+}
+
+func parseCronPeriod(sched *configpb.Schedule, p *period) error {
+	var err error
+	if p.cron, err = parseCron(sched.GetCron()); err != nil {
+		return err
+	}
+	if sched.GetDuration() == "" {
+		return nil
+	}
+	if p.duration, err = time.ParseDuration(sched.GetDuration()); err != nil {
+		return fmt.Errorf("error parsing duration (%s): %v", sched.GetDuration(), err)
+	}
+	if p.duration <= 0 || p.duration >= maxCronDuration {
+		return fmt.Errorf("invalid schedule: duration (%s) should be positive and less than 7 days", sched.GetDuration())
+	}
+	return nil
+}
+
 func parsePeriod(sched *configpb.Schedule, l *logger.Logger) (*period, error) {
 	p := &period{l: l}
 
+	if sched.GetCron() != "" {
+		loc, err := time.LoadLocation(sched.GetTimezone())
+		if err != nil {
+			return nil, fmt.Errorf("error loading timezone (%s): %v", sched.GetTimezone(), err)
+		}
+		p.loc = loc
+		if err := parseCronPeriod(sched, p); err != nil {
+			return nil, err
+		}
+		l.Infof("Schedule: %s", p.String())
+		return p, nil
+	}
+	if sched.GetDuration() != "" {
+		return nil, fmt.Errorf("invalid schedule: duration is only supported with cron")
+	}
+
 	if sched.GetStartWeekday() == configpb.Schedule_EVERYDAY || sched.GetEndWeekday() == configpb.Schedule_EVERYDAY {
 		if sched.GetStartWeekday() != sched.GetEndWeekday() {
 			return nil, fmt.Errorf("invalid schedule: if start_weekday is set to EVERYDAY, end_weekday should also be set to EVERYDAY, and vice versa")
@@ -114,6 +170,12 @@ func parsePeriod(sched *configpb.Schedule, l *logger.Logger) (*period, error) {
 }
 
 func (p *period) String() string {
+	if p.cron != nil {
+		if p.duration != 0 {
+			return fmt.Sprintf("Cron %q for %s (%s)", p.cron.spec, p.duration, p.loc)
+		}
+		return fmt.Sprintf("Cron %q (%s)", p.cron.spec, p.loc)
+	}
 	if p.everyDay {
 		return fmt.Sprintf("Everyday %s - %s", p.startTime.Format("15:04 MST"), p.endTime.Format("15:04 MST"))
 	}
@@ -178,6 +178,81 @@ func TestSchedules(t *testing.T) {
 				"2023-12-15 22:01:00 -0500": false, // Fri
 			},
 		},
+		{
+			name: "cronBusinessHoursAndDowntime",
+			confs: []*configpb.Schedule{
+				{
+					Type:     configpb.Schedule_ENABLE.Enum(),
+					Cron:     proto.String("* 8-19 * * MON-FRI"),
+					Timezone: proto.String("America/New_York"),
+				},
+				{
+					Type:     configpb.Schedule_DISABLE.Enum(),
+					Cron:     proto.String("30 11 * * *"),
+					Duration: proto.String("45m"),
+					Timezone: proto.String("America/New_York"),
+				},
+			},
+			results: map[string]bool{
+				"2023-12-10 12:00:00 -0500": false, // Sun
+				"2023-12-11 07:59:00 -0500": false, // Mon
+				"2023-12-11 08:00:00 -0500": true,  // Mon
+				"2023-12-11 11:29:00 -0500": true,  // Mon
+				"2023-12-11 11:30:00 -0500": false, // Mon -- downtime
+				"2023-12-11 12:14:00 -0500": false, // Mon -- downtime
+				"2023-12-11 12:15:00 -0500": true,  // Mon
+				"2023-12-11 19:59:00 -0500": true,  // Mon
+				"2023-12-11 20:00:00 -0500": false, // Mon
+			},
+		},
+		{
+			name: "cronOvernight",
+			confs: []*configpb.Schedule{
+				{
+					Type:     configpb.Schedule_DISABLE.Enum(),
+					Cron:     proto.String("0 22 * * FRI"),
+					Duration: proto.String("36h"),
+				},
+			},
+			results: map[string]bool{
+				"2023-12-15 21:59:00 +0000": true,  // Fri
+				"2023-12-15 22:00:00 +0000": false, // Fri
+				"2023-12-16 12:00:00 +0100": false, // Sat
+				"2023-12-17 09:59:00 +0000": false, // Sun
+				"2023-12-17 10:00:00 +0000": true,  // Sun
+			},
+		},
+		{
+			name: "err-cron-spec",
+			confs: []*configpb.Schedule{
+				{
+					Type: configpb.Schedule_DISABLE.Enum(),
+					Cron: proto.String("0 25 * * *"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "err-cron-duration",
+			confs: []*configpb.Schedule{
+				{
+					Type:     configpb.Schedule_DISABLE.Enum(),
+					Cron:     proto.String("0 2 * * *"),
+					Duration: proto.String("168h"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "err-duration-without-cron",
+			confs: []*configpb.Schedule{
+				{
+					Type:     configpb.Schedule_DISABLE.Enum(),
+					Duration: proto.String("1h"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
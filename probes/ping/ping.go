@@ -285,8 +285,19 @@ func (p *Probe) sendPackets(runID uint16, tracker chan bool) {
 	// We re-use the same memory space for all outgoing packets.
 	pktbuf := make([]byte, icmpHeaderSize+p.c.GetPayloadSize())
 
+	// Targets over the rate limits are skipped for the whole run.
+	throttled := make(map[string]bool)
+	for _, target := range p.targets {
+		if !p.opts.RateLimiter.Allow(target) {
+			throttled[target.Name] = true
+		}
+	}
+
 	for {
 		for _, target := range p.targets {
+			if throttled[target.Name] {
+				continue
+			}
 			p.results[target.Name].sent++
 
 			if p.target2addr[target.Name] == nil {
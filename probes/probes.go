@@ -23,10 +23,12 @@ import (
 	"sync"
 
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/arp"
 	"github.com/cloudprober/cloudprober/probes/dns"
 	"github.com/cloudprober/cloudprober/probes/external"
 	grpcprobe "github.com/cloudprober/cloudprober/probes/grpc"
 	httpprobe "github.com/cloudprober/cloudprober/probes/http"
+	"github.com/cloudprober/cloudprober/probes/multicast"
 	"github.com/cloudprober/cloudprober/probes/options"
 	"github.com/cloudprober/cloudprober/probes/ping"
 	configpb "github.com/cloudprober/cloudprober/probes/proto"
@@ -144,6 +146,12 @@ func initProbe(p *configpb.ProbeDef, opts *options.Options) (probe Probe, probeC
 	case configpb.ProbeDef_TCP:
 		probe = &tcp.Probe{}
 		probeConf = p.GetTcpProbe()
+	case configpb.ProbeDef_ARP:
+		probe = &arp.Probe{}
+		probeConf = p.GetArpProbe()
+	case configpb.ProbeDef_MULTICAST:
+		probe = &multicast.Probe{}
+		probeConf = p.GetMulticastProbe()
 	case configpb.ProbeDef_UDP:
 		probe = &udp.Probe{}
 		probeConf = p.GetUdpProbe()
@@ -5,6 +5,7 @@ import (
 	proto_1 "github.com/cloudprober/cloudprober/metrics/proto"
 	proto_5 "github.com/cloudprober/cloudprober/internal/validators/proto"
 	proto_A "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	proto_R "github.com/cloudprober/cloudprober/internal/ratelimit/proto"
 	proto_8 "github.com/cloudprober/cloudprober/probes/ping/proto"
 	proto_E "github.com/cloudprober/cloudprober/probes/http/proto"
 	proto_B "github.com/cloudprober/cloudprober/probes/dns/proto"
@@ -13,9 +14,11 @@ import (
 	proto_3 "github.com/cloudprober/cloudprober/probes/udplistener/proto"
 	proto_A2 "github.com/cloudprober/cloudprober/probes/grpc/proto"
 	proto_F "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	proto_C "github.com/cloudprober/cloudprober/probes/arp/proto"
+	proto_D "github.com/cloudprober/cloudprober/probes/multicast/proto"
 )
 
-// Next tag: 101
+// Next tag: 107
 #ProbeDef: {
 	// Probe name. It should be unique across all probes.
 	name?: string @protobuf(1,string)
@@ -27,7 +30,9 @@ import (
 		{"UDP", #enumValue: 4} |
 		{"UDP_LISTENER", #enumValue: 5} |
 		{"GRPC", #enumValue: 6} |
-		{"TCP", #enumValue: 7} | {
+		{"TCP", #enumValue: 7} |
+		{"ARP", #enumValue: 8} |
+		{"MULTICAST", #enumValue: 9} | {
 			// One of the extension probe types. See "extensions" below for more
 			// details.
 			"EXTENSION"
@@ -50,6 +55,8 @@ import (
 		UDP_LISTENER: 5
 		GRPC:         6
 		TCP:          7
+		ARP:          8
+		MULTICAST:    9
 		EXTENSION:    98
 		USER_DEFINED: 99
 	}
@@ -159,6 +166,26 @@ import (
 	// (More detailed example at: examples/additional_label/cloudprober.cfg)
 	additionalLabel?: [...#AdditionalLabel] @protobuf(14,AdditionalLabel,name=additional_label)
 
+	// Discovered target attributes to attach to the probe results as labels.
+	// Map's key is the metric label and value is the target label to take the
+	// value from. This is a shorthand for additional_label with
+	// "@target.label.<label>@" values, useful for slicing results by
+	// infrastructure dimensions, e.g. zone, machine type, k8s namespace or AWS
+	// tags (see RDS providers for the attributes exported by each of them).
+	//
+	// Example:
+	//   target_labels {
+	//     key: "zone"
+	//     value: "zone"
+	//   }
+	//   target_labels {
+	//     key: "team"
+	//     value: "team"
+	//   }
+	targetLabels?: {
+		[string]: string
+	} @protobuf(103,map[string]string,target_labels)
+
 	// (Experimental) If set, test is inversed, i.e. we count it as success if
 	// target doesn't respond. This is useful, for example, that your firewall is
 	// working as expected.
@@ -198,6 +225,10 @@ import (
 		grpcProbe: proto_A2.#ProbeConf @protobuf(26,grpc.ProbeConf,name=grpc_probe)
 	} | {
 		tcpProbe: proto_F.#ProbeConf @protobuf(27,tcp.ProbeConf,name=tcp_probe)
+	} | {
+		arpProbe: proto_C.#ProbeConf @protobuf(28,arp.ProbeConf,name=arp_probe)
+	} | {
+		multicastProbe: proto_D.#ProbeConf @protobuf(29,multicast.ProbeConf,name=multicast_probe)
 	} | {
 		// This field's contents are passed on to the user defined probe,
 		// registered for this probe's name through probes.RegisterUserDefined().
@@ -240,6 +271,109 @@ import (
 
 	// Debug options. Currently only used to enable logging metrics.
 	debugOptions?: #DebugOptions @protobuf(100,DebugOptions,name=debug_options)
+
+	// Per-target overrides of the probe options. This allows a single probe
+	// definition to cover heterogeneous targets. Overrides are applied in the
+	// given order, i.e. if multiple overrides match a target, later ones win.
+	// Per-target overrides can also be specified through targets' labels, see
+	// TargetOverride below for details.
+	// Example:
+	//   target_override {
+	//     target_regex: "legacy-.*"
+	//     port: 8080
+	//     relative_url: "/healthz"
+	//   }
+	targetOverride?: [...#TargetOverride] @protobuf(102,TargetOverride,name=target_override)
+
+	// Adaptive interval shortens the probe interval for a target while it's
+	// failing, so that failures and recoveries are detected sooner, without
+	// probing frequently all the time. Interval goes back to the probe interval
+	// once the target is healthy again. Note that as stats are exported after a
+	// fixed number of probe runs, they are exported more often as well while
+	// the interval is shortened.
+	// Currently supported only for HTTP, TCP, ARP and MULTICAST (SEND mode)
+	// probes.
+	// Example:
+	//   interval: "30s"
+	//   adaptive_interval {
+	//     min_interval: "5s"
+	//   }
+	adaptiveInterval?: #AdaptiveInterval @protobuf(104,AdaptiveInterval,name=adaptive_interval)
+
+	// Probes this probe depends on. While a dependency is failing, this probe's
+	// results are marked with a "suppressed" label and don't trigger alerts,
+	// e.g. to avoid a cascade of alerts when a shared gateway is down. With
+	// skip set, probe doesn't run at all for the affected targets.
+	// Example:
+	//   depends_on {
+	//     probe: "gateway-ping"
+	//   }
+	dependsOn?: [...#Dependency] @protobuf(105,Dependency,name=depends_on)
+
+	// Rate limits on the probe's requests, shared by its targets, in addition
+	// to the global rate limits. See RateLimit for details.
+	// Not supported for UDP_LISTENER, EXTENSION and USER_DEFINED probes.
+	rateLimit?: [...proto_R.#RateLimit] @protobuf(106,ratelimit.RateLimit,name=rate_limit)
+}
+
+// TargetOverride overrides probe options for the matching targets. Same
+// options can also be set through the following target labels (useful for
+// discovered targets), with the overrides in the probe config taking
+// precedence:
+//   cloudprober_port, cloudprober_timeout, cloudprober_path, cloudprober_body,
+//   cloudprober_header_<header-name>
+#TargetOverride: {
+	// Regex to match target names with.
+	targetRegex?: string @protobuf(1,string,name=target_regex)
+
+	// Port to probe the target on. Used by port-aware probes, e.g. HTTP and TCP.
+	port?: int32 @protobuf(2,int32)
+
+	// Probe timeout for the target, e.g. "5s". It should be smaller than the
+	// probe interval. Used by HTTP and TCP probes.
+	timeout?: string @protobuf(3,string)
+
+	// HTTP probe's relative URL (path).
+	relativeUrl?: string @protobuf(4,string,name=relative_url)
+
+	// HTTP probe's request headers. These are added to the probe's headers.
+	header?: {
+		[string]: string
+	} @protobuf(5,map[string]string)
+
+	// HTTP probe's request body.
+	body?: string @protobuf(6,string)
+}
+
+#Dependency: {
+	// Name of the probe this probe depends on.
+	probe?: string @protobuf(1,string)
+
+	// By default, dependency is considered failing if the dependency probe is
+	// failing for any of its targets. If per_target is set, dependency is
+	// considered failing for a target only if the dependency probe is failing
+	// for the same target (matched by name).
+	perTarget?: bool @protobuf(2,bool,name=per_target,"default=false")
+
+	// Number of consecutive failed probe cycles (stats export intervals) after
+	// which the dependency probe is considered failing.
+	failureThreshold?: int32 @protobuf(3,int32,name=failure_threshold,"default=1")
+
+	// Skip probing the affected targets while the dependency is failing,
+	// instead of only marking the results as suppressed. Targets are skipped
+	// and restored at the targets refresh interval.
+	skip?: bool @protobuf(4,bool,"default=false")
+}
+
+#AdaptiveInterval: {
+	// Shortest interval to probe a failing target at, e.g. "5s". It should be
+	// smaller than the probe interval, and not smaller than the probe timeout.
+	minInterval?: string @protobuf(1,string,name=min_interval)
+
+	// Interval is divided by this factor after every failed probe run, down to
+	// the min_interval, and multiplied by it after every successful run, back
+	// up to the probe interval.
+	factor?: float32 @protobuf(2,float,"default=2")
 }
 
 #AdditionalLabel: {
@@ -298,6 +432,21 @@ import (
 	// Timezone in which the probe should run. If not specified, it defaults to
 	// UTC. Example: "America/New_York"
 	timezone?: string @protobuf(6,string,#"default="UTC""#)
+
+	// Cron spec for the period, in the standard 5-field format: minute, hour,
+	// day of month, month, and day of week. If set, weekday and time fields are
+	// ignored. Without a duration, period covers all the minutes matching the
+	// spec, e.g. business hours:
+	//   cron: "* 9-17 * * MON-FRI"
+	// With a duration, period starts at every minute matching the spec and
+	// lasts for the duration, e.g. a nightly downtime from 2am to 4:30am:
+	//   cron: "0 2 * * *"
+	//   duration: "2h30m"
+	cron?: string @protobuf(7,string)
+
+	// Duration of the period starting at the cron spec matches, e.g. "2h".
+	// Must be less than 7 days.
+	duration?: string @protobuf(8,string)
 }
 
 #DebugOptions: {
@@ -1,6 +1,8 @@
 package proto
 
-// Next tag: 4
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Next tag: 5
 #ProbeConf: {
 	// Port for TCP requests. If not specfied, and port is provided by the
 	// targets (e.g. kubernetes endpoint or service), that port is used.
@@ -15,4 +17,10 @@ package proto
 
 	// Interval between targets.
 	intervalBetweenTargetsMsec?: int32 @protobuf(3,int32,name=interval_between_targets_msec,"default=10")
+
+	// If set, probe performs a TLS handshake after connecting. Handshake
+	// failures are counted as probe failures. Validators (e.g.
+	// cert_validator) are run on the TLS connection state.
+	// If server_name is not set, target name is used for SNI.
+	tlsConfig?: proto.#TLSConfig @protobuf(4,tlsconfig.TLSConfig,name=tls_config)
 }
@@ -17,12 +17,16 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
 	"github.com/cloudprober/cloudprober/internal/validators"
+	latencyvalidator "github.com/cloudprober/cloudprober/internal/validators/latency"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/probes/common/sched"
@@ -41,6 +45,7 @@ type Probe struct {
 	// book-keeping params
 	network     string
 	dialContext func(context.Context, string, string) (net.Conn, error) // Keeps some dialing related config
+	tlsConfig   *tls.Config
 }
 
 type probeResult struct {
@@ -117,11 +122,24 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 	}
 	p.dialContext = dialer.DialContext
 
+	if p.c.GetTlsConfig() != nil {
+		p.tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(p.tlsConfig, p.c.GetTlsConfig()); err != nil {
+			return fmt.Errorf("tls_config error: %v", err)
+		}
+	}
+
 	return nil
 }
 
 func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
-	ctx, cancelCtx := context.WithTimeout(ctx, p.opts.Timeout)
+	tgo := p.opts.TargetOverrides(target)
+
+	timeout := p.opts.Timeout
+	if tgo.Timeout != 0 {
+		timeout = tgo.Timeout
+	}
+	ctx, cancelCtx := context.WithTimeout(ctx, timeout)
 	defer cancelCtx()
 
 	// Convert interface to struct type
@@ -156,14 +174,25 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sche
 	if port == 0 {
 		port = target.Port
 	}
+	if tgo.Port != 0 {
+		port = tgo.Port
+	}
 	addr := net.JoinHostPort(host, strconv.Itoa(port))
 
 	start := time.Now()
 	conn, err := p.dialContext(ctx, p.network, addr)
-	latency := time.Since(start)
 	if conn != nil {
 		defer conn.Close()
 	}
+	phases := map[string]time.Duration{latencyvalidator.PhaseConnect: time.Since(start)}
+
+	var tlsState *tls.ConnectionState
+	if err == nil && p.tlsConfig != nil {
+		tlsStart := time.Now()
+		tlsState, err = p.tlsHandshake(ctx, conn, target.Name)
+		phases[latencyvalidator.PhaseTLS] = time.Since(tlsStart)
+	}
+	latency := time.Since(start)
 
 	if p.opts.NegativeTest {
 		if err == nil {
@@ -178,10 +207,41 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sche
 		p.l.Warning("Target:", target.Name, ", doTCP: ", err.Error())
 		return
 	}
+
+	if p.opts.Validators != nil {
+		input := &validators.Input{Latency: latency, PhaseLatency: phases}
+		if tlsState != nil {
+			input.Response = tlsState
+		}
+		failedValidations := validators.RunValidators(p.opts.Validators, input, result.validationFailure, p.l)
+		if len(failedValidations) > 0 {
+			p.l.Debug("Target:", target.Name, " failed validations: ", strings.Join(failedValidations, ","), ".")
+			return
+		}
+	}
+
 	result.success++
 	result.latency.AddFloat64(latency.Seconds() / p.opts.LatencyUnit.Seconds())
 }
 
+// tlsHandshake performs the TLS handshake over the given connection and
+// returns the connection state. Target name is used for SNI, unless
+// server_name is set in the TLS config.
+func (p *Probe) tlsHandshake(ctx context.Context, conn net.Conn, targetName string) (*tls.ConnectionState, error) {
+	cfg := p.tlsConfig
+	if cfg.ServerName == "" {
+		cfg = cfg.Clone()
+		cfg.ServerName = targetName
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, fmt.Errorf("TLS handshake error: %v", err)
+	}
+	state := tlsConn.ConnectionState()
+	return &state, nil
+}
+
 // Start starts and runs the probe indefinitely.
 func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
 	s := &sched.Scheduler{
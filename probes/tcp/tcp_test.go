@@ -18,12 +18,21 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
 
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/internal/validators"
+	certpb "github.com/cloudprober/cloudprober/internal/validators/cert/proto"
+	validatorspb "github.com/cloudprober/cloudprober/internal/validators/proto"
 	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/tcp/proto"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 type dialState struct {
@@ -114,3 +123,45 @@ func TestRunProbe(t *testing.T) {
 	}
 
 }
+
+func TestRunProbeTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	host, portStr, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	for _, minDays := range []int32{1, 36500} {
+		t.Run(fmt.Sprintf("min_days_to_expiry=%d", minDays), func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.ProbeConf = &configpb.ProbeConf{
+				TlsConfig: &tlsconfigpb.TLSConfig{DisableCertValidation: proto.Bool(true)},
+			}
+			vs, err := validators.Init([]*validatorspb.Validator{
+				{
+					Name: "cert",
+					Type: &validatorspb.Validator_CertValidator{
+						CertValidator: &certpb.Validator{MinDaysToExpiry: minDays},
+					},
+				},
+			}, nil)
+			assert.NoError(t, err)
+			opts.Validators = vs
+
+			p := &Probe{}
+			assert.NoError(t, p.Init("test-probe", opts))
+
+			res := p.newResult()
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: host, Port: port}, res)
+			result := res.(*probeResult)
+
+			// Test server's certificate expires in 2084.
+			wantSuccess, wantFailures := int64(1), int64(0)
+			if minDays > 365*60 {
+				wantSuccess, wantFailures = 0, 1
+			}
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, wantSuccess, result.success)
+			assert.Equal(t, wantFailures, result.validationFailure.GetKey("cert.expiry"))
+		})
+	}
+}
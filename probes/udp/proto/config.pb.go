@@ -54,6 +54,15 @@ type ProbeConf struct {
 	// list under maxTargets.  A large number of targets has impact on resource
 	// consumption.
 	MaxTargets *int32 `protobuf:"varint,9,opt,name=max_targets,json=maxTargets,def=500" json:"max_targets,omitempty"`
+	// Expect server timestamps in the responses. Set this field if probing a
+	// cloudprober UDP server running in TIMESTAMP mode. In addition to the
+	// round-trip latency, probe exports:
+	//
+	//	one_way_delay: time from probe sending the packet to server receiving
+	//	               it. It's accurate only if clocks are synchronized.
+	//	server_processing_time: time between server receiving the packet and
+	//	                        sending it back.
+	ServerTimestamps *bool `protobuf:"varint,10,opt,name=server_timestamps,json=serverTimestamps,def=0" json:"server_timestamps,omitempty"`
 }
 
 // Default values for ProbeConf fields.
@@ -64,6 +73,7 @@ const (
 	Default_ProbeConf_ExportMetricsByPort   = bool(false)
 	Default_ProbeConf_UseAllTxPortsPerProbe = bool(false)
 	Default_ProbeConf_MaxTargets            = int32(500)
+	Default_ProbeConf_ServerTimestamps      = bool(false)
 )
 
 func (x *ProbeConf) Reset() {
@@ -147,6 +157,13 @@ func (x *ProbeConf) GetMaxTargets() int32 {
 	return Default_ProbeConf_MaxTargets
 }
 
+func (x *ProbeConf) GetServerTimestamps() bool {
+	if x != nil && x.ServerTimestamps != nil {
+		return *x.ServerTimestamps
+	}
+	return Default_ProbeConf_ServerTimestamps
+}
+
 var File_github_com_cloudprober_cloudprober_probes_udp_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_probes_udp_proto_config_proto_rawDesc = []byte{
@@ -155,7 +172,7 @@ var file_github_com_cloudprober_cloudprober_probes_udp_proto_config_proto_rawDes
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75, 0x64, 0x70, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x16, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x75, 0x64, 0x70, 0x22, 0xb8, 0x02, 0x0a, 0x09, 0x50,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x75, 0x64, 0x70, 0x22, 0xec, 0x02, 0x0a, 0x09, 0x50,
 	0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x19, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x05, 0x33, 0x31, 0x31, 0x32, 0x32, 0x52, 0x04, 0x70,
 	0x6f, 0x72, 0x74, 0x12, 0x24, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x70, 0x6f,
@@ -175,10 +192,13 @@ var file_github_com_cloudprober_cloudprober_probes_udp_proto_config_proto_rawDes
 	0x54, 0x78, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x50, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12,
 	0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x09,
 	0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x35, 0x30, 0x30, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2f, 0x75, 0x64, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75, 0x64, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
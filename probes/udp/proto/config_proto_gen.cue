@@ -36,4 +36,13 @@ package proto
 	// list under maxTargets.  A large number of targets has impact on resource
 	// consumption.
 	maxTargets?: int32 @protobuf(9,int32,name=max_targets,"default=500")
+
+	// Expect server timestamps in the responses. Set this field if probing a
+	// cloudprober UDP server running in TIMESTAMP mode. In addition to the
+	// round-trip latency, probe exports:
+	//   one_way_delay: time from probe sending the packet to server receiving
+	//                  it. It's accurate only if clocks are synchronized.
+	//   server_processing_time: time between server receiving the packet and
+	//                           sending it back.
+	serverTimestamps?: bool @protobuf(10,bool,name=server_timestamps,"default=false")
 }
@@ -88,6 +88,9 @@ type probeResult struct {
 	total, success, delayed int64
 	latency                 metrics.LatencyValue
 	target                  endpoint.Endpoint
+
+	// Set only if server timestamps are enabled.
+	oneWayDelay, serverTime metrics.LatencyValue
 }
 
 // Metrics converts probeResult into metrics.EventMetrics object
@@ -100,8 +103,14 @@ func (prr probeResult) eventMetrics(probeName string, opts *options.Options, f f
 		AddMetric("total"+suffix, metrics.NewInt(prr.total)).
 		AddMetric("success"+suffix, metrics.NewInt(prr.success)).
 		AddMetric(opts.LatencyMetricName+suffix, prr.latency.Clone()).
-		AddMetric("delayed"+suffix, metrics.NewInt(prr.delayed)).
-		AddLabel("ptype", "udp").
+		AddMetric("delayed"+suffix, metrics.NewInt(prr.delayed))
+
+	if prr.oneWayDelay != nil {
+		m.AddMetric("one_way_delay"+suffix, prr.oneWayDelay.Clone()).
+			AddMetric("server_processing_time"+suffix, prr.serverTime.Clone())
+	}
+
+	m.AddLabel("ptype", "udp").
 		AddLabel("probe", probeName).
 		AddLabel("dst", f.target)
 
@@ -113,17 +122,23 @@ func (prr probeResult) eventMetrics(probeName string, opts *options.Options, f f
 	return m
 }
 
-func (p *Probe) newProbeResult(target endpoint.Endpoint) *probeResult {
-	var latVal metrics.LatencyValue
+func (p *Probe) newLatencyValue() metrics.LatencyValue {
 	if p.opts.LatencyDist != nil {
-		latVal = p.opts.LatencyDist.CloneDist()
-	} else {
-		latVal = metrics.NewFloat(0)
+		return p.opts.LatencyDist.CloneDist()
 	}
-	return &probeResult{
-		latency: latVal,
+	return metrics.NewFloat(0)
+}
+
+func (p *Probe) newProbeResult(target endpoint.Endpoint) *probeResult {
+	res := &probeResult{
+		latency: p.newLatencyValue(),
 		target:  target,
 	}
+	if p.c.GetServerTimestamps() {
+		res.oneWayDelay = p.newLatencyValue()
+		res.serverTime = p.newLatencyValue()
+	}
+	return res
 }
 
 // Init initializes the probe with the given params.
@@ -230,6 +245,10 @@ type packetID struct {
 	seq  uint64
 	txTS time.Time
 	rxTS time.Time
+
+	// Server's receive and transmit timestamps, if server timestamps are
+	// enabled.
+	srvRxTS, srvTxTS time.Time
 }
 
 func (p *Probe) resultsKey(f flow) flow {
@@ -257,6 +276,11 @@ func (p *Probe) processRcvdPacket(rpkt packetID) {
 	}
 	res.success++
 	res.latency.AddFloat64(latency.Seconds() / p.opts.LatencyUnit.Seconds())
+
+	if res.oneWayDelay != nil && !rpkt.srvRxTS.IsZero() {
+		res.oneWayDelay.AddFloat64(rpkt.srvRxTS.Sub(rpkt.txTS).Seconds() / p.opts.LatencyUnit.Seconds())
+		res.serverTime.AddFloat64(rpkt.srvTxTS.Sub(rpkt.srvRxTS).Seconds() / p.opts.LatencyUnit.Seconds())
+	}
 }
 
 func (p *Probe) processSentPacket(spkt packetID) {
@@ -346,13 +370,29 @@ func (p *Probe) recvLoop(ctx context.Context, conn *net.UDPConn) {
 		}
 
 		rxTS := time.Now()
-		msg, err := udpmessage.NewMessage(b[:msgLen])
+		pkt := b[:msgLen]
+		var srvRxTS, srvTxTS time.Time
+		if p.c.GetServerTimestamps() {
+			if pkt, srvRxTS, srvTxTS, err = udpsrv.ParseTimestamps(pkt); err != nil {
+				p.l.Errorf("Incoming message error from %s: %v", raddr, err)
+				continue
+			}
+		}
+		msg, err := udpmessage.NewMessage(pkt)
 		if err != nil {
 			p.l.Errorf("Incoming message error from %s: %v", raddr, err)
 			continue
 		}
+		pktID := packetID{
+			f:       flow{msg.SrcPort(), msg.Dst()},
+			seq:     msg.Seq(),
+			txTS:    msg.SrcTS(),
+			rxTS:    rxTS,
+			srvRxTS: srvRxTS,
+			srvTxTS: srvTxTS,
+		}
 		select {
-		case p.rcvdPackets <- packetID{flow{msg.SrcPort(), msg.Dst()}, msg.Seq(), msg.SrcTS(), rxTS}:
+		case p.rcvdPackets <- pktID:
 		default:
 			p.l.Errorf("rcvdPackets channel full")
 		}
@@ -374,7 +414,7 @@ func (p *Probe) runSingleProbe(f flow, conn *net.UDPConn, maxLen int, raddr *net
 	// Send packet over sentPackets channel
 	// May need to make a longer buffer for the channel.
 	select {
-	case p.sentPackets <- packetID{f, seq, now, time.Time{}}:
+	case p.sentPackets <- packetID{f: f, seq: seq, txTS: now}:
 		return nil
 	default:
 		return fmt.Errorf("sentPackets channel full")
@@ -404,13 +444,21 @@ func (p *Probe) runProbe() {
 		initialConn = int(p.runID % uint64(len(p.connList)))
 	}
 
+	// Targets over the rate limits are skipped for this run.
+	var targets []endpoint.Endpoint
+	for _, target := range p.targets {
+		if p.opts.RateLimiter.Allow(target) {
+			targets = append(targets, target)
+		}
+	}
+
 	var wg sync.WaitGroup
-	wg.Add(len(p.targets) * packetsPerTarget)
+	wg.Add(len(targets) * packetsPerTarget)
 
 	for _, conn := range p.connList {
 		conn.SetWriteDeadline(time.Now().Add(p.opts.Interval / 2))
 	}
-	for _, target := range p.targets {
+	for _, target := range targets {
 		ip, err := p.opts.Targets.Resolve(target.Name, p.ipVer)
 		if err != nil {
 			p.l.Errorf("unable to resolve %s: %v", target.Name, err)
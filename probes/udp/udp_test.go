@@ -34,6 +34,7 @@ import (
 	"github.com/cloudprober/cloudprober/probes/options"
 	configpb "github.com/cloudprober/cloudprober/probes/udp/proto"
 	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 )
@@ -350,3 +351,38 @@ func TestLossAndDelayed(t *testing.T) {
 		})
 	}
 }
+
+func TestServerTimestamps(t *testing.T) {
+	p := &Probe{
+		c: &configpb.ProbeConf{ServerTimestamps: proto.Bool(true)},
+		opts: &options.Options{
+			Timeout:           time.Second,
+			LatencyUnit:       time.Millisecond,
+			LatencyMetricName: "latency",
+		},
+		l:   &logger.Logger{},
+		res: make(map[flow]*probeResult),
+	}
+	f := flow{"", "target"}
+	p.res[f] = p.newProbeResult(endpoint.Endpoint{Name: "target"})
+
+	txTS := time.Now()
+	p.processRcvdPacket(packetID{
+		f:       f,
+		txTS:    txTS,
+		rxTS:    txTS.Add(10 * time.Millisecond),
+		srvRxTS: txTS.Add(4 * time.Millisecond),
+		srvTxTS: txTS.Add(5 * time.Millisecond),
+	})
+
+	em := p.res[f].eventMetrics("probe", p.opts, f, p.c)
+	assert.Equal(t, int64(1), extractMetric(em, "success"))
+	assert.InDelta(t, 10.0, em.Metric("latency").(*metrics.Float).Float64(), 0.001)
+	assert.InDelta(t, 4.0, em.Metric("one_way_delay").(*metrics.Float).Float64(), 0.001)
+	assert.InDelta(t, 1.0, em.Metric("server_processing_time").(*metrics.Float).Float64(), 0.001)
+
+	// Server timestamp metrics are not exported by default.
+	p.c = &configpb.ProbeConf{}
+	em = p.newProbeResult(endpoint.Endpoint{Name: "target"}).eventMetrics("probe", p.opts, f, p.c)
+	assert.Nil(t, em.Metric("one_way_delay"))
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/managedwriter"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
@@ -270,7 +271,10 @@ func (s *Surfacer) batchInsertRowsToBQ(ctx context.Context, inserter iInserter)
 			bqRowsArr = append(bqRowsArr, bqMetrics...)
 		}
 		if len(bqRowsArr) > 0 {
-			if err := inserter.Put(bqctx, bqRowsArr); err != nil {
+			start := time.Now()
+			err := inserter.Put(bqctx, bqRowsArr)
+			s.opts.ReportPush(start, err)
+			if err != nil {
 				for _, row := range bqRowsArr {
 					s.l.Errorf("failed uploading row to Bigquery: %v, row: %v", err, row.value)
 				}
@@ -303,9 +307,49 @@ func (s *Surfacer) init(ctx context.Context) error {
 	}
 	defer client.Close()
 
-	inserter := client.Dataset(s.c.GetBigqueryDataset()).Table(s.c.GetBigqueryTable()).Inserter()
-	if inserter == nil {
-		return fmt.Errorf("error bigquery inserter cannot be created")
+	table := client.Dataset(s.c.GetBigqueryDataset()).Table(s.c.GetBigqueryTable())
+
+	var schema bigquery.Schema
+	if s.c.GetCreateTable() {
+		if schema, err = ensureTable(ctx, table, s.c); err != nil {
+			return fmt.Errorf("bigquery: %v", err)
+		}
+	}
+
+	var inserter iInserter
+	switch s.c.GetWriteApi() {
+	case configpb.SurfacerConf_STORAGE_WRITE:
+		if s.c.GetProjectName() == "" {
+			return fmt.Errorf("bigquery: project_name is required for the storage write API")
+		}
+		if schema == nil {
+			md, err := table.Metadata(ctx)
+			if err != nil {
+				return fmt.Errorf("bigquery: error getting table metadata: %v", err)
+			}
+			schema = md.Schema
+		}
+
+		mwClient, err := managedwriter.NewClient(ctx, s.c.GetProjectName())
+		if err != nil {
+			return fmt.Errorf("bigquery: error creating storage write client: %v", err)
+		}
+		inserter, err = newStorageInserter(ctx, mwClient, s.c.GetProjectName(), s.c.GetBigqueryDataset(), s.c.GetBigqueryTable(), schema)
+		if err != nil {
+			mwClient.Close()
+			return fmt.Errorf("bigquery: %v", err)
+		}
+		go func() {
+			<-ctx.Done()
+			mwClient.Close()
+		}()
+
+	default:
+		tableInserter := table.Inserter()
+		if tableInserter == nil {
+			return fmt.Errorf("error bigquery inserter cannot be created")
+		}
+		inserter = tableInserter
 	}
 
 	// Start a goroutine to run forever, polling on the writeChan. Allows
@@ -23,6 +23,10 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 const (
@@ -359,3 +363,68 @@ func TestWriteToBQ(t *testing.T) {
 		t.Fatalf("Error in writeToBQ!")
 	}
 }
+
+func TestTableSchema(t *testing.T) {
+	metricCols := bigquery.Schema{
+		{Name: metricTimeCol, Type: bigquery.TimestampFieldType, Required: true},
+		{Name: metricNameCol, Type: bigquery.StringFieldType, Required: true},
+		{Name: metricValueCol, Type: bigquery.FloatFieldType},
+	}
+
+	conf := newSurfacerConfig(nil)
+	schema, err := tableSchema(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, append(bigquery.Schema{{Name: "labels", Type: bigquery.StringFieldType}}, metricCols...), schema)
+
+	conf.BigqueryColumns = []*configpb.BQColumn{
+		{Label: proto.String("probe"), ColumnName: proto.String("probe"), ColumnType: proto.String("string")},
+		{Label: proto.String("code"), ColumnName: proto.String("code"), ColumnType: proto.String("int")},
+	}
+	schema, err = tableSchema(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, append(bigquery.Schema{
+		{Name: "probe", Type: bigquery.StringFieldType},
+		{Name: "code", Type: bigquery.IntegerFieldType},
+	}, metricCols...), schema)
+
+	conf.BigqueryColumns[1].ColumnType = proto.String("bytes")
+	_, err = tableSchema(conf)
+	assert.Error(t, err)
+}
+
+func TestEncodeRow(t *testing.T) {
+	conf := newSurfacerConfig(map[string]string{"probe": "string", "code": "int"})
+	schema, err := tableSchema(conf)
+	if err != nil {
+		t.Fatalf("tableSchema() error: %v", err)
+	}
+	md, err := messageDescriptor(schema)
+	if err != nil {
+		t.Fatalf("messageDescriptor() error: %v", err)
+	}
+
+	ts := time.Unix(1700000000, 0)
+	b, err := encodeRow(md, &bqrow{value: map[string]bigquery.Value{
+		"probe":        "p1",
+		"code":         "",
+		"unknown_col":  "x",
+		metricTimeCol:  ts,
+		metricNameCol:  "total",
+		metricValueCol: "10",
+	}})
+	assert.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(md)
+	assert.NoError(t, proto.Unmarshal(b, msg))
+	field := func(name string) protoreflect.Value {
+		return msg.Get(md.Fields().ByName(protoreflect.Name(name)))
+	}
+	assert.Equal(t, "p1", field("probe").String())
+	assert.False(t, msg.Has(md.Fields().ByName("code")), "code should not be set")
+	assert.Equal(t, ts.UnixMicro(), field(metricTimeCol).Int())
+	assert.Equal(t, "total", field(metricNameCol).String())
+	assert.Equal(t, 10.0, field(metricValueCol).Float())
+
+	_, err = encodeRow(md, &bqrow{value: map[string]bigquery.Value{metricValueCol: "not-a-number"}})
+	assert.Error(t, err)
+}
@@ -20,6 +20,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SurfacerConf_WriteAPI int32
+
+const (
+	// Legacy streaming API (tabledata.insertAll).
+	SurfacerConf_INSERT_ALL SurfacerConf_WriteAPI = 0
+	// BigQuery Storage Write API. It's cheaper and has higher throughput than
+	// the legacy streaming API. Rows are appended to the table's default
+	// stream.
+	SurfacerConf_STORAGE_WRITE SurfacerConf_WriteAPI = 1
+)
+
+// Enum value maps for SurfacerConf_WriteAPI.
+var (
+	SurfacerConf_WriteAPI_name = map[int32]string{
+		0: "INSERT_ALL",
+		1: "STORAGE_WRITE",
+	}
+	SurfacerConf_WriteAPI_value = map[string]int32{
+		"INSERT_ALL":    0,
+		"STORAGE_WRITE": 1,
+	}
+)
+
+func (x SurfacerConf_WriteAPI) Enum() *SurfacerConf_WriteAPI {
+	p := new(SurfacerConf_WriteAPI)
+	*p = x
+	return p
+}
+
+func (x SurfacerConf_WriteAPI) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerConf_WriteAPI) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SurfacerConf_WriteAPI) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_enumTypes[0]
+}
+
+func (x SurfacerConf_WriteAPI) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerConf_WriteAPI) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerConf_WriteAPI(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerConf_WriteAPI.Descriptor instead.
+func (SurfacerConf_WriteAPI) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type SurfacerConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -47,9 +107,21 @@ type SurfacerConf struct {
 	BatchTimerSec    *int64 `protobuf:"varint,7,opt,name=batch_timer_sec,json=batchTimerSec,def=10" json:"batch_timer_sec,omitempty"`
 	MetricsBatchSize *int64 `protobuf:"varint,8,opt,name=metrics_batch_size,json=metricsBatchSize,def=1000" json:"metrics_batch_size,omitempty"`
 	// Column name for metrics name, value and timestamp
-	MetricTimeColName  *string `protobuf:"bytes,9,opt,name=metric_time_col_name,json=metricTimeColName,def=metric_time" json:"metric_time_col_name,omitempty"`
-	MetricNameColName  *string `protobuf:"bytes,10,opt,name=metric_name_col_name,json=metricNameColName,def=metric_name" json:"metric_name_col_name,omitempty"`
-	MetricValueColName *string `protobuf:"bytes,11,opt,name=metric_value_col_name,json=metricValueColName,def=metric_value" json:"metric_value_col_name,omitempty"`
+	MetricTimeColName  *string                `protobuf:"bytes,9,opt,name=metric_time_col_name,json=metricTimeColName,def=metric_time" json:"metric_time_col_name,omitempty"`
+	MetricNameColName  *string                `protobuf:"bytes,10,opt,name=metric_name_col_name,json=metricNameColName,def=metric_name" json:"metric_name_col_name,omitempty"`
+	MetricValueColName *string                `protobuf:"bytes,11,opt,name=metric_value_col_name,json=metricValueColName,def=metric_value" json:"metric_value_col_name,omitempty"`
+	WriteApi           *SurfacerConf_WriteAPI `protobuf:"varint,12,opt,name=write_api,json=writeApi,enum=cloudprober.surfacer.bigquery.SurfacerConf_WriteAPI,def=0" json:"write_api,omitempty"`
+	// If enabled, cloudprober creates the table if it doesn't exist, and adds
+	// missing columns if it does. Table schema is derived from the
+	// bigquery_columns and the metric columns (or a "labels" JSON column if no
+	// bigquery_columns are specified). Metric value column is created as FLOAT.
+	//
+	// New tables are partitioned by day on the metric time column, so that
+	// queries over a time range scan only the relevant partitions.
+	CreateTable *bool `protobuf:"varint,13,opt,name=create_table,json=createTable" json:"create_table,omitempty"`
+	// Expiration for the table partitions, in days. Only used while creating a
+	// new table. Default is no expiration.
+	PartitionExpirationDays *int32 `protobuf:"varint,14,opt,name=partition_expiration_days,json=partitionExpirationDays" json:"partition_expiration_days,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -61,6 +133,7 @@ const (
 	Default_SurfacerConf_MetricTimeColName  = string("metric_time")
 	Default_SurfacerConf_MetricNameColName  = string("metric_name")
 	Default_SurfacerConf_MetricValueColName = string("metric_value")
+	Default_SurfacerConf_WriteApi           = SurfacerConf_INSERT_ALL
 )
 
 func (x *SurfacerConf) Reset() {
@@ -172,6 +245,27 @@ func (x *SurfacerConf) GetMetricValueColName() string {
 	return Default_SurfacerConf_MetricValueColName
 }
 
+func (x *SurfacerConf) GetWriteApi() SurfacerConf_WriteAPI {
+	if x != nil && x.WriteApi != nil {
+		return *x.WriteApi
+	}
+	return Default_SurfacerConf_WriteApi
+}
+
+func (x *SurfacerConf) GetCreateTable() bool {
+	if x != nil && x.CreateTable != nil {
+		return *x.CreateTable
+	}
+	return false
+}
+
+func (x *SurfacerConf) GetPartitionExpirationDays() int32 {
+	if x != nil && x.PartitionExpirationDays != nil {
+		return *x.PartitionExpirationDays
+	}
+	return 0
+}
+
 type BQColumn struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -245,7 +339,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_co
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
 	0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65,
-	0x72, 0x79, 0x22, 0xe2, 0x04, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
+	0x72, 0x79, 0x22, 0xcf, 0x06, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
 	0x6f, 0x6e, 0x66, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65,
 	0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65,
@@ -283,18 +377,32 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_co
 	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x0b, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0c, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x76, 0x61,
 	0x6c, 0x75, 0x65, 0x52, 0x12, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x43, 0x6f, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x08, 0x42, 0x51, 0x43, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f,
+	0x43, 0x6f, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x5d, 0x0a, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x5f, 0x61, 0x70, 0x69, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x2e, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x50, 0x49,
+	0x3a, 0x0a, 0x49, 0x4e, 0x53, 0x45, 0x52, 0x54, 0x5f, 0x41, 0x4c, 0x4c, 0x52, 0x08, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x41, 0x70, 0x69, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x61, 0x79, 0x73, 0x22, 0x2d, 0x0a, 0x08, 0x57, 0x72, 0x69, 0x74, 0x65, 0x41, 0x50,
+	0x49, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e, 0x53, 0x45, 0x52, 0x54, 0x5f, 0x41, 0x4c, 0x4c, 0x10,
+	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x57, 0x52, 0x49,
+	0x54, 0x45, 0x10, 0x01, 0x22, 0x62, 0x0a, 0x08, 0x42, 0x51, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -309,18 +417,21 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_c
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_rawDescData
 }
 
+var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_goTypes = []interface{}{
-	(*SurfacerConf)(nil), // 0: cloudprober.surfacer.bigquery.SurfacerConf
-	(*BQColumn)(nil),     // 1: cloudprober.surfacer.bigquery.BQColumn
+	(SurfacerConf_WriteAPI)(0), // 0: cloudprober.surfacer.bigquery.SurfacerConf.WriteAPI
+	(*SurfacerConf)(nil),       // 1: cloudprober.surfacer.bigquery.SurfacerConf
+	(*BQColumn)(nil),           // 2: cloudprober.surfacer.bigquery.BQColumn
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_depIdxs = []int32{
-	1, // 0: cloudprober.surfacer.bigquery.SurfacerConf.bigquery_columns:type_name -> cloudprober.surfacer.bigquery.BQColumn
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: cloudprober.surfacer.bigquery.SurfacerConf.bigquery_columns:type_name -> cloudprober.surfacer.bigquery.BQColumn
+	0, // 1: cloudprober.surfacer.bigquery.SurfacerConf.write_api:type_name -> cloudprober.surfacer.bigquery.SurfacerConf.WriteAPI
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() {
@@ -361,13 +472,14 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_c
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_surfacers_internal_bigquery_proto_config_proto = out.File
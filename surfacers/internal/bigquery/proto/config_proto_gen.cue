@@ -29,6 +29,37 @@ package proto
 	metricTimeColName?:  string @protobuf(9,string,name=metric_time_col_name,#"default="metric_time""#)
 	metricNameColName?:  string @protobuf(10,string,name=metric_name_col_name,#"default="metric_name""#)
 	metricValueColName?: string @protobuf(11,string,name=metric_value_col_name,#"default="metric_value""#)
+
+	#WriteAPI: {
+		// Legacy streaming API (tabledata.insertAll).
+		"INSERT_ALL"
+		#enumValue: 0
+	} | {
+		// BigQuery Storage Write API. It's cheaper and has higher throughput than
+		// the legacy streaming API. Rows are appended to the table's default
+		// stream.
+		"STORAGE_WRITE"
+		#enumValue: 1
+	}
+
+	#WriteAPI_value: {
+		INSERT_ALL:    0
+		STORAGE_WRITE: 1
+	}
+	writeApi?: #WriteAPI @protobuf(12,WriteAPI,name=write_api,"default=INSERT_ALL")
+
+	// If enabled, cloudprober creates the table if it doesn't exist, and adds
+	// missing columns if it does. Table schema is derived from the
+	// bigquery_columns and the metric columns (or a "labels" JSON column if no
+	// bigquery_columns are specified). Metric value column is created as FLOAT.
+	//
+	// New tables are partitioned by day on the metric time column, so that
+	// queries over a time range scan only the relevant partitions.
+	createTable?: bool @protobuf(13,bool,name=create_table)
+
+	// Expiration for the table partitions, in days. Only used while creating a
+	// new table. Default is no expiration.
+	partitionExpirationDays?: int32 @protobuf(14,int32,name=partition_expiration_days)
 }
 
 #BQColumn: {
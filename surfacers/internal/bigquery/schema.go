@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
+	"google.golang.org/api/googleapi"
+)
+
+func bqFieldType(colType string) (bigquery.FieldType, error) {
+	switch strings.ToLower(colType) {
+	case "string":
+		return bigquery.StringFieldType, nil
+	case "integer", "int", "numeric":
+		return bigquery.IntegerFieldType, nil
+	case "float", "double":
+		return bigquery.FloatFieldType, nil
+	case "timestamp":
+		return bigquery.TimestampFieldType, nil
+	default:
+		return "", fmt.Errorf("invalid column type: %s", colType)
+	}
+}
+
+// tableSchema returns the table schema corresponding to the surfacer config.
+// It mirrors the rows generated by parseBQCols.
+func tableSchema(c *configpb.SurfacerConf) (bigquery.Schema, error) {
+	var schema bigquery.Schema
+
+	if len(c.GetBigqueryColumns()) > 0 {
+		for _, col := range c.GetBigqueryColumns() {
+			fieldType, err := bqFieldType(col.GetColumnType())
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %v", col.GetColumnName(), err)
+			}
+			schema = append(schema, &bigquery.FieldSchema{Name: col.GetColumnName(), Type: fieldType})
+		}
+	} else {
+		schema = append(schema, &bigquery.FieldSchema{Name: "labels", Type: bigquery.StringFieldType})
+	}
+
+	return append(schema,
+		&bigquery.FieldSchema{Name: c.GetMetricTimeColName(), Type: bigquery.TimestampFieldType, Required: true},
+		&bigquery.FieldSchema{Name: c.GetMetricNameColName(), Type: bigquery.StringFieldType, Required: true},
+		&bigquery.FieldSchema{Name: c.GetMetricValueColName(), Type: bigquery.FloatFieldType},
+	), nil
+}
+
+// ensureTable creates the table if it doesn't exist, or adds the missing
+// columns to it if it does. It returns the resulting table schema.
+func ensureTable(ctx context.Context, table *bigquery.Table, c *configpb.SurfacerConf) (bigquery.Schema, error) {
+	schema, err := tableSchema(c)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := table.Metadata(ctx)
+	if err != nil {
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+			return nil, fmt.Errorf("error getting table metadata: %v", err)
+		}
+
+		err := table.Create(ctx, &bigquery.TableMetadata{
+			Schema: schema,
+			TimePartitioning: &bigquery.TimePartitioning{
+				Type:       bigquery.DayPartitioningType,
+				Field:      c.GetMetricTimeColName(),
+				Expiration: time.Duration(c.GetPartitionExpirationDays()) * 24 * time.Hour,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating table: %v", err)
+		}
+		return schema, nil
+	}
+
+	existing := make(map[string]bool)
+	for _, f := range md.Schema {
+		existing[f.Name] = true
+	}
+
+	newSchema := append(bigquery.Schema{}, md.Schema...)
+	for _, f := range schema {
+		if !existing[f.Name] {
+			// New columns must be nullable.
+			f.Required = false
+			newSchema = append(newSchema, f)
+		}
+	}
+	if len(newSchema) == len(md.Schema) {
+		return md.Schema, nil
+	}
+
+	md, err = table.Update(ctx, bigquery.TableMetadataToUpdate{Schema: newSchema}, md.ETag)
+	if err != nil {
+		return nil, fmt.Errorf("error adding columns to the table: %v", err)
+	}
+	return md.Schema, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/managedwriter"
+	"cloud.google.com/go/bigquery/storage/managedwriter/adapt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// storageInserter implements iInserter using the BigQuery Storage Write API.
+// Rows are appended to the table's default stream.
+type storageInserter struct {
+	stream *managedwriter.ManagedStream
+	md     protoreflect.MessageDescriptor
+}
+
+// messageDescriptor returns the proto message descriptor corresponding to the
+// table schema.
+func messageDescriptor(schema bigquery.Schema) (protoreflect.MessageDescriptor, error) {
+	storageSchema, err := adapt.BQSchemaToStorageTableSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	d, err := adapt.StorageSchemaToProto2Descriptor(storageSchema, "root")
+	if err != nil {
+		return nil, err
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unexpected descriptor type: %T", d)
+	}
+	return md, nil
+}
+
+func newStorageInserter(ctx context.Context, client *managedwriter.Client, project, dataset, table string, schema bigquery.Schema) (*storageInserter, error) {
+	md, err := messageDescriptor(schema)
+	if err != nil {
+		return nil, fmt.Errorf("error building proto descriptor from table schema: %v", err)
+	}
+	dp, err := adapt.NormalizeDescriptor(md)
+	if err != nil {
+		return nil, fmt.Errorf("error normalizing proto descriptor: %v", err)
+	}
+
+	stream, err := client.NewManagedStream(ctx,
+		managedwriter.WithDestinationTable(managedwriter.TableParentFromParts(project, dataset, table)),
+		managedwriter.WithType(managedwriter.DefaultStream),
+		managedwriter.WithSchemaDescriptor(dp))
+	if err != nil {
+		return nil, fmt.Errorf("error creating managed stream: %v", err)
+	}
+
+	return &storageInserter{stream: stream, md: md}, nil
+}
+
+// protoValue converts a bigquery.Value into a proto value for the given field.
+func protoValue(fd protoreflect.FieldDescriptor, v bigquery.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(fmt.Sprint(v)), nil
+
+	case protoreflect.Int64Kind:
+		switch val := v.(type) {
+		case time.Time:
+			// TIMESTAMP columns are encoded as microseconds since epoch.
+			return protoreflect.ValueOfInt64(val.UnixMicro()), nil
+		case int64:
+			return protoreflect.ValueOfInt64(val), nil
+		case float64:
+			return protoreflect.ValueOfInt64(int64(val)), nil
+		case string:
+			i, err := strconv.ParseInt(val, 10, 64)
+			return protoreflect.ValueOfInt64(i), err
+		}
+
+	case protoreflect.DoubleKind:
+		switch val := v.(type) {
+		case float64:
+			return protoreflect.ValueOfFloat64(val), nil
+		case int64:
+			return protoreflect.ValueOfFloat64(float64(val)), nil
+		case string:
+			f, err := strconv.ParseFloat(val, 64)
+			return protoreflect.ValueOfFloat64(f), err
+		}
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("can't convert %v (%T) to %v", v, v, fd.Kind())
+}
+
+// encodeRow encodes a row as a serialized proto message. Values for columns
+// that don't exist in the table are ignored.
+func encodeRow(md protoreflect.MessageDescriptor, row *bqrow) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	for k, v := range row.value {
+		fd := md.Fields().ByName(protoreflect.Name(k))
+		if fd == nil {
+			continue
+		}
+		// Empty labels are converted to "" by convertToBqType.
+		if s, ok := v.(string); ok && s == "" && fd.Kind() != protoreflect.StringKind {
+			continue
+		}
+		pv, err := protoValue(fd, v)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", k, err)
+		}
+		msg.Set(fd, pv)
+	}
+	return proto.Marshal(msg)
+}
+
+// Put implements the iInserter interface.
+func (si *storageInserter) Put(ctx context.Context, src any) error {
+	rows, ok := src.([]*bqrow)
+	if !ok {
+		return fmt.Errorf("unexpected type for rows: %T", src)
+	}
+
+	data := make([][]byte, 0, len(rows))
+	for _, row := range rows {
+		b, err := encodeRow(si.md, row)
+		if err != nil {
+			return err
+		}
+		data = append(data, b)
+	}
+
+	result, err := si.stream.AppendRows(ctx, data)
+	if err != nil {
+		return err
+	}
+	_, err = result.GetResult(ctx)
+	return err
+}
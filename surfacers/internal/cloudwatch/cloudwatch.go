@@ -14,15 +14,25 @@
 
 /*
 Package cloudwatch implements a surfacer to export metrics to AWS Cloudwatch.
+
+Metrics are published either using the PutMetricData API (default), or as
+Embedded Metric Format (EMF) log lines, which CloudWatch converts into metrics
+once they are ingested by CloudWatch Logs.
 */
 package cloudwatch
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
@@ -31,6 +41,7 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch/proto"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/diskqueue"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
 )
 
@@ -43,6 +54,8 @@ type CWSurfacer struct {
 	opts      *options.Options
 	writeChan chan *metrics.EventMetrics
 	session   *cloudwatch.Client
+	emfWriter io.Writer // Used only if output is EMF.
+	diskQueue *diskqueue.Queue
 	l         *logger.Logger
 
 	// A cache of []types.MetricDatum's, used for batch writing to the
@@ -54,22 +67,34 @@ type CWSurfacer struct {
 // passed in. It then hands off to a goroutine to surface metrics to cloudwatch
 // across a buffered channel.
 func New(ctx context.Context, conf *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*CWSurfacer, error) {
-	region := getRegion(conf)
-
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
-	if err != nil {
-		return nil, err
-	}
-
 	cw := &CWSurfacer{
 		c:                conf,
 		opts:             opts,
 		writeChan:        make(chan *metrics.EventMetrics, opts.Config.GetMetricsBufferSize()), // incoming internal metrics buffer
-		session:          cloudwatch.NewFromConfig(cfg),
 		l:                l,
 		metricDatumCache: make([]types.MetricDatum, 0, int(conf.GetMetricsBatchSize())), // batching buffer between cloudprober and cloudwatch
 	}
 
+	if conf.GetOutput() == configpb.SurfacerConf_EMF {
+		// EMF output doesn't need AWS credentials, log shipping takes care
+		// of getting the metrics into CloudWatch.
+		cw.emfWriter = os.Stdout
+		if conf.GetEmfFilePath() != "" {
+			f, err := os.OpenFile(conf.GetEmfFilePath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("cloudwatch: error opening EMF file: %v", err)
+			}
+			cw.emfWriter = f
+		}
+	} else {
+		cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(getRegion(conf)))
+		if err != nil {
+			return nil, err
+		}
+		cw.session = cloudwatch.NewFromConfig(cfg)
+		cw.diskQueue = opts.DiskQueue
+	}
+
 	go cw.processIncomingMetrics(ctx)
 
 	cw.l.Infof("Initialised Cloudwatch surfacer with batchsize: %d, publish timer (secs): %d\n", conf.GetMetricsBatchSize(), conf.GetBatchTimerSec())
@@ -162,17 +187,85 @@ func (cw *CWSurfacer) addMetricAndPublish(ctx context.Context, publishTimer *tim
 	}
 }
 
-// publishMetrics will publish the metric buffer to cloudwatch APIs
+// publishMetrics will publish the metric buffer to cloudwatch APIs, or write
+// it out as EMF log lines.
 func (cw *CWSurfacer) publishMetrics(ctx context.Context) {
+	if cw.emfWriter != nil {
+		start := time.Now()
+		err := cw.writeEMF(cw.emfWriter, cw.metricDatumCache)
+		cw.opts.ReportPush(start, err)
+		if err != nil {
+			cw.l.Errorf("Error writing EMF metrics: %v", err)
+		}
+		cw.metricDatumCache = cw.metricDatumCache[:0]
+		return
+	}
+
+	if cw.diskQueue != nil && cw.diskQueue.Len() > 0 {
+		if err := cw.diskQueue.Replay(func(data []byte) error { return cw.replayMetrics(ctx, data) }); err != nil {
+			cw.l.Warningf("Error re-sending buffered metrics (%v), buffering %d new metrics", err, len(cw.metricDatumCache))
+			cw.bufferMetrics(cw.metricDatumCache)
+			cw.metricDatumCache = cw.metricDatumCache[:0]
+			return
+		}
+	}
+
+	if err := cw.putMetricData(ctx, cw.metricDatumCache); err != nil {
+		cw.l.Errorf("Error publishing metrics to cloudwatch: %v", err)
+		if cw.diskQueue != nil && isTransientError(err) {
+			cw.bufferMetrics(cw.metricDatumCache)
+		}
+	}
+
+	cw.metricDatumCache = cw.metricDatumCache[:0] // reset the buffer
+}
+
+func (cw *CWSurfacer) putMetricData(ctx context.Context, md []types.MetricDatum) error {
+	start := time.Now()
 	_, err := cw.session.PutMetricData(ctx, &cloudwatch.PutMetricDataInput{
 		Namespace:  aws.String(cw.c.GetNamespace()),
-		MetricData: cw.metricDatumCache,
+		MetricData: md,
 	})
+	cw.opts.ReportPush(start, err)
+	return err
+}
+
+// isTransientError reports whether a failed publish is worth retrying.
+func isTransientError(err error) bool {
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		code := respErr.HTTPStatusCode()
+		return code == 429 || code >= 500
+	}
+	// Network errors, timeouts, etc.
+	return true
+}
+
+// bufferMetrics buffers the metrics that we failed to publish on disk.
+func (cw *CWSurfacer) bufferMetrics(md []types.MetricDatum) {
+	b, err := json.Marshal(md)
+	if err == nil {
+		err = cw.diskQueue.Push(b)
+	}
 	if err != nil {
-		cw.l.Errorf("Error publishing metrics to cloudwatch: %v", err)
+		cw.l.Errorf("Error buffering %d metrics on disk: %v", len(md), err)
 	}
+}
 
-	cw.metricDatumCache = cw.metricDatumCache[:0] // reset the buffer
+// replayMetrics re-sends buffered metrics. Metrics that can't be decoded or
+// that fail with non-transient errors are dropped.
+func (cw *CWSurfacer) replayMetrics(ctx context.Context, data []byte) error {
+	var md []types.MetricDatum
+	if err := json.Unmarshal(data, &md); err != nil {
+		cw.l.Warningf("Dropping undecodable buffered metrics: %v", err)
+		return nil
+	}
+	err := cw.putMetricData(ctx, md)
+	if err != nil && !isTransientError(err) {
+		cw.l.Warningf("Dropping %d buffered metrics: %v", len(md), err)
+		return nil
+	}
+	return err
 }
 
 // Create a new cloudwatch metriddatum using the values passed in.
@@ -15,7 +15,9 @@
 package cloudwatch
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -27,6 +29,7 @@ import (
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch/proto"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func newTestCWSurfacer() CWSurfacer {
@@ -353,3 +356,54 @@ func TestCWSurfacerRecordEventMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestCWSurfacerEMF(t *testing.T) {
+	var b bytes.Buffer
+	cw := &CWSurfacer{
+		c: &configpb.SurfacerConf{
+			Output:           configpb.SurfacerConf_EMF.Enum(),
+			MetricsBatchSize: proto.Int32(100),
+		},
+		opts:      &options.Options{},
+		emfWriter: &b,
+	}
+
+	ts := time.UnixMilli(1700000000123)
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("latency", metrics.NewFloat(2000)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 9)).
+		AddLabel("probe", "p1")
+	em.LatencyUnit = time.Microsecond
+
+	publishTimer := time.NewTicker(time.Hour)
+	defer publishTimer.Stop()
+	cw.recordEventMetrics(context.Background(), publishTimer, em)
+	cw.publishMetrics(context.Background())
+	assert.Empty(t, cw.metricDatumCache)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Equal(t, []string{
+		`{"_aws":{"Timestamp":1700000000123,"CloudWatchMetrics":[{"Namespace":"cloudprober","Dimensions":[["probe"]],"Metrics":[{"Name":"total","Unit":"Count","StorageResolution":60},{"Name":"latency","Unit":"Milliseconds","StorageResolution":60}]}]},"latency":2,"probe":"p1","total":10}`,
+		`{"_aws":{"Timestamp":1700000000123,"CloudWatchMetrics":[{"Namespace":"cloudprober","Dimensions":[["probe","code"]],"Metrics":[{"Name":"resp-code","Unit":"Count","StorageResolution":60}]}]},"code":"200","probe":"p1","resp-code":9}`,
+	}, lines)
+}
+
+func TestEMFDocuments(t *testing.T) {
+	ts := aws.Time(time.UnixMilli(1700000000000))
+	dims := []types.Dimension{{Name: aws.String("probe"), Value: aws.String("p1")}}
+
+	var datums []types.MetricDatum
+	for i := 0; i < emfMaxMetrics+1; i++ {
+		datums = append(datums, types.MetricDatum{MetricName: aws.String(fmt.Sprintf("m%d", i)), Value: aws.Float64(1), Dimensions: dims, Timestamp: ts})
+	}
+	// Repeated metric goes in as an array of values.
+	datums = append(datums, types.MetricDatum{MetricName: aws.String("m0"), Value: aws.Float64(2), Dimensions: dims, Timestamp: ts})
+
+	docs := emfDocuments("ns", datums)
+	assert.Len(t, docs, 2)
+	assert.Len(t, docs[0]["_aws"].(emfMetadata).CloudWatchMetrics[0].Metrics, emfMaxMetrics)
+	assert.Len(t, docs[1]["_aws"].(emfMetadata).CloudWatchMetrics[0].Metrics, 1)
+	assert.Equal(t, []float64{1, 2}, docs[0]["m0"])
+	assert.Equal(t, "p1", docs[1]["probe"])
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudwatch
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CloudWatch accepts at most 100 metrics per EMF metric directive.
+const emfMaxMetrics = 100
+
+type emfMetric struct {
+	Name              string
+	Unit              string `json:",omitempty"`
+	StorageResolution int32  `json:",omitempty"`
+}
+
+type emfDirective struct {
+	Namespace  string
+	Dimensions [][]string
+	Metrics    []emfMetric
+}
+
+type emfMetadata struct {
+	Timestamp         int64
+	CloudWatchMetrics []emfDirective
+}
+
+// emfGroup collects metric datums that share a timestamp and dimensions, and
+// hence can go into the same EMF document.
+type emfGroup struct {
+	timestamp  int64
+	dimensions []types.Dimension
+	names      []string // To keep metric names ordered
+	metrics    map[string]emfMetric
+	values     map[string][]float64
+}
+
+func dimensionsKey(timestamp int64, dimensions []types.Dimension) string {
+	var b strings.Builder
+	b.WriteString(strconv.FormatInt(timestamp, 10))
+	for _, d := range dimensions {
+		b.WriteString("," + aws.ToString(d.Name) + "=" + aws.ToString(d.Value))
+	}
+	return b.String()
+}
+
+// groupDatums groups metric datums by timestamp and dimensions.
+func groupDatums(datums []types.MetricDatum) []*emfGroup {
+	var groups []*emfGroup
+	groupMap := make(map[string]*emfGroup)
+
+	for _, md := range datums {
+		ts := aws.ToTime(md.Timestamp).UnixMilli()
+		key := dimensionsKey(ts, md.Dimensions)
+		g := groupMap[key]
+		if g == nil {
+			g = &emfGroup{
+				timestamp:  ts,
+				dimensions: md.Dimensions,
+				metrics:    make(map[string]emfMetric),
+				values:     make(map[string][]float64),
+			}
+			groupMap[key] = g
+			groups = append(groups, g)
+		}
+
+		name := aws.ToString(md.MetricName)
+		if _, ok := g.metrics[name]; !ok {
+			g.names = append(g.names, name)
+			g.metrics[name] = emfMetric{
+				Name:              name,
+				Unit:              string(md.Unit),
+				StorageResolution: aws.ToInt32(md.StorageResolution),
+			}
+		}
+		g.values[name] = append(g.values[name], aws.ToFloat64(md.Value))
+	}
+	return groups
+}
+
+// emfDocuments converts the metric datums into EMF documents. Datums with the
+// same timestamp and dimensions are put into the same document, with
+// dimensions as top level fields.
+func emfDocuments(namespace string, datums []types.MetricDatum) []map[string]any {
+	var docs []map[string]any
+
+	for _, g := range groupDatums(datums) {
+		dimNames := make([]string, 0, len(g.dimensions))
+		for _, d := range g.dimensions {
+			dimNames = append(dimNames, aws.ToString(d.Name))
+		}
+
+		for start := 0; start < len(g.names); start += emfMaxMetrics {
+			names := g.names[start:min(start+emfMaxMetrics, len(g.names))]
+
+			doc := make(map[string]any)
+			for _, d := range g.dimensions {
+				doc[aws.ToString(d.Name)] = aws.ToString(d.Value)
+			}
+
+			directive := emfDirective{
+				Namespace:  namespace,
+				Dimensions: [][]string{dimNames},
+			}
+			for _, name := range names {
+				directive.Metrics = append(directive.Metrics, g.metrics[name])
+				// EMF supports multiple values for a metric as an array.
+				if vals := g.values[name]; len(vals) == 1 {
+					doc[name] = vals[0]
+				} else {
+					doc[name] = vals
+				}
+			}
+
+			doc["_aws"] = emfMetadata{
+				Timestamp:         g.timestamp,
+				CloudWatchMetrics: []emfDirective{directive},
+			}
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// writeEMF writes the metric datums to w as EMF JSON log lines.
+func (cw *CWSurfacer) writeEMF(w io.Writer, datums []types.MetricDatum) error {
+	enc := json.NewEncoder(w)
+	for _, doc := range emfDocuments(cw.c.GetNamespace(), datums) {
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,6 +20,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SurfacerConf_Output int32
+
+const (
+	// Publish metrics using the PutMetricData API.
+	SurfacerConf_PUT_METRIC_DATA SurfacerConf_Output = 0
+	// Write metrics as CloudWatch Embedded Metric Format (EMF) JSON log
+	// lines, to stdout or to emf_file_path. CloudWatch extracts metrics from
+	// these log lines once they reach CloudWatch Logs, e.g. through the
+	// CloudWatch agent, Lambda, or the ECS/EKS awslogs log driver. This
+	// avoids the PutMetricData API rate limits and per-call cost.
+	// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+	SurfacerConf_EMF SurfacerConf_Output = 1
+)
+
+// Enum value maps for SurfacerConf_Output.
+var (
+	SurfacerConf_Output_name = map[int32]string{
+		0: "PUT_METRIC_DATA",
+		1: "EMF",
+	}
+	SurfacerConf_Output_value = map[string]int32{
+		"PUT_METRIC_DATA": 0,
+		"EMF":             1,
+	}
+)
+
+func (x SurfacerConf_Output) Enum() *SurfacerConf_Output {
+	p := new(SurfacerConf_Output)
+	*p = x
+	return p
+}
+
+func (x SurfacerConf_Output) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerConf_Output) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SurfacerConf_Output) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_enumTypes[0]
+}
+
+func (x SurfacerConf_Output) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerConf_Output) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerConf_Output(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerConf_Output.Descriptor instead.
+func (SurfacerConf_Output) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type SurfacerConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -49,7 +112,11 @@ type SurfacerConf struct {
 	// The maximum amount of time to hold metrics in the buffer (above).
 	// Metrics will be published when the timer expires, or the buffer is
 	// full, whichever happens first.
-	BatchTimerSec *int32 `protobuf:"varint,5,opt,name=batch_timer_sec,json=batchTimerSec,def=30" json:"batch_timer_sec,omitempty"`
+	BatchTimerSec *int32               `protobuf:"varint,5,opt,name=batch_timer_sec,json=batchTimerSec,def=30" json:"batch_timer_sec,omitempty"`
+	Output        *SurfacerConf_Output `protobuf:"varint,6,opt,name=output,enum=cloudprober.surfacer.cloudwatch.SurfacerConf_Output,def=0" json:"output,omitempty"`
+	// File to write EMF log lines to. If not specified, EMF log lines are
+	// written to stdout. Only used if output is EMF.
+	EmfFilePath *string `protobuf:"bytes,7,opt,name=emf_file_path,json=emfFilePath" json:"emf_file_path,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -58,6 +125,7 @@ const (
 	Default_SurfacerConf_Resolution       = int32(60)
 	Default_SurfacerConf_MetricsBatchSize = int32(1000)
 	Default_SurfacerConf_BatchTimerSec    = int32(30)
+	Default_SurfacerConf_Output           = SurfacerConf_PUT_METRIC_DATA
 )
 
 func (x *SurfacerConf) Reset() {
@@ -127,6 +195,20 @@ func (x *SurfacerConf) GetBatchTimerSec() int32 {
 	return Default_SurfacerConf_BatchTimerSec
 }
 
+func (x *SurfacerConf) GetOutput() SurfacerConf_Output {
+	if x != nil && x.Output != nil {
+		return *x.Output
+	}
+	return Default_SurfacerConf_Output
+}
+
+func (x *SurfacerConf) GetEmfFilePath() string {
+	if x != nil && x.EmfFilePath != nil {
+		return *x.EmfFilePath
+	}
+	return ""
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_rawDesc = []byte{
@@ -137,7 +219,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_
 	0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
 	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x22, 0xd5, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
+	0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x22, 0x80, 0x03, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
 	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x29, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
 	0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x63, 0x6c, 0x6f, 0x75,
 	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
@@ -150,12 +232,23 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_
 	0x52, 0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69,
 	0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
 	0x72, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33, 0x30, 0x52,
-	0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x63, 0x42, 0x48,
-	0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74,
-	0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x63, 0x12, 0x5d,
+	0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x34,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x3a, 0x0f, 0x50, 0x55, 0x54, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43,
+	0x5f, 0x44, 0x41, 0x54, 0x41, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x22, 0x0a,
+	0x0d, 0x65, 0x6d, 0x66, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6d, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
+	0x68, 0x22, 0x26, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x13, 0x0a, 0x0f, 0x50,
+	0x55, 0x54, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x45, 0x4d, 0x46, 0x10, 0x01, 0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
+	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -170,16 +263,19 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_rawDescData
 }
 
+var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_goTypes = []interface{}{
-	(*SurfacerConf)(nil), // 0: cloudprober.surfacer.cloudwatch.SurfacerConf
+	(SurfacerConf_Output)(0), // 0: cloudprober.surfacer.cloudwatch.SurfacerConf.Output
+	(*SurfacerConf)(nil),     // 1: cloudprober.surfacer.cloudwatch.SurfacerConf
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: cloudprober.surfacer.cloudwatch.SurfacerConf.output:type_name -> cloudprober.surfacer.cloudwatch.SurfacerConf.Output
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() {
@@ -208,13 +304,14 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto = out.File
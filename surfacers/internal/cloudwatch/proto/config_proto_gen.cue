@@ -30,4 +30,29 @@ package proto
 	// Metrics will be published when the timer expires, or the buffer is
 	// full, whichever happens first.
 	batchTimerSec?: int32 @protobuf(5,int32,name=batch_timer_sec,"default=30")
+
+	#Output: {
+		// Publish metrics using the PutMetricData API.
+		"PUT_METRIC_DATA"
+		#enumValue: 0
+	} | {
+		// Write metrics as CloudWatch Embedded Metric Format (EMF) JSON log
+		// lines, to stdout or to emf_file_path. CloudWatch extracts metrics from
+		// these log lines once they reach CloudWatch Logs, e.g. through the
+		// CloudWatch agent, Lambda, or the ECS/EKS awslogs log driver. This
+		// avoids the PutMetricData API rate limits and per-call cost.
+		// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+		"EMF"
+		#enumValue: 1
+	}
+
+	#Output_value: {
+		PUT_METRIC_DATA: 0
+		EMF:             1
+	}
+	output?: #Output @protobuf(6,Output,"default=PUT_METRIC_DATA")
+
+	// File to write EMF log lines to. If not specified, EMF log lines are
+	// written to stdout. Only used if output is EMF.
+	emfFilePath?: string @protobuf(7,string,name=emf_file_path)
 }
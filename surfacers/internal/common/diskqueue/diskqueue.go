@@ -0,0 +1,197 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package diskqueue implements a bounded, disk-backed FIFO queue that push
+surfacers use to hold the data that they failed to send, until the backend is
+reachable again.
+
+Each record is stored in its own file, named after its sequence number, so
+records survive cloudprober restarts and a partially written record never
+corrupts the rest of the queue. When the queue grows beyond its maximum size,
+oldest records are dropped.
+*/
+package diskqueue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const recordSuffix = ".rec"
+
+type record struct {
+	seq  uint64
+	size int64
+}
+
+// Queue is a bounded, disk-backed FIFO queue of opaque records.
+type Queue struct {
+	dir      string
+	maxBytes int64
+	l        *logger.Logger
+
+	mu      sync.Mutex
+	records []record
+	size    int64
+	nextSeq uint64
+
+	// Serializes replays, so that a record is not sent twice.
+	replayMu sync.Mutex
+}
+
+// New creates a queue in the given directory, picking up any records left
+// over from a previous run.
+func New(dir string, maxBytes int64, l *logger.Logger) (*Queue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("diskqueue: error creating directory %s: %v", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("diskqueue: error reading directory %s: %v", dir, err)
+	}
+
+	q := &Queue{
+		dir:      dir,
+		maxBytes: maxBytes,
+		l:        l,
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), recordSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), recordSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		q.records = append(q.records, record{seq, info.Size()})
+		q.size += info.Size()
+	}
+	sort.Slice(q.records, func(i, j int) bool { return q.records[i].seq < q.records[j].seq })
+	if len(q.records) > 0 {
+		q.nextSeq = q.records[len(q.records)-1].seq + 1
+		l.Infof("diskqueue: found %d records (%d bytes) in %s", len(q.records), q.size, dir)
+	}
+
+	return q, nil
+}
+
+func (q *Queue) path(seq uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", seq, recordSuffix))
+}
+
+// Push appends a record to the queue, dropping the oldest records if the
+// queue grows beyond its maximum size.
+func (q *Queue) Push(data []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	seq := q.nextSeq
+	q.nextSeq++
+
+	// Write to a temporary file first, so that we never see partial records.
+	tmpPath := q.path(seq) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("diskqueue: error writing record: %v", err)
+	}
+	if err := os.Rename(tmpPath, q.path(seq)); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("diskqueue: error writing record: %v", err)
+	}
+
+	q.records = append(q.records, record{seq, int64(len(data))})
+	q.size += int64(len(data))
+
+	var dropped int
+	for q.size > q.maxBytes && len(q.records) > 1 {
+		q.removeFirst()
+		dropped++
+	}
+	if dropped > 0 {
+		q.l.Warningf("diskqueue: queue size over %d bytes, dropped %d oldest records", q.maxBytes, dropped)
+	}
+	return nil
+}
+
+func (q *Queue) removeFirst() {
+	r := q.records[0]
+	if err := os.Remove(q.path(r.seq)); err != nil && !os.IsNotExist(err) {
+		q.l.Warningf("diskqueue: error removing record %d: %v", r.seq, err)
+	}
+	q.records = q.records[1:]
+	q.size -= r.size
+}
+
+// Len returns the number of records in the queue.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.records)
+}
+
+// Replay calls fn for the queued records, oldest first, removing each record
+// for which fn returns nil. It stops at the first error, leaving that record
+// and the ones after it in the queue, and returns the error. To drop a record
+// that can never be sent, fn should return nil.
+//
+// Queue is not locked while fn runs, so a slow backend doesn't block Push.
+func (q *Queue) Replay(fn func(data []byte) error) error {
+	q.replayMu.Lock()
+	defer q.replayMu.Unlock()
+
+	q.mu.Lock()
+	batch := append([]record(nil), q.records...)
+	q.mu.Unlock()
+
+	for _, r := range batch {
+		data, err := os.ReadFile(q.path(r.seq))
+		if err != nil {
+			// Record may have been dropped by Push in the meantime.
+			if !os.IsNotExist(err) {
+				q.l.Warningf("diskqueue: error reading record %d, dropping it: %v", r.seq, err)
+			}
+			q.remove(r.seq)
+			continue
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+		q.remove(r.seq)
+	}
+	return nil
+}
+
+// remove removes the record with the given sequence number, if it's still in
+// the queue. Records are only dropped from the front of the queue, so if the
+// record is still there, it's the first one.
+func (q *Queue) remove(seq uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.records) > 0 && q.records[0].seq == seq {
+		q.removeFirst()
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskqueue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func replayAll(t *testing.T, q *Queue) []string {
+	t.Helper()
+	var got []string
+	assert.NoError(t, q.Replay(func(data []byte) error {
+		got = append(got, string(data))
+		return nil
+	}))
+	return got
+}
+
+func TestQueue(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := New(dir, 1000, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating queue: %v", err)
+	}
+	for _, s := range []string{"r1", "r2", "r3"} {
+		assert.NoError(t, q.Push([]byte(s)))
+	}
+	assert.Equal(t, 3, q.Len())
+
+	// Replay stops at the first error, leaving remaining records queued.
+	var got []string
+	err = q.Replay(func(data []byte) error {
+		if string(data) == "r2" {
+			return errors.New("send error")
+		}
+		got = append(got, string(data))
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"r1"}, got)
+	assert.Equal(t, 2, q.Len())
+
+	// Records survive across queue instances.
+	q, err = New(dir, 1000, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error re-creating queue: %v", err)
+	}
+	assert.Equal(t, 2, q.Len())
+	assert.NoError(t, q.Push([]byte("r4")))
+	assert.Equal(t, []string{"r2", "r3", "r4"}, replayAll(t, q))
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestQueueMaxSize(t *testing.T) {
+	q, err := New(t.TempDir(), 10, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating queue: %v", err)
+	}
+
+	for _, s := range []string{"aaaa", "bbbb", "cccc"} {
+		assert.NoError(t, q.Push([]byte(s)))
+	}
+	assert.Equal(t, []string{"bbbb", "cccc"}, replayAll(t, q))
+
+	// A record bigger than the max size is still kept, by itself.
+	assert.NoError(t, q.Push([]byte("aaaa")))
+	assert.NoError(t, q.Push([]byte("bbbbbbbbbbbb")))
+	assert.Equal(t, []string{"bbbbbbbbbbbb"}, replayAll(t, q))
+}
+
+func TestQueuePushDuringReplay(t *testing.T) {
+	q, err := New(t.TempDir(), 10, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating queue: %v", err)
+	}
+	for _, s := range []string{"aaaa", "bbbb"} {
+		assert.NoError(t, q.Push([]byte(s)))
+	}
+
+	// Pushes while sending don't block, and records they drop are skipped.
+	var got []string
+	assert.NoError(t, q.Replay(func(data []byte) error {
+		if string(data) == "aaaa" {
+			assert.NoError(t, q.Push([]byte("cccc")))
+			assert.NoError(t, q.Push([]byte("dddd")))
+		}
+		got = append(got, string(data))
+		return nil
+	}))
+	assert.Equal(t, []string{"aaaa"}, got)
+	assert.Equal(t, []string{"cccc", "dddd"}, replayAll(t, q))
+}
@@ -20,16 +20,19 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/cloudprober/cloudprober/config/runconfig"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/diskqueue"
 	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
 )
 
 type labelFilter struct {
-	key   string
-	value string
+	key     string
+	value   string
+	valueRe *regexp.Regexp
 }
 
 func (lf *labelFilter) matchEventMetrics(em *metrics.EventMetrics) bool {
@@ -38,6 +41,9 @@ func (lf *labelFilter) matchEventMetrics(em *metrics.EventMetrics) bool {
 			if lf.key != lKey {
 				continue
 			}
+			if lf.valueRe != nil {
+				return lf.valueRe.MatchString(em.Label(lKey))
+			}
 			if lf.value == "" {
 				return true
 			}
@@ -60,6 +66,17 @@ func parseMetricsFilter(configs []*surfacerpb.LabelFilter) ([]*labelFilter, erro
 			return nil, fmt.Errorf("key is required to match against val (%s)", c.GetValue())
 		}
 
+		if c.GetValueRegex() != "" {
+			if lf.key == "" || lf.value != "" {
+				return nil, fmt.Errorf("value_regex (%s) requires key and can't be used with value", c.GetValueRegex())
+			}
+			re, err := regexp.Compile("^(?:" + c.GetValueRegex() + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid value_regex (%s): %v", c.GetValueRegex(), err)
+			}
+			lf.valueRe = re
+		}
+
 		filters = append(filters, lf)
 	}
 
@@ -79,6 +96,24 @@ type Options struct {
 	ignoreMetricName   *regexp.Regexp
 
 	AddFailureMetric bool
+
+	// DiskQueue is set only if disk buffer is configured. Surfacers that
+	// support disk buffering push the data that they fail to send to it.
+	DiskQueue *diskqueue.Queue
+
+	// PushResult, if set, is called by the push surfacers after every push to
+	// the backend, with the push latency and error. It's set by the SHADOW
+	// surfacer to compare its backends.
+	PushResult func(latency time.Duration, err error)
+}
+
+// ReportPush reports the result of a push, started at the given time, to the
+// PushResult function, if set.
+func (opts *Options) ReportPush(start time.Time, err error) {
+	if opts == nil || opts.PushResult == nil {
+		return
+	}
+	opts.PushResult(time.Since(start), err)
 }
 
 // AllowEventMetrics returns whether a certain EventMetrics should be allowed
@@ -168,6 +203,16 @@ func buildOptions(sdef *surfacerpb.SurfacerDef, ignoreInit bool, l *logger.Logge
 		}
 	}
 
+	if sdef.GetDiskBuffer() != nil {
+		if sdef.GetDiskBuffer().GetDir() == "" {
+			return nil, errors.New("disk_buffer: dir is required")
+		}
+		opts.DiskQueue, err = diskqueue.New(sdef.GetDiskBuffer().GetDir(), int64(sdef.GetDiskBuffer().GetMaxSizeMb())*1024*1024, l)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	opts.AddFailureMetric = opts.Config.GetAddFailureMetric()
 	defaultDisableFailureMetric := map[surfacerpb.Type]bool{
 		surfacerpb.Type_FILE:   true,
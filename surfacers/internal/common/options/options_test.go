@@ -128,6 +128,69 @@ func TestAllowEventMetrics(t *testing.T) {
 	}
 }
 
+func TestAllowEventMetricsValueRegex(t *testing.T) {
+	tests := []struct {
+		desc        string
+		allow       []*configpb.LabelFilter
+		ignore      []*configpb.LabelFilter
+		wantAllowed []int
+		wantErr     bool
+	}{
+		{
+			desc:        "allow-homepage-probes",
+			allow:       []*configpb.LabelFilter{{Key: proto.String("probe"), ValueRegex: proto.String(".*_homepage")}},
+			wantAllowed: []int{0, 1},
+		},
+		{
+			desc:        "regex-must-match-whole-value",
+			allow:       []*configpb.LabelFilter{{Key: proto.String("probe"), ValueRegex: proto.String("homepage")}},
+			wantAllowed: nil,
+		},
+		{
+			desc:        "ignore-with-regex",
+			ignore:      []*configpb.LabelFilter{{Key: proto.String("probe"), ValueRegex: proto.String("manugarg_.*|sysvars")}},
+			wantAllowed: []int{1},
+		},
+		{
+			desc:    "error-regex-with-value",
+			allow:   []*configpb.LabelFilter{{Key: proto.String("probe"), Value: proto.String("sysvars"), ValueRegex: proto.String("sys.*")}},
+			wantErr: true,
+		},
+		{
+			desc:    "error-regex-without-key",
+			allow:   []*configpb.LabelFilter{{ValueRegex: proto.String("sys.*")}},
+			wantErr: true,
+		},
+		{
+			desc:    "error-bad-regex",
+			allow:   []*configpb.LabelFilter{{Key: proto.String("probe"), ValueRegex: proto.String("(sys")}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			opts, err := BuildOptionsFromConfig(&configpb.SurfacerDef{
+				AllowMetricsWithLabel:  test.allow,
+				IgnoreMetricsWithLabel: test.ignore,
+			}, nil)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var gotEM []int
+			for i, em := range testEventMetrics {
+				if opts.AllowEventMetrics(em) {
+					gotEM = append(gotEM, i)
+				}
+			}
+			assert.Equal(t, test.wantAllowed, gotEM)
+		})
+	}
+}
+
 func TestAllowMetric(t *testing.T) {
 	tests := []struct {
 		desc        string
@@ -266,3 +329,20 @@ func TestBuildOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestReportPush(t *testing.T) {
+	// Nil options and options without PushResult are no-ops.
+	var nilOpts *Options
+	nilOpts.ReportPush(time.Now(), nil)
+	(&Options{}).ReportPush(time.Now(), nil)
+
+	var gotErr error
+	var calls int
+	opts := &Options{PushResult: func(latency time.Duration, err error) {
+		calls++
+		gotErr = err
+	}}
+	opts.ReportPush(time.Now(), os.ErrDeadlineExceeded)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, os.ErrDeadlineExceeded, gotErr)
+}
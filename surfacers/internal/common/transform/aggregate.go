@@ -0,0 +1,86 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Aggregator aggregates EventMetrics over a time window. EventMetrics are
+// aggregated per time series, identified by EventMetrics key.
+//
+// CUMULATIVE EventMetrics already include all previous increments, so the
+// latest EventMetrics replaces the earlier ones. For GAUGE EventMetrics,
+// distributions are merged and other values are replaced by the latest ones.
+type Aggregator struct {
+	mu      sync.Mutex
+	pending map[string]*metrics.EventMetrics
+	keys    []string // To keep flush order stable
+}
+
+// NewAggregator returns a new Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		pending: make(map[string]*metrics.EventMetrics),
+	}
+}
+
+// Add adds an EventMetrics to the current window.
+func (a *Aggregator) Add(em *metrics.EventMetrics) error {
+	key := em.Key()
+	newEM := em.Clone()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lastEM, ok := a.pending[key]
+	if !ok {
+		a.keys = append(a.keys, key)
+	}
+	a.pending[key] = newEM
+
+	if !ok || em.Kind != metrics.GAUGE || lastEM.Kind != metrics.GAUGE {
+		return nil
+	}
+
+	for _, name := range newEM.MetricsKeys() {
+		d, ok := newEM.Metric(name).(*metrics.Distribution)
+		if !ok {
+			continue
+		}
+		if err := d.Add(lastEM.Metric(name)); err != nil {
+			return fmt.Errorf("error merging distribution %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Flush returns the aggregated EventMetrics for the current window, in the
+// order they were first seen, and starts a new window.
+func (a *Aggregator) Flush() []*metrics.EventMetrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := make([]*metrics.EventMetrics, 0, len(a.keys))
+	for _, key := range a.keys {
+		out = append(out, a.pending[key])
+	}
+	a.pending = make(map[string]*metrics.EventMetrics)
+	a.keys = nil
+	return out
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregator(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	testEM := func(kind metrics.Kind, i int64, probe string, samples ...float64) *metrics.EventMetrics {
+		d := metrics.NewDistribution([]float64{1, 10})
+		for _, s := range samples {
+			d.AddSample(s)
+		}
+		em := metrics.NewEventMetrics(ts.Add(time.Duration(i)*time.Second)).
+			AddMetric("total", metrics.NewInt(i)).
+			AddMetric("latency", d).
+			AddLabel("probe", probe)
+		em.Kind = kind
+		return em
+	}
+
+	wantDist := func(samples ...float64) string {
+		d := metrics.NewDistribution([]float64{1, 10})
+		for _, s := range samples {
+			d.AddSample(s)
+		}
+		return d.String()
+	}
+
+	tests := []struct {
+		name string
+		kind metrics.Kind
+		want []string // latency distribution for p1 and p2
+	}{
+		{
+			name: "cumulative",
+			kind: metrics.CUMULATIVE,
+			want: []string{wantDist(5), wantDist(20)},
+		},
+		{
+			name: "gauge",
+			kind: metrics.GAUGE,
+			want: []string{wantDist(0.5, 2, 5), wantDist(20)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAggregator()
+			assert.NoError(t, a.Add(testEM(test.kind, 1, "p1", 0.5)))
+			assert.NoError(t, a.Add(testEM(test.kind, 2, "p2", 20)))
+			assert.NoError(t, a.Add(testEM(test.kind, 3, "p1", 2)))
+			assert.NoError(t, a.Add(testEM(test.kind, 4, "p1", 5)))
+
+			got := a.Flush()
+			assert.Len(t, got, 2)
+			for i, probe := range []string{"p1", "p2"} {
+				assert.Equal(t, probe, got[i].Label("probe"))
+				assert.Equal(t, test.kind, got[i].Kind)
+				assert.Equal(t, test.want[i], got[i].Metric("latency").String())
+			}
+			// Last value for the non-distribution metrics.
+			assert.Equal(t, int64(4), got[0].Metric("total").(metrics.NumValue).Int64())
+			assert.Equal(t, ts.Add(4*time.Second), got[0].Timestamp)
+
+			assert.Empty(t, a.Flush())
+		})
+	}
+}
+
+func TestAggregatorGaugeDeltas(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	a := NewAggregator()
+	for i, v := range []int64{3, 0, 2} {
+		em := metrics.NewEventMetrics(ts.Add(time.Duration(i)*time.Second)).
+			AddMetric("paused", metrics.NewInt(v)).
+			AddMetric("ssl_earliest_cert_expiry_sec", metrics.NewFloat(float64(v)*1.5)).
+			AddMetric("version", metrics.NewString(fmt.Sprintf("v%d", i))).
+			AddLabel("probe", "p1")
+		em.Kind = metrics.GAUGE
+		assert.NoError(t, a.Add(em))
+	}
+
+	// Gauges are not added up, last value in the window is exported.
+	got := a.Flush()
+	assert.Len(t, got, 1)
+	assert.Equal(t, int64(2), got[0].Metric("paused").(metrics.NumValue).Int64())
+	assert.Equal(t, 3.0, got[0].Metric("ssl_earliest_cert_expiry_sec").(metrics.NumValue).Float64())
+	assert.Equal(t, `"v2"`, got[0].Metric("version").String())
+	assert.Equal(t, ts.Add(2*time.Second), got[0].Timestamp)
+}
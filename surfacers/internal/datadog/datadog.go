@@ -157,7 +157,10 @@ func (dd *DDSurfacer) addMetricsAndPublish(ctx context.Context, publishTimer *ti
 }
 
 func (dd *DDSurfacer) publishMetrics(ctx context.Context) {
-	if err := dd.client.submitMetrics(ctx, dd.ddSeriesCache); err != nil {
+	start := time.Now()
+	err := dd.client.submitMetrics(ctx, dd.ddSeriesCache)
+	dd.opts.ReportPush(start, err)
+	if err != nil {
 		dd.l.Errorf("Failed to publish %d series to datadog: %v", len(dd.ddSeriesCache), err)
 	}
 
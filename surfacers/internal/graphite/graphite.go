@@ -0,0 +1,279 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package graphite implements a surfacer that sends metrics to Graphite's Carbon
+daemon, using either the plaintext or the pickle protocol.
+
+Metric paths are built from a template, e.g. with the default template,
+"cloudprober.@probe@.@dst@.@metric@", total metric for the probe "homepage"
+and the target "www.google.com" is exported as:
+
+	cloudprober.homepage.www_google_com.total 151 1700000000
+*/
+package graphite
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/strtemplate"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/graphite/proto"
+)
+
+// Characters that are not safe in a Graphite path component.
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_\-:]`)
+
+// Matches @label@ placeholders in the path template.
+var placeholderRe = regexp.MustCompile(`@([^@]+)@`)
+
+type dataPoint struct {
+	path      string
+	value     float64
+	timestamp int64
+}
+
+// Surfacer implements a Graphite surfacer.
+type Surfacer struct {
+	c              *configpb.SurfacerConf
+	opts           *options.Options
+	templateLabels []string
+	writeChan      chan *metrics.EventMetrics
+	l              *logger.Logger
+
+	conn  net.Conn
+	batch []dataPoint
+}
+
+// New creates a new instance of the Graphite surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetMetricsBatchSize() <= 0 {
+		return nil, fmt.Errorf("graphite: invalid metrics_batch_size: %d", config.GetMetricsBatchSize())
+	}
+	if !strings.Contains(config.GetPathTemplate(), "@metric@") {
+		return nil, fmt.Errorf("graphite: path_template (%s) must contain @metric@", config.GetPathTemplate())
+	}
+
+	s := &Surfacer{
+		c:         config,
+		opts:      opts,
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]dataPoint, 0, config.GetMetricsBatchSize()),
+	}
+	for _, m := range placeholderRe.FindAllStringSubmatch(config.GetPathTemplate(), -1) {
+		if m[1] != "metric" {
+			s.templateLabels = append(s.templateLabels, m[1])
+		}
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			if s.conn != nil {
+				s.conn.Close()
+			}
+			return
+		case em := <-s.writeChan:
+			for _, dp := range s.dataPoints(em) {
+				if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+					s.flush()
+					batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+				}
+				s.batch = append(s.batch, dp)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush()
+			}
+		}
+	}
+}
+
+func (s *Surfacer) send(payload []byte) error {
+	timeout := time.Duration(s.c.GetTimeoutSec()) * time.Second
+
+	if s.conn == nil {
+		conn, err := net.DialTimeout("tcp", s.c.GetAddress(), timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(timeout))
+	if _, err := s.conn.Write(payload); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// flush sends the batched data points to Carbon. If write fails, e.g. because
+// Carbon closed the connection, we reconnect and try once more.
+func (s *Surfacer) flush() {
+	var payload []byte
+	if s.c.GetProtocol() == configpb.SurfacerConf_PICKLE {
+		payload = pickleEncode(s.batch)
+	} else {
+		payload = plaintextEncode(s.batch)
+	}
+
+	start := time.Now()
+	err := s.send(payload)
+	if err != nil {
+		s.l.Warningf("Error writing to Carbon (%s), will reconnect and retry: %v", s.c.GetAddress(), err)
+		if err = s.send(payload); err != nil {
+			s.l.Errorf("Error writing %d data points to Carbon (%s): %v", len(s.batch), s.c.GetAddress(), err)
+		}
+	}
+	s.opts.ReportPush(start, err)
+	s.batch = s.batch[:0]
+}
+
+func plaintextEncode(dps []dataPoint) []byte {
+	var b bytes.Buffer
+	for _, dp := range dps {
+		b.WriteString(dp.path + " " + strconv.FormatFloat(dp.value, 'f', -1, 64) + " " + strconv.FormatInt(dp.timestamp, 10) + "\n")
+	}
+	return b.Bytes()
+}
+
+// pickleEncode encodes data points as a pickled (protocol 2) list of
+// (path, (timestamp, value)) tuples, prefixed with a 4-byte big-endian length
+// header, as expected by Carbon's pickle receiver.
+func pickleEncode(dps []dataPoint) []byte {
+	const (
+		opProto      = 0x80
+		opEmptyList  = ']'
+		opMark       = '('
+		opBinUnicode = 'X'
+		opBinFloat   = 'G'
+		opTuple2     = 0x86
+		opAppends    = 'e'
+		opStop       = '.'
+	)
+
+	b := []byte{opProto, 2, opEmptyList, opMark}
+	for _, dp := range dps {
+		b = append(b, opBinUnicode)
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(dp.path)))
+		b = append(b, dp.path...)
+
+		b = append(b, opBinFloat)
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(float64(dp.timestamp)))
+		b = append(b, opBinFloat)
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(dp.value))
+
+		b = append(b, opTuple2, opTuple2)
+	}
+	b = append(b, opAppends, opStop)
+
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
+
+func sanitize(s string) string {
+	return unsafeChars.ReplaceAllString(s, "_")
+}
+
+func mapDataPoints[T int64 | float64](m *metrics.Map[T], name string, pathFn func(string) string, ts int64) []dataPoint {
+	var out []dataPoint
+	for _, k := range m.Keys() {
+		out = append(out, dataPoint{pathFn(name + "." + sanitize(k)), float64(m.GetKey(k)), ts})
+	}
+	return out
+}
+
+// dataPoints converts an EventMetrics into Graphite data points.
+func (s *Surfacer) dataPoints(em *metrics.EventMetrics) []dataPoint {
+	ts := em.Timestamp.Unix()
+
+	labels := make(map[string]string, len(s.templateLabels)+1)
+	for _, k := range s.templateLabels {
+		labels[k] = "none"
+		if v := em.Label(k); v != "" {
+			labels[k] = sanitize(v)
+		}
+	}
+	pathFn := func(metric string) string {
+		labels["metric"] = metric
+		path, _ := strtemplate.SubstituteLabels(s.c.GetPathTemplate(), labels)
+		return path
+	}
+
+	var out []dataPoint
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+		name := sanitize(metricKey)
+
+		switch v := em.Metric(metricKey).(type) {
+		case metrics.NumValue:
+			out = append(out, dataPoint{pathFn(name), v.Float64(), ts})
+		case *metrics.Map[int64]:
+			out = append(out, mapDataPoints(v, name, pathFn, ts)...)
+		case *metrics.Map[float64]:
+			out = append(out, mapDataPoints(v, name, pathFn, ts)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, dataPoint{pathFn(name + ".sum"), d.Sum, ts})
+			out = append(out, dataPoint{pathFn(name + ".count"), float64(d.Count), ts})
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "inf"
+				if i < len(d.LowerBounds)-1 {
+					le = sanitize(strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64))
+				}
+				out = append(out, dataPoint{pathFn(name + ".le_" + le), float64(cumCount), ts})
+			}
+		default:
+			// Graphite supports only numerical values.
+			s.l.Debugf("Skipping non-numerical metric: %s", metricKey)
+		}
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/graphite/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDataPoints(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	d := metrics.NewDistribution([]float64{0.5})
+	d.AddSample(2)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "homepage").
+		AddLabel("dst", "www.google.com")
+
+	tests := []struct {
+		template string
+		want     []dataPoint
+	}{
+		{
+			template: "cloudprober.@probe@.@dst@.@metric@",
+			want: []dataPoint{
+				{"cloudprober.homepage.www_google_com.total", 10, 1700000000},
+				{"cloudprober.homepage.www_google_com.resp-code.200", 4, 1700000000},
+				{"cloudprober.homepage.www_google_com.latency.sum", 2, 1700000000},
+				{"cloudprober.homepage.www_google_com.latency.count", 1, 1700000000},
+				{"cloudprober.homepage.www_google_com.latency.le_0_5", 0, 1700000000},
+				{"cloudprober.homepage.www_google_com.latency.le_inf", 1, 1700000000},
+			},
+		},
+		{
+			template: "probes.@zone@.@ptype@.@metric@",
+			want: []dataPoint{
+				{"probes.none.http.total", 10, 1700000000},
+				{"probes.none.http.resp-code.200", 4, 1700000000},
+				{"probes.none.http.latency.sum", 2, 1700000000},
+				{"probes.none.http.latency.count", 1, 1700000000},
+				{"probes.none.http.latency.le_0_5", 0, 1700000000},
+				{"probes.none.http.latency.le_inf", 1, 1700000000},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			s, err := New(context.Background(), &configpb.SurfacerConf{
+				PathTemplate:  proto.String(test.template),
+				BatchTimerSec: proto.Int32(3600),
+			}, &options.Options{}, &logger.Logger{})
+			if err != nil {
+				t.Fatalf("Error creating surfacer: %v", err)
+			}
+			assert.Equal(t, test.want, s.dataPoints(em))
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{MetricsBatchSize: proto.Int32(0)},
+		{PathTemplate: proto.String("cloudprober.@probe@")},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, nil)
+		assert.Error(t, err, "conf: %v", conf)
+	}
+}
+
+func TestPickleEncode(t *testing.T) {
+	b := pickleEncode([]dataPoint{{"a.b", 1.5, 1700000000}})
+
+	assert.Equal(t, uint32(len(b)-4), binary.BigEndian.Uint32(b[:4]), "length header")
+	want := []byte{0x80, 2, ']', '(', 'X', 3, 0, 0, 0, 'a', '.', 'b', 'G'}
+	assert.Equal(t, want, b[4:4+len(want)])
+	assert.Equal(t, []byte{0x86, 0x86, 'e', '.'}, b[len(b)-4:])
+}
+
+func TestSurfacer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error creating listener: %v", err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Address:          proto.String(ln.Addr().String()),
+		MetricsBatchSize: proto.Int32(1),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	s.Write(ctx, metrics.NewEventMetrics(time.Unix(1700000000, 0)).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("success", metrics.NewInt(9)).
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t1"))
+
+	select {
+	case line := <-lines:
+		assert.Equal(t, "cloudprober.p1.t1.total 10 1700000000", line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for data")
+	}
+}
@@ -0,0 +1,40 @@
+package proto
+
+// Surfacer config for Graphite surfacer. This surfacer sends metrics to
+// Carbon using either the plaintext or the pickle protocol.
+#SurfacerConf: {
+	// Carbon address. Carbon listens for plaintext protocol on port 2003 and for
+	// pickle protocol on port 2004 by default.
+	address?: string @protobuf(1,string,#"default="localhost:2003""#)
+
+	#Protocol: {"PLAINTEXT", #enumValue: 0} |
+		{"PICKLE", #enumValue: 1}
+
+	#Protocol_value: {
+		PLAINTEXT: 0
+		PICKLE:    1
+	}
+	protocol?: #Protocol @protobuf(2,Protocol,"default=PLAINTEXT")
+
+	// Template for metric paths. @label@ is replaced by the value of the
+	// corresponding EventMetrics label, and @metric@ by the metric name. Dots
+	// and spaces in label values are replaced by underscores. Labels that are
+	// missing from the EventMetrics are replaced by "none".
+	//
+	// Map values are exported with map key appended to the metric name, e.g.
+	// "resp_code.200", and distributions are exported as "<metric>.sum",
+	// "<metric>.count" and "<metric>.le_<bucket_upper_bound>" (cumulative
+	// counts).
+	pathTemplate?: string @protobuf(3,string,name=path_template,#"default="cloudprober.@probe@.@dst@.@metric@""#)
+
+	// Maximum number of data points to send in one write. Data points are sent
+	// when the batch is full or when the batch timer expires, whichever happens
+	// first.
+	metricsBatchSize?: int32 @protobuf(4,int32,name=metrics_batch_size,"default=500")
+
+	// Maximum time to hold data points in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(5,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for connecting to Carbon and for each write.
+	timeoutSec?: int32 @protobuf(6,int32,name=timeout_sec,"default=10")
+}
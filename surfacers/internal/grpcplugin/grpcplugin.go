@@ -0,0 +1,365 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package grpcplugin implements a surfacer that streams EventMetrics to an
+external surfacer plugin over gRPC. This makes it possible to write surfacers
+for proprietary backends, in any language, without changing cloudprober.
+
+Plugins implement the SurfacerPlugin service defined in
+surfacers/plugin/proto/plugin.proto, and usually run as a sidecar. Surfacer
+waits for the plugin to report SERVING through the standard gRPC health
+service, performs a handshake, and then streams batches of EventMetrics to it.
+Plugin acknowledges each batch; surfacer stops sending when there are too many
+unacknowledged batches, and resumes once the plugin catches up.
+*/
+package grpcplugin
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/config/runconfig"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/grpcplugin/proto"
+	pluginpb "github.com/cloudprober/cloudprober/surfacers/plugin/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// protocolVersion is the version of the plugin protocol, as exchanged in the
+// handshake.
+const protocolVersion = 1
+
+// Surfacer implements a gRPC plugin surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	conn      *grpc.ClientConn
+	client    pluginpb.SurfacerPluginClient
+	health    healthpb.HealthClient
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	batch       []*pluginpb.EventMetrics
+	nextBatchID uint64
+}
+
+// New creates a new instance of the gRPC plugin surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetAddress() == "" {
+		return nil, errors.New("grpc_plugin: address is required")
+	}
+	if config.GetBatchSize() <= 0 {
+		return nil, fmt.Errorf("grpc_plugin: invalid batch_size: %d", config.GetBatchSize())
+	}
+	if config.GetMaxInflightBatches() <= 0 {
+		return nil, fmt.Errorf("grpc_plugin: invalid max_inflight_batches: %d", config.GetMaxInflightBatches())
+	}
+
+	var dialOpts []grpc.DialOption
+	if config.GetTlsConfig() != nil {
+		tlsConfig := &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(tlsConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("grpc_plugin: error initializing TLS config: %v", err)
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	// Dial doesn't block, connection is established in the background.
+	conn, err := grpc.Dial(config.GetAddress(), dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("grpc_plugin: error connecting to %s: %v", config.GetAddress(), err)
+	}
+
+	s := &Surfacer{
+		c:         config,
+		opts:      opts,
+		conn:      conn,
+		client:    pluginpb.NewSurfacerPluginClient(conn),
+		health:    healthpb.NewHealthClient(conn),
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]*pluginpb.EventMetrics, 0, config.GetBatchSize()),
+	}
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for the plugin.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) rpcTimeout() time.Duration {
+	return time.Duration(s.c.GetRpcTimeoutSec()) * time.Second
+}
+
+// serving reports whether plugin's health service reports SERVING. Plugins
+// that don't implement the health service are assumed to be serving.
+func (s *Surfacer) serving(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, s.rpcTimeout())
+	defer cancel()
+
+	resp, err := s.health.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return true
+		}
+		s.l.Debugf("grpc_plugin: health check failed: %v", err)
+		return false
+	}
+	return resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+}
+
+// handshake performs the handshake with the plugin, and returns the maximum
+// number of inflight batches to use.
+func (s *Surfacer) handshake(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.rpcTimeout())
+	defer cancel()
+
+	resp, err := s.client.Handshake(ctx, &pluginpb.HandshakeRequest{
+		ProtocolVersion:    proto.Uint32(protocolVersion),
+		SurfacerName:       proto.String(s.opts.Config.GetName()),
+		CloudproberVersion: proto.String(runconfig.Version()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if resp.GetProtocolVersion() != protocolVersion {
+		return 0, fmt.Errorf("unsupported plugin protocol version: %d, want: %d", resp.GetProtocolVersion(), protocolVersion)
+	}
+
+	window := int(s.c.GetMaxInflightBatches())
+	if n := int(resp.GetMaxInflightBatches()); n > 0 && n < window {
+		window = n
+	}
+	return window, nil
+}
+
+// run connects to the plugin and streams data to it, re-connecting on
+// failures, until the context is canceled.
+func (s *Surfacer) run(ctx context.Context) {
+	defer s.conn.Close()
+
+	retryInterval := time.Duration(s.c.GetHealthCheckIntervalSec()) * time.Second
+	wait := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryInterval):
+			return true
+		}
+	}
+
+	for ctx.Err() == nil {
+		if !s.serving(ctx) {
+			if !wait() {
+				break
+			}
+			continue
+		}
+
+		window, err := s.handshake(ctx)
+		if err != nil {
+			s.l.Errorf("grpc_plugin: handshake with plugin at %s failed: %v", s.c.GetAddress(), err)
+			if !wait() {
+				break
+			}
+			continue
+		}
+
+		s.l.Infof("grpc_plugin: connected to plugin at %s, max inflight batches: %d", s.c.GetAddress(), window)
+		if err := s.stream(ctx, window); err != nil && ctx.Err() == nil {
+			s.l.Errorf("grpc_plugin: stream to plugin at %s failed: %v", s.c.GetAddress(), err)
+			if !wait() {
+				break
+			}
+		}
+	}
+	s.l.Infof("Context canceled, stopping the surfacer write loop")
+}
+
+// stream opens a WriteMetrics stream and sends batches on it, with at most
+// window unacknowledged batches at a time. It returns when the stream fails
+// or the context is canceled.
+func (s *Surfacer) stream(ctx context.Context, window int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.WriteMetrics(ctx)
+	if err != nil {
+		return err
+	}
+
+	ackChan := make(chan *pluginpb.WriteMetricsResponse)
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+			select {
+			case ackChan <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Inflight batches, with their send times.
+	inflight := make(map[uint64]time.Time)
+	send := func() error {
+		s.nextBatchID++
+		req := &pluginpb.WriteMetricsRequest{
+			BatchId:      proto.Uint64(s.nextBatchID),
+			EventMetrics: s.batch,
+		}
+		s.batch = make([]*pluginpb.EventMetrics, 0, s.c.GetBatchSize())
+		start := time.Now()
+		if err := stream.Send(req); err != nil {
+			s.opts.ReportPush(start, err)
+			return fmt.Errorf("error sending batch of %d EventMetrics: %v", len(req.GetEventMetrics()), err)
+		}
+		inflight[req.GetBatchId()] = start
+		return nil
+	}
+
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		if len(s.batch) >= int(s.c.GetBatchSize()) && len(inflight) < window {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+
+		// Stop reading new data while the batch is full; that's how plugin's
+		// backpressure reaches the surfacer's buffer.
+		var in chan *metrics.EventMetrics
+		if len(s.batch) < int(s.c.GetBatchSize()) {
+			in = s.writeChan
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case em := <-in:
+			s.batch = append(s.batch, s.eventMetrics(em))
+		case <-batchTimer.C:
+			if len(s.batch) != 0 && len(inflight) < window {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+		case resp := <-ackChan:
+			start, ok := inflight[resp.GetBatchId()]
+			delete(inflight, resp.GetBatchId())
+			var err error
+			if resp.GetError() != "" {
+				err = errors.New(resp.GetError())
+			}
+			if ok {
+				s.opts.ReportPush(start, err)
+			}
+			if err != nil {
+				s.l.Warningf("grpc_plugin: plugin failed to process batch %d: %s", resp.GetBatchId(), resp.GetError())
+			}
+		case err := <-errChan:
+			return err
+		}
+	}
+}
+
+func mapValue[T int64 | float64](m *metrics.Map[T]) *pluginpb.MapValue {
+	mv := &pluginpb.MapValue{MapName: proto.String(m.MapName)}
+	for _, k := range m.Keys() {
+		mv.Key = append(mv.Key, k)
+		mv.Value = append(mv.Value, float64(m.GetKey(k)))
+	}
+	return mv
+}
+
+// eventMetrics converts an EventMetrics into its plugin protocol
+// representation.
+func (s *Surfacer) eventMetrics(em *metrics.EventMetrics) *pluginpb.EventMetrics {
+	pem := &pluginpb.EventMetrics{
+		TimestampMsec:   proto.Int64(em.Timestamp.UnixMilli()),
+		Kind:            pluginpb.EventMetrics_CUMULATIVE.Enum(),
+		LatencyUnitNsec: proto.Int64(int64(em.LatencyUnit)),
+	}
+	if em.Kind == metrics.GAUGE {
+		pem.Kind = pluginpb.EventMetrics_GAUGE.Enum()
+	}
+
+	for _, k := range em.LabelsKeys() {
+		pem.Label = append(pem.Label, &pluginpb.Label{Key: proto.String(k), Value: proto.String(em.Label(k))})
+	}
+
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+
+		m := &pluginpb.Metric{Name: proto.String(metricKey)}
+		switch v := em.Metric(metricKey).(type) {
+		case *metrics.Int:
+			m.Value = &pluginpb.Metric_IntValue{IntValue: v.Int64()}
+		case metrics.NumValue:
+			m.Value = &pluginpb.Metric_FloatValue{FloatValue: v.Float64()}
+		case *metrics.Map[int64]:
+			m.Value = &pluginpb.Metric_MapValue{MapValue: mapValue(v)}
+		case *metrics.Map[float64]:
+			m.Value = &pluginpb.Metric_MapValue{MapValue: mapValue(v)}
+		case *metrics.Distribution:
+			d := v.Data()
+			m.Value = &pluginpb.Metric_DistributionValue{DistributionValue: &pluginpb.Distribution{
+				LowerBound:  d.LowerBounds,
+				BucketCount: append([]int64{}, d.BucketCounts...),
+				Sum:         proto.Float64(d.Sum),
+				Count:       proto.Int64(d.Count),
+			}}
+		case metrics.String:
+			m.Value = &pluginpb.Metric_StringValue{StringValue: strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")}
+		default:
+			s.l.Warningf("grpc_plugin: unsupported value type for metric %s: %T", metricKey, v)
+			continue
+		}
+		pem.Metric = append(pem.Metric, m)
+	}
+	return pem
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcplugin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/grpcplugin/proto"
+	pluginpb "github.com/cloudprober/cloudprober/surfacers/plugin/proto"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// testPlugin is a plugin that forwards the received batches to a channel and
+// acknowledges them only when asked to.
+type testPlugin struct {
+	pluginpb.UnimplementedSurfacerPluginServer
+	version       uint32
+	handshakeReqs chan *pluginpb.HandshakeRequest
+	batches       chan *pluginpb.WriteMetricsRequest
+	acks          chan string
+}
+
+func (p *testPlugin) Handshake(ctx context.Context, req *pluginpb.HandshakeRequest) (*pluginpb.HandshakeResponse, error) {
+	p.handshakeReqs <- req
+	return &pluginpb.HandshakeResponse{
+		ProtocolVersion:    proto.Uint32(p.version),
+		MaxInflightBatches: proto.Uint32(1),
+	}, nil
+}
+
+func (p *testPlugin) WriteMetrics(stream pluginpb.SurfacerPlugin_WriteMetricsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		p.batches <- req
+		errMsg := <-p.acks
+		if err := stream.Send(&pluginpb.WriteMetricsResponse{BatchId: proto.Uint64(req.GetBatchId()), Error: proto.String(errMsg)}); err != nil {
+			return err
+		}
+	}
+}
+
+func startPlugin(t *testing.T, p *testPlugin, healthStatus healthpb.HealthCheckResponse_ServingStatus) (string, *health.Server) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Error starting listener: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	pluginpb.RegisterSurfacerPluginServer(srv, p)
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthStatus)
+	healthpb.RegisterHealthServer(srv, hs)
+
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String(), hs
+}
+
+func newTestPlugin(version uint32) *testPlugin {
+	return &testPlugin{
+		version:       version,
+		handshakeReqs: make(chan *pluginpb.HandshakeRequest, 10),
+		batches:       make(chan *pluginpb.WriteMetricsRequest, 10),
+		acks:          make(chan string, 10),
+	}
+}
+
+func testSurfacer(t *testing.T, ctx context.Context, addr string) *Surfacer {
+	t.Helper()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Address:                proto.String(addr),
+		BatchSize:              proto.Int32(1),
+		HealthCheckIntervalSec: proto.Int32(1),
+	}, &options.Options{
+		Config:            &surfacerpb.SurfacerDef{Name: proto.String("test-plugin")},
+		MetricsBufferSize: 10,
+	}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+	return s
+}
+
+func waitFor[T any](t *testing.T, ch chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for plugin")
+	}
+	var zero T
+	return zero
+}
+
+func TestSurfacer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := newTestPlugin(protocolVersion)
+	addr, hs := startPlugin(t, p, healthpb.HealthCheckResponse_NOT_SERVING)
+	s := testSurfacer(t, ctx, addr)
+
+	// Surfacer waits for the plugin to become healthy.
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, p.handshakeReqs, 0)
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	hsReq := waitFor(t, p.handshakeReqs)
+	assert.Equal(t, uint32(protocolVersion), hsReq.GetProtocolVersion())
+	assert.Equal(t, "test-plugin", hsReq.GetSurfacerName())
+
+	ts := time.Now()
+	for _, dst := range []string{"host1", "host2"} {
+		s.Write(ctx, metrics.NewEventMetrics(ts).AddMetric("total", metrics.NewInt(10)).AddLabel("dst", dst))
+	}
+
+	batch := waitFor(t, p.batches)
+	assert.Equal(t, uint64(1), batch.GetBatchId())
+	assert.Len(t, batch.GetEventMetrics(), 1)
+	assert.Equal(t, "host1", batch.GetEventMetrics()[0].GetLabel()[0].GetValue())
+
+	// Plugin allows only 1 inflight batch, second batch is sent only after
+	// the first one is acknowledged.
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, p.batches, 0)
+	p.acks <- "backend error"
+
+	batch = waitFor(t, p.batches)
+	assert.Equal(t, uint64(2), batch.GetBatchId())
+	assert.Equal(t, "host2", batch.GetEventMetrics()[0].GetLabel()[0].GetValue())
+	p.acks <- ""
+}
+
+func TestSurfacerVersionMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := newTestPlugin(protocolVersion + 1)
+	addr, _ := startPlugin(t, p, healthpb.HealthCheckResponse_SERVING)
+	s := testSurfacer(t, ctx, addr)
+
+	waitFor(t, p.handshakeReqs)
+	s.Write(ctx, metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(10)))
+
+	// Handshake is retried, no data is sent.
+	waitFor(t, p.handshakeReqs)
+	assert.Len(t, p.batches, 0)
+}
+
+func TestEventMetrics(t *testing.T) {
+	ts := time.Now()
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(5)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("latency", metrics.NewFloat(12.5)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 8).IncKeyBy("500", 2)).
+		AddMetric("latency_dist", d).
+		AddMetric("version", metrics.NewString("v1.2")).
+		AddLabel("probe", "p1")
+	em.Kind = metrics.GAUGE
+	em.LatencyUnit = time.Millisecond
+
+	s := &Surfacer{opts: &options.Options{}, l: &logger.Logger{}}
+	want := &pluginpb.EventMetrics{
+		TimestampMsec:   proto.Int64(ts.UnixMilli()),
+		Kind:            pluginpb.EventMetrics_GAUGE.Enum(),
+		LatencyUnitNsec: proto.Int64(int64(time.Millisecond)),
+		Label:           []*pluginpb.Label{{Key: proto.String("probe"), Value: proto.String("p1")}},
+		Metric: []*pluginpb.Metric{
+			{Name: proto.String("total"), Value: &pluginpb.Metric_IntValue{IntValue: 10}},
+			{Name: proto.String("latency"), Value: &pluginpb.Metric_FloatValue{FloatValue: 12.5}},
+			{Name: proto.String("resp-code"), Value: &pluginpb.Metric_MapValue{MapValue: &pluginpb.MapValue{
+				MapName: proto.String("code"),
+				Key:     []string{"200", "500"},
+				Value:   []float64{8, 2},
+			}}},
+			{Name: proto.String("latency_dist"), Value: &pluginpb.Metric_DistributionValue{DistributionValue: &pluginpb.Distribution{
+				LowerBound:  d.Data().LowerBounds,
+				BucketCount: []int64{0, 1, 0},
+				Sum:         proto.Float64(5),
+				Count:       proto.Int64(1),
+			}}},
+			{Name: proto.String("version"), Value: &pluginpb.Metric_StringValue{StringValue: "v1.2"}},
+		},
+	}
+	got := s.eventMetrics(em)
+	assert.True(t, proto.Equal(want, got), "got: %v, want: %v", got, want)
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{},
+		{Address: proto.String("localhost:9400"), BatchSize: proto.Int32(0)},
+		{Address: proto.String("localhost:9400"), MaxInflightBatches: proto.Int32(-1)},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, &logger.Logger{})
+		assert.Error(t, err, "config: %v", conf)
+	}
+}
@@ -0,0 +1,34 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+#SurfacerConf: {
+	// Address of the plugin's gRPC server, e.g. "localhost:9400" or
+	// "unix:///var/run/cloudprober/plugin.sock". Plugin should implement the
+	// SurfacerPlugin service defined in surfacers/plugin/proto/plugin.proto.
+	address?: string @protobuf(1,string)
+
+	// TLS config for the connection to the plugin. If not set, plaintext
+	// connection is used, which is fine for sidecars.
+	tlsConfig?: proto.#TLSConfig @protobuf(2,tlsconfig.TLSConfig,name=tls_config)
+
+	// Maximum number of EventMetrics in a batch.
+	batchSize?: int32 @protobuf(3,int32,name=batch_size,"default=100")
+
+	// Send partial batches after this interval.
+	batchTimerSec?: int32 @protobuf(4,int32,name=batch_timer_sec,"default=10")
+
+	// Maximum number of batches sent to the plugin but not yet acknowledged.
+	// Once this limit is reached, cloudprober stops sending more data and
+	// incoming data starts piling up in the surfacer's buffer (see
+	// metrics_buffer_size in SurfacerDef), and is dropped if the buffer fills
+	// up. Plugin can lower this limit in the handshake response.
+	maxInflightBatches?: int32 @protobuf(5,int32,name=max_inflight_batches,"default=10")
+
+	// How often to check plugin's health while it's not serving, and how long to
+	// wait before re-connecting after a stream failure.
+	healthCheckIntervalSec?: int32 @protobuf(6,int32,name=health_check_interval_sec,"default=10")
+
+	// Timeout for the handshake and health check RPCs.
+	rpcTimeoutSec?: int32 @protobuf(7,int32,name=rpc_timeout_sec,"default=10")
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package honeycomb implements a surfacer that sends probe results to Honeycomb
+(or a compatible events API) as wide events.
+
+Each EventMetrics becomes one event, with all labels and metrics as fields:
+
+	{"probe":"homepage","dst":"www.google.com","ptype":"http","total":1,
+	 "success":1,"latency":23.4,"resp-code.200":1}
+
+Map values are expanded into one field per key ("<metric>.<key>"), and
+distributions into "<metric>.sum", "<metric>.count" and "<metric>.avg"
+fields. The "latency" metric is always reported in milliseconds.
+*/
+package honeycomb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/honeycomb/proto"
+)
+
+type event struct {
+	Time string         `json:"time"`
+	Data map[string]any `json:"data"`
+}
+
+// Surfacer implements a Honeycomb surfacer.
+type Surfacer struct {
+	c          *configpb.SurfacerConf
+	opts       *options.Options
+	batchURL   string
+	apiKey     string
+	httpClient *http.Client
+	writeChan  chan *metrics.EventMetrics
+	l          *logger.Logger
+
+	lvCache map[string]*metrics.EventMetrics
+	batch   []event
+}
+
+// New creates a new instance of the Honeycomb surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetDataset() == "" {
+		return nil, fmt.Errorf("honeycomb: dataset is required")
+	}
+	if config.GetBatchSize() <= 0 {
+		return nil, fmt.Errorf("honeycomb: invalid batch_size: %d", config.GetBatchSize())
+	}
+
+	apiKey := config.GetApiKey()
+	if apiKey == "" {
+		apiKey = os.Getenv("HONEYCOMB_API_KEY")
+	}
+
+	s := &Surfacer{
+		c:          config,
+		opts:       opts,
+		batchURL:   strings.TrimSuffix(config.GetApiUrl(), "/") + "/1/batch/" + url.PathEscape(config.GetDataset()),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: time.Duration(config.GetRequestTimeoutSec()) * time.Second},
+		writeChan:  make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:          l,
+		lvCache:    make(map[string]*metrics.EventMetrics),
+		batch:      make([]event, 0, config.GetBatchSize()),
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			ev, err := s.event(em)
+			if err != nil {
+				s.l.Warningf("Error creating event from EventMetrics: %v", err)
+				continue
+			}
+			s.batch = append(s.batch, ev)
+			if len(s.batch) >= int(s.c.GetBatchSize()) {
+				s.flush(ctx)
+				batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.send(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error sending %d events to Honeycomb: %v", len(s.batch), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+// toGauge converts the counters in the EventMetrics to the change since the
+// last EventMetrics. String metrics are not counters, so they are kept out of
+// the conversion and copied over as they are.
+func (s *Surfacer) toGauge(em *metrics.EventMetrics) (*metrics.EventMetrics, error) {
+	counters := metrics.NewEventMetrics(em.Timestamp)
+	counters.LatencyUnit = em.LatencyUnit
+	for _, k := range em.LabelsKeys() {
+		counters.AddLabel(k, em.Label(k))
+	}
+	var strMetrics []string
+	for _, k := range em.MetricsKeys() {
+		if _, ok := em.Metric(k).(metrics.String); ok {
+			strMetrics = append(strMetrics, k)
+			continue
+		}
+		counters.AddMetric(k, em.Metric(k))
+	}
+
+	gaugeEM, err := transform.CumulativeToGauge(counters, s.lvCache, s.l)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range strMetrics {
+		gaugeEM.AddMetric(k, em.Metric(k))
+	}
+	return gaugeEM, nil
+}
+
+func addMapFields[T int64 | float64](data map[string]any, name string, m *metrics.Map[T]) {
+	for _, k := range m.Keys() {
+		data[name+"."+k] = m.GetKey(k)
+	}
+}
+
+// event converts an EventMetrics into a Honeycomb event.
+func (s *Surfacer) event(em *metrics.EventMetrics) (event, error) {
+	if em.Kind == metrics.CUMULATIVE && !s.c.GetCumulativeValues() {
+		gaugeEM, err := s.toGauge(em)
+		if err != nil {
+			return event{}, err
+		}
+		em = gaugeEM
+	}
+
+	data := make(map[string]any)
+	for _, k := range em.LabelsKeys() {
+		data[k] = em.Label(k)
+	}
+
+	// Latency values are in EventMetrics' latency unit.
+	latencyScale := 1.0
+	if em.LatencyUnit != 0 {
+		latencyScale = float64(em.LatencyUnit) / float64(time.Millisecond)
+	}
+
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+
+		scale := 1.0
+		if metricKey == "latency" {
+			scale = latencyScale
+		}
+
+		switch v := em.Metric(metricKey).(type) {
+		case *metrics.Int:
+			if scale != 1 {
+				data[metricKey] = float64(v.Int64()) * scale
+			} else {
+				data[metricKey] = v.Int64()
+			}
+		case metrics.NumValue:
+			data[metricKey] = v.Float64() * scale
+		case *metrics.Map[int64]:
+			addMapFields(data, metricKey, v)
+		case *metrics.Map[float64]:
+			addMapFields(data, metricKey, v)
+		case *metrics.Distribution:
+			d := v.Data()
+			data[metricKey+".sum"] = d.Sum * scale
+			data[metricKey+".count"] = d.Count
+			if d.Count != 0 {
+				data[metricKey+".avg"] = d.Sum * scale / float64(d.Count)
+			}
+		case metrics.String:
+			data[metricKey] = strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+		}
+	}
+
+	return event{Time: em.Timestamp.Format(time.RFC3339Nano), Data: data}, nil
+}
+
+func (s *Surfacer) send(ctx context.Context, events []event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.batchURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("X-Honeycomb-Team", s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("HTTP status: %d, response: %s", resp.StatusCode, string(b[:min(len(b), 512)]))
+	}
+
+	// Batch API returns a status for each event.
+	var statuses []struct {
+		Status int    `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(b, &statuses); err != nil {
+		// Compatible APIs may not return per-event statuses.
+		return nil
+	}
+	var failed int
+	var lastErr string
+	for _, st := range statuses {
+		if st.Status/100 != 2 {
+			failed++
+			lastErr = st.Error
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d events rejected, last error: %s", failed, lastErr)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycomb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/honeycomb/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM(ts time.Time, total, success int64, latencyUs float64, d *metrics.Distribution) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("success", metrics.NewInt(success)).
+		AddMetric("latency", metrics.NewFloat(latencyUs)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", success)).
+		AddMetric("size", d.Clone()).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t1")
+	em.LatencyUnit = time.Microsecond
+	return em
+}
+
+func TestEvent(t *testing.T) {
+	ts := time.Unix(1700000000, 0).UTC()
+
+	tests := []struct {
+		name       string
+		cumulative bool
+		want       map[string]any
+	}{
+		{
+			name: "delta",
+			want: map[string]any{
+				"ptype":         "http",
+				"probe":         "p1",
+				"dst":           "t1",
+				"total":         int64(1),
+				"success":       int64(1),
+				"latency":       3.0,
+				"resp-code.200": int64(1),
+				"size.sum":      30.0,
+				"size.count":    int64(1),
+				"size.avg":      30.0,
+				"version":       "v1",
+			},
+		},
+		{
+			name:       "cumulative",
+			cumulative: true,
+			want: map[string]any{
+				"ptype":         "http",
+				"probe":         "p1",
+				"dst":           "t1",
+				"total":         int64(2),
+				"success":       int64(2),
+				"latency":       5.0,
+				"resp-code.200": int64(2),
+				"size.sum":      50.0,
+				"size.count":    int64(2),
+				"size.avg":      25.0,
+				"version":       "v1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Surfacer{
+				c:       &configpb.SurfacerConf{CumulativeValues: proto.Bool(test.cumulative)},
+				opts:    &options.Options{},
+				l:       &logger.Logger{},
+				lvCache: make(map[string]*metrics.EventMetrics),
+			}
+
+			d := metrics.NewDistribution([]float64{10})
+			d.AddSample(20)
+			_, err := s.event(testEM(ts, 1, 1, 2000, d))
+			assert.NoError(t, err)
+
+			// Second run: 3ms latency, size 30.
+			d.AddSample(30)
+			ev, err := s.event(testEM(ts.Add(time.Second), 2, 2, 5000, d))
+			assert.NoError(t, err)
+			assert.Equal(t, "2023-11-14T22:13:21Z", ev.Time)
+			assert.Equal(t, test.want, ev.Data)
+		})
+	}
+}
+
+func TestSurfacer(t *testing.T) {
+	events := make(chan []map[string]any, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1/batch/probes" || r.Header.Get("X-Honeycomb-Team") != "key1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var evs []map[string]any
+		json.Unmarshal(b, &evs)
+		events <- evs
+		w.Write([]byte(`[{"status":202}]`))
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		ApiUrl:    proto.String(ts.URL + "/"),
+		Dataset:   proto.String("probes"),
+		ApiKey:    proto.String("key1"),
+		BatchSize: proto.Int32(1),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	s.Write(ctx, testEM(time.Unix(1700000000, 0), 1, 1, 2000, metrics.NewDistribution([]float64{10})))
+
+	select {
+	case evs := <-events:
+		assert.Len(t, evs, 1)
+		assert.Equal(t, "2023-11-14T22:13:20Z", evs[0]["time"])
+		assert.Equal(t, "p1", evs[0]["data"].(map[string]any)["probe"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events")
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"status":202},{"status":400,"error":"bad event"}]`))
+	}))
+	defer ts.Close()
+
+	s := &Surfacer{batchURL: ts.URL, httpClient: http.DefaultClient}
+	err := s.send(context.Background(), []event{{}, {}})
+	assert.ErrorContains(t, err, "1 events rejected, last error: bad event")
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{},
+		{Dataset: proto.String("probes"), BatchSize: proto.Int32(0)},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, nil)
+		assert.Error(t, err, "conf: %v", conf)
+	}
+}
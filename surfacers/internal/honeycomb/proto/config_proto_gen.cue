@@ -0,0 +1,32 @@
+package proto
+
+// Surfacer config for Honeycomb surfacer. This surfacer sends one wide event
+// per EventMetrics (i.e. per probe run and target) to Honeycomb's batch events
+// API, or to any API compatible with it.
+#SurfacerConf: {
+	// Events API URL. Events are sent to <api_url>/1/batch/<dataset>.
+	apiUrl?: string @protobuf(1,string,name=api_url,#"default="https://api.honeycomb.io""#)
+
+	// Dataset to send events to.
+	dataset?: string @protobuf(2,string)
+
+	// Honeycomb API key. If not set, HONEYCOMB_API_KEY environment variable is
+	// used.
+	apiKey?: string @protobuf(3,string,name=api_key)
+
+	// By default, cumulative counters (e.g. total, success) are converted to
+	// the change since the last event for the same probe and target, so that
+	// each event describes a single probe run. Set this to true to send
+	// cumulative values instead.
+	cumulativeValues?: bool @protobuf(4,bool,name=cumulative_values)
+
+	// Maximum number of events to send in one request. Events are sent when the
+	// batch is full or when the batch timer expires, whichever happens first.
+	batchSize?: int32 @protobuf(5,int32,name=batch_size,"default=100")
+
+	// Maximum time to hold events in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(6,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for each request.
+	requestTimeoutSec?: int32 @protobuf(7,int32,name=request_timeout_sec,"default=10")
+}
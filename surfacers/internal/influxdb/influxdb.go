@@ -0,0 +1,328 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package influxdb implements a surfacer that writes metrics to InfluxDB using
+the line protocol over InfluxDB's v1 or v2 HTTP write API.
+
+Each metric becomes a measurement, with EventMetrics labels as tags and the
+metric value in the "value" field, e.g.:
+
+	cloudprober_total,probe=homepage,ptype=http,dst=www.google.com value=151i 1700000000000
+
+Map values are expanded into one point per map key, with map name as an
+additional tag. Distributions are written as <metric>_sum and <metric>_count
+points, and a <metric>_bucket point per bucket with an "le" tag (similar to
+Prometheus histograms).
+*/
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/influxdb/proto"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+	stringFieldEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, "\n", `\n`)
+)
+
+// Surfacer implements an InfluxDB surfacer.
+type Surfacer struct {
+	c          *configpb.SurfacerConf
+	opts       *options.Options
+	writeURL   string
+	authHeader string
+	httpClient *http.Client
+	writeChan  chan *metrics.EventMetrics
+	l          *logger.Logger
+
+	batch []string
+}
+
+func writeURL(c *configpb.SurfacerConf) (string, string, error) {
+	baseURL := strings.TrimSuffix(c.GetUrl(), "/")
+	params := url.Values{}
+	params.Set("precision", "ms")
+
+	switch c.GetApiVersion() {
+	case configpb.SurfacerConf_V2:
+		if c.GetBucket() == "" {
+			return "", "", fmt.Errorf("bucket is required for InfluxDB v2 API")
+		}
+		params.Set("bucket", c.GetBucket())
+		if c.GetOrg() != "" {
+			params.Set("org", c.GetOrg())
+		}
+		token := c.GetToken()
+		if token == "" {
+			token = os.Getenv("INFLUXDB_TOKEN")
+		}
+		var authHeader string
+		if token != "" {
+			authHeader = "Token " + token
+		}
+		return baseURL + "/api/v2/write?" + params.Encode(), authHeader, nil
+
+	case configpb.SurfacerConf_V1:
+		if c.GetDatabase() == "" {
+			return "", "", fmt.Errorf("database is required for InfluxDB v1 API")
+		}
+		params.Set("db", c.GetDatabase())
+		if c.GetRetentionPolicy() != "" {
+			params.Set("rp", c.GetRetentionPolicy())
+		}
+		var authHeader string
+		if c.GetUsername() != "" {
+			password := c.GetPassword()
+			if password == "" {
+				password = os.Getenv("INFLUXDB_PASSWORD")
+			}
+			req := &http.Request{Header: make(http.Header)}
+			req.SetBasicAuth(c.GetUsername(), password)
+			authHeader = req.Header.Get("Authorization")
+		}
+		return baseURL + "/write?" + params.Encode(), authHeader, nil
+	}
+
+	return "", "", fmt.Errorf("unknown API version: %v", c.GetApiVersion())
+}
+
+// New creates a new instance of the InfluxDB surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetUrl() == "" {
+		return nil, fmt.Errorf("influxdb: url is required")
+	}
+	if config.GetMetricsBatchSize() <= 0 {
+		return nil, fmt.Errorf("influxdb: invalid metrics_batch_size: %d", config.GetMetricsBatchSize())
+	}
+
+	wURL, authHeader, err := writeURL(config)
+	if err != nil {
+		return nil, fmt.Errorf("influxdb: %v", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("influxdb: error parsing TLS config: %v", err)
+		}
+	}
+
+	s := &Surfacer{
+		c:          config,
+		opts:       opts,
+		writeURL:   wURL,
+		authHeader: authHeader,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(config.GetRequestTimeoutSec()) * time.Second,
+		},
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]string, 0, config.GetMetricsBatchSize()),
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			for _, line := range s.lines(em) {
+				if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+					s.flush(ctx)
+					batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+				}
+				s.batch = append(s.batch, line)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.writeLines(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error writing %d points to InfluxDB: %v", len(s.batch), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+func (s *Surfacer) writeLines(ctx context.Context, lines []string) error {
+	body := []byte(strings.Join(lines, "\n"))
+
+	if !s.c.GetDisableCompression() {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if !s.c.GetDisableCompression() {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if s.authHeader != "" {
+		req.Header.Set("Authorization", s.authHeader)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP status: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+// line formats a single line protocol point. tags should already be
+// formatted and escaped.
+func line(measurement, tags, field string, ts int64, extraTags ...string) string {
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(measurement))
+	b.WriteString(tags)
+	for i := 0; i+1 < len(extraTags); i += 2 {
+		if extraTags[i+1] == "" {
+			continue
+		}
+		b.WriteString("," + tagEscaper.Replace(extraTags[i]) + "=" + tagEscaper.Replace(extraTags[i+1]))
+	}
+	b.WriteString(" value=" + field + " " + strconv.FormatInt(ts, 10))
+	return b.String()
+}
+
+func fieldValue(v metrics.NumValue) string {
+	if _, ok := v.(*metrics.Int); ok {
+		return strconv.FormatInt(v.Int64(), 10) + "i"
+	}
+	return strconv.FormatFloat(v.Float64(), 'f', -1, 64)
+}
+
+func mapLines[T int64 | float64](m *metrics.Map[T], measurement, tags string, ts int64) []string {
+	var out []string
+	for _, k := range m.Keys() {
+		var field string
+		switch v := any(m.GetKey(k)).(type) {
+		case int64:
+			field = strconv.FormatInt(v, 10) + "i"
+		case float64:
+			field = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		out = append(out, line(measurement, tags, field, ts, m.MapName, k))
+	}
+	return out
+}
+
+// lines converts an EventMetrics into line protocol points.
+func (s *Surfacer) lines(em *metrics.EventMetrics) []string {
+	ts := em.Timestamp.UnixMilli()
+
+	var tags strings.Builder
+	for _, k := range em.LabelsKeys() {
+		// InfluxDB doesn't accept empty tag values.
+		if v := em.Label(k); v != "" {
+			tags.WriteString("," + tagEscaper.Replace(k) + "=" + tagEscaper.Replace(v))
+		}
+	}
+
+	var out []string
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+		measurement := s.c.GetMetricsPrefix() + metricKey
+
+		switch v := em.Metric(metricKey).(type) {
+		case metrics.NumValue:
+			out = append(out, line(measurement, tags.String(), fieldValue(v), ts))
+		case *metrics.Map[int64]:
+			out = append(out, mapLines(v, measurement, tags.String(), ts)...)
+		case *metrics.Map[float64]:
+			out = append(out, mapLines(v, measurement, tags.String(), ts)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, line(measurement+"_sum", tags.String(), strconv.FormatFloat(d.Sum, 'f', -1, 64), ts))
+			out = append(out, line(measurement+"_count", tags.String(), strconv.FormatInt(d.Count, 10)+"i", ts))
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "+Inf"
+				if i < len(d.LowerBounds)-1 {
+					le = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
+				}
+				out = append(out, line(measurement+"_bucket", tags.String(), strconv.FormatInt(cumCount, 10)+"i", ts, "le", le))
+			}
+		case metrics.String:
+			out = append(out, line(measurement, tags.String(), `"`+stringFieldEscaper.Replace(strings.TrimSuffix(strings.TrimPrefix(v.String(), `"`), `"`))+`"`, ts))
+		default:
+			s.l.Warningf("Unsupported value type (%T) for metric: %s", v, metricKey)
+		}
+	}
+	return out
+}
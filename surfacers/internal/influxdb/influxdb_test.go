@@ -0,0 +1,191 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/influxdb/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWriteURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       *configpb.SurfacerConf
+		env        map[string]string
+		wantURL    string
+		wantHeader string
+		wantErr    bool
+	}{
+		{
+			name: "v2",
+			conf: &configpb.SurfacerConf{
+				Url:    proto.String("http://influxdb:8086/"),
+				Org:    proto.String("my-org"),
+				Bucket: proto.String("probes"),
+				Token:  proto.String("t1"),
+			},
+			wantURL:    "http://influxdb:8086/api/v2/write?bucket=probes&org=my-org&precision=ms",
+			wantHeader: "Token t1",
+		},
+		{
+			name: "v2_token_from_env",
+			conf: &configpb.SurfacerConf{
+				Url:    proto.String("http://influxdb:8086"),
+				Bucket: proto.String("probes"),
+			},
+			env:        map[string]string{"INFLUXDB_TOKEN": "t2"},
+			wantURL:    "http://influxdb:8086/api/v2/write?bucket=probes&precision=ms",
+			wantHeader: "Token t2",
+		},
+		{
+			name:    "v2_no_bucket",
+			conf:    &configpb.SurfacerConf{Url: proto.String("http://influxdb:8086")},
+			wantErr: true,
+		},
+		{
+			name: "v1",
+			conf: &configpb.SurfacerConf{
+				Url:             proto.String("http://influxdb:8086"),
+				ApiVersion:      configpb.SurfacerConf_V1.Enum(),
+				Database:        proto.String("probes"),
+				RetentionPolicy: proto.String("1w"),
+				Username:        proto.String("user"),
+				Password:        proto.String("pass"),
+			},
+			wantURL:    "http://influxdb:8086/write?db=probes&precision=ms&rp=1w",
+			wantHeader: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "v1_no_database",
+			conf: &configpb.SurfacerConf{
+				Url:        proto.String("http://influxdb:8086"),
+				ApiVersion: configpb.SurfacerConf_V1.Enum(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			gotURL, gotHeader, err := writeURL(test.conf)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("writeURL() error = %v, wantErr %v", err, test.wantErr)
+			}
+			assert.Equal(t, test.wantURL, gotURL)
+			assert.Equal(t, test.wantHeader, gotHeader)
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(2)
+	d.AddSample(20)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("latency", d).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("ratio", metrics.NewFloat(0.5)).
+		AddMetric("version", metrics.NewString("v1 \"beta\"")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "my probe").
+		AddLabel("empty", "")
+
+	s := &Surfacer{
+		c:    &configpb.SurfacerConf{},
+		opts: &options.Options{},
+		l:    &logger.Logger{},
+	}
+
+	tags := `,ptype=http,probe=my\ probe`
+	want := []string{
+		"cloudprober_total" + tags + " value=10i 1700000000000",
+		"cloudprober_latency_sum" + tags + " value=22 1700000000000",
+		"cloudprober_latency_count" + tags + " value=2i 1700000000000",
+		"cloudprober_latency_bucket" + tags + ",le=1 value=0i 1700000000000",
+		"cloudprober_latency_bucket" + tags + ",le=10 value=1i 1700000000000",
+		"cloudprober_latency_bucket" + tags + ",le=+Inf value=2i 1700000000000",
+		"cloudprober_resp-code" + tags + ",code=200 value=4i 1700000000000",
+		"cloudprober_ratio" + tags + " value=0.5 1700000000000",
+		"cloudprober_version" + tags + ` value="v1 \"beta\"" 1700000000000`,
+	}
+	assert.Equal(t, want, s.lines(em))
+}
+
+func TestSurfacer(t *testing.T) {
+	received := make(chan string, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token t1", r.Header.Get("Authorization"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("error creating gzip reader: %v", err)
+			return
+		}
+		b, _ := io.ReadAll(gz)
+		received <- string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Url:              proto.String(ts.URL),
+		Bucket:           proto.String("probes"),
+		Token:            proto.String("t1"),
+		MetricsBatchSize: proto.Int32(2),
+	}, &options.Options{MetricsBufferSize: 10}, nil)
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	em := metrics.NewEventMetrics(time.Unix(1700000000, 0)).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("success", metrics.NewInt(9)).
+		AddMetric("failure", metrics.NewInt(1)).
+		AddLabel("probe", "p1")
+	s.Write(ctx, em)
+
+	// Batch size is 2, so first 2 points should be sent right away.
+	select {
+	case body := <-received:
+		assert.Equal(t, []string{
+			"cloudprober_total,probe=p1 value=10i 1700000000000",
+			"cloudprober_success,probe=p1 value=9i 1700000000000",
+		}, strings.Split(body, "\n"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for write request")
+	}
+}
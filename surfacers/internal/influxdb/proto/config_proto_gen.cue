@@ -0,0 +1,59 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Surfacer config for InfluxDB surfacer. Metrics are written using the
+// InfluxDB line protocol, with metric name as the measurement name, metric
+// labels as tags, and metric value in the "value" field.
+#SurfacerConf: {
+	// InfluxDB server URL, e.g. "http://influxdb:8086".
+	url?: string @protobuf(1,string)
+
+	#APIVersion: {"V2", #enumValue: 0} |
+		{"V1", #enumValue: 1}
+
+	#APIVersion_value: {
+		V2: 0
+		V1: 1
+	}
+
+	// InfluxDB HTTP API version. V2 API is supported by InfluxDB 2.x and 3.x,
+	// and by InfluxDB 1.8+ through its compatibility endpoints.
+	apiVersion?: #APIVersion @protobuf(2,APIVersion,name=api_version,"default=V2")
+
+	// Organization and bucket (V2 API).
+	org?:    string @protobuf(3,string)
+	bucket?: string @protobuf(4,string)
+
+	// API token (V2 API). If not set, INFLUXDB_TOKEN environment variable is
+	// used.
+	token?: string @protobuf(5,string)
+
+	// Database and retention policy (V1 API).
+	database?:        string @protobuf(6,string)
+	retentionPolicy?: string @protobuf(7,string,name=retention_policy)
+
+	// Username and password (V1 API). If password is not set, INFLUXDB_PASSWORD
+	// environment variable is used.
+	username?:  string           @protobuf(8,string)
+	password?:  string           @protobuf(9,string)
+	tlsConfig?: proto.#TLSConfig @protobuf(10,tlsconfig.TLSConfig,name=tls_config)
+
+	// Prefix to add to all measurement names.
+	metricsPrefix?: string @protobuf(11,string,name=metrics_prefix,#"default="cloudprober_""#)
+
+	// Maximum number of points to send in one write request. Points are sent
+	// when the batch is full or when the batch timer expires, whichever happens
+	// first.
+	metricsBatchSize?: int32 @protobuf(12,int32,name=metrics_batch_size,"default=1000")
+
+	// Maximum time to hold points in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(13,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for each write request.
+	requestTimeoutSec?: int32 @protobuf(14,int32,name=request_timeout_sec,"default=10")
+
+	// Disable gzip compression of the write requests. Compression is enabled by
+	// default.
+	disableCompression?: bool @protobuf(15,bool,name=disable_compression)
+}
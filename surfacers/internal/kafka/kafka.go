@@ -0,0 +1,224 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package kafka implements a surfacer that publishes EventMetrics to a Kafka
+topic, so that downstream pipelines can consume probe results as an event
+stream.
+
+Messages are encoded either as JSON or as Avro (Confluent wire format, with
+the schema registered in the schema registry). Message key is built from the
+EventMetrics labels (probe and dst by default), so that results for a
+probe-target pair always go to the same partition.
+*/
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/kafka/proto"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+var defaultKeyLabels = []string{"probe", "dst"}
+
+var kafkaCompression = map[configpb.SurfacerConf_Compression]kafka.Compression{
+	configpb.SurfacerConf_GZIP:   kafka.Gzip,
+	configpb.SurfacerConf_SNAPPY: kafka.Snappy,
+	configpb.SurfacerConf_LZ4:    kafka.Lz4,
+	configpb.SurfacerConf_ZSTD:   kafka.Zstd,
+}
+
+// messageWriter is implemented by kafka.Writer. It's an interface for
+// testing.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Surfacer implements a Kafka surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	keyLabels []string
+	w         messageWriter
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	// Schema registry client and schema id, used only for AVRO format.
+	registryClient *http.Client
+	schemaID       int32
+}
+
+func newWriter(c *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*kafka.Writer, error) {
+	transport := &kafka.Transport{}
+
+	if c.GetTlsConfig() != nil {
+		transport.TLS = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLS, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("error parsing TLS config: %v", err)
+		}
+	}
+
+	if c.GetSaslUsername() != "" {
+		password := c.GetSaslPassword()
+		if password == "" {
+			password = os.Getenv("KAFKA_SASL_PASSWORD")
+		}
+		transport.SASL = plain.Mechanism{
+			Username: c.GetSaslUsername(),
+			Password: password,
+		}
+	}
+
+	return &kafka.Writer{
+		Addr:  kafka.TCP(c.GetBroker()...),
+		Topic: c.GetTopic(),
+		// Hash balancer sends messages with the same key to the same partition.
+		Balancer:     &kafka.Hash{},
+		BatchSize:    int(c.GetBatchSize()),
+		BatchTimeout: time.Duration(c.GetBatchTimeoutMsec()) * time.Millisecond,
+		Compression:  kafkaCompression[c.GetCompression()],
+		RequiredAcks: kafka.RequireOne,
+		Transport:    transport,
+		// In async mode, WriteMessages doesn't wait for the batch to be sent.
+		// Errors are reported through the completion function.
+		Async: true,
+		Completion: func(msgs []kafka.Message, err error) {
+			// Messages carry their write time, see writeLoop.
+			if len(msgs) != 0 {
+				if start, ok := msgs[0].WriterData.(time.Time); ok {
+					opts.ReportPush(start, err)
+				}
+			}
+			if err != nil {
+				l.Errorf("Error publishing %d messages to Kafka: %v", len(msgs), err)
+			}
+		},
+	}, nil
+}
+
+// New creates a new instance of the Kafka surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if len(config.GetBroker()) == 0 {
+		return nil, fmt.Errorf("kafka: at least one broker is required")
+	}
+	if config.GetFormat() == configpb.SurfacerConf_AVRO && config.GetSchemaRegistryUrl() == "" {
+		return nil, fmt.Errorf("kafka: schema_registry_url is required for AVRO format")
+	}
+
+	w, err := newWriter(config, opts, l)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: %v", err)
+	}
+
+	s := &Surfacer{
+		c:              config,
+		opts:           opts,
+		keyLabels:      config.GetKeyLabel(),
+		w:              w,
+		writeChan:      make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:              l,
+		registryClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	if len(s.keyLabels) == 0 {
+		s.keyLabels = defaultKeyLabels
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for publishing.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			if err := s.w.Close(); err != nil {
+				s.l.Warningf("Error closing Kafka writer: %v", err)
+			}
+			return
+		case em := <-s.writeChan:
+			msg, err := s.kafkaMessage(ctx, em)
+			if err != nil {
+				s.l.Errorf("Error creating Kafka message: %v", err)
+				continue
+			}
+			msg.WriterData = time.Now()
+			if err := s.w.WriteMessages(ctx, msg); err != nil {
+				s.l.Errorf("Error publishing message to Kafka: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) messageKey(em *metrics.EventMetrics) []byte {
+	parts := make([]string, len(s.keyLabels))
+	for i, k := range s.keyLabels {
+		parts[i] = em.Label(k)
+	}
+	return []byte(strings.Join(parts, "/"))
+}
+
+func (s *Surfacer) kafkaMessage(ctx context.Context, em *metrics.EventMetrics) (kafka.Message, error) {
+	msg := newMessage(em, s.opts.AllowMetric)
+
+	var value []byte
+	switch s.c.GetFormat() {
+	case configpb.SurfacerConf_JSON:
+		b, err := msg.jsonEncode()
+		if err != nil {
+			return kafka.Message{}, err
+		}
+		value = b
+	case configpb.SurfacerConf_AVRO:
+		// Register schema lazily so that schema registry being unavailable at
+		// startup doesn't prevent cloudprober from starting.
+		if s.schemaID == 0 {
+			id, err := registerSchema(ctx, s.registryClient, s.c.GetSchemaRegistryUrl(), s.c.GetTopic()+"-value")
+			if err != nil {
+				return kafka.Message{}, fmt.Errorf("error registering Avro schema: %v", err)
+			}
+			s.schemaID = id
+		}
+		value = msg.avroEncode(s.schemaID)
+	}
+
+	return kafka.Message{
+		Key:   s.messageKey(em),
+		Value: value,
+		Time:  em.Timestamp,
+	}, nil
+}
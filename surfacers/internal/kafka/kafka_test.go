@@ -0,0 +1,230 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/kafka/proto"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type testWriter struct {
+	mu   sync.Mutex
+	msgs []kafka.Message
+}
+
+func (tw *testWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.msgs = append(tw.msgs, msgs...)
+	return nil
+}
+
+func (tw *testWriter) Close() error { return nil }
+
+func (tw *testWriter) messages() []kafka.Message {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return append([]kafka.Message{}, tw.msgs...)
+}
+
+func testEM(ts time.Time) *metrics.EventMetrics {
+	d := metrics.NewDistribution([]float64{1})
+	d.AddSample(2)
+
+	return metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1").
+		AddLabel("dst", "target1")
+}
+
+func TestNewMessage(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	em := testEM(ts)
+
+	allowAll := func(string) bool { return true }
+	want := &message{
+		TimestampMs: 1700000000000,
+		Kind:        "CUMULATIVE",
+		Labels:      map[string]string{"ptype": "http", "probe": "p1", "dst": "target1"},
+		Metrics: []metric{
+			{Name: "total", Value: 10},
+			{Name: "resp-code", Labels: map[string]string{"code": "200"}, Value: 4},
+			{Name: "latency_sum", Value: 2},
+			{Name: "latency_count", Value: 1},
+			{Name: "latency_bucket", Labels: map[string]string{"le": "1"}, Value: 0},
+			{Name: "latency_bucket", Labels: map[string]string{"le": "+Inf"}, Value: 1},
+			{Name: "version", Labels: map[string]string{"val": "v1"}, Value: 1},
+		},
+	}
+	assert.Equal(t, want, newMessage(em, allowAll))
+
+	// Filter metrics.
+	msg := newMessage(em, func(name string) bool { return name == "total" })
+	assert.Equal(t, []metric{{Name: "total", Value: 10}}, msg.Metrics)
+
+	b, err := msg.jsonEncode()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"timestamp_ms":1700000000000,"kind":"CUMULATIVE","labels":{"dst":"target1","probe":"p1","ptype":"http"},"metrics":[{"name":"total","value":10}]}`, string(b))
+}
+
+func TestAvroEncode(t *testing.T) {
+	msg := &message{
+		TimestampMs: 1700000000000,
+		Kind:        "GAUGE",
+		Labels:      map[string]string{"probe": "p1"},
+		Metrics: []metric{
+			{Name: "total", Value: 10},
+		},
+	}
+
+	b := msg.avroEncode(42)
+
+	// Header: magic byte and schema id.
+	assert.Equal(t, byte(0), b[0])
+	assert.Equal(t, uint32(42), binary.BigEndian.Uint32(b[1:5]))
+	b = b[5:]
+
+	readLong := func() int64 {
+		v, n := binary.Varint(b)
+		b = b[n:]
+		return v
+	}
+	readString := func() string {
+		l := readLong()
+		s := string(b[:l])
+		b = b[l:]
+		return s
+	}
+
+	assert.Equal(t, int64(1700000000000), readLong(), "timestamp_ms")
+	assert.Equal(t, "GAUGE", readString(), "kind")
+	assert.Equal(t, int64(1), readLong(), "labels block count")
+	assert.Equal(t, "probe", readString())
+	assert.Equal(t, "p1", readString())
+	assert.Equal(t, int64(0), readLong(), "labels end")
+	assert.Equal(t, int64(1), readLong(), "metrics block count")
+	assert.Equal(t, "total", readString())
+	assert.Equal(t, int64(0), readLong(), "metric labels (empty)")
+	assert.Equal(t, 10.0, math.Float64frombits(binary.LittleEndian.Uint64(b[:8])))
+	b = b[8:]
+	assert.Equal(t, int64(0), readLong(), "metrics end")
+	assert.Len(t, b, 0)
+}
+
+func TestRegisterSchema(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects/cloudprober-value/versions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		var req map[string]string
+		json.Unmarshal(b, &req)
+		assert.Equal(t, avroSchema, req["schema"])
+		w.Write([]byte(`{"id":7}`))
+	}))
+	defer ts.Close()
+
+	id, err := registerSchema(context.Background(), http.DefaultClient, ts.URL, "cloudprober-value")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), id)
+
+	_, err = registerSchema(context.Background(), http.DefaultClient, ts.URL, "other-value")
+	assert.Error(t, err)
+}
+
+func TestSurfacer(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *configpb.SurfacerConf
+		wantKey string
+	}{
+		{
+			name:    "default_key",
+			conf:    &configpb.SurfacerConf{},
+			wantKey: "p1/target1",
+		},
+		{
+			name:    "custom_key",
+			conf:    &configpb.SurfacerConf{KeyLabel: []string{"ptype", "probe"}},
+			wantKey: "http/p1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tw := &testWriter{}
+			s := &Surfacer{
+				c:         test.conf,
+				opts:      &options.Options{},
+				keyLabels: defaultKeyLabels,
+				w:         tw,
+				writeChan: make(chan *metrics.EventMetrics, 10),
+				l:         &logger.Logger{},
+			}
+			if len(test.conf.GetKeyLabel()) != 0 {
+				s.keyLabels = test.conf.GetKeyLabel()
+			}
+			go s.processMetrics(ctx)
+
+			ts := time.Unix(1700000000, 0)
+			s.Write(ctx, testEM(ts))
+
+			assert.Eventually(t, func() bool { return len(tw.messages()) == 1 }, time.Second, 10*time.Millisecond)
+			msg := tw.messages()[0]
+			assert.Equal(t, test.wantKey, string(msg.Key))
+			assert.True(t, ts.Equal(msg.Time))
+			assert.Contains(t, string(msg.Value), `"timestamp_ms":1700000000000`)
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{},
+		{Broker: []string{"localhost:9092"}, Format: configpb.SurfacerConf_AVRO.Enum()},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, nil)
+		assert.Error(t, err, "conf: %v", conf)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := New(ctx, &configpb.SurfacerConf{Broker: []string{"localhost:9092"}, Topic: proto.String("probes")}, &options.Options{}, nil)
+	assert.NoError(t, err)
+}
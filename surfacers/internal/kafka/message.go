@@ -0,0 +1,213 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// message is the data model for a Kafka message. Each message corresponds to
+// one EventMetrics. Metric values are flattened: map values get one entry per
+// map key (with map name as an extra label), distributions get _sum, _count
+// and _bucket entries (with "le" label for buckets), and string values get a
+// value of 1 with the string in the "val" label.
+type message struct {
+	TimestampMs int64             `json:"timestamp_ms"`
+	Kind        string            `json:"kind"`
+	Labels      map[string]string `json:"labels"`
+	Metrics     []metric          `json:"metrics"`
+}
+
+type metric struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+// avroSchema is the Avro schema corresponding to the message struct.
+const avroSchema = `{"type":"record","name":"EventMetrics","namespace":"org.cloudprober","fields":[` +
+	`{"name":"timestamp_ms","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+	`{"name":"kind","type":"string"},` +
+	`{"name":"labels","type":{"type":"map","values":"string"}},` +
+	`{"name":"metrics","type":{"type":"array","items":{"type":"record","name":"Metric","fields":[` +
+	`{"name":"name","type":"string"},` +
+	`{"name":"labels","type":{"type":"map","values":"string"}},` +
+	`{"name":"value","type":"double"}]}}}]}`
+
+func mapMetrics[T int64 | float64](m *metrics.Map[T], name string) []metric {
+	var out []metric
+	for _, k := range m.Keys() {
+		out = append(out, metric{Name: name, Labels: map[string]string{m.MapName: k}, Value: float64(m.GetKey(k))})
+	}
+	return out
+}
+
+// newMessage converts an EventMetrics into a message. allowMetric is used to
+// filter metrics by name.
+func newMessage(em *metrics.EventMetrics, allowMetric func(string) bool) *message {
+	msg := &message{
+		TimestampMs: em.Timestamp.UnixMilli(),
+		Kind:        "CUMULATIVE",
+		Labels:      make(map[string]string),
+	}
+	if em.Kind == metrics.GAUGE {
+		msg.Kind = "GAUGE"
+	}
+	for _, k := range em.LabelsKeys() {
+		msg.Labels[k] = em.Label(k)
+	}
+
+	for _, name := range em.MetricsKeys() {
+		if !allowMetric(name) {
+			continue
+		}
+
+		switch v := em.Metric(name).(type) {
+		case metrics.NumValue:
+			msg.Metrics = append(msg.Metrics, metric{Name: name, Value: v.Float64()})
+		case *metrics.Map[int64]:
+			msg.Metrics = append(msg.Metrics, mapMetrics(v, name)...)
+		case *metrics.Map[float64]:
+			msg.Metrics = append(msg.Metrics, mapMetrics(v, name)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			msg.Metrics = append(msg.Metrics, metric{Name: name + "_sum", Value: d.Sum})
+			msg.Metrics = append(msg.Metrics, metric{Name: name + "_count", Value: float64(d.Count)})
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "+Inf"
+				if i < len(d.LowerBounds)-1 {
+					le = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
+				}
+				msg.Metrics = append(msg.Metrics, metric{Name: name + "_bucket", Labels: map[string]string{"le": le}, Value: float64(cumCount)})
+			}
+		case metrics.String:
+			val := strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+			msg.Metrics = append(msg.Metrics, metric{Name: name, Labels: map[string]string{"val": val}, Value: 1})
+		}
+	}
+	return msg
+}
+
+func (msg *message) jsonEncode() ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+// Avro binary encoding helpers. See:
+// https://avro.apache.org/docs/1.11.1/specification/#binary-encoding
+func appendAvroLong(b []byte, v int64) []byte {
+	return binary.AppendVarint(b, v)
+}
+
+func appendAvroString(b []byte, s string) []byte {
+	b = appendAvroLong(b, int64(len(s)))
+	return append(b, s...)
+}
+
+func appendAvroDouble(b []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+}
+
+func appendAvroStringMap(b []byte, m map[string]string) []byte {
+	if len(m) == 0 {
+		return appendAvroLong(b, 0)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b = appendAvroLong(b, int64(len(keys)))
+	for _, k := range keys {
+		b = appendAvroString(b, k)
+		b = appendAvroString(b, m[k])
+	}
+	return appendAvroLong(b, 0)
+}
+
+// avroEncode encodes the message in Confluent's wire format: magic byte (0),
+// 4-byte big-endian schema id, and the Avro binary encoded message.
+func (msg *message) avroEncode(schemaID int32) []byte {
+	b := []byte{0}
+	b = binary.BigEndian.AppendUint32(b, uint32(schemaID))
+
+	b = appendAvroLong(b, msg.TimestampMs)
+	b = appendAvroString(b, msg.Kind)
+	b = appendAvroStringMap(b, msg.Labels)
+
+	if len(msg.Metrics) > 0 {
+		b = appendAvroLong(b, int64(len(msg.Metrics)))
+		for _, m := range msg.Metrics {
+			b = appendAvroString(b, m.Name)
+			b = appendAvroStringMap(b, m.Labels)
+			b = appendAvroDouble(b, m.Value)
+		}
+	}
+	return appendAvroLong(b, 0)
+}
+
+// registerSchema registers the Avro schema with the schema registry and
+// returns the schema id. Registering an already registered schema is a no-op
+// that returns the existing id.
+func registerSchema(ctx context.Context, client *http.Client, registryURL, subject string) (int32, error) {
+	reqBody, err := json.Marshal(map[string]string{"schema": avroSchema})
+	if err != nil {
+		return 0, err
+	}
+
+	u := strings.TrimSuffix(registryURL, "/") + "/subjects/" + url.PathEscape(subject) + "/versions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schema registry returned HTTP status %d: %s", resp.StatusCode, string(b))
+	}
+
+	var respData struct {
+		ID int32 `json:"id"`
+	}
+	if err := json.Unmarshal(b, &respData); err != nil {
+		return 0, fmt.Errorf("error parsing schema registry response (%s): %v", string(b), err)
+	}
+	return respData.ID, nil
+}
@@ -0,0 +1,68 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Surfacer config for Kafka surfacer. Kafka surfacer publishes each
+// EventMetrics as a message to a Kafka topic.
+#SurfacerConf: {
+	// Kafka brokers, e.g. "kafka-1:9092".
+	broker?: [...string] @protobuf(1,string)
+
+	// Kafka topic to publish to.
+	topic?: string @protobuf(2,string,#"default="cloudprober""#)
+
+	#Format: {
+		// JSON encoded message.
+		"JSON"
+		#enumValue: 0
+	} | {
+		// Avro binary encoded message, in Confluent's wire format (magic byte and
+		// schema id followed by Avro payload). Schema is registered with the
+		// schema registry (under "<topic>-value" subject) before publishing the
+		// first message.
+		"AVRO"
+		#enumValue: 1
+	}
+
+	#Format_value: {
+		JSON: 0
+		AVRO: 1
+	}
+	format?: #Format @protobuf(3,Format,"default=JSON")
+
+	// Schema registry URL, e.g. "http://schema-registry:8081". Required for
+	// AVRO format.
+	schemaRegistryUrl?: string @protobuf(4,string,name=schema_registry_url)
+
+	// Labels to build message key from. Label values are joined using "/".
+	// Messages with the same key go to the same partition, so keying on probe
+	// and target preserves ordering for each probe-target pair. If not
+	// specified, "probe" and "dst" labels are used.
+	keyLabel?: [...string] @protobuf(5,string,name=key_label)
+
+	#Compression: {"NONE", #enumValue: 0} |
+		{"GZIP", #enumValue: 1} |
+		{"SNAPPY", #enumValue: 2} |
+		{"LZ4", #enumValue: 3} |
+		{"ZSTD", #enumValue: 4}
+
+	#Compression_value: {
+		NONE:   0
+		GZIP:   1
+		SNAPPY: 2
+		LZ4:    3
+		ZSTD:   4
+	}
+	compression?: #Compression     @protobuf(6,Compression,"default=NONE")
+	tlsConfig?:   proto.#TLSConfig @protobuf(7,tlsconfig.TLSConfig,name=tls_config)
+
+	// SASL PLAIN authentication. If sasl_password is not set,
+	// KAFKA_SASL_PASSWORD environment variable is used.
+	saslUsername?: string @protobuf(8,string,name=sasl_username)
+	saslPassword?: string @protobuf(9,string,name=sasl_password)
+
+	// Maximum number of messages in a batch and the maximum time to wait for
+	// a batch to fill up before sending it.
+	batchSize?:        int32 @protobuf(10,int32,name=batch_size,"default=100")
+	batchTimeoutMsec?: int32 @protobuf(11,int32,name=batch_timeout_msec,"default=1000")
+}
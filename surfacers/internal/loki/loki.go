@@ -0,0 +1,373 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package loki implements a surfacer that ships probe failure details to Grafana
+Loki.
+
+Instead of exporting metrics, this surfacer looks at the change in "total" and
+"success" metrics between successive EventMetrics for the same probe and
+target, and for every interval that contains failures, it sends a structured
+(JSON) log line to Loki. Log lines include the number of failures, an error
+string, validation failures and a response snippet (if available), so that
+failures can be inspected in Grafana next to the latency metrics.
+
+The error string is taken from the "error" string metric, if a probe provides
+one (e.g. external probes); otherwise, it's derived from the metrics:
+"timeout", "validation failed" or "probe failed".
+*/
+package loki
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/loki/proto"
+)
+
+var defaultStreamLabels = []string{"probe", "dst", "ptype"}
+
+// failureRecord is the structured log line sent to Loki.
+type failureRecord struct {
+	Labels             map[string]string `json:"labels,omitempty"`
+	Probe              string            `json:"probe"`
+	Dst                string            `json:"dst"`
+	Total              int64             `json:"total"`
+	Failures           int64             `json:"failures"`
+	Error              string            `json:"error"`
+	ValidationFailures map[string]int64  `json:"validation_failures,omitempty"`
+	Response           string            `json:"response,omitempty"`
+}
+
+type entry struct {
+	labels map[string]string
+	ts     time.Time
+	line   string
+}
+
+// Surfacer implements a Loki surfacer.
+type Surfacer struct {
+	c            *configpb.SurfacerConf
+	opts         *options.Options
+	streamLabels []string
+	authHeader   string
+	httpClient   *http.Client
+	writeChan    chan *metrics.EventMetrics
+	l            *logger.Logger
+
+	// Last EventMetrics for each probe and target, used to compute the change
+	// in metrics.
+	lvCache map[string]*metrics.EventMetrics
+	batch   []entry
+}
+
+// New creates a new instance of the Loki surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetBatchSize() <= 0 {
+		return nil, fmt.Errorf("loki: invalid batch_size: %d", config.GetBatchSize())
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("loki: error parsing TLS config: %v", err)
+		}
+	}
+
+	s := &Surfacer{
+		c:            config,
+		opts:         opts,
+		streamLabels: defaultStreamLabels,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(config.GetRequestTimeoutSec()) * time.Second,
+		},
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		lvCache:   make(map[string]*metrics.EventMetrics),
+		batch:     make([]entry, 0, config.GetBatchSize()),
+	}
+	if len(config.GetStreamLabel()) != 0 {
+		s.streamLabels = config.GetStreamLabel()
+	}
+
+	if config.GetUsername() != "" {
+		password := config.GetPassword()
+		if password == "" {
+			password = os.Getenv("LOKI_PASSWORD")
+		}
+		req := &http.Request{Header: make(http.Header)}
+		req.SetBasicAuth(config.GetUsername(), password)
+		s.authHeader = req.Header.Get("Authorization")
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			e, ok := s.failureEntry(em)
+			if !ok {
+				continue
+			}
+			s.batch = append(s.batch, e)
+			if len(s.batch) >= int(s.c.GetBatchSize()) {
+				s.flush(ctx)
+				batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.push(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error pushing %d log lines to Loki: %v", len(s.batch), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+func int64Metric(em *metrics.EventMetrics, name string) int64 {
+	if v, ok := em.Metric(name).(metrics.NumValue); ok {
+		return v.Int64()
+	}
+	return 0
+}
+
+func mapMetric(em *metrics.EventMetrics, name string) *metrics.Map[int64] {
+	m, _ := em.Metric(name).(*metrics.Map[int64])
+	return m
+}
+
+// truncate truncates s to at most n bytes, without splitting a UTF-8
+// character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// failureEntry returns a log entry for the given EventMetrics if it contains
+// new failures.
+func (s *Surfacer) failureEntry(em *metrics.EventMetrics) (entry, bool) {
+	if em.Metric("total") == nil || em.Metric("success") == nil {
+		return entry{}, false
+	}
+
+	// Work only with the counters we need. This also keeps non-counter metrics
+	// (e.g. strings) out of CumulativeToGauge.
+	cem := metrics.NewEventMetrics(em.Timestamp)
+	cem.Kind = em.Kind
+	for _, k := range em.LabelsKeys() {
+		cem.AddLabel(k, em.Label(k))
+	}
+	for _, name := range []string{"total", "success", "timeouts", "validation_failure", "resp-body"} {
+		if v := em.Metric(name); v != nil {
+			cem.AddMetric(name, v)
+		}
+	}
+
+	if cem.Kind == metrics.CUMULATIVE {
+		// For the first EventMetrics, CumulativeToGauge returns it as it is.
+		// That's alright as probes start with zero counters.
+		gaugeEM, err := transform.CumulativeToGauge(cem, s.lvCache, s.l)
+		if err != nil {
+			s.l.Warningf("Error computing the change in metrics: %v", err)
+			return entry{}, false
+		}
+		cem = gaugeEM
+	}
+
+	total, success := int64Metric(cem, "total"), int64Metric(cem, "success")
+	if total-success <= 0 {
+		return entry{}, false
+	}
+
+	rec := &failureRecord{
+		Probe:    em.Label("probe"),
+		Dst:      em.Label("dst"),
+		Total:    total,
+		Failures: total - success,
+	}
+
+	if m := mapMetric(cem, "validation_failure"); m != nil {
+		for _, k := range m.Keys() {
+			if v := m.GetKey(k); v > 0 {
+				if rec.ValidationFailures == nil {
+					rec.ValidationFailures = make(map[string]int64)
+				}
+				rec.ValidationFailures[k] = v
+			}
+		}
+	}
+
+	if m := mapMetric(cem, "resp-body"); m != nil {
+		for _, k := range m.Keys() {
+			if m.GetKey(k) > 0 {
+				rec.Response = truncate(k, int(s.c.GetMaxSnippetLength()))
+				break
+			}
+		}
+	}
+
+	switch {
+	case em.Metric("error") != nil:
+		rec.Error = strings.TrimSuffix(strings.TrimPrefix(em.Metric("error").String(), "\""), "\"")
+	case int64Metric(cem, "timeouts") > 0:
+		rec.Error = "timeout"
+	case len(rec.ValidationFailures) > 0:
+		rec.Error = "validation failed"
+	default:
+		rec.Error = "probe failed"
+	}
+
+	labels := make(map[string]string)
+	for k, v := range s.c.GetStaticLabel() {
+		labels[k] = v
+	}
+	isStreamLabel := make(map[string]bool)
+	for _, k := range s.streamLabels {
+		isStreamLabel[k] = true
+		if v := em.Label(k); v != "" {
+			labels[k] = v
+		}
+	}
+	for _, k := range em.LabelsKeys() {
+		if !isStreamLabel[k] && k != "probe" && k != "dst" {
+			if rec.Labels == nil {
+				rec.Labels = make(map[string]string)
+			}
+			rec.Labels[k] = em.Label(k)
+		}
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		s.l.Warningf("Error marshaling failure record: %v", err)
+		return entry{}, false
+	}
+
+	return entry{labels: labels, ts: em.Timestamp, line: string(b)}, true
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// pushRequest groups log entries into streams, as expected by Loki's push
+// API.
+func pushRequest(entries []entry) map[string][]*stream {
+	var streams []*stream
+	byKey := make(map[string]*stream)
+
+	for _, e := range entries {
+		keys := make([]string, 0, len(e.labels))
+		for k := range e.labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var key strings.Builder
+		for _, k := range keys {
+			key.WriteString(k + "=" + e.labels[k] + ",")
+		}
+
+		st := byKey[key.String()]
+		if st == nil {
+			st = &stream{Stream: e.labels}
+			byKey[key.String()] = st
+			streams = append(streams, st)
+		}
+		st.Values = append(st.Values, [2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line})
+	}
+
+	return map[string][]*stream{"streams": streams}
+}
+
+func (s *Surfacer) push(ctx context.Context, entries []entry) error {
+	body, err := json.Marshal(pushRequest(entries))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.c.GetUrl(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.c.GetTenantId() != "" {
+		req.Header.Set("X-Scope-OrgID", s.c.GetTenantId())
+	}
+	if s.authHeader != "" {
+		req.Header.Set("Authorization", s.authHeader)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP status: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/loki/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM(ts time.Time, total, success, timeouts, vf int64, body string) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("success", metrics.NewInt(success)).
+		AddMetric("timeouts", metrics.NewInt(timeouts)).
+		AddMetric("validation_failure", metrics.NewMap("validator").IncKeyBy("data-integrity", vf)).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t1").
+		AddLabel("zone", "us-east1")
+	respBody := metrics.NewMap("resp")
+	if body != "" {
+		respBody.IncKey(body)
+	}
+	return em.AddMetric("resp-body", respBody)
+}
+
+func testSurfacer(conf *configpb.SurfacerConf) *Surfacer {
+	s := &Surfacer{
+		c:            conf,
+		opts:         &options.Options{},
+		streamLabels: defaultStreamLabels,
+		l:            &logger.Logger{},
+		lvCache:      make(map[string]*metrics.EventMetrics),
+	}
+	if len(conf.GetStreamLabel()) != 0 {
+		s.streamLabels = conf.GetStreamLabel()
+	}
+	return s
+}
+
+func TestFailureEntry(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	s := testSurfacer(&configpb.SurfacerConf{
+		StaticLabel:      map[string]string{"job": "cloudprober"},
+		MaxSnippetLength: proto.Int32(5),
+	})
+
+	wantLabels := map[string]string{"job": "cloudprober", "probe": "p1", "dst": "t1", "ptype": "http"}
+
+	// First EventMetrics: 1 timeout.
+	e, ok := s.failureEntry(testEM(ts, 2, 1, 1, 0, ""))
+	assert.True(t, ok)
+	assert.Equal(t, wantLabels, e.labels)
+	assert.JSONEq(t, `{"labels":{"zone":"us-east1"},"probe":"p1","dst":"t1","total":2,"failures":1,"error":"timeout"}`, e.line)
+
+	// No new failures.
+	_, ok = s.failureEntry(testEM(ts.Add(time.Second), 3, 2, 1, 0, ""))
+	assert.False(t, ok)
+
+	// 2 new validation failures, with response snippet.
+	e, ok = s.failureEntry(testEM(ts.Add(2*time.Second), 5, 2, 1, 2, "<html>body</html>"))
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"labels":{"zone":"us-east1"},"probe":"p1","dst":"t1","total":2,"failures":2,"error":"validation failed","validation_failures":{"data-integrity":2},"response":"<html"}`, e.line)
+
+	// Error string provided by the probe.
+	em := testEM(ts.Add(3*time.Second), 6, 2, 1, 2, "").AddMetric("error", metrics.NewString("connection refused"))
+	e, ok = s.failureEntry(em)
+	assert.True(t, ok)
+	assert.Contains(t, e.line, `"error":"connection refused"`)
+}
+
+func TestFailureEntryStreamLabels(t *testing.T) {
+	s := testSurfacer(&configpb.SurfacerConf{StreamLabel: []string{"probe", "zone"}})
+
+	e, ok := s.failureEntry(testEM(time.Unix(1700000000, 0), 1, 0, 0, 0, ""))
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"probe": "p1", "zone": "us-east1"}, e.labels)
+	assert.JSONEq(t, `{"labels":{"ptype":"http"},"probe":"p1","dst":"t1","total":1,"failures":1,"error":"probe failed"}`, e.line)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	assert.Equal(t, "a", truncate("aéb", 2), "shouldn't split a multi-byte character")
+}
+
+func TestSurfacer(t *testing.T) {
+	reqs := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reqs <- r
+		bodies <- b
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Url:       proto.String(ts.URL + "/loki/api/v1/push"),
+		TenantId:  proto.String("team-a"),
+		Username:  proto.String("user"),
+		Password:  proto.String("pass"),
+		BatchSize: proto.Int32(1),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	s.Write(ctx, testEM(time.Unix(1700000000, 0), 1, 1, 0, 0, ""))
+	s.Write(ctx, testEM(time.Unix(1700000001, 0), 2, 1, 1, 0, ""))
+
+	select {
+	case r := <-reqs:
+		assert.Equal(t, "/loki/api/v1/push", r.URL.Path)
+		assert.Equal(t, "team-a", r.Header.Get("X-Scope-OrgID"))
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+
+		var req struct {
+			Streams []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"`
+			} `json:"streams"`
+		}
+		assert.NoError(t, json.Unmarshal(<-bodies, &req))
+		assert.Len(t, req.Streams, 1)
+		assert.Equal(t, map[string]string{"probe": "p1", "dst": "t1", "ptype": "http"}, req.Streams[0].Stream)
+		assert.Equal(t, "1700000001000000000", req.Streams[0].Values[0][0])
+		assert.Contains(t, req.Streams[0].Values[0][1], `"error":"timeout"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for push request")
+	}
+}
+
+func TestPushRequest(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	l1 := map[string]string{"probe": "p1"}
+	l2 := map[string]string{"probe": "p2"}
+
+	req := pushRequest([]entry{
+		{labels: l1, ts: ts, line: "a"},
+		{labels: l2, ts: ts, line: "b"},
+		{labels: map[string]string{"probe": "p1"}, ts: ts.Add(time.Second), line: "c"},
+	})
+
+	assert.Equal(t, map[string][]*stream{
+		"streams": {
+			{Stream: l1, Values: [][2]string{{"1700000000000000000", "a"}, {"1700000001000000000", "c"}}},
+			{Stream: l2, Values: [][2]string{{"1700000000000000000", "b"}}},
+		},
+	}, req)
+}
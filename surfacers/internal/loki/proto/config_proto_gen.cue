@@ -0,0 +1,52 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Surfacer config for Loki surfacer. This surfacer doesn't export metrics;
+// instead, it sends a structured (JSON) log line to Loki for every probe
+// result that contains failures, e.g.:
+//   {"probe":"homepage","dst":"www.google.com","total":1,"failures":1,
+//    "error":"validation failed","validation_failures":{"data-integrity":1},
+//    "response":"<html>..."}
+#SurfacerConf: {
+	// Loki push API URL.
+	url?: string @protobuf(1,string,#"default="http://localhost:3100/loki/api/v1/push""#)
+
+	// Tenant ID, sent in the X-Scope-OrgID header. Required only for
+	// multi-tenant Loki deployments.
+	tenantId?: string @protobuf(2,string,name=tenant_id)
+
+	// Username and password for basic authentication. If password is not set,
+	// LOKI_PASSWORD environment variable is used.
+	username?: string @protobuf(3,string)
+	password?: string @protobuf(4,string)
+
+	tlsConfig?: proto.#TLSConfig @protobuf(5,tlsconfig.TLSConfig,name=tls_config)
+
+	// EventMetrics labels to use as Loki stream labels. All other labels are
+	// included in the log line itself. Keep in mind that Loki performs best
+	// with a small number of low-cardinality stream labels.
+	// Default: probe, dst, ptype.
+	streamLabel?: [...string] @protobuf(6,string,name=stream_label)
+
+	// Static labels to add to all streams, e.g. job: "cloudprober".
+	staticLabel?: {
+		[string]: string
+	} @protobuf(7,map[string]string,static_label)
+
+	// Maximum length of the response snippet included in the log line. Response
+	// snippets are available only for probes that export response bodies as
+	// metrics, e.g. HTTP probe with export_response_as_metrics set.
+	maxSnippetLength?: int32 @protobuf(8,int32,name=max_snippet_length,"default=256")
+
+	// Maximum number of log lines to send in one push request. Log lines are
+	// sent when the batch is full or when the batch timer expires, whichever
+	// happens first.
+	batchSize?: int32 @protobuf(9,int32,name=batch_size,"default=100")
+
+	// Maximum time to hold log lines in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(10,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for each push request.
+	requestTimeoutSec?: int32 @protobuf(11,int32,name=request_timeout_sec,"default=10")
+}
@@ -0,0 +1,290 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package mqtt implements a surfacer that publishes EventMetrics to an MQTT
+broker. It's meant for fleets of edge probers that already have an MQTT
+backbone, e.g. IoT devices.
+
+Topics are built from the EventMetrics labels using a template (default:
+cloudprober/@probe@/@dst@). Each EventMetrics is published either as a single
+JSON message on that topic, or as one message per metric, on a sub-topic
+named after the metric.
+*/
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/cloudprober/cloudprober/common/strtemplate"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
+)
+
+// topicReplacer replaces characters that have a special meaning in MQTT
+// topics.
+var topicReplacer = strings.NewReplacer("/", "_", "+", "_", "#", "_")
+
+type message struct {
+	TimestampMs int64             `json:"timestamp_ms"`
+	Kind        string            `json:"kind"`
+	Labels      map[string]string `json:"labels"`
+	Metrics     map[string]any    `json:"metrics"`
+}
+
+// Surfacer implements an MQTT surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	client paho.Client
+	// publish publishes a message. It's a field for testing.
+	publish func(topic string, payload []byte) error
+}
+
+func newClient(c *configpb.SurfacerConf, l *logger.Logger) (paho.Client, error) {
+	clientID := c.GetClientId()
+	if clientID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname for client id: %v", err)
+		}
+		clientID = "cloudprober-" + hostname
+	}
+
+	timeout := time.Duration(c.GetTimeoutSec()) * time.Second
+	opts := paho.NewClientOptions().
+		SetClientID(clientID).
+		SetKeepAlive(time.Duration(c.GetKeepaliveSec()) * time.Second).
+		SetConnectTimeout(timeout).
+		SetWriteTimeout(timeout).
+		// Keep trying to connect in the background, so that the broker being
+		// unavailable doesn't prevent cloudprober from starting.
+		SetConnectRetry(true).
+		SetAutoReconnect(true).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			l.Warningf("Lost connection to the MQTT broker: %v", err)
+		}).
+		SetOnConnectHandler(func(_ paho.Client) {
+			l.Infof("Connected to the MQTT broker")
+		})
+
+	for _, broker := range c.GetBroker() {
+		opts.AddBroker(broker)
+	}
+
+	if c.GetUsername() != "" {
+		password := c.GetPassword()
+		if password == "" {
+			password = os.Getenv("MQTT_PASSWORD")
+		}
+		opts.SetUsername(c.GetUsername()).SetPassword(password)
+	}
+
+	if c.GetTlsConfig() != nil {
+		tlsConfig := &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(tlsConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("error parsing TLS config: %v", err)
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	return paho.NewClient(opts), nil
+}
+
+// New creates a new instance of the MQTT surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if len(config.GetBroker()) == 0 {
+		return nil, fmt.Errorf("mqtt: at least one broker is required")
+	}
+	if config.GetQos() < 0 || config.GetQos() > 1 {
+		return nil, fmt.Errorf("mqtt: unsupported qos: %d, only 0 and 1 are supported", config.GetQos())
+	}
+
+	client, err := newClient(config, l)
+	if err != nil {
+		return nil, fmt.Errorf("mqtt: %v", err)
+	}
+	// With connect retry enabled, this returns right away and the client
+	// keeps connecting in the background.
+	client.Connect()
+
+	s := &Surfacer{
+		c:         config,
+		opts:      opts,
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		client:    client,
+	}
+	s.publish = s.clientPublish
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+func (s *Surfacer) clientPublish(topic string, payload []byte) error {
+	t := s.client.Publish(topic, byte(s.c.GetQos()), s.c.GetRetained(), payload)
+	if !t.WaitTimeout(time.Duration(s.c.GetTimeoutSec()) * time.Second) {
+		return fmt.Errorf("timed out publishing to %s", topic)
+	}
+	return t.Error()
+}
+
+// Write queues the incoming EventMetrics for publishing.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			if s.client != nil {
+				s.client.Disconnect(250)
+			}
+			return
+		case em := <-s.writeChan:
+			topic, err := s.topic(em)
+			if err != nil {
+				s.l.Warningf("Dropping EventMetrics: %v", err)
+				continue
+			}
+			for t, payload := range s.payloads(topic, em) {
+				start := time.Now()
+				err := s.publish(t, payload)
+				s.opts.ReportPush(start, err)
+				if err != nil {
+					s.l.Errorf("Error publishing to MQTT topic %s: %v", t, err)
+				}
+			}
+		}
+	}
+}
+
+// topic returns the topic for the EventMetrics, built from the topic
+// template.
+func (s *Surfacer) topic(em *metrics.EventMetrics) (string, error) {
+	labels := make(map[string]string, len(em.LabelsKeys()))
+	for _, k := range em.LabelsKeys() {
+		labels[k] = topicReplacer.Replace(em.Label(k))
+	}
+	topic, foundAll := strtemplate.SubstituteLabels(s.c.GetTopicTemplate(), labels)
+	if !foundAll {
+		return "", fmt.Errorf("missing labels for the topic template, topic: %s", topic)
+	}
+	return topic, nil
+}
+
+func stringValue(v metrics.String) string {
+	return strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+}
+
+// payloads returns the messages to publish for an EventMetrics, keyed by
+// topic.
+func (s *Surfacer) payloads(topic string, em *metrics.EventMetrics) map[string][]byte {
+	if s.c.GetFormat() == configpb.SurfacerConf_VALUE {
+		return s.valuePayloads(topic, em)
+	}
+
+	msg := &message{
+		TimestampMs: em.Timestamp.UnixMilli(),
+		Kind:        "CUMULATIVE",
+		Labels:      make(map[string]string),
+		Metrics:     make(map[string]any),
+	}
+	if em.Kind == metrics.GAUGE {
+		msg.Kind = "GAUGE"
+	}
+	for _, k := range em.LabelsKeys() {
+		msg.Labels[k] = em.Label(k)
+	}
+	for _, name := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(name) {
+			continue
+		}
+		switch v := em.Metric(name).(type) {
+		case metrics.NumValue:
+			msg.Metrics[name] = v.Float64()
+		case *metrics.Map[int64]:
+			msg.Metrics[name] = mapValues(v)
+		case *metrics.Map[float64]:
+			msg.Metrics[name] = mapValues(v)
+		case *metrics.Distribution:
+			d := v.Data()
+			msg.Metrics[name] = map[string]any{"sum": d.Sum, "count": d.Count}
+		case metrics.String:
+			msg.Metrics[name] = stringValue(v)
+		}
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		s.l.Errorf("Error encoding EventMetrics as JSON: %v", err)
+		return nil
+	}
+	return map[string][]byte{topic: b}
+}
+
+func mapValues[T int64 | float64](m *metrics.Map[T]) map[string]T {
+	out := make(map[string]T, len(m.Keys()))
+	for _, k := range m.Keys() {
+		out[k] = m.GetKey(k)
+	}
+	return out
+}
+
+func (s *Surfacer) valuePayloads(topic string, em *metrics.EventMetrics) map[string][]byte {
+	out := make(map[string][]byte)
+	for _, name := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(name) {
+			continue
+		}
+		metricTopic := topic + "/" + topicReplacer.Replace(name)
+
+		switch v := em.Metric(name).(type) {
+		case metrics.NumValue:
+			out[metricTopic] = []byte(v.String())
+		case *metrics.Map[int64]:
+			for _, k := range v.Keys() {
+				out[metricTopic+"/"+topicReplacer.Replace(k)] = []byte(fmt.Sprint(v.GetKey(k)))
+			}
+		case *metrics.Map[float64]:
+			for _, k := range v.Keys() {
+				out[metricTopic+"/"+topicReplacer.Replace(k)] = []byte(fmt.Sprint(v.GetKey(k)))
+			}
+		case metrics.String:
+			out[metricTopic] = []byte(stringValue(v))
+		}
+	}
+	return out
+}
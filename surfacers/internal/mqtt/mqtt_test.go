@@ -0,0 +1,148 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM(ts time.Time, probe string) *metrics.EventMetrics {
+	d := metrics.NewDistribution([]float64{1})
+	d.AddSample(2)
+
+	return metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", probe).
+		AddLabel("dst", "10.0.0.1/32")
+}
+
+func TestTopic(t *testing.T) {
+	s := &Surfacer{c: &configpb.SurfacerConf{}}
+
+	topic, err := s.topic(testEM(time.Now(), "p1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudprober/p1/10.0.0.1_32", topic)
+
+	s.c.TopicTemplate = proto.String("fleet/@region@/@probe@")
+	_, err = s.topic(testEM(time.Now(), "p1"))
+	assert.Error(t, err, "missing region label")
+}
+
+func TestPayloads(t *testing.T) {
+	em := testEM(time.Unix(1700000000, 0), "p1")
+
+	tests := []struct {
+		name   string
+		format configpb.SurfacerConf_Format
+		want   map[string]string
+	}{
+		{
+			name:   "json",
+			format: configpb.SurfacerConf_JSON,
+			want: map[string]string{
+				"t": `{"timestamp_ms":1700000000000,"kind":"CUMULATIVE","labels":{"dst":"10.0.0.1/32","probe":"p1"},"metrics":{"latency":{"count":1,"sum":2},"resp-code":{"200":4},"total":10,"version":"v1"}}`,
+			},
+		},
+		{
+			name:   "value",
+			format: configpb.SurfacerConf_VALUE,
+			want: map[string]string{
+				"t/total":         "10",
+				"t/resp-code/200": "4",
+				"t/version":       "v1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Surfacer{
+				c:    &configpb.SurfacerConf{Format: test.format.Enum()},
+				opts: &options.Options{},
+				l:    &logger.Logger{},
+			}
+			got := make(map[string]string)
+			for topic, payload := range s.payloads("t", em) {
+				got[topic] = string(payload)
+			}
+			if test.format == configpb.SurfacerConf_JSON {
+				assert.JSONEq(t, test.want["t"], got["t"])
+				return
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSurfacer(t *testing.T) {
+	var mu sync.Mutex
+	published := make(map[string]string)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &Surfacer{
+		c:         &configpb.SurfacerConf{Format: configpb.SurfacerConf_VALUE.Enum()},
+		opts:      &options.Options{},
+		writeChan: make(chan *metrics.EventMetrics, 10),
+		l:         &logger.Logger{},
+		publish: func(topic string, payload []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			published[topic] = string(payload)
+			return nil
+		},
+	}
+	go s.processMetrics(ctx)
+
+	s.Write(ctx, testEM(time.Now(), "p1"))
+	s.Write(ctx, testEM(time.Now(), "p2"))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(published) == 6
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "10", published["cloudprober/p1/10.0.0.1_32/total"])
+	assert.Equal(t, "v1", published["cloudprober/p2/10.0.0.1_32/version"])
+}
+
+func TestNewErrors(t *testing.T) {
+	for name, conf := range map[string]*configpb.SurfacerConf{
+		"no_broker": {},
+		"bad_qos":   {Broker: []string{"tcp://localhost:1883"}, Qos: proto.Int32(2)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(context.Background(), conf, &options.Options{}, &logger.Logger{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Surfacer config for MQTT surfacer. MQTT surfacer publishes EventMetrics to
+// an MQTT broker, on topics built from the EventMetrics labels.
+#SurfacerConf: {
+	// MQTT broker URLs, e.g. "tcp://mqtt.example.com:1883" or
+	// "ssl://mqtt.example.com:8883". If more than one broker is specified,
+	// they are tried in order.
+	broker?: [...string] @protobuf(1,string)
+
+	// MQTT client id. Client ids should be unique per broker, so if you run
+	// many probers against the same broker, make sure to set it differently
+	// for each of them. Default is "cloudprober-<hostname>".
+	clientId?: string @protobuf(2,string,name=client_id)
+
+	// Username and password. If password is not set, MQTT_PASSWORD environment
+	// variable is used.
+	username?:  string           @protobuf(3,string)
+	password?:  string           @protobuf(4,string)
+	tlsConfig?: proto.#TLSConfig @protobuf(5,tlsconfig.TLSConfig,name=tls_config)
+
+	// Topic template. EventMetrics labels can be used in the template using
+	// the @label@ syntax, e.g. "cloudprober/@probe@/@dst@". Characters that are
+	// special in MQTT topics ('/', '+', '#') are replaced with '_' in the
+	// label values. EventMetrics that are missing a label used in the template
+	// are dropped.
+	topicTemplate?: string @protobuf(6,string,name=topic_template,#"default="cloudprober/@probe@/@dst@""#)
+
+	#Format: {
+		// One JSON encoded message per EventMetrics, on the templated topic.
+		"JSON"
+		#enumValue: 0
+	} | {
+		// One message per metric, on the "<templated_topic>/<metric_name>" topic,
+		// with the metric value as the payload, e.g. "cloudprober/p1/t1/total"
+		// -> "42". This is convenient for the devices and dashboards that consume
+		// single values. Map values are published as
+		// "<topic>/<metric_name>/<map_key>", and distributions are skipped.
+		"VALUE"
+		#enumValue: 1
+	}
+
+	#Format_value: {
+		JSON:  0
+		VALUE: 1
+	}
+	format?: #Format @protobuf(7,Format,"default=JSON")
+
+	// MQTT QoS level: 0 (at most once) or 1 (at least once). QoS 2 is not
+	// supported.
+	qos?: int32 @protobuf(8,int32,"default=0")
+
+	// Set the retained flag on the published messages, so that new
+	// subscribers immediately get the latest result for each topic.
+	retained?: bool @protobuf(9,bool,"default=false")
+
+	// Keep-alive interval.
+	keepaliveSec?: int32 @protobuf(10,int32,name=keepalive_sec,"default=30")
+
+	// Timeout for connecting to the broker and for publishing (QoS 1) a
+	// message.
+	timeoutSec?: int32 @protobuf(11,int32,name=timeout_sec,"default=10")
+}
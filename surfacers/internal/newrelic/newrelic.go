@@ -0,0 +1,309 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package newrelic implements a surfacer that sends metrics to the New Relic
+Metric API (https://docs.newrelic.com/docs/data-apis/ingest-apis/metric-api/).
+
+EventMetrics labels become metric attributes. Cumulative counters are sent as
+New Relic "count" metrics (change since the last EventMetrics, with the
+interval), and gauge values as "gauge" metrics. Map values are sent as one
+metric per key, with the map key as an attribute (e.g. code="200"), and
+distributions as "<metric>.sum" and "<metric>.count" metrics. String metrics
+are added as attributes to the other metrics of the same EventMetrics. The
+"latency" metric is always reported in milliseconds.
+*/
+package newrelic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/newrelic/proto"
+)
+
+var regionURL = map[configpb.SurfacerConf_Region]string{
+	configpb.SurfacerConf_US: "https://metric-api.newrelic.com/metric/v1",
+	configpb.SurfacerConf_EU: "https://metric-api.eu.newrelic.com/metric/v1",
+}
+
+// metric is a single metric in the Metric API payload.
+type metric struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Value      float64           `json:"value"`
+	Timestamp  int64             `json:"timestamp"`
+	IntervalMs int64             `json:"interval.ms,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type payload struct {
+	Common *struct {
+		Attributes map[string]string `json:"attributes"`
+	} `json:"common,omitempty"`
+	Metrics []metric `json:"metrics"`
+}
+
+// Surfacer implements a New Relic surfacer.
+type Surfacer struct {
+	c          *configpb.SurfacerConf
+	opts       *options.Options
+	url        string
+	licenseKey string
+	httpClient *http.Client
+	writeChan  chan *metrics.EventMetrics
+	l          *logger.Logger
+
+	lvCache map[string]*metrics.EventMetrics
+	batch   []metric
+}
+
+// New creates a new instance of the New Relic surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetBatchSize() <= 0 {
+		return nil, fmt.Errorf("newrelic: invalid batch_size: %d", config.GetBatchSize())
+	}
+
+	licenseKey := config.GetLicenseKey()
+	if licenseKey == "" {
+		licenseKey = os.Getenv("NEW_RELIC_LICENSE_KEY")
+	}
+	if licenseKey == "" {
+		return nil, fmt.Errorf("newrelic: license_key is not set, and NEW_RELIC_LICENSE_KEY environment variable is empty")
+	}
+
+	url := config.GetApiUrl()
+	if url == "" {
+		url = regionURL[config.GetRegion()]
+	}
+
+	s := &Surfacer{
+		c:          config,
+		opts:       opts,
+		url:        url,
+		licenseKey: licenseKey,
+		httpClient: &http.Client{Timeout: time.Duration(config.GetRequestTimeoutSec()) * time.Second},
+		writeChan:  make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:          l,
+		lvCache:    make(map[string]*metrics.EventMetrics),
+		batch:      make([]metric, 0, config.GetBatchSize()),
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			ms, err := s.metrics(em)
+			if err != nil {
+				s.l.Warningf("Error converting EventMetrics: %v", err)
+				continue
+			}
+			s.batch = append(s.batch, ms...)
+			if len(s.batch) >= int(s.c.GetBatchSize()) {
+				s.flush(ctx)
+				batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.send(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error sending %d metrics to New Relic: %v", len(s.batch), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+// attributes returns the metric attributes for an EventMetrics' labels.
+func (s *Surfacer) attributes(em *metrics.EventMetrics) map[string]string {
+	attrs := make(map[string]string)
+	for _, k := range em.LabelsKeys() {
+		name := k
+		if attr, ok := s.c.GetLabelToAttribute()[k]; ok {
+			name = attr
+		}
+		attrs[name] = em.Label(k)
+	}
+	for _, k := range em.MetricsKeys() {
+		if v, ok := em.Metric(k).(metrics.String); ok && s.opts.AllowMetric(k) {
+			attrs[k] = strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+		}
+	}
+	return attrs
+}
+
+// counters returns a copy of the EventMetrics without the string metrics, as
+// they are not counters.
+func counters(em *metrics.EventMetrics) *metrics.EventMetrics {
+	c := metrics.NewEventMetrics(em.Timestamp)
+	c.LatencyUnit = em.LatencyUnit
+	for _, k := range em.LabelsKeys() {
+		c.AddLabel(k, em.Label(k))
+	}
+	for _, k := range em.MetricsKeys() {
+		if _, ok := em.Metric(k).(metrics.String); !ok {
+			c.AddMetric(k, em.Metric(k).Clone())
+		}
+	}
+	return c
+}
+
+// metrics converts an EventMetrics into New Relic metrics.
+func (s *Surfacer) metrics(em *metrics.EventMetrics) ([]metric, error) {
+	mType := "gauge"
+	var intervalMs int64
+	ts := em.Timestamp.UnixMilli()
+	attrs := s.attributes(em)
+
+	if em.Kind == metrics.CUMULATIVE && !s.c.GetCumulativeAsGauge() {
+		key := em.Key()
+		lastEM := s.lvCache[key]
+		s.lvCache[key] = counters(em)
+		// We need the previous EventMetrics to compute the counts.
+		if lastEM == nil {
+			return nil, nil
+		}
+		deltaEM, err := s.lvCache[key].SubtractLast(lastEM)
+		if err != nil {
+			return nil, fmt.Errorf("error subtracting cached metrics from current metrics: %v", err)
+		}
+		mType = "count"
+		intervalMs = em.Timestamp.Sub(lastEM.Timestamp).Milliseconds()
+		em = deltaEM
+	}
+
+	newMetric := func(name string, value float64, extraAttrs ...string) metric {
+		m := metric{
+			Name:       s.c.GetMetricsPrefix() + name,
+			Type:       mType,
+			Value:      value,
+			Timestamp:  ts,
+			IntervalMs: intervalMs,
+			Attributes: attrs,
+		}
+		if len(extraAttrs) > 0 {
+			m.Attributes = make(map[string]string, len(attrs)+1)
+			for k, v := range attrs {
+				m.Attributes[k] = v
+			}
+			m.Attributes[extraAttrs[0]] = extraAttrs[1]
+		}
+		return m
+	}
+
+	// Latency values are in EventMetrics' latency unit.
+	latencyScale := 1.0
+	if em.LatencyUnit != 0 {
+		latencyScale = float64(em.LatencyUnit) / float64(time.Millisecond)
+	}
+
+	var out []metric
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+
+		scale := 1.0
+		if metricKey == "latency" {
+			scale = latencyScale
+		}
+
+		switch v := em.Metric(metricKey).(type) {
+		case metrics.NumValue:
+			out = append(out, newMetric(metricKey, v.Float64()*scale))
+		case *metrics.Map[int64]:
+			for _, k := range v.Keys() {
+				out = append(out, newMetric(metricKey, float64(v.GetKey(k))*scale, v.MapName, k))
+			}
+		case *metrics.Map[float64]:
+			for _, k := range v.Keys() {
+				out = append(out, newMetric(metricKey, v.GetKey(k)*scale, v.MapName, k))
+			}
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, newMetric(metricKey+".sum", d.Sum*scale), newMetric(metricKey+".count", float64(d.Count)))
+		}
+	}
+	return out, nil
+}
+
+func (s *Surfacer) send(ctx context.Context, ms []metric) error {
+	p := payload{Metrics: ms}
+	if len(s.c.GetCommonAttribute()) != 0 {
+		p.Common = &struct {
+			Attributes map[string]string `json:"attributes"`
+		}{s.c.GetCommonAttribute()}
+	}
+
+	body, err := json.Marshal([]payload{p})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Api-Key", s.licenseKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP status: %d, response: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/newrelic/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM(ts time.Time, total int64, latencyUs float64) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("latency", metrics.NewFloat(latencyUs)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", total)).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t1")
+	em.LatencyUnit = time.Microsecond
+	return em
+}
+
+func TestMetrics(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	s := &Surfacer{
+		c: &configpb.SurfacerConf{
+			LabelToAttribute: map[string]string{"dst": "target"},
+		},
+		lvCache: make(map[string]*metrics.EventMetrics),
+	}
+
+	// First cumulative EventMetrics only initializes the cache.
+	ms, err := s.metrics(testEM(ts, 10, 10000))
+	assert.NoError(t, err)
+	assert.Empty(t, ms)
+
+	ms, err = s.metrics(testEM(ts.Add(30*time.Second), 12, 16000))
+	assert.NoError(t, err)
+
+	attrs := map[string]string{"probe": "p1", "target": "t1", "version": "v1"}
+	codeAttrs := map[string]string{"probe": "p1", "target": "t1", "version": "v1", "code": "200"}
+	tsMs := ts.Add(30 * time.Second).UnixMilli()
+	assert.Equal(t, []metric{
+		{Name: "cloudprober.total", Type: "count", Value: 2, Timestamp: tsMs, IntervalMs: 30000, Attributes: attrs},
+		{Name: "cloudprober.latency", Type: "count", Value: 6, Timestamp: tsMs, IntervalMs: 30000, Attributes: attrs},
+		{Name: "cloudprober.resp-code", Type: "count", Value: 2, Timestamp: tsMs, IntervalMs: 30000, Attributes: codeAttrs},
+	}, ms)
+
+	// Cumulative values as gauges.
+	s.c.CumulativeAsGauge = proto.Bool(true)
+	ms, err = s.metrics(testEM(ts, 10, 10000))
+	assert.NoError(t, err)
+	assert.Equal(t, metric{Name: "cloudprober.total", Type: "gauge", Value: 10, Timestamp: ts.UnixMilli(), Attributes: attrs}, ms[0])
+}
+
+func TestSurfacer(t *testing.T) {
+	var gotKey string
+	var gotPayload []map[string]any
+	reqCh := make(chan struct{}, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("Api-Key")
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &gotPayload)
+		w.WriteHeader(http.StatusAccepted)
+		reqCh <- struct{}{}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		LicenseKey:        proto.String("test-key"),
+		ApiUrl:            proto.String(srv.URL),
+		CommonAttribute:   map[string]string{"env": "prod"},
+		CumulativeAsGauge: proto.Bool(true),
+		BatchSize:         proto.Int32(3),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	s.Write(ctx, testEM(time.Now(), 10, 10000))
+
+	select {
+	case <-reqCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the request")
+	}
+	assert.Equal(t, "test-key", gotKey)
+	assert.Len(t, gotPayload, 1)
+	assert.Equal(t, map[string]any{"attributes": map[string]any{"env": "prod"}}, gotPayload[0]["common"])
+	assert.Len(t, gotPayload[0]["metrics"], 3)
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "")
+	_, err := New(context.Background(), &configpb.SurfacerConf{}, &options.Options{}, &logger.Logger{})
+	assert.Error(t, err, "no license key")
+
+	t.Setenv("NEW_RELIC_LICENSE_KEY", "env-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Region: configpb.SurfacerConf_EU.Enum(),
+	}, &options.Options{}, &logger.Logger{})
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", s.licenseKey)
+	assert.Equal(t, "https://metric-api.eu.newrelic.com/metric/v1", s.url)
+}
@@ -0,0 +1,60 @@
+package proto
+
+// Surfacer config for New Relic surfacer. This surfacer sends metrics to the
+// New Relic Metric API.
+#SurfacerConf: {
+	// New Relic license key (or insert key). If not set, NEW_RELIC_LICENSE_KEY
+	// environment variable is used.
+	licenseKey?: string @protobuf(1,string,name=license_key)
+
+	#Region: {"US", #enumValue: 0} |
+		{"EU", #enumValue: 1}
+
+	#Region_value: {
+		US: 0
+		EU: 1
+	}
+
+	// New Relic data center region to send metrics to.
+	region?: #Region @protobuf(2,Region,"default=US")
+
+	// Metric API URL. If set, it overrides the region's endpoint, e.g. to use
+	// the FedRAMP endpoint.
+	apiUrl?: string @protobuf(3,string,name=api_url)
+
+	// Prefix to add to all metric names.
+	metricsPrefix?: string @protobuf(4,string,name=metrics_prefix,#"default="cloudprober.""#)
+
+	// By default, all EventMetrics labels become metric attributes with the
+	// same name. Use this field to rename some of them. Key is the label name,
+	// value is the attribute name, e.g.:
+	//   label_to_attribute {
+	//     key: "dst"
+	//     value: "target"
+	//   }
+	labelToAttribute?: {
+		[string]: string
+	} @protobuf(5,map[string]string,label_to_attribute)
+
+	// Attributes to add to all metrics, e.g. service name or environment.
+	commonAttribute?: {
+		[string]: string
+	} @protobuf(6,map[string]string,common_attribute)
+
+	// By default, cumulative counters (e.g. total, success) are sent as New
+	// Relic count metrics, i.e. as the change since the last EventMetrics for
+	// the same probe and target. Set this to true to send cumulative values as
+	// gauge metrics instead.
+	cumulativeAsGauge?: bool @protobuf(7,bool,name=cumulative_as_gauge)
+
+	// Maximum number of metrics to send in one request. Metrics are sent when
+	// the batch is full or when the batch timer expires, whichever happens
+	// first.
+	batchSize?: int32 @protobuf(8,int32,name=batch_size,"default=1000")
+
+	// Maximum time to hold metrics in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(9,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for each request.
+	requestTimeoutSec?: int32 @protobuf(10,int32,name=request_timeout_sec,"default=10")
+}
@@ -17,7 +17,9 @@ Package otel provides an OpenTelemetry surfacer for Cloudprober. It can
 export metrics to OpenTelemetry Collector over gRPC or HTTP.
 
 This surfacer holds the incoming EventMetrics in memory and periodically
-(default: 10s) exports them the configured HTTP or gRPC endpoint.
+(default: 10s) exports them the configured HTTP or gRPC endpoint. Cumulative
+metrics are exported with cumulative temporality by default; they can be
+exported with delta temporality using the "temporality" config option.
 */
 package otel
 
@@ -31,10 +33,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cloudprober/cloudprober/internal/sysvars"
 	"github.com/cloudprober/cloudprober/internal/tlsconfig"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/otel/proto"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
@@ -57,6 +61,10 @@ type OtelSurfacer struct {
 	mu           sync.Mutex
 	scopeMetrics map[string]*metricdata.ScopeMetrics
 
+	// Last values and timestamps, used for the delta temporality.
+	lvCache map[string]*metrics.EventMetrics
+	lastTS  map[string]time.Time
+
 	startTime time.Time
 }
 
@@ -144,6 +152,20 @@ func getExporter(ctx context.Context, config *configpb.SurfacerConf, l *logger.L
 	return stdoutmetric.New()
 }
 
+// reportingExporter reports the result of every export through the
+// surfacer options, see options.Options.PushResult.
+type reportingExporter struct {
+	metric.Exporter
+	opts *options.Options
+}
+
+func (e *reportingExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, rm)
+	e.opts.ReportPush(start, err)
+	return err
+}
+
 // New returns a prometheus surfacer based on the config provided. It sets up a
 // goroutine to process both the incoming EventMetrics and the web requests for
 // the URL handler /metrics.
@@ -156,6 +178,8 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		c:            config,
 		opts:         opts,
 		scopeMetrics: make(map[string]*metricdata.ScopeMetrics),
+		lvCache:      make(map[string]*metrics.EventMetrics),
+		lastTS:       make(map[string]time.Time),
 		startTime:    time.Now(),
 		l:            l,
 	}
@@ -168,13 +192,13 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	// Reader is sort of a binding between exporter and producer. It collects
 	// from the producer and exports to the exporter.
 	exportInterval := time.Second * time.Duration(config.GetExportIntervalSec())
-	r := metric.NewPeriodicReader(exp, metric.WithProducer(os), metric.WithInterval(exportInterval))
+	r := metric.NewPeriodicReader(&reportingExporter{exp, opts}, metric.WithProducer(os), metric.WithInterval(exportInterval))
 
-	var attrKVs []attribute.KeyValue
-	for _, attr := range config.GetResourceAttribute() {
-		attrKVs = append(attrKVs, attribute.String(attr.GetKey(), attr.GetValue()))
+	var sysVars map[string]string
+	if len(config.GetSysvarsResourceAttribute()) > 0 {
+		sysVars = sysvars.Vars()
 	}
-	res, err := resource.New(ctx, resource.WithHost(), resource.WithFromEnv(), resource.WithAttributes(attrKVs...))
+	res, err := resource.New(ctx, resource.WithHost(), resource.WithFromEnv(), resource.WithAttributes(resourceAttributes(config, sysVars)...))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %v", err)
 	}
@@ -188,6 +212,40 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	return os, nil
 }
 
+// resourceAttributes returns the resource attributes based on the config and
+// the given system variables.
+func resourceAttributes(config *configpb.SurfacerConf, sysVars map[string]string) []attribute.KeyValue {
+	attrs := make(map[string]string)
+
+	for _, name := range config.GetSysvarsResourceAttribute() {
+		if name == "*" {
+			for k, v := range sysVars {
+				attrs[k] = v
+			}
+			continue
+		}
+		if v, ok := sysVars[name]; ok {
+			attrs[name] = v
+		}
+	}
+
+	for _, attr := range config.GetResourceAttribute() {
+		attrs[attr.GetKey()] = attr.GetValue()
+	}
+
+	var keys []string
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var attrKVs []attribute.KeyValue
+	for _, k := range keys {
+		attrKVs = append(attrKVs, attribute.String(k, attrs[k]))
+	}
+	return attrKVs
+}
+
 func (os *OtelSurfacer) Produce(_ context.Context) ([]metricdata.ScopeMetrics, error) {
 	os.mu.Lock()
 	defer os.mu.Unlock()
@@ -226,7 +284,7 @@ func mapDataPoints[T int64 | float64](baseAttrs attribute.Set, m *metrics.Map[T]
 	return dataPoints
 }
 
-func convertDistribution(dist *metrics.Distribution, kind metrics.Kind, baseAttrs attribute.Set, startTime, ts time.Time) metricdata.Histogram[float64] {
+func convertDistribution(dist *metrics.Distribution, temporality metricdata.Temporality, baseAttrs attribute.Set, startTime, ts time.Time) metricdata.Histogram[float64] {
 	d := dist.Data()
 
 	hdp := metricdata.HistogramDataPoint[float64]{
@@ -243,17 +301,10 @@ func convertDistribution(dist *metrics.Distribution, kind metrics.Kind, baseAttr
 		hdp.BucketCounts[i] = uint64(d.BucketCounts[i])
 	}
 
-	hist := metricdata.Histogram[float64]{
-		DataPoints: []metricdata.HistogramDataPoint[float64]{hdp},
-	}
-
-	if kind == metrics.GAUGE {
-		hist.Temporality = metricdata.DeltaTemporality
-	} else {
-		hist.Temporality = metricdata.CumulativeTemporality
+	return metricdata.Histogram[float64]{
+		DataPoints:  []metricdata.HistogramDataPoint[float64]{hdp},
+		Temporality: temporality,
 	}
-
-	return hist
 }
 
 func otelAttributes(em *metrics.EventMetrics) attribute.Set {
@@ -264,19 +315,26 @@ func otelAttributes(em *metrics.EventMetrics) attribute.Set {
 	return attribute.NewSet(attrs...)
 }
 
-func sumOrGauge[T int64 | float64](kind metrics.Kind, dataPoints ...metricdata.DataPoint[T]) metricdata.Aggregation {
-	if kind == metrics.GAUGE {
+// exportKind determines how a metric is exported: as a gauge, or as a sum
+// with the given temporality.
+type exportKind struct {
+	gauge       bool
+	temporality metricdata.Temporality
+}
+
+func sumOrGauge[T int64 | float64](ek exportKind, dataPoints ...metricdata.DataPoint[T]) metricdata.Aggregation {
+	if ek.gauge {
 		return metricdata.Gauge[T]{DataPoints: dataPoints}
 	}
 	return metricdata.Sum[T]{
-		Temporality: metricdata.CumulativeTemporality,
+		Temporality: ek.temporality,
 		DataPoints:  dataPoints,
 		IsMonotonic: true,
 	}
 }
 
-func numberData[T int64 | float64](kind metrics.Kind, v T, attrs attribute.Set, startTime, timestamp time.Time) metricdata.Aggregation {
-	return sumOrGauge[T](kind, metricdata.DataPoint[T]{
+func numberData[T int64 | float64](ek exportKind, v T, attrs attribute.Set, startTime, timestamp time.Time) metricdata.Aggregation {
+	return sumOrGauge[T](ek, metricdata.DataPoint[T]{
 		Attributes: attrs,
 		StartTime:  startTime,
 		Time:       timestamp,
@@ -284,11 +342,13 @@ func numberData[T int64 | float64](kind metrics.Kind, v T, attrs attribute.Set,
 	})
 }
 
-func (os *OtelSurfacer) convertMetric(em *metrics.EventMetrics, metricName string) (metricdata.Metrics, error) {
+func (os *OtelSurfacer) convertMetric(em *metrics.EventMetrics, metricName string, ek exportKind, startTime time.Time) (metricdata.Metrics, error) {
 	baseAttrs := otelAttributes(em)
 
 	unit := "1"
-	if metricName == "latency" {
+	if u, ok := os.c.GetMetricUnit()[metricName]; ok {
+		unit = u
+	} else if metricName == "latency" {
 		if em.LatencyUnit == 0 {
 			unit = "us"
 		} else {
@@ -308,18 +368,22 @@ func (os *OtelSurfacer) convertMetric(em *metrics.EventMetrics, metricName strin
 
 	switch v := em.Metric(metricName).(type) {
 	case *metrics.Int:
-		return otelmetrics(numberData[int64](em.Kind, v.Int64(), baseAttrs, os.startTime, em.Timestamp)), nil
+		return otelmetrics(numberData[int64](ek, v.Int64(), baseAttrs, startTime, em.Timestamp)), nil
 	case *metrics.Float:
-		return otelmetrics(numberData[float64](em.Kind, v.Float64(), baseAttrs, os.startTime, em.Timestamp)), nil
+		return otelmetrics(numberData[float64](ek, v.Float64(), baseAttrs, startTime, em.Timestamp)), nil
 	case *metrics.Map[int64]:
-		return otelmetrics(sumOrGauge[int64](em.Kind, mapDataPoints[int64](baseAttrs, v, os.startTime, em.Timestamp)...)), nil
+		return otelmetrics(sumOrGauge[int64](ek, mapDataPoints[int64](baseAttrs, v, startTime, em.Timestamp)...)), nil
 	case *metrics.Map[float64]:
-		return otelmetrics(sumOrGauge[float64](em.Kind, mapDataPoints[float64](baseAttrs, v, os.startTime, em.Timestamp)...)), nil
+		return otelmetrics(sumOrGauge[float64](ek, mapDataPoints[float64](baseAttrs, v, startTime, em.Timestamp)...)), nil
 	case metrics.String:
 		attrs := attribute.NewSet(append(baseAttrs.ToSlice(), attribute.String("value", v.String()))...)
-		return otelmetrics(numberData[int64](em.Kind, 1, attrs, os.startTime, em.Timestamp)), nil
+		return otelmetrics(numberData[int64](ek, 1, attrs, startTime, em.Timestamp)), nil
 	case *metrics.Distribution:
-		return otelmetrics(convertDistribution(v, em.Kind, baseAttrs, os.startTime, em.Timestamp)), nil
+		temporality := ek.temporality
+		if ek.gauge {
+			temporality = metricdata.DeltaTemporality
+		}
+		return otelmetrics(convertDistribution(v, temporality, baseAttrs, startTime, em.Timestamp)), nil
 	}
 
 	return metricdata.Metrics{}, fmt.Errorf("unsupported metric type: %T", em.Metric(metricName))
@@ -335,12 +399,46 @@ func getScope(em *metrics.EventMetrics) string {
 	return "global"
 }
 
+// toDelta converts a cumulative EventMetrics to delta EventMetrics, i.e.
+// EventMetrics containing the change since the last EventMetrics for the same
+// set of labels. It returns the converted EventMetrics and the start time for
+// the delta.
+func (os *OtelSurfacer) toDelta(em *metrics.EventMetrics) (*metrics.EventMetrics, time.Time, error) {
+	key := em.Key()
+
+	startTime, ok := os.lastTS[key]
+	if !ok {
+		startTime = os.startTime
+	}
+
+	deltaEM, err := transform.CumulativeToGauge(em, os.lvCache, os.l)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	os.lastTS[key] = em.Timestamp
+
+	return deltaEM, startTime, nil
+}
+
 // record processes the incoming EventMetrics and updates the in-memory
 // otel metrics database.
 func (os *OtelSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
 	os.mu.Lock()
 	defer os.mu.Unlock()
 
+	ek := exportKind{gauge: em.Kind == metrics.GAUGE, temporality: metricdata.CumulativeTemporality}
+	startTime := os.startTime
+
+	if em.Kind == metrics.CUMULATIVE && os.c.GetTemporality() == configpb.SurfacerConf_DELTA {
+		deltaEM, deltaStartTime, err := os.toDelta(em)
+		if err != nil {
+			os.l.Warningf("Error converting metrics to delta, exporting them as cumulative: %v", err)
+		} else {
+			em, startTime = deltaEM, deltaStartTime
+			ek.temporality = metricdata.DeltaTemporality
+		}
+	}
+
 	scope := getScope(em)
 	sm := os.scopeMetrics[scope]
 	if sm == nil {
@@ -357,7 +455,7 @@ func (os *OtelSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
 			continue
 		}
 
-		otelmetrics, err := os.convertMetric(em, metricName)
+		otelmetrics, err := os.convertMetric(em, metricName, ek, startTime)
 		if err != nil {
 			os.l.Errorf("Error converting metric: %s, err: %v", metricName, err)
 			continue
@@ -224,3 +224,123 @@ func TestGetExporterType(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceAttributes(t *testing.T) {
+	sysVars := map[string]string{
+		"hostname": "host1",
+		"zone":     "us-east1-b",
+		"project":  "p1",
+	}
+
+	tests := []struct {
+		name   string
+		config *configpb.SurfacerConf
+		want   []attribute.KeyValue
+	}{
+		{
+			name:   "no_attributes",
+			config: &configpb.SurfacerConf{},
+		},
+		{
+			name: "sysvars_and_config",
+			config: &configpb.SurfacerConf{
+				SysvarsResourceAttribute: []string{"zone", "hostname", "missing"},
+				ResourceAttribute: []*configpb.SurfacerConf_Attribute{
+					{Key: proto.String("env"), Value: proto.String("prod")},
+					{Key: proto.String("hostname"), Value: proto.String("host-override")},
+				},
+			},
+			want: []attribute.KeyValue{
+				attribute.String("env", "prod"),
+				attribute.String("hostname", "host-override"),
+				attribute.String("zone", "us-east1-b"),
+			},
+		},
+		{
+			name: "all_sysvars",
+			config: &configpb.SurfacerConf{
+				SysvarsResourceAttribute: []string{"*"},
+			},
+			want: []attribute.KeyValue{
+				attribute.String("hostname", "host1"),
+				attribute.String("project", "p1"),
+				attribute.String("zone", "us-east1-b"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceAttributes(tt.config, sysVars))
+		})
+	}
+}
+
+func TestOtelSurfacerDeltaTemporality(t *testing.T) {
+	startTime := time.Now()
+	ts := startTime.Add(time.Second)
+
+	os := &OtelSurfacer{
+		c: &configpb.SurfacerConf{
+			Temporality: configpb.SurfacerConf_DELTA.Enum(),
+			MetricUnit:  map[string]string{"resp_size": "By"},
+		},
+		startTime:    startTime,
+		scopeMetrics: make(map[string]*metricdata.ScopeMetrics),
+		lvCache:      make(map[string]*metrics.EventMetrics),
+		lastTS:       make(map[string]time.Time),
+	}
+
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(2)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp_size", metrics.NewInt(1000)).
+		AddMetric("latency", d).
+		AddLabel("probe", "p1")
+	os.Write(context.Background(), em)
+
+	// Second EventMetrics with updated values.
+	d2 := d.CloneDist()
+	d2.AddSample(20)
+	em2 := metrics.NewEventMetrics(ts.Add(10*time.Second)).
+		AddMetric("total", metrics.NewInt(15)).
+		AddMetric("resp_size", metrics.NewInt(1500)).
+		AddMetric("latency", d2).
+		AddLabel("probe", "p1")
+	os.Write(context.Background(), em2)
+
+	deltaSum := func(v int64, start, ts time.Time) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			DataPoints:  []metricdata.DataPoint[int64]{dataPoint[int64](v, [][2]string{{"probe", "p1"}}, start, ts)},
+			Temporality: metricdata.DeltaTemporality,
+			IsMonotonic: true,
+		}
+	}
+	deltaHist := func(count uint64, sum float64, bc []uint64, start, ts time.Time) metricdata.Histogram[float64] {
+		return metricdata.Histogram[float64]{
+			DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{
+					Attributes:   attribute.NewSet(attribute.String("probe", "p1")),
+					StartTime:    start,
+					Time:         ts,
+					Count:        count,
+					Sum:          sum,
+					Bounds:       []float64{1, 10},
+					BucketCounts: bc,
+				},
+			},
+			Temporality: metricdata.DeltaTemporality,
+		}
+	}
+
+	wantMetrics := []metricdata.Metrics{
+		testMetric("cloudprober_total", "1", deltaSum(10, startTime, em.Timestamp)),
+		testMetric("cloudprober_resp_size", "By", deltaSum(1000, startTime, em.Timestamp)),
+		testMetric("cloudprober_latency", "us", deltaHist(1, 2, []uint64{0, 1, 0}, startTime, em.Timestamp)),
+		testMetric("cloudprober_total", "1", deltaSum(5, em.Timestamp, em2.Timestamp)),
+		testMetric("cloudprober_resp_size", "By", deltaSum(500, em.Timestamp, em2.Timestamp)),
+		testMetric("cloudprober_latency", "us", deltaHist(1, 20, []uint64{0, 0, 1}, em.Timestamp, em2.Timestamp)),
+	}
+	assert.Equal(t, wantMetrics, os.scopeMetrics["probe.p1"].Metrics)
+}
@@ -77,6 +77,67 @@ func (Compression) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_rawDescGZIP(), []int{0}
 }
 
+// Aggregation temporality for the cumulative (counter) metrics. Cloudprober
+// generates cumulative metrics, i.e. the value at each point is the total
+// since the start. Some backends prefer delta temporality, i.e. the value at
+// each point is the change since the previous point. Delta temporality
+// requires keeping a copy of the last exported values in memory.
+type SurfacerConf_Temporality int32
+
+const (
+	SurfacerConf_CUMULATIVE SurfacerConf_Temporality = 0
+	SurfacerConf_DELTA      SurfacerConf_Temporality = 1
+)
+
+// Enum value maps for SurfacerConf_Temporality.
+var (
+	SurfacerConf_Temporality_name = map[int32]string{
+		0: "CUMULATIVE",
+		1: "DELTA",
+	}
+	SurfacerConf_Temporality_value = map[string]int32{
+		"CUMULATIVE": 0,
+		"DELTA":      1,
+	}
+)
+
+func (x SurfacerConf_Temporality) Enum() *SurfacerConf_Temporality {
+	p := new(SurfacerConf_Temporality)
+	*p = x
+	return p
+}
+
+func (x SurfacerConf_Temporality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerConf_Temporality) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_enumTypes[1].Descriptor()
+}
+
+func (SurfacerConf_Temporality) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_enumTypes[1]
+}
+
+func (x SurfacerConf_Temporality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerConf_Temporality) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerConf_Temporality(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerConf_Temporality.Descriptor instead.
+func (SurfacerConf_Temporality) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type HTTPExporter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -255,12 +316,30 @@ type SurfacerConf struct {
 	// Prefix to use for metrics. Defaults to "cloudprober_".
 	MetricsPrefix     *string                   `protobuf:"bytes,4,opt,name=metrics_prefix,json=metricsPrefix,def=cloudprober_" json:"metrics_prefix,omitempty"`
 	ResourceAttribute []*SurfacerConf_Attribute `protobuf:"bytes,5,rep,name=resource_attribute,json=resourceAttribute" json:"resource_attribute,omitempty"`
+	// System variables to add as resource attributes, e.g. "hostname",
+	// "instance", "zone", "region". System variables are discovered at startup
+	// from the environment and cloud metadata (GCE, EC2). Use "*" to add all
+	// the system variables. Explicitly configured resource attributes take
+	// precedence over system variables.
+	SysvarsResourceAttribute []string `protobuf:"bytes,6,rep,name=sysvars_resource_attribute,json=sysvarsResourceAttribute" json:"sysvars_resource_attribute,omitempty"`
+	// Units for metrics, keyed by metric name (without the prefix), e.g.:
+	//
+	//	metric_unit {
+	//	  key: "resp_size"
+	//	  value: "By"
+	//	}
+	//
+	// Units should follow the UCUM convention. If not specified, latency metrics
+	// use the probe's latency unit and all other metrics use the unit "1".
+	MetricUnit  map[string]string         `protobuf:"bytes,7,rep,name=metric_unit,json=metricUnit" json:"metric_unit,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Temporality *SurfacerConf_Temporality `protobuf:"varint,8,opt,name=temporality,enum=cloudprober.surfacer.otel.SurfacerConf_Temporality,def=0" json:"temporality,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
 const (
 	Default_SurfacerConf_ExportIntervalSec = int32(10)
 	Default_SurfacerConf_MetricsPrefix     = string("cloudprober_")
+	Default_SurfacerConf_Temporality       = SurfacerConf_CUMULATIVE
 )
 
 func (x *SurfacerConf) Reset() {
@@ -337,6 +416,27 @@ func (x *SurfacerConf) GetResourceAttribute() []*SurfacerConf_Attribute {
 	return nil
 }
 
+func (x *SurfacerConf) GetSysvarsResourceAttribute() []string {
+	if x != nil {
+		return x.SysvarsResourceAttribute
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetMetricUnit() map[string]string {
+	if x != nil {
+		return x.MetricUnit
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetTemporality() SurfacerConf_Temporality {
+	if x != nil && x.Temporality != nil {
+		return *x.Temporality
+	}
+	return Default_SurfacerConf_Temporality
+}
+
 type isSurfacerConf_Exporter interface {
 	isSurfacerConf_Exporter()
 }
@@ -469,7 +569,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config
 	0x74, 0x70, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
 	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
 	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcc, 0x03, 0x0a, 0x0c, 0x53, 0x75,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb0, 0x06, 0x0a, 0x0c, 0x53, 0x75,
 	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x57, 0x0a, 0x12, 0x6f, 0x74,
 	0x6c, 0x70, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
@@ -494,17 +594,40 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config
 	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6f, 0x74, 0x65, 0x6c, 0x2e, 0x53, 0x75,
 	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
 	0x62, 0x75, 0x74, 0x65, 0x52, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x74,
-	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x1a, 0x33, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0a, 0x0a, 0x08,
-	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2a, 0x21, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10,
-	0x00, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x5a, 0x49, 0x50, 0x10, 0x01, 0x42, 0x42, 0x5a, 0x40, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x74, 0x65, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x73, 0x79, 0x73, 0x76, 0x61,
+	0x72, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x61, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x18, 0x73, 0x79, 0x73,
+	0x76, 0x61, 0x72, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x58, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x2e, 0x6f, 0x74, 0x65, 0x6c, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x55, 0x6e, 0x69, 0x74, 0x12,
+	0x61, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6f, 0x74, 0x65, 0x6c,
+	0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x54, 0x65,
+	0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x3a, 0x0a, 0x43, 0x55, 0x4d, 0x55, 0x4c,
+	0x41, 0x54, 0x49, 0x56, 0x45, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x1a, 0x33, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x3d, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x55, 0x6e, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a, 0x0b, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x55, 0x4d, 0x55, 0x4c, 0x41, 0x54,
+	0x49, 0x56, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x4c, 0x54, 0x41, 0x10, 0x01,
+	0x42, 0x0a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2a, 0x21, 0x0a, 0x0b,
+	0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x5a, 0x49, 0x50, 0x10, 0x01, 0x42,
+	0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x74, 0x65, 0x6c, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -519,33 +642,37 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_confi
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_goTypes = []interface{}{
 	(Compression)(0),               // 0: cloudprober.surfacer.otel.Compression
-	(*HTTPExporter)(nil),           // 1: cloudprober.surfacer.otel.HTTPExporter
-	(*GRPCExporter)(nil),           // 2: cloudprober.surfacer.otel.GRPCExporter
-	(*SurfacerConf)(nil),           // 3: cloudprober.surfacer.otel.SurfacerConf
-	nil,                            // 4: cloudprober.surfacer.otel.HTTPExporter.HttpHeaderEntry
-	nil,                            // 5: cloudprober.surfacer.otel.GRPCExporter.HttpHeaderEntry
-	(*SurfacerConf_Attribute)(nil), // 6: cloudprober.surfacer.otel.SurfacerConf.Attribute
-	(*proto.TLSConfig)(nil),        // 7: cloudprober.tlsconfig.TLSConfig
+	(SurfacerConf_Temporality)(0),  // 1: cloudprober.surfacer.otel.SurfacerConf.Temporality
+	(*HTTPExporter)(nil),           // 2: cloudprober.surfacer.otel.HTTPExporter
+	(*GRPCExporter)(nil),           // 3: cloudprober.surfacer.otel.GRPCExporter
+	(*SurfacerConf)(nil),           // 4: cloudprober.surfacer.otel.SurfacerConf
+	nil,                            // 5: cloudprober.surfacer.otel.HTTPExporter.HttpHeaderEntry
+	nil,                            // 6: cloudprober.surfacer.otel.GRPCExporter.HttpHeaderEntry
+	(*SurfacerConf_Attribute)(nil), // 7: cloudprober.surfacer.otel.SurfacerConf.Attribute
+	nil,                            // 8: cloudprober.surfacer.otel.SurfacerConf.MetricUnitEntry
+	(*proto.TLSConfig)(nil),        // 9: cloudprober.tlsconfig.TLSConfig
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_depIdxs = []int32{
-	7, // 0: cloudprober.surfacer.otel.HTTPExporter.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
-	4, // 1: cloudprober.surfacer.otel.HTTPExporter.http_header:type_name -> cloudprober.surfacer.otel.HTTPExporter.HttpHeaderEntry
-	0, // 2: cloudprober.surfacer.otel.HTTPExporter.compression:type_name -> cloudprober.surfacer.otel.Compression
-	7, // 3: cloudprober.surfacer.otel.GRPCExporter.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
-	5, // 4: cloudprober.surfacer.otel.GRPCExporter.http_header:type_name -> cloudprober.surfacer.otel.GRPCExporter.HttpHeaderEntry
-	0, // 5: cloudprober.surfacer.otel.GRPCExporter.compression:type_name -> cloudprober.surfacer.otel.Compression
-	1, // 6: cloudprober.surfacer.otel.SurfacerConf.otlp_http_exporter:type_name -> cloudprober.surfacer.otel.HTTPExporter
-	2, // 7: cloudprober.surfacer.otel.SurfacerConf.otlp_grpc_exporter:type_name -> cloudprober.surfacer.otel.GRPCExporter
-	6, // 8: cloudprober.surfacer.otel.SurfacerConf.resource_attribute:type_name -> cloudprober.surfacer.otel.SurfacerConf.Attribute
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	9,  // 0: cloudprober.surfacer.otel.HTTPExporter.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	5,  // 1: cloudprober.surfacer.otel.HTTPExporter.http_header:type_name -> cloudprober.surfacer.otel.HTTPExporter.HttpHeaderEntry
+	0,  // 2: cloudprober.surfacer.otel.HTTPExporter.compression:type_name -> cloudprober.surfacer.otel.Compression
+	9,  // 3: cloudprober.surfacer.otel.GRPCExporter.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	6,  // 4: cloudprober.surfacer.otel.GRPCExporter.http_header:type_name -> cloudprober.surfacer.otel.GRPCExporter.HttpHeaderEntry
+	0,  // 5: cloudprober.surfacer.otel.GRPCExporter.compression:type_name -> cloudprober.surfacer.otel.Compression
+	2,  // 6: cloudprober.surfacer.otel.SurfacerConf.otlp_http_exporter:type_name -> cloudprober.surfacer.otel.HTTPExporter
+	3,  // 7: cloudprober.surfacer.otel.SurfacerConf.otlp_grpc_exporter:type_name -> cloudprober.surfacer.otel.GRPCExporter
+	7,  // 8: cloudprober.surfacer.otel.SurfacerConf.resource_attribute:type_name -> cloudprober.surfacer.otel.SurfacerConf.Attribute
+	8,  // 9: cloudprober.surfacer.otel.SurfacerConf.metric_unit:type_name -> cloudprober.surfacer.otel.SurfacerConf.MetricUnitEntry
+	1,  // 10: cloudprober.surfacer.otel.SurfacerConf.temporality:type_name -> cloudprober.surfacer.otel.SurfacerConf.Temporality
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() {
@@ -614,8 +741,8 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_confi
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_otel_proto_config_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -68,4 +68,36 @@ import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 		value?: string @protobuf(2,string)
 	}
 	resourceAttribute?: [...#Attribute] @protobuf(5,Attribute,name=resource_attribute)
+
+	// System variables to add as resource attributes, e.g. "hostname",
+	// "instance", "zone", "region". System variables are discovered at startup
+	// from the environment and cloud metadata (GCE, EC2). Use "*" to add all
+	// the system variables. Explicitly configured resource attributes take
+	// precedence over system variables.
+	sysvarsResourceAttribute?: [...string] @protobuf(6,string,name=sysvars_resource_attribute)
+
+	// Units for metrics, keyed by metric name (without the prefix), e.g.:
+	//   metric_unit {
+	//     key: "resp_size"
+	//     value: "By"
+	//   }
+	// Units should follow the UCUM convention. If not specified, latency metrics
+	// use the probe's latency unit and all other metrics use the unit "1".
+	metricUnit?: {
+		[string]: string
+	} @protobuf(7,map[string]string,metric_unit)
+
+	// Aggregation temporality for the cumulative (counter) metrics. Cloudprober
+	// generates cumulative metrics, i.e. the value at each point is the total
+	// since the start. Some backends prefer delta temporality, i.e. the value at
+	// each point is the change since the previous point. Delta temporality
+	// requires keeping a copy of the last exported values in memory.
+	#Temporality: {"CUMULATIVE", #enumValue: 0} |
+		{"DELTA", #enumValue: 1}
+
+	#Temporality_value: {
+		CUMULATIVE: 0
+		DELTA:      1
+	}
+	temporality?: #Temporality @protobuf(8,Temporality,"default=CUMULATIVE")
 }
@@ -26,6 +26,13 @@ cloudprober config:
 		  metrics_table_name: "metrics"
 	  }
 	}
+
+To have the surfacer create the table, partition it by day and drop data
+older than 30 days, add a managed_schema stanza:
+
+	managed_schema {
+	  retention_days: 30
+	}
 */
 package postgres
 
@@ -185,10 +192,15 @@ type Surfacer struct {
 
 // New initializes a Postgres surfacer. Postgres surfacer inserts probe results
 // into a postgres database.
-func New(ctx context.Context, config *configpb.SurfacerConf, l *logger.Logger) (*Surfacer, error) {
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if err := validateManagedSchema(config); err != nil {
+		return nil, err
+	}
+
 	s := &Surfacer{
-		c: config,
-		l: l,
+		c:    config,
+		opts: opts,
+		l:    l,
 		openDB: func(cs string) (*sql.DB, error) {
 			return sql.Open("postgres", cs)
 		},
@@ -254,6 +266,13 @@ func (s *Surfacer) init(ctx context.Context) error {
 	if err = s.db.Ping(); err != nil {
 		return err
 	}
+
+	if s.c.GetManagedSchema() != nil {
+		if err := s.initSchema(ctx); err != nil {
+			return err
+		}
+		go s.runMaintenance(ctx)
+	}
 	s.writeChan = make(chan *metrics.EventMetrics, s.c.GetMetricsBufferSize())
 
 	// Generate the desired columns either with 'labels' by default
@@ -276,7 +295,10 @@ func (s *Surfacer) init(ctx context.Context) error {
 				}
 				// Note: we may want to batch calls to writeMetrics, as each call results in
 				// a database transaction.
-				if err := s.writeMetrics(em); err != nil {
+				start := time.Now()
+				err := s.writeMetrics(em)
+				s.opts.ReportPush(start, err)
+				if err != nil {
 					s.l.Warningf("Error while writing metrics: %v", err)
 				}
 			}
@@ -20,6 +20,68 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ManagedSchema_Partitioning int32
+
+const (
+	// Single table. Retention is enforced by deleting old rows.
+	ManagedSchema_NONE ManagedSchema_Partitioning = 0
+	// One partition per day.
+	ManagedSchema_DAILY ManagedSchema_Partitioning = 1
+	// One partition per month.
+	ManagedSchema_MONTHLY ManagedSchema_Partitioning = 2
+)
+
+// Enum value maps for ManagedSchema_Partitioning.
+var (
+	ManagedSchema_Partitioning_name = map[int32]string{
+		0: "NONE",
+		1: "DAILY",
+		2: "MONTHLY",
+	}
+	ManagedSchema_Partitioning_value = map[string]int32{
+		"NONE":    0,
+		"DAILY":   1,
+		"MONTHLY": 2,
+	}
+)
+
+func (x ManagedSchema_Partitioning) Enum() *ManagedSchema_Partitioning {
+	p := new(ManagedSchema_Partitioning)
+	*p = x
+	return p
+}
+
+func (x ManagedSchema_Partitioning) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ManagedSchema_Partitioning) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ManagedSchema_Partitioning) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_enumTypes[0]
+}
+
+func (x ManagedSchema_Partitioning) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ManagedSchema_Partitioning) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ManagedSchema_Partitioning(num)
+	return nil
+}
+
+// Deprecated: Use ManagedSchema_Partitioning.Descriptor instead.
+func (ManagedSchema_Partitioning) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDescGZIP(), []int{1, 0}
+}
+
 type SurfacerConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -37,6 +99,7 @@ type SurfacerConf struct {
 	//	time timestamp, metric_name varchar(80), value float8, labels jsonb
 	//
 	// )
+	// Alternatively, use managed_schema to let the surfacer create the table.
 	MetricsTableName *string `protobuf:"bytes,2,req,name=metrics_table_name,json=metricsTableName" json:"metrics_table_name,omitempty"`
 	// Adding label_to_column fields changes how labels are stored in a Postgres
 	// table. If this field is not specified at all, all the labels are stored as
@@ -46,6 +109,11 @@ type SurfacerConf struct {
 	// don't have a mapping will be dropped.
 	LabelToColumn     []*LabelToColumn `protobuf:"bytes,4,rep,name=label_to_column,json=labelToColumn" json:"label_to_column,omitempty"`
 	MetricsBufferSize *int64           `protobuf:"varint,3,opt,name=metrics_buffer_size,json=metricsBufferSize,def=10000" json:"metrics_buffer_size,omitempty"`
+	// If set, surfacer manages the metrics table itself: it creates the table
+	// if it doesn't exist, adds missing columns (e.g. for new label_to_column
+	// entries) and indexes, and, if partitioning is enabled, creates upcoming
+	// time partitions and drops the ones older than the retention period.
+	ManagedSchema *ManagedSchema `protobuf:"bytes,5,opt,name=managed_schema,json=managedSchema" json:"managed_schema,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -113,6 +181,114 @@ func (x *SurfacerConf) GetMetricsBufferSize() int64 {
 	return Default_SurfacerConf_MetricsBufferSize
 }
 
+func (x *SurfacerConf) GetManagedSchema() *ManagedSchema {
+	if x != nil {
+		return x.ManagedSchema
+	}
+	return nil
+}
+
+type ManagedSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Partition the table by time. Partitioned tables are much cheaper to
+	// clean up, as old partitions are dropped as a whole. Note that this
+	// setting is used only when creating the table; an existing unpartitioned
+	// table is not converted. Requires Postgres 11 or later.
+	Partitioning *ManagedSchema_Partitioning `protobuf:"varint,1,opt,name=partitioning,enum=cloudprober.surfacer.postgres.ManagedSchema_Partitioning,def=1" json:"partitioning,omitempty"`
+	// Number of future partitions to keep created ahead of time.
+	PrecreatePartitions *int32 `protobuf:"varint,2,opt,name=precreate_partitions,json=precreatePartitions,def=2" json:"precreate_partitions,omitempty"`
+	// Delete data older than this many days. 0 means keep data forever. With
+	// partitioning, a partition is dropped once all its data is older than the
+	// retention period.
+	RetentionDays *int32 `protobuf:"varint,3,opt,name=retention_days,json=retentionDays" json:"retention_days,omitempty"`
+	// Create a GIN index on the labels column. Applicable only if labels are
+	// stored in the JSONB labels column (i.e. label_to_column is not used).
+	IndexLabels *bool `protobuf:"varint,4,opt,name=index_labels,json=indexLabels,def=0" json:"index_labels,omitempty"`
+	// Labels to expand from the JSONB labels column into their own (generated)
+	// columns, named "label_<label>", with an index on each of them. This makes
+	// filtering on frequently used labels, e.g. probe and dst, fast. Applicable
+	// only if labels are stored in the JSONB labels column. Requires Postgres
+	// 12 or later.
+	ExpandLabel []string `protobuf:"bytes,5,rep,name=expand_label,json=expandLabel" json:"expand_label,omitempty"`
+}
+
+// Default values for ManagedSchema fields.
+const (
+	Default_ManagedSchema_Partitioning        = ManagedSchema_DAILY
+	Default_ManagedSchema_PrecreatePartitions = int32(2)
+	Default_ManagedSchema_IndexLabels         = bool(false)
+)
+
+func (x *ManagedSchema) Reset() {
+	*x = ManagedSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ManagedSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManagedSchema) ProtoMessage() {}
+
+func (x *ManagedSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManagedSchema.ProtoReflect.Descriptor instead.
+func (*ManagedSchema) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ManagedSchema) GetPartitioning() ManagedSchema_Partitioning {
+	if x != nil && x.Partitioning != nil {
+		return *x.Partitioning
+	}
+	return Default_ManagedSchema_Partitioning
+}
+
+func (x *ManagedSchema) GetPrecreatePartitions() int32 {
+	if x != nil && x.PrecreatePartitions != nil {
+		return *x.PrecreatePartitions
+	}
+	return Default_ManagedSchema_PrecreatePartitions
+}
+
+func (x *ManagedSchema) GetRetentionDays() int32 {
+	if x != nil && x.RetentionDays != nil {
+		return *x.RetentionDays
+	}
+	return 0
+}
+
+func (x *ManagedSchema) GetIndexLabels() bool {
+	if x != nil && x.IndexLabels != nil {
+		return *x.IndexLabels
+	}
+	return Default_ManagedSchema_IndexLabels
+}
+
+func (x *ManagedSchema) GetExpandLabel() []string {
+	if x != nil {
+		return x.ExpandLabel
+	}
+	return nil
+}
+
 type LabelToColumn struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -127,7 +303,7 @@ type LabelToColumn struct {
 func (x *LabelToColumn) Reset() {
 	*x = LabelToColumn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[1]
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -140,7 +316,7 @@ func (x *LabelToColumn) String() string {
 func (*LabelToColumn) ProtoMessage() {}
 
 func (x *LabelToColumn) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[1]
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +329,7 @@ func (x *LabelToColumn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LabelToColumn.ProtoReflect.Descriptor instead.
 func (*LabelToColumn) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDescGZIP(), []int{1}
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *LabelToColumn) GetLabel() string {
@@ -180,7 +356,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_co
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
 	0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72,
-	0x65, 0x73, 0x22, 0xf6, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
+	0x65, 0x73, 0x22, 0xcb, 0x02, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
 	0x6f, 0x6e, 0x66, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x10,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
@@ -195,16 +371,42 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_co
 	0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x35, 0x0a, 0x13, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f,
 	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x03, 0x3a, 0x05, 0x31, 0x30, 0x30, 0x30, 0x30, 0x52, 0x11, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x54, 0x6f, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x02,
-	0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f,
+	0x73, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x53, 0x0a, 0x0e, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x67,
+	0x72, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x52, 0x0d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x22, 0xd1, 0x02, 0x0a, 0x0d, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x12, 0x64, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x39, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
+	0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x69, 0x6e, 0x67, 0x3a, 0x05, 0x44, 0x41, 0x49, 0x4c, 0x59, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x34, 0x0a, 0x14, 0x70, 0x72, 0x65, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x32, 0x52, 0x13, 0x70, 0x72, 0x65, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x61, 0x79, 0x73, 0x12, 0x28, 0x0a, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c,
+	0x73, 0x65, 0x52, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x22, 0x30, 0x0a, 0x0c, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x41, 0x49, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x4f, 0x4e, 0x54, 0x48,
+	0x4c, 0x59, 0x10, 0x02, 0x22, 0x3d, 0x0a, 0x0d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x54, 0x6f, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01,
+	0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6f, 0x73,
+	0x74, 0x67, 0x72, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -219,18 +421,23 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_c
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_goTypes = []interface{}{
-	(*SurfacerConf)(nil),  // 0: cloudprober.surfacer.postgres.SurfacerConf
-	(*LabelToColumn)(nil), // 1: cloudprober.surfacer.postgres.LabelToColumn
+	(ManagedSchema_Partitioning)(0), // 0: cloudprober.surfacer.postgres.ManagedSchema.Partitioning
+	(*SurfacerConf)(nil),            // 1: cloudprober.surfacer.postgres.SurfacerConf
+	(*ManagedSchema)(nil),           // 2: cloudprober.surfacer.postgres.ManagedSchema
+	(*LabelToColumn)(nil),           // 3: cloudprober.surfacer.postgres.LabelToColumn
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_depIdxs = []int32{
-	1, // 0: cloudprober.surfacer.postgres.SurfacerConf.label_to_column:type_name -> cloudprober.surfacer.postgres.LabelToColumn
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: cloudprober.surfacer.postgres.SurfacerConf.label_to_column:type_name -> cloudprober.surfacer.postgres.LabelToColumn
+	2, // 1: cloudprober.surfacer.postgres.SurfacerConf.managed_schema:type_name -> cloudprober.surfacer.postgres.ManagedSchema
+	0, // 2: cloudprober.surfacer.postgres.ManagedSchema.partitioning:type_name -> cloudprober.surfacer.postgres.ManagedSchema.Partitioning
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() {
@@ -254,6 +461,18 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_c
 			}
 		}
 		file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ManagedSchema); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LabelToColumn); i {
 			case 0:
 				return &v.state
@@ -271,13 +490,14 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_c
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_surfacers_internal_postgres_proto_config_proto = out.File
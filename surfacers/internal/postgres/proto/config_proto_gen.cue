@@ -11,6 +11,7 @@ package proto
 	// CREATE TABLE metrics (
 	//   time timestamp, metric_name varchar(80), value float8, labels jsonb
 	// )
+	// Alternatively, use managed_schema to let the surfacer create the table.
 	metricsTableName?: string @protobuf(2,string,name=metrics_table_name)
 
 	// Adding label_to_column fields changes how labels are stored in a Postgres
@@ -21,6 +22,59 @@ package proto
 	// don't have a mapping will be dropped.
 	labelToColumn?: [...#LabelToColumn] @protobuf(4,LabelToColumn,name=label_to_column)
 	metricsBufferSize?: int64 @protobuf(3,int64,name=metrics_buffer_size,"default=10000")
+
+	// If set, surfacer manages the metrics table itself: it creates the table
+	// if it doesn't exist, adds missing columns (e.g. for new label_to_column
+	// entries) and indexes, and, if partitioning is enabled, creates upcoming
+	// time partitions and drops the ones older than the retention period.
+	managedSchema?: #ManagedSchema @protobuf(5,ManagedSchema,name=managed_schema)
+}
+
+#ManagedSchema: {
+	#Partitioning: {
+		// Single table. Retention is enforced by deleting old rows.
+		"NONE"
+		#enumValue: 0
+	} | {
+		// One partition per day.
+		"DAILY"
+		#enumValue: 1
+	} | {
+		// One partition per month.
+		"MONTHLY"
+		#enumValue: 2
+	}
+
+	#Partitioning_value: {
+		NONE:    0
+		DAILY:   1
+		MONTHLY: 2
+	}
+
+	// Partition the table by time. Partitioned tables are much cheaper to
+	// clean up, as old partitions are dropped as a whole. Note that this
+	// setting is used only when creating the table; an existing unpartitioned
+	// table is not converted. Requires Postgres 11 or later.
+	partitioning?: #Partitioning @protobuf(1,Partitioning,"default=DAILY")
+
+	// Number of future partitions to keep created ahead of time.
+	precreatePartitions?: int32 @protobuf(2,int32,name=precreate_partitions,"default=2")
+
+	// Delete data older than this many days. 0 means keep data forever. With
+	// partitioning, a partition is dropped once all its data is older than the
+	// retention period.
+	retentionDays?: int32 @protobuf(3,int32,name=retention_days)
+
+	// Create a GIN index on the labels column. Applicable only if labels are
+	// stored in the JSONB labels column (i.e. label_to_column is not used).
+	indexLabels?: bool @protobuf(4,bool,name=index_labels,"default=false")
+
+	// Labels to expand from the JSONB labels column into their own (generated)
+	// columns, named "label_<label>", with an index on each of them. This makes
+	// filtering on frequently used labels, e.g. probe and dst, fast. Applicable
+	// only if labels are stored in the JSONB labels column. Requires Postgres
+	// 12 or later.
+	expandLabel?: [...string] @protobuf(5,string,name=expand_label)
 }
 
 #LabelToColumn: {
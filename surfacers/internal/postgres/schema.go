@@ -0,0 +1,253 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+// This file implements the managed schema: table creation and upgrade,
+// time partitions and retention.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/postgres/proto"
+	"github.com/lib/pq"
+)
+
+// How often to create new partitions and enforce retention.
+const maintenanceInterval = time.Hour
+
+func validateManagedSchema(c *configpb.SurfacerConf) error {
+	ms := c.GetManagedSchema()
+	if ms == nil {
+		return nil
+	}
+	if len(c.GetLabelToColumn()) > 0 && (ms.GetIndexLabels() || len(ms.GetExpandLabel()) > 0) {
+		return errors.New("postgres: index_labels and expand_label require labels to be stored in the labels column, i.e. no label_to_column")
+	}
+	if ms.GetRetentionDays() < 0 {
+		return fmt.Errorf("postgres: invalid retention_days: %d", ms.GetRetentionDays())
+	}
+	if ms.GetPrecreatePartitions() < 0 {
+		return fmt.Errorf("postgres: invalid precreate_partitions: %d", ms.GetPrecreatePartitions())
+	}
+	return nil
+}
+
+// schemaStatements returns the statements to create the metrics table, or
+// upgrade an existing one. All statements are idempotent.
+func schemaStatements(c *configpb.SurfacerConf) []string {
+	table := c.GetMetricsTableName()
+	qTable := pq.QuoteIdentifier(table)
+	ms := c.GetManagedSchema()
+
+	columns := []string{"time timestamptz NOT NULL", "metric_name varchar(80) NOT NULL", "value float8"}
+	if len(c.GetLabelToColumn()) == 0 {
+		columns = append(columns, "labels jsonb")
+	}
+	for _, ltc := range c.GetLabelToColumn() {
+		columns = append(columns, pq.QuoteIdentifier(ltc.GetColumn())+" text")
+	}
+
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", qTable, strings.Join(columns, ", "))
+	if ms.GetPartitioning() != configpb.ManagedSchema_NONE {
+		create += " PARTITION BY RANGE (time)"
+	}
+	stmts := []string{create}
+
+	// Columns that may have been added to the config after the table was
+	// created.
+	for _, ltc := range c.GetLabelToColumn() {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s text", qTable, pq.QuoteIdentifier(ltc.GetColumn())))
+	}
+
+	stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (time)", pq.QuoteIdentifier(table+"_time_idx"), qTable))
+
+	if ms.GetIndexLabels() {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (labels)", pq.QuoteIdentifier(table+"_labels_idx"), qTable))
+	}
+
+	for _, label := range ms.GetExpandLabel() {
+		column := "label_" + label
+		stmts = append(stmts,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s text GENERATED ALWAYS AS (labels->>%s) STORED", qTable, pq.QuoteIdentifier(column), pq.QuoteLiteral(label)),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", pq.QuoteIdentifier(table+"_"+column+"_idx"), qTable, pq.QuoteIdentifier(column)))
+	}
+
+	return stmts
+}
+
+// partitionStart returns the start of the partition that t falls in.
+func partitionStart(p configpb.ManagedSchema_Partitioning, t time.Time) time.Time {
+	t = t.UTC()
+	if p == configpb.ManagedSchema_MONTHLY {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// nextPartitionStart returns the start of the partition following the one
+// that starts at start.
+func nextPartitionStart(p configpb.ManagedSchema_Partitioning, start time.Time) time.Time {
+	if p == configpb.ManagedSchema_MONTHLY {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+func partitionSuffixLayout(p configpb.ManagedSchema_Partitioning) string {
+	if p == configpb.ManagedSchema_MONTHLY {
+		return "200601"
+	}
+	return "20060102"
+}
+
+// partitionName returns the name of the partition starting at start, e.g.
+// metrics_p20240115 for daily partitions.
+func partitionName(table string, p configpb.ManagedSchema_Partitioning, start time.Time) string {
+	return table + "_p" + start.Format(partitionSuffixLayout(p))
+}
+
+// parsePartitionName returns the start of the partition with the given name.
+func parsePartitionName(table string, p configpb.ManagedSchema_Partitioning, name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, table+"_p")
+	if !ok {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(partitionSuffixLayout(p), suffix)
+	return start, err == nil
+}
+
+// createPartitionStatements returns the statements to create the partitions
+// covering now and the configured number of future partitions.
+func createPartitionStatements(c *configpb.SurfacerConf, now time.Time) []string {
+	p := c.GetManagedSchema().GetPartitioning()
+	table := c.GetMetricsTableName()
+
+	var stmts []string
+	start := partitionStart(p, now)
+	for i := 0; i <= int(c.GetManagedSchema().GetPrecreatePartitions()); i++ {
+		end := nextPartitionStart(p, start)
+		stmts = append(stmts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			pq.QuoteIdentifier(partitionName(table, p, start)), pq.QuoteIdentifier(table), start.Format(time.RFC3339), end.Format(time.RFC3339)))
+		start = end
+	}
+	return stmts
+}
+
+// expiredPartitions returns the partitions, out of the given ones, that
+// contain only data older than the retention period.
+func expiredPartitions(c *configpb.SurfacerConf, partitions []string, now time.Time) []string {
+	retention := c.GetManagedSchema().GetRetentionDays()
+	if retention == 0 {
+		return nil
+	}
+	p := c.GetManagedSchema().GetPartitioning()
+	cutoff := now.AddDate(0, 0, -int(retention))
+
+	var expired []string
+	for _, name := range partitions {
+		start, ok := parsePartitionName(c.GetMetricsTableName(), p, name)
+		if !ok {
+			continue
+		}
+		if !nextPartitionStart(p, start).After(cutoff) {
+			expired = append(expired, name)
+		}
+	}
+	return expired
+}
+
+// initSchema creates or upgrades the metrics table.
+func (s *Surfacer) initSchema(ctx context.Context) error {
+	for _, stmt := range schemaStatements(s.c) {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("postgres: error running %q: %v", stmt, err)
+		}
+	}
+	return s.maintain(ctx, time.Now())
+}
+
+func (s *Surfacer) listPartitions(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid WHERE i.inhparent = $1::regclass", pq.QuoteIdentifier(s.c.GetMetricsTableName()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var partitions []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, name)
+	}
+	return partitions, rows.Err()
+}
+
+// maintain creates upcoming partitions and enforces retention.
+func (s *Surfacer) maintain(ctx context.Context, now time.Time) error {
+	ms := s.c.GetManagedSchema()
+	qTable := pq.QuoteIdentifier(s.c.GetMetricsTableName())
+
+	if ms.GetPartitioning() == configpb.ManagedSchema_NONE {
+		if ms.GetRetentionDays() == 0 {
+			return nil
+		}
+		cutoff := now.AddDate(0, 0, -int(ms.GetRetentionDays()))
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE time < $1", qTable), cutoff); err != nil {
+			return fmt.Errorf("postgres: error deleting old data: %v", err)
+		}
+		return nil
+	}
+
+	for _, stmt := range createPartitionStatements(s.c, now) {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("postgres: error running %q: %v", stmt, err)
+		}
+	}
+
+	partitions, err := s.listPartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: error listing partitions: %v", err)
+	}
+	for _, name := range expiredPartitions(s.c, partitions, now) {
+		s.l.Infof("Dropping expired partition: %s", name)
+		if _, err := s.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+pq.QuoteIdentifier(name)); err != nil {
+			return fmt.Errorf("postgres: error dropping partition %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// runMaintenance runs maintenance periodically until the context is canceled.
+func (s *Surfacer) runMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.maintain(ctx, time.Now()); err != nil {
+				s.l.Warningf("Error during schema maintenance: %v", err)
+			}
+		}
+	}
+}
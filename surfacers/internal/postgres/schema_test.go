@@ -0,0 +1,152 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/postgres/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSchemaStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *configpb.SurfacerConf
+		want []string
+	}{
+		{
+			name: "jsonb_labels_partitioned",
+			conf: &configpb.SurfacerConf{
+				MetricsTableName: proto.String("metrics"),
+				ManagedSchema: &configpb.ManagedSchema{
+					IndexLabels: proto.Bool(true),
+					ExpandLabel: []string{"probe"},
+				},
+			},
+			want: []string{
+				`CREATE TABLE IF NOT EXISTS "metrics" (time timestamptz NOT NULL, metric_name varchar(80) NOT NULL, value float8, labels jsonb) PARTITION BY RANGE (time)`,
+				`CREATE INDEX IF NOT EXISTS "metrics_time_idx" ON "metrics" (time)`,
+				`CREATE INDEX IF NOT EXISTS "metrics_labels_idx" ON "metrics" USING GIN (labels)`,
+				`ALTER TABLE "metrics" ADD COLUMN IF NOT EXISTS "label_probe" text GENERATED ALWAYS AS (labels->>'probe') STORED`,
+				`CREATE INDEX IF NOT EXISTS "metrics_label_probe_idx" ON "metrics" ("label_probe")`,
+			},
+		},
+		{
+			name: "label_columns_unpartitioned",
+			conf: &configpb.SurfacerConf{
+				MetricsTableName: proto.String("metrics"),
+				LabelToColumn: []*configpb.LabelToColumn{
+					{Label: proto.String("probe"), Column: proto.String("probe_name")},
+				},
+				ManagedSchema: &configpb.ManagedSchema{
+					Partitioning: configpb.ManagedSchema_NONE.Enum(),
+				},
+			},
+			want: []string{
+				`CREATE TABLE IF NOT EXISTS "metrics" (time timestamptz NOT NULL, metric_name varchar(80) NOT NULL, value float8, "probe_name" text)`,
+				`ALTER TABLE "metrics" ADD COLUMN IF NOT EXISTS "probe_name" text`,
+				`CREATE INDEX IF NOT EXISTS "metrics_time_idx" ON "metrics" (time)`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, schemaStatements(test.conf))
+		})
+	}
+}
+
+func TestCreatePartitionStatements(t *testing.T) {
+	now := time.Date(2024, 1, 31, 15, 0, 0, 0, time.UTC)
+
+	conf := &configpb.SurfacerConf{
+		MetricsTableName: proto.String("metrics"),
+		ManagedSchema: &configpb.ManagedSchema{
+			PrecreatePartitions: proto.Int32(1),
+		},
+	}
+	assert.Equal(t, []string{
+		`CREATE TABLE IF NOT EXISTS "metrics_p20240131" PARTITION OF "metrics" FOR VALUES FROM ('2024-01-31T00:00:00Z') TO ('2024-02-01T00:00:00Z')`,
+		`CREATE TABLE IF NOT EXISTS "metrics_p20240201" PARTITION OF "metrics" FOR VALUES FROM ('2024-02-01T00:00:00Z') TO ('2024-02-02T00:00:00Z')`,
+	}, createPartitionStatements(conf, now))
+
+	conf.ManagedSchema.Partitioning = configpb.ManagedSchema_MONTHLY.Enum()
+	conf.ManagedSchema.PrecreatePartitions = proto.Int32(0)
+	assert.Equal(t, []string{
+		`CREATE TABLE IF NOT EXISTS "metrics_p202401" PARTITION OF "metrics" FOR VALUES FROM ('2024-01-01T00:00:00Z') TO ('2024-02-01T00:00:00Z')`,
+	}, createPartitionStatements(conf, now))
+}
+
+func TestExpiredPartitions(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		partitioning configpb.ManagedSchema_Partitioning
+		retention    int32
+		partitions   []string
+		want         []string
+	}{
+		{
+			name:         "daily",
+			partitioning: configpb.ManagedSchema_DAILY,
+			retention:    7,
+			// Cutoff is 2024-03-03 15:00, partition 0303 still has data
+			// within the retention period.
+			partitions: []string{"metrics_p20240301", "metrics_p20240302", "metrics_p20240303", "metrics_p20240310", "metrics_default", "other_p20240101"},
+			want:       []string{"metrics_p20240301", "metrics_p20240302"},
+		},
+		{
+			name:         "monthly",
+			partitioning: configpb.ManagedSchema_MONTHLY,
+			retention:    30,
+			partitions:   []string{"metrics_p202401", "metrics_p202402", "metrics_p202403"},
+			want:         []string{"metrics_p202401"},
+		},
+		{
+			name:         "no_retention",
+			partitioning: configpb.ManagedSchema_DAILY,
+			partitions:   []string{"metrics_p20200101"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := &configpb.SurfacerConf{
+				MetricsTableName: proto.String("metrics"),
+				ManagedSchema: &configpb.ManagedSchema{
+					Partitioning:  test.partitioning.Enum(),
+					RetentionDays: proto.Int32(test.retention),
+				},
+			}
+			assert.Equal(t, test.want, expiredPartitions(conf, test.partitions, now))
+		})
+	}
+}
+
+func TestValidateManagedSchema(t *testing.T) {
+	assert.NoError(t, validateManagedSchema(&configpb.SurfacerConf{}))
+	assert.Error(t, validateManagedSchema(&configpb.SurfacerConf{
+		LabelToColumn: []*configpb.LabelToColumn{{Label: proto.String("probe"), Column: proto.String("probe")}},
+		ManagedSchema: &configpb.ManagedSchema{ExpandLabel: []string{"dst"}},
+	}))
+	assert.Error(t, validateManagedSchema(&configpb.SurfacerConf{
+		ManagedSchema: &configpb.ManagedSchema{RetentionDays: proto.Int32(-1)},
+	}))
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// API endpoints, serving the probes' status in JSON format.
+const (
+	apiProbesURL  = "/api/v1/probes"
+	apiTargetsURL = "/api/v1/targets"
+)
+
+// targetState keeps the latest state of a target, used by the JSON API.
+type targetState struct {
+	results        metrics.ResultsTracker
+	timeouts       int64
+	validationFail map[string]int64
+
+	latencyMs   float64 // Average latency over the last update.
+	lastUpdated time.Time
+	lastError   *lastError
+}
+
+type lastError struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+// failureReason tells why the probe failed in the last update, based on the
+// metrics that probes export for failures.
+func failureReason(em *metrics.EventMetrics, state *targetState) string {
+	var reasons []string
+	if v, ok := em.Metric("timeouts").(metrics.NumValue); ok && v.Int64() > state.timeouts {
+		reasons = append(reasons, "timeout")
+	}
+	if m, ok := em.Metric("validation_failure").(*metrics.Map[int64]); ok {
+		for _, k := range m.Keys() {
+			if m.GetKey(k) > state.validationFail[k] {
+				reasons = append(reasons, "validation failed: "+k)
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		return "probe failed"
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// update updates the target's state from the incoming EventMetrics.
+func (state *targetState) update(em *metrics.EventMetrics) {
+	delta, ok := state.results.Update(em)
+	if !ok {
+		return
+	}
+	if delta.Reset {
+		state.timeouts, state.validationFail = 0, nil
+	}
+
+	if delta.Success < delta.Total {
+		state.lastError = &lastError{
+			Time:   em.Timestamp,
+			Reason: failureReason(em, state),
+		}
+	}
+
+	if delta.Latency > 0 {
+		state.latencyMs = float64(delta.Latency) / float64(time.Millisecond)
+	}
+
+	if v, ok := em.Metric("timeouts").(metrics.NumValue); ok {
+		state.timeouts = v.Int64()
+	}
+	if m, ok := em.Metric("validation_failure").(*metrics.Map[int64]); ok {
+		state.validationFail = make(map[string]int64)
+		for _, k := range m.Keys() {
+			state.validationFail[k] = m.GetKey(k)
+		}
+	}
+
+	state.lastUpdated = em.Timestamp
+}
+
+type apiProbe struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type,omitempty"`
+	Targets []string `json:"targets"`
+}
+
+type apiTargetStatus struct {
+	Probe        string             `json:"probe"`
+	Target       string             `json:"target"`
+	Total        int64              `json:"total"`
+	Success      int64              `json:"success"`
+	SuccessRatio map[string]float64 `json:"success_ratio"`
+	LatencyMs    float64            `json:"latency_ms"`
+	LastUpdated  time.Time          `json:"last_updated"`
+	LastError    *lastError         `json:"last_error,omitempty"`
+}
+
+func (ps *Surfacer) targetStatus(probeName, targetName string) *apiTargetStatus {
+	ts, state := ps.metrics[probeName][targetName], ps.states[probeName][targetName]
+	if ts == nil || state == nil {
+		return nil
+	}
+
+	status := &apiTargetStatus{
+		Probe:        probeName,
+		Target:       targetName,
+		Total:        state.results.Total(),
+		Success:      state.results.Success(),
+		SuccessRatio: make(map[string]float64),
+		LatencyMs:    state.latencyMs,
+		LastUpdated:  state.lastUpdated,
+		LastError:    state.lastError,
+	}
+	for i, td := range ps.dashDurations {
+		if t, s := ts.computeDelta(td); t > 0 {
+			status.SuccessRatio[ps.dashDurationsText[i]] = float64(s) / float64(t)
+		}
+	}
+	return status
+}
+
+func (ps *Surfacer) probeStatus(probeName string) []*apiTargetStatus {
+	result := []*apiTargetStatus{}
+	for _, targetName := range ps.probeTargets[probeName] {
+		if status := ps.targetStatus(probeName, targetName); status != nil {
+			result = append(result, status)
+		}
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// serveProbesAPI serves the /api/v1/probes and /api/v1/probes/{name}/status
+// endpoints.
+func (ps *Surfacer) serveProbesAPI(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == apiProbesURL {
+		probes := []*apiProbe{}
+		for _, probeName := range ps.probeNames {
+			probes = append(probes, &apiProbe{
+				Name:    probeName,
+				Type:    ps.probeTypes[probeName],
+				Targets: append([]string{}, ps.probeTargets[probeName]...),
+			})
+		}
+		writeJSON(w, map[string]interface{}{"probes": probes})
+		return
+	}
+
+	probeName, ok := strings.CutSuffix(strings.TrimPrefix(path, apiProbesURL+"/"), "/status")
+	if !ok || probeName == "" || strings.Contains(probeName, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if ps.metrics[probeName] == nil {
+		http.Error(w, "probe not found: "+probeName, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"probe":   probeName,
+		"targets": ps.probeStatus(probeName),
+	})
+}
+
+// serveTargetsAPI serves the /api/v1/targets endpoint: status of all targets
+// across all probes, optionally filtered by the target name.
+func (ps *Surfacer) serveTargetsAPI(w http.ResponseWriter, r *http.Request) {
+	targetFilter := r.URL.Query().Get("target")
+
+	targets := []*apiTargetStatus{}
+	for _, probeName := range ps.probeNames {
+		for _, status := range ps.probeStatus(probeName) {
+			if targetFilter == "" || status.Target == targetFilter {
+				targets = append(targets, status)
+			}
+		}
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].Target < targets[j].Target
+	})
+	writeJSON(w, map[string]interface{}{"targets": targets})
+}
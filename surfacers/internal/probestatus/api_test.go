@@ -0,0 +1,142 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTargetStateUpdate(t *testing.T) {
+	ts := time.Now()
+	em := func(total, success, timeouts int64, latency float64) *metrics.EventMetrics {
+		ts = ts.Add(time.Minute)
+		em := metrics.NewEventMetrics(ts).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success)).
+			AddMetric("timeouts", metrics.NewInt(timeouts)).
+			AddMetric("latency", metrics.NewFloat(latency))
+		em.LatencyUnit = time.Microsecond
+		return em
+	}
+
+	state := &targetState{}
+	state.update(em(10, 10, 0, 1000))
+	assert.Equal(t, 0.1, state.latencyMs)
+	assert.Nil(t, state.lastError)
+
+	state.update(em(20, 18, 2, 3000))
+	assert.Equal(t, 0.25, state.latencyMs)
+	if assert.NotNil(t, state.lastError) {
+		assert.Equal(t, "timeout", state.lastError.Reason)
+		assert.Equal(t, ts, state.lastError.Time)
+	}
+
+	// Validation failures.
+	vfEM := em(30, 27, 2, 5000)
+	vfEM.AddMetric("validation_failure", metrics.NewMap("validator").IncKey("status-code"))
+	state.update(vfEM)
+	assert.Equal(t, "validation failed: status-code", state.lastError.Reason)
+
+	// Probe restart: last error is kept.
+	state.update(em(5, 5, 0, 500))
+	assert.Equal(t, int64(5), state.results.Total())
+	assert.Equal(t, 0.1, state.latencyMs)
+	assert.Equal(t, "validation failed: status-code", state.lastError.Reason)
+}
+
+func TestAPI(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	mux := http.NewServeMux()
+	ps, err := New(ctx, &configpb.SurfacerConf{
+		TimeseriesSize: proto.Int32(10),
+	}, &options.Options{HTTPServeMux: mux}, nil)
+	if err != nil {
+		t.Fatalf("Error creating probestatus surfacer: %v", err)
+	}
+
+	start := time.Now().Add(-5 * time.Minute)
+	for i, tgt := range []string{"t1", "t2"} {
+		for j := 0; j < 3; j++ {
+			total, success := 10*(j+1), 10*(j+1)-i*j // t2 fails sometimes.
+			ps.record(metrics.NewEventMetrics(start.Add(time.Duration(j)*time.Minute)).
+				AddLabel("ptype", "http").
+				AddLabel("probe", "p1").
+				AddLabel("dst", tgt).
+				AddMetric("total", metrics.NewInt(int64(total))).
+				AddMetric("success", metrics.NewInt(int64(success))).
+				AddMetric("latency", metrics.NewFloat(float64(1000*success))))
+		}
+	}
+
+	get := func(url string, v interface{}) int {
+		t.Helper()
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), v))
+		}
+		return w.Code
+	}
+
+	var probesResp struct {
+		Probes []apiProbe `json:"probes"`
+	}
+	assert.Equal(t, http.StatusOK, get("/api/v1/probes", &probesResp))
+	assert.Equal(t, []apiProbe{{Name: "p1", Type: "http", Targets: []string{"t1", "t2"}}}, probesResp.Probes)
+
+	var statusResp struct {
+		Probe   string            `json:"probe"`
+		Targets []apiTargetStatus `json:"targets"`
+	}
+	assert.Equal(t, http.StatusOK, get("/api/v1/probes/p1/status", &statusResp))
+	assert.Equal(t, "p1", statusResp.Probe)
+	if assert.Len(t, statusResp.Targets, 2) {
+		t1, t2 := statusResp.Targets[0], statusResp.Targets[1]
+		assert.Equal(t, "t1", t1.Target)
+		assert.Equal(t, int64(30), t1.Total)
+		assert.Nil(t, t1.LastError)
+		assert.Equal(t, 1.0, t1.LatencyMs)
+
+		assert.Equal(t, "t2", t2.Target)
+		assert.Equal(t, int64(28), t2.Success)
+		assert.NotNil(t, t2.LastError)
+		assert.Less(t, t2.SuccessRatio["10m"], 1.0)
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/probes/p2/status", &statusResp))
+	assert.Equal(t, http.StatusNotFound, get("/api/v1/probes/p1/unknown", &statusResp))
+
+	var targetsResp struct {
+		Targets []apiTargetStatus `json:"targets"`
+	}
+	assert.Equal(t, http.StatusOK, get("/api/v1/targets?target=t2", &targetsResp))
+	if assert.Len(t, targetsResp.Targets, 1) {
+		assert.Equal(t, "p1", targetsResp.Targets[0].Probe)
+		assert.Equal(t, "t2", targetsResp.Targets[0].Target)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// bytesPerPoint is the approximate memory used by a timeseries point: a
+// pointer in the timeseries array and the datum it points to.
+const bytesPerPoint = 32
+
+// snapshot is the on-disk format of the timeseries.
+type snapshot struct {
+	Resolution time.Duration
+	Probes     []snapshotProbe
+}
+
+type snapshotProbe struct {
+	Name    string
+	Targets []snapshotTarget
+}
+
+type snapshotTarget struct {
+	Name      string
+	CurrentTS time.Time
+	StartTime time.Time
+	Total     []int64 // Oldest first.
+	Success   []int64
+}
+
+// points returns the timeseries' data points, oldest first.
+func (ts *timeseries) points() []*datum {
+	var result []*datum
+	for i := ts.oldest; ; i = (i + 1) % len(ts.a) {
+		if ts.a[i] != nil {
+			result = append(result, ts.a[i])
+		}
+		if i == ts.latest {
+			break
+		}
+	}
+	return result
+}
+
+// maxTimeseries returns the number of timeseries that fit in the configured
+// memory cap, or -1 if there is no cap.
+func (ps *Surfacer) maxTimeseries() int {
+	if ps.c.GetMaxMemoryMb() <= 0 {
+		return -1
+	}
+	return int(ps.c.GetMaxMemoryMb()) << 20 / (ps.tsSize * bytesPerPoint)
+}
+
+func (ps *Surfacer) numTimeseries() int {
+	n := 0
+	for _, probeTS := range ps.metrics {
+		n += len(probeTS)
+	}
+	return n
+}
+
+// save writes the timeseries to the persistence file. It writes to a
+// temporary file first, to not leave a partially written file behind.
+func (ps *Surfacer) save() error {
+	snap := &snapshot{Resolution: ps.resolution}
+	for _, probeName := range ps.probeNames {
+		sp := snapshotProbe{Name: probeName}
+		for _, targetName := range ps.probeTargets[probeName] {
+			ts := ps.metrics[probeName][targetName]
+			if ts == nil {
+				continue
+			}
+			st := snapshotTarget{
+				Name:      targetName,
+				CurrentTS: ts.currentTS,
+				StartTime: ts.startTime,
+			}
+			for _, d := range ts.points() {
+				st.Total, st.Success = append(st.Total, d.total), append(st.Success, d.success)
+			}
+			sp.Targets = append(sp.Targets, st)
+		}
+		snap.Probes = append(snap.Probes, sp)
+	}
+
+	fileName := ps.c.GetPersistenceFile()
+	f, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := gob.NewEncoder(f).Encode(snap); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), fileName)
+}
+
+func (ps *Surfacer) persist() {
+	if err := ps.save(); err != nil {
+		ps.l.Errorf("Error saving timeseries to %s: %v", ps.c.GetPersistenceFile(), err)
+	}
+}
+
+// load restores the timeseries from the persistence file, if it exists. Data
+// older than the retention period is skipped.
+func (ps *Surfacer) load() error {
+	f, err := os.Open(ps.c.GetPersistenceFile())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	snap := &snapshot{}
+	if err := gob.NewDecoder(f).Decode(snap); err != nil {
+		return fmt.Errorf("error decoding timeseries from %s: %v", ps.c.GetPersistenceFile(), err)
+	}
+	if snap.Resolution != ps.resolution {
+		ps.l.Warningf("Resolution changed (%v -> %v), not restoring the timeseries", snap.Resolution, ps.resolution)
+		return nil
+	}
+
+	retention := time.Duration(ps.tsSize) * ps.resolution
+	for _, sp := range snap.Probes {
+		for _, st := range sp.Targets {
+			if time.Since(st.CurrentTS) > retention || len(st.Total) == 0 || len(st.Total) != len(st.Success) {
+				continue
+			}
+			if ps.metrics[sp.Name] == nil {
+				ps.metrics[sp.Name] = make(map[string]*timeseries)
+				ps.states[sp.Name] = make(map[string]*targetState)
+				ps.probeNames = append(ps.probeNames, sp.Name)
+			}
+			if len(ps.metrics[sp.Name]) >= int(ps.c.GetMaxTargetsPerProbe()) {
+				continue
+			}
+			if maxTS := ps.maxTimeseries(); maxTS != -1 && ps.numTimeseries() >= maxTS {
+				return nil
+			}
+
+			ts := newTimeseries(ps.resolution, ps.tsSize, ps.l)
+			// Keep the latest points that fit in the timeseries.
+			first := max(0, len(st.Total)-ps.tsSize)
+			for i := first; i < len(st.Total); i++ {
+				ts.a[i-first] = &datum{total: st.Total[i], success: st.Success[i]}
+			}
+			ts.latest = len(st.Total) - first - 1
+			ts.currentTS, ts.startTime = st.CurrentTS, st.StartTime
+			// Probes start over after restart, so new data continues from
+			// the restored values.
+			ts.offset = *ts.a[ts.latest]
+
+			ps.metrics[sp.Name][st.Name] = ts
+			ps.states[sp.Name][st.Name] = &targetState{}
+			ps.probeTargets[sp.Name] = append(ps.probeTargets[sp.Name], st.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func recordTestData(ps *Surfacer, start time.Time, probe, target string, totals []int64) {
+	for i, total := range totals {
+		ps.record(metrics.NewEventMetrics(start.Add(time.Duration(i)*time.Minute)).
+			AddLabel("probe", probe).
+			AddLabel("dst", target).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(total)))
+	}
+}
+
+func pointTotals(ts *timeseries) []int64 {
+	var result []int64
+	for _, d := range ts.points() {
+		result = append(result, d.total)
+	}
+	return result
+}
+
+func TestCounterReset(t *testing.T) {
+	ts := newTimeseries(time.Minute, 10, nil)
+	start := time.Now().Truncate(time.Minute)
+	for i, total := range []int64{10, 20, 5, 15} {
+		ts.addDatum(start.Add(time.Duration(i)*time.Minute), &datum{total: total, success: total})
+	}
+	assert.Equal(t, []int64{10, 20, 25, 35}, pointTotals(ts))
+}
+
+func TestPersistence(t *testing.T) {
+	persistenceFile := filepath.Join(t.TempDir(), "probestatus.data")
+	conf := &configpb.SurfacerConf{
+		RetentionSec:    proto.Int32(600),
+		PersistenceFile: proto.String(persistenceFile),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ps, err := New(ctx, conf, &options.Options{HTTPServeMux: http.NewServeMux()}, nil)
+	if err != nil {
+		t.Fatalf("Error creating probestatus surfacer: %v", err)
+	}
+	assert.Equal(t, 10, ps.tsSize)
+
+	start := time.Now().Truncate(time.Minute).Add(-5 * time.Minute)
+	recordTestData(ps, start, "p1", "t1", []int64{10, 20, 30})
+	recordTestData(ps, start, "p1", "t2", []int64{10})
+	assert.NoError(t, ps.save())
+
+	// New surfacer, as after restart.
+	ps2, err := New(ctx, conf, &options.Options{HTTPServeMux: http.NewServeMux()}, nil)
+	if err != nil {
+		t.Fatalf("Error creating probestatus surfacer: %v", err)
+	}
+	assert.Equal(t, []string{"p1"}, ps2.probeNames)
+	assert.Equal(t, []string{"t1", "t2"}, ps2.probeTargets["p1"])
+	assert.Equal(t, []int64{10, 20, 30}, pointTotals(ps2.metrics["p1"]["t1"]))
+	assert.Equal(t, ps.metrics["p1"]["t1"].currentTS, ps2.metrics["p1"]["t1"].currentTS)
+
+	// New data, with counters starting over, continues from the restored data.
+	recordTestData(ps2, start.Add(3*time.Minute), "p1", "t1", []int64{5})
+	assert.Equal(t, []int64{10, 20, 30, 35}, pointTotals(ps2.metrics["p1"]["t1"]))
+}
+
+func TestMemoryCap(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ps, err := New(ctx, &configpb.SurfacerConf{
+		// Each timeseries takes 1MB: 32768 points * 32 bytes.
+		TimeseriesSize: proto.Int32(32768),
+		MaxMemoryMb:    proto.Int32(2),
+	}, &options.Options{HTTPServeMux: http.NewServeMux()}, nil)
+	if err != nil {
+		t.Fatalf("Error creating probestatus surfacer: %v", err)
+	}
+
+	for _, target := range []string{"t1", "t2", "t3"} {
+		recordTestData(ps, time.Now(), "p1", target, []int64{10})
+	}
+	assert.Equal(t, []string{"t1", "t2"}, ps.probeTargets["p1"])
+}
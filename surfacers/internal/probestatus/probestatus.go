@@ -60,6 +60,9 @@ type httpWriter struct {
 	w        http.ResponseWriter
 	r        *http.Request
 	doneChan chan struct{}
+
+	// If set, used to serve the request instead of the status page.
+	serve func(http.ResponseWriter, *http.Request)
 }
 
 type pageCache struct {
@@ -103,13 +106,19 @@ type Surfacer struct {
 	l         *logger.Logger
 
 	resolution   time.Duration
+	tsSize       int
 	metrics      map[string]map[string]*timeseries
+	states       map[string]map[string]*targetState
 	probeNames   []string
+	probeTypes   map[string]string
 	probeTargets map[string][]string
 
 	// Dashboard page cache.
 	pageCache *pageCache
 
+	// Whether memory cap has been reached. Used to log only once.
+	memCapReached bool
+
 	// Dashboard Metadata
 	dashDurations     []time.Duration
 	dashDurationsText []string
@@ -137,22 +146,43 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		res = time.Minute
 	}
 
+	tsSize := int(config.GetTimeseriesSize())
+	if config.GetRetentionSec() > 0 {
+		tsSize = int(time.Duration(config.GetRetentionSec()) * time.Second / res)
+	}
+	if tsSize < 2 {
+		return nil, fmt.Errorf("timeseries size (%d) should be at least 2, check retention_sec and timeseries_size", tsSize)
+	}
+
 	ps := &Surfacer{
 		c:            config,
 		opts:         opts,
 		emChan:       make(chan *metrics.EventMetrics, metricsBufferSize),
 		queryChan:    make(chan *httpWriter, queriesQueueSize),
 		metrics:      make(map[string]map[string]*timeseries),
+		states:       make(map[string]map[string]*targetState),
+		probeTypes:   make(map[string]string),
 		probeTargets: make(map[string][]string),
 		startTime:    sysvars.StartTime().Truncate(time.Millisecond),
 
 		resolution: res,
+		tsSize:     tsSize,
 		l:          l,
 	}
 
-	ps.dashDurations, ps.dashDurationsText = dashboardDurations(ps.resolution * time.Duration(ps.c.GetTimeseriesSize()))
+	ps.dashDurations, ps.dashDurationsText = dashboardDurations(ps.resolution * time.Duration(ps.tsSize))
 	ps.pageCache = newPageCache(int(ps.c.GetCacheTimeSec()))
 
+	var persistTicker <-chan time.Time
+	if ps.c.GetPersistenceFile() != "" {
+		if err := ps.load(); err != nil {
+			ps.l.Warningf("Error restoring timeseries from %s: %v", ps.c.GetPersistenceFile(), err)
+		}
+		ticker := time.NewTicker(time.Duration(ps.c.GetPersistenceIntervalSec()) * time.Second)
+		persistTicker = ticker.C
+		context.AfterFunc(ctx, ticker.Stop)
+	}
+
 	// Start a goroutine to process the incoming EventMetrics as well as
 	// the incoming web queries. To avoid data access race conditions, we do
 	// one thing at a time.
@@ -161,23 +191,37 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 			select {
 			case <-ctx.Done():
 				ps.l.Infof("Context canceled, stopping the input/output processing loop.")
+				if persistTicker != nil {
+					ps.persist()
+				}
 				return
+			case <-persistTicker:
+				ps.persist()
 			case em := <-ps.emChan:
 				ps.record(em)
 			case hw := <-ps.queryChan:
-				ps.writeData(hw)
+				if hw.serve != nil {
+					hw.serve(hw.w, hw.r)
+				} else {
+					ps.writeData(hw)
+				}
 				close(hw.doneChan)
 			}
 		}
 	}()
 
-	opts.HTTPServeMux.HandleFunc(config.GetUrl(), func(w http.ResponseWriter, r *http.Request) {
-		// doneChan is used to track the completion of the response writing. This is
-		// required as response is written in a different goroutine.
-		doneChan := make(chan struct{}, 1)
-		ps.queryChan <- &httpWriter{w, r, doneChan}
-		<-doneChan
-	})
+	opts.HTTPServeMux.HandleFunc(config.GetUrl(), ps.queryHandler(nil))
+
+	// JSON API endpoints.
+	for url, serve := range map[string]func(http.ResponseWriter, *http.Request){
+		apiProbesURL:       ps.serveProbesAPI,
+		apiProbesURL + "/": ps.serveProbesAPI,
+		apiTargetsURL:      ps.serveTargetsAPI,
+	} {
+		if !webutils.IsHandled(opts.HTTPServeMux, url) {
+			opts.HTTPServeMux.HandleFunc(url, ps.queryHandler(serve))
+		}
+	}
 
 	if !webutils.IsHandled(opts.HTTPServeMux, "/probestatus") {
 		opts.HTTPServeMux.Handle("/probestatus", http.RedirectHandler(config.GetUrl(), http.StatusFound))
@@ -199,6 +243,19 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	return ps, nil
 }
 
+// queryHandler returns an HTTP handler that serves the request in the
+// processing goroutine, using the serve function, or the status page if serve
+// is nil.
+func (ps *Surfacer) queryHandler(serve func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// doneChan is used to track the completion of the response writing. This is
+		// required as response is written in a different goroutine.
+		doneChan := make(chan struct{}, 1)
+		ps.queryChan <- &httpWriter{w: w, r: r, doneChan: doneChan, serve: serve}
+		<-doneChan
+	}
+}
+
 // Write queues the incoming data into a channel. This channel is watched by a
 // goroutine that actually processes the data and updates the in-memory
 // database.
@@ -232,8 +289,10 @@ func (ps *Surfacer) record(em *metrics.EventMetrics) {
 	if probeTS == nil {
 		probeTS = make(map[string]*timeseries)
 		ps.metrics[probeName] = probeTS
+		ps.states[probeName] = make(map[string]*targetState)
 		ps.probeNames = append(ps.probeNames, probeName)
 	}
+	ps.probeTypes[probeName] = em.Label("ptype")
 
 	targetTS := probeTS[targetName]
 	if targetTS == nil {
@@ -243,8 +302,16 @@ func (ps *Surfacer) record(em *metrics.EventMetrics) {
 		if len(probeTS) >= int(ps.c.GetMaxTargetsPerProbe()) {
 			return
 		}
-		targetTS = newTimeseries(ps.resolution, int(ps.c.GetTimeseriesSize()), ps.l)
+		if maxTS := ps.maxTimeseries(); maxTS != -1 && ps.numTimeseries() >= maxTS {
+			if !ps.memCapReached {
+				ps.l.Warningf("Reached the memory cap (%d MB) with target \"%s\". All new targets will be silently dropped.", ps.c.GetMaxMemoryMb(), targetName)
+				ps.memCapReached = true
+			}
+			return
+		}
+		targetTS = newTimeseries(ps.resolution, ps.tsSize, ps.l)
 		probeTS[targetName] = targetTS
+		ps.states[probeName][targetName] = &targetState{}
 		ps.probeTargets[probeName] = append(ps.probeTargets[probeName], targetName)
 	}
 
@@ -252,10 +319,12 @@ func (ps *Surfacer) record(em *metrics.EventMetrics) {
 		total:   total.Int64(),
 		success: success.Int64(),
 	})
+	ps.states[probeName][targetName].update(em)
 }
 
 func (ps *Surfacer) deleteTargetWithNoLock(probeName, targetName string) {
 	delete(ps.metrics[probeName], targetName)
+	delete(ps.states[probeName], targetName)
 
 	targets := ps.probeTargets[probeName]
 
@@ -356,7 +425,7 @@ func (ps *Surfacer) writeData(hw *httpWriter) {
 	if v := hw.r.URL.Query()["probe"]; v != nil {
 		probes = v
 	}
-	maxDuration := time.Duration(ps.c.GetTimeseriesSize()) * ps.resolution
+	maxDuration := time.Duration(ps.tsSize) * ps.resolution
 	graphOpts := graphOptsFromURL(hw.r.URL.Query(), maxDuration, ps.l)
 
 	for _, probeName := range probes {
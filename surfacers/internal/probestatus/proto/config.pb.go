@@ -42,15 +42,28 @@ type SurfacerConf struct {
 	// Probestatus surfacer is enabled by default. To disable it, set this
 	// option.
 	Disable *bool `protobuf:"varint,6,opt,name=disable" json:"disable,omitempty"`
+	// How long to keep the data for, e.g. 86400 for 24h. If set, it overrides
+	// timeseries_size: timeseries_size = retention_sec / resolution_sec.
+	RetentionSec *int32 `protobuf:"varint,7,opt,name=retention_sec,json=retentionSec" json:"retention_sec,omitempty"`
+	// Approximate cap on the memory used by the timeseries, in MB. Once the
+	// cap is reached, new targets are not tracked. Default is no cap.
+	MaxMemoryMb *int32 `protobuf:"varint,8,opt,name=max_memory_mb,json=maxMemoryMb" json:"max_memory_mb,omitempty"`
+	// If set, timeseries are saved to this file periodically and on exit,
+	// and are restored from it on startup, so that the status page keeps the
+	// history across restarts.
+	PersistenceFile *string `protobuf:"bytes,9,opt,name=persistence_file,json=persistenceFile" json:"persistence_file,omitempty"`
+	// How often to save the timeseries to the persistence_file.
+	PersistenceIntervalSec *int32 `protobuf:"varint,10,opt,name=persistence_interval_sec,json=persistenceIntervalSec,def=300" json:"persistence_interval_sec,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
 const (
-	Default_SurfacerConf_ResolutionSec      = int32(60)
-	Default_SurfacerConf_TimeseriesSize     = int32(4320)
-	Default_SurfacerConf_MaxTargetsPerProbe = int32(20)
-	Default_SurfacerConf_Url                = string("/status")
-	Default_SurfacerConf_CacheTimeSec       = int32(2)
+	Default_SurfacerConf_ResolutionSec          = int32(60)
+	Default_SurfacerConf_TimeseriesSize         = int32(4320)
+	Default_SurfacerConf_MaxTargetsPerProbe     = int32(20)
+	Default_SurfacerConf_Url                    = string("/status")
+	Default_SurfacerConf_CacheTimeSec           = int32(2)
+	Default_SurfacerConf_PersistenceIntervalSec = int32(300)
 )
 
 func (x *SurfacerConf) Reset() {
@@ -127,6 +140,34 @@ func (x *SurfacerConf) GetDisable() bool {
 	return false
 }
 
+func (x *SurfacerConf) GetRetentionSec() int32 {
+	if x != nil && x.RetentionSec != nil {
+		return *x.RetentionSec
+	}
+	return 0
+}
+
+func (x *SurfacerConf) GetMaxMemoryMb() int32 {
+	if x != nil && x.MaxMemoryMb != nil {
+		return *x.MaxMemoryMb
+	}
+	return 0
+}
+
+func (x *SurfacerConf) GetPersistenceFile() string {
+	if x != nil && x.PersistenceFile != nil {
+		return *x.PersistenceFile
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetPersistenceIntervalSec() int32 {
+	if x != nil && x.PersistenceIntervalSec != nil {
+		return *x.PersistenceIntervalSec
+	}
+	return Default_SurfacerConf_PersistenceIntervalSec
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_rawDesc = []byte{
@@ -137,7 +178,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto
 	0x74, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x20, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
 	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xfd, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72,
+	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xb0, 0x03, 0x0a, 0x0c, 0x53, 0x75, 0x72,
 	0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x29, 0x0a, 0x0e, 0x72, 0x65, 0x73,
 	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x05, 0x3a, 0x02, 0x36, 0x30, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
@@ -153,12 +194,23 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto
 	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x32,
 	0x52, 0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x12, 0x18,
 	0x0a, 0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f,
+	0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x12, 0x22, 0x0a,
+	0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x62, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d,
+	0x62, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x18,
+	0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03,
+	0x33, 0x30, 0x30, 0x52, 0x16, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x42, 0x49, 0x5a, 0x47, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
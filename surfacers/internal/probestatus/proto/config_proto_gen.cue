@@ -23,4 +23,20 @@ package proto
 	// Probestatus surfacer is enabled by default. To disable it, set this
 	// option.
 	disable?: bool @protobuf(6,bool)
+
+	// How long to keep the data for, e.g. 86400 for 24h. If set, it overrides
+	// timeseries_size: timeseries_size = retention_sec / resolution_sec.
+	retentionSec?: int32 @protobuf(7,int32,name=retention_sec)
+
+	// Approximate cap on the memory used by the timeseries, in MB. Once the
+	// cap is reached, new targets are not tracked. Default is no cap.
+	maxMemoryMb?: int32 @protobuf(8,int32,name=max_memory_mb)
+
+	// If set, timeseries are saved to this file periodically and on exit,
+	// and are restored from it on startup, so that the status page keeps the
+	// history across restarts.
+	persistenceFile?: string @protobuf(9,string,name=persistence_file)
+
+	// How often to save the timeseries to the persistence_file.
+	persistenceIntervalSec?: int32 @protobuf(10,int32,name=persistence_interval_sec,"default=300")
 }
@@ -27,6 +27,11 @@ type timeseries struct {
 	currentTS      time.Time
 	startTime      time.Time
 	l              *logger.Logger
+
+	// Cumulative counters go back to zero when probes restart. To keep the
+	// timeseries monotonic, we add the last values before the reset (offset)
+	// to the new values.
+	lastRaw, offset datum
 }
 
 func (ts *timeseries) shallowCopy() *timeseries {
@@ -52,6 +57,15 @@ func newTimeseries(resolution time.Duration, size int, l *logger.Logger) *timese
 }
 
 func (ts *timeseries) addDatum(t time.Time, d *datum) {
+	if d.total < ts.lastRaw.total {
+		ts.offset.total += ts.lastRaw.total
+		ts.offset.success += ts.lastRaw.success
+	}
+	ts.lastRaw = *d
+	if ts.offset.total != 0 {
+		d = &datum{total: d.total + ts.offset.total, success: d.success + ts.offset.success}
+	}
+
 	tt := t.Truncate(ts.res)
 	// Need a new bucket
 	if tt.After(ts.currentTS) && !ts.currentTS.IsZero() {
@@ -0,0 +1,278 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+// This file implements the Prometheus protobuf exposition format, which is
+// required for exporting native histograms. We encode the messages directly
+// using protowire, to avoid depending on the Prometheus client libraries.
+// Message definitions:
+// https://github.com/prometheus/client_model/blob/master/io/prometheus/client/metrics.proto
+
+import (
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const protobufContentType = "application/vnd.google.protobuf; proto=io.prometheus.client.MetricFamily; encoding=delimited"
+
+// Zero threshold used by the Prometheus client libraries by default.
+const nativeZeroThreshold = 2.938735877055719e-39
+
+// MetricType values from the Prometheus client model.
+const (
+	pbCounter   = 0
+	pbGauge     = 1
+	pbUntyped   = 3
+	pbHistogram = 4
+)
+
+// histPoint holds the latest distribution for a native histogram series.
+type histPoint struct {
+	labels    []string
+	data      *metrics.DistributionData
+	timestamp int64
+}
+
+// acceptsProtobuf reports whether the scrape request accepts the delimited
+// protobuf exposition format.
+func acceptsProtobuf(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType == "application/vnd.google.protobuf" && params["proto"] == "io.prometheus.client.MetricFamily" && params["encoding"] == "delimited" {
+			return true
+		}
+	}
+	return false
+}
+
+// representativeValue returns a value that stands for all the samples in a
+// distribution bucket [lb, ub).
+func representativeValue(lb, ub float64) float64 {
+	switch {
+	case math.IsInf(lb, -1):
+		return ub
+	case math.IsInf(ub, 1):
+		return lb
+	case lb > 0:
+		return math.Sqrt(lb * ub)
+	default:
+		return (lb + ub) / 2
+	}
+}
+
+// nativeIndex returns the index of the native histogram bucket that v (> 0)
+// falls in for the given schema. Bucket i covers (base^(i-1), base^i], where
+// base is 2^(2^-schema).
+func nativeIndex(v float64, schema int32) int {
+	return int(math.Ceil(math.Log2(v) * math.Exp2(float64(schema))))
+}
+
+// nativeBuckets maps the distribution's buckets to native histogram buckets.
+func nativeBuckets(d *metrics.DistributionData, schema int32) (zeroCount int64, pos, neg map[int]int64) {
+	pos, neg = make(map[int]int64), make(map[int]int64)
+	for i, count := range d.BucketCounts {
+		if count == 0 {
+			continue
+		}
+		ub := math.Inf(1)
+		if i < len(d.LowerBounds)-1 {
+			ub = d.LowerBounds[i+1]
+		}
+		v := representativeValue(d.LowerBounds[i], ub)
+		switch {
+		case math.Abs(v) <= nativeZeroThreshold:
+			zeroCount += count
+		case v > 0:
+			pos[nativeIndex(v, schema)] += count
+		default:
+			neg[nativeIndex(-v, schema)] += count
+		}
+	}
+	return zeroCount, pos, neg
+}
+
+// appendSpans appends the bucket spans and deltas for the given buckets to b,
+// using the given field numbers.
+func appendSpans(b []byte, buckets map[int]int64, spanField, deltaField protowire.Number) []byte {
+	indices := make([]int, 0, len(buckets))
+	for i := range buckets {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	var span []byte
+	var spanLen uint64
+	endSpan := func() {
+		if spanLen != 0 {
+			span = protowire.AppendTag(span, 2, protowire.VarintType)
+			span = protowire.AppendVarint(span, spanLen)
+			b = protowire.AppendTag(b, spanField, protowire.BytesType)
+			b = protowire.AppendBytes(b, span)
+		}
+	}
+
+	var prevIndex int
+	var prevCount int64
+	for n, i := range indices {
+		// Start a new span for the first bucket and on any gap.
+		if n == 0 || i != prevIndex+1 {
+			endSpan()
+			offset := i
+			if n != 0 {
+				offset = i - prevIndex - 1
+			}
+			span = protowire.AppendTag(nil, 1, protowire.VarintType)
+			span = protowire.AppendVarint(span, protowire.EncodeZigZag(int64(offset)))
+			spanLen = 0
+		}
+		spanLen++
+		prevIndex = i
+	}
+	endSpan()
+
+	// Bucket counts are delta encoded across all spans.
+	var deltas []byte
+	for _, i := range indices {
+		deltas = protowire.AppendVarint(deltas, protowire.EncodeZigZag(buckets[i]-prevCount))
+		prevCount = buckets[i]
+	}
+	if len(deltas) != 0 {
+		b = protowire.AppendTag(b, deltaField, protowire.BytesType)
+		b = protowire.AppendBytes(b, deltas)
+	}
+	return b
+}
+
+// appendNativeHistogram appends the Histogram message for d to b.
+func appendNativeHistogram(b []byte, d *metrics.DistributionData, schema int32) []byte {
+	zeroCount, pos, neg := nativeBuckets(d, schema)
+
+	b = protowire.AppendTag(b, 1, protowire.VarintType) // sample_count
+	b = protowire.AppendVarint(b, uint64(d.Count))
+	b = protowire.AppendTag(b, 2, protowire.Fixed64Type) // sample_sum
+	b = protowire.AppendFixed64(b, math.Float64bits(d.Sum))
+	b = protowire.AppendTag(b, 5, protowire.VarintType) // schema
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(schema)))
+	b = protowire.AppendTag(b, 6, protowire.Fixed64Type) // zero_threshold
+	b = protowire.AppendFixed64(b, math.Float64bits(nativeZeroThreshold))
+	b = protowire.AppendTag(b, 7, protowire.VarintType) // zero_count
+	b = protowire.AppendVarint(b, uint64(zeroCount))
+	b = appendSpans(b, neg, 9, 10)
+	b = appendSpans(b, pos, 12, 13)
+
+	// A histogram without any buckets is interpreted as a classic histogram
+	// by Prometheus. Add an empty span to mark it as native.
+	if len(pos) == 0 && len(neg) == 0 {
+		b = protowire.AppendTag(b, 12, protowire.BytesType)
+		b = protowire.AppendBytes(b, nil)
+	}
+	return b
+}
+
+// parseLabel splits a label string of the form name="value".
+func parseLabel(label string) (string, string) {
+	name, value, _ := strings.Cut(label, "=")
+	return name, strings.TrimSuffix(strings.TrimPrefix(value, "\""), "\"")
+}
+
+// appendMetric appends a Metric message, with the given labels, timestamp
+// and value field (already encoded), to b.
+func (ps *PromSurfacer) appendMetric(b []byte, labels []string, timestamp int64, valueField protowire.Number, value []byte) []byte {
+	var m []byte
+	for _, label := range labels {
+		name, value := parseLabel(label)
+		var lp []byte
+		lp = protowire.AppendTag(lp, 1, protowire.BytesType)
+		lp = protowire.AppendString(lp, name)
+		lp = protowire.AppendTag(lp, 2, protowire.BytesType)
+		lp = protowire.AppendString(lp, value)
+		m = protowire.AppendTag(m, 1, protowire.BytesType)
+		m = protowire.AppendBytes(m, lp)
+	}
+	m = protowire.AppendTag(m, valueField, protowire.BytesType)
+	m = protowire.AppendBytes(m, value)
+	if ps.c.GetIncludeTimestamp() {
+		m = protowire.AppendTag(m, 6, protowire.VarintType)
+		m = protowire.AppendVarint(m, uint64(timestamp))
+	}
+
+	b = protowire.AppendTag(b, 4, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+func protoType(typ string) (uint64, protowire.Number) {
+	switch typ {
+	case "counter":
+		return pbCounter, 3
+	case "gauge":
+		return pbGauge, 2
+	default:
+		return pbUntyped, 5
+	}
+}
+
+// metricFamily encodes a MetricFamily message for the given metric.
+func (ps *PromSurfacer) metricFamily(name string, pm *promMetric) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, name)
+
+	if pm.typ == histogram {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, pbHistogram)
+		for _, k := range pm.histKeys {
+			hp := pm.hists[k]
+			b = ps.appendMetric(b, hp.labels, hp.timestamp, 7, appendNativeHistogram(nil, hp.data, ps.c.GetNativeHistogramSchema()))
+		}
+		return b
+	}
+
+	typ, valueField := protoType(pm.typ)
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, typ)
+	for _, k := range pm.dataKeys {
+		dp := pm.data[k]
+		f, err := strconv.ParseFloat(dp.value, 64)
+		if err != nil {
+			ps.l.Warningf("Skipping non-numeric value for %s: %s", k, dp.value)
+			continue
+		}
+		var value []byte
+		value = protowire.AppendTag(value, 1, protowire.Fixed64Type)
+		value = protowire.AppendFixed64(value, math.Float64bits(f))
+		b = ps.appendMetric(b, dp.labels, dp.timestamp, valueField, value)
+	}
+	return b
+}
+
+// writeProtoData writes metrics data on w in the delimited protobuf format.
+func (ps *PromSurfacer) writeProtoData(w io.Writer) {
+	for _, name := range ps.metricNames {
+		mf := ps.metricFamily(name, ps.metrics[name])
+		w.Write(protowire.AppendVarint(nil, uint64(len(mf))))
+		w.Write(mf)
+	}
+}
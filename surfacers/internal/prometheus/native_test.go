@@ -0,0 +1,227 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/prometheus/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// pbFields decodes a protobuf message into field number -> values. Varint
+// and fixed64 values are returned as uint64, bytes values as []byte.
+func pbFields(t *testing.T, b []byte) map[protowire.Number][]any {
+	t.Helper()
+	out := make(map[protowire.Number][]any)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		assert.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		var v any
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		default:
+			t.Fatalf("unexpected wire type: %v", typ)
+		}
+		if n < 0 {
+			t.Fatalf("error decoding field %d", num)
+		}
+		b = b[n:]
+		out[num] = append(out[num], v)
+	}
+	return out
+}
+
+func testDistribution() *metrics.Distribution {
+	d := metrics.NewDistribution([]float64{0, 1, 4})
+	d.AddSample(-1) // [-Inf, 0): zero bucket
+	d.AddSample(0.5)
+	d.AddSample(2)
+	d.AddSample(5)
+	return d
+}
+
+func TestNativeBuckets(t *testing.T) {
+	tests := []struct {
+		schema    int32
+		zeroCount int64
+		pos       map[int]int64
+	}{
+		{
+			// Representative values: 0.5, 2, 4 -> buckets (0.25,0.5], (1,2], (2,4]
+			schema:    0,
+			zeroCount: 1,
+			pos:       map[int]int64{-1: 1, 1: 1, 2: 1},
+		},
+		{
+			schema:    2,
+			zeroCount: 1,
+			pos:       map[int]int64{-4: 1, 4: 1, 8: 1},
+		},
+		{
+			// Representative values 2 and 4 share the bucket (1,4].
+			schema:    -1,
+			zeroCount: 1,
+			pos:       map[int]int64{0: 1, 1: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("schema=%d", test.schema), func(t *testing.T) {
+			zeroCount, pos, neg := nativeBuckets(testDistribution().Data(), test.schema)
+			assert.Equal(t, test.zeroCount, zeroCount)
+			assert.Equal(t, test.pos, pos)
+			assert.Empty(t, neg)
+		})
+	}
+
+	d := metrics.NewDistribution([]float64{-4, -1})
+	d.AddSample(-2)
+	_, pos, neg := nativeBuckets(d.Data(), 0)
+	assert.Empty(t, pos)
+	assert.Equal(t, map[int]int64{2: 1}, neg) // midpoint -2.5
+}
+
+func TestAppendNativeHistogram(t *testing.T) {
+	f := pbFields(t, appendNativeHistogram(nil, testDistribution().Data(), 0))
+
+	assert.Equal(t, []any{uint64(4)}, f[1])                 // sample_count
+	assert.Equal(t, []any{math.Float64bits(6.5)}, f[2])     // sample_sum
+	assert.Equal(t, []any{protowire.EncodeZigZag(0)}, f[5]) // schema
+	assert.Equal(t, []any{uint64(1)}, f[7])                 // zero_count
+	assert.Len(t, f[12], 2)                                 // positive_span
+	assert.Equal(t, []any{[]byte{2, 0, 0}}, f[13])          // positive_delta: 1, 0, 0
+	assert.Empty(t, f[9])                                   // negative_span
+
+	// Buckets -1, 1, 2: span{offset: -1, length: 1}, span{offset: 1, length: 2}
+	span1, span2 := pbFields(t, f[12][0].([]byte)), pbFields(t, f[12][1].([]byte))
+	assert.Equal(t, []any{protowire.EncodeZigZag(-1)}, span1[1])
+	assert.Equal(t, []any{uint64(1)}, span1[2])
+	assert.Equal(t, []any{protowire.EncodeZigZag(1)}, span2[1])
+	assert.Equal(t, []any{uint64(2)}, span2[2])
+
+	// Empty histogram still carries a span to be recognized as native.
+	f = pbFields(t, appendNativeHistogram(nil, metrics.NewDistribution([]float64{1}).Data(), 0))
+	assert.Equal(t, []any{[]byte{}}, f[12])
+}
+
+func TestAcceptsProtobuf(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"": false,
+		"text/plain;version=0.0.4;q=0.5,*/*;q=0.1": false,
+		"application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3": true,
+		"application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=text":                                           false,
+	} {
+		r := httptest.NewRequest("GET", "/metrics", nil)
+		r.Header.Set("Accept", accept)
+		assert.Equal(t, want, acceptsProtobuf(r), "accept: %s", accept)
+	}
+}
+
+func TestScrapeProtobuf(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux := http.NewServeMux()
+	url := fmt.Sprintf("/metrics_%d", rand.Int())
+	ps, err := New(ctx, &configpb.SurfacerConf{
+		MetricsUrl:             proto.String(url),
+		EnableNativeHistograms: proto.Bool(true),
+		NativeHistogramSchema:  proto.Int32(0),
+	}, &options.Options{HTTPServeMux: mux}, nil)
+	if err != nil {
+		t.Fatalf("Error creating prometheus surfacer: %v", err)
+	}
+
+	ts := time.Now()
+	ps.Write(ctx, metrics.NewEventMetrics(ts).
+		AddMetric("sent", metrics.NewInt(32)).
+		AddMetric("latency", testDistribution()).
+		AddLabel("ptype", "http"))
+
+	scrape := func(accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", url, nil)
+		r.Header.Set("Accept", accept)
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	// Wait for the EventMetrics to be processed.
+	assert.Eventually(t, func() bool {
+		return strings.Contains(scrape("").Body.String(), "sent{")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Text format fallback keeps classic buckets.
+	assert.Contains(t, scrape("text/plain").Body.String(), "latency_bucket{ptype=\"http\",le=\"+Inf\"} 4")
+
+	w := scrape("application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited")
+	assert.Equal(t, protobufContentType, w.Header().Get("Content-Type"))
+
+	families := make(map[string]map[protowire.Number][]any)
+	b := w.Body.Bytes()
+	for len(b) > 0 {
+		mf, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatalf("error decoding delimited message")
+		}
+		b = b[n:]
+		f := pbFields(t, mf)
+		families[string(f[1][0].([]byte))] = f
+	}
+	assert.Len(t, families, 2)
+
+	sent := families["sent"]
+	assert.Equal(t, []any{uint64(pbCounter)}, sent[3])
+	m := pbFields(t, sent[4][0].([]byte))
+	label := pbFields(t, m[1][0].([]byte))
+	assert.Equal(t, "ptype", string(label[1][0].([]byte)))
+	assert.Equal(t, "http", string(label[2][0].([]byte)))
+	assert.Equal(t, []any{math.Float64bits(32)}, pbFields(t, m[3][0].([]byte))[1])
+	assert.Equal(t, []any{uint64(promTime(ts))}, m[6])
+
+	latency := families["latency"]
+	assert.Equal(t, []any{uint64(pbHistogram)}, latency[3])
+	assert.Len(t, latency[4], 1)
+	m = pbFields(t, latency[4][0].([]byte))
+	h := pbFields(t, m[7][0].([]byte))
+	assert.Equal(t, []any{uint64(4)}, h[1])
+	assert.Equal(t, []any{uint64(1)}, h[7])
+}
+
+func TestInvalidNativeHistogramSchema(t *testing.T) {
+	_, err := New(context.Background(), &configpb.SurfacerConf{
+		NativeHistogramSchema: proto.Int32(9),
+	}, &options.Options{HTTPServeMux: http.NewServeMux()}, nil)
+	assert.Error(t, err)
+}
@@ -29,6 +29,10 @@ sent{ptype="ping",probe="vm-to-public-dns",dst="8.8.4.4"} 362600 1497330037000
 # TYPE rcvd counter
 rcvd{ptype="dns",probe="vm-to-public-dns",dst="8.8.8.8"} 181234 1497330037000
 rcvd{ptype="ping",probe="vm-to-public-dns",dst="8.8.4.4"} 362600 1497330037000
+
+If enable_native_histograms is set in the config, distributions are also
+exported as native histograms to scrapers that negotiate the protobuf
+exposition format. Other scrapers continue to get the text format.
 */
 package prometheus
 
@@ -83,17 +87,23 @@ type promMetric struct {
 	typ      string
 	data     map[string]*dataPoint
 	dataKeys []string // To keep data keys ordered
+
+	// Native histograms, populated only if native histograms are enabled.
+	hists    map[string]*histPoint
+	histKeys []string
 }
 
 type dataPoint struct {
 	value     string
 	timestamp int64
+	labels    []string
 }
 
 // httpWriter is a wrapper for http.ResponseWriter that includes a channel
 // to signal the completion of the writing of the response.
 type httpWriter struct {
 	w        http.ResponseWriter
+	protobuf bool // Write in protobuf exposition format
 	doneChan chan struct{}
 }
 
@@ -131,6 +141,9 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	if config == nil {
 		config = &configpb.SurfacerConf{}
 	}
+	if schema := config.GetNativeHistogramSchema(); schema < -4 || schema > 8 {
+		return nil, fmt.Errorf("prometheus: invalid native_histogram_schema: %d, should be between -4 and 8", schema)
+	}
 	ps := &PromSurfacer{
 		c:            config,
 		opts:         opts,
@@ -168,7 +181,12 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 			case em := <-ps.emChan:
 				ps.record(em)
 			case hw := <-ps.queryChan:
-				ps.writeData(hw.w)
+				if hw.protobuf {
+					hw.w.Header().Set("Content-Type", protobufContentType)
+					ps.writeProtoData(hw.w)
+				} else {
+					ps.writeData(hw.w)
+				}
 				close(hw.doneChan)
 			case <-staleMetricDeleteTimer.C:
 				ps.deleteExpiredMetrics()
@@ -180,7 +198,8 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		// doneChan is used to track the completion of the response writing. This is
 		// required as response is written in a different goroutine.
 		doneChan := make(chan struct{}, 1)
-		ps.queryChan <- &httpWriter{w, doneChan}
+		protobuf := ps.c.GetEnableNativeHistograms() && acceptsProtobuf(r)
+		ps.queryChan <- &httpWriter{w, protobuf, doneChan}
 		<-doneChan
 	})
 
@@ -215,7 +234,7 @@ func promTime(t time.Time) int64 {
 	return t.UnixNano() / (1000 * 1000)
 }
 
-func (ps *PromSurfacer) recordMetric(metricName, key, value string, em *metrics.EventMetrics, typ string) {
+func (ps *PromSurfacer) recordMetric(metricName, key, value string, labels []string, em *metrics.EventMetrics, typ string) {
 	// Recognized metric
 	if pm := ps.metrics[metricName]; pm != nil {
 		// Recognized metric name and labels combination.
@@ -227,6 +246,7 @@ func (ps *PromSurfacer) recordMetric(metricName, key, value string, em *metrics.
 		pm.data[key] = &dataPoint{
 			value:     value,
 			timestamp: promTime(em.Timestamp),
+			labels:    append([]string(nil), labels...),
 		}
 		pm.dataKeys = append(pm.dataKeys, key)
 	} else {
@@ -240,6 +260,7 @@ func (ps *PromSurfacer) recordMetric(metricName, key, value string, em *metrics.
 				key: {
 					value:     value,
 					timestamp: promTime(em.Timestamp),
+					labels:    append([]string(nil), labels...),
 				},
 			},
 			dataKeys: []string{key},
@@ -248,6 +269,36 @@ func (ps *PromSurfacer) recordMetric(metricName, key, value string, em *metrics.
 	}
 }
 
+// recordHistogram records the distribution for native histogram export. It
+// should be called after the classic histogram has been recorded, which
+// creates the promMetric.
+func (ps *PromSurfacer) recordHistogram(metricName string, labels []string, d *metrics.DistributionData, em *metrics.EventMetrics) {
+	// DistributionData shares bucket counts with the distribution, which may
+	// continue to be updated by the probe.
+	d = &metrics.DistributionData{
+		LowerBounds:  d.LowerBounds,
+		BucketCounts: append([]int64(nil), d.BucketCounts...),
+		Count:        d.Count,
+		Sum:          d.Sum,
+	}
+
+	pm := ps.metrics[metricName]
+	if pm.hists == nil {
+		pm.hists = make(map[string]*histPoint)
+	}
+	key := dataKey(metricName, labels)
+	if hp := pm.hists[key]; hp != nil {
+		hp.data, hp.timestamp = d, promTime(em.Timestamp)
+		return
+	}
+	pm.hists[key] = &histPoint{
+		labels:    append([]string(nil), labels...),
+		data:      d,
+		timestamp: promTime(em.Timestamp),
+	}
+	pm.histKeys = append(pm.histKeys, key)
+}
+
 // checkLabelName finds a prometheus label name for an incoming label. If label
 // is found to be invalid even after some basic conversions, a zero string is
 // returned.
@@ -312,8 +363,8 @@ func recordMap[T int64 | float64](ps *PromSurfacer, m *metrics.Map[T], em *metri
 		return
 	}
 	for _, k := range m.Keys() {
-		key := dataKey(pMetricName, append(labels, labelName+"=\""+k+"\""))
-		ps.recordMetric(pMetricName, key, metrics.MapValueToString(m.GetKey(k)), em, "")
+		mapLabels := append(labels, labelName+"=\""+k+"\"")
+		ps.recordMetric(pMetricName, dataKey(pMetricName, mapLabels), metrics.MapValueToString(m.GetKey(k)), mapLabels, em, "")
 	}
 }
 
@@ -363,8 +414,8 @@ func (ps *PromSurfacer) record(em *metrics.EventMetrics) {
 		case *metrics.Distribution:
 			d := v.Data()
 			var val int64
-			ps.recordMetric(pMetricName, dataKey(pMetricName+"_sum", labels), strconv.FormatFloat(d.Sum, 'f', -1, 64), em, histogram)
-			ps.recordMetric(pMetricName, dataKey(pMetricName+"_count", labels), strconv.FormatInt(d.Count, 10), em, histogram)
+			ps.recordMetric(pMetricName, dataKey(pMetricName+"_sum", labels), strconv.FormatFloat(d.Sum, 'f', -1, 64), labels, em, histogram)
+			ps.recordMetric(pMetricName, dataKey(pMetricName+"_count", labels), strconv.FormatInt(d.Count, 10), labels, em, histogram)
 			for i := range d.LowerBounds {
 				val += d.BucketCounts[i]
 				var lb string
@@ -374,15 +425,18 @@ func (ps *PromSurfacer) record(em *metrics.EventMetrics) {
 					lb = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
 				}
 				labelsWithBucket := append(labels, "le=\""+lb+"\"")
-				ps.recordMetric(pMetricName, dataKey(pMetricName+"_bucket", labelsWithBucket), strconv.FormatInt(val, 10), em, histogram)
+				ps.recordMetric(pMetricName, dataKey(pMetricName+"_bucket", labelsWithBucket), strconv.FormatInt(val, 10), labelsWithBucket, em, histogram)
+			}
+			if ps.c.GetEnableNativeHistograms() {
+				ps.recordHistogram(pMetricName, labels, d, em)
 			}
 		case metrics.String:
 			newLabels := append(labels, "val="+val.String())
-			ps.recordMetric(pMetricName, dataKey(pMetricName, newLabels), "1", em, "")
+			ps.recordMetric(pMetricName, dataKey(pMetricName, newLabels), "1", newLabels, em, "")
 
 		// All other value types, mostly numerical types.
 		default:
-			ps.recordMetric(pMetricName, dataKey(pMetricName, labels), val.String(), em, "")
+			ps.recordMetric(pMetricName, dataKey(pMetricName, labels), val.String(), labels, em, "")
 		}
 	}
 }
@@ -418,6 +472,13 @@ func (ps *PromSurfacer) deleteExpiredMetrics() {
 			delete(pm.data, expiredMetricKey)
 			pm.dataKeys = deleteFromSlice(pm.dataKeys, expiredMetricKey)
 		}
+
+		for histKey, hp := range pm.hists {
+			if hp.timestamp < staleTimeThreshold {
+				delete(pm.hists, histKey)
+				pm.histKeys = deleteFromSlice(pm.histKeys, histKey)
+			}
+		}
 	}
 }
 
@@ -43,13 +43,30 @@ type SurfacerConf struct {
 	// "cloudprober_" will result in metrics with names:
 	// cloudprober_total, cloudprober_success, cloudprober_latency, ..
 	MetricsPrefix *string `protobuf:"bytes,4,opt,name=metrics_prefix,json=metricsPrefix" json:"metrics_prefix,omitempty"`
+	// Export distributions as Prometheus native histograms, if the scraper
+	// asks for the protobuf exposition format. Prometheus requests protobuf
+	// format only if native histograms are enabled on the Prometheus server
+	// (--enable-feature=native-histograms). For all other scrapers, we fall
+	// back to the classic buckets in the text format.
+	//
+	// Note that in protobuf format, distributions are exported only as native
+	// histograms; classic buckets are not included. Native histogram buckets
+	// are derived from the distribution's buckets, so their accuracy depends on
+	// the distribution's bucket boundaries.
+	EnableNativeHistograms *bool `protobuf:"varint,5,opt,name=enable_native_histograms,json=enableNativeHistograms,def=0" json:"enable_native_histograms,omitempty"`
+	// Native histogram schema (resolution). Valid values are from -4 to 8.
+	// Bucket boundaries grow by a factor of 2^(2^-schema), e.g. schema 3 means
+	// each bucket is about 9% wider than the previous one.
+	NativeHistogramSchema *int32 `protobuf:"varint,6,opt,name=native_histogram_schema,json=nativeHistogramSchema,def=3" json:"native_histogram_schema,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
 const (
-	Default_SurfacerConf_MetricsBufferSize = int64(10000)
-	Default_SurfacerConf_IncludeTimestamp  = bool(true)
-	Default_SurfacerConf_MetricsUrl        = string("/metrics")
+	Default_SurfacerConf_MetricsBufferSize      = int64(10000)
+	Default_SurfacerConf_IncludeTimestamp       = bool(true)
+	Default_SurfacerConf_MetricsUrl             = string("/metrics")
+	Default_SurfacerConf_EnableNativeHistograms = bool(false)
+	Default_SurfacerConf_NativeHistogramSchema  = int32(3)
 )
 
 func (x *SurfacerConf) Reset() {
@@ -112,6 +129,20 @@ func (x *SurfacerConf) GetMetricsPrefix() string {
 	return ""
 }
 
+func (x *SurfacerConf) GetEnableNativeHistograms() bool {
+	if x != nil && x.EnableNativeHistograms != nil {
+		return *x.EnableNativeHistograms
+	}
+	return Default_SurfacerConf_EnableNativeHistograms
+}
+
+func (x *SurfacerConf) GetNativeHistogramSchema() int32 {
+	if x != nil && x.NativeHistogramSchema != nil {
+		return *x.NativeHistogramSchema
+	}
+	return Default_SurfacerConf_NativeHistogramSchema
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_prometheus_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_prometheus_proto_config_proto_rawDesc = []byte{
@@ -122,7 +153,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_prometheus_proto_
 	0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
 	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x6d,
-	0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
+	0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x22, 0xc6, 0x02, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
 	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x35, 0x0a, 0x13, 0x6d, 0x65, 0x74, 0x72, 0x69,
 	0x63, 0x73, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x03, 0x3a, 0x05, 0x31, 0x30, 0x30, 0x30, 0x30, 0x52, 0x11, 0x6d, 0x65, 0x74,
@@ -135,11 +166,19 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_prometheus_proto_
 	0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x55, 0x72, 0x6c, 0x12, 0x25, 0x0a, 0x0e,
 	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x50, 0x72, 0x65,
-	0x66, 0x69, 0x78, 0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f,
-	0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x66, 0x69, 0x78, 0x12, 0x3f, 0x0a, 0x18, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x16, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x39, 0x0a, 0x17, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x33, 0x52, 0x15, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x42,
+	0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68,
+	0x65, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
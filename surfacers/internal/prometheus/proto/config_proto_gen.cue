@@ -22,4 +22,21 @@ package proto
 	// "cloudprober_" will result in metrics with names:
 	// cloudprober_total, cloudprober_success, cloudprober_latency, ..
 	metricsPrefix?: string @protobuf(4,string,name=metrics_prefix)
+
+	// Export distributions as Prometheus native histograms, if the scraper
+	// asks for the protobuf exposition format. Prometheus requests protobuf
+	// format only if native histograms are enabled on the Prometheus server
+	// (--enable-feature=native-histograms). For all other scrapers, we fall
+	// back to the classic buckets in the text format.
+	//
+	// Note that in protobuf format, distributions are exported only as native
+	// histograms; classic buckets are not included. Native histogram buckets
+	// are derived from the distribution's buckets, so their accuracy depends on
+	// the distribution's bucket boundaries.
+	enableNativeHistograms?: bool @protobuf(5,bool,name=enable_native_histograms,"default=false")
+
+	// Native histogram schema (resolution). Valid values are from -4 to 8.
+	// Bucket boundaries grow by a factor of 2^(2^-schema), e.g. schema 3 means
+	// each bucket is about 9% wider than the previous one.
+	nativeHistogramSchema?: int32 @protobuf(6,int32,name=native_histogram_schema,"default=3")
 }
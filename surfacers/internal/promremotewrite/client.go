@@ -0,0 +1,164 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promremotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// label and timeSeries mirror the remote-write protobuf messages
+// (prometheus/prompb). We encode them directly using protowire to avoid
+// pulling in the prometheus server module.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+type label struct {
+	name, value string
+}
+
+type timeSeries struct {
+	labels    []label // Sorted by name, with __name__ first.
+	value     float64
+	timestamp int64 // Milliseconds since epoch.
+}
+
+func appendLabel(b []byte, l label) []byte {
+	var lb []byte
+	lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+	lb = protowire.AppendString(lb, l.name)
+	lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+	lb = protowire.AppendString(lb, l.value)
+
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, lb)
+}
+
+func appendSample(b []byte, value float64, ts int64) []byte {
+	var sb []byte
+	sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+	sb = protowire.AppendFixed64(sb, math.Float64bits(value))
+	sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+	sb = protowire.AppendVarint(sb, uint64(ts))
+
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendBytes(b, sb)
+}
+
+// encodeWriteRequest encodes time series into a protobuf serialized
+// WriteRequest message.
+func encodeWriteRequest(series []timeSeries) []byte {
+	var b, tsb []byte
+	for _, ts := range series {
+		tsb = tsb[:0]
+		for _, l := range ts.labels {
+			tsb = appendLabel(tsb, l)
+		}
+		tsb = appendSample(tsb, ts.value, ts.timestamp)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, tsb)
+	}
+	return b
+}
+
+// retryableError is returned for errors that may go away on retrying.
+type retryableError struct {
+	err error
+}
+
+func (re *retryableError) Error() string {
+	return re.err.Error()
+}
+
+type client struct {
+	url            string
+	headers        map[string]string
+	httpClient     *http.Client
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func (c *client) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("User-Agent", "cloudprober")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &retryableError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("remote-write HTTP status: %d, response: %s", resp.StatusCode, string(b))
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return &retryableError{err}
+	}
+	return err
+}
+
+// write sends the time series to the remote-write endpoint, retrying with
+// exponential backoff on retryable errors.
+func (c *client) write(ctx context.Context, series []timeSeries) error {
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	backoff := c.initialBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*retryableError); !ok || attempt >= c.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v (giving up: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
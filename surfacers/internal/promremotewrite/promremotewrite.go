@@ -0,0 +1,220 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package promremotewrite implements a surfacer that pushes metrics to a
+Prometheus remote-write endpoint (Prometheus, Mimir, Thanos Receive, Cortex,
+VictoriaMetrics, etc). It's useful in environments where scraping cloudprober
+is not possible, e.g. behind NAT or on ephemeral edge nodes.
+
+Metrics are converted to time series in the same way as the prometheus
+surfacer: map values are expanded into one time series per map key,
+distributions are expanded into _sum, _count and _bucket series, and string
+values are exported as a series with value 1 and a "val" label.
+*/
+package promremotewrite
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/promremotewrite/proto"
+)
+
+var (
+	invalidMetricChars = regexp.MustCompile("[^a-zA-Z0-9_:]")
+	invalidLabelChars  = regexp.MustCompile("[^a-zA-Z0-9_]")
+)
+
+// Surfacer implements a Prometheus remote-write surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	client    *client
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	batch []timeSeries
+}
+
+// New creates a new instance of the remote-write surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetUrl() == "" {
+		return nil, fmt.Errorf("promremotewrite: url is required")
+	}
+	if config.GetMetricsBatchSize() <= 0 {
+		return nil, fmt.Errorf("promremotewrite: invalid metrics_batch_size: %d", config.GetMetricsBatchSize())
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("promremotewrite: error parsing TLS config: %v", err)
+		}
+	}
+
+	s := &Surfacer{
+		c:    config,
+		opts: opts,
+		client: &client{
+			url:     config.GetUrl(),
+			headers: config.GetHttpHeader(),
+			httpClient: &http.Client{
+				Transport: transport,
+				Timeout:   time.Duration(config.GetRequestTimeoutSec()) * time.Second,
+			},
+			maxRetries:     int(config.GetMaxRetries()),
+			initialBackoff: time.Duration(config.GetInitialBackoffMsec()) * time.Millisecond,
+			maxBackoff:     time.Duration(config.GetMaxBackoffMsec()) * time.Millisecond,
+		},
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]timeSeries, 0, config.GetMetricsBatchSize()),
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			for _, ts := range s.timeSeries(em) {
+				if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+					s.flush(ctx)
+					batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+				}
+				s.batch = append(s.batch, ts)
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.client.write(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error writing %d time series to %s: %v", len(s.batch), s.c.GetUrl(), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+func metricName(prefix, name string) string {
+	return invalidMetricChars.ReplaceAllString(prefix+name, "_")
+}
+
+func labelName(name string) string {
+	return invalidLabelChars.ReplaceAllString(name, "_")
+}
+
+// newTimeSeries creates a time series with the given name and labels. Labels
+// are sorted by name, as required by the remote-write protocol.
+func newTimeSeries(name string, labels []label, value float64, ts int64, extraLabels ...label) timeSeries {
+	allLabels := make([]label, 0, len(labels)+len(extraLabels)+1)
+	allLabels = append(allLabels, label{"__name__", name})
+	allLabels = append(allLabels, labels...)
+	allLabels = append(allLabels, extraLabels...)
+	sort.SliceStable(allLabels[1:], func(i, j int) bool {
+		return allLabels[i+1].name < allLabels[j+1].name
+	})
+
+	return timeSeries{labels: allLabels, value: value, timestamp: ts}
+}
+
+func mapTimeSeries[T int64 | float64](m *metrics.Map[T], name string, labels []label, ts int64) []timeSeries {
+	var out []timeSeries
+	for _, k := range m.Keys() {
+		out = append(out, newTimeSeries(name, labels, float64(m.GetKey(k)), ts, label{labelName(m.MapName), k}))
+	}
+	return out
+}
+
+// timeSeries converts an EventMetrics into remote-write time series.
+func (s *Surfacer) timeSeries(em *metrics.EventMetrics) []timeSeries {
+	ts := em.Timestamp.UnixMilli()
+
+	var labels []label
+	for _, k := range em.LabelsKeys() {
+		labels = append(labels, label{labelName(k), em.Label(k)})
+	}
+
+	var out []timeSeries
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+		name := metricName(s.c.GetMetricsPrefix(), metricKey)
+
+		switch v := em.Metric(metricKey).(type) {
+		case metrics.NumValue:
+			out = append(out, newTimeSeries(name, labels, v.Float64(), ts))
+		case *metrics.Map[int64]:
+			out = append(out, mapTimeSeries(v, name, labels, ts)...)
+		case *metrics.Map[float64]:
+			out = append(out, mapTimeSeries(v, name, labels, ts)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, newTimeSeries(name+"_sum", labels, d.Sum, ts))
+			out = append(out, newTimeSeries(name+"_count", labels, float64(d.Count), ts))
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "+Inf"
+				if i < len(d.LowerBounds)-1 {
+					le = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
+				}
+				out = append(out, newTimeSeries(name+"_bucket", labels, float64(cumCount), ts, label{"le", le}))
+			}
+		case metrics.String:
+			out = append(out, newTimeSeries(name, labels, 1, ts, label{"val", strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")}))
+		default:
+			s.l.Warningf("Unsupported value type (%T) for metric: %s", v, metricKey)
+		}
+	}
+	return out
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promremotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/promremotewrite/proto"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// decodeWriteRequest decodes a WriteRequest message, for testing.
+func decodeWriteRequest(t *testing.T, b []byte) []timeSeries {
+	t.Helper()
+
+	consumeFields := func(b []byte, f func(num protowire.Number, typ protowire.Type, b []byte) int) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			if n < 0 {
+				t.Fatalf("error decoding tag: %v", protowire.ParseError(n))
+			}
+			b = b[n:]
+			n = f(num, typ, b)
+			if n < 0 {
+				t.Fatalf("error decoding field %d: %v", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+		}
+	}
+
+	var series []timeSeries
+	consumeFields(b, func(_ protowire.Number, _ protowire.Type, b []byte) int {
+		tsb, n := protowire.ConsumeBytes(b)
+		var ts timeSeries
+		consumeFields(tsb, func(num protowire.Number, _ protowire.Type, b []byte) int {
+			fb, n := protowire.ConsumeBytes(b)
+			switch num {
+			case 1:
+				var l label
+				consumeFields(fb, func(num protowire.Number, _ protowire.Type, b []byte) int {
+					s, n := protowire.ConsumeString(b)
+					if num == 1 {
+						l.name = s
+					} else {
+						l.value = s
+					}
+					return n
+				})
+				ts.labels = append(ts.labels, l)
+			case 2:
+				consumeFields(fb, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					if num == 1 {
+						v, n := protowire.ConsumeFixed64(b)
+						ts.value = math.Float64frombits(v)
+						return n
+					}
+					v, n := protowire.ConsumeVarint(b)
+					ts.timestamp = int64(v)
+					return n
+				})
+			}
+			return n
+		})
+		series = append(series, ts)
+		return n
+	})
+	return series
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	series := []timeSeries{
+		{labels: []label{{"__name__", "total"}, {"probe", "p1"}}, value: 10, timestamp: 1700000000000},
+		{labels: []label{{"__name__", "latency"}, {"dst", "d1"}, {"probe", "p1"}}, value: 1.5, timestamp: 1700000001000},
+	}
+	assert.Equal(t, series, decodeWriteRequest(t, encodeWriteRequest(series)))
+}
+
+func TestTimeSeries(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(2)
+	d.AddSample(20)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1")
+
+	s := &Surfacer{
+		c:    &configpb.SurfacerConf{},
+		opts: &options.Options{},
+		l:    &logger.Logger{},
+	}
+
+	labels := func(name string, extra ...label) []label {
+		return append([]label{{"__name__", name}, {"probe", "p1"}, {"ptype", "http"}}, extra...)
+	}
+	ms := ts.UnixMilli()
+	want := []timeSeries{
+		{labels: labels("cloudprober_total"), value: 10, timestamp: ms},
+		{labels: []label{{"__name__", "cloudprober_resp_code"}, {"code", "200"}, {"probe", "p1"}, {"ptype", "http"}}, value: 4, timestamp: ms},
+		{labels: labels("cloudprober_latency_sum"), value: 22, timestamp: ms},
+		{labels: labels("cloudprober_latency_count"), value: 2, timestamp: ms},
+		{labels: []label{{"__name__", "cloudprober_latency_bucket"}, {"le", "1"}, {"probe", "p1"}, {"ptype", "http"}}, value: 0, timestamp: ms},
+		{labels: []label{{"__name__", "cloudprober_latency_bucket"}, {"le", "10"}, {"probe", "p1"}, {"ptype", "http"}}, value: 1, timestamp: ms},
+		{labels: []label{{"__name__", "cloudprober_latency_bucket"}, {"le", "+Inf"}, {"probe", "p1"}, {"ptype", "http"}}, value: 2, timestamp: ms},
+		{labels: labels("cloudprober_version", label{"val", "v1"}), value: 1, timestamp: ms},
+	}
+
+	assert.Equal(t, want, s.timeSeries(em))
+}
+
+func TestClientWrite(t *testing.T) {
+	var mu sync.Mutex
+	var gotSeries []timeSeries
+	var reqCount int
+	statusCodes := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "tenant1", r.Header.Get("X-Scope-OrgID"))
+
+		b, _ := io.ReadAll(r.Body)
+		data, err := snappy.Decode(nil, b)
+		assert.NoError(t, err)
+		gotSeries = decodeWriteRequest(t, data)
+
+		w.WriteHeader(statusCodes[reqCount%len(statusCodes)])
+		reqCount++
+	}))
+	defer ts.Close()
+
+	series := []timeSeries{{labels: []label{{"__name__", "total"}}, value: 10, timestamp: 1700000000000}}
+
+	c := &client{
+		url:            ts.URL,
+		headers:        map[string]string{"X-Scope-OrgID": "tenant1"},
+		httpClient:     http.DefaultClient,
+		maxRetries:     3,
+		initialBackoff: time.Millisecond,
+		maxBackoff:     2 * time.Millisecond,
+	}
+	assert.NoError(t, c.write(context.Background(), series))
+	assert.Equal(t, 3, reqCount, "request count")
+	assert.Equal(t, series, gotSeries)
+
+	// Not enough retries.
+	c.maxRetries = 1
+	reqCount = 0
+	assert.Error(t, c.write(context.Background(), series))
+	assert.Equal(t, 2, reqCount, "request count")
+
+	// Non-retryable error.
+	statusCodes = []int{http.StatusBadRequest}
+	reqCount = 0
+	c.maxRetries = 3
+	assert.Error(t, c.write(context.Background(), series))
+	assert.Equal(t, 1, reqCount, "request count")
+}
+
+func TestSurfacer(t *testing.T) {
+	received := make(chan []timeSeries, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		data, _ := snappy.Decode(nil, b)
+		received <- decodeWriteRequest(t, data)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Url:              proto.String(ts.URL),
+		MetricsBatchSize: proto.Int32(2),
+	}, &options.Options{MetricsBufferSize: 10}, nil)
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("success", metrics.NewInt(9)).
+		AddMetric("failure", metrics.NewInt(1)).
+		AddLabel("probe", "p1")
+	s.Write(ctx, em)
+
+	// Batch size is 2, so first 2 series should be sent right away.
+	select {
+	case series := <-received:
+		assert.Len(t, series, 2)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for remote-write request")
+	}
+}
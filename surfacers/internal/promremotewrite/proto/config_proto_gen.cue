@@ -0,0 +1,46 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+
+// Surfacer config for Prometheus remote-write surfacer. This surfacer pushes
+// metrics to a remote-write compatible endpoint, e.g. Prometheus (with
+// --web.enable-remote-write-receiver), Mimir, Thanos Receive, Cortex or
+// VictoriaMetrics.
+#SurfacerConf: {
+	// Remote-write endpoint URL, e.g.
+	// "http://mimir:9009/api/v1/push" or
+	// "http://victoria-metrics:8428/api/v1/write"
+	url?: string @protobuf(1,string)
+
+	// HTTP request headers, e.g. X-Scope-OrgID for multi-tenant Mimir and
+	// Cortex, or Authorization for authenticated endpoints.
+	httpHeader?: {
+		[string]: string
+	} @protobuf(2,map[string]string,http_header)
+	tlsConfig?: proto.#TLSConfig @protobuf(3,tlsconfig.TLSConfig,name=tls_config)
+
+	// Prefix to add to all metric names.
+	metricsPrefix?: string @protobuf(4,string,name=metrics_prefix,#"default="cloudprober_""#)
+
+	// Maximum number of time series to send in one remote-write request.
+	// Metrics are sent when the batch is full or when the batch timer expires,
+	// whichever happens first.
+	metricsBatchSize?: int32 @protobuf(5,int32,name=metrics_batch_size,"default=1000")
+
+	// Maximum time to hold metrics in the batch before sending them.
+	batchTimerSec?: int32 @protobuf(6,int32,name=batch_timer_sec,"default=10")
+
+	// Timeout for each remote-write request.
+	requestTimeoutSec?: int32 @protobuf(7,int32,name=request_timeout_sec,"default=10")
+
+	// Maximum number of retries for a failed request. Requests that fail
+	// because of network errors, HTTP 5xx or HTTP 429 are retried with
+	// exponential backoff. Other errors (e.g. HTTP 400) are not retried, as
+	// retrying them is not going to help.
+	maxRetries?: int32 @protobuf(8,int32,name=max_retries,"default=3")
+
+	// Initial backoff for retries. Backoff is doubled after every retry, up to
+	// max_backoff_msec.
+	initialBackoffMsec?: int32 @protobuf(9,int32,name=initial_backoff_msec,"default=100")
+	maxBackoffMsec?:     int32 @protobuf(10,int32,name=max_backoff_msec,"default=5000")
+}
@@ -20,6 +20,75 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SurfacerConf_MessageFormat int32
+
+const (
+	// EventMetrics in cloudprober's text format, one per line, e.g.:
+	//
+	//	1700000000 labels=ptype=http,probe=web,dst=example.com total=20 ...
+	SurfacerConf_TEXT SurfacerConf_MessageFormat = 0
+	// EventMetrics as JSON objects, one per line, e.g.:
+	//
+	//	{"timestamp_usec":1700000000000000,"kind":"CUMULATIVE",
+	//	 "labels":{"probe":"web","dst":"example.com"},
+	//	 "metrics":{"total":20,"success":19,"resp-code.200":19}}
+	//
+	// Map metrics are expanded into one metric per key ("<metric>.<key>"),
+	// and distributions into "<metric>.sum" and "<metric>.count". String
+	// metrics are added to labels. This format can be validated by Avro or
+	// Protocol Buffer topic schemas (with JSON encoding).
+	SurfacerConf_JSON SurfacerConf_MessageFormat = 1
+)
+
+// Enum value maps for SurfacerConf_MessageFormat.
+var (
+	SurfacerConf_MessageFormat_name = map[int32]string{
+		0: "TEXT",
+		1: "JSON",
+	}
+	SurfacerConf_MessageFormat_value = map[string]int32{
+		"TEXT": 0,
+		"JSON": 1,
+	}
+)
+
+func (x SurfacerConf_MessageFormat) Enum() *SurfacerConf_MessageFormat {
+	p := new(SurfacerConf_MessageFormat)
+	*p = x
+	return p
+}
+
+func (x SurfacerConf_MessageFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerConf_MessageFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SurfacerConf_MessageFormat) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_enumTypes[0]
+}
+
+func (x SurfacerConf_MessageFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerConf_MessageFormat) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerConf_MessageFormat(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerConf_MessageFormat.Descriptor instead.
+func (SurfacerConf_MessageFormat) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type SurfacerConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -32,12 +101,38 @@ type SurfacerConf struct {
 	// Default is cloudprober-{hostname}
 	TopicName *string `protobuf:"bytes,2,opt,name=topic_name,json=topicName" json:"topic_name,omitempty"`
 	// Compress data before writing to pubsub.
-	CompressionEnabled *bool `protobuf:"varint,4,opt,name=compression_enabled,json=compressionEnabled,def=0" json:"compression_enabled,omitempty"`
+	CompressionEnabled *bool                       `protobuf:"varint,4,opt,name=compression_enabled,json=compressionEnabled,def=0" json:"compression_enabled,omitempty"`
+	MessageFormat      *SurfacerConf_MessageFormat `protobuf:"varint,5,opt,name=message_format,json=messageFormat,enum=cloudprober.surfacer.pubsub.SurfacerConf_MessageFormat,def=0" json:"message_format,omitempty"`
+	// If set, messages are published with this ordering key, and the topic is
+	// configured for ordered delivery. Ordering key can use EventMetrics
+	// labels, e.g. "@probe@/@dst@" to keep messages for the same probe and
+	// target in order. Ordering keys require compression to be disabled, as
+	// compressed messages contain multiple EventMetrics.
+	// Note: ordered delivery also needs to be enabled on the subscription.
+	OrderingKey *string `protobuf:"bytes,6,opt,name=ordering_key,json=orderingKey" json:"ordering_key,omitempty"`
+	// Copy EventMetrics labels to message attributes, so that subscribers can
+	// filter or route messages without decoding them. Key is the label name,
+	// value is the attribute name, e.g.:
+	//
+	//	label_to_attribute {
+	//	  key: "probe"
+	//	  value: "probe_name"
+	//	}
+	//
+	// This option requires compression to be disabled.
+	LabelToAttribute map[string]string `protobuf:"bytes,7,rep,name=label_to_attribute,json=labelToAttribute" json:"label_to_attribute,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Schema to validate messages against, e.g.
+	// "projects/my-project/schemas/cloudprober-results". If topic doesn't exist,
+	// it's created with this schema (and JSON encoding). If topic exists, its
+	// schema should match this schema. Requires JSON message format and
+	// compression to be disabled.
+	Schema *string `protobuf:"bytes,8,opt,name=schema" json:"schema,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
 const (
 	Default_SurfacerConf_CompressionEnabled = bool(false)
+	Default_SurfacerConf_MessageFormat      = SurfacerConf_TEXT
 )
 
 func (x *SurfacerConf) Reset() {
@@ -93,6 +188,34 @@ func (x *SurfacerConf) GetCompressionEnabled() bool {
 	return Default_SurfacerConf_CompressionEnabled
 }
 
+func (x *SurfacerConf) GetMessageFormat() SurfacerConf_MessageFormat {
+	if x != nil && x.MessageFormat != nil {
+		return *x.MessageFormat
+	}
+	return Default_SurfacerConf_MessageFormat
+}
+
+func (x *SurfacerConf) GetOrderingKey() string {
+	if x != nil && x.OrderingKey != nil {
+		return *x.OrderingKey
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetLabelToAttribute() map[string]string {
+	if x != nil {
+		return x.LabelToAttribute
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetSchema() string {
+	if x != nil && x.Schema != nil {
+		return *x.Schema
+	}
+	return ""
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_rawDesc = []byte{
@@ -102,20 +225,43 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_conf
 	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x1b, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x22, 0x7f,
-	0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f,
-	0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x12, 0x63, 0x6f, 0x6d,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x22, 0xf9,
+	0x03, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x12, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x64, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
+	0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x3a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x6d, 0x0a, 0x12, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x5f, 0x74, 0x6f, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x62,
+	0x73, 0x75, 0x62, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x54, 0x6f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x54, 0x6f, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x1a, 0x43, 0x0a, 0x15, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x54, 0x6f, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -130,16 +276,21 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_con
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_goTypes = []interface{}{
-	(*SurfacerConf)(nil), // 0: cloudprober.surfacer.pubsub.SurfacerConf
+	(SurfacerConf_MessageFormat)(0), // 0: cloudprober.surfacer.pubsub.SurfacerConf.MessageFormat
+	(*SurfacerConf)(nil),            // 1: cloudprober.surfacer.pubsub.SurfacerConf
+	nil,                             // 2: cloudprober.surfacer.pubsub.SurfacerConf.LabelToAttributeEntry
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: cloudprober.surfacer.pubsub.SurfacerConf.message_format:type_name -> cloudprober.surfacer.pubsub.SurfacerConf.MessageFormat
+	2, // 1: cloudprober.surfacer.pubsub.SurfacerConf.label_to_attribute:type_name -> cloudprober.surfacer.pubsub.SurfacerConf.LabelToAttributeEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() {
@@ -168,13 +319,14 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_con
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_surfacers_internal_pubsub_proto_config_proto = out.File
@@ -11,4 +11,55 @@ package proto
 
 	// Compress data before writing to pubsub.
 	compressionEnabled?: bool @protobuf(4,bool,name=compression_enabled,"default=false")
+
+	#MessageFormat: {
+		// EventMetrics in cloudprober's text format, one per line, e.g.:
+		//   1700000000 labels=ptype=http,probe=web,dst=example.com total=20 ...
+		"TEXT"
+		#enumValue: 0
+	} | {
+		// EventMetrics as JSON objects, one per line, e.g.:
+		//   {"timestamp_usec":1700000000000000,"kind":"CUMULATIVE",
+		//    "labels":{"probe":"web","dst":"example.com"},
+		//    "metrics":{"total":20,"success":19,"resp-code.200":19}}
+		// Map metrics are expanded into one metric per key ("<metric>.<key>"),
+		// and distributions into "<metric>.sum" and "<metric>.count". String
+		// metrics are added to labels. This format can be validated by Avro or
+		// Protocol Buffer topic schemas (with JSON encoding).
+		"JSON"
+		#enumValue: 1
+	}
+
+	#MessageFormat_value: {
+		TEXT: 0
+		JSON: 1
+	}
+	messageFormat?: #MessageFormat @protobuf(5,MessageFormat,name=message_format,"default=TEXT")
+
+	// If set, messages are published with this ordering key, and the topic is
+	// configured for ordered delivery. Ordering key can use EventMetrics
+	// labels, e.g. "@probe@/@dst@" to keep messages for the same probe and
+	// target in order. Ordering keys require compression to be disabled, as
+	// compressed messages contain multiple EventMetrics.
+	// Note: ordered delivery also needs to be enabled on the subscription.
+	orderingKey?: string @protobuf(6,string,name=ordering_key)
+
+	// Copy EventMetrics labels to message attributes, so that subscribers can
+	// filter or route messages without decoding them. Key is the label name,
+	// value is the attribute name, e.g.:
+	//   label_to_attribute {
+	//     key: "probe"
+	//     value: "probe_name"
+	//   }
+	// This option requires compression to be disabled.
+	labelToAttribute?: {
+		[string]: string
+	} @protobuf(7,map[string]string,label_to_attribute)
+
+	// Schema to validate messages against, e.g.
+	// "projects/my-project/schemas/cloudprober-results". If topic doesn't exist,
+	// it's created with this schema (and JSON encoding). If topic exists, its
+	// schema should match this schema. Requires JSON message format and
+	// compression to be disabled.
+	schema?: string @protobuf(8,string)
 }
@@ -18,18 +18,25 @@ package pubsub
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
 	"cloud.google.com/go/pubsub"
+	"github.com/cloudprober/cloudprober/common/strtemplate"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/compress"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/diskqueue"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/pubsub/proto"
 )
@@ -56,6 +63,22 @@ var newPubsubClient = func(ctx context.Context, project string) (*pubsub.Client,
 	return pubsub.NewClient(ctx, project)
 }
 
+// publishResult keeps the published message along with the result, so that
+// we can buffer the message if publishing fails.
+type publishResult struct {
+	res   *pubsub.PublishResult
+	msg   *pubsub.Message
+	start time.Time
+}
+
+// bufferedMessage is the on-disk representation of a message that failed to
+// publish.
+type bufferedMessage struct {
+	Data        []byte            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"ordering_key,omitempty"`
+}
+
 // Surfacer implements a pubsub surfacer.
 type Surfacer struct {
 	// Configuration
@@ -64,7 +87,7 @@ type Surfacer struct {
 
 	// Channel for incoming data.
 	inChan            chan *metrics.EventMetrics
-	publishResultChan chan *pubsub.PublishResult
+	publishResultChan chan *publishResult
 
 	topic      *pubsub.Topic
 	topicName  string
@@ -74,9 +97,12 @@ type Surfacer struct {
 	starttime         string
 	compressionBuffer *compress.CompressionBuffer
 	processInputWg    sync.WaitGroup
+	diskQueue         *diskqueue.Queue
 }
 
-func (s *Surfacer) publishMessage(globalCtx context.Context, data []byte) {
+// newMessage creates a pubsub message for the given data. em is the
+// EventMetrics that the data was created from, nil for compressed data.
+func (s *Surfacer) newMessage(data []byte, em *metrics.EventMetrics) *pubsub.Message {
 	boolToString := map[bool]string{
 		true:  "true",
 		false: "false",
@@ -88,10 +114,158 @@ func (s *Surfacer) publishMessage(globalCtx context.Context, data []byte) {
 		},
 		Data: data,
 	}
+	if em == nil {
+		return msg
+	}
+
+	for label, attr := range s.c.GetLabelToAttribute() {
+		if v := em.Label(label); v != "" {
+			msg.Attributes[attr] = v
+		}
+	}
+	if s.c.GetOrderingKey() != "" {
+		labels := make(map[string]string)
+		for _, k := range em.LabelsKeys() {
+			labels[k] = em.Label(k)
+		}
+		msg.OrderingKey, _ = strtemplate.SubstituteLabels(s.c.GetOrderingKey(), labels)
+	}
+	return msg
+}
 
+func (s *Surfacer) publishMessage(globalCtx context.Context, msg *pubsub.Message) {
 	publishCtx, cancel := context.WithTimeout(globalCtx, publishTimeout)
 	defer cancel()
-	s.publishResultChan <- s.topic.Publish(publishCtx, msg)
+	s.publishResultChan <- &publishResult{s.topic.Publish(publishCtx, msg), msg, time.Now()}
+}
+
+func mapValues[T int64 | float64](metricsMap map[string]float64, name string, m *metrics.Map[T]) {
+	for _, k := range m.Keys() {
+		metricsMap[name+"."+k] = float64(m.GetKey(k))
+	}
+}
+
+// jsonMessage converts an EventMetrics into a JSON object. See the
+// MessageFormat documentation in the config for the format.
+func (s *Surfacer) jsonMessage(em *metrics.EventMetrics) ([]byte, error) {
+	msg := struct {
+		TimestampUsec int64              `json:"timestamp_usec"`
+		Kind          string             `json:"kind"`
+		Labels        map[string]string  `json:"labels"`
+		Metrics       map[string]float64 `json:"metrics"`
+	}{
+		TimestampUsec: em.Timestamp.UnixMicro(),
+		Kind:          "CUMULATIVE",
+		Labels:        make(map[string]string),
+		Metrics:       make(map[string]float64),
+	}
+	if em.Kind == metrics.GAUGE {
+		msg.Kind = "GAUGE"
+	}
+
+	for _, k := range em.LabelsKeys() {
+		msg.Labels[k] = em.Label(k)
+	}
+
+	for _, metricKey := range em.MetricsKeys() {
+		switch v := em.Metric(metricKey).(type) {
+		case metrics.NumValue:
+			msg.Metrics[metricKey] = v.Float64()
+		case *metrics.Map[int64]:
+			mapValues(msg.Metrics, metricKey, v)
+		case *metrics.Map[float64]:
+			mapValues(msg.Metrics, metricKey, v)
+		case *metrics.Distribution:
+			d := v.Data()
+			msg.Metrics[metricKey+".sum"] = d.Sum
+			msg.Metrics[metricKey+".count"] = float64(d.Count)
+		case metrics.String:
+			msg.Labels[metricKey] = strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+		}
+	}
+
+	return json.Marshal(msg)
+}
+
+// isTransientError reports whether a failed publish is worth retrying.
+func isTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	}
+	return true
+}
+
+// handlePublishResult waits for the publish result. If disk buffer is
+// configured, it buffers the data that failed to publish, and re-sends the
+// buffered data once publishing succeeds again.
+func (s *Surfacer) handlePublishResult(ctx context.Context, pr *publishResult) {
+	_, err := pr.res.Get(ctx)
+	s.opts.ReportPush(pr.start, err)
+	if err != nil {
+		s.l.Warningf("Error publishing message: %v", err)
+		// Publishing for an ordering key is paused after a failure.
+		if pr.msg.OrderingKey != "" {
+			s.topic.ResumePublish(pr.msg.OrderingKey)
+		}
+		if s.diskQueue != nil && ctx.Err() == nil && isTransientError(err) {
+			s.bufferMessage(pr.msg)
+		}
+		return
+	}
+
+	if s.diskQueue == nil || s.diskQueue.Len() == 0 {
+		return
+	}
+	err = s.diskQueue.Replay(func(data []byte) error {
+		var bm bufferedMessage
+		if err := json.Unmarshal(data, &bm); err != nil {
+			s.l.Warningf("Dropping undecodable buffered message: %v", err)
+			return nil
+		}
+		msg := &pubsub.Message{Data: bm.Data, Attributes: bm.Attributes, OrderingKey: bm.OrderingKey}
+
+		publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+		defer cancel()
+		start := time.Now()
+		_, err := s.topic.Publish(publishCtx, msg).Get(publishCtx)
+		s.opts.ReportPush(start, err)
+		if err != nil && msg.OrderingKey != "" {
+			s.topic.ResumePublish(msg.OrderingKey)
+		}
+		if err != nil && !isTransientError(err) {
+			s.l.Warningf("Dropping buffered message: %v", err)
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		s.l.Warningf("Error re-sending buffered messages: %v", err)
+	}
+}
+
+func (s *Surfacer) bufferMessage(msg *pubsub.Message) {
+	data, err := json.Marshal(&bufferedMessage{
+		Data:        msg.Data,
+		Attributes:  msg.Attributes,
+		OrderingKey: msg.OrderingKey,
+	})
+	if err != nil {
+		s.l.Errorf("Error encoding message for disk buffer: %v", err)
+		return
+	}
+	if err := s.diskQueue.Push(data); err != nil {
+		s.l.Errorf("Error buffering message on disk: %v", err)
+	}
+}
+
+// messageData returns the message data for an EventMetrics, in the
+// configured format.
+func (s *Surfacer) messageData(em *metrics.EventMetrics) ([]byte, error) {
+	if s.c.GetMessageFormat() == configpb.SurfacerConf_JSON {
+		return s.jsonMessage(em)
+	}
+	return []byte(em.String()), nil
 }
 
 func (s *Surfacer) processInput(ctx context.Context) {
@@ -106,10 +280,15 @@ func (s *Surfacer) processInput(ctx context.Context) {
 			if !ok {
 				return
 			}
+			data, err := s.messageData(em)
+			if err != nil {
+				s.l.Errorf("Error encoding EventMetrics: %v", err)
+				continue
+			}
 			if s.c.GetCompressionEnabled() {
-				s.compressionBuffer.WriteLineToBuffer(em.String())
+				s.compressionBuffer.WriteLineToBuffer(string(data))
 			} else {
-				s.publishMessage(ctx, []byte(em.String()))
+				s.publishMessage(ctx, s.newMessage(data, em))
 			}
 		}
 	}
@@ -148,13 +327,25 @@ func (s *Surfacer) init(ctx context.Context) error {
 		return fmt.Errorf("pubsub_surfacer: error determining if topic (%s) exists: %v", s.topicName, err)
 	}
 
-	if !exists {
-		topic, err := client.CreateTopic(ctx, s.topicName)
+	if exists {
+		if err := s.verifyTopicSchema(ctx); err != nil {
+			return err
+		}
+	} else {
+		tc := &pubsub.TopicConfig{}
+		if s.c.GetSchema() != "" {
+			tc.SchemaSettings = &pubsub.SchemaSettings{
+				Schema:   s.c.GetSchema(),
+				Encoding: pubsub.EncodingJSON,
+			}
+		}
+		topic, err := client.CreateTopicWithConfig(ctx, s.topicName, tc)
 		if err != nil {
 			return fmt.Errorf("pubsub_surfacer: error creating topic (%s) for publishing: %v", s.topicName, err)
 		}
 		s.topic = topic
 	}
+	s.topic.EnableMessageOrdering = s.c.GetOrderingKey() != ""
 
 	go func() {
 		for {
@@ -162,21 +353,18 @@ func (s *Surfacer) init(ctx context.Context) error {
 			case <-ctx.Done():
 				s.topic.Stop()
 				return
-			case res, ok := <-s.publishResultChan:
+			case pr, ok := <-s.publishResultChan:
 				if !ok {
 					return
 				}
-				_, err := res.Get(ctx)
-				if err != nil {
-					s.l.Warningf("Error publishing message: %v", err)
-				}
+				s.handlePublishResult(ctx, pr)
 			}
 		}
 	}()
 
 	if s.c.GetCompressionEnabled() {
 		s.compressionBuffer = compress.NewCompressionBuffer(ctx, func(data []byte) {
-			s.publishMessage(ctx, data)
+			s.publishMessage(ctx, s.newMessage(data, nil))
 		}, s.opts.MetricsBufferSize/10, s.l)
 	}
 
@@ -188,6 +376,31 @@ func (s *Surfacer) init(ctx context.Context) error {
 	return nil
 }
 
+// verifyTopicSchema verifies that an existing topic's schema settings are
+// compatible with the surfacer's configuration.
+func (s *Surfacer) verifyTopicSchema(ctx context.Context) error {
+	tc, err := s.topic.Config(ctx)
+	if err != nil {
+		return fmt.Errorf("pubsub_surfacer: error getting topic (%s) config: %v", s.topicName, err)
+	}
+
+	ss := tc.SchemaSettings
+	if ss == nil {
+		if s.c.GetSchema() != "" {
+			return fmt.Errorf("pubsub_surfacer: topic (%s) has no schema, expected schema: %s", s.topicName, s.c.GetSchema())
+		}
+		return nil
+	}
+
+	if s.c.GetSchema() != "" && ss.Schema != s.c.GetSchema() {
+		return fmt.Errorf("pubsub_surfacer: topic (%s) schema (%s) doesn't match the configured schema (%s)", s.topicName, ss.Schema, s.c.GetSchema())
+	}
+	if s.c.GetMessageFormat() != configpb.SurfacerConf_JSON || s.c.GetCompressionEnabled() || ss.Encoding != pubsub.EncodingJSON {
+		return fmt.Errorf("pubsub_surfacer: topic (%s) has schema (%s), it requires JSON schema encoding, JSON message format and no compression", s.topicName, ss.Schema)
+	}
+	return nil
+}
+
 // close closes the input channel, waits for input processing to finish,
 // and closes the compression buffer if open.
 func (s *Surfacer) close() {
@@ -213,13 +426,23 @@ func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
 
 // New initializes a Surfacer for publishing data to a pubsub topic.
 func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetCompressionEnabled() && (config.GetOrderingKey() != "" || len(config.GetLabelToAttribute()) != 0 || config.GetSchema() != "") {
+		return nil, errors.New("pubsub_surfacer: ordering_key, label_to_attribute and schema are not supported with compression")
+	}
+	if config.GetSchema() != "" && config.GetMessageFormat() != configpb.SurfacerConf_JSON {
+		return nil, errors.New("pubsub_surfacer: schema requires JSON message format")
+	}
+
 	s := &Surfacer{
 		c:                 config,
 		opts:              opts,
 		l:                 l,
 		topicName:         config.GetTopicName(),
 		gcpProject:        config.GetProject(),
-		publishResultChan: make(chan *pubsub.PublishResult, 1000),
+		publishResultChan: make(chan *publishResult, 1000),
+	}
+	if opts != nil {
+		s.diskQueue = opts.DiskQueue
 	}
 
 	return s, s.init(ctx)
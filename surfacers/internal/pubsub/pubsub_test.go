@@ -30,6 +30,7 @@ import (
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/compress"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/pubsub/proto"
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/api/option"
 	pb "google.golang.org/genproto/googleapis/pubsub/v1"
 	pb_grpc "google.golang.org/genproto/googleapis/pubsub/v1"
@@ -53,8 +54,9 @@ type testServer struct {
 
 // A Message is a message that was published to the server.
 type Message struct {
-	Data       []byte
-	Attributes map[string]string
+	Data        []byte
+	Attributes  map[string]string
+	OrderingKey string
 }
 
 func (s *testServer) CreateTopic(_ context.Context, t *pb.Topic) (*pb.Topic, error) {
@@ -76,8 +78,9 @@ func (s *testServer) Publish(_ context.Context, req *pb.PublishRequest) (*pb.Pub
 	var ids []string
 	for _, pm := range req.Messages {
 		m := &Message{
-			Data:       pm.Data,
-			Attributes: pm.Attributes,
+			Data:        pm.Data,
+			Attributes:  pm.Attributes,
+			OrderingKey: pm.OrderingKey,
 		}
 		ids = append(ids, fmt.Sprintf("m%d", s.nextID))
 		s.nextID++
@@ -86,42 +89,49 @@ func (s *testServer) Publish(_ context.Context, req *pb.PublishRequest) (*pb.Pub
 	return &pb.PublishResponse{MessageIds: ids}, nil
 }
 
+// startTestServer starts a test pubsub server and points newPubsubClient to
+// it.
+func startTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", 0))
+	if err != nil {
+		t.Fatalf("Error creating listener: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	gSrv := grpc.NewServer()
+	srv := &testServer{
+		topics: map[string]*pb.Topic{},
+	}
+
+	pb_grpc.RegisterPublisherServer(gSrv, srv)
+	pb_grpc.RegisterSubscriberServer(gSrv, srv)
+
+	go func() {
+		if err := gSrv.Serve(l); err != nil {
+			t.Errorf("gRPC server start: %v", err)
+		}
+	}()
+	t.Cleanup(gSrv.Stop)
+
+	// Connect to the server without using TLS.
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Error establishing connection to the test pubsub server (%s): %v", l.Addr().String(), err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	newPubsubClient = func(ctx context.Context, project string) (*pubsub.Client, error) {
+		return pubsub.NewClient(ctx, project, option.WithGRPCConn(conn))
+	}
+	return srv
+}
+
 func TestSurfacer(t *testing.T) {
 	for _, compression := range []bool{false, true} {
 		t.Run(fmt.Sprintf("with_compression=%v", compression), func(t *testing.T) {
-			l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", 0))
-			if err != nil {
-				t.Fatalf("Error creating listener: %v", err)
-			}
-			defer l.Close()
-
-			gSrv := grpc.NewServer()
-			srv := &testServer{
-				topics: map[string]*pb.Topic{},
-			}
-
-			pb_grpc.RegisterPublisherServer(gSrv, srv)
-			pb_grpc.RegisterSubscriberServer(gSrv, srv)
-
-			go func() {
-				if err := gSrv.Serve(l); err != nil {
-					t.Errorf("gRPC server start: %v", err)
-				}
-			}()
-			defer gSrv.Stop()
-
-			// Connect to the server without using TLS.
-			conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
-			if err != nil {
-				t.Fatalf("Error establishing connection to the test pubsub server (%s): %v", l.Addr().String(), err)
-			}
-			defer conn.Close()
-
-			newPubsubClient = func(ctx context.Context, project string) (*pubsub.Client, error) {
-				return pubsub.NewClient(ctx, project, option.WithGRPCConn(conn))
-			}
-
-			createSurfacerAndVerify(t, srv, compression)
+			createSurfacerAndVerify(t, startTestServer(t), compression)
 		})
 	}
 }
@@ -183,3 +193,71 @@ func createSurfacerAndVerify(t *testing.T, srv *testServer, compression bool) {
 		}
 	}
 }
+
+func TestSurfacerJSONWithOrderingAndAttributes(t *testing.T) {
+	srv := startTestServer(t)
+
+	conf := &configpb.SurfacerConf{
+		Project:          proto.String("test-project"),
+		TopicName:        proto.String("test-topic"),
+		MessageFormat:    configpb.SurfacerConf_JSON.Enum(),
+		OrderingKey:      proto.String("@probe@/@dst@"),
+		LabelToAttribute: map[string]string{"probe": "probe_name"},
+		Schema:           proto.String("projects/test-project/schemas/cloudprober"),
+	}
+	s, err := New(context.Background(), conf, &options.Options{MetricsBufferSize: 1000}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error while creating new surfacer: %v", err)
+	}
+
+	// Topic is created with the schema.
+	topic := srv.topics["projects/test-project/topics/test-topic"]
+	assert.Equal(t, "projects/test-project/schemas/cloudprober", topic.GetSchemaSettings().GetSchema())
+	assert.Equal(t, pb.Encoding_JSON, topic.GetSchemaSettings().GetEncoding())
+
+	ts := time.Unix(1700000000, 0)
+	s.Write(context.Background(), metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(20)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 19)).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", "web").
+		AddLabel("dst", "example.com"))
+	s.close()
+
+	assert.Len(t, srv.msgs, 1)
+	msg := srv.msgs[0]
+	assert.Equal(t, "web/example.com", msg.OrderingKey)
+	assert.Equal(t, map[string]string{
+		"starttime":  s.starttime,
+		"compressed": "false",
+		"probe_name": "web",
+	}, msg.Attributes)
+	assert.JSONEq(t, `{
+		"timestamp_usec": 1700000000000000,
+		"kind": "CUMULATIVE",
+		"labels": {"probe": "web", "dst": "example.com", "version": "v1"},
+		"metrics": {"total": 20, "resp-code.200": 19}
+	}`, string(msg.Data))
+
+	// Topic exists now, and its schema requires JSON format.
+	conf.MessageFormat, conf.Schema = nil, nil
+	_, err = New(context.Background(), conf, &options.Options{MetricsBufferSize: 1000}, &logger.Logger{})
+	assert.Error(t, err)
+}
+
+func TestNewConfigErrors(t *testing.T) {
+	for name, conf := range map[string]*configpb.SurfacerConf{
+		"ordering_with_compression": {
+			CompressionEnabled: proto.Bool(true),
+			OrderingKey:        proto.String("@probe@"),
+		},
+		"schema_with_text_format": {
+			Schema: proto.String("projects/p/schemas/s"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(context.Background(), conf, &options.Options{}, &logger.Logger{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package proto
+
+// Surfacer config for SQLite surfacer. This surfacer writes metrics into a
+// local SQLite database file, making it possible to keep queryable history on
+// single-node (e.g. edge or air-gapped) deployments without any external
+// backend.
+//
+// Metrics are stored in the following table (created automatically):
+//   CREATE TABLE metrics (
+//     time INTEGER,  -- Unix time in milliseconds
+//     metric_name TEXT,
+//     value REAL,
+//     labels TEXT    -- JSON object
+//   )
+#SurfacerConf: {
+	// Path to the database file. It's created if it doesn't exist.
+	file?: string @protobuf(1,string)
+
+	// Metrics table name.
+	tableName?: string @protobuf(2,string,name=table_name,#"default="metrics""#)
+
+	// Rows older than this are deleted periodically. Set to 0 to keep all data.
+	retentionHours?: int32 @protobuf(3,int32,name=retention_hours,"default=168")
+
+	// How often to delete old rows.
+	pruneIntervalSec?: int32 @protobuf(4,int32,name=prune_interval_sec,"default=3600")
+
+	// Maximum number of rows to write in one transaction. Rows are written when
+	// the batch is full or when the batch timer expires, whichever happens
+	// first.
+	metricsBatchSize?: int32 @protobuf(5,int32,name=metrics_batch_size,"default=1000")
+
+	// Maximum time to hold rows in the batch before writing them.
+	batchTimerSec?: int32 @protobuf(6,int32,name=batch_timer_sec,"default=10")
+
+	// If set, a read API is exposed at this URL on the cloudprober's HTTP
+	// server, e.g. "/sqlite/metrics". It returns matching rows in JSON format.
+	// Query parameters:
+	//   metric: metric name (required)
+	//   since, until: time range, as Unix time (seconds) or as a duration
+	//                 relative to now, e.g. "1h". Default: last 1 hour.
+	//   limit: maximum number of rows to return (default: 1000)
+	// All other parameters are used as label filters, e.g.
+	//   /sqlite/metrics?metric=total&probe=homepage&since=24h
+	readApiUrl?: string @protobuf(7,string,name=read_api_url)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultQueryWindow = time.Hour
+	defaultQueryLimit  = 1000
+)
+
+var validLabelKey = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+
+// Reserved query parameters; all other parameters are label filters.
+var reservedParams = map[string]bool{"metric": true, "since": true, "until": true, "limit": true}
+
+type queryRow struct {
+	Time   int64             `json:"time"`
+	Metric string            `json:"metric"`
+	Value  float64           `json:"value"`
+	Labels map[string]string `json:"labels"`
+}
+
+// parseTime parses a time query parameter, specified either as Unix time
+// (seconds) or as a duration relative to now.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time: %s", s)
+	}
+	return now.Add(-d), nil
+}
+
+// buildQuery builds the SQL query and its arguments for the read API request
+// parameters.
+func (s *Surfacer) buildQuery(params url.Values, now time.Time) (string, []any, error) {
+	metric := params.Get("metric")
+	if metric == "" {
+		return "", nil, fmt.Errorf("metric parameter is required")
+	}
+
+	since, until := now.Add(-defaultQueryWindow), now
+	var err error
+	if v := params.Get("since"); v != "" {
+		if since, err = parseTime(v, now); err != nil {
+			return "", nil, err
+		}
+	}
+	if v := params.Get("until"); v != "" {
+		if until, err = parseTime(v, now); err != nil {
+			return "", nil, err
+		}
+	}
+
+	limit := defaultQueryLimit
+	if v := params.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			return "", nil, fmt.Errorf("invalid limit: %s", v)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("SELECT time, metric_name, value, labels FROM %s WHERE metric_name = ? AND time >= ? AND time <= ?", s.c.GetTableName()))
+	args := []any{metric, since.UnixMilli(), until.UnixMilli()}
+
+	// Sort label filters for a deterministic query.
+	var keys []string
+	for k := range params {
+		if !reservedParams[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !validLabelKey.MatchString(k) {
+			return "", nil, fmt.Errorf("invalid label name: %s", k)
+		}
+		b.WriteString(` AND json_extract(labels, '$."` + k + `"') = ?`)
+		args = append(args, params.Get(k))
+	}
+
+	b.WriteString(" ORDER BY time LIMIT ?")
+	args = append(args, limit)
+
+	return b.String(), args, nil
+}
+
+func (s *Surfacer) query(ctx context.Context, q string, args []any) ([]queryRow, error) {
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []queryRow{}
+	for rows.Next() {
+		var r queryRow
+		var labels string
+		if err := rows.Scan(&r.Time, &r.Metric, &r.Value, &labels); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(labels), &r.Labels); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+func (s *Surfacer) readHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		q, args, err := s.buildQuery(r.Form, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rows, err := s.query(r.Context(), q, args)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rows)
+	})
+}
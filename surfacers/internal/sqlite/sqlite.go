@@ -0,0 +1,289 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package sqlite implements a surfacer that writes metrics into a local SQLite
+database file. It uses a pure-Go SQLite implementation, so it doesn't require
+cgo.
+
+To use this surfacer, add a stanza similar to the following to your
+cloudprober config:
+
+	surfacer {
+	  type: SQLITE
+	  sqlite_surfacer {
+	    file: "/var/lib/cloudprober/metrics.db"
+	    retention_hours: 72
+	    read_api_url: "/sqlite/metrics"
+	  }
+	}
+*/
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/sqlite/proto"
+
+	// Registers the "sqlite" database/sql driver.
+	_ "modernc.org/sqlite"
+)
+
+var validIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// row represents a single metric and corresponds to a single row in the
+// metrics table.
+type row struct {
+	time       int64
+	metricName string
+	value      float64
+	labels     map[string]string
+}
+
+// Surfacer implements a SQLite surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	db        *sql.DB
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	batch []row
+}
+
+// New creates a new instance of the SQLite surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetFile() == "" {
+		return nil, fmt.Errorf("sqlite: file is required")
+	}
+	if !validIdentifier.MatchString(config.GetTableName()) {
+		return nil, fmt.Errorf("sqlite: invalid table name: %s", config.GetTableName())
+	}
+	if config.GetMetricsBatchSize() <= 0 {
+		return nil, fmt.Errorf("sqlite: invalid metrics_batch_size: %d", config.GetMetricsBatchSize())
+	}
+
+	// WAL journal mode lets the read API query the database while we are
+	// writing to it.
+	dsn := "file:" + config.GetFile() + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: error opening database (%s): %v", config.GetFile(), err)
+	}
+
+	s := &Surfacer{
+		c:         config,
+		opts:      opts,
+		db:        db,
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]row, 0, config.GetMetricsBatchSize()),
+	}
+
+	if err := s.createTable(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite: %v", err)
+	}
+
+	if config.GetReadApiUrl() != "" && opts.HTTPServeMux != nil {
+		opts.HTTPServeMux.Handle(config.GetReadApiUrl(), s.readHandler())
+	}
+
+	go s.processMetrics(ctx)
+
+	return s, nil
+}
+
+func (s *Surfacer) createTable(ctx context.Context) error {
+	table := s.c.GetTableName()
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (time INTEGER NOT NULL, metric_name TEXT NOT NULL, value REAL, labels TEXT)", table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_metric_time ON %s (metric_name, time)", table, table),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_time ON %s (time)", table, table),
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("error creating table %s: %v", table, err)
+		}
+	}
+	return nil
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	defer s.db.Close()
+
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	// Prune timer is not started if retention is disabled.
+	var pruneChan <-chan time.Time
+	if s.c.GetRetentionHours() > 0 {
+		pruneTicker := time.NewTicker(time.Duration(s.c.GetPruneIntervalSec()) * time.Second)
+		defer pruneTicker.Stop()
+		pruneChan = pruneTicker.C
+		s.prune(ctx, time.Now())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			for _, r := range s.rows(em) {
+				s.batch = append(s.batch, r)
+				if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+					s.flush(ctx)
+					batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+				}
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		case <-pruneChan:
+			s.prune(ctx, time.Now())
+		}
+	}
+}
+
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	err := s.writeRows(ctx, s.batch)
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		s.l.Errorf("Error writing %d rows to SQLite: %v", len(s.batch), err)
+	}
+	s.batch = s.batch[:0]
+}
+
+// writeRows writes rows to the database in a single transaction.
+func (s *Surfacer) writeRows(ctx context.Context, rows []row) error {
+	txn, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	stmt, err := txn.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (time, metric_name, value, labels) VALUES (?, ?, ?, ?)", s.c.GetTableName()))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		labels, err := json.Marshal(r.labels)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, r.time, r.metricName, r.value, string(labels)); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// prune deletes rows older than the retention period.
+func (s *Surfacer) prune(ctx context.Context, now time.Time) {
+	cutoff := now.Add(-time.Duration(s.c.GetRetentionHours()) * time.Hour).UnixMilli()
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE time < ?", s.c.GetTableName()), cutoff)
+	if err != nil {
+		s.l.Errorf("Error deleting old rows from SQLite: %v", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		s.l.Infof("Deleted %d rows older than %d hours", n, s.c.GetRetentionHours())
+	}
+}
+
+func withLabel(labels map[string]string, k, v string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for lk, lv := range labels {
+		out[lk] = lv
+	}
+	out[k] = v
+	return out
+}
+
+func mapRows[T int64 | float64](m *metrics.Map[T], ts int64, name string, labels map[string]string) []row {
+	var out []row
+	for _, k := range m.Keys() {
+		out = append(out, row{ts, name, float64(m.GetKey(k)), withLabel(labels, m.MapName, k)})
+	}
+	return out
+}
+
+// rows converts an EventMetrics into table rows.
+func (s *Surfacer) rows(em *metrics.EventMetrics) []row {
+	ts := em.Timestamp.UnixMilli()
+
+	labels := make(map[string]string)
+	for _, k := range em.LabelsKeys() {
+		labels[k] = em.Label(k)
+	}
+
+	var out []row
+	for _, metricName := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricName) {
+			continue
+		}
+
+		switch v := em.Metric(metricName).(type) {
+		case metrics.NumValue:
+			out = append(out, row{ts, metricName, v.Float64(), labels})
+		case *metrics.Map[int64]:
+			out = append(out, mapRows(v, ts, metricName, labels)...)
+		case *metrics.Map[float64]:
+			out = append(out, mapRows(v, ts, metricName, labels)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, row{ts, metricName + "_sum", d.Sum, labels})
+			out = append(out, row{ts, metricName + "_count", float64(d.Count), labels})
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "+Inf"
+				if i < len(d.LowerBounds)-1 {
+					le = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
+				}
+				out = append(out, row{ts, metricName + "_bucket", float64(cumCount), withLabel(labels, "le", le)})
+			}
+		case metrics.String:
+			// Convert string metrics to a numeric metric by moving metric value
+			// to the "val" label and setting the metric value to 1.
+			out = append(out, row{ts, metricName, 1, withLabel(labels, "val", strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\""))})
+		}
+	}
+	return out
+}
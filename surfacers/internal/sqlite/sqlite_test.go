@@ -0,0 +1,161 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/sqlite/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSurfacer(ctx context.Context, t *testing.T, conf *configpb.SurfacerConf) (*Surfacer, *http.ServeMux) {
+	t.Helper()
+
+	conf.File = proto.String(filepath.Join(t.TempDir(), "metrics.db"))
+	mux := http.NewServeMux()
+	s, err := New(ctx, conf, &options.Options{MetricsBufferSize: 10, HTTPServeMux: mux}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+	return s, mux
+}
+
+func TestRows(t *testing.T) {
+	d := metrics.NewDistribution([]float64{1})
+	d.AddSample(2)
+
+	em := metrics.NewEventMetrics(time.Unix(1700000000, 0)).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", "p1")
+
+	s := &Surfacer{opts: &options.Options{}}
+	labels := map[string]string{"probe": "p1"}
+	ts := int64(1700000000000)
+
+	assert.Equal(t, []row{
+		{ts, "total", 10, labels},
+		{ts, "resp-code", 4, map[string]string{"probe": "p1", "code": "200"}},
+		{ts, "latency_sum", 2, labels},
+		{ts, "latency_count", 1, labels},
+		{ts, "latency_bucket", 0, map[string]string{"probe": "p1", "le": "1"}},
+		{ts, "latency_bucket", 1, map[string]string{"probe": "p1", "le": "+Inf"}},
+		{ts, "version", 1, map[string]string{"probe": "p1", "val": "v1"}},
+	}, s.rows(em))
+}
+
+func TestWriteQueryPrune(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, _ := testSurfacer(ctx, t, &configpb.SurfacerConf{RetentionHours: proto.Int32(1)})
+
+	now := time.Now()
+	var rows []row
+	for i, ts := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Minute), now} {
+		rows = append(rows, row{ts.UnixMilli(), "total", float64(i), map[string]string{"probe": "p1"}})
+		rows = append(rows, row{ts.UnixMilli(), "total", float64(10 + i), map[string]string{"probe": "p2"}})
+	}
+	assert.NoError(t, s.writeRows(ctx, rows))
+
+	query := func(params url.Values) []queryRow {
+		t.Helper()
+		q, args, err := s.buildQuery(params, now)
+		assert.NoError(t, err)
+		out, err := s.query(ctx, q, args)
+		assert.NoError(t, err)
+		return out
+	}
+
+	// Default window: last 1 hour.
+	assert.Len(t, query(url.Values{"metric": {"total"}}), 4)
+	assert.Len(t, query(url.Values{"metric": {"total"}, "since": {"3h"}}), 6)
+
+	got := query(url.Values{"metric": {"total"}, "probe": {"p2"}, "since": {"3h"}, "limit": {"2"}})
+	assert.Equal(t, []queryRow{
+		{now.Add(-2 * time.Hour).UnixMilli(), "total", 10, map[string]string{"probe": "p2"}},
+		{now.Add(-time.Minute).UnixMilli(), "total", 11, map[string]string{"probe": "p2"}},
+	}, got)
+
+	s.prune(ctx, now)
+	assert.Len(t, query(url.Values{"metric": {"total"}, "since": {"3h"}}), 4)
+}
+
+func TestBuildQueryErrors(t *testing.T) {
+	s := &Surfacer{c: &configpb.SurfacerConf{}}
+	for _, params := range []url.Values{
+		{},
+		{"metric": {"total"}, "since": {"yesterday"}},
+		{"metric": {"total"}, "limit": {"-1"}},
+		{"metric": {"total"}, "probe') OR 1=1 --": {"x"}},
+	} {
+		_, _, err := s.buildQuery(params, time.Now())
+		assert.Error(t, err, "params: %v", params)
+	}
+}
+
+func TestSurfacer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, mux := testSurfacer(ctx, t, &configpb.SurfacerConf{
+		MetricsBatchSize: proto.Int32(1),
+		ReadApiUrl:       proto.String("/sqlite/metrics"),
+	})
+
+	s.Write(ctx, metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(10)).
+		AddLabel("probe", "p1"))
+
+	assert.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/sqlite/metrics?metric=total&probe=p1", nil))
+		if w.Code != http.StatusOK {
+			return false
+		}
+		var rows []queryRow
+		json.Unmarshal(w.Body.Bytes(), &rows)
+		return len(rows) == 1 && rows[0].Value == 10
+	}, 5*time.Second, 50*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/sqlite/metrics", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{},
+		{File: proto.String("/tmp/x.db"), TableName: proto.String("metrics; DROP TABLE x")},
+		{File: proto.String("/tmp/x.db"), MetricsBatchSize: proto.Int32(0)},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, nil)
+		assert.Error(t, err, "conf: %v", conf)
+	}
+}
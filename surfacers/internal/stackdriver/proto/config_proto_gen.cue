@@ -1,5 +1,30 @@
 package proto
 
+// MonitoredResource maps EventMetrics to a custom monitored resource.
+#MonitoredResource: {
+	// Monitored resource type, e.g. "k8s_container", "gce_instance",
+	// "generic_node". See https://cloud.google.com/monitoring/api/resources for
+	// the resource types and their labels.
+	type?: string @protobuf(1,string)
+
+	// Monitored resource labels. Label values can refer to EventMetrics labels
+	// (e.g. probe, dst, or target labels) using the @label@ syntax. If
+	// "project_id" label is not specified, it's set to the surfacer's project.
+	// Example:
+	//   labels { key: "node_id" value: "@dst@" }
+	//   labels { key: "location" value: "@region@" }
+	labels?: {
+		[string]: string
+	} @protobuf(2,map[string]string)
+
+	// Use this resource only for EventMetrics that have all of these labels
+	// with the given values. If not specified, resource applies to all
+	// EventMetrics.
+	matchLabels?: {
+		[string]: string
+	} @protobuf(3,map[string]string,match_labels)
+}
+
 #SurfacerConf: {
 	// GCP project name for stackdriver. If not specified and running on GCP,
 	// project is used.
@@ -50,4 +75,11 @@ package proto
 	// Metric prefix to use for stackdriver metrics. If not specified, default
 	// is PTYPE_PROBE.
 	metricsPrefix?: #MetricPrefix @protobuf(6,MetricPrefix,name=metrics_prefix,"default=PTYPE_PROBE")
+
+	// Custom monitored resources. For each EventMetrics, the first resource
+	// with matching match_labels is used. If a resource label refers to a label
+	// that the EventMetrics doesn't have, that resource is skipped. If no
+	// resource applies, we fall back to the automatically detected resource
+	// (on GCP), or to no resource (global).
+	monitoredResource?: [...#MonitoredResource] @protobuf(7,MonitoredResource,name=monitored_resource)
 }
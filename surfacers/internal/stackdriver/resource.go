@@ -20,7 +20,10 @@ import (
 
 	"cloud.google.com/go/compute/metadata"
 	md "github.com/cloudprober/cloudprober/common/metadata"
+	"github.com/cloudprober/cloudprober/common/strtemplate"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/stackdriver/proto"
 	monitoring "google.golang.org/api/monitoring/v3"
 )
 
@@ -113,3 +116,51 @@ func monitoredResourceOnGCE(projectID string, l *logger.Logger) (*monitoring.Mon
 	}
 	return gceResource(projectID, l)
 }
+
+// customResource builds the monitored resource for the given EventMetrics
+// labels from the resource config. It returns nil if EventMetrics doesn't
+// match the resource's match_labels, or if it doesn't have all the labels
+// that resource labels refer to.
+func customResource(conf *configpb.MonitoredResource, emLabels map[string]string, projectID string) *monitoring.MonitoredResource {
+	for k, v := range conf.GetMatchLabels() {
+		if emLabels[k] != v {
+			return nil
+		}
+	}
+
+	labels := make(map[string]string, len(conf.GetLabels())+1)
+	for k, tmpl := range conf.GetLabels() {
+		v, foundAll := strtemplate.SubstituteLabels(tmpl, emLabels)
+		if !foundAll {
+			return nil
+		}
+		labels[k] = v
+	}
+	if _, ok := labels["project_id"]; !ok && projectID != "" {
+		labels["project_id"] = projectID
+	}
+
+	return &monitoring.MonitoredResource{
+		Type:   conf.GetType(),
+		Labels: labels,
+	}
+}
+
+// monitoredResource returns the monitored resource for the EventMetrics: the
+// first matching custom resource, or the default resource.
+func (s *SDSurfacer) monitoredResource(em *metrics.EventMetrics) *monitoring.MonitoredResource {
+	if len(s.c.GetMonitoredResource()) == 0 {
+		return s.resource
+	}
+
+	emLabels := make(map[string]string, len(em.LabelsKeys()))
+	for _, k := range em.LabelsKeys() {
+		emLabels[k] = em.Label(k)
+	}
+	for _, conf := range s.c.GetMonitoredResource() {
+		if mr := customResource(conf, emLabels, s.projectName); mr != nil {
+			return mr
+		}
+	}
+	return s.resource
+}
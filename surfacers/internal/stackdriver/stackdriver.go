@@ -21,6 +21,8 @@ package stackdriver
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -31,10 +33,12 @@ import (
 	"cloud.google.com/go/compute/metadata"
 	"github.com/cloudprober/cloudprober/logger"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	monitoring "google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/option"
 
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/diskqueue"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/stackdriver/proto"
 )
@@ -79,6 +83,9 @@ type SDSurfacer struct {
 
 	// Monitoring client
 	client *monitoring.Service
+
+	// Disk queue for the time series that we fail to write, if configured.
+	diskQueue *diskqueue.Queue
 }
 
 // New initializes a SDSurfacer for Stackdriver with all its necessary internal
@@ -98,6 +105,9 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		startTime:    time.Now(),
 		l:            l,
 	}
+	if opts != nil {
+		s.diskQueue = opts.DiskQueue
+	}
 
 	if s.c.GetAllowedMetricsRegex() != "" {
 		l.Warning("allowed_metrics_regex is now deprecated. Please use the common surfacer options: allow_metrics, ignore_metrics.")
@@ -108,6 +118,12 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		s.allowedMetricsRegex = r
 	}
 
+	for _, mr := range s.c.GetMonitoredResource() {
+		if mr.GetType() == "" {
+			return nil, fmt.Errorf("monitored_resource type is required: %v", mr)
+		}
+	}
+
 	// Find all the necessary information for writing metrics to Stack
 	// Driver.
 	var err error
@@ -246,13 +262,7 @@ func (s *SDSurfacer) writeBatch(ctx context.Context) {
 				// a time series create call will automatically register a new metric
 				// with the correct information if it does not already exist.
 				// Ref: https://cloud.google.com/monitoring/custom-metrics/creating-metrics#auto-creation
-				requestBody := monitoring.CreateTimeSeriesRequest{
-					TimeSeries: ts[i:endIndex],
-				}
-				if _, err := s.client.Projects.TimeSeries.Create("projects/"+s.projectName, &requestBody).Do(); err != nil {
-					s.failCnt++
-					s.l.Warningf("Unable to fulfill TimeSeries Create call. Err: %v", err)
-				}
+				s.sendBatch(ts[i:endIndex])
 			}
 
 			// Flush the cache after we've finished writing so we don't accidentally
@@ -265,6 +275,75 @@ func (s *SDSurfacer) writeBatch(ctx context.Context) {
 	}
 }
 
+func (s *SDSurfacer) createTimeSeries(ts []*monitoring.TimeSeries) error {
+	requestBody := monitoring.CreateTimeSeriesRequest{
+		TimeSeries: ts,
+	}
+	start := time.Now()
+	_, err := s.client.Projects.TimeSeries.Create("projects/"+s.projectName, &requestBody).Do()
+	s.opts.ReportPush(start, err)
+	return err
+}
+
+// isTransientError reports whether a failed write is worth retrying.
+func isTransientError(err error) bool {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		return gErr.Code == http.StatusTooManyRequests || gErr.Code >= 500
+	}
+	// Network errors, timeouts, etc.
+	return true
+}
+
+// sendBatch writes a batch of time series to stackdriver. If disk buffer is
+// configured, batches that fail with transient errors are buffered on disk
+// and re-sent before the next batch.
+func (s *SDSurfacer) sendBatch(ts []*monitoring.TimeSeries) {
+	if s.diskQueue != nil && s.diskQueue.Len() > 0 {
+		// Points of a time series must be written in order, so we send the
+		// buffered data first, and buffer the new data as well if that fails.
+		if err := s.diskQueue.Replay(s.replayBatch); err != nil {
+			s.l.Warningf("Error re-sending buffered time series (%v), buffering %d new time series", err, len(ts))
+			s.bufferBatch(ts)
+			return
+		}
+	}
+
+	if err := s.createTimeSeries(ts); err != nil {
+		s.failCnt++
+		s.l.Warningf("Unable to fulfill TimeSeries Create call. Err: %v", err)
+		if s.diskQueue != nil && isTransientError(err) {
+			s.bufferBatch(ts)
+		}
+	}
+}
+
+func (s *SDSurfacer) bufferBatch(ts []*monitoring.TimeSeries) {
+	b, err := json.Marshal(ts)
+	if err == nil {
+		err = s.diskQueue.Push(b)
+	}
+	if err != nil {
+		s.l.Errorf("Error buffering %d time series on disk: %v", len(ts), err)
+	}
+}
+
+// replayBatch re-sends a buffered batch. Batches that can't be decoded or
+// that fail with non-transient errors are dropped.
+func (s *SDSurfacer) replayBatch(data []byte) error {
+	var ts []*monitoring.TimeSeries
+	if err := json.Unmarshal(data, &ts); err != nil {
+		s.l.Warningf("Dropping undecodable buffered time series: %v", err)
+		return nil
+	}
+	err := s.createTimeSeries(ts)
+	if err != nil && !isTransientError(err) {
+		s.l.Warningf("Dropping %d buffered time series: %v", len(ts), err)
+		return nil
+	}
+	return err
+}
+
 //-----------------------------------------------------------------------------
 // StackDriver Object Creation and Helper Functions
 //-----------------------------------------------------------------------------
@@ -277,6 +356,7 @@ type baseMetric struct {
 	labels           map[string]string
 	valueType        string
 	cacheKey         string
+	resource         *monitoring.MonitoredResource
 }
 
 func (bm *baseMetric) Clone() *baseMetric {
@@ -327,8 +407,8 @@ func (s *SDSurfacer) recordTimeSeries(bm *baseMetric, tv *monitoring.TypedValue)
 		},
 	}
 
-	if s.resource != nil {
-		ts.Resource = s.resource
+	if bm.resource != nil {
+		ts.Resource = bm.resource
 	}
 
 	// We create a key that is a composite of both the name and the
@@ -395,6 +475,7 @@ func (s *SDSurfacer) baseMetric(em *metrics.EventMetrics) (*baseMetric, string)
 		valueType: "DOUBLE",
 		labels:    labels,
 		cacheKey:  strings.Join(sortedLabels, ","),
+		resource:  s.monitoredResource(em),
 	}, metricPrefix
 }
 
@@ -291,3 +291,86 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitoredResource(t *testing.T) {
+	s := newTestSurfacer()
+	s.c = &configpb.SurfacerConf{
+		MonitoredResource: []*configpb.MonitoredResource{
+			{
+				Type: proto.String("k8s_container"),
+				Labels: map[string]string{
+					"cluster_name":   "@cluster@",
+					"location":       "us-central1",
+					"namespace_name": "@namespace@",
+					"pod_name":       "@dst@",
+					"container_name": "app",
+				},
+				MatchLabels: map[string]string{"ptype": "http"},
+			},
+			{
+				Type: proto.String("generic_node"),
+				Labels: map[string]string{
+					"project_id": "other-project",
+					"location":   "global",
+					"namespace":  "probes",
+					"node_id":    "@dst@",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		labels [][2]string
+		want   *monitoring.MonitoredResource
+	}{
+		{
+			name:   "k8s_container",
+			labels: [][2]string{{"ptype", "http"}, {"dst", "pod-1"}, {"cluster", "c1"}, {"namespace", "default"}},
+			want: &monitoring.MonitoredResource{
+				Type: "k8s_container",
+				Labels: map[string]string{
+					"project_id":     "test-project",
+					"cluster_name":   "c1",
+					"location":       "us-central1",
+					"namespace_name": "default",
+					"pod_name":       "pod-1",
+					"container_name": "app",
+				},
+			},
+		},
+		{
+			name:   "missing_label_falls_through",
+			labels: [][2]string{{"ptype", "http"}, {"dst", "pod-1"}},
+			want: &monitoring.MonitoredResource{
+				Type: "generic_node",
+				Labels: map[string]string{
+					"project_id": "other-project",
+					"location":   "global",
+					"namespace":  "probes",
+					"node_id":    "pod-1",
+				},
+			},
+		},
+		{
+			name:   "default",
+			labels: [][2]string{{"ptype", "ping"}},
+			want:   s.resource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			em := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1))
+			for _, l := range tt.labels {
+				em.AddLabel(l[0], l[1])
+			}
+			assert.Equal(t, tt.want, s.monitoredResource(em))
+
+			s.opts = &options.Options{}
+			s.c.MonitoringUrl = proto.String("custom.googleapis.com/cloudprober/")
+			ts := s.recordEventMetrics(em)
+			assert.Equal(t, tt.want, ts[0].Resource)
+		})
+	}
+}
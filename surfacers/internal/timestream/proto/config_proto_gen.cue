@@ -0,0 +1,34 @@
+package proto
+
+#SurfacerConf: {
+	// Timestream database and table to write to. Both must already exist.
+	database?: string @protobuf(1,string)
+	table?:    string @protobuf(2,string)
+
+	// The AWS Region, used to create the Timestream client.
+	// The order of fallback for evaluating the AWS Region:
+	// 1. This config value.
+	// 2. EC2 metadata endpoint, via cloudprober sysvars.
+	// 3. AWS_REGION environment value.
+	region?: string @protobuf(3,string)
+
+	// Measure name for the multi-measure records. Each EventMetrics is written
+	// as one multi-measure record (or more, if it has more measures than a
+	// record can hold), with this measure name and one measure value per
+	// metric.
+	measureName?: string @protobuf(4,string,name=measure_name,#"default="cloudprober""#)
+
+	// Labels to export as dimensions. If not specified, all labels are exported
+	// as dimensions. Labels with empty values are always skipped as Timestream
+	// doesn't allow empty dimension values.
+	dimensionLabel?: [...string] @protobuf(5,string,name=dimension_label)
+
+	// Maximum number of records to write in one WriteRecords call. 100 is the
+	// maximum number of records supported by the WriteRecords API.
+	// Records will be written when the timer expires, or the buffer is full,
+	// whichever happens first.
+	metricsBatchSize?: int32 @protobuf(6,int32,name=metrics_batch_size,"default=100")
+
+	// The maximum amount of time to hold records in the buffer (above).
+	batchTimerSec?: int32 @protobuf(7,int32,name=batch_timer_sec,"default=10")
+}
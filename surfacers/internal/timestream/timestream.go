@@ -0,0 +1,277 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package timestream implements a surfacer to export metrics to Amazon
+Timestream.
+
+Each EventMetrics is written as a multi-measure record: EventMetrics labels
+become dimensions and each metric becomes a measure value. Map values are
+flattened into one measure per key (e.g. "resp-code_200"), and distributions
+into "<metric>_sum", "<metric>_count" and "<metric>_le_<upper_bound>"
+(cumulative counts) measures.
+*/
+package timestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite/types"
+	"github.com/cloudprober/cloudprober/internal/sysvars"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/timestream/proto"
+)
+
+// Timestream API limits.
+const (
+	maxRecordsPerWrite   = 100
+	maxMeasuresPerRecord = 256
+)
+
+// writeClient is the subset of the Timestream write client used by the
+// surfacer. It's an interface to allow testing without AWS.
+type writeClient interface {
+	WriteRecords(ctx context.Context, params *timestreamwrite.WriteRecordsInput, optFns ...func(*timestreamwrite.Options)) (*timestreamwrite.WriteRecordsOutput, error)
+}
+
+// Surfacer implements an Amazon Timestream surfacer.
+type Surfacer struct {
+	c         *configpb.SurfacerConf
+	opts      *options.Options
+	client    writeClient
+	writeChan chan *metrics.EventMetrics
+	l         *logger.Logger
+
+	batch []types.Record
+}
+
+// New creates a new instance of the Timestream surfacer.
+func New(ctx context.Context, conf *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if conf.GetDatabase() == "" || conf.GetTable() == "" {
+		return nil, fmt.Errorf("timestream: both database and table must be specified")
+	}
+	if conf.GetMetricsBatchSize() <= 0 || conf.GetMetricsBatchSize() > maxRecordsPerWrite {
+		return nil, fmt.Errorf("timestream: metrics_batch_size (%d) must be between 1 and %d", conf.GetMetricsBatchSize(), maxRecordsPerWrite)
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(getRegion(conf)))
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSurfacer(conf, opts, timestreamwrite.NewFromConfig(cfg), l)
+	go s.processMetrics(ctx)
+
+	s.l.Infof("Initialized Timestream surfacer for table %s.%s", conf.GetDatabase(), conf.GetTable())
+	return s, nil
+}
+
+func newSurfacer(conf *configpb.SurfacerConf, opts *options.Options, client writeClient, l *logger.Logger) *Surfacer {
+	return &Surfacer{
+		c:         conf,
+		opts:      opts,
+		client:    client,
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		l:         l,
+		batch:     make([]types.Record, 0, conf.GetMetricsBatchSize()),
+	}
+}
+
+// Write queues the incoming EventMetrics for export.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	select {
+	case s.writeChan <- em:
+	default:
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+func (s *Surfacer) processMetrics(ctx context.Context) {
+	batchTimer := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			for _, r := range s.records(em) {
+				s.batch = append(s.batch, r)
+				if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+					s.flush(ctx)
+					batchTimer.Reset(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+				}
+			}
+		case <-batchTimer.C:
+			if len(s.batch) != 0 {
+				s.flush(ctx)
+			}
+		}
+	}
+}
+
+// flush writes the batched records to Timestream.
+func (s *Surfacer) flush(ctx context.Context) {
+	start := time.Now()
+	_, err := s.client.WriteRecords(ctx, &timestreamwrite.WriteRecordsInput{
+		DatabaseName: aws.String(s.c.GetDatabase()),
+		TableName:    aws.String(s.c.GetTable()),
+		Records:      s.batch,
+	})
+	s.opts.ReportPush(start, err)
+	if err != nil {
+		var rejected *types.RejectedRecordsException
+		if errors.As(err, &rejected) {
+			for _, rr := range rejected.RejectedRecords {
+				s.l.Warningf("Timestream rejected record %d: %s", rr.RecordIndex, aws.ToString(rr.Reason))
+			}
+		}
+		s.l.Errorf("Error writing %d records to Timestream: %v", len(s.batch), err)
+	}
+
+	s.batch = s.batch[:0]
+}
+
+// dimensions returns the Timestream dimensions for the EventMetrics labels.
+func (s *Surfacer) dimensions(em *metrics.EventMetrics) []types.Dimension {
+	keys := s.c.GetDimensionLabel()
+	if len(keys) == 0 {
+		keys = em.LabelsKeys()
+	}
+
+	var dims []types.Dimension
+	for _, k := range keys {
+		v := em.Label(k)
+		// Timestream doesn't allow empty dimension values.
+		if v == "" {
+			continue
+		}
+		dims = append(dims, types.Dimension{
+			Name:  aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return dims
+}
+
+func measureValue(name string, valueType types.MeasureValueType, value string) types.MeasureValue {
+	return types.MeasureValue{
+		Name:  aws.String(name),
+		Type:  valueType,
+		Value: aws.String(value),
+	}
+}
+
+func mapMeasureValues[T int64 | float64](m *metrics.Map[T], name string) []types.MeasureValue {
+	var out []types.MeasureValue
+	for _, k := range m.Keys() {
+		switch v := any(m.GetKey(k)).(type) {
+		case int64:
+			out = append(out, measureValue(name+"_"+k, types.MeasureValueTypeBigint, strconv.FormatInt(v, 10)))
+		case float64:
+			out = append(out, measureValue(name+"_"+k, types.MeasureValueTypeDouble, strconv.FormatFloat(v, 'f', -1, 64)))
+		}
+	}
+	return out
+}
+
+// measureValues converts EventMetrics metrics into Timestream measure values.
+func (s *Surfacer) measureValues(em *metrics.EventMetrics) []types.MeasureValue {
+	var out []types.MeasureValue
+
+	for _, metricKey := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(metricKey) {
+			continue
+		}
+
+		switch v := em.Metric(metricKey).(type) {
+		case *metrics.Float:
+			out = append(out, measureValue(metricKey, types.MeasureValueTypeDouble, strconv.FormatFloat(v.Float64(), 'f', -1, 64)))
+		case metrics.NumValue:
+			out = append(out, measureValue(metricKey, types.MeasureValueTypeBigint, strconv.FormatInt(v.Int64(), 10)))
+		case *metrics.Map[int64]:
+			out = append(out, mapMeasureValues(v, metricKey)...)
+		case *metrics.Map[float64]:
+			out = append(out, mapMeasureValues(v, metricKey)...)
+		case *metrics.Distribution:
+			d := v.Data()
+			out = append(out, measureValue(metricKey+"_sum", types.MeasureValueTypeDouble, strconv.FormatFloat(d.Sum, 'f', -1, 64)))
+			out = append(out, measureValue(metricKey+"_count", types.MeasureValueTypeBigint, strconv.FormatInt(d.Count, 10)))
+			var cumCount int64
+			for i := range d.LowerBounds {
+				cumCount += d.BucketCounts[i]
+				le := "inf"
+				if i < len(d.LowerBounds)-1 {
+					le = strconv.FormatFloat(d.LowerBounds[i+1], 'f', -1, 64)
+				}
+				out = append(out, measureValue(metricKey+"_le_"+le, types.MeasureValueTypeBigint, strconv.FormatInt(cumCount, 10)))
+			}
+		case metrics.String:
+			// String() returns a quoted string.
+			val := strings.TrimSuffix(strings.TrimPrefix(v.String(), "\""), "\"")
+			out = append(out, measureValue(metricKey, types.MeasureValueTypeVarchar, val))
+		}
+	}
+
+	return out
+}
+
+// records converts an EventMetrics into multi-measure records. If there are
+// more measures than a record can hold, they are split across records.
+func (s *Surfacer) records(em *metrics.EventMetrics) []types.Record {
+	mvs := s.measureValues(em)
+	dims := s.dimensions(em)
+	ts := strconv.FormatInt(em.Timestamp.UnixMilli(), 10)
+
+	var out []types.Record
+	for len(mvs) > 0 {
+		n := min(len(mvs), maxMeasuresPerRecord)
+		out = append(out, types.Record{
+			Dimensions:       dims,
+			MeasureName:      aws.String(s.c.GetMeasureName()),
+			MeasureValueType: types.MeasureValueTypeMulti,
+			MeasureValues:    mvs[:n],
+			Time:             aws.String(ts),
+			TimeUnit:         types.TimeUnitMilliseconds,
+		})
+		mvs = mvs[n:]
+	}
+	return out
+}
+
+// getRegion provides an order of precedence for the lookup of the AWS Region:
+// config, EC2 metadata (through sysvars), and finally, by returning an empty
+// string, the AWS_REGION environment variable as evaluated by the AWS SDK.
+func getRegion(conf *configpb.SurfacerConf) string {
+	if conf.Region != nil {
+		return conf.GetRegion()
+	}
+
+	if v, exists := sysvars.Vars()["EC2_Region"]; exists {
+		return v
+	}
+
+	return ""
+}
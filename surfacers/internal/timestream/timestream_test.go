@@ -0,0 +1,168 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestream
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite"
+	"github.com/aws/aws-sdk-go-v2/service/timestreamwrite/types"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/timestream/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type testClient struct {
+	mu     sync.Mutex
+	inputs []*timestreamwrite.WriteRecordsInput
+}
+
+func (tc *testClient) WriteRecords(ctx context.Context, params *timestreamwrite.WriteRecordsInput, optFns ...func(*timestreamwrite.Options)) (*timestreamwrite.WriteRecordsOutput, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	params.Records = append([]types.Record{}, params.Records...)
+	tc.inputs = append(tc.inputs, params)
+	return &timestreamwrite.WriteRecordsOutput{}, nil
+}
+
+func (tc *testClient) writes() []*timestreamwrite.WriteRecordsInput {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return append([]*timestreamwrite.WriteRecordsInput{}, tc.inputs...)
+}
+
+func dim(k, v string) types.Dimension {
+	return types.Dimension{Name: aws.String(k), Value: aws.String(v)}
+}
+
+func TestRecords(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	d := metrics.NewDistribution([]float64{1})
+	d.AddSample(2)
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(10)).
+		AddMetric("latency", metrics.NewFloat(12.5)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", 4)).
+		AddMetric("dist", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1").
+		AddLabel("dst", "")
+
+	tests := []struct {
+		name     string
+		dimLabel []string
+		wantDims []types.Dimension
+	}{
+		{
+			name:     "all_labels",
+			wantDims: []types.Dimension{dim("ptype", "http"), dim("probe", "p1")},
+		},
+		{
+			name:     "dimension_label",
+			dimLabel: []string{"probe", "dst"},
+			wantDims: []types.Dimension{dim("probe", "p1")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newSurfacer(&configpb.SurfacerConf{DimensionLabel: test.dimLabel}, &options.Options{}, &testClient{}, nil)
+
+			want := []types.Record{
+				{
+					Dimensions:       test.wantDims,
+					MeasureName:      aws.String("cloudprober"),
+					MeasureValueType: types.MeasureValueTypeMulti,
+					MeasureValues: []types.MeasureValue{
+						measureValue("total", types.MeasureValueTypeBigint, "10"),
+						measureValue("latency", types.MeasureValueTypeDouble, "12.5"),
+						measureValue("resp-code_200", types.MeasureValueTypeBigint, "4"),
+						measureValue("dist_sum", types.MeasureValueTypeDouble, "2"),
+						measureValue("dist_count", types.MeasureValueTypeBigint, "1"),
+						measureValue("dist_le_1", types.MeasureValueTypeBigint, "0"),
+						measureValue("dist_le_inf", types.MeasureValueTypeBigint, "1"),
+						measureValue("version", types.MeasureValueTypeVarchar, "v1"),
+					},
+					Time:     aws.String("1700000000000"),
+					TimeUnit: types.TimeUnitMilliseconds,
+				},
+			}
+			assert.Equal(t, want, s.records(em))
+		})
+	}
+}
+
+func TestRecordsSplit(t *testing.T) {
+	em := metrics.NewEventMetrics(time.Unix(1700000000, 0)).AddLabel("probe", "p1")
+	for i := 0; i < maxMeasuresPerRecord+10; i++ {
+		em.AddMetric("m"+strconv.Itoa(i), metrics.NewInt(int64(i)))
+	}
+
+	s := newSurfacer(&configpb.SurfacerConf{}, &options.Options{}, &testClient{}, nil)
+	records := s.records(em)
+
+	assert.Len(t, records, 2)
+	assert.Len(t, records[0].MeasureValues, maxMeasuresPerRecord)
+	assert.Len(t, records[1].MeasureValues, 10)
+	assert.Equal(t, records[0].Dimensions, records[1].Dimensions)
+}
+
+func TestSurfacer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tc := &testClient{}
+	s := newSurfacer(&configpb.SurfacerConf{
+		Database:         proto.String("db"),
+		Table:            proto.String("probes"),
+		MetricsBatchSize: proto.Int32(2),
+		BatchTimerSec:    proto.Int32(3600),
+	}, &options.Options{MetricsBufferSize: 10}, tc, &logger.Logger{})
+	go s.processMetrics(ctx)
+
+	for i := 0; i < 3; i++ {
+		s.Write(ctx, metrics.NewEventMetrics(time.Unix(1700000000+int64(i), 0)).
+			AddMetric("total", metrics.NewInt(int64(i))).
+			AddLabel("probe", "p1"))
+	}
+
+	// Only the first 2 records are written, third is waiting for the timer.
+	assert.Eventually(t, func() bool { return len(tc.writes()) == 1 }, time.Second, 10*time.Millisecond)
+	w := tc.writes()[0]
+	assert.Equal(t, "db", aws.ToString(w.DatabaseName))
+	assert.Equal(t, "probes", aws.ToString(w.TableName))
+	assert.Len(t, w.Records, 2)
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, conf := range []*configpb.SurfacerConf{
+		{},
+		{Database: proto.String("db")},
+		{Database: proto.String("db"), Table: proto.String("t"), MetricsBatchSize: proto.Int32(101)},
+	} {
+		_, err := New(context.Background(), conf, &options.Options{}, nil)
+		assert.Error(t, err, "conf: %v", conf)
+	}
+}
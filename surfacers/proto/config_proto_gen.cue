@@ -11,6 +11,17 @@ import (
 	proto_36 "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
 	proto_9 "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
 	proto_3 "github.com/cloudprober/cloudprober/surfacers/internal/otel/proto"
+	proto_D "github.com/cloudprober/cloudprober/surfacers/internal/promremotewrite/proto"
+	proto_I "github.com/cloudprober/cloudprober/surfacers/internal/influxdb/proto"
+	proto_K "github.com/cloudprober/cloudprober/surfacers/internal/kafka/proto"
+	proto_G "github.com/cloudprober/cloudprober/surfacers/internal/graphite/proto"
+	proto_T "github.com/cloudprober/cloudprober/surfacers/internal/timestream/proto"
+	proto_L "github.com/cloudprober/cloudprober/surfacers/internal/loki/proto"
+	proto_H "github.com/cloudprober/cloudprober/surfacers/internal/honeycomb/proto"
+	proto_S "github.com/cloudprober/cloudprober/surfacers/internal/sqlite/proto"
+	proto_GP "github.com/cloudprober/cloudprober/surfacers/internal/grpcplugin/proto"
+	proto_N "github.com/cloudprober/cloudprober/surfacers/internal/newrelic/proto"
+	proto_M "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
 )
 
 // Enumeration for each type of surfacer we can parse and create
@@ -29,26 +40,88 @@ import (
 		"BIGQUERY"// Experimental mode.
 					#enumValue: 9
 	} | {"OTEL", #enumValue: 10} |
+	{"PROMETHEUS_REMOTE_WRITE", #enumValue: 11} |
+	{"INFLUXDB", #enumValue: 12} |
+	{"KAFKA", #enumValue: 13} |
+	{"GRAPHITE", #enumValue: 14} |
+	{"TIMESTREAM", #enumValue: 15} |
+	{"LOKI", #enumValue: 16} |
+	{"HONEYCOMB", #enumValue: 17} |
+	{"SQLITE", #enumValue: 18} |
+	{"GRPC_PLUGIN", #enumValue: 19} |
+	{"SHADOW", #enumValue: 20} |
+	{"NEWRELIC", #enumValue: 21} |
+	{"MQTT", #enumValue: 22} |
 	{"USER_DEFINED", #enumValue: 99}
 
 #Type_value: {
-	NONE:         0
-	PROMETHEUS:   1
-	STACKDRIVER:  2
-	FILE:         3
-	POSTGRES:     4
-	PUBSUB:       5
-	CLOUDWATCH:   6
-	DATADOG:      7
-	PROBESTATUS:  8
-	BIGQUERY:     9
-	OTEL:         10
-	USER_DEFINED: 99
+	NONE:                    0
+	PROMETHEUS:              1
+	STACKDRIVER:             2
+	FILE:                    3
+	POSTGRES:                4
+	PUBSUB:                  5
+	CLOUDWATCH:              6
+	DATADOG:                 7
+	PROBESTATUS:             8
+	BIGQUERY:                9
+	OTEL:                    10
+	PROMETHEUS_REMOTE_WRITE: 11
+	INFLUXDB:                12
+	KAFKA:                   13
+	GRAPHITE:                14
+	TIMESTREAM:              15
+	LOKI:                    16
+	HONEYCOMB:               17
+	SQLITE:                  18
+	GRPC_PLUGIN:             19
+	SHADOW:                  20
+	NEWRELIC:                21
+	MQTT:                    22
+	USER_DEFINED:            99
 }
 
 #LabelFilter: {
 	key?:   string @protobuf(1,string)
 	value?: string @protobuf(2,string)
+
+	// Regex to match the label value against. Regex must match the whole
+	// label value, e.g. "web-.*" matches "web-1" but not "app-web-1". Only one
+	// of value and value_regex can be specified.
+	// Example, to match all probes with the name starting with "web-":
+	// allow_metrics_with_label {
+	//   key: "probe",
+	//   value_regex: "web-.*",
+	// }
+	valueRegex?: string @protobuf(3,string,name=value_regex)
+}
+
+// DiskBuffer configures an on-disk buffer for the data that a surfacer fails
+// to send to its backend.
+#DiskBuffer: {
+	// Directory to store the buffered data in. Each surfacer should use its own
+	// directory. Data in this directory survives cloudprober restarts.
+	dir?: string @protobuf(1,string)
+
+	// Maximum size of the buffer. Once the buffer is full, oldest data is
+	// dropped.
+	maxSizeMb?: int32 @protobuf(2,int32,name=max_size_mb,"default=100")
+}
+
+// ShadowSurfacerConf configures a SHADOW surfacer. SHADOW surfacer writes
+// metrics to two surfacers, primary and shadow, and periodically exports
+// statistics that compare them (to both of them), e.g.:
+//   shadow_surfacer_writes{backend="shadow",surfacer="new-backend"}
+// This is useful to de-risk migrations from one metrics backend to another.
+#ShadowSurfacerConf: {
+	// Surfacer currently in use.
+	primary?: #SurfacerDef @protobuf(1,SurfacerDef)
+
+	// Surfacer being evaluated. It gets the same data as the primary surfacer.
+	shadow?: #SurfacerDef @protobuf(2,SurfacerDef)
+
+	// How often to export the comparison metrics.
+	statsExportIntervalSec?: int32 @protobuf(3,int32,name=stats_export_interval_sec,"default=60")
 }
 
 #SurfacerDef: {
@@ -109,6 +182,28 @@ import (
 	// However, it should not be noticeable unless you're producing large number
 	// of metrics (say > 10000 metrics per second).
 	exportAsGauge?: bool @protobuf(9,bool,name=export_as_gauge)
+
+	// If set, cloudprober aggregates EventMetrics over this interval and
+	// exports only one EventMetrics per time series per interval. This is
+	// useful if a backend doesn't need (or charges for) the probes' resolution,
+	// e.g. for 2s probes feeding a backend that only wants 60s resolution.
+	// Aggregation doesn't double count:
+	//  - CUMULATIVE metrics: latest values are exported, as they already
+	//    include all the increments (i.e. counters are summed over the interval).
+	//  - GAUGE metrics: distributions are merged, other values use the last
+	//    value in the interval.
+	// Aggregation happens before export_as_gauge conversion, so gauge values
+	// cover the complete interval.
+	aggregationIntervalSec?: int32 @protobuf(28,int32,name=aggregation_interval_sec)
+
+	// If configured, data that the surfacer fails to send (e.g. because the
+	// backend is unreachable) is buffered on disk and re-sent once the backend
+	// is reachable again, instead of being dropped. Only data that failed
+	// because of transient errors is buffered.
+	//
+	// This option is currently supported only by the STACKDRIVER, CLOUDWATCH
+	// and PUBSUB surfacers.
+	diskBuffer?: #DiskBuffer @protobuf(29,DiskBuffer,name=disk_buffer)
 	// Matching surfacer specific configuration (one for each type in the above
 	// enum)
 	{} | {
@@ -131,5 +226,29 @@ import (
 		bigquerySurfacer: proto_9.#SurfacerConf @protobuf(18,bigquery.SurfacerConf,name=bigquery_surfacer)
 	} | {
 		otelSurfacer: proto_3.#SurfacerConf @protobuf(19,otel.SurfacerConf,name=otel_surfacer)
+	} | {
+		prometheusRemoteWriteSurfacer: proto_D.#SurfacerConf @protobuf(20,promremotewrite.SurfacerConf,name=prometheus_remote_write_surfacer)
+	} | {
+		influxdbSurfacer: proto_I.#SurfacerConf @protobuf(21,influxdb.SurfacerConf,name=influxdb_surfacer)
+	} | {
+		kafkaSurfacer: proto_K.#SurfacerConf @protobuf(22,kafka.SurfacerConf,name=kafka_surfacer)
+	} | {
+		graphiteSurfacer: proto_G.#SurfacerConf @protobuf(23,graphite.SurfacerConf,name=graphite_surfacer)
+	} | {
+		timestreamSurfacer: proto_T.#SurfacerConf @protobuf(24,timestream.SurfacerConf,name=timestream_surfacer)
+	} | {
+		lokiSurfacer: proto_L.#SurfacerConf @protobuf(25,loki.SurfacerConf,name=loki_surfacer)
+	} | {
+		honeycombSurfacer: proto_H.#SurfacerConf @protobuf(26,honeycomb.SurfacerConf,name=honeycomb_surfacer)
+	} | {
+		sqliteSurfacer: proto_S.#SurfacerConf @protobuf(27,sqlite.SurfacerConf,name=sqlite_surfacer)
+	} | {
+		grpcPluginSurfacer: proto_GP.#SurfacerConf @protobuf(30,grpcplugin.SurfacerConf,name=grpc_plugin_surfacer)
+	} | {
+		shadowSurfacer: #ShadowSurfacerConf @protobuf(31,ShadowSurfacerConf,name=shadow_surfacer)
+	} | {
+		newrelicSurfacer: proto_N.#SurfacerConf @protobuf(32,newrelic.SurfacerConf,name=newrelic_surfacer)
+	} | {
+		mqttSurfacer: proto_M.#SurfacerConf @protobuf(33,mqtt.SurfacerConf,name=mqtt_surfacer)
 	}
 }
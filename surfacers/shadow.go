@@ -0,0 +1,187 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfacers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+)
+
+// shadowBackend tracks write statistics for one of the SHADOW surfacer's
+// backends.
+type shadowBackend struct {
+	role string // "primary" or "shadow"
+	name string
+	sw   *surfacerWrapper
+
+	mu           sync.Mutex
+	writes       int64
+	pushes       int64
+	pushFailures int64
+	pushLatency  *metrics.Distribution // In microseconds
+}
+
+// shadowSurfacer writes EventMetrics to two surfacers, and exports statistics
+// that compare them.
+type shadowSurfacer struct {
+	primary, shadow *shadowBackend
+	l               *logger.Logger
+}
+
+func newShadowBackend(ctx context.Context, role string, def *surfacerpb.SurfacerDef) (*shadowBackend, error) {
+	if def == nil {
+		return nil, fmt.Errorf("shadow surfacer: %s surfacer is not configured", role)
+	}
+
+	sType := def.GetType()
+	if sType == surfacerpb.Type_NONE {
+		sType = inferType(def)
+	}
+	if sType == surfacerpb.Type_SHADOW {
+		return nil, errors.New("shadow surfacer: SHADOW surfacers can't be nested")
+	}
+
+	b := &shadowBackend{
+		role: role,
+		name: def.GetName(),
+	}
+	if b.name == "" {
+		b.name = strings.ToLower(sType.String())
+	}
+	b.pushLatency, _ = metrics.NewExponentialDistribution(2, 10, 20)
+
+	s, _, err := initSurfacer(ctx, def, sType, b.recordPush)
+	if err != nil {
+		return nil, fmt.Errorf("shadow surfacer: error initializing %s surfacer: %v", role, err)
+	}
+	b.sw = s.(*surfacerWrapper)
+
+	return b, nil
+}
+
+func newShadowSurfacer(ctx context.Context, c *surfacerpb.ShadowSurfacerConf, l *logger.Logger) (*shadowSurfacer, error) {
+	if c.GetStatsExportIntervalSec() <= 0 {
+		return nil, fmt.Errorf("shadow surfacer: invalid stats_export_interval_sec: %d", c.GetStatsExportIntervalSec())
+	}
+
+	primary, err := newShadowBackend(ctx, "primary", c.GetPrimary())
+	if err != nil {
+		return nil, err
+	}
+	shadow, err := newShadowBackend(ctx, "shadow", c.GetShadow())
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &shadowSurfacer{
+		primary: primary,
+		shadow:  shadow,
+		l:       l,
+	}
+	go ss.exportStats(ctx, time.Duration(c.GetStatsExportIntervalSec())*time.Second)
+
+	return ss, nil
+}
+
+// recordPush records the result of a push to the backend. It's called by the
+// push surfacers, see options.Options.PushResult.
+func (b *shadowBackend) recordPush(latency time.Duration, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pushes++
+	if err != nil {
+		b.pushFailures++
+	}
+	b.pushLatency.AddFloat64(float64(latency.Microseconds()))
+}
+
+func (b *shadowBackend) write(ctx context.Context, em *metrics.EventMetrics) {
+	if b.sw.opts.AllowEventMetrics(em) {
+		b.mu.Lock()
+		b.writes++
+		b.mu.Unlock()
+	}
+	b.sw.Write(ctx, em)
+}
+
+// Write writes the EventMetrics to the primary surfacer first, and then to
+// the shadow surfacer, so that shadow surfacer doesn't slow down the primary
+// one. Surfacers send the data to their backends asynchronously; write
+// failures and latency are recorded when they push the data, see recordPush.
+func (ss *shadowSurfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	ss.primary.write(ctx, em)
+	ss.shadow.write(ctx, em)
+}
+
+func (b *shadowBackend) stats(ts time.Time) (*metrics.EventMetrics, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("shadow_surfacer_writes", metrics.NewInt(b.writes)).
+		AddMetric("shadow_surfacer_pushes", metrics.NewInt(b.pushes)).
+		AddMetric("shadow_surfacer_push_failures", metrics.NewInt(b.pushFailures)).
+		AddMetric("shadow_surfacer_push_latency", b.pushLatency.Clone()).
+		AddLabel("ptype", "shadow_surfacer").
+		AddLabel("backend", b.role).
+		AddLabel("surfacer", b.name)
+	em.LatencyUnit = time.Microsecond
+	return em, b.writes
+}
+
+// statsEventMetrics returns the comparison metrics: one EventMetrics per
+// backend, and one with the difference between the primary and shadow
+// surfacers' accepted writes, e.g. due to different metrics filters.
+func (ss *shadowSurfacer) statsEventMetrics(ts time.Time) []*metrics.EventMetrics {
+	primaryEM, primaryWrites := ss.primary.stats(ts)
+	shadowEM, shadowWrites := ss.shadow.stats(ts)
+
+	divergenceEM := metrics.NewEventMetrics(ts).
+		AddMetric("shadow_surfacer_writes_divergence", metrics.NewInt(primaryWrites-shadowWrites)).
+		AddLabel("ptype", "shadow_surfacer").
+		AddLabel("primary", ss.primary.name).
+		AddLabel("shadow", ss.shadow.name)
+	divergenceEM.Kind = metrics.GAUGE
+
+	return []*metrics.EventMetrics{primaryEM, shadowEM, divergenceEM}
+}
+
+// exportStats exports the comparison metrics to both the surfacers at the
+// given interval.
+func (ss *shadowSurfacer) exportStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ts := <-ticker.C:
+			for _, em := range ss.statsEventMetrics(ts) {
+				ss.primary.sw.Write(ctx, em)
+				ss.shadow.sw.Write(ctx, em)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfacers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/config/runconfig"
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestShadowSurfacer(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ts1, ts2 := &testSurfacer{}, &testSurfacer{}
+	Register("shadow-test-primary", ts1)
+	Register("shadow-test-shadow", ts2)
+
+	s, _, err := initSurfacer(context.Background(), &surfacerpb.SurfacerDef{
+		Surfacer: &surfacerpb.SurfacerDef_ShadowSurfacer{
+			ShadowSurfacer: &surfacerpb.ShadowSurfacerConf{
+				Primary: &surfacerpb.SurfacerDef{
+					Name: proto.String("shadow-test-primary"),
+					Type: surfacerpb.Type_USER_DEFINED.Enum(),
+				},
+				Shadow: &surfacerpb.SurfacerDef{
+					Name: proto.String("shadow-test-shadow"),
+					Type: surfacerpb.Type_USER_DEFINED.Enum(),
+					IgnoreMetricsWithLabel: []*surfacerpb.LabelFilter{
+						{Key: proto.String("probe"), Value: proto.String("p2")},
+					},
+				},
+			},
+		},
+	}, surfacerpb.Type_SHADOW, nil)
+	if err != nil {
+		t.Fatalf("Error initializing shadow surfacer: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		s.Write(context.Background(), metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(int64(i))).
+			AddLabel("probe", fmt.Sprintf("p%d", i%2+1)))
+	}
+	assert.Len(t, ts1.received, 4)
+	assert.Len(t, ts2.received, 2)
+
+	// Simulate backend pushes, as reported by the push surfacers.
+	ss := s.(*surfacerWrapper).Surfacer.(*shadowSurfacer)
+	ss.primary.sw.opts.ReportPush(time.Now(), nil)
+	ss.shadow.sw.opts.ReportPush(time.Now(), nil)
+	ss.shadow.sw.opts.ReportPush(time.Now(), errors.New("backend error"))
+
+	ems := ss.statsEventMetrics(time.Now())
+	assert.Len(t, ems, 3)
+
+	for i, want := range []struct {
+		backend, name                string
+		writes, pushes, pushFailures int64
+	}{
+		{"primary", "shadow-test-primary", 4, 1, 0},
+		{"shadow", "shadow-test-shadow", 2, 2, 1},
+	} {
+		em := ems[i]
+		assert.Equal(t, want.backend, em.Label("backend"))
+		assert.Equal(t, want.name, em.Label("surfacer"))
+		assert.Equal(t, want.writes, em.Metric("shadow_surfacer_writes").(metrics.NumValue).Int64())
+		assert.Equal(t, want.pushes, em.Metric("shadow_surfacer_pushes").(metrics.NumValue).Int64())
+		assert.Equal(t, want.pushFailures, em.Metric("shadow_surfacer_push_failures").(metrics.NumValue).Int64())
+		assert.Equal(t, want.pushes, em.Metric("shadow_surfacer_push_latency").(*metrics.Distribution).Data().Count)
+	}
+	assert.Equal(t, int64(2), ems[2].Metric("shadow_surfacer_writes_divergence").(metrics.NumValue).Int64())
+}
+
+func TestShadowSurfacerErrors(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	userDef := &surfacerpb.SurfacerDef{
+		Name: proto.String("shadow-test-primary"),
+		Type: surfacerpb.Type_USER_DEFINED.Enum(),
+	}
+	Register("shadow-test-primary", &testSurfacer{})
+
+	for name, conf := range map[string]*surfacerpb.ShadowSurfacerConf{
+		"no_shadow": {Primary: userDef},
+		"nested": {
+			Primary: userDef,
+			Shadow: &surfacerpb.SurfacerDef{
+				Surfacer: &surfacerpb.SurfacerDef_ShadowSurfacer{},
+			},
+		},
+		"bad_interval": {Primary: userDef, Shadow: userDef, StatsExportIntervalSec: proto.Int32(0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newShadowSurfacer(context.Background(), conf, nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
@@ -39,13 +40,25 @@ import (
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
 	"github.com/cloudprober/cloudprober/surfacers/internal/datadog"
 	"github.com/cloudprober/cloudprober/surfacers/internal/file"
+	"github.com/cloudprober/cloudprober/surfacers/internal/graphite"
+	"github.com/cloudprober/cloudprober/surfacers/internal/grpcplugin"
+	"github.com/cloudprober/cloudprober/surfacers/internal/honeycomb"
+	"github.com/cloudprober/cloudprober/surfacers/internal/influxdb"
+	"github.com/cloudprober/cloudprober/surfacers/internal/kafka"
+	"github.com/cloudprober/cloudprober/surfacers/internal/loki"
+	"github.com/cloudprober/cloudprober/surfacers/internal/mqtt"
+	"github.com/cloudprober/cloudprober/surfacers/internal/newrelic"
 	"github.com/cloudprober/cloudprober/surfacers/internal/otel"
 	"github.com/cloudprober/cloudprober/surfacers/internal/postgres"
 	"github.com/cloudprober/cloudprober/surfacers/internal/probestatus"
 	"github.com/cloudprober/cloudprober/surfacers/internal/prometheus"
+	"github.com/cloudprober/cloudprober/surfacers/internal/promremotewrite"
 	"github.com/cloudprober/cloudprober/surfacers/internal/pubsub"
+	"github.com/cloudprober/cloudprober/surfacers/internal/sqlite"
 	"github.com/cloudprober/cloudprober/surfacers/internal/stackdriver"
+	"github.com/cloudprober/cloudprober/surfacers/internal/timestream"
 	"github.com/cloudprober/cloudprober/web/formatutils"
+	"google.golang.org/protobuf/proto"
 
 	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
 )
@@ -110,6 +123,10 @@ type surfacerWrapper struct {
 	Surfacer
 	opts    *options.Options
 	lvCache map[string]*metrics.EventMetrics
+
+	// If aggregation is enabled, EventMetrics are written to the surfacer
+	// only when aggregation windows are flushed.
+	aggregator *transform.Aggregator
 }
 
 func (sw *surfacerWrapper) Write(ctx context.Context, em *metrics.EventMetrics) {
@@ -123,6 +140,35 @@ func (sw *surfacerWrapper) Write(ctx context.Context, em *metrics.EventMetrics)
 		}
 	}
 
+	if sw.aggregator != nil {
+		if err := sw.aggregator.Add(em); err != nil {
+			sw.opts.Logger.Warningf("Error aggregating EventMetrics: %v", err)
+		}
+		return
+	}
+
+	sw.write(ctx, em)
+}
+
+// runAggregation flushes the aggregated EventMetrics to the surfacer at every
+// aggregation interval.
+func (sw *surfacerWrapper) runAggregation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, em := range sw.aggregator.Flush() {
+				sw.write(ctx, em)
+			}
+		}
+	}
+}
+
+func (sw *surfacerWrapper) write(ctx context.Context, em *metrics.EventMetrics) {
 	if sw.opts.Config.GetExportAsGauge() && em.Kind == metrics.CUMULATIVE {
 		newEM, err := transform.CumulativeToGauge(em, sw.lvCache, sw.opts.Logger)
 		if err != nil {
@@ -141,6 +187,11 @@ type SurfacerInfo struct {
 	Type string
 	Name string
 	Conf string
+
+	// Surfacer's definition (nil for the required surfacers added
+	// automatically), and the function to stop it on config reloads.
+	def    *surfacerpb.SurfacerDef
+	cancel context.CancelFunc
 }
 
 func inferType(s *surfacerpb.SurfacerDef) surfacerpb.Type {
@@ -165,13 +216,38 @@ func inferType(s *surfacerpb.SurfacerDef) surfacerpb.Type {
 		return surfacerpb.Type_BIGQUERY
 	case *surfacerpb.SurfacerDef_OtelSurfacer:
 		return surfacerpb.Type_OTEL
+	case *surfacerpb.SurfacerDef_PrometheusRemoteWriteSurfacer:
+		return surfacerpb.Type_PROMETHEUS_REMOTE_WRITE
+	case *surfacerpb.SurfacerDef_InfluxdbSurfacer:
+		return surfacerpb.Type_INFLUXDB
+	case *surfacerpb.SurfacerDef_KafkaSurfacer:
+		return surfacerpb.Type_KAFKA
+	case *surfacerpb.SurfacerDef_GraphiteSurfacer:
+		return surfacerpb.Type_GRAPHITE
+	case *surfacerpb.SurfacerDef_TimestreamSurfacer:
+		return surfacerpb.Type_TIMESTREAM
+	case *surfacerpb.SurfacerDef_LokiSurfacer:
+		return surfacerpb.Type_LOKI
+	case *surfacerpb.SurfacerDef_HoneycombSurfacer:
+		return surfacerpb.Type_HONEYCOMB
+	case *surfacerpb.SurfacerDef_SqliteSurfacer:
+		return surfacerpb.Type_SQLITE
+	case *surfacerpb.SurfacerDef_GrpcPluginSurfacer:
+		return surfacerpb.Type_GRPC_PLUGIN
+	case *surfacerpb.SurfacerDef_ShadowSurfacer:
+		return surfacerpb.Type_SHADOW
+	case *surfacerpb.SurfacerDef_NewrelicSurfacer:
+		return surfacerpb.Type_NEWRELIC
+	case *surfacerpb.SurfacerDef_MqttSurfacer:
+		return surfacerpb.Type_MQTT
 	}
 
 	return surfacerpb.Type_NONE
 }
 
 // initSurfacer initializes and returns a new surfacer based on the config.
-func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacerpb.Type) (Surfacer, interface{}, error) {
+// If pushResult is not nil, push surfacers report their pushes to it.
+func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacerpb.Type, pushResult func(time.Duration, error)) (Surfacer, interface{}, error) {
 	// Create a new logger
 	logName := s.GetName()
 	if logName == "" {
@@ -184,6 +260,7 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 	if err != nil {
 		return nil, nil, err
 	}
+	opts.PushResult = pushResult
 
 	var conf interface{}
 	var surfacer Surfacer
@@ -199,7 +276,7 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 		surfacer, err = file.New(ctx, s.GetFileSurfacer(), opts, l)
 		conf = s.GetFileSurfacer()
 	case surfacerpb.Type_POSTGRES:
-		surfacer, err = postgres.New(ctx, s.GetPostgresSurfacer(), l)
+		surfacer, err = postgres.New(ctx, s.GetPostgresSurfacer(), opts, l)
 		conf = s.GetPostgresSurfacer()
 	case surfacerpb.Type_PUBSUB:
 		surfacer, err = pubsub.New(ctx, s.GetPubsubSurfacer(), opts, l)
@@ -219,6 +296,42 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 	case surfacerpb.Type_OTEL:
 		surfacer, err = otel.New(ctx, s.GetOtelSurfacer(), opts, l)
 		conf = s.GetOtelSurfacer()
+	case surfacerpb.Type_PROMETHEUS_REMOTE_WRITE:
+		surfacer, err = promremotewrite.New(ctx, s.GetPrometheusRemoteWriteSurfacer(), opts, l)
+		conf = s.GetPrometheusRemoteWriteSurfacer()
+	case surfacerpb.Type_INFLUXDB:
+		surfacer, err = influxdb.New(ctx, s.GetInfluxdbSurfacer(), opts, l)
+		conf = s.GetInfluxdbSurfacer()
+	case surfacerpb.Type_KAFKA:
+		surfacer, err = kafka.New(ctx, s.GetKafkaSurfacer(), opts, l)
+		conf = s.GetKafkaSurfacer()
+	case surfacerpb.Type_GRAPHITE:
+		surfacer, err = graphite.New(ctx, s.GetGraphiteSurfacer(), opts, l)
+		conf = s.GetGraphiteSurfacer()
+	case surfacerpb.Type_TIMESTREAM:
+		surfacer, err = timestream.New(ctx, s.GetTimestreamSurfacer(), opts, l)
+		conf = s.GetTimestreamSurfacer()
+	case surfacerpb.Type_LOKI:
+		surfacer, err = loki.New(ctx, s.GetLokiSurfacer(), opts, l)
+		conf = s.GetLokiSurfacer()
+	case surfacerpb.Type_HONEYCOMB:
+		surfacer, err = honeycomb.New(ctx, s.GetHoneycombSurfacer(), opts, l)
+		conf = s.GetHoneycombSurfacer()
+	case surfacerpb.Type_SQLITE:
+		surfacer, err = sqlite.New(ctx, s.GetSqliteSurfacer(), opts, l)
+		conf = s.GetSqliteSurfacer()
+	case surfacerpb.Type_GRPC_PLUGIN:
+		surfacer, err = grpcplugin.New(ctx, s.GetGrpcPluginSurfacer(), opts, l)
+		conf = s.GetGrpcPluginSurfacer()
+	case surfacerpb.Type_SHADOW:
+		surfacer, err = newShadowSurfacer(ctx, s.GetShadowSurfacer(), l)
+		conf = s.GetShadowSurfacer()
+	case surfacerpb.Type_NEWRELIC:
+		surfacer, err = newrelic.New(ctx, s.GetNewrelicSurfacer(), opts, l)
+		conf = s.GetNewrelicSurfacer()
+	case surfacerpb.Type_MQTT:
+		surfacer, err = mqtt.New(ctx, s.GetMqttSurfacer(), opts, l)
+		conf = s.GetMqttSurfacer()
 	case surfacerpb.Type_USER_DEFINED:
 		userDefinedSurfacersMu.Lock()
 		defer userDefinedSurfacersMu.Unlock()
@@ -230,25 +343,35 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 		return nil, nil, fmt.Errorf("unknown surfacer type: %s", s.GetType())
 	}
 
-	return &surfacerWrapper{
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sw := &surfacerWrapper{
 		Surfacer: surfacer,
 		opts:     opts,
 		lvCache:  make(map[string]*metrics.EventMetrics),
-	}, conf, err
+	}
+
+	if s.GetAggregationIntervalSec() > 0 {
+		sw.aggregator = transform.NewAggregator()
+		go sw.runAggregation(ctx, time.Duration(s.GetAggregationIntervalSec())*time.Second)
+	}
+
+	return sw, conf, nil
 }
 
-// Init initializes the surfacers from the config protobufs and returns them as
-// a list.
-func Init(ctx context.Context, sDefs []*surfacerpb.SurfacerDef) ([]*SurfacerInfo, error) {
+// effectiveDefs returns the surfacer definitions to use, along with their
+// types, taking care of the default and the disabled surfacers.
+func effectiveDefs(sDefs []*surfacerpb.SurfacerDef) ([]*surfacerpb.SurfacerDef, []surfacerpb.Type) {
 	// If no surfacers are defined, return default surfacers. This behavior
 	// can be disabled by explicitly specifying "surfacer {}" in the config.
 	if len(sDefs) == 0 {
 		sDefs = defaultSurfacers
 	}
 
-	foundSurfacers := make(map[surfacerpb.Type]bool)
-
-	var result []*SurfacerInfo
+	var defs []*surfacerpb.SurfacerDef
+	var types []surfacerpb.Type
 	for _, sDef := range sDefs {
 		sType := sDef.GetType()
 
@@ -262,32 +385,130 @@ func Init(ctx context.Context, sDefs []*surfacerpb.SurfacerDef) ([]*SurfacerInfo
 			}
 			sType = inferType(sDef)
 		}
+		defs, types = append(defs, sDef), append(types, sType)
+	}
+	return defs, types
+}
+
+// newSurfacerInfo initializes a surfacer with its own context, so that it can
+// be stopped independently of the other surfacers.
+func newSurfacerInfo(ctx context.Context, sDef *surfacerpb.SurfacerDef, sType surfacerpb.Type) (*SurfacerInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s, conf, err := initSurfacer(ctx, sDef, sType, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &SurfacerInfo{
+		Surfacer: s,
+		Type:     sType.String(),
+		Name:     sDef.GetName(),
+		Conf:     formatutils.ConfToString(conf),
+		def:      sDef,
+		cancel:   cancel,
+	}, nil
+}
 
-		s, conf, err := initSurfacer(ctx, sDef, sType)
+// Init initializes the surfacers from the config protobufs and returns them as
+// a list.
+func Init(ctx context.Context, sDefs []*surfacerpb.SurfacerDef) ([]*SurfacerInfo, error) {
+	foundSurfacers := make(map[surfacerpb.Type]bool)
+
+	var result []*SurfacerInfo
+	defs, types := effectiveDefs(sDefs)
+	for i, sDef := range defs {
+		si, err := newSurfacerInfo(ctx, sDef, types[i])
 		if err != nil {
 			return nil, err
 		}
-
-		foundSurfacers[sType] = true
-
-		result = append(result, &SurfacerInfo{
-			Surfacer: s,
-			Type:     sType.String(),
-			Name:     sDef.GetName(),
-			Conf:     formatutils.ConfToString(conf),
-		})
+		foundSurfacers[types[i]] = true
+		result = append(result, si)
 	}
 
 	for _, s := range requiredSurfacers {
 		if !foundSurfacers[s.GetType()] {
-			surfacer, _, err := initSurfacer(ctx, s, s.GetType())
+			si, err := newSurfacerInfo(ctx, s, s.GetType())
 			if err != nil {
 				return nil, err
 			}
-			result = append(result, &SurfacerInfo{
-				Surfacer: surfacer,
-				Type:     s.GetType().String(),
-			})
+			si.Conf, si.def = "", nil
+			result = append(result, si)
+		}
+	}
+	return result, nil
+}
+
+// registersHTTPHandlers tells whether the surfacer registers handlers with
+// the default HTTP server. Such surfacers cannot be added or removed without
+// a restart, as HTTP handlers cannot be unregistered.
+func registersHTTPHandlers(sDef *surfacerpb.SurfacerDef, sType surfacerpb.Type) bool {
+	switch sType {
+	case surfacerpb.Type_PROMETHEUS, surfacerpb.Type_PROBESTATUS:
+		return true
+	case surfacerpb.Type_SQLITE:
+		return sDef.GetSqliteSurfacer().GetReadApiUrl() != ""
+	}
+	return false
+}
+
+// Reload applies the new surfacer definitions to the current surfacers: it
+// keeps the surfacers whose definitions haven't changed, initializes the new
+// ones and stops the removed ones. Changed surfacers are re-initialized. On
+// error, current surfacers are left untouched.
+func Reload(ctx context.Context, current []*SurfacerInfo, sDefs []*surfacerpb.SurfacerDef) ([]*SurfacerInfo, error) {
+	defs, types := effectiveDefs(sDefs)
+
+	kept := make(map[*SurfacerInfo]bool)
+	existing := make([]*SurfacerInfo, len(defs))
+	for i, sDef := range defs {
+		for _, si := range current {
+			if si.def != nil && !kept[si] && proto.Equal(si.def, sDef) {
+				existing[i], kept[si] = si, true
+				break
+			}
+		}
+		if existing[i] == nil && registersHTTPHandlers(sDef, types[i]) {
+			return nil, fmt.Errorf("adding or changing %s surfacer requires a restart", types[i])
+		}
+	}
+
+	// Required surfacers, added automatically at the init time, are kept as
+	// it is.
+	for _, si := range current {
+		if si.def == nil {
+			kept[si] = true
+		}
+	}
+	for _, si := range current {
+		if !kept[si] && registersHTTPHandlers(si.def, surfacerpb.Type(surfacerpb.Type_value[si.Type])) {
+			return nil, fmt.Errorf("removing or changing %s surfacer requires a restart", si.Type)
+		}
+	}
+
+	var result, added []*SurfacerInfo
+	for i, sDef := range defs {
+		si := existing[i]
+		if si == nil {
+			var err error
+			if si, err = newSurfacerInfo(ctx, sDef, types[i]); err != nil {
+				for _, a := range added {
+					a.cancel()
+				}
+				return nil, err
+			}
+			added = append(added, si)
+		}
+		result = append(result, si)
+	}
+	for _, si := range current {
+		if si.def == nil {
+			result = append(result, si)
+		}
+	}
+
+	for _, si := range current {
+		if !kept[si] && si.cancel != nil {
+			si.cancel()
 		}
 	}
 	return result, nil
@@ -22,6 +22,8 @@ import (
 
 	"github.com/cloudprober/cloudprober/config/runconfig"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
 	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
@@ -72,6 +74,19 @@ func TestInferType(t *testing.T) {
 		"STACKDRIVER": {Surfacer: &surfacerpb.SurfacerDef_StackdriverSurfacer{}},
 		"BIGQUERY":    {Surfacer: &surfacerpb.SurfacerDef_BigquerySurfacer{}},
 		"OTEL":        {Surfacer: &surfacerpb.SurfacerDef_OtelSurfacer{}},
+		"INFLUXDB":    {Surfacer: &surfacerpb.SurfacerDef_InfluxdbSurfacer{}},
+		"KAFKA":       {Surfacer: &surfacerpb.SurfacerDef_KafkaSurfacer{}},
+		"GRAPHITE":    {Surfacer: &surfacerpb.SurfacerDef_GraphiteSurfacer{}},
+		"TIMESTREAM":  {Surfacer: &surfacerpb.SurfacerDef_TimestreamSurfacer{}},
+		"LOKI":        {Surfacer: &surfacerpb.SurfacerDef_LokiSurfacer{}},
+		"HONEYCOMB":   {Surfacer: &surfacerpb.SurfacerDef_HoneycombSurfacer{}},
+		"SQLITE":      {Surfacer: &surfacerpb.SurfacerDef_SqliteSurfacer{}},
+		"GRPC_PLUGIN": {Surfacer: &surfacerpb.SurfacerDef_GrpcPluginSurfacer{}},
+		"SHADOW":      {Surfacer: &surfacerpb.SurfacerDef_ShadowSurfacer{}},
+		"NEWRELIC":    {Surfacer: &surfacerpb.SurfacerDef_NewrelicSurfacer{}},
+		"MQTT":        {Surfacer: &surfacerpb.SurfacerDef_MqttSurfacer{}},
+
+		"PROMETHEUS_REMOTE_WRITE": {Surfacer: &surfacerpb.SurfacerDef_PrometheusRemoteWriteSurfacer{}},
 	}
 
 	for k := range surfacerpb.Type_value {
@@ -229,3 +244,93 @@ func TestFailureMetric(t *testing.T) {
 		}
 	}
 }
+
+func TestAggregation(t *testing.T) {
+	ts := &testSurfacer{}
+	sw := &surfacerWrapper{
+		Surfacer: ts,
+		opts: options.BuildOptionsForTest(&surfacerpb.SurfacerDef{
+			ExportAsGauge:          proto.Bool(true),
+			AggregationIntervalSec: proto.Int32(60),
+		}),
+		lvCache:    make(map[string]*metrics.EventMetrics),
+		aggregator: transform.NewAggregator(),
+	}
+
+	testEM := func(total int64) *metrics.EventMetrics {
+		return metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(total)).
+			AddLabel("probe", "p1")
+	}
+	flush := func() {
+		for _, em := range sw.aggregator.Flush() {
+			sw.write(context.Background(), em)
+		}
+	}
+
+	// Two windows with 3 probe runs each.
+	for _, total := range []int64{1, 2, 3} {
+		sw.Write(context.Background(), testEM(total))
+	}
+	assert.Empty(t, ts.received)
+	flush()
+	for _, total := range []int64{4, 5, 6} {
+		sw.Write(context.Background(), testEM(total))
+	}
+	flush()
+
+	var got []int64
+	for _, em := range ts.received {
+		got = append(got, em.Metric("total").(metrics.NumValue).Int64())
+	}
+	// First window is exported as is, second one as the change since the
+	// first window.
+	assert.Equal(t, []int64{3, 3}, got)
+}
+
+func TestReload(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	Register("reload-s1", &testSurfacer{})
+	Register("reload-s2", &testSurfacer{})
+	Register("reload-s3", &testSurfacer{})
+
+	userDefined := func(name string) *surfacerpb.SurfacerDef {
+		return &surfacerpb.SurfacerDef{
+			Name: proto.String(name),
+			Type: surfacerpb.Type_USER_DEFINED.Enum(),
+		}
+	}
+
+	current, err := Init(context.Background(), []*surfacerpb.SurfacerDef{userDefined("reload-s1"), userDefined("reload-s2")})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+	byName := func(sis []*SurfacerInfo) map[string]*SurfacerInfo {
+		m := make(map[string]*SurfacerInfo)
+		for _, si := range sis {
+			m[si.Name] = si
+		}
+		return m
+	}
+	before := byName(current)
+
+	// s1 unchanged, s2 removed, s3 added.
+	got, err := Reload(context.Background(), current, []*surfacerpb.SurfacerDef{userDefined("reload-s1"), userDefined("reload-s3")})
+	assert.NoError(t, err)
+	after := byName(got)
+	assert.Same(t, before["reload-s1"], after["reload-s1"], "unchanged surfacer should be kept")
+	assert.Nil(t, after["reload-s2"])
+	assert.NotNil(t, after["reload-s3"])
+	assert.Same(t, before[""], after[""], "required surfacer should be kept")
+	assert.Len(t, got, 3)
+
+	// Adding surfacers that register HTTP handlers is not allowed.
+	_, err = Reload(context.Background(), got, []*surfacerpb.SurfacerDef{userDefined("reload-s1"), {Type: surfacerpb.Type_PROMETHEUS.Enum()}})
+	assert.Error(t, err)
+
+	// Errors don't affect the current surfacers.
+	_, err = Reload(context.Background(), got, []*surfacerpb.SurfacerDef{userDefined("reload-s4")})
+	assert.Error(t, err)
+	assert.Equal(t, after, byName(got))
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package consul implements Consul based targets for cloudprober.
+*/
+package consul
+
+import (
+	"context"
+
+	"github.com/cloudprober/cloudprober/internal/rds/client"
+	client_configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/consul"
+	consul_configpb "github.com/cloudprober/cloudprober/internal/rds/consul/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/consul/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// New returns new Consul targets.
+func New(opts *configpb.TargetsConf, l *logger.Logger) (*client.Client, error) {
+	lister, err := consul.New(&consul_configpb.ProviderConfig{
+		Address:      proto.String(opts.GetAddress()),
+		Token:        proto.String(opts.GetToken()),
+		TlsConfig:    opts.GetTlsConfig(),
+		Datacenter:   proto.String(opts.GetDatacenter()),
+		Service:      opts.GetService(),
+		Tag:          opts.GetTag(),
+		NodeMeta:     opts.GetNodeMeta(),
+		HealthStatus: opts.GetHealthStatus().Enum(),
+	}, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Similar to file targets, we can use a short client refresh interval as
+	// the consul provider keeps its cache up-to-date using blocking queries
+	// and sets last_modified only when something changes.
+	clientConf := &client_configpb.ClientConf{
+		Request:   &rdspb.ListResourcesRequest{Filter: opts.GetFilter()},
+		ReEvalSec: proto.Int32(5),
+	}
+
+	return client.New(clientConf, func(_ context.Context, req *rdspb.ListResourcesRequest) (*rdspb.ListResourcesResponse, error) {
+		return lister.ListResources(req)
+	}, l)
+}
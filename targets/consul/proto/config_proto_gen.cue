@@ -0,0 +1,41 @@
+package proto
+
+import (
+	"github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	proto_1 "github.com/cloudprober/cloudprober/internal/rds/consul/proto"
+	proto_5 "github.com/cloudprober/cloudprober/internal/rds/proto"
+)
+
+#TargetsConf: {
+	// Consul HTTP API address, e.g. "http://consul.service:8500". If not
+	// specified, CONSUL_HTTP_ADDR environment variable is used, and if that's
+	// not set either, "http://localhost:8500".
+	address?: string @protobuf(1,string)
+
+	// ACL token. If not specified, CONSUL_HTTP_TOKEN environment variable is
+	// used.
+	token?: string @protobuf(2,string)
+
+	// TLS config to talk to the Consul API.
+	tlsConfig?: proto.#TLSConfig @protobuf(3,.cloudprober.tlsconfig.TLSConfig,name=tls_config)
+
+	// Datacenter to discover services in.
+	datacenter?: string @protobuf(4,string)
+
+	// Services to probe. If not specified, all services in the catalog are
+	// probed.
+	service?: [...string] @protobuf(5,string)
+
+	// Only probe service instances that have all these tags.
+	tag?: [...string] @protobuf(6,string)
+
+	// Only probe service instances running on the nodes with this metadata.
+	nodeMeta?: {
+		[string]: string
+	} @protobuf(7,map[string]string,node_meta)
+	healthStatus?: proto_1.#ProviderConfig.#HealthStatus @protobuf(8,.cloudprober.rds.consul.ProviderConfig.HealthStatus,name=health_status,"default=PASSING")
+
+	// Filters to further narrow down the targets. Supported filters: name,
+	// service, tag, labels.<key>.
+	filter?: [...proto_5.#Filter] @protobuf(9,.cloudprober.rds.Filter)
+}
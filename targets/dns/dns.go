@@ -0,0 +1,226 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package dns implements DNS SRV records based targets for cloudprober. Each SRV
+record becomes an endpoint, with SRV record's target host as the endpoint name
+and SRV record's port as the endpoint port. Optionally, TXT records of the
+target hosts are used as endpoint labels.
+
+Example config:
+
+	targets {
+	  dns_targets {
+	    srv_name: "_http._tcp.web.service.consul"
+	    txt_labels: true
+	  }
+	}
+*/
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/dns/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	dnsRes "github.com/cloudprober/cloudprober/targets/resolver"
+)
+
+// lookupTimeout is the timeout for each DNS lookup.
+const lookupTimeout = 10 * time.Second
+
+// Targets implements DNS SRV records based targets.
+type Targets struct {
+	c   *configpb.TargetsConf
+	res *dnsRes.Resolver
+	l   *logger.Logger
+
+	lookupSRV func(ctx context.Context, name string) ([]*net.SRV, error)
+	lookupTXT func(ctx context.Context, name string) ([]string, error)
+
+	mu        sync.RWMutex
+	endpoints map[string][]endpoint.Endpoint // Keyed by SRV name.
+}
+
+// ListEndpoints returns the current list of endpoints.
+func (t *Targets) ListEndpoints() []endpoint.Endpoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var result []endpoint.Endpoint
+	for _, name := range t.c.GetSrvName() {
+		result = append(result, t.endpoints[name]...)
+	}
+	return result
+}
+
+// Resolve resolves the target host's IP address.
+func (t *Targets) Resolve(name string, ipVer int) (net.IP, error) {
+	return t.res.Resolve(name, ipVer)
+}
+
+// parseTXT parses "key=value" strings in TXT records into labels. Strings
+// without a "=" are ignored.
+func parseTXT(records []string) map[string]string {
+	labels := make(map[string]string)
+	for _, rec := range records {
+		for _, s := range strings.Fields(rec) {
+			k, v, ok := strings.Cut(s, "=")
+			if !ok || k == "" {
+				continue
+			}
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+func (t *Targets) lookup(ctx context.Context, srvName string) ([]endpoint.Endpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	records, err := t.lookupSRV(ctx, srvName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []endpoint.Endpoint
+	for _, rec := range records {
+		host := strings.TrimSuffix(rec.Target, ".")
+		labels := map[string]string{"srv_name": srvName}
+
+		if t.c.GetTxtLabels() {
+			txt, err := t.lookupTXT(ctx, rec.Target)
+			if err != nil {
+				// Missing TXT records are not an error.
+				var dnsErr *net.DNSError
+				if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+					return nil, fmt.Errorf("error looking up TXT records for %s: %v", host, err)
+				}
+			}
+			for k, v := range parseTXT(txt) {
+				labels[k] = v
+			}
+		}
+
+		result = append(result, endpoint.Endpoint{
+			Name:   host,
+			Port:   int(rec.Port),
+			Labels: labels,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Port < result[j].Port
+	})
+	return result, nil
+}
+
+// refresh re-resolves all SRV names. If a lookup fails, we keep using the
+// existing endpoints for that name. Endpoints that haven't changed retain
+// their last-updated timestamp.
+func (t *Targets) refresh(ctx context.Context) {
+	for _, srvName := range t.c.GetSrvName() {
+		eps, err := t.lookup(ctx, srvName)
+		if err != nil {
+			t.l.Errorf("dns.refresh: error resolving %s: %v", srvName, err)
+			continue
+		}
+
+		t.mu.Lock()
+		oldEps := make(map[string]time.Time)
+		for _, ep := range t.endpoints[srvName] {
+			oldEps[ep.Key()] = ep.LastUpdated
+		}
+		now := time.Now()
+		for i := range eps {
+			eps[i].LastUpdated = now
+			if lastUpdated, ok := oldEps[eps[i].Key()]; ok {
+				eps[i].LastUpdated = lastUpdated
+			}
+		}
+		t.endpoints[srvName] = eps
+		t.mu.Unlock()
+	}
+}
+
+// New returns new DNS targets.
+func New(opts *configpb.TargetsConf, res *dnsRes.Resolver, l *logger.Logger) (*Targets, error) {
+	if len(opts.GetSrvName()) == 0 {
+		return nil, errors.New("dns_targets: srv_name is required")
+	}
+	if opts.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("dns_targets: invalid re_eval_sec: %d", opts.GetReEvalSec())
+	}
+
+	netRes := net.DefaultResolver
+	if opts.GetDnsServer() != "" {
+		server := opts.GetDnsServer()
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		netRes = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+		res = dnsRes.NewWithResolve(func(name string) ([]net.IP, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+			defer cancel()
+			return netRes.LookupIP(ctx, "ip", name)
+		})
+	}
+
+	t := &Targets{
+		c:   opts,
+		res: res,
+		l:   l,
+		lookupSRV: func(ctx context.Context, name string) ([]*net.SRV, error) {
+			_, records, err := netRes.LookupSRV(ctx, "", "", name)
+			return records, err
+		},
+		lookupTXT: netRes.LookupTXT,
+		endpoints: make(map[string][]endpoint.Endpoint),
+	}
+
+	ctx := context.Background()
+	t.refresh(ctx)
+
+	reEvalInterval := time.Duration(opts.GetReEvalSec()) * time.Second
+	go func() {
+		// Introduce a random delay between 0-reEvalInterval before starting
+		// the refresh loop, to spread DNS queries from multiple probes.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			t.refresh(ctx)
+		}
+	}()
+
+	return t, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/dns/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseTXT(t *testing.T) {
+	got := parseTXT([]string{"env=prod zone=us-east1-b", "standalone", "=x", "version=1.2"})
+	assert.Equal(t, map[string]string{"env": "prod", "zone": "us-east1-b", "version": "1.2"}, got)
+}
+
+type fakeDNS struct {
+	srv    map[string][]*net.SRV
+	txt    map[string][]string
+	srvErr error
+}
+
+func (fd *fakeDNS) lookupSRV(_ context.Context, name string) ([]*net.SRV, error) {
+	if fd.srvErr != nil {
+		return nil, fd.srvErr
+	}
+	return fd.srv[name], nil
+}
+
+func (fd *fakeDNS) lookupTXT(_ context.Context, name string) ([]string, error) {
+	if fd.txt[name] == nil {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return fd.txt[name], nil
+}
+
+func testTargets(c *configpb.TargetsConf, fd *fakeDNS) *Targets {
+	return &Targets{
+		c:         c,
+		l:         &logger.Logger{},
+		lookupSRV: fd.lookupSRV,
+		lookupTXT: fd.lookupTXT,
+		endpoints: make(map[string][]endpoint.Endpoint),
+	}
+}
+
+func TestListEndpoints(t *testing.T) {
+	fd := &fakeDNS{
+		srv: map[string][]*net.SRV{
+			"_http._tcp.web.example.com": {
+				{Target: "web-2.example.com.", Port: 8080},
+				{Target: "web-1.example.com.", Port: 8081},
+				{Target: "web-1.example.com.", Port: 8080},
+			},
+			"_http._tcp.api.example.com": {
+				{Target: "api-1.example.com.", Port: 9000},
+			},
+		},
+		txt: map[string][]string{
+			"web-1.example.com.": {"env=prod"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		txtLabels bool
+		want      []endpoint.Endpoint
+	}{
+		{
+			name: "no_txt",
+			want: []endpoint.Endpoint{
+				{Name: "web-1.example.com", Port: 8080, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com"}},
+				{Name: "web-1.example.com", Port: 8081, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com"}},
+				{Name: "web-2.example.com", Port: 8080, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com"}},
+				{Name: "api-1.example.com", Port: 9000, Labels: map[string]string{"srv_name": "_http._tcp.api.example.com"}},
+			},
+		},
+		{
+			name:      "txt",
+			txtLabels: true,
+			want: []endpoint.Endpoint{
+				{Name: "web-1.example.com", Port: 8080, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com", "env": "prod"}},
+				{Name: "web-1.example.com", Port: 8081, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com", "env": "prod"}},
+				{Name: "web-2.example.com", Port: 8080, Labels: map[string]string{"srv_name": "_http._tcp.web.example.com"}},
+				{Name: "api-1.example.com", Port: 9000, Labels: map[string]string{"srv_name": "_http._tcp.api.example.com"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tgts := testTargets(&configpb.TargetsConf{
+				SrvName:   []string{"_http._tcp.web.example.com", "_http._tcp.api.example.com"},
+				TxtLabels: proto.Bool(test.txtLabels),
+			}, fd)
+			tgts.refresh(context.Background())
+
+			got := tgts.ListEndpoints()
+			for i := range got {
+				assert.False(t, got[i].LastUpdated.IsZero(), "last updated")
+				got[i].LastUpdated = test.want[i].LastUpdated
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	srvName := "_http._tcp.web.example.com"
+	fd := &fakeDNS{
+		srv: map[string][]*net.SRV{
+			srvName: {{Target: "web-1.example.com.", Port: 8080}},
+		},
+	}
+	tgts := testTargets(&configpb.TargetsConf{SrvName: []string{srvName}}, fd)
+
+	tgts.refresh(context.Background())
+	eps := tgts.ListEndpoints()
+	assert.Len(t, eps, 1)
+	lastUpdated := eps[0].LastUpdated
+
+	// Unchanged endpoints retain their last-updated timestamp.
+	fd.srv[srvName] = append(fd.srv[srvName], &net.SRV{Target: "web-2.example.com.", Port: 8080})
+	tgts.refresh(context.Background())
+	eps = tgts.ListEndpoints()
+	assert.Len(t, eps, 2)
+	assert.Equal(t, lastUpdated, eps[0].LastUpdated)
+
+	// Lookup errors don't remove existing endpoints.
+	fd.srvErr = errors.New("server misbehaving")
+	tgts.refresh(context.Background())
+	assert.Len(t, tgts.ListEndpoints(), 2)
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(&configpb.TargetsConf{}, nil, nil)
+	assert.Error(t, err, "no srv_name")
+
+	_, err = New(&configpb.TargetsConf{SrvName: []string{"_http._tcp.web"}, ReEvalSec: proto.Int32(0)}, nil, nil)
+	assert.Error(t, err, "invalid re_eval_sec")
+}
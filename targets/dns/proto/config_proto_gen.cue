@@ -0,0 +1,20 @@
+package proto
+
+#TargetsConf: {
+	// SRV record names to resolve, e.g. "_http._tcp.web.service.consul". Each
+	// SRV record becomes a target, with SRV record's target host as the target
+	// name and SRV record's port as the target port.
+	srvName?: [...string] @protobuf(1,string,name=srv_name)
+
+	// If enabled, TXT records of the SRV targets' hosts are looked up and
+	// "key=value" strings in them are added as target labels.
+	txtLabels?: bool @protobuf(2,bool,name=txt_labels,"default=false")
+
+	// DNS server to use, as "host:port", e.g. "10.0.0.53:53". This server is
+	// used to resolve the targets' hosts as well. If not specified, system
+	// resolver is used.
+	dnsServer?: string @protobuf(3,string,name=dns_server)
+
+	// How often to re-resolve the SRV (and TXT) records.
+	reEvalSec?: int32 @protobuf(4,int32,name=re_eval_sec,"default=30")
+}
@@ -0,0 +1,29 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/rds/proto"
+
+#TargetsConf: {
+	// Docker Engine API address, e.g. "unix:///var/run/docker.sock". If not
+	// specified, DOCKER_HOST environment variable is used, and if that's not set
+	// either, "unix:///var/run/docker.sock".
+	address?: string @protobuf(1,string)
+
+	// Only probe containers with these labels. Labels can be specified as
+	// "key" (container has the label) or "key=value".
+	label?: [...string] @protobuf(2,string)
+
+	// Network to take the container IP from. If not specified, the first
+	// network (in alphabetical order) with an IP address is used.
+	network?: string @protobuf(3,string)
+
+	// Probe containers through their published (host) ports, instead of the
+	// container IP and port.
+	usePublishedPorts?: bool @protobuf(4,bool,name=use_published_ports,"default=false")
+
+	// Filters to further narrow down the targets. Supported filters: name,
+	// image, network, labels.<key>.
+	filter?: [...proto.#Filter] @protobuf(5,.cloudprober.rds.Filter)
+
+	// How often to refresh the containers list.
+	reEvalSec?: int32 @protobuf(6,int32,name=re_eval_sec,"default=30")
+}
@@ -34,8 +34,11 @@ import (
 // New returns new file targets.
 func New(opts *configpb.TargetsConf, res *dnsRes.Resolver, l *logger.Logger) (*client.Client, error) {
 	lister, err := file.New(&file_configpb.ProviderConfig{
-		FilePath:  []string{opts.GetFilePath()},
-		ReEvalSec: proto.Int32(opts.GetReEvalSec()),
+		FilePath:    []string{opts.GetFilePath()},
+		ReEvalSec:   proto.Int32(opts.GetReEvalSec()),
+		Header:      opts.GetHeader(),
+		OauthConfig: opts.GetOauthConfig(),
+		TlsConfig:   opts.GetTlsConfig(),
 	}, l)
 	if err != nil {
 		return nil, err
@@ -3,10 +3,16 @@ package proto
 import (
 	"github.com/cloudprober/cloudprober/internal/rds/proto"
 	proto_1 "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	proto_5 "github.com/cloudprober/cloudprober/internal/oauth/proto"
+	proto_8 "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
 )
 
 #TargetsConf: {
-	// File that contains resources in either textproto or json format.
+	// File that contains resources in either textproto or json format. File
+	// can also be an HTTP(S) URL, or a GCS (gs://) or S3 (s3://) object. Remote
+	// files are downloaded again only if they change (using ETag and
+	// Last-Modified).
+	//
 	// Example in textproto format:
 	//
 	// resource {
@@ -29,4 +35,15 @@ import (
 
 	// If specified, file will be re-read at the given interval.
 	reEvalSec?: int32 @protobuf(4,int32,name=re_eval_sec)
+
+	// HTTP headers to add to the requests for HTTP(S) URLs.
+	header?: {
+		[string]: string
+	} @protobuf(5,map[string]string)
+
+	// OAuth config for HTTP(S) URLs and GCS objects.
+	oauthConfig?: proto_5.#Config @protobuf(6,.cloudprober.oauth.Config,name=oauth_config)
+
+	// TLS config for HTTP(S) URLs.
+	tlsConfig?: proto_8.#TLSConfig @protobuf(7,.cloudprober.tlsconfig.TLSConfig,name=tls_config)
 }
@@ -0,0 +1,218 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package health implements health-gated targets: targets that exclude the
+endpoints that are currently failing another ("gate") probe.
+
+Probes report their results to this package through Record. To keep the
+overhead low, results are tracked only for the probes that are used as gates,
+or that other probes depend on (see Track).
+*/
+package health
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+)
+
+type targetState struct {
+	results  metrics.ResultsTracker
+	failures int // Consecutive failed probe cycles.
+}
+
+// global keeps the gate probes' results and the gated targets.
+var global = struct {
+	mu     sync.RWMutex
+	states map[string]map[string]*targetState // probe -> target -> state
+	gated  []*GatedTargets
+}{
+	states: make(map[string]map[string]*targetState),
+}
+
+func registerGate(gt *GatedTargets) {
+	Track(gt.gateProbe)
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.gated = append(global.gated, gt)
+}
+
+// Track starts tracking the given probe's results, for Failing.
+func Track(probe string) {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if global.states[probe] == nil {
+		global.states[probe] = make(map[string]*targetState)
+	}
+}
+
+// Record records a probe result (EventMetrics with cumulative total and
+// success metrics) for the given endpoint. Results are recorded only for the
+// gate probes.
+func Record(ep endpoint.Endpoint, em *metrics.EventMetrics) {
+	probe := em.Label("probe")
+
+	global.mu.RLock()
+	states := global.states[probe]
+	global.mu.RUnlock()
+	if states == nil {
+		return
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	ts := states[ep.Name]
+	if ts == nil {
+		ts = &targetState{}
+	}
+	delta, ok := ts.results.Update(em)
+	if !ok {
+		return
+	}
+	states[ep.Name] = ts
+
+	if delta.Total == 0 {
+		return
+	}
+	if delta.Success < delta.Total {
+		ts.failures++
+	} else {
+		ts.failures = 0
+	}
+}
+
+func failures(probe, target string) int {
+	global.mu.RLock()
+	defer global.mu.RUnlock()
+
+	if ts := global.states[probe][target]; ts != nil {
+		return ts.failures
+	}
+	return 0
+}
+
+// Failing returns true if the probe has been failing for the target for at
+// least threshold consecutive probe cycles. If target is empty, it returns
+// true if the probe is failing for any target. Probe should be tracked
+// through Track.
+func Failing(probe, target string, threshold int) bool {
+	if target != "" {
+		return failures(probe, target) >= threshold
+	}
+
+	global.mu.RLock()
+	defer global.mu.RUnlock()
+	for _, ts := range global.states[probe] {
+		if ts.failures >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// Targets is the interface implemented by the targets being gated. It's the
+// same as targets.Targets.
+type Targets interface {
+	endpoint.Lister
+	endpoint.Resolver
+}
+
+// GatedTargets wraps targets to exclude the endpoints failing the gate probe.
+type GatedTargets struct {
+	tgts      Targets
+	probe     string
+	gateProbe string
+	threshold int
+	gatedOut  int64
+	l         *logger.Logger
+}
+
+// ListEndpoints returns the underlying targets' endpoints, excluding the ones
+// currently failing the gate probe.
+func (gt *GatedTargets) ListEndpoints() []endpoint.Endpoint {
+	eps := gt.tgts.ListEndpoints()
+
+	var result []endpoint.Endpoint
+	for _, ep := range eps {
+		if failures(gt.gateProbe, ep.Name) >= gt.threshold {
+			continue
+		}
+		result = append(result, ep)
+	}
+
+	gatedOut := len(eps) - len(result)
+	if old := atomic.SwapInt64(&gt.gatedOut, int64(gatedOut)); old != int64(gatedOut) {
+		gt.l.Infof("health_gate: %d targets are failing the gate probe (%s)", gatedOut, gt.gateProbe)
+	}
+	return result
+}
+
+// Resolve resolves the target using the underlying targets.
+func (gt *GatedTargets) Resolve(name string, ipVer int) (net.IP, error) {
+	return gt.tgts.Resolve(name, ipVer)
+}
+
+// New returns targets gated by the given health gate, for the given probe.
+func New(tgts Targets, c *targetspb.HealthGate, probe string, l *logger.Logger) (*GatedTargets, error) {
+	if c.GetProbe() == "" {
+		return nil, fmt.Errorf("health_gate: gate probe name is required")
+	}
+	if c.GetProbe() == probe {
+		return nil, fmt.Errorf("health_gate: probe (%s) can't be its own gate", probe)
+	}
+	if c.GetFailureThreshold() <= 0 {
+		return nil, fmt.Errorf("health_gate: invalid failure_threshold: %d", c.GetFailureThreshold())
+	}
+
+	gt := &GatedTargets{
+		tgts:      tgts,
+		probe:     probe,
+		gateProbe: c.GetProbe(),
+		threshold: int(c.GetFailureThreshold()),
+		l:         l,
+	}
+	registerGate(gt)
+	return gt, nil
+}
+
+// GatedOutMetrics returns the number of targets gated out for each gated
+// probe, as observed during the last targets listing.
+func GatedOutMetrics(ts time.Time) []*metrics.EventMetrics {
+	global.mu.RLock()
+	defer global.mu.RUnlock()
+
+	var result []*metrics.EventMetrics
+	for _, gt := range global.gated {
+		em := metrics.NewEventMetrics(ts).
+			AddMetric("health_gated_targets", metrics.NewInt(atomic.LoadInt64(&gt.gatedOut))).
+			AddLabel("ptype", "sysvars").
+			AddLabel("probe", "sysvars").
+			AddLabel("gated_probe", gt.probe).
+			AddLabel("gate_probe", gt.gateProbe)
+		em.Kind = metrics.GAUGE
+		result = append(result, em)
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type testTargets struct {
+	eps []endpoint.Endpoint
+}
+
+func (tt *testTargets) ListEndpoints() []endpoint.Endpoint {
+	return tt.eps
+}
+
+func (tt *testTargets) Resolve(name string, ipVer int) (net.IP, error) {
+	return nil, nil
+}
+
+func testEM(probe string, total, success int64) *metrics.EventMetrics {
+	return metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("success", metrics.NewInt(success)).
+		AddLabel("probe", probe)
+}
+
+func TestGatedTargets(t *testing.T) {
+	tgts := &testTargets{eps: endpoint.EndpointsFromNames([]string{"vm-1", "vm-2", "vm-3"})}
+
+	tests := []struct {
+		desc      string
+		threshold *int32
+		results   map[string][][2]int64 // Cumulative total, success.
+		want      []string
+	}{
+		{
+			desc: "no_results",
+			want: []string{"vm-1", "vm-2", "vm-3"},
+		},
+		{
+			desc: "one_failure",
+			results: map[string][][2]int64{
+				"vm-1": {{1, 1}, {2, 2}},
+				"vm-2": {{1, 1}, {2, 1}},
+				"vm-3": {{1, 0}, {2, 1}},
+			},
+			want: []string{"vm-1", "vm-3"},
+		},
+		{
+			desc:      "threshold",
+			threshold: proto.Int32(2),
+			results: map[string][][2]int64{
+				"vm-1": {{1, 0}, {2, 0}},
+				"vm-2": {{1, 1}, {2, 1}},
+				"vm-3": {{1, 1}, {2, 1}, {2, 1}}, // Same result again is ignored.
+			},
+			want: []string{"vm-2", "vm-3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gateProbe := "ping-" + test.desc
+			gt, err := New(tgts, &targetspb.HealthGate{
+				Probe:            proto.String(gateProbe),
+				FailureThreshold: test.threshold,
+			}, "browser", &logger.Logger{})
+			assert.NoError(t, err)
+
+			for target, results := range test.results {
+				for _, r := range results {
+					Record(endpoint.Endpoint{Name: target}, testEM(gateProbe, r[0], r[1]))
+				}
+				// Results from other probes are ignored.
+				Record(endpoint.Endpoint{Name: target}, testEM("other", 10, 0))
+			}
+
+			assert.Equal(t, test.want, endpoint.NamesFromEndpoints(gt.ListEndpoints()))
+
+			var found bool
+			for _, em := range GatedOutMetrics(time.Now()) {
+				if em.Label("gate_probe") == gateProbe {
+					found = true
+					assert.Equal(t, "browser", em.Label("gated_probe"))
+					assert.Equal(t, int64(3-len(test.want)), em.Metric("health_gated_targets").(metrics.NumValue).Int64())
+				}
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tgts := &testTargets{}
+	for _, c := range []*targetspb.HealthGate{
+		{},
+		{Probe: proto.String("browser")},
+		{Probe: proto.String("ping"), FailureThreshold: proto.Int32(0)},
+	} {
+		_, err := New(tgts, c, "browser", &logger.Logger{})
+		assert.Error(t, err, "health gate: %v", c)
+	}
+}
+
+func TestFailing(t *testing.T) {
+	probe := "test-failing-dep"
+	vm1, vm2 := endpoint.Endpoint{Name: "vm-1"}, endpoint.Endpoint{Name: "vm-2"}
+
+	// Results of the untracked probes are not recorded.
+	Record(vm1, testEM(probe, 1, 0))
+	assert.False(t, Failing(probe, "", 1))
+
+	Track(probe)
+	Record(vm1, testEM(probe, 1, 1))
+	Record(vm2, testEM(probe, 1, 1))
+	assert.False(t, Failing(probe, "", 1))
+
+	Record(vm2, testEM(probe, 2, 1))
+	assert.True(t, Failing(probe, "", 1))
+	assert.True(t, Failing(probe, "vm-2", 1))
+	assert.False(t, Failing(probe, "vm-1", 1))
+	assert.False(t, Failing(probe, "", 2))
+	assert.False(t, Failing(probe, "vm-3", 1))
+}
@@ -38,9 +38,10 @@ var global struct {
 	servers map[string]*server.Server
 }
 
-func key(namespace string, labelSelector []string, resourceType string) string {
+func key(namespace string, labelSelector, fieldSelector []string, resourceType string) string {
 	sort.Strings(labelSelector)
-	return strings.Join([]string{namespace, strings.Join(labelSelector, ","), resourceType}, "+")
+	sort.Strings(fieldSelector)
+	return strings.Join([]string{namespace, strings.Join(labelSelector, ","), strings.Join(fieldSelector, ","), resourceType}, "+")
 }
 
 func initRDSServer(k string, kpc *k8sconfigpb.ProviderConfig, l *logger.Logger) (*server.Server, error) {
@@ -76,6 +77,7 @@ func parseConfig(pb *targetspb.K8STargets) (*k8sconfigpb.ProviderConfig, string,
 	pc := &k8sconfigpb.ProviderConfig{
 		Namespace:     proto.String(pb.GetNamespace()),
 		LabelSelector: pb.GetLabelSelector(),
+		FieldSelector: pb.GetFieldSelector(),
 		ReEvalSec:     proto.Int32(int32(pb.GetReEvalSec())),
 	}
 
@@ -92,6 +94,12 @@ func parseConfig(pb *targetspb.K8STargets) (*k8sconfigpb.ProviderConfig, string,
 	case *targetspb.K8STargets_Pods:
 		pc.Pods = &k8sconfigpb.Pods{}
 		return pc, "pods", pb.GetPods()
+	case *targetspb.K8STargets_Endpointslices:
+		pc.Endpointslices = &k8sconfigpb.EndpointSlices{}
+		return pc, "endpointslices", pb.GetEndpointslices()
+	case *targetspb.K8STargets_Httproutes:
+		pc.Httproutes = &k8sconfigpb.HTTPRoutes{}
+		return pc, "httproutes", pb.GetHttproutes()
 	}
 
 	return nil, "", ""
@@ -141,7 +149,7 @@ func k8sTargets(pb *targetspb.K8STargets, l *logger.Logger) (*rdsclient.Client,
 		return rdsclient.New(conf, nil, l)
 	}
 
-	s, err := initRDSServer(key(pb.GetNamespace(), pb.GetLabelSelector(), resources), pc, l)
+	s, err := initRDSServer(key(pb.GetNamespace(), pb.GetLabelSelector(), pb.GetFieldSelector(), resources), pc, l)
 	if err != nil {
 		return nil, fmt.Errorf("k8s: error creating resource discovery server: %v", err)
 	}
@@ -60,6 +60,15 @@ func Test_parseConfig(t *testing.T) {
 			},
 			wantName: "pods",
 		},
+		{
+			cfg: `httproutes:""`,
+			wantPC: &k8sconfigpb.ProviderConfig{
+				Namespace:  proto.String(""),
+				Httproutes: &k8sconfigpb.HTTPRoutes{},
+				ReEvalSec:  proto.Int32(30),
+			},
+			wantName: "httproutes",
+		},
 		{
 			cfg: `namespace:"dev"
 			      endpoints:".*-service"
@@ -73,6 +82,20 @@ func Test_parseConfig(t *testing.T) {
 			wantName:  "endpoints",
 			wantValue: ".*-service",
 		},
+		{
+			cfg: `endpointslices:"web"
+			      labelSelector:["env in (prod,qa)"]
+			      fieldSelector:["metadata.namespace!=kube-system"]`,
+			wantPC: &k8sconfigpb.ProviderConfig{
+				Namespace:      proto.String(""),
+				LabelSelector:  []string{"env in (prod,qa)"},
+				FieldSelector:  []string{"metadata.namespace!=kube-system"},
+				Endpointslices: &k8sconfigpb.EndpointSlices{},
+				ReEvalSec:      proto.Int32(30),
+			},
+			wantName:  "endpointslices",
+			wantValue: "web",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.cfg, func(t *testing.T) {
@@ -0,0 +1,66 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package kv implements targets for the services that register themselves in a
+key-value store (etcd or ZooKeeper).
+*/
+package kv
+
+import (
+	"context"
+
+	"github.com/cloudprober/cloudprober/internal/rds/client"
+	client_configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/kv"
+	kv_configpb "github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/kv/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// New returns new key-value store targets.
+func New(opts *configpb.TargetsConf, l *logger.Logger) (*client.Client, error) {
+	pc := &kv_configpb.ProviderConfig{
+		Prefix:       proto.String(opts.GetPrefix()),
+		AddressField: proto.String(opts.GetAddressField()),
+		PortField:    proto.String(opts.GetPortField()),
+		LabelsField:  proto.String(opts.GetLabelsField()),
+		NameField:    proto.String(opts.GetNameField()),
+		ReEvalSec:    proto.Int32(opts.GetReEvalSec()),
+	}
+	switch opts.Backend.(type) {
+	case *configpb.TargetsConf_Etcd:
+		pc.Backend = &kv_configpb.ProviderConfig_Etcd{Etcd: opts.GetEtcd()}
+	case *configpb.TargetsConf_Zookeeper:
+		pc.Backend = &kv_configpb.ProviderConfig_Zookeeper{Zookeeper: opts.GetZookeeper()}
+	}
+
+	lister, err := kv.New(pc, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// KV provider sets last_modified only when resources change, so we can
+	// use a short client refresh interval.
+	clientConf := &client_configpb.ClientConf{
+		Request:   &rdspb.ListResourcesRequest{Filter: opts.GetFilter()},
+		ReEvalSec: proto.Int32(5),
+	}
+
+	return client.New(clientConf, func(_ context.Context, req *rdspb.ListResourcesRequest) (*rdspb.ListResourcesResponse, error) {
+		return lister.ListResources(req)
+	}, l)
+}
@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"github.com/cloudprober/cloudprober/internal/rds/kv/proto"
+	proto_1 "github.com/cloudprober/cloudprober/internal/rds/proto"
+)
+
+#TargetsConf: {
+	{} | {
+		etcd: proto.#EtcdConfig @protobuf(1,.cloudprober.rds.kv.EtcdConfig)
+	} | {
+		zookeeper: proto.#ZooKeeperConfig @protobuf(2,.cloudprober.rds.kv.ZooKeeperConfig)
+	}
+
+	// Key prefix where services register themselves, e.g. "/services/web/".
+	// For ZooKeeper, it's the parent znode.
+	prefix?: string @protobuf(3,string,#"default="/""#)
+
+	// Where to find the target attributes in the JSON values. See
+	// internal/rds/kv/proto/config.proto for details.
+	addressField?: string @protobuf(4,string,name=address_field,#"default="address""#)
+	portField?:    string @protobuf(5,string,name=port_field,#"default="port""#)
+	labelsField?:  string @protobuf(6,string,name=labels_field,#"default="labels""#)
+	nameField?:    string @protobuf(7,string,name=name_field)
+
+	// Filters to further narrow down the targets. Supported filters: name, key,
+	// labels.<key>.
+	filter?: [...proto_1.#Filter] @protobuf(8,.cloudprober.rds.Filter)
+
+	// How often to re-read the keys.
+	reEvalSec?: int32 @protobuf(9,int32,name=re_eval_sec,"default=30")
+}
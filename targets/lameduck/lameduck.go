@@ -13,9 +13,10 @@
 // limitations under the License.
 
 // Package lameduck implements a lameducks provider. Lameduck provider fetches
-// lameducks from the RTC (Runtime Configurator) service. This functionality
-// allows an operator to do hitless VM upgrades. If a target is set to be in
-// lameduck by the operator, it is taken out of the targets list.
+// lameducks from the RTC (Runtime Configurator) service, PubSub messages, a
+// local file and a local admin API. This functionality allows an operator to
+// do hitless VM upgrades. If a target is set to be in lameduck by the
+// operator, it is taken out of the targets list.
 package lameduck
 
 import (
@@ -24,6 +25,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/cloudprober/cloudprober/config/runconfig"
@@ -38,6 +40,7 @@ import (
 	configpb "github.com/cloudprober/cloudprober/targets/lameduck/proto"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
 	"github.com/cloudprober/cloudprober/targets/rtc/rtcservice"
+	"github.com/cloudprober/cloudprober/web/webutils"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -203,6 +206,9 @@ func (li *lister) ListEndpoints() []endpoint.Endpoint {
 	for _, cl := range li.clients {
 		result = append(result, cl.ListEndpoints()...)
 	}
+	if li.local != nil {
+		result = append(result, li.local.ListEndpoints()...)
+	}
 
 	if len(result) != 0 {
 		li.l.Infof("Lameducked targets: %v", result)
@@ -218,6 +224,7 @@ type lister struct {
 	rdsServerOpts     *rdsclientpb.ClientConf_ServerOptions
 	listResourcesFunc rdsclient.ListResourcesFunc
 	clients           []*rdsclient.Client
+	local             *localLister
 	l                 *logger.Logger
 }
 
@@ -226,11 +233,25 @@ func newLister(globalOpts *targetspb.GlobalTargetsOptions, l *logger.Logger) (*l
 	li := &lister{
 		opts:          opts,
 		rdsServerOpts: globalOpts.GetRdsServerOptions(),
-		rtcConfig:     opts.GetRuntimeconfigName(),
 		pubsubTopic:   opts.GetPubsubTopic(),
 		l:             l,
 	}
 
+	if opts.GetUseRuntimeconfig() {
+		li.rtcConfig = opts.GetRuntimeconfigName()
+	}
+
+	if opts.GetLameduckFile() != "" || opts.GetEnableAdminApi() {
+		if err := li.initLocal(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nothing more to do if we are not using GCP based lameducks.
+	if li.rtcConfig == "" && li.pubsubTopic == "" {
+		return li, nil
+	}
+
 	var err error
 	li.project, err = getProject(opts)
 	if err != nil {
@@ -260,6 +281,30 @@ func newLister(globalOpts *targetspb.GlobalTargetsOptions, l *logger.Logger) (*l
 	return li, li.initClients()
 }
 
+func (li *lister) initLocal() error {
+	var err error
+	li.local, err = newLocalLister(li.opts.GetLameduckFile(), li.opts.GetEnableAdminApi(), li.l)
+	if err != nil {
+		return err
+	}
+
+	if li.opts.GetLameduckFile() != "" {
+		go li.local.watchFile(time.Duration(li.opts.GetReEvalSec()) * time.Second)
+	}
+
+	if li.opts.GetEnableAdminApi() {
+		srvMux := runconfig.DefaultHTTPServeMux()
+		if srvMux == nil {
+			return nil
+		}
+		if webutils.IsHandled(srvMux, "/lameduck") {
+			return fmt.Errorf("lameduck: url /lameduck is already handled")
+		}
+		srvMux.Handle("/lameduck", li.local)
+	}
+	return nil
+}
+
 // InitDefaultLister initializes the package using the given arguments. If a
 // lister is given in the arguments, global.lister is set to that, otherwise a
 // new lameduck service is created using the config options, and global.lister
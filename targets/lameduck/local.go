@@ -0,0 +1,228 @@
+// Copyright 2026 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lameduck
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// ErrAdminAPIDisabled is returned by the package level Lameduck, Unlameduck
+// and List functions if lameduck admin API is not enabled.
+var ErrAdminAPIDisabled = errors.New("lameduck admin API is not enabled")
+
+// localLister provides lameducks that don't need any external service: ones
+// set through the admin API and ones listed in the lameduck file.
+type localLister struct {
+	mu          sync.RWMutex
+	apiEnabled  bool
+	api         map[string]bool
+	file        string
+	fileModTime time.Time
+	fileNames   []string
+	l           *logger.Logger
+}
+
+func newLocalLister(file string, apiEnabled bool, l *logger.Logger) (*localLister, error) {
+	ll := &localLister{
+		apiEnabled: apiEnabled,
+		api:        make(map[string]bool),
+		file:       file,
+		l:          l,
+	}
+
+	if ll.file != "" {
+		if err := ll.refreshFile(); err != nil {
+			return nil, err
+		}
+	}
+	return ll, nil
+}
+
+// refreshFile re-reads the lameduck file if it has been modified since the
+// last read.
+func (ll *localLister) refreshFile() error {
+	fi, err := os.Stat(ll.file)
+	if err != nil {
+		return fmt.Errorf("lameduck: error reading lameduck file (%s): %v", ll.file, err)
+	}
+
+	ll.mu.RLock()
+	modTime := ll.fileModTime
+	ll.mu.RUnlock()
+	if fi.ModTime().Equal(modTime) {
+		return nil
+	}
+
+	b, err := os.ReadFile(ll.file)
+	if err != nil {
+		return fmt.Errorf("lameduck: error reading lameduck file (%s): %v", ll.file, err)
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	ll.fileModTime = fi.ModTime()
+	ll.fileNames = names
+	return nil
+}
+
+// watchFile keeps refreshing the lameduck file at the given interval.
+func (ll *localLister) watchFile(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := ll.refreshFile(); err != nil {
+			ll.l.Warning(err.Error())
+		}
+	}
+}
+
+// Lameduck puts the target in lameduck mode.
+func (ll *localLister) Lameduck(name string) error {
+	if !ll.apiEnabled {
+		return ErrAdminAPIDisabled
+	}
+	if name == "" {
+		return errors.New("lameduck: target name cannot be empty")
+	}
+
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	ll.api[name] = true
+	return nil
+}
+
+// Unlameduck removes the target from lameduck mode.
+func (ll *localLister) Unlameduck(name string) error {
+	if !ll.apiEnabled {
+		return ErrAdminAPIDisabled
+	}
+
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	delete(ll.api, name)
+	return nil
+}
+
+func (ll *localLister) names() []string {
+	ll.mu.RLock()
+	defer ll.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, name := range ll.fileNames {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for name := range ll.api {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListEndpoints returns the locally lameducked targets. Returned endpoints
+// don't have LastUpdated set, so these lameducks apply until they are removed.
+func (ll *localLister) ListEndpoints() []endpoint.Endpoint {
+	return endpoint.EndpointsFromNames(ll.names())
+}
+
+// ServeHTTP implements the lameduck admin API's HTTP interface.
+func (ll *localLister) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	switch r.Method {
+	case http.MethodGet:
+		for _, name := range ll.names() {
+			fmt.Fprintln(w, name)
+		}
+		return
+	case http.MethodPost, http.MethodPut:
+		err = ll.Lameduck(r.URL.Query().Get("target"))
+	case http.MethodDelete:
+		err = ll.Unlameduck(r.URL.Query().Get("target"))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ll.l.Infof("lameduck: %s %s through admin API", r.Method, r.URL.Query().Get("target"))
+}
+
+// Lameduck lameducks the given target through the lameduck admin API.
+func Lameduck(name string) error {
+	ll, err := getLocalLister()
+	if err != nil {
+		return err
+	}
+	return ll.Lameduck(name)
+}
+
+// Unlameduck un-lameducks the given target through the lameduck admin API.
+func Unlameduck(name string) error {
+	ll, err := getLocalLister()
+	if err != nil {
+		return err
+	}
+	return ll.Unlameduck(name)
+}
+
+// List returns the targets lameducked through the lameduck admin API or the
+// lameduck file.
+func List() ([]string, error) {
+	ll, err := getLocalLister()
+	if err != nil {
+		return nil, err
+	}
+	return ll.names(), nil
+}
+
+func getLocalLister() (*localLister, error) {
+	global.mu.RLock()
+	defer global.mu.RUnlock()
+	li, ok := global.lister.(*lister)
+	if !ok || li.local == nil || !li.local.apiEnabled {
+		return nil, ErrAdminAPIDisabled
+	}
+	return li.local, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lameduck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	configpb "github.com/cloudprober/cloudprober/targets/lameduck/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLocalListerFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lameducks")
+	assert.NoError(t, os.WriteFile(file, []byte("vm-1\n# comment\n\n  vm-2  \n"), 0644))
+
+	ll, err := newLocalLister(file, false, &logger.Logger{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vm-1", "vm-2"}, endpoint.NamesFromEndpoints(ll.ListEndpoints()))
+
+	// Admin API is not enabled.
+	assert.ErrorIs(t, ll.Lameduck("vm-3"), ErrAdminAPIDisabled)
+
+	// Update the file, with a modification time different from the last one.
+	assert.NoError(t, os.WriteFile(file, []byte("vm-3\n"), 0644))
+	assert.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+	assert.NoError(t, ll.refreshFile())
+	assert.Equal(t, []string{"vm-3"}, endpoint.NamesFromEndpoints(ll.ListEndpoints()))
+
+	_, err = newLocalLister(filepath.Join(t.TempDir(), "missing"), false, &logger.Logger{})
+	assert.Error(t, err)
+}
+
+func TestLocalListerAPI(t *testing.T) {
+	ll, err := newLocalLister("", true, &logger.Logger{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, ll.Lameduck("vm-2"))
+	assert.NoError(t, ll.Lameduck("vm-1"))
+	assert.Error(t, ll.Lameduck(""))
+	assert.Equal(t, []string{"vm-1", "vm-2"}, ll.names())
+
+	assert.NoError(t, ll.Unlameduck("vm-2"))
+	assert.Equal(t, []string{"vm-1"}, ll.names())
+
+	// API lameducks don't have last-updated set, so they don't expire.
+	assert.True(t, ll.ListEndpoints()[0].LastUpdated.IsZero())
+}
+
+func TestLocalListerHTTP(t *testing.T) {
+	ll, err := newLocalLister("", true, &logger.Logger{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		method   string
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{method: http.MethodPost, url: "/lameduck?target=vm-1", wantCode: http.StatusOK},
+		{method: http.MethodPut, url: "/lameduck?target=vm-2", wantCode: http.StatusOK},
+		{method: http.MethodPost, url: "/lameduck", wantCode: http.StatusBadRequest},
+		{method: http.MethodGet, url: "/lameduck", wantCode: http.StatusOK, wantBody: "vm-1\nvm-2\n"},
+		{method: http.MethodDelete, url: "/lameduck?target=vm-1", wantCode: http.StatusOK},
+		{method: http.MethodGet, url: "/lameduck", wantCode: http.StatusOK, wantBody: "vm-2\n"},
+		{method: http.MethodPatch, url: "/lameduck", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.url, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ll.ServeHTTP(w, httptest.NewRequest(test.method, test.url, nil))
+			assert.Equal(t, test.wantCode, w.Code)
+			if test.wantBody != "" {
+				assert.Equal(t, test.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewListerWithoutRuntimeconfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lameducks")
+	assert.NoError(t, os.WriteFile(file, []byte("vm-1\n"), 0644))
+
+	// No GCP access should be needed here.
+	li, err := newLister(&targetspb.GlobalTargetsOptions{
+		LameDuckOptions: &configpb.Options{
+			UseRuntimeconfig: proto.Bool(false),
+			LameduckFile:     proto.String(file),
+			EnableAdminApi:   proto.Bool(true),
+		},
+	}, &logger.Logger{})
+	assert.NoError(t, err)
+	assert.Empty(t, li.clients)
+
+	assert.NoError(t, li.local.Lameduck("vm-2"))
+	assert.Equal(t, []string{"vm-1", "vm-2"}, endpoint.NamesFromEndpoints(li.ListEndpoints()))
+
+	// Verify package level functions.
+	global.mu.Lock()
+	oldLister := global.lister
+	global.lister = li
+	global.mu.Unlock()
+	defer func() {
+		global.mu.Lock()
+		global.lister = oldLister
+		global.mu.Unlock()
+	}()
+
+	assert.NoError(t, Lameduck("vm-3"))
+	assert.NoError(t, Unlameduck("vm-2"))
+	names, err := List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vm-1", "vm-3"}, names)
+}
@@ -58,6 +58,27 @@ type Options struct {
 	//	    ...
 	//	  }
 	RdsServerOptions *proto.ClientConf_ServerOptions `protobuf:"bytes,6,opt,name=rds_server_options,json=rdsServerOptions" json:"rds_server_options,omitempty"`
+	// Whether to look for lame-duck targets in the runtime config. Set this to
+	// false to use lameducks in non-GCP environments, i.e. through the
+	// lameduck_file or the admin API (if pubsub_topic is not set either, no
+	// GCP access is needed).
+	UseRuntimeconfig *bool `protobuf:"varint,8,opt,name=use_runtimeconfig,json=useRuntimeconfig,def=1" json:"use_runtimeconfig,omitempty"`
+	// File containing lame-duck targets, one target name per line. Empty lines
+	// and lines starting with '#' are ignored. File is re-read (if modified)
+	// every re_eval_sec seconds. Unlike runtime config variables, targets in this
+	// file don't expire; remove them from the file to un-lameduck them.
+	LameduckFile *string `protobuf:"bytes,9,opt,name=lameduck_file,json=lameduckFile" json:"lameduck_file,omitempty"`
+	// Enable the local lameduck admin API. If enabled, targets can be lameducked
+	// and un-lameducked through:
+	//   - HTTP (on the default HTTP server):
+	//     GET /lameduck                  # list lameducked targets
+	//     POST /lameduck?target=<name>   # lameduck a target
+	//     DELETE /lameduck?target=<name> # un-lameduck a target
+	//   - gRPC (if grpc_port is configured): SetLameduck and ListLameducks
+	//     methods of the Cloudprober service.
+	//
+	// Lameducks set through the API are kept in memory only and don't expire.
+	EnableAdminApi *bool `protobuf:"varint,10,opt,name=enable_admin_api,json=enableAdminApi" json:"enable_admin_api,omitempty"`
 }
 
 // Default values for Options fields.
@@ -65,6 +86,7 @@ const (
 	Default_Options_ReEvalSec         = int32(10)
 	Default_Options_RuntimeconfigName = string("lame-duck-targets")
 	Default_Options_ExpirationSec     = int32(300)
+	Default_Options_UseRuntimeconfig  = bool(true)
 )
 
 func (x *Options) Reset() {
@@ -149,6 +171,27 @@ func (x *Options) GetRdsServerOptions() *proto.ClientConf_ServerOptions {
 	return nil
 }
 
+func (x *Options) GetUseRuntimeconfig() bool {
+	if x != nil && x.UseRuntimeconfig != nil {
+		return *x.UseRuntimeconfig
+	}
+	return Default_Options_UseRuntimeconfig
+}
+
+func (x *Options) GetLameduckFile() string {
+	if x != nil && x.LameduckFile != nil {
+		return *x.LameduckFile
+	}
+	return ""
+}
+
+func (x *Options) GetEnableAdminApi() bool {
+	if x != nil && x.EnableAdminApi != nil {
+		return *x.EnableAdminApi
+	}
+	return false
+}
+
 var File_github_com_cloudprober_cloudprober_targets_lameduck_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_targets_lameduck_proto_config_proto_rawDesc = []byte{
@@ -163,7 +206,7 @@ var file_github_com_cloudprober_cloudprober_targets_lameduck_proto_config_proto_
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
 	0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xe9, 0x02, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a,
+	0x6f, 0x22, 0xeb, 0x03, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a,
 	0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65,
 	0x63, 0x12, 0x33, 0x0a, 0x15, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x63, 0x6f, 0x6e, 0x66,
@@ -185,11 +228,19 @@ var file_github_com_cloudprober_cloudprober_targets_lameduck_proto_config_proto_
 	0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72,
 	0x64, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x65,
 	0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x10, 0x72, 0x64, 0x73,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x3b, 0x5a,
-	0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2f, 0x6c, 0x61, 0x6d, 0x65,
-	0x64, 0x75, 0x63, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a,
+	0x11, 0x75, 0x73, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x10,
+	0x75, 0x73, 0x65, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x61, 0x6d, 0x65, 0x64, 0x75, 0x63, 0x6b, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x6d, 0x65, 0x64, 0x75, 0x63,
+	0x6b, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x70, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x41, 0x70, 0x69, 0x42,
+	0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2f, 0x6c, 0x61,
+	0x6d, 0x65, 0x64, 0x75, 0x63, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -36,4 +36,27 @@ import "github.com/cloudprober/cloudprober/internal/rds/client/proto"
 	//     ...
 	//   }
 	rdsServerOptions?: proto.#ClientConf.#ServerOptions @protobuf(6,rds.ClientConf.ServerOptions,name=rds_server_options)
+
+	// Whether to look for lame-duck targets in the runtime config. Set this to
+	// false to use lameducks in non-GCP environments, i.e. through the
+	// lameduck_file or the admin API (if pubsub_topic is not set either, no
+	// GCP access is needed).
+	useRuntimeconfig?: bool @protobuf(8,bool,name=use_runtimeconfig,"default=true")
+
+	// File containing lame-duck targets, one target name per line. Empty lines
+	// and lines starting with '#' are ignored. File is re-read (if modified)
+	// every re_eval_sec seconds. Unlike runtime config variables, targets in this
+	// file don't expire; remove them from the file to un-lameduck them.
+	lameduckFile?: string @protobuf(9,string,name=lameduck_file)
+
+	// Enable the local lameduck admin API. If enabled, targets can be lameducked
+	// and un-lameducked through:
+	//   - HTTP (on the default HTTP server):
+	//       GET /lameduck                  # list lameducked targets
+	//       POST /lameduck?target=<name>   # lameduck a target
+	//       DELETE /lameduck?target=<name> # un-lameduck a target
+	//   - gRPC (if grpc_port is configured): SetLameduck and ListLameducks
+	//     methods of the Cloudprober service.
+	// Lameducks set through the API are kept in memory only and don't expire.
+	enableAdminApi?: bool @protobuf(10,bool,name=enable_admin_api)
 }
@@ -0,0 +1,67 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package nomad implements Nomad based targets for cloudprober.
+*/
+package nomad
+
+import (
+	"context"
+
+	"github.com/cloudprober/cloudprober/internal/rds/client"
+	client_configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/nomad"
+	nomad_configpb "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/nomad/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// New returns new Nomad targets.
+func New(opts *configpb.TargetsConf, l *logger.Logger) (*client.Client, error) {
+	lister, err := nomad.New(&nomad_configpb.ProviderConfig{
+		Address:             proto.String(opts.GetAddress()),
+		Token:               proto.String(opts.GetToken()),
+		TlsConfig:           opts.GetTlsConfig(),
+		Namespace:           opts.GetNamespace(),
+		Service:             opts.GetService(),
+		Tag:                 opts.GetTag(),
+		DiscoverAllocations: proto.Bool(opts.GetAllocations()),
+		ReEvalSec:           proto.Int32(opts.GetReEvalSec()),
+	}, l)
+	if err != nil {
+		return nil, err
+	}
+
+	resPath := nomad.ResourceTypes.Services
+	if opts.GetAllocations() {
+		resPath = nomad.ResourceTypes.Allocations
+	}
+
+	// Nomad provider sets last_modified only when something changes, so we
+	// can use a short client refresh interval.
+	clientConf := &client_configpb.ClientConf{
+		Request: &rdspb.ListResourcesRequest{
+			ResourcePath: proto.String(resPath),
+			Filter:       opts.GetFilter(),
+		},
+		ReEvalSec: proto.Int32(5),
+	}
+
+	return client.New(clientConf, func(_ context.Context, req *rdspb.ListResourcesRequest) (*rdspb.ListResourcesResponse, error) {
+		return lister.ListResources(req)
+	}, l)
+}
@@ -0,0 +1,40 @@
+package proto
+
+import (
+	"github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	proto_5 "github.com/cloudprober/cloudprober/internal/rds/proto"
+)
+
+#TargetsConf: {
+	// Nomad HTTP API address, e.g. "http://nomad.service:4646". If not
+	// specified, NOMAD_ADDR environment variable is used, and if that's not set
+	// either, "http://127.0.0.1:4646".
+	address?: string @protobuf(1,string)
+
+	// ACL token. If not specified, NOMAD_TOKEN environment variable is used.
+	token?: string @protobuf(2,string)
+
+	// TLS config to talk to the Nomad API.
+	tlsConfig?: proto.#TLSConfig @protobuf(3,.cloudprober.tlsconfig.TLSConfig,name=tls_config)
+
+	// Namespaces to probe services (or allocations) in. If not specified, all
+	// namespaces are used.
+	namespace?: [...string] @protobuf(4,string)
+
+	// Services to probe. If not specified, all services are probed.
+	service?: [...string] @protobuf(5,string)
+
+	// Only probe services that have all these tags.
+	tag?: [...string] @protobuf(6,string)
+
+	// Probe running allocations, instead of services. Each allocation port
+	// becomes a target.
+	allocations?: bool @protobuf(7,bool,"default=false")
+
+	// Filters to further narrow down the targets. Supported filters: name,
+	// namespace, service, job, tag, labels.<key>.
+	filter?: [...proto_5.#Filter] @protobuf(8,.cloudprober.rds.Filter)
+
+	// How often to refresh the targets from the Nomad API.
+	reEvalSec?: int32 @protobuf(9,int32,name=re_eval_sec,"default=30")
+}
@@ -6,6 +6,12 @@ import (
 	proto_5 "github.com/cloudprober/cloudprober/targets/gce/proto"
 	proto_A "github.com/cloudprober/cloudprober/targets/file/proto"
 	proto_8 "github.com/cloudprober/cloudprober/targets/lameduck/proto"
+	proto_C "github.com/cloudprober/cloudprober/targets/consul/proto"
+	proto_D "github.com/cloudprober/cloudprober/targets/docker/proto"
+	proto_E "github.com/cloudprober/cloudprober/targets/nomad/proto"
+	proto_F "github.com/cloudprober/cloudprober/targets/dns/proto"
+	proto_G "github.com/cloudprober/cloudprober/targets/tailscale/proto"
+	proto_H "github.com/cloudprober/cloudprober/targets/kv/proto"
 )
 
 #RDSTargets: {
@@ -32,6 +38,18 @@ import (
 
 	// IP config to specify the IP address to pick for a resource.
 	ipConfig?: proto_1.#IPConfig @protobuf(4,rds.IPConfig,name=ip_config)
+
+	// Receive resource changes from the RDS server over a stream, instead of
+	// polling it periodically. This reduces the load on the RDS server and
+	// propagates changes faster, especially for large fleets. If the server
+	// doesn't support it, we fall back to polling.
+	watch?: bool @protobuf(5,bool)
+
+	// How long the resources remain fresh in the client cache, after which
+	// they are considered stale (see rds.ClientConf.cache_ttl_sec). If not
+	// specified, provider's rds_cache_ttl_sec from the global targets options is
+	// used.
+	cacheTtlSec?: int32 @protobuf(6,int32,name=cache_ttl_sec)
 }
 
 #K8sTargets: {
@@ -44,12 +62,20 @@ import (
 	//   labelSelector: "k8s-app"       # label k8s-app exists
 	//   labelSelector: "role=frontend" # label role=frontend
 	//   labelSelector: "!canary"       # canary label doesn't exist
+	//   labelSelector: "env in (prod,staging)"
 	labelSelector?: [...string] @protobuf(2,string)
+
+	// fieldSelector uses the same format as kubernetes API calls.
+	// Example:
+	//   fieldSelector: "metadata.name!=kubernetes"
+	fieldSelector?: [...string] @protobuf(11,string)
 	// Which resources to target. If value is not empty (""), we use it as a
 	// regex for resource names.
 	// Example:
 	//   services: ""             // All services.
 	//   endpoints: ".*-service"  // Endpoints ending with "service".
+	//   endpointslices: "web"    // EndpointSlices for the "web" service.
+	//   httproutes: ""           // All HTTPRoutes.
 	{} | {
 		services: string @protobuf(3,string)
 	} | {
@@ -58,6 +84,14 @@ import (
 		ingresses: string @protobuf(5,string)
 	} | {
 		pods: string @protobuf(6,string)
+	} | {
+		// EndpointSlices are matched by the service name, and only the ready
+		// endpoints are returned, same as what kube-proxy routes to.
+		endpointslices: string @protobuf(7,string)
+	} | {
+		// Gateway API HTTPRoutes. Similar to ingresses, there is one target per
+		// hostname and path.
+		httproutes: string @protobuf(8,string)
 	}
 
 	// portFilter can be used to filter resources by port name. This is useful
@@ -155,6 +189,57 @@ import (
 		//   services: ""
 		// }
 		k8s: #K8sTargets @protobuf(6,K8sTargets)
+	} | {
+		// Consul targets: service instances discovered from the Consul catalog.
+		// Example:
+		// consul_targets {
+		//   address: "http://consul.service:8500"
+		//   service: "web"
+		//   tag: "prod"
+		// }
+		consulTargets: proto_C.#TargetsConf @protobuf(7,consul.TargetsConf,name=consul_targets)
+	} | {
+		// Docker targets: containers running on the local Docker host.
+		// Example:
+		// docker_targets {
+		//   label: "cloudprober.probe=true"
+		// }
+		dockerTargets: proto_D.#TargetsConf @protobuf(8,docker.TargetsConf,name=docker_targets)
+	} | {
+		// Nomad targets: services registered with Nomad's native service
+		// discovery, or running allocations.
+		// Example:
+		// nomad_targets {
+		//   address: "http://nomad.service:4646"
+		//   namespace: "prod"
+		//   tag: "http"
+		// }
+		nomadTargets: proto_E.#TargetsConf @protobuf(9,nomad.TargetsConf,name=nomad_targets)
+	} | {
+		// DNS targets: endpoints discovered through DNS SRV records.
+		// Example:
+		// dns_targets {
+		//   srv_name: "_http._tcp.web.service.consul"
+		//   txt_labels: true
+		// }
+		dnsTargets: proto_F.#TargetsConf @protobuf(10,dns.TargetsConf,name=dns_targets)
+	} | {
+		// Tailscale targets: peers in the tailnet, discovered through the local
+		// Tailscale API, or peers from a WireGuard config.
+		// Example:
+		// tailscale_targets {
+		//   tag: "tag:web"
+		// }
+		tailscaleTargets: proto_G.#TargetsConf @protobuf(11,tailscale.TargetsConf,name=tailscale_targets)
+	} | {
+		// Key-value store targets: services that register themselves under a key
+		// prefix in etcd or ZooKeeper.
+		// Example:
+		// kv_targets {
+		//   etcd { endpoint: "http://etcd:2379" }
+		//   prefix: "/services/web/"
+		// }
+		kvTargets: proto_H.#TargetsConf @protobuf(12,kv.TargetsConf,name=kv_targets)
 	} | {
 		// Empty targets to meet the probe definition requirement where there are
 		// actually no targets, for example in case of some external probes.
@@ -186,6 +271,105 @@ import (
 	// configurator) service. This functionality works only if lame_duck_options
 	// are specified.
 	excludeLameducks?: bool @protobuf(22,bool,name=exclude_lameducks,default)
+
+	// Probe only a subset of the targets. This is useful for probes running
+	// against a very large number of targets, where probing all of them in
+	// every cycle is not needed (or not feasible). Sampling is applied after
+	// all other filters, whenever the probe refreshes its targets, not on
+	// every probe cycle. Probes refresh their targets at their own interval,
+	// typically every minute (or every probe interval, if that's longer).
+	// Example:
+	//   sampling {
+	//     max_targets: 100
+	//     weight_label: "weight"
+	//   }
+	sampling?: #Sampling @protobuf(24,Sampling)
+
+	// Don't shard these targets, even if sharding is configured in the global
+	// targets options. Useful for targets that all instances should probe.
+	disableSharding?: bool @protobuf(25,bool,name=disable_sharding)
+
+	// Exclude targets that are currently failing another ("gate") probe. This
+	// is useful to not waste expensive probes (e.g. browser or transaction
+	// probes) on targets that are known to be down. Targets are matched with the
+	// gate probe's targets by name. Health gate is applied only to the probe's
+	// targets, i.e. it's not supported for shared targets definitions.
+	// Example:
+	//   health_gate {
+	//     probe: "ping-vms"
+	//   }
+	healthGate?: #HealthGate @protobuf(26,HealthGate,name=health_gate)
+}
+
+#Sampling: {
+	// Maximum number of targets to select.
+	maxTargets?: int32 @protobuf(1,int32,name=max_targets)
+
+	#Method: {"RANDOM", #enumValue: 0} |
+		{"CONSISTENT_HASH", #enumValue: 1}
+
+	#Method_value: {
+		RANDOM:          0
+		CONSISTENT_HASH: 1
+	}
+	method?: #Method @protobuf(2,Method,"default=RANDOM")
+
+	// Label to get the targets' weights from. Targets with higher weights are
+	// more likely to be selected. Targets without this label, or with an
+	// invalid value for it, get a weight of 1. Targets with weight 0 are never
+	// selected.
+	weightLabel?: string @protobuf(3,string,name=weight_label)
+
+	// Key used for consistent hashing. Different keys select different subsets
+	// of targets, which can be used to spread targets across multiple
+	// cloudprober instances. Default is the hostname.
+	hashKey?: string @protobuf(4,string,name=hash_key)
+
+	// How often to select a new random subset (RANDOM method). Until then, all
+	// the users of the targets, e.g. the probe and the targets status page, see
+	// the same subset. Note that probes start afresh for the newly selected
+	// targets.
+	resampleIntervalSec?: int32 @protobuf(5,int32,name=resample_interval_sec,"default=300")
+}
+
+// Sharding options, to distribute targets across multiple cloudprober
+// instances. Each target is assigned to exactly one of the shards using
+// rendezvous hashing, so that targets are not probed by multiple instances,
+// and changes in the set of shards move only a small fraction of targets.
+//
+// Shards can be specified either statically, using shard_count and
+// shard_index, or dynamically, by discovering cloudprober instances (members)
+// through targets, e.g. kubernetes pods or consul service instances.
+#ShardingOptions: {
+	// Total number of shards.
+	shardCount?: int32 @protobuf(1,int32,name=shard_count)
+
+	// This instance's shard index, in the range [0, shard_count).
+	shardIndex?: int32 @protobuf(2,int32,name=shard_index)
+
+	// Discover members (cloudprober instances) using targets. Member names are
+	// the endpoint names, e.g.:
+	// members {
+	//   k8s {
+	//     namespace: "monitoring"
+	//     pods: "cloudprober-.*"
+	//   }
+	// }
+	members?: #TargetsDef @protobuf(3,TargetsDef)
+
+	// Name of this instance in the members list. Default is the hostname.
+	memberName?: string @protobuf(4,string,name=member_name)
+}
+
+#HealthGate: {
+	// Name of the gate probe.
+	probe?: string @protobuf(1,string)
+
+	// Number of consecutive failed probe cycles after which a target is
+	// considered failing. A target is included again as soon as a gate probe
+	// cycle succeeds for it. Targets without any gate probe results are always
+	// included.
+	failureThreshold?: int32 @protobuf(2,int32,name=failure_threshold,"default=1")
 }
 
 // DummyTargets represent empty targets, which are useful for external
@@ -220,4 +404,22 @@ import (
 	// Lame duck options. If provided, targets module checks for the lame duck
 	// targets and removes them from the targets list.
 	lameDuckOptions?: proto_8.#Options @protobuf(2,lameduck.Options,name=lame_duck_options)
+
+	// Per-provider cache TTL for RDS targets, keyed by the provider name, e.g.
+	// rds_cache_ttl_sec {
+	//   key: "k8s"
+	//   value: 300
+	// }
+	rdsCacheTtlSec?: {
+		[string]: int32
+	} @protobuf(5,map[string]int32,rds_cache_ttl_sec)
+
+	// Shard targets across multiple cloudprober instances. Sharding applies to
+	// all targets, except dummy targets and targets with disable_sharding set.
+	// Example:
+	// sharding {
+	//   shard_count: 3
+	//   shard_index: 0
+	// }
+	sharding?: #ShardingOptions @protobuf(6,ShardingOptions)
 }
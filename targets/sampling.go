@@ -0,0 +1,179 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+)
+
+// sampler selects a weighted subset of endpoints.
+//
+// Both the sampling methods use the same selection scheme: each endpoint gets
+// a score of ln(u)/weight, where u is a number in the range (0,1), and the
+// endpoints with the highest scores are selected. For random sampling, u is a
+// random number (Efraimidis-Spirakis weighted sampling), and for consistent
+// hashing, u is derived from the hash of the endpoint (weighted rendezvous
+// hashing).
+type sampler struct {
+	maxTargets       int
+	consistent       bool
+	weightLabel      string
+	hashKey          string
+	resampleInterval time.Duration
+
+	// Random selection is kept until the resample interval, so that all the
+	// users of the targets see the same subset.
+	mu        sync.Mutex
+	selected  map[string]bool
+	sampledAt time.Time
+}
+
+func newSampler(c *targetspb.Sampling) (*sampler, error) {
+	if c.GetMaxTargets() <= 0 {
+		return nil, fmt.Errorf("invalid sampling max_targets: %d", c.GetMaxTargets())
+	}
+
+	s := &sampler{
+		maxTargets:  int(c.GetMaxTargets()),
+		consistent:  c.GetMethod() == targetspb.Sampling_CONSISTENT_HASH,
+		weightLabel: c.GetWeightLabel(),
+		hashKey:     c.GetHashKey(),
+
+		resampleInterval: time.Duration(c.GetResampleIntervalSec()) * time.Second,
+	}
+
+	if s.consistent && s.hashKey == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname for sampling hash_key: %v", err)
+		}
+		s.hashKey = hostname
+	}
+
+	return s, nil
+}
+
+func (s *sampler) weight(ep *endpoint.Endpoint) float64 {
+	if s.weightLabel == "" {
+		return 1
+	}
+	v, ok := ep.Labels[s.weightLabel]
+	if !ok {
+		return 1
+	}
+	w, err := strconv.ParseFloat(v, 64)
+	if err != nil || w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+		return 1
+	}
+	return w
+}
+
+// unitValue returns a number in the range (0,1) for the given endpoint.
+func (s *sampler) unitValue(ep *endpoint.Endpoint) float64 {
+	if !s.consistent {
+		for {
+			if u := rand.Float64(); u > 0 {
+				return u
+			}
+		}
+	}
+	h := fnv.New64a()
+	h.Write([]byte(s.hashKey + "/" + ep.Key()))
+	return (float64(h.Sum64()>>11) + 0.5) / (1 << 53)
+}
+
+// sample returns up to maxTargets endpoints. Selected endpoints retain their
+// original order. Consistent hashing selects the same endpoints for the same
+// input anyway. Random selection is kept until the resample interval, only the
+// endpoints that have gone away are replaced in between.
+func (s *sampler) sample(eps []endpoint.Endpoint) []endpoint.Endpoint {
+	if s.consistent {
+		return s.selectN(eps, s.maxTargets)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.sampledAt) >= s.resampleInterval {
+		s.selected, s.sampledAt = nil, time.Now()
+	}
+
+	var kept, rest []endpoint.Endpoint
+	for i := range eps {
+		if s.selected[eps[i].Key()] && s.weight(&eps[i]) != 0 && len(kept) < s.maxTargets {
+			kept = append(kept, eps[i])
+		} else {
+			rest = append(rest, eps[i])
+		}
+	}
+
+	s.selected = make(map[string]bool)
+	for _, ep := range append(kept, s.selectN(rest, s.maxTargets-len(kept))...) {
+		s.selected[ep.Key()] = true
+	}
+
+	var result []endpoint.Endpoint
+	for _, ep := range eps {
+		if s.selected[ep.Key()] {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
+
+// selectN returns up to n endpoints, selected as per the sampling method.
+// Selected endpoints retain their original order.
+func (s *sampler) selectN(eps []endpoint.Endpoint, n int) []endpoint.Endpoint {
+	type scored struct {
+		index int
+		score float64
+	}
+
+	candidates := make([]scored, 0, len(eps))
+	for i := range eps {
+		w := s.weight(&eps[i])
+		if w == 0 {
+			continue
+		}
+		candidates = append(candidates, scored{i, math.Log(s.unitValue(&eps[i])) / w})
+	}
+
+	if len(candidates) > n {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].score > candidates[j].score
+		})
+		candidates = candidates[:n]
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].index < candidates[j].index
+		})
+	}
+
+	result := make([]endpoint.Endpoint, len(candidates))
+	for i, c := range candidates {
+		result[i] = eps[c.index]
+	}
+	return result
+}
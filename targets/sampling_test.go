@@ -0,0 +1,166 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSamplingEndpoints(n int) []endpoint.Endpoint {
+	eps := make([]endpoint.Endpoint, n)
+	for i := range eps {
+		eps[i] = endpoint.Endpoint{Name: fmt.Sprintf("ep-%03d", i)}
+	}
+	return eps
+}
+
+func TestSamplerWeight(t *testing.T) {
+	s := &sampler{weightLabel: "weight"}
+	for v, want := range map[string]float64{"": 1, "3": 3, "0.5": 0.5, "0": 0, "-1": 1, "abc": 1, "NaN": 1} {
+		ep := &endpoint.Endpoint{Labels: map[string]string{}}
+		if v != "" {
+			ep.Labels["weight"] = v
+		}
+		assert.Equal(t, want, s.weight(ep), "weight label: %s", v)
+	}
+}
+
+func TestSample(t *testing.T) {
+	eps := testSamplingEndpoints(100)
+
+	for _, method := range []targetspb.Sampling_Method{targetspb.Sampling_RANDOM, targetspb.Sampling_CONSISTENT_HASH} {
+		t.Run(method.String(), func(t *testing.T) {
+			s, err := newSampler(&targetspb.Sampling{
+				MaxTargets: proto.Int32(10),
+				Method:     method.Enum(),
+				HashKey:    proto.String("instance-1"),
+			})
+			assert.NoError(t, err)
+
+			got := s.sample(eps)
+			assert.Len(t, got, 10)
+			for i := 1; i < len(got); i++ {
+				assert.Less(t, got[i-1].Name, got[i].Name, "order not retained")
+			}
+
+			// Fewer targets than max_targets.
+			assert.Equal(t, eps[:5], s.sample(eps[:5]))
+		})
+	}
+}
+
+func TestSampleRandom(t *testing.T) {
+	eps := testSamplingEndpoints(100)
+	s, err := newSampler(&targetspb.Sampling{MaxTargets: proto.Int32(10)})
+	assert.NoError(t, err)
+
+	got := endpoint.NamesFromEndpoints(s.sample(eps))
+	assert.Equal(t, got, endpoint.NamesFromEndpoints(s.sample(eps)), "selection changed before resample interval")
+
+	// A selected endpoint goes away, only that one is replaced.
+	var reduced []endpoint.Endpoint
+	for _, ep := range eps {
+		if ep.Name != got[0] {
+			reduced = append(reduced, ep)
+		}
+	}
+	got2 := endpoint.NamesFromEndpoints(s.sample(reduced))
+	assert.Len(t, got2, 10)
+	assert.NotContains(t, got2, got[0])
+	for _, name := range got[1:] {
+		assert.Contains(t, got2, name)
+	}
+
+	// New selection after the resample interval.
+	s.sampledAt = s.sampledAt.Add(-s.resampleInterval)
+	assert.NotEqual(t, got2, endpoint.NamesFromEndpoints(s.sample(reduced)))
+}
+
+func TestSampleConsistentHash(t *testing.T) {
+	eps := testSamplingEndpoints(100)
+	s := &sampler{maxTargets: 10, consistent: true, hashKey: "instance-1"}
+
+	got := endpoint.NamesFromEndpoints(s.sample(eps))
+	assert.Equal(t, got, endpoint.NamesFromEndpoints(s.sample(eps)), "selection not stable")
+
+	// Removing an unselected endpoint doesn't change the selection.
+	var unselected int
+	for i, ep := range eps {
+		if !contains(got, ep.Name) {
+			unselected = i
+			break
+		}
+	}
+	reduced := append(append([]endpoint.Endpoint{}, eps[:unselected]...), eps[unselected+1:]...)
+	assert.Equal(t, got, endpoint.NamesFromEndpoints(s.sample(reduced)))
+
+	// Different hash keys select different subsets.
+	s2 := &sampler{maxTargets: 10, consistent: true, hashKey: "instance-2"}
+	assert.NotEqual(t, got, endpoint.NamesFromEndpoints(s2.sample(eps)))
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSampleWeighted(t *testing.T) {
+	eps := testSamplingEndpoints(20)
+	for i := range eps {
+		eps[i].Labels = map[string]string{"weight": "1"}
+	}
+	eps[0].Labels["weight"] = "0"
+	eps[1].Labels["weight"] = "100"
+
+	s := &sampler{maxTargets: 2, weightLabel: "weight"}
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		for _, ep := range s.sample(eps) {
+			counts[ep.Name]++
+		}
+	}
+	assert.Zero(t, counts[eps[0].Name], "zero weight target selected")
+	assert.Greater(t, counts[eps[1].Name], 900, "heavy weight target selected only %d times", counts[eps[1].Name])
+}
+
+func TestSamplingTargets(t *testing.T) {
+	_, err := New(&targetspb.TargetsDef{
+		Type:     &targetspb.TargetsDef_HostNames{HostNames: "a,b,c"},
+		Sampling: &targetspb.Sampling{MaxTargets: proto.Int32(0)},
+	}, nil, nil, nil, nil)
+	assert.Error(t, err)
+
+	tgts, err := New(&targetspb.TargetsDef{
+		Type:     &targetspb.TargetsDef_HostNames{HostNames: "a,b,c,d,e"},
+		Regex:    proto.String("[a-d]"),
+		Sampling: &targetspb.Sampling{MaxTargets: proto.Int32(2)},
+	}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	got := tgts.ListEndpoints()
+	assert.Len(t, got, 2)
+	for _, ep := range got {
+		assert.NotEqual(t, "e", ep.Name)
+	}
+}
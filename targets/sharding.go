@@ -0,0 +1,149 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// globalSharder is shared by all targets, as sharding is configured through
+// the global targets options.
+var (
+	globalSharder   *sharder
+	globalSharderMu sync.Mutex
+)
+
+// sharder assigns targets to shards (members) using rendezvous hashing: each
+// target goes to the member with the highest hash(member, target).
+type sharder struct {
+	self          string
+	staticMembers []string
+	members       endpoint.Lister
+	l             *logger.Logger
+}
+
+func newSharder(c *targetspb.ShardingOptions, globalOpts *targetspb.GlobalTargetsOptions, l *logger.Logger) (*sharder, error) {
+	s := &sharder{l: l}
+
+	if c.GetMembers() != nil {
+		if c.ShardCount != nil || c.ShardIndex != nil {
+			return nil, errors.New("sharding: members and shard_count/shard_index are mutually exclusive")
+		}
+		s.self = c.GetMemberName()
+		if s.self == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, fmt.Errorf("sharding: error getting hostname: %v", err)
+			}
+			s.self = hostname
+		}
+
+		// Members themselves are not sharded.
+		membersOpts := proto.Clone(globalOpts).(*targetspb.GlobalTargetsOptions)
+		membersOpts.Sharding = nil
+		members, err := New(c.GetMembers(), nil, membersOpts, l, l)
+		if err != nil {
+			return nil, fmt.Errorf("sharding: error creating members targets: %v", err)
+		}
+		s.members = members
+		return s, nil
+	}
+
+	if c.GetShardCount() <= 0 {
+		return nil, fmt.Errorf("sharding: invalid shard_count: %d", c.GetShardCount())
+	}
+	if c.GetShardIndex() < 0 || c.GetShardIndex() >= c.GetShardCount() {
+		return nil, fmt.Errorf("sharding: shard_index (%d) is not in the range [0, %d)", c.GetShardIndex(), c.GetShardCount())
+	}
+	for i := 0; i < int(c.GetShardCount()); i++ {
+		s.staticMembers = append(s.staticMembers, strconv.Itoa(i))
+	}
+	s.self = strconv.Itoa(int(c.GetShardIndex()))
+	return s, nil
+}
+
+// getSharder returns the global sharder, creating it if required.
+func getSharder(globalOpts *targetspb.GlobalTargetsOptions, l *logger.Logger) (*sharder, error) {
+	globalSharderMu.Lock()
+	defer globalSharderMu.Unlock()
+
+	if globalSharder != nil {
+		return globalSharder, nil
+	}
+
+	s, err := newSharder(globalOpts.GetSharding(), globalOpts, l)
+	if err != nil {
+		return nil, err
+	}
+	globalSharder = s
+	return s, nil
+}
+
+func (s *sharder) memberNames() []string {
+	if s.members == nil {
+		return s.staticMembers
+	}
+	return endpoint.NamesFromEndpoints(s.members.ListEndpoints())
+}
+
+func rendezvousHash(member string, ep *endpoint.Endpoint) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(member + "/" + ep.Name + ":" + strconv.Itoa(ep.Port)))
+	return h.Sum64()
+}
+
+// filter returns the endpoints assigned to this instance's shard. If this
+// instance is not one of the members (e.g. it has not been discovered yet), no
+// endpoints are returned.
+func (s *sharder) filter(eps []endpoint.Endpoint) []endpoint.Endpoint {
+	members := s.memberNames()
+
+	found := false
+	for _, m := range members {
+		if m == s.self {
+			found = true
+			break
+		}
+	}
+	if !found {
+		s.l.Warningf("sharding: this instance (%s) is not in the members list (%v), not probing any targets", s.self, members)
+		return nil
+	}
+
+	var result []endpoint.Endpoint
+	for i := range eps {
+		var owner string
+		var maxHash uint64
+		for _, m := range members {
+			if h := rendezvousHash(m, &eps[i]); owner == "" || h > maxHash {
+				owner, maxHash = m, h
+			}
+		}
+		if owner == s.self {
+			result = append(result, eps[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"testing"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewSharderErrors(t *testing.T) {
+	for _, c := range []*targetspb.ShardingOptions{
+		{},
+		{ShardCount: proto.Int32(3), ShardIndex: proto.Int32(3)},
+		{ShardCount: proto.Int32(3), ShardIndex: proto.Int32(-1)},
+		{ShardCount: proto.Int32(3), Members: &targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: "a,b"}}},
+	} {
+		_, err := newSharder(c, &targetspb.GlobalTargetsOptions{}, &logger.Logger{})
+		assert.Error(t, err, "sharding options: %v", c)
+	}
+}
+
+func TestShardingStatic(t *testing.T) {
+	eps := testSamplingEndpoints(300)
+
+	seen := make(map[string]int)
+	for i := 0; i < 3; i++ {
+		s, err := newSharder(&targetspb.ShardingOptions{ShardCount: proto.Int32(3), ShardIndex: proto.Int32(int32(i))}, nil, &logger.Logger{})
+		assert.NoError(t, err)
+
+		shard := s.filter(eps)
+		assert.Greater(t, len(shard), 50, "shard %d is too small: %d", i, len(shard))
+		for _, ep := range shard {
+			seen[ep.Name]++
+		}
+	}
+
+	// Each target is assigned to exactly one shard.
+	assert.Len(t, seen, len(eps))
+	for name, count := range seen {
+		assert.Equal(t, 1, count, "target %s", name)
+	}
+}
+
+func TestShardingMembers(t *testing.T) {
+	eps := testSamplingEndpoints(100)
+	members := &staticLister{list: endpoint.EndpointsFromNames([]string{"cp-0", "cp-1", "cp-2"})}
+
+	s := &sharder{self: "cp-1", members: members, l: &logger.Logger{}}
+	before := endpoint.NamesFromEndpoints(s.filter(eps))
+	assert.NotEmpty(t, before)
+
+	// A new member takes over targets only from the existing members, i.e.
+	// cp-1 doesn't get any new targets.
+	members.list = endpoint.EndpointsFromNames([]string{"cp-0", "cp-1", "cp-2", "cp-3"})
+	after := endpoint.NamesFromEndpoints(s.filter(eps))
+	assert.Less(t, len(after), len(before))
+	for _, name := range after {
+		assert.Contains(t, before, name)
+	}
+
+	// Not a member.
+	s.self = "cp-9"
+	assert.Empty(t, s.filter(eps))
+}
+
+func TestShardingTargets(t *testing.T) {
+	defer func() { globalSharder = nil }()
+
+	globalOpts := &targetspb.GlobalTargetsOptions{
+		Sharding: &targetspb.ShardingOptions{
+			Members:    &targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: "cp-0,cp-1"}},
+			MemberName: proto.String("cp-0"),
+		},
+	}
+
+	hosts := "a,b,c,d,e,f,g,h,i,j"
+	sharded, err := New(&targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: hosts}}, nil, globalOpts, nil, nil)
+	assert.NoError(t, err)
+	assert.Less(t, len(sharded.ListEndpoints()), 10)
+
+	notSharded, err := New(&targetspb.TargetsDef{
+		Type:            &targetspb.TargetsDef_HostNames{HostNames: hosts},
+		DisableSharding: proto.Bool(true),
+	}, nil, globalOpts, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, notSharded.ListEndpoints(), 10)
+
+	globalOpts.Sharding.MemberName = proto.String("cp-9")
+	globalSharder = nil
+	dummy, err := New(&targetspb.TargetsDef{Type: &targetspb.TargetsDef_DummyTargets{}}, nil, globalOpts, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, dummy.ListEndpoints(), 1)
+
+	sharded, err = New(&targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: hosts}}, nil, globalOpts, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, sharded.ListEndpoints(), "not a member")
+}
@@ -0,0 +1,32 @@
+package proto
+
+import "github.com/cloudprober/cloudprober/internal/rds/proto"
+
+#TargetsConf: {
+	// Tailscale local API address. Default is
+	// "unix:///var/run/tailscale/tailscaled.sock".
+	address?: string @protobuf(1,string)
+
+	// WireGuard config file to read peers from, instead of the Tailscale local
+	// API, e.g. "/etc/wireguard/wg0.conf". Peer names are taken from a
+	// "# Name = <name>" comment in the [Peer] section, and default to the peer's
+	// first allowed IP.
+	wireguardConfig?: string @protobuf(2,string,name=wireguard_config)
+
+	// Only probe peers with these tags, e.g. "tag:web". Not supported with
+	// wireguard_config.
+	tag?: [...string] @protobuf(3,string)
+
+	// Probe offline peers as well.
+	includeOffline?: bool @protobuf(4,bool,name=include_offline,"default=false")
+
+	// Probe this node itself as well.
+	includeSelf?: bool @protobuf(5,bool,name=include_self,"default=false")
+
+	// Filters to further narrow down the targets. Supported filters: name, tag,
+	// os, labels.<key>.
+	filter?: [...proto.#Filter] @protobuf(6,.cloudprober.rds.Filter)
+
+	// How often to refresh the peers list.
+	reEvalSec?: int32 @protobuf(7,int32,name=re_eval_sec,"default=30")
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package tailscale implements targets for the peers of a Tailscale (or plain
+WireGuard) mesh network.
+*/
+package tailscale
+
+import (
+	"context"
+
+	"github.com/cloudprober/cloudprober/internal/rds/client"
+	client_configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/tailscale"
+	tailscale_configpb "github.com/cloudprober/cloudprober/internal/rds/tailscale/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/targets/tailscale/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// New returns new Tailscale targets.
+func New(opts *configpb.TargetsConf, l *logger.Logger) (*client.Client, error) {
+	lister, err := tailscale.New(&tailscale_configpb.ProviderConfig{
+		Address:         proto.String(opts.GetAddress()),
+		WireguardConfig: proto.String(opts.GetWireguardConfig()),
+		Tag:             opts.GetTag(),
+		IncludeOffline:  proto.Bool(opts.GetIncludeOffline()),
+		IncludeSelf:     proto.Bool(opts.GetIncludeSelf()),
+		ReEvalSec:       proto.Int32(opts.GetReEvalSec()),
+	}, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tailscale provider sets last_modified only when peers change, so we
+	// can use a short client refresh interval.
+	clientConf := &client_configpb.ClientConf{
+		Request:   &rdspb.ListResourcesRequest{Filter: opts.GetFilter()},
+		ReEvalSec: proto.Int32(5),
+	}
+
+	return client.New(clientConf, func(_ context.Context, req *rdspb.ListResourcesRequest) (*rdspb.ListResourcesResponse, error) {
+		return lister.ListResources(req)
+	}, l)
+}
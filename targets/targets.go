@@ -23,8 +23,10 @@
 package targets
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"regexp"
 	"strings"
@@ -36,11 +38,18 @@ import (
 	rdsclientpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
 	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/consul"
+	"github.com/cloudprober/cloudprober/targets/dns"
+	"github.com/cloudprober/cloudprober/targets/docker"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"github.com/cloudprober/cloudprober/targets/file"
 	"github.com/cloudprober/cloudprober/targets/gce"
+	"github.com/cloudprober/cloudprober/targets/kv"
+	"github.com/cloudprober/cloudprober/targets/nomad"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
 	dnsRes "github.com/cloudprober/cloudprober/targets/resolver"
+	"github.com/cloudprober/cloudprober/targets/tailscale"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -84,6 +93,12 @@ type Targets interface {
 	endpoint.Resolver
 }
 
+// StalenessExporter is implemented by the targets that cache their resources,
+// to export how stale the cached resources are.
+type StalenessExporter interface {
+	ExportStaleness(ctx context.Context, interval time.Duration, ptype, probeName string, dataChan chan<- *metrics.EventMetrics)
+}
+
 // staticLister is a simple list of hosts that does not change. This corresponds
 // to the "host_names" type in cloudprober/targets/targets.proto.  For
 // example, one could have a probe whose targets are `host_names:
@@ -124,6 +139,9 @@ type targets struct {
 	staticEndpoints []endpoint.Endpoint
 	re              *regexp.Regexp
 	ldLister        endpoint.Lister
+	sharder         *sharder
+	sampler         *sampler
+	closer          io.Closer // Set if lister needs to be closed.
 	l               *logger.Logger
 }
 
@@ -137,6 +155,23 @@ func (t *targets) Resolve(name string, ipVer int) (net.IP, error) {
 	return t.resolver.Resolve(name, ipVer)
 }
 
+// Close releases the resources held by the targets, e.g. the refresh
+// goroutines of the RDS based targets.
+func (t *targets) Close() error {
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer.Close()
+}
+
+// ExportStaleness exports the staleness of the targets' cache, if the core
+// lister caches its resources, e.g. RDS targets with cache TTL.
+func (t *targets) ExportStaleness(ctx context.Context, interval time.Duration, ptype, probeName string, dataChan chan<- *metrics.EventMetrics) {
+	if se, ok := t.lister.(StalenessExporter); ok {
+		se.ExportStaleness(ctx, interval, ptype, probeName, dataChan)
+	}
+}
+
 func (t *targets) lameduckMap() map[string]endpoint.Endpoint {
 	lameDuckMap := make(map[string]endpoint.Endpoint)
 	if t.ldLister != nil {
@@ -177,7 +212,8 @@ func (t *targets) includeInResult(ep endpoint.Endpoint, ldMap map[string]endpoin
 // consists of a name and associated metadata like port and target labels.
 //
 // It gets the list of targets from the configured targets type, filters them
-// by the configured regex, excludes lame ducks and returns the resultant list.
+// by the configured regex, excludes lame ducks, keeps only this instance's
+// shard and samples them (if configured), and returns the resultant list.
 //
 // This method should be concurrency safe as it doesn't modify any shared
 // variables and doesn't rely on multiple accesses to same variable being
@@ -208,6 +244,16 @@ func (t *targets) ListEndpoints() []endpoint.Endpoint {
 		list = result
 	}
 
+	if t.sharder != nil {
+		list = t.sharder.filter(list)
+	}
+
+	// Sampler keeps its selection across calls, so the probe and the other
+	// listers (e.g. targets status page) see the same subset.
+	if t.sampler != nil {
+		list = t.sampler.sample(list)
+	}
+
 	return list
 }
 
@@ -241,6 +287,13 @@ func baseTargets(targetsDef *targetspb.TargetsDef, ldLister endpoint.Lister, l *
 		}
 	}
 
+	if targetsDef.GetSampling() != nil {
+		var err error
+		if tgts.sampler, err = newSampler(targetsDef.GetSampling()); err != nil {
+			return nil, err
+		}
+	}
+
 	return tgts, nil
 }
 
@@ -294,6 +347,11 @@ func rdsClientConf(pb *targetspb.RDSTargets, globalOpts *targetspb.GlobalTargets
 	}
 	provider := toks[0]
 
+	cacheTTLSec := globalOpts.GetRdsCacheTtlSec()[provider]
+	if pb.CacheTtlSec != nil {
+		cacheTTLSec = pb.GetCacheTtlSec()
+	}
+
 	return listResourcesFunc, &rdsclientpb.ClientConf{
 		ServerOptions: serverOpts,
 		Request: &rdspb.ListResourcesRequest{
@@ -302,6 +360,8 @@ func rdsClientConf(pb *targetspb.RDSTargets, globalOpts *targetspb.GlobalTargets
 			Filter:       pb.GetFilter(),
 			IpConfig:     pb.GetIpConfig(),
 		},
+		Watch:       proto.Bool(pb.GetWatch()),
+		CacheTtlSec: proto.Int32(cacheTTLSec),
 	}, nil
 }
 
@@ -367,6 +427,48 @@ func New(targetsDef *targetspb.TargetsDef, ldLister endpoint.Lister, globalOpts
 		}
 		t.lister, t.resolver = ft, ft
 
+	case *targetspb.TargetsDef_ConsulTargets:
+		ct, err := consul.New(targetsDef.GetConsulTargets(), l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating Consul targets: %v", err)
+		}
+		t.lister, t.resolver = ct, ct
+
+	case *targetspb.TargetsDef_DockerTargets:
+		dt, err := docker.New(targetsDef.GetDockerTargets(), l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating Docker targets: %v", err)
+		}
+		t.lister, t.resolver = dt, dt
+
+	case *targetspb.TargetsDef_NomadTargets:
+		nt, err := nomad.New(targetsDef.GetNomadTargets(), l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating Nomad targets: %v", err)
+		}
+		t.lister, t.resolver = nt, nt
+
+	case *targetspb.TargetsDef_DnsTargets:
+		dt, err := dns.New(targetsDef.GetDnsTargets(), globalResolver, l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating DNS targets: %v", err)
+		}
+		t.lister, t.resolver = dt, dt
+
+	case *targetspb.TargetsDef_TailscaleTargets:
+		tt, err := tailscale.New(targetsDef.GetTailscaleTargets(), l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating Tailscale targets: %v", err)
+		}
+		t.lister, t.resolver = tt, tt
+
+	case *targetspb.TargetsDef_KvTargets:
+		kt, err := kv.New(targetsDef.GetKvTargets(), l)
+		if err != nil {
+			return nil, fmt.Errorf("target.New(): error creating key-value store targets: %v", err)
+		}
+		t.lister, t.resolver = kt, kt
+
 	case *targetspb.TargetsDef_K8S:
 		kt, err := k8sTargets(targetsDef.GetK8S(), l)
 		if err != nil {
@@ -393,6 +495,18 @@ func New(targetsDef *targetspb.TargetsDef, ldLister endpoint.Lister, globalOpts
 		return nil, fmt.Errorf("targets.New(): no targets type specified and no static endpoints")
 	}
 
+	// Shared targets are not owned by these targets, they are never closed.
+	if _, shared := targetsDef.Type.(*targetspb.TargetsDef_SharedTargets); !shared {
+		t.closer, _ = t.lister.(io.Closer)
+	}
+
+	_, isDummy := targetsDef.Type.(*targetspb.TargetsDef_DummyTargets)
+	if globalOpts.GetSharding() != nil && !targetsDef.GetDisableSharding() && !isDummy {
+		if t.sharder, err = getSharder(globalOpts, globalLogger); err != nil {
+			return nil, fmt.Errorf("targets.New(): %v", err)
+		}
+	}
+
 	return t, nil
 }
 
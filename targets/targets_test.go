@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -26,6 +28,7 @@ import (
 	rdsclientpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	filepb "github.com/cloudprober/cloudprober/targets/file/proto"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
 	testdatapb "github.com/cloudprober/cloudprober/targets/testdata"
 	"github.com/stretchr/testify/assert"
@@ -287,6 +290,35 @@ func TestRDSClientConf(t *testing.T) {
 	}
 }
 
+func TestRDSClientConfCacheTTL(t *testing.T) {
+	globalOpts := &targetspb.GlobalTargetsOptions{
+		RdsServerAddress: proto.String("test-global-addr"),
+		RdsCacheTtlSec:   map[string]int32{"k8s": 300},
+	}
+
+	tests := []struct {
+		resourcePath string
+		cacheTTLSec  *int32
+		want         int32
+	}{
+		{resourcePath: "k8s://pods", want: 300},
+		{resourcePath: "k8s://pods", cacheTTLSec: proto.Int32(60), want: 60},
+		{resourcePath: "gcp://gce_instances/p1", want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s:%d", test.resourcePath, test.want), func(t *testing.T) {
+			pb := &targetspb.RDSTargets{
+				ResourcePath: proto.String(test.resourcePath),
+				CacheTtlSec:  test.cacheTTLSec,
+			}
+			_, cc, err := rdsClientConf(pb, globalOpts, &logger.Logger{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, cc.GetCacheTtlSec())
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -340,3 +372,29 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetsClose(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "targets.json")
+	if err := os.WriteFile(f, []byte(`{"resource": [{"name": "host1"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tgts, err := New(&targetspb.TargetsDef{
+		Type: &targetspb.TargetsDef_FileTargets{FileTargets: &filepb.TargetsConf{FilePath: proto.String(f)}},
+	}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error creating file targets: %v", err)
+	}
+	assert.NotNil(t, tgts.(*targets).closer, "file targets should be closed")
+	assert.NoError(t, tgts.(*targets).Close())
+
+	// Shared targets are never closed through the targets that use them.
+	SetSharedTargets("shared_file_targets", tgts)
+	tgts, err = New(&targetspb.TargetsDef{
+		Type: &targetspb.TargetsDef_SharedTargets{SharedTargets: "shared_file_targets"},
+	}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error creating targets from shared targets: %v", err)
+	}
+	assert.Nil(t, tgts.(*targets).closer)
+}
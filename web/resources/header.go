@@ -33,7 +33,7 @@ var t = template.Must(template.New("header").Parse(`
   <b>Started</b>: {{.StartTime}} -- up {{.Uptime}}<br/>
   <b>Version</b>: {{.Version}}<br>
   <b>Built at</b>: {{.BuiltAt}}<br>
-  <b>Other Links</b>: <a href="/status">/status</a>, <a href="/config-running">/config</a> (<a href="/config-parsed">parsed</a> | <a href="/config">raw</a>), <a href="/alerts">/alerts</a>, <a href="/health">/health</a><br>
+  <b>Other Links</b>: <a href="/status">/status</a>, <a href="/targets">/targets</a>, <a href="/config-running">/config</a> (<a href="/config">expanded</a> | <a href="/config-parsed">parsed</a> | <a href="/config-raw">raw</a>), <a href="/alerts">/alerts</a> (<a href="/alerts/history">history</a>), <a href="/health">/health</a><br>
 </div>
 `))
 
@@ -0,0 +1,212 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/prober"
+	"github.com/cloudprober/cloudprober/web/resources"
+)
+
+var targetsTmpl = template.Must(template.New("targets").Funcs(template.FuncMap{
+	"labels": formatLabels,
+	"since": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return time.Since(t).Truncate(time.Second).String() + " ago"
+	},
+}).Parse(`
+<h3>Targets</h3>
+<form method="GET">
+  Probe: <select name="probe">
+    <option value="">All</option>
+    {{range .ProbeNames}}<option value="{{.}}" {{if eq . $.Query.Probe}}selected{{end}}>{{.}}</option>{{end}}
+  </select>
+  Target: <input type="text" name="target" value="{{.Query.Target}}" placeholder="substring">
+  Label: <input type="text" name="label" value="{{.Query.Label}}" placeholder="key=value">
+  Status: <select name="status">
+    {{range .StatusOptions}}<option value="{{.}}" {{if eq . $.Query.Status}}selected{{end}}>{{if .}}{{.}}{{else}}All{{end}}</option>{{end}}
+  </select>
+  Sort by: <select name="sort">
+    {{range .SortOptions}}<option value="{{.}}" {{if eq . $.Query.Sort}}selected{{end}}>{{.}}</option>{{end}}
+  </select>
+  <input type="submit" value="Apply">
+</form>
+
+{{range .Probes}}
+<h4>{{.Name}} ({{len .Targets}} targets{{if .Unhealthy}}, <span class="unhealthy">{{.Unhealthy}} unhealthy</span>{{end}})</h4>
+<table class="status-list">
+  <tr><th>Target</th><th>Status</th><th>Consecutive Failures</th><th>Last Latency</th><th>Last Updated</th><th>Labels</th></tr>
+  {{range .Targets}}
+  <tr>
+    <td>{{.Target}}</td>
+    {{if not .Known}}<td class="greyed">unknown</td>{{else if .Healthy}}<td class="healthy">healthy</td>{{else}}<td class="unhealthy">unhealthy</td>{{end}}
+    <td>{{.ConsecutiveFailures}}</td>
+    <td>{{if .Known}}{{.LastLatency}}{{else}}-{{end}}</td>
+    <td>{{since .LastUpdated}}</td>
+    <td>{{labels .Labels}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+<p>No targets found.</p>
+{{end}}
+`))
+
+var (
+	targetsStatusOptions = []string{"", "healthy", "unhealthy", "unknown"}
+	targetsSortOptions   = []string{"target", "failures", "latency", "updated"}
+)
+
+// targetsQuery is the targets page query: filters and sort order.
+type targetsQuery struct {
+	Probe, Target, Label, Status, Sort string
+}
+
+func parseTargetsQuery(q url.Values) targetsQuery {
+	tq := targetsQuery{
+		Probe:  q.Get("probe"),
+		Target: q.Get("target"),
+		Label:  q.Get("label"),
+		Status: q.Get("status"),
+		Sort:   q.Get("sort"),
+	}
+	if tq.Sort == "" {
+		tq.Sort = "target"
+	}
+	return tq
+}
+
+func formatLabels(labels map[string]string) string {
+	var parts []string
+	for k, v := range labels {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func (tq targetsQuery) match(ts *prober.TargetStatus) bool {
+	if tq.Probe != "" && ts.Probe != tq.Probe {
+		return false
+	}
+	if tq.Target != "" && !strings.Contains(ts.Target, tq.Target) {
+		return false
+	}
+	if tq.Label != "" {
+		// Label filter is either key=value, or just key.
+		key, value, hasValue := strings.Cut(tq.Label, "=")
+		v, ok := ts.Labels[key]
+		if !ok || (hasValue && v != value) {
+			return false
+		}
+	}
+	switch tq.Status {
+	case "healthy":
+		return ts.Known && ts.Healthy
+	case "unhealthy":
+		return ts.Known && !ts.Healthy
+	case "unknown":
+		return !ts.Known
+	}
+	return true
+}
+
+// less orders the targets by the sort key, with the worst targets first.
+func (tq targetsQuery) less(a, b *prober.TargetStatus) bool {
+	switch tq.Sort {
+	case "failures":
+		if a.ConsecutiveFailures != b.ConsecutiveFailures {
+			return a.ConsecutiveFailures > b.ConsecutiveFailures
+		}
+	case "latency":
+		if a.LastLatency != b.LastLatency {
+			return a.LastLatency > b.LastLatency
+		}
+	case "updated":
+		if !a.LastUpdated.Equal(b.LastUpdated) {
+			return a.LastUpdated.Before(b.LastUpdated)
+		}
+	}
+	return a.Target < b.Target
+}
+
+type probeTargets struct {
+	Name      string
+	Targets   []*prober.TargetStatus
+	Unhealthy int
+}
+
+// groupTargets filters and sorts the targets, and groups them by probe.
+func groupTargets(targets []*prober.TargetStatus, tq targetsQuery) []*probeTargets {
+	var result []*probeTargets
+	byProbe := make(map[string]*probeTargets)
+	for _, ts := range targets {
+		if !tq.match(ts) {
+			continue
+		}
+		pt := byProbe[ts.Probe]
+		if pt == nil {
+			pt = &probeTargets{Name: ts.Probe}
+			byProbe[ts.Probe] = pt
+			result = append(result, pt)
+		}
+		pt.Targets = append(pt.Targets, ts)
+		if ts.Known && !ts.Healthy {
+			pt.Unhealthy++
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	for _, pt := range result {
+		sort.SliceStable(pt.Targets, func(i, j int) bool { return tq.less(pt.Targets[i], pt.Targets[j]) })
+	}
+	return result
+}
+
+// targetsPage returns the targets status page.
+func targetsPage(pr *prober.Prober, q url.Values) string {
+	targets := pr.TargetsStatus()
+	tq := parseTargetsQuery(q)
+
+	var probeNames []string
+	for _, ts := range targets {
+		if len(probeNames) == 0 || probeNames[len(probeNames)-1] != ts.Probe {
+			probeNames = append(probeNames, ts.Probe)
+		}
+	}
+
+	body := execTmpl(targetsTmpl, struct {
+		Query         targetsQuery
+		ProbeNames    []string
+		StatusOptions []string
+		SortOptions   []string
+		Probes        []*probeTargets
+	}{
+		Query:         tq,
+		ProbeNames:    probeNames,
+		StatusOptions: targetsStatusOptions,
+		SortOptions:   targetsSortOptions,
+		Probes:        groupTargets(targets, tq),
+	})
+	return fmt.Sprintf(htmlTmpl, resources.Header(), body)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/prober"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupTargets(t *testing.T) {
+	targets := []*prober.TargetStatus{
+		{Probe: "p1", Target: "web-1", Known: true, Healthy: true, LastLatency: 5 * time.Millisecond, Labels: map[string]string{"zone": "a"}},
+		{Probe: "p1", Target: "web-2", Known: true, ConsecutiveFailures: 3, LastLatency: 2 * time.Millisecond, Labels: map[string]string{"zone": "b"}},
+		{Probe: "p1", Target: "db-1", Known: true, ConsecutiveFailures: 1, Labels: map[string]string{"zone": "a"}},
+		{Probe: "p2", Target: "web-1", Labels: map[string]string{"zone": "a", "env": "prod"}},
+	}
+
+	group := func(query string) map[string][]string {
+		t.Helper()
+		q, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatalf("Error parsing query %s: %v", query, err)
+		}
+		result := make(map[string][]string)
+		for _, pt := range groupTargets(targets, parseTargetsQuery(q)) {
+			for _, ts := range pt.Targets {
+				result[pt.Name] = append(result[pt.Name], ts.Target)
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		query string
+		want  map[string][]string
+	}{
+		{query: "", want: map[string][]string{"p1": {"db-1", "web-1", "web-2"}, "p2": {"web-1"}}},
+		{query: "probe=p2", want: map[string][]string{"p2": {"web-1"}}},
+		{query: "target=web", want: map[string][]string{"p1": {"web-1", "web-2"}, "p2": {"web-1"}}},
+		{query: "label=zone%3Da", want: map[string][]string{"p1": {"db-1", "web-1"}, "p2": {"web-1"}}},
+		{query: "label=env", want: map[string][]string{"p2": {"web-1"}}},
+		{query: "status=unhealthy", want: map[string][]string{"p1": {"db-1", "web-2"}}},
+		{query: "status=unknown", want: map[string][]string{"p2": {"web-1"}}},
+		{query: "probe=p1&sort=failures", want: map[string][]string{"p1": {"web-2", "db-1", "web-1"}}},
+		{query: "probe=p1&sort=latency", want: map[string][]string{"p1": {"web-1", "web-2", "db-1"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.want, group(test.query))
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	assert.Equal(t, "env=prod, zone=a", formatLabels(map[string]string{"zone": "a", "env": "prod"}))
+	assert.Equal(t, "", formatLabels(nil))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 
 	"github.com/cloudprober/cloudprober"
 	"github.com/cloudprober/cloudprober/config"
@@ -91,6 +92,14 @@ func runningConfig() string {
 	return fmt.Sprintf(htmlTmpl, resources.Header(), statusBuf.String())
 }
 
+func alertsHistory(q url.Values) string {
+	history, err := alerting.HistoryHTML(q)
+	if err != nil {
+		return fmt.Sprintf(htmlTmpl, err.Error())
+	}
+	return fmt.Sprintf(htmlTmpl, resources.Header(), history)
+}
+
 func alertsState() string {
 	status, err := alerting.StatusHTML()
 	if err != nil {
@@ -99,40 +108,87 @@ func alertsState() string {
 	return fmt.Sprintf(htmlTmpl, resources.Header(), status)
 }
 
+// expandedConfigHandler serves the running config, with the secrets
+// redacted. Output format can be set using the "format" parameter: textpb
+// (default), yaml, or json.
+func expandedConfigHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "textpb"
+	}
+	b, err := config.FormatConfig(cloudprober.GetRedactedConfig(), format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Write(b)
+}
+
 // Init initializes cloudprober web interface handler.
 func Init() error {
 	srvMux := runconfig.DefaultHTTPServeMux()
-	for _, url := range []string{"/config", "/config-running", "/static/"} {
+	for _, url := range []string{"/config", "/config-raw", "/config-running", "/alerts/silences", "/alerts/ack", "/alerts/ack/pagerduty", "/alerts/history", "/alerts/history.json", "/probes/run", "/probe", "/targets", "/static/"} {
 		if webutils.IsHandled(srvMux, url) {
 			return fmt.Errorf("url %s is already handled", url)
 		}
 	}
 
-	srvMux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+	srvMux.HandleFunc("/config", expandedConfigHandler)
+	srvMux.HandleFunc("/config-raw", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, cloudprober.GetRawConfig())
 	})
 
-	parsedConfig := cloudprober.GetParsedConfig()
 	srvMux.HandleFunc("/config-parsed", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, cloudprober.GetParsedConfig())
 	})
 
-	var configRunning string
-	if !config.EnvRegex.MatchString(parsedConfig) {
-		configRunning = runningConfig()
-	} else {
-		configRunning = `
+	// Running config changes on config reloads, so it's computed for every
+	// request.
+	srvMux.HandleFunc("/config-running", func(w http.ResponseWriter, r *http.Request) {
+		if config.EnvRegex.MatchString(cloudprober.GetParsedConfig()) {
+			fmt.Fprint(w, `
 		<p>Config contains secrets. /config-running is not available.<br>
 		Visit <a href=/config-parsed>/config-parsed</a> to see the config.<p>
-		`
-	}
-	srvMux.HandleFunc("/config-running", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprint(w, configRunning)
+		`)
+			return
+		}
+		fmt.Fprint(w, runningConfig())
 	})
 
 	srvMux.HandleFunc("/alerts", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, alertsState())
 	})
+	srvMux.HandleFunc("/alerts/silences", alerting.SilencesHandler)
+	srvMux.HandleFunc("/alerts/ack", alerting.AckHandler)
+	srvMux.HandleFunc("/alerts/ack/pagerduty", alerting.PagerDutyAckHandler)
+	srvMux.HandleFunc("/alerts/history", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, alertsHistory(r.URL.Query()))
+	})
+	srvMux.HandleFunc("/alerts/history.json", alerting.HistoryHandler)
+	srvMux.HandleFunc("/probes/run", func(w http.ResponseWriter, r *http.Request) {
+		pr := cloudprober.GetProber()
+		if pr == nil {
+			http.Error(w, "prober is not initialized", http.StatusServiceUnavailable)
+			return
+		}
+		pr.RunProbeHandler(w, r)
+	})
+	srvMux.HandleFunc("/probe", func(w http.ResponseWriter, r *http.Request) {
+		pr := cloudprober.GetProber()
+		if pr == nil {
+			http.Error(w, "prober is not initialized", http.StatusServiceUnavailable)
+			return
+		}
+		pr.BlackboxProbeHandler(w, r)
+	})
+	srvMux.HandleFunc("/targets", func(w http.ResponseWriter, r *http.Request) {
+		pr := cloudprober.GetProber()
+		if pr == nil {
+			http.Error(w, "prober is not initialized", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, targetsPage(pr, r.URL.Query()))
+	})
 	srvMux.Handle("/static/", http.FileServer(http.FS(content)))
 	return nil
 }
@@ -0,0 +1,334 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/web/webauth/proto"
+)
+
+// Minimum time between key refreshes triggered by unknown key IDs.
+const minKeysRefreshInterval = time.Minute
+
+// oidcVerifier verifies OIDC ID tokens and maps them to roles.
+type oidcVerifier struct {
+	c          *configpb.OIDCConfig
+	issuer     string
+	audiences  map[string]bool
+	adminUsers map[string]bool
+	readUsers  map[string]bool
+	client     *http.Client
+	l          *logger.Logger
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+}
+
+func newOIDCVerifier(c *configpb.OIDCConfig, l *logger.Logger) (*oidcVerifier, error) {
+	if c.GetIssuerUrl() == "" {
+		return nil, errors.New("webauth: OIDC issuer_url is required")
+	}
+	if len(c.GetAudience()) == 0 {
+		return nil, errors.New("webauth: at least one OIDC audience is required")
+	}
+
+	v := &oidcVerifier{
+		c:          c,
+		issuer:     strings.TrimSuffix(c.GetIssuerUrl(), "/"),
+		audiences:  make(map[string]bool),
+		adminUsers: make(map[string]bool),
+		readUsers:  make(map[string]bool),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		l:          l,
+	}
+	for _, aud := range c.GetAudience() {
+		v.audiences[aud] = true
+	}
+	for _, u := range c.GetAdminUser() {
+		v.adminUsers[u] = true
+	}
+	for _, u := range c.GetReadOnlyUser() {
+		v.readUsers[u] = true
+	}
+	return v, nil
+}
+
+func (v *oidcVerifier) getJSON(url string, out interface{}) error {
+	resp, err := v.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+}
+
+// refreshKeys fetches the issuer's signing keys, discovering the keys URL
+// from the issuer's OpenID configuration. It should be called with v.mu held.
+func (v *oidcVerifier) refreshKeys() error {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return fmt.Errorf("error fetching OpenID configuration: %v", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != v.issuer {
+		return fmt.Errorf("issuer mismatch in OpenID configuration: %s", discovery.Issuer)
+	}
+
+	var jwks struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := v.getJSON(discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("error fetching signing keys: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range jwks.Keys {
+		pk, err := k.publicKey()
+		if err != nil {
+			v.l.Warningf("webauth: skipping OIDC signing key %s: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = pk
+	}
+	v.keys, v.keysFetched = keys, time.Now()
+	return nil
+}
+
+func (v *oidcVerifier) key(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	refreshInterval := time.Duration(v.c.GetKeysRefreshIntervalSec()) * time.Second
+	stale := time.Since(v.keysFetched) > refreshInterval
+	if v.keys[kid] == nil && time.Since(v.keysFetched) > minKeysRefreshInterval {
+		stale = true
+	}
+	if stale {
+		if err := v.refreshKeys(); err != nil {
+			if v.keys[kid] == nil {
+				return nil, err
+			}
+			v.l.Warningf("webauth: error refreshing OIDC signing keys, using the old keys: %v", err)
+		}
+	}
+
+	if v.keys[kid] == nil {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return v.keys[kid], nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch alg {
+	case "RS256":
+		pk, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("RS256 token with a non-RSA key")
+		}
+		return rsa.VerifyPKCS1v15(pk, crypto.SHA256, digest[:], sig)
+	case "ES256":
+		pk, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(sig) != 64 {
+			return errors.New("invalid ES256 signature or key")
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pk, digest[:], r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signing algorithm: %s", alg)
+}
+
+// audience is the "aud" claim, which can be a string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(a))
+}
+
+// boolClaim is a boolean claim. Some issuers encode boolean claims as
+// strings, e.g. "email_verified": "true".
+type boolClaim bool
+
+func (bc *boolClaim) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*bc = boolClaim(v)
+	case string:
+		*bc = boolClaim(v == "true")
+	}
+	return nil
+}
+
+type claims struct {
+	Issuer        string    `json:"iss"`
+	Audience      audience  `json:"aud"`
+	Expiry        int64     `json:"exp"`
+	NotBefore     int64     `json:"nbf"`
+	Subject       string    `json:"sub"`
+	Email         string    `json:"email"`
+	EmailVerified boolClaim `json:"email_verified"`
+}
+
+// verify verifies the ID token and returns the role of its user.
+func (v *oidcVerifier) verify(token string) (configpb.Role, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return -1, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return -1, fmt.Errorf("malformed token header: %v", err)
+	}
+	if err := json.Unmarshal(b, &header); err != nil {
+		return -1, fmt.Errorf("malformed token header: %v", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return -1, fmt.Errorf("malformed token signature: %v", err)
+	}
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return -1, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return -1, err
+	}
+
+	var c claims
+	if b, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return -1, fmt.Errorf("malformed token claims: %v", err)
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return -1, fmt.Errorf("malformed token claims: %v", err)
+	}
+
+	now := time.Now().Unix()
+	if strings.TrimSuffix(c.Issuer, "/") != v.issuer {
+		return -1, fmt.Errorf("unexpected issuer: %s", c.Issuer)
+	}
+	if c.Expiry <= now {
+		return -1, errors.New("token expired")
+	}
+	if c.NotBefore > now {
+		return -1, errors.New("token not yet valid")
+	}
+	audOK := false
+	for _, aud := range c.Audience {
+		audOK = audOK || v.audiences[aud]
+	}
+	if !audOK {
+		return -1, fmt.Errorf("unexpected audience: %v", c.Audience)
+	}
+
+	// Unverified emails can be set to anything by the users themselves.
+	user := c.Subject
+	if c.Email != "" && c.EmailVerified {
+		user = c.Email
+	}
+	if v.adminUsers[user] {
+		return configpb.Role_ADMIN, nil
+	}
+	if v.readUsers[user] {
+		return configpb.Role_READ_ONLY, nil
+	}
+	return -1, fmt.Errorf("user %s is not allowed", user)
+}
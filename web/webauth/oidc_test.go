@@ -0,0 +1,205 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/web/webauth/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type testIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	ti := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   ti.URL,
+			"jwks_uri": ti.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	ti.Server = httptest.NewServer(mux)
+	t.Cleanup(ti.Close)
+	return ti
+}
+
+func (ti *testIssuer) token(t *testing.T, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	enc := func(v interface{}) string {
+		b, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := enc(map[string]string{"alg": "RS256", "kid": kid}) + "." + enc(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, ti.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestOIDCVerify(t *testing.T) {
+	ti := newTestIssuer(t)
+	v, err := newOIDCVerifier(&configpb.OIDCConfig{
+		IssuerUrl:    proto.String(ti.URL),
+		Audience:     []string{"cloudprober"},
+		AdminUser:    []string{"admin@example.com"},
+		ReadOnlyUser: []string{"viewer@example.com", "svc-123"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error creating OIDC verifier: %v", err)
+	}
+
+	validClaims := func(user string) map[string]interface{} {
+		return map[string]interface{}{
+			"iss":            ti.URL,
+			"aud":            "cloudprober",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"email":          user,
+			"email_verified": true,
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		token    string
+		wantRole configpb.Role
+		wantErr  bool
+	}{
+		{desc: "admin", token: ti.token(t, "key1", validClaims("admin@example.com")), wantRole: configpb.Role_ADMIN},
+		{desc: "read_only", token: ti.token(t, "key1", validClaims("viewer@example.com")), wantRole: configpb.Role_READ_ONLY},
+		{desc: "unknown_user", token: ti.token(t, "key1", validClaims("other@example.com")), wantErr: true},
+		{
+			desc: "unverified_email",
+			token: ti.token(t, "key1", map[string]interface{}{
+				"iss":            ti.URL,
+				"aud":            "cloudprober",
+				"exp":            time.Now().Add(time.Hour).Unix(),
+				"sub":            "user-456",
+				"email":          "admin@example.com",
+				"email_verified": false,
+			}),
+			wantErr: true,
+		},
+		{
+			desc: "email_verified_string",
+			token: ti.token(t, "key1", map[string]interface{}{
+				"iss":            ti.URL,
+				"aud":            "cloudprober",
+				"exp":            time.Now().Add(time.Hour).Unix(),
+				"email":          "viewer@example.com",
+				"email_verified": "true",
+			}),
+			wantRole: configpb.Role_READ_ONLY,
+		},
+		{
+			desc: "subject_audience_list",
+			token: ti.token(t, "key1", map[string]interface{}{
+				"iss": ti.URL,
+				"aud": []string{"other", "cloudprober"},
+				"exp": time.Now().Add(time.Hour).Unix(),
+				"sub": "svc-123",
+			}),
+			wantRole: configpb.Role_READ_ONLY,
+		},
+		{
+			desc: "expired",
+			token: ti.token(t, "key1", map[string]interface{}{
+				"iss":   ti.URL,
+				"aud":   "cloudprober",
+				"exp":   time.Now().Add(-time.Minute).Unix(),
+				"email": "admin@example.com",
+			}),
+			wantErr: true,
+		},
+		{
+			desc: "wrong_audience",
+			token: ti.token(t, "key1", map[string]interface{}{
+				"iss":   ti.URL,
+				"aud":   "other",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"email": "admin@example.com",
+			}),
+			wantErr: true,
+		},
+		{desc: "unknown_key", token: ti.token(t, "key2", validClaims("admin@example.com")), wantErr: true},
+		{desc: "tampered", token: ti.token(t, "key1", validClaims("viewer@example.com")) + "x", wantErr: true},
+		{desc: "malformed", token: "not-a-jwt", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			role, err := v.verify(test.token)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantRole, role)
+		})
+	}
+}
+
+func TestOIDCVerifyDeniesByDefault(t *testing.T) {
+	ti := newTestIssuer(t)
+	v, err := newOIDCVerifier(&configpb.OIDCConfig{
+		IssuerUrl: proto.String(ti.URL),
+		Audience:  []string{"cloudprober"},
+		AdminUser: []string{"admin@example.com"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error creating OIDC verifier: %v", err)
+	}
+
+	_, err = v.verify(ti.token(t, "key1", map[string]interface{}{
+		"iss": ti.URL,
+		"aud": "cloudprober",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"sub": "user-456",
+	}))
+	assert.Error(t, err, "user not in admin_user or read_only_user")
+}
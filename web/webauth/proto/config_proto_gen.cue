@@ -0,0 +1,87 @@
+package proto
+
+// Role determines what an authenticated client can access.
+#Role: {
+	"READ_ONLY"// Read-only access: status pages, config view, metrics and the JSON APIs.
+	#enumValue: 0
+} | {
+	// Admin access: everything, including the mutating endpoints, e.g. config
+	// reload, running probes on demand, silencing and acknowledging alerts, and
+	// the debug (pprof) handlers.
+	"ADMIN"
+	#enumValue: 1
+}
+
+#Role_value: {
+	READ_ONLY: 0
+	ADMIN:     1
+}
+
+#BearerToken: {
+	// File containing the token. Clients send the token in the
+	// "Authorization: Bearer <token>" header. Token files are re-read every
+	// minute, so tokens can be rotated without restarting cloudprober.
+	tokenFile?: string @protobuf(1,string,name=token_file)
+	role?:      #Role  @protobuf(2,Role,"default=READ_ONLY")
+}
+
+#BasicAuthUser: {
+	username?: string @protobuf(1,string)
+
+	// File containing the user's password. Like token files, password files are
+	// re-read every minute.
+	passwordFile?: string @protobuf(2,string,name=password_file)
+	role?:         #Role  @protobuf(3,Role,"default=READ_ONLY")
+}
+
+// OIDC authentication verifies the OpenID Connect ID tokens (JWTs) sent in
+// the "Authorization: Bearer <token>" header, for example by an
+// authenticating reverse proxy like oauth2-proxy, or by scripts using
+// "gcloud auth print-identity-token". Tokens signed using RS256 and ES256 are
+// supported.
+#OIDCConfig: {
+	// Issuer URL, e.g. https://accounts.google.com. Token signing keys are
+	// discovered using the issuer's /.well-known/openid-configuration.
+	issuerUrl?: string @protobuf(1,string,name=issuer_url)
+
+	// Allowed audiences, usually the OAuth client IDs. Token's "aud" claim
+	// should match one of these.
+	audience?: [...string] @protobuf(2,string)
+
+	// Users with the admin role, matched against the token's "email" claim if
+	// the email is verified ("email_verified" claim), or "sub" claim otherwise.
+	adminUser?: [...string] @protobuf(3,string,name=admin_user)
+
+	// Users with the read-only role. Tokens of the users that are neither admin
+	// nor read-only users are rejected.
+	readOnlyUser?: [...string] @protobuf(4,string,name=read_only_user)
+
+	// How often to refresh the issuer's signing keys. Keys are also refreshed
+	// (at most once a minute) if a token is signed using an unknown key.
+	keysRefreshIntervalSec?: int32 @protobuf(5,int32,name=keys_refresh_interval_sec,"default=3600")
+}
+
+// AuthConfig configures authentication for the default HTTP server, i.e. for
+// the status pages, config view, metrics, and the admin endpoints. If
+// multiple methods are configured, a request is allowed if it passes any of
+// them.
+//
+// Example:
+//   web_auth {
+//     bearer_token {
+//       token_file: "/etc/cloudprober/admin-token"
+//       role: ADMIN
+//     }
+//     basic_auth_user {
+//       username: "oncall"
+//       password_file: "/etc/cloudprober/oncall-password"
+//     }
+//   }
+#AuthConfig: {
+	bearerToken?: [...#BearerToken] @protobuf(1,BearerToken,name=bearer_token)
+	basicAuthUser?: [...#BasicAuthUser] @protobuf(2,BasicAuthUser,name=basic_auth_user)
+	oidc?: #OIDCConfig @protobuf(3,OIDCConfig)
+
+	// URL paths that don't require authentication.
+	unauthenticatedPath?: [...string] @protobuf(4,string,name=unauthenticated_path)
+}
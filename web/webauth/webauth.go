@@ -0,0 +1,189 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webauth implements authentication for cloudprober's default HTTP
+// server, using static bearer tokens, basic auth, or OIDC ID tokens. Clients
+// get either read-only or admin access.
+package webauth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/web/webauth/proto"
+)
+
+const secretReloadInterval = time.Minute
+
+// Paths that never require authentication.
+var defaultUnauthenticatedPaths = []string{"/health"}
+
+// Paths that require admin access, irrespective of the request method. On-demand
+// probe run endpoints send probes to arbitrary targets, even over GET.
+var (
+	adminPaths        = map[string]bool{"/probe": true, "/probes/run": true}
+	adminPathPrefixes = []string{"/debug/pprof/"}
+)
+
+// Authenticator authenticates HTTP requests.
+type Authenticator struct {
+	c               *configpb.AuthConfig
+	basicUsers      map[string]*configpb.BasicAuthUser
+	oidc            *oidcVerifier
+	unauthenticated map[string]bool
+	l               *logger.Logger
+}
+
+func readSecret(fname string) ([]byte, error) {
+	b, err := file.ReadWithCache(fname, secretReloadInterval)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(string(b))), nil
+}
+
+// New returns a new authenticator for the given config.
+func New(c *configpb.AuthConfig, l *logger.Logger) (*Authenticator, error) {
+	if len(c.GetBearerToken()) == 0 && len(c.GetBasicAuthUser()) == 0 && c.GetOidc() == nil {
+		return nil, fmt.Errorf("webauth: auth config doesn't specify any authentication method")
+	}
+
+	a := &Authenticator{
+		c:               c,
+		basicUsers:      make(map[string]*configpb.BasicAuthUser),
+		unauthenticated: make(map[string]bool),
+		l:               l,
+	}
+
+	// Verify early that we can read the token and password files.
+	for _, bt := range c.GetBearerToken() {
+		if _, err := readSecret(bt.GetTokenFile()); err != nil {
+			return nil, fmt.Errorf("webauth: error reading token file (%s): %v", bt.GetTokenFile(), err)
+		}
+	}
+	for _, u := range c.GetBasicAuthUser() {
+		if _, err := readSecret(u.GetPasswordFile()); err != nil {
+			return nil, fmt.Errorf("webauth: error reading password file (%s) for user %s: %v", u.GetPasswordFile(), u.GetUsername(), err)
+		}
+		if a.basicUsers[u.GetUsername()] != nil {
+			return nil, fmt.Errorf("webauth: duplicate basic auth user: %s", u.GetUsername())
+		}
+		a.basicUsers[u.GetUsername()] = u
+	}
+
+	if c.GetOidc() != nil {
+		v, err := newOIDCVerifier(c.GetOidc(), l)
+		if err != nil {
+			return nil, err
+		}
+		a.oidc = v
+	}
+
+	for _, p := range append(defaultUnauthenticatedPaths, c.GetUnauthenticatedPath()...) {
+		a.unauthenticated[p] = true
+	}
+	return a, nil
+}
+
+func secretMatches(fname, secret string) bool {
+	b, err := readSecret(fname)
+	if err != nil || len(b) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(b, []byte(secret)) == 1
+}
+
+func (a *Authenticator) bearerRole(token string) (configpb.Role, bool) {
+	role, ok := configpb.Role(-1), false
+	for _, bt := range a.c.GetBearerToken() {
+		if secretMatches(bt.GetTokenFile(), token) && bt.GetRole() > role {
+			role, ok = bt.GetRole(), true
+		}
+	}
+	if ok || a.oidc == nil {
+		return role, ok
+	}
+
+	role, err := a.oidc.verify(token)
+	if err != nil {
+		a.l.Warningf("webauth: OIDC token verification failed: %v", err)
+		return role, false
+	}
+	return role, true
+}
+
+// authenticate returns the role of the request's client, and false if the
+// request could not be authenticated.
+func (a *Authenticator) authenticate(r *http.Request) (configpb.Role, bool) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		return a.bearerRole(token)
+	}
+
+	if username, password, ok := r.BasicAuth(); ok {
+		if u := a.basicUsers[username]; u != nil && secretMatches(u.GetPasswordFile(), password) {
+			return u.GetRole(), true
+		}
+	}
+	return configpb.Role(-1), false
+}
+
+// requiredRole returns the role required for the request: admin for the
+// mutating (non-GET) requests, the probe run endpoints and the debug handlers,
+// read-only otherwise.
+func requiredRole(r *http.Request) configpb.Role {
+	if adminPaths[r.URL.Path] {
+		return configpb.Role_ADMIN
+	}
+	for _, prefix := range adminPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return configpb.Role_ADMIN
+		}
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return configpb.Role_READ_ONLY
+	}
+	return configpb.Role_ADMIN
+}
+
+// Handler wraps the given handler to require authentication.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.unauthenticated[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		role, ok := a.authenticate(r)
+		if !ok {
+			if len(a.basicUsers) > 0 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="cloudprober", charset="UTF-8"`)
+			}
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		if role < requiredRole(r) {
+			a.l.Warningf("webauth: %s %s from %s requires admin access", r.Method, r.URL.Path, r.RemoteAddr)
+			http.Error(w, "admin access required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
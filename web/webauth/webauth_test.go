@@ -0,0 +1,120 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/web/webauth/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func writeSecret(t *testing.T, name, secret string) string {
+	t.Helper()
+	fname := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(fname, []byte(secret+"\n"), 0600))
+	return fname
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&configpb.AuthConfig{}, nil)
+	assert.Error(t, err, "no auth method")
+
+	_, err = New(&configpb.AuthConfig{
+		BearerToken: []*configpb.BearerToken{{TokenFile: proto.String("/does/not/exist")}},
+	}, nil)
+	assert.Error(t, err, "missing token file")
+
+	_, err = New(&configpb.AuthConfig{
+		Oidc: &configpb.OIDCConfig{IssuerUrl: proto.String("https://accounts.example.com")},
+	}, nil)
+	assert.Error(t, err, "no OIDC audience")
+}
+
+func TestHandler(t *testing.T) {
+	a, err := New(&configpb.AuthConfig{
+		BearerToken: []*configpb.BearerToken{
+			{TokenFile: proto.String(writeSecret(t, "read-token", "r3ad"))},
+			{TokenFile: proto.String(writeSecret(t, "admin-token", "adm1n")), Role: configpb.Role_ADMIN.Enum()},
+		},
+		BasicAuthUser: []*configpb.BasicAuthUser{
+			{Username: proto.String("oncall"), PasswordFile: proto.String(writeSecret(t, "oncall", "pa55"))},
+		},
+		UnauthenticatedPath: []string{"/probestatus"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error creating authenticator: %v", err)
+	}
+
+	h := a.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	}))
+
+	tests := []struct {
+		desc   string
+		method string
+		path   string
+		token  string
+		user   string
+		pass   string
+		want   int
+	}{
+		{desc: "health", path: "/health", want: http.StatusOK},
+		{desc: "unauthenticated_path", path: "/probestatus", want: http.StatusOK},
+		{desc: "no_auth", path: "/config", want: http.StatusUnauthorized},
+		{desc: "invalid_token", path: "/config", token: "r3adx", want: http.StatusUnauthorized},
+		{desc: "read_token", path: "/config", token: "r3ad", want: http.StatusOK},
+		{desc: "read_token_post", method: http.MethodPost, path: "/-/reload", token: "r3ad", want: http.StatusForbidden},
+		{desc: "read_token_pprof", path: "/debug/pprof/heap", token: "r3ad", want: http.StatusForbidden},
+		{desc: "read_token_probe", path: "/probe", token: "r3ad", want: http.StatusForbidden},
+		{desc: "read_token_probes_run", path: "/probes/run", token: "r3ad", want: http.StatusForbidden},
+		{desc: "read_token_probes", path: "/probes", token: "r3ad", want: http.StatusOK},
+		{desc: "admin_token_probe", path: "/probe", token: "adm1n", want: http.StatusOK},
+		{desc: "admin_token_post", method: http.MethodPost, path: "/-/reload", token: "adm1n", want: http.StatusOK},
+		{desc: "admin_token_pprof", path: "/debug/pprof/heap", token: "adm1n", want: http.StatusOK},
+		{desc: "basic_auth", path: "/status", user: "oncall", pass: "pa55", want: http.StatusOK},
+		{desc: "basic_auth_post", method: http.MethodPost, path: "/alerts/silences", user: "oncall", pass: "pa55", want: http.StatusForbidden},
+		{desc: "basic_auth_wrong_password", path: "/status", user: "oncall", pass: "pass", want: http.StatusUnauthorized},
+		{desc: "basic_auth_unknown_user", path: "/status", user: "admin", pass: "pa55", want: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, test.path, nil)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			if test.user != "" {
+				req.SetBasicAuth(test.user, test.pass)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			assert.Equal(t, test.want, w.Code)
+			if test.want == http.StatusUnauthorized {
+				assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Basic")
+			}
+		})
+	}
+}